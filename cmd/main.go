@@ -2,16 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
 	"os/exec"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/bootstrap"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/container"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/handler"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/router"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/tracing"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
@@ -39,6 +46,17 @@ func main() {
 		}
 	}()
 
+	// Configurar OpenTelemetry (propagación W3C siempre; exportación según config)
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, cfg.Environment, resources.Logger)
+	if err != nil {
+		log.Fatalf("❌ Error configurando tracing: %v", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("⚠️  Error cerrando tracing: %v", err)
+		}
+	}()
+
 	// Regenerar documentación Swagger
 	if err := regenerateSwagger(resources.Logger); err != nil {
 		resources.Logger.Warn("continuando inicio de aplicación con documentación Swagger existente",
@@ -49,12 +67,24 @@ func main() {
 	// Crear container de dependencias (DI)
 	c := container.NewContainer(resources)
 	resources.Logger.Info("container de dependencias inicializado correctamente")
+	defer func() {
+		// Se ejecuta antes del cleanup de bootstrap: bus, stream y procesos en segundo plano
+		// se detienen mientras las conexiones siguen abiertas
+		if err := c.Close(); err != nil {
+			log.Printf("⚠️  Error cerrando container: %v", err)
+		}
+	}()
 
 	// Configurar modo de Gin según ambiente
 	configureGinMode(cfg.Environment)
 
 	// Crear handler de health check
-	healthHandler := handler.NewHealthHandler(resources.PostgreSQL, resources.MongoDB)
+	// RabbitMQ reporta el estado real de la conexión (incluye reconexiones en curso)
+	var rabbitMQChecker handler.HealthChecker
+	if resources.RabbitMQMonitor != nil {
+		rabbitMQChecker = resources.RabbitMQMonitor
+	}
+	healthHandler := handler.NewHealthHandlerWithCheckers(resources.PostgreSQL, resources.MongoDB, rabbitMQChecker, nil)
 
 	// Configurar host de Swagger dinámicamente basado en la configuración
 	router.ConfigureSwaggerHost(cfg.Server.Host, cfg.Server.Port)
@@ -63,7 +93,7 @@ func main() {
 	r := router.SetupRouter(c, healthHandler)
 
 	// Iniciar servidor HTTP
-	startServer(r, cfg, resources.Logger)
+	startServer(r, cfg, resources.Logger, c.Infrastructure.StreamHub.Close)
 }
 
 // @title EduGo API Mobile
@@ -120,11 +150,16 @@ func configureGinMode(env string) {
 	}
 }
 
+// shutdownTimeout es el tiempo que se espera a que terminen los requests en curso al apagar
+const shutdownTimeout = 15 * time.Second
+
 // startServer inicia el servidor HTTP en la dirección y puerto configurados.
+// Retorna al recibir SIGINT/SIGTERM, después de esperar los requests en curso;
+// onShutdown se ejecuta al iniciar el apagado para cortar conexiones largas (SSE)
 func startServer(r *gin.Engine, cfg *config.Config, appLogger interface {
 	Info(string, ...interface{})
 	Fatal(string, ...interface{})
-}) {
+}, onShutdown func()) {
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 
 	appLogger.Info("servidor HTTP iniciado",
@@ -136,10 +171,40 @@ func startServer(r *gin.Engine, cfg *config.Config, appLogger interface {
 	log.Printf("🚀 API Mobile ejecutándose en http://localhost:%d", cfg.Server.Port)
 	log.Printf("📚 Swagger UI: http://localhost:%d/swagger/index.html", cfg.Server.Port)
 
-	if err := r.Run(addr); err != nil {
-		appLogger.Fatal("error iniciando servidor HTTP",
-			zap.Error(err),
-			zap.String("address", addr),
-		)
+	srv := &http.Server{
+		Addr:         addr,
+		Handler:      r,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+	}
+	if onShutdown != nil {
+		srv.RegisterOnShutdown(onShutdown)
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			appLogger.Fatal("error iniciando servidor HTTP",
+				zap.Error(err),
+				zap.String("address", addr),
+			)
+		}
+	case sig := <-stop:
+		appLogger.Info("señal recibida, deteniendo servidor HTTP", zap.String("signal", sig.String()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("⚠️  Error deteniendo servidor HTTP: %v", err)
+		}
 	}
 }
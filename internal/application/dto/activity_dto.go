@@ -0,0 +1,45 @@
+package dto
+
+// DailyActivityDTO actividad de un día (fecha local en la zona horaria solicitada)
+type DailyActivityDTO struct {
+	Date             string  `json:"date" example:"2026-03-10"`
+	MinutesStudied   float64 `json:"minutes_studied" example:"42.5"`
+	MaterialsTouched int     `json:"materials_touched" example:"3"`
+	Attempts         int     `json:"attempts" example:"1"`
+	ActiveUsers      int     `json:"active_users,omitempty" example:"25"`
+}
+
+// ActivityTotalsDTO totales del rango consultado
+type ActivityTotalsDTO struct {
+	ActiveDays     int     `json:"active_days" example:"12"`
+	MinutesStudied float64 `json:"minutes_studied" example:"380.5"`
+	Attempts       int     `json:"attempts" example:"7"`
+}
+
+// StreakDTO rachas de días consecutivos con actividad
+// Current sigue vigente si el último día activo es hoy o ayer
+type StreakDTO struct {
+	Current        int    `json:"current" example:"4"`
+	Longest        int    `json:"longest" example:"11"`
+	LastActiveDate string `json:"last_active_date,omitempty" example:"2026-03-10"`
+}
+
+// UserActivityResponse serie diaria de actividad del usuario autenticado
+type UserActivityResponse struct {
+	Timezone string             `json:"timezone" example:"America/Bogota"`
+	From     string             `json:"from" example:"2026-02-09"`
+	To       string             `json:"to" example:"2026-03-10"`
+	Days     []DailyActivityDTO `json:"days"`
+	Totals   ActivityTotalsDTO  `json:"totals"`
+	Streak   StreakDTO          `json:"streak"`
+}
+
+// ActivityStatsResponse serie diaria de actividad del sistema o de una escuela (admins)
+type ActivityStatsResponse struct {
+	SchoolID string             `json:"school_id,omitempty"`
+	Timezone string             `json:"timezone" example:"UTC"`
+	From     string             `json:"from" example:"2026-02-09"`
+	To       string             `json:"to" example:"2026-03-10"`
+	Days     []DailyActivityDTO `json:"days"`
+	Totals   ActivityTotalsDTO  `json:"totals"`
+}
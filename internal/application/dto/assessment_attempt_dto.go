@@ -96,3 +96,38 @@ type AttemptHistoryResponse struct {
 	Page       int                 `json:"page"`
 	Limit      int                 `json:"limit"`
 }
+
+// ItemAnalysisResponse representa el análisis de ítems de una evaluación (vista docente)
+// Se calcula con el primer intento completado de cada estudiante
+type ItemAnalysisResponse struct {
+	AssessmentID     uuid.UUID             `json:"assessment_id"`
+	MaterialID       uuid.UUID             `json:"material_id"`
+	TotalRespondents int                   `json:"total_respondents"`
+	GroupSize        int                   `json:"group_size"` // Estudiantes en cada grupo superior/inferior (27%)
+	Questions        []QuestionAnalysisDTO `json:"questions"`
+	GeneratedAt      time.Time             `json:"generated_at"`
+}
+
+// QuestionAnalysisDTO representa las métricas de una pregunta
+// DifficultyIndex es el porcentaje de aciertos (0-100); DiscriminationIndex va de -1 a 1
+type QuestionAnalysisDTO struct {
+	QuestionIndex       int             `json:"question_index"`
+	QuestionID          string          `json:"question_id"`
+	QuestionText        string          `json:"question_text"`
+	Type                string          `json:"type"`
+	CorrectAnswer       string          `json:"correct_answer"`
+	Responses           int             `json:"responses"`
+	DifficultyIndex     float64         `json:"difficulty_index"`
+	DiscriminationIndex float64         `json:"discrimination_index"`
+	AvgTimeSpentSeconds float64         `json:"avg_time_spent_seconds"`
+	Distractors         []DistractorDTO `json:"distractors"`
+	Flags               []string        `json:"flags"`
+}
+
+// DistractorDTO representa cuántas veces se eligió una opción incorrecta
+type DistractorDTO struct {
+	OptionID string  `json:"option_id"`
+	Text     string  `json:"text"`
+	Count    int     `json:"count"`
+	Rate     float64 `json:"rate"` // Porcentaje sobre las respuestas de la pregunta
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// FailedEventDTO representa un evento del dead-letter store
+// Payload se expone como JSON cuando es válido; si no, como string en PayloadRaw
+type FailedEventDTO struct {
+	ID            string          `json:"id" example:"3f2b7c1e-5d7a-4d1b-9a0e-8f6a4c2d1b3e"`
+	Exchange      string          `json:"exchange" example:"edugo.events"`
+	RoutingKey    string          `json:"routing_key" example:"material.completed"`
+	Payload       json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+	PayloadRaw    string          `json:"payload_raw,omitempty"`
+	Error         string          `json:"error" example:"failed to publish message: not connected to RabbitMQ"`
+	Attempts      int             `json:"attempts" example:"1"`
+	Status        string          `json:"status" example:"pending"`
+	FirstFailedAt time.Time       `json:"first_failed_at"`
+	LastFailedAt  time.Time       `json:"last_failed_at"`
+	ReplayedAt    *time.Time      `json:"replayed_at,omitempty"`
+}
+
+// FailedEventListResponse representa una página de eventos fallidos
+type FailedEventListResponse struct {
+	Events     []FailedEventDTO `json:"events"`
+	TotalCount int64            `json:"total_count"`
+	Limit      int              `json:"limit"`
+	Offset     int              `json:"offset"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+// Estados de un paso de ruta de aprendizaje para un estudiante
+const (
+	PathStepLocked     = "locked"
+	PathStepAvailable  = "available"
+	PathStepInProgress = "in_progress"
+	PathStepCompleted  = "completed"
+)
+
+// LearningPathRequest cuerpo para crear o reemplazar una ruta de aprendizaje
+type LearningPathRequest struct {
+	Title          string                    `json:"title" binding:"required,min=3,max=200" example:"Unidad 1: Sumas"`
+	Description    string                    `json:"description" binding:"max=2000" example:"Leer la guía y aprobar su evaluación antes de pasar a las restas"`
+	AcademicUnitID *string                   `json:"academic_unit_id,omitempty" binding:"omitempty,uuid" example:"880e8400-e29b-41d4-a716-446655440003"`
+	Steps          []LearningPathStepRequest `json:"steps" binding:"required,min=1,max=50,dive"`
+}
+
+// LearningPathStepRequest paso de una ruta en el orden en que se recorre
+// Si Requires se omite, el paso requiere el paso anterior; una lista vacía lo deja sin requisitos
+type LearningPathStepRequest struct {
+	MaterialID string   `json:"material_id" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	MinScore   *int     `json:"min_score,omitempty" binding:"omitempty,min=0,max=100" example:"70"`
+	Requires   []string `json:"requires,omitempty" binding:"omitempty,dive,uuid"`
+}
+
+// LearningPathResponse ruta con el estado de desbloqueo del usuario
+type LearningPathResponse struct {
+	ID             string                `json:"id"`
+	Title          string                `json:"title"`
+	Description    string                `json:"description,omitempty"`
+	AcademicUnitID *string               `json:"academic_unit_id,omitempty"`
+	CreatedBy      string                `json:"created_by"`
+	Steps          []LearningPathStepDTO `json:"steps"`
+	CompletedSteps int                   `json:"completed_steps"`
+	TotalSteps     int                   `json:"total_steps"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// LearningPathStepDTO paso de una ruta con el progreso del usuario
+type LearningPathStepDTO struct {
+	Position           int      `json:"position"`
+	MaterialID         string   `json:"material_id"`
+	MaterialTitle      string   `json:"material_title"`
+	MinScore           *int     `json:"min_score,omitempty"`
+	Requires           []string `json:"requires"`
+	State              string   `json:"state" example:"locked"` // locked, available, in_progress, completed
+	ProgressPercentage int      `json:"progress_percentage"`
+	BestScore          *float64 `json:"best_score,omitempty"`
+}
+
+// MaterialLock motivo por el que un material está bloqueado para un estudiante
+type MaterialLock struct {
+	MaterialID   string   `json:"material_id"`
+	PathID       string   `json:"path_id"`
+	PathTitle    string   `json:"path_title"`
+	MissingSteps []string `json:"missing_steps"` // Materiales requeridos aún no completados
+}
@@ -0,0 +1,74 @@
+package dto
+
+import "time"
+
+// UserProgressDTO representa el estado de un usuario en un material iniciado ("mi aprendizaje")
+// BestScore y Passed se omiten si el usuario no completó la evaluación del material
+type UserProgressDTO struct {
+	MaterialID         string    `json:"material_id" example:"660e8400-e29b-41d4-a716-446655440001"`
+	MaterialTitle      string    `json:"material_title" example:"Introducción a la Física"`
+	ProgressPercentage int       `json:"progress_percentage" example:"75"`
+	LastPage           int       `json:"last_page" example:"45"`
+	Status             string    `json:"status" example:"in_progress"`
+	LastAccessedAt     time.Time `json:"last_accessed_at"`
+	AttemptsCount      int       `json:"attempts_count" example:"2"`
+	BestScore          *float64  `json:"best_score,omitempty" example:"85"`
+	Passed             *bool     `json:"passed,omitempty" example:"true"`
+}
+
+// UserProgressListResponse representa una página del progreso de un usuario
+type UserProgressListResponse struct {
+	Items      []UserProgressDTO `json:"items"`
+	TotalCount int64             `json:"total_count"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+}
+
+// ReadingEventsRequest lote de eventos de lectura de un material
+// El tamaño del contenido (páginas, secciones, duración) lo conoce el servidor por el procesamiento
+// del material: los eventos fuera de ese tamaño se rechazan
+type ReadingEventsRequest struct {
+	MaterialID string            `json:"material_id" binding:"required" example:"660e8400-e29b-41d4-a716-446655440001"`
+	Events     []ReadingEventDTO `json:"events" binding:"required,min=1,max=200,dive"`
+}
+
+// ReadingEventDTO evento de lectura: página o sección vista, o posición de reproducción de un video
+// client_event_id identifica el evento en el dispositivo; los reenvíos se ignoran
+type ReadingEventDTO struct {
+	ClientEventID   string     `json:"client_event_id" binding:"required,max=64" example:"a1b2c3-0001"`
+	Type            string     `json:"type" binding:"required,oneof=page section video" example:"page"`
+	Page            int        `json:"page,omitempty" example:"12"`
+	SectionID       string     `json:"section_id,omitempty" binding:"max=128" example:"cap-2"`
+	PositionSeconds int        `json:"position_seconds,omitempty" example:"0"`
+	DwellSeconds    int        `json:"dwell_seconds" example:"45"`
+	OccurredAt      *time.Time `json:"occurred_at,omitempty"`
+}
+
+// ReadingEventsResponse progreso derivado en el servidor tras registrar un lote
+// progress_percentage es el valor guardado (nunca retrocede); derived_percentage es el calculado
+// a partir de los eventos desde el último reinicio
+type ReadingEventsResponse struct {
+	MaterialID         string   `json:"material_id" example:"660e8400-e29b-41d4-a716-446655440001"`
+	ProgressPercentage int      `json:"progress_percentage" example:"40"`
+	DerivedPercentage  int      `json:"derived_percentage" example:"40"`
+	Status             string   `json:"status" example:"in_progress"`
+	LastPage           int      `json:"last_page" example:"48"`
+	TimeOnTaskSeconds  int      `json:"time_on_task_seconds" example:"1830"`
+	PagesViewed        int      `json:"pages_viewed" example:"48"`
+	SectionsViewed     int      `json:"sections_viewed" example:"0"`
+	WatchedSeconds     int      `json:"watched_seconds" example:"0"`
+	Accepted           int      `json:"accepted" example:"10"`
+	Duplicates         int      `json:"duplicates" example:"0"`
+	Flags              []string `json:"flags,omitempty" example:"rapid_jump"`
+}
+
+// ProgressStateDTO estado guardado del progreso de un usuario en un material
+// updated_at es la hora del servidor; last_accessed_at puede venir del cliente
+type ProgressStateDTO struct {
+	MaterialID         string    `json:"material_id" example:"660e8400-e29b-41d4-a716-446655440001"`
+	ProgressPercentage int       `json:"progress_percentage" example:"75"`
+	LastPage           int       `json:"last_page" example:"45"`
+	Status             string    `json:"status" example:"in_progress"`
+	LastAccessedAt     time.Time `json:"last_accessed_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
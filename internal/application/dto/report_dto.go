@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// UnitProgressReport representa el libro de calificaciones de una unidad académica
+// Students[i].Materials sigue el mismo orden que Materials para facilitar la exportación tabular
+type UnitProgressReport struct {
+	AcademicUnitID   string              `json:"academic_unit_id"`
+	AcademicUnitName string              `json:"academic_unit_name"`
+	Materials        []ReportMaterialDTO `json:"materials"`
+	Students         []StudentReportDTO  `json:"students"`
+	GeneratedAt      time.Time           `json:"generated_at"`
+}
+
+// ReportMaterialDTO resumen de la clase en un material
+type ReportMaterialDTO struct {
+	MaterialID     string   `json:"material_id"`
+	Title          string   `json:"title"`
+	HasAssessment  bool     `json:"has_assessment"`
+	StartedCount   int      `json:"started_count"`
+	CompletedCount int      `json:"completed_count"`
+	AvgProgress    float64  `json:"avg_progress"`             // Sobre todos los estudiantes de la unidad
+	AvgBestScore   *float64 `json:"avg_best_score,omitempty"` // Sobre estudiantes que rindieron la evaluación
+	PassedCount    int      `json:"passed_count"`
+}
+
+// StudentReportDTO fila del libro de calificaciones de un estudiante
+type StudentReportDTO struct {
+	StudentID          string                     `json:"student_id"`
+	FirstName          string                     `json:"first_name"`
+	LastName           string                     `json:"last_name"`
+	Email              string                     `json:"email"`
+	AvgProgress        float64                    `json:"avg_progress"`
+	CompletedMaterials int                        `json:"completed_materials"`
+	AvgBestScore       *float64                   `json:"avg_best_score,omitempty"`
+	Materials          []StudentMaterialResultDTO `json:"materials"`
+}
+
+// StudentMaterialResultDTO resultado de un estudiante en un material
+type StudentMaterialResultDTO struct {
+	MaterialID         string     `json:"material_id"`
+	ProgressPercentage int        `json:"progress_percentage"`
+	LastAccessedAt     *time.Time `json:"last_accessed_at,omitempty"`
+	AttemptsCount      int        `json:"attempts_count"`
+	BestScore          *float64   `json:"best_score,omitempty"`
+	Passed             *bool      `json:"passed,omitempty"`
+}
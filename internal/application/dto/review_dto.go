@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// ReviewQueueResponse ítems de repaso vencidos para la sesión de práctica
+type ReviewQueueResponse struct {
+	Items    []ReviewItemDTO `json:"items"`
+	DueCount int             `json:"due_count"` // Total vencido, puede superar len(items)
+}
+
+// ReviewItemDTO ítem de repaso espaciado con su programación SM-2
+type ReviewItemDTO struct {
+	ID             string            `json:"id"`
+	Kind           string            `json:"kind" example:"question"` // question, glossary
+	MaterialID     string            `json:"material_id"`
+	Prompt         string            `json:"prompt"`
+	Options        []ReviewOptionDTO `json:"options,omitempty"`
+	Answer         string            `json:"answer"`
+	Explanation    string            `json:"explanation,omitempty"`
+	DueAt          time.Time         `json:"due_at"`
+	IntervalDays   int               `json:"interval_days"`
+	Repetitions    int               `json:"repetitions"`
+	EaseFactor     float64           `json:"ease_factor"`
+	LastReviewedAt *time.Time        `json:"last_reviewed_at,omitempty"`
+}
+
+// ReviewOptionDTO opción de una pregunta de repaso
+type ReviewOptionDTO struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// GradeReviewRequest calificación de recuerdo según SM-2
+// 0-2: no lo recordó (el ítem vuelve mañana); 3: con dificultad; 4: con duda; 5: perfecto
+type GradeReviewRequest struct {
+	Grade *int `json:"grade" binding:"required,min=0,max=5" example:"4"`
+}
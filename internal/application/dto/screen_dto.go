@@ -1,6 +1,11 @@
 package dto
 
-import "github.com/EduGoGroup/edugo-shared/screenconfig"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/EduGoGroup/edugo-shared/screenconfig"
+)
 
 // CombinedScreenDTO es un alias al tipo del shared library screenconfig.CombinedScreenDTO.
 // Evita romper codigo existente que referencia dto.CombinedScreenDTO.
@@ -9,3 +14,10 @@ type CombinedScreenDTO = screenconfig.CombinedScreenDTO
 
 // ResourceScreenDTO es un alias al tipo del shared library screenconfig.ResourceScreenDTO.
 type ResourceScreenDTO = screenconfig.ResourceScreenDTO
+
+// ScreenPreferencesVersionDTO versión anterior de las preferencias de un usuario para una pantalla
+type ScreenPreferencesVersionDTO struct {
+	Preferences json.RawMessage `json:"preferences"`
+	SavedAt     time.Time       `json:"savedAt"`
+	ReplacedAt  time.Time       `json:"replacedAt"`
+}
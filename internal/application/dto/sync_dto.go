@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Tipos de ítem de sincronización
+const (
+	SyncItemProgress      = "progress"
+	SyncItemReadingEvents = "reading_events"
+	SyncItemAttempt       = "attempt"
+)
+
+// Resultados por ítem de sincronización
+const (
+	SyncResultApplied    = "applied"     // Aplicado en esta request
+	SyncResultDuplicate  = "duplicate"   // Ya aplicado o rechazado antes: se repite el resultado original
+	SyncResultRejected   = "rejected"    // Rechazo permanente: reintentar no cambia el resultado
+	SyncResultFailed     = "failed"      // Error transitorio: el cliente debe reintentar el ítem
+	SyncResultInProgress = "in_progress" // Otra request está aplicando el mismo client_id
+)
+
+// SyncRequest lote de cambios hechos offline más el cursor de la última sincronización
+// items puede ir vacío para solo traer cambios del servidor. Los ítems no se validan al
+// bindear: SyncService los valida uno por uno y un ítem inválido no rechaza el lote
+type SyncRequest struct {
+	Cursor string        `json:"cursor,omitempty" example:"MjAyNi0xMC0xOVQxMjowMDowMFp8"`
+	Items  []SyncItemDTO `json:"items" binding:"max=500"`
+}
+
+// SyncItemDTO cambio hecho en el dispositivo
+// client_id lo genera el cliente y hace idempotente el ítem; client_timestamp es la hora del dispositivo
+// al hacer el cambio y decide el orden de aplicación (last-writer-wins)
+type SyncItemDTO struct {
+	ClientID        string                `json:"client_id" example:"7f1c2b9e-0001"`
+	Type            string                `json:"type" example:"progress" enums:"progress,reading_events,attempt"`
+	ClientTimestamp time.Time             `json:"client_timestamp"`
+	Progress        *SyncProgressDTO      `json:"progress,omitempty"`
+	ReadingEvents   *ReadingEventsRequest `json:"reading_events,omitempty"`
+	Attempt         *SyncAttemptDTO       `json:"attempt,omitempty"`
+}
+
+// SyncProgressDTO actualización de progreso (porcentaje combinado por máximo, página por last-writer-wins)
+type SyncProgressDTO struct {
+	MaterialID         string `json:"material_id" example:"660e8400-e29b-41d4-a716-446655440001"`
+	ProgressPercentage int    `json:"progress_percentage" example:"75"`
+	LastPage           int    `json:"last_page" example:"45"`
+}
+
+// SyncAttemptDTO intento de evaluación completado offline
+// completed_at es la hora de finalización en el dispositivo (default: client_timestamp)
+type SyncAttemptDTO struct {
+	MaterialID  string     `json:"material_id" example:"660e8400-e29b-41d4-a716-446655440001"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreateAttemptRequest
+}
+
+// SyncItemResultDTO resultado de un ítem; result contiene la respuesta del endpoint equivalente
+type SyncItemResultDTO struct {
+	ClientID string          `json:"client_id" example:"7f1c2b9e-0001"`
+	Type     string          `json:"type" example:"progress"`
+	Status   string          `json:"status" example:"applied"`
+	Result   json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	Error    *SyncErrorDTO   `json:"error,omitempty"`
+}
+
+// SyncErrorDTO error de un ítem rechazado o fallido
+type SyncErrorDTO struct {
+	Code    string `json:"code" example:"VALIDATION_ERROR"`
+	Message string `json:"message" example:"percentage must be between 0 and 100"`
+}
+
+// SyncResponse resultados por ítem (en el orden recibido) y cambios del servidor desde el cursor
+// Si has_more es true el cliente debe sincronizar de nuevo con el cursor retornado
+type SyncResponse struct {
+	Results    []SyncItemResultDTO `json:"results"`
+	Changes    []ProgressStateDTO  `json:"changes"`
+	Cursor     string              `json:"cursor" example:"MjAyNi0xMC0xOVQxMjowMDowMFp8"`
+	HasMore    bool                `json:"has_more" example:"false"`
+	ServerTime time.Time           `json:"server_time"`
+}
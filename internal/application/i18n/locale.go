@@ -0,0 +1,68 @@
+// Package i18n negocia el idioma de las respuestas y traduce los mensajes de error de la API
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale idioma en el que se redactan pantallas y menús; también es el de respaldo
+const DefaultLocale = "es"
+
+// SupportedLocales idiomas con traducciones, el de respaldo primero
+var SupportedLocales = []string{DefaultLocale, "en", "pt"}
+
+// Negotiate elige el idioma soportado preferido en un header Accept-Language (RFC 9110):
+// respeta los pesos q, acepta variantes regionales (pt-BR -> pt) e ignora q=0
+// Sin coincidencias retorna DefaultLocale
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{tag: tag, q: q})
+	}
+	// Estable: a igual peso gana el orden del header
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if c.tag == "*" {
+			return DefaultLocale
+		}
+		if locale, ok := Supported(c.tag); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// Supported normaliza una etiqueta de idioma (es-AR -> es) y reporta si tiene traducciones
+func Supported(tag string) (string, bool) {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	for _, locale := range SupportedLocales {
+		if base == locale {
+			return locale, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,58 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "sin header", header: "", want: "es"},
+		{name: "idioma exacto", header: "en", want: "en"},
+		{name: "variante regional", header: "pt-BR", want: "pt"},
+		{name: "mayúsculas", header: "EN-us", want: "en"},
+		{name: "pesos q", header: "es;q=0.5, pt;q=0.9, en;q=0.7", want: "pt"},
+		{name: "a igual peso gana el orden", header: "en, pt", want: "en"},
+		{name: "no soportado cae al siguiente", header: "fr-FR, fr;q=0.9, en;q=0.8", want: "en"},
+		{name: "ninguno soportado", header: "fr, de", want: "es"},
+		{name: "q=0 excluye", header: "en;q=0, pt;q=0.1", want: "pt"},
+		{name: "comodín", header: "fr, *;q=0.5", want: "es"},
+		{name: "q inválido se ignora", header: "en;q=abc, pt", want: "pt"},
+		{name: "entradas vacías", header: " , ,en", want: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.header))
+		})
+	}
+}
+
+func TestSupported(t *testing.T) {
+	locale, ok := Supported("es-AR")
+	assert.True(t, ok)
+	assert.Equal(t, "es", locale)
+
+	_, ok = Supported("fr")
+	assert.False(t, ok)
+}
+
+func TestErrorMessages_CoverSupportedLocales(t *testing.T) {
+	for code, messages := range errorMessages {
+		for _, locale := range SupportedLocales {
+			assert.NotEmpty(t, messages[locale], "falta %s para %s", locale, code)
+		}
+	}
+
+	message, ok := ErrorMessage("pt", "NOT_FOUND")
+	assert.True(t, ok)
+	assert.Equal(t, "O recurso solicitado não existe", message)
+
+	_, ok = ErrorMessage("pt", "UNKNOWN_CODE")
+	assert.False(t, ok)
+}
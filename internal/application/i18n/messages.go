@@ -0,0 +1,117 @@
+package i18n
+
+// errorMessages mensajes de error de la API por código y por idioma
+// Los códigos vienen de los handlers (INVALID_USER_ID, ...) y de AppError (VALIDATION_ERROR, ...)
+var errorMessages = map[string]map[string]string{
+	"INVALID_REQUEST": {
+		"es": "La solicitud no es válida",
+		"en": "Invalid request",
+		"pt": "A solicitação não é válida",
+	},
+	"INVALID_USER_ID": {
+		"es": "El identificador de usuario no es válido",
+		"en": "Invalid user ID",
+		"pt": "O identificador de usuário não é válido",
+	},
+	"INVALID_MATERIAL_ID": {
+		"es": "El identificador del material no es válido",
+		"en": "Invalid material ID",
+		"pt": "O identificador do material não é válido",
+	},
+	"INVALID_ATTEMPT_ID": {
+		"es": "El identificador del intento no es válido",
+		"en": "Invalid attempt ID",
+		"pt": "O identificador da tentativa não é válido",
+	},
+	"INVALID_FILENAME": {
+		"es": "El nombre de archivo no es válido",
+		"en": "Invalid file name",
+		"pt": "O nome do arquivo não é válido",
+	},
+	"INVALID_FORMAT": {
+		"es": "El formato solicitado no es válido",
+		"en": "Invalid format",
+		"pt": "O formato solicitado não é válido",
+	},
+	"VALIDATION_ERROR": {
+		"es": "Los datos enviados no son válidos",
+		"en": "The submitted data is not valid",
+		"pt": "Os dados enviados não são válidos",
+	},
+	"PAYLOAD_TOO_LARGE": {
+		"es": "El cuerpo de la solicitud es demasiado grande",
+		"en": "Request body is too large",
+		"pt": "O corpo da solicitação é grande demais",
+	},
+	"UNAUTHORIZED": {
+		"es": "Se requiere autenticación",
+		"en": "Authentication required",
+		"pt": "Autenticação necessária",
+	},
+	"FORBIDDEN": {
+		"es": "No tiene permiso para realizar esta acción",
+		"en": "You do not have permission to perform this action",
+		"pt": "Você não tem permissão para realizar esta ação",
+	},
+	"NOT_FOUND": {
+		"es": "El recurso solicitado no existe",
+		"en": "The requested resource was not found",
+		"pt": "O recurso solicitado não existe",
+	},
+	"FILE_NOT_FOUND": {
+		"es": "El archivo no existe",
+		"en": "File not found",
+		"pt": "O arquivo não existe",
+	},
+	"ALREADY_EXISTS": {
+		"es": "El recurso ya existe",
+		"en": "The resource already exists",
+		"pt": "O recurso já existe",
+	},
+	"CONFLICT": {
+		"es": "La solicitud entra en conflicto con el estado actual del recurso",
+		"en": "The request conflicts with the current state of the resource",
+		"pt": "A solicitação entra em conflito com o estado atual do recurso",
+	},
+	"RATE_LIMIT_EXCEEDED": {
+		"es": "Demasiadas solicitudes. Intente más tarde",
+		"en": "Too many requests. Please try again later",
+		"pt": "Solicitações demais. Tente mais tarde",
+	},
+	"BUSINESS_RULE_VIOLATION": {
+		"es": "La operación no está permitida en el estado actual",
+		"en": "The operation is not allowed in the current state",
+		"pt": "A operação não é permitida no estado atual",
+	},
+	"MATERIAL_LOCKED": {
+		"es": "El material está bloqueado hasta completar los pasos previos",
+		"en": "The material is locked until the previous steps are completed",
+		"pt": "O material está bloqueado até concluir as etapas anteriores",
+	},
+	"TOO_MANY_STREAMS": {
+		"es": "Hay demasiadas conexiones abiertas",
+		"en": "Too many open connections",
+		"pt": "Há conexões abertas demais",
+	},
+	"STREAM_UNAVAILABLE": {
+		"es": "El servicio en tiempo real no está disponible",
+		"en": "The real-time service is unavailable",
+		"pt": "O serviço em tempo real não está disponível",
+	},
+	"DATABASE_ERROR": {
+		"es": "No se pudo acceder a los datos. Intente de nuevo",
+		"en": "Data could not be accessed. Please try again",
+		"pt": "Não foi possível acessar os dados. Tente novamente",
+	},
+	"INTERNAL_ERROR": {
+		"es": "Error interno del servidor",
+		"en": "Internal server error",
+		"pt": "Erro interno do servidor",
+	},
+}
+
+// ErrorMessage retorna el mensaje del código en el idioma indicado; ok=false si no hay traducción
+func ErrorMessage(locale, code string) (string, bool) {
+	message, ok := errorMessages[code][locale]
+	return message, ok
+}
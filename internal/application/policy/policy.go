@@ -0,0 +1,165 @@
+// Package policy autoriza acciones sobre recursos concretos en la capa de aplicación
+// RequirePermission decide en la ruta si el rol puede ejecutar la acción; las políticas deciden
+// sobre el recurso (dueño, escuela y unidad académica) y los servicios las evalúan al cargarlo
+package policy
+
+import (
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+)
+
+// Resource tipo de recurso protegido
+type Resource string
+
+// Action acción sobre un recurso
+type Action string
+
+// Subject usuario que ejecuta la acción, con su contexto RBAC activo
+type Subject struct {
+	UserID         string
+	SchoolID       string
+	AcademicUnitID string
+	Permissions    []string
+}
+
+// NewSubject arma el Subject desde el user_id y el UserContext del token (puede ser nil)
+func NewSubject(userID string, activeCtx *auth.UserContext) Subject {
+	subject := Subject{UserID: userID}
+	if activeCtx != nil {
+		subject.SchoolID = activeCtx.SchoolID
+		subject.AcademicUnitID = activeCtx.AcademicUnitID
+		subject.Permissions = activeCtx.Permissions
+	}
+	return subject
+}
+
+// HasPermission indica si el contexto activo incluye el permiso
+func (s Subject) HasPermission(permission enum.Permission) bool {
+	for _, p := range s.Permissions {
+		if p == permission.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// Target recurso sobre el que se ejecuta la acción
+type Target struct {
+	OwnerID        string // Autor o dueño (creador del material, estudiante del intento)
+	SchoolID       string
+	AcademicUnitID string
+}
+
+// Condition condición sobre el usuario y el recurso
+type Condition func(subject Subject, target Target) bool
+
+// IsOwner el usuario es el dueño del recurso
+func IsOwner(subject Subject, target Target) bool {
+	return subject.UserID != "" && subject.UserID == target.OwnerID
+}
+
+// SameSchool el recurso pertenece a la escuela del contexto activo
+func SameSchool(subject Subject, target Target) bool {
+	return subject.SchoolID != "" && subject.SchoolID == target.SchoolID
+}
+
+// SameAcademicUnit el recurso pertenece a la unidad académica del contexto activo
+func SameAcademicUnit(subject Subject, target Target) bool {
+	return subject.AcademicUnitID != "" && subject.AcademicUnitID == target.AcademicUnitID
+}
+
+// HasPermission el contexto activo incluye el permiso
+func HasPermission(permission enum.Permission) Condition {
+	return func(subject Subject, _ Target) bool {
+		return subject.HasPermission(permission)
+	}
+}
+
+// AnyOf se cumple alguna de las condiciones
+func AnyOf(conditions ...Condition) Condition {
+	return func(subject Subject, target Target) bool {
+		for _, condition := range conditions {
+			if condition(subject, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Requirement condición que debe cumplirse para permitir la acción
+type Requirement struct {
+	Condition Condition
+	// Message motivo del 403 si no se cumple
+	Message string
+	// NotFound si no está vacío se responde 404 con este nombre de recurso en lugar de 403,
+	// para no revelar que el recurso existe (p. ej. recursos de otra escuela)
+	NotFound string
+}
+
+// Require la acción se rechaza con 403 y message si no se cumple la condición
+func Require(condition Condition, message string) Requirement {
+	return Requirement{Condition: condition, Message: message}
+}
+
+// Conceal la acción se rechaza con 404 (resource not found) si no se cumple la condición
+func Conceal(condition Condition, resource string) Requirement {
+	return Requirement{Condition: condition, NotFound: resource}
+}
+
+// Rule política de una acción sobre un recurso: todos los requisitos deben cumplirse, en orden
+type Rule struct {
+	Resource     Resource
+	Action       Action
+	Requirements []Requirement
+}
+
+type ruleKey struct {
+	resource Resource
+	action   Action
+}
+
+// Engine evalúa las reglas por (recurso, acción)
+// Una acción sin regla se rechaza: agregar un recurso exige declarar su política
+type Engine struct {
+	rules map[ruleKey][]Requirement
+}
+
+// NewEngine crea el motor con las reglas dadas; una regla repetida reemplaza a la anterior
+func NewEngine(rules ...Rule) *Engine {
+	engine := &Engine{rules: make(map[ruleKey][]Requirement, len(rules))}
+	for _, rule := range rules {
+		engine.rules[ruleKey{rule.Resource, rule.Action}] = rule.Requirements
+	}
+	return engine
+}
+
+// Authorize retorna nil si subject puede ejecutar action sobre target, o un AppError
+// FORBIDDEN (o NOT_FOUND para requisitos ocultos) en caso contrario
+func (e *Engine) Authorize(subject Subject, resource Resource, action Action, target Target) error {
+	requirements, ok := e.rules[ruleKey{resource, action}]
+	if !ok {
+		return errors.NewForbiddenError(fmt.Sprintf("no policy for %s:%s", resource, action))
+	}
+	for _, requirement := range requirements {
+		if requirement.Condition(subject, target) {
+			continue
+		}
+		if requirement.NotFound != "" {
+			return errors.NewNotFoundError(requirement.NotFound)
+		}
+		return errors.NewForbiddenError(requirement.Message)
+	}
+	return nil
+}
+
+// defaultEngine motor con las reglas de la aplicación (DefaultRules)
+var defaultEngine = NewEngine(DefaultRules()...)
+
+// Authorize evalúa las reglas de la aplicación; ver Engine.Authorize
+func Authorize(subject Subject, resource Resource, action Action, target Target) error {
+	return defaultEngine.Authorize(subject, resource, action, target)
+}
@@ -0,0 +1,149 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+const (
+	testUserID    = "660e8400-e29b-41d4-a716-446655440001"
+	testOtherUser = "660e8400-e29b-41d4-a716-446655440009"
+	testSchoolID  = "770e8400-e29b-41d4-a716-446655440002"
+	testOtherSch  = "770e8400-e29b-41d4-a716-446655440009"
+	testUnitID    = "880e8400-e29b-41d4-a716-446655440003"
+	testOtherUnit = "880e8400-e29b-41d4-a716-446655440009"
+)
+
+// teacher docente de la unidad testUnitID
+var teacher = Subject{
+	UserID:         testUserID,
+	SchoolID:       testSchoolID,
+	AcademicUnitID: testUnitID,
+	Permissions:    []string{"stats:unit", "materials:update"},
+}
+
+// coordinator coordinador con alcance de escuela
+var coordinator = Subject{
+	UserID:      testUserID,
+	SchoolID:    testSchoolID,
+	Permissions: []string{"stats:unit", "stats:school"},
+}
+
+// TestDefaultRules verifica cada regla de la aplicación
+func TestDefaultRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource Resource
+		action   Action
+		subject  Subject
+		target   Target
+		wantCode errors.ErrorCode // vacío = permitido
+	}{
+		// material:read
+		{"material: lectura en la escuela", ResourceMaterial, ActionRead, teacher, Target{OwnerID: testOtherUser, SchoolID: testSchoolID}, ""},
+		{"material: lectura de otra escuela se oculta", ResourceMaterial, ActionRead, teacher, Target{SchoolID: testOtherSch}, errors.ErrorCodeNotFound},
+		{"material: sin contexto activo se oculta", ResourceMaterial, ActionRead, Subject{UserID: testUserID}, Target{SchoolID: testSchoolID}, errors.ErrorCodeNotFound},
+
+		// material:update
+		{"material: el creador edita", ResourceMaterial, ActionUpdate, teacher, Target{OwnerID: testUserID, SchoolID: testSchoolID}, ""},
+		{"material: otro docente no edita", ResourceMaterial, ActionUpdate, teacher, Target{OwnerID: testOtherUser, SchoolID: testSchoolID}, errors.ErrorCodeForbidden},
+		{"material: el creador no edita desde otra escuela", ResourceMaterial, ActionUpdate, teacher, Target{OwnerID: testUserID, SchoolID: testOtherSch}, errors.ErrorCodeNotFound},
+		{"material: sin usuario no edita", ResourceMaterial, ActionUpdate, Subject{}, Target{}, errors.ErrorCodeNotFound},
+
+		// assessment_attempt:read
+		{"intento: el estudiante ve su resultado", ResourceAssessmentAttempt, ActionRead, Subject{UserID: testUserID}, Target{OwnerID: testUserID}, ""},
+		{"intento: otro usuario no lo ve", ResourceAssessmentAttempt, ActionRead, Subject{UserID: testUserID}, Target{OwnerID: testOtherUser}, errors.ErrorCodeForbidden},
+
+		// unit_report:read
+		{"reporte: unidad propia", ResourceUnitReport, ActionRead, teacher, Target{SchoolID: testSchoolID, AcademicUnitID: testUnitID}, ""},
+		{"reporte: otra unidad sin stats:school", ResourceUnitReport, ActionRead, teacher, Target{SchoolID: testSchoolID, AcademicUnitID: testOtherUnit}, errors.ErrorCodeForbidden},
+		{"reporte: otra unidad con stats:school", ResourceUnitReport, ActionRead, coordinator, Target{SchoolID: testSchoolID, AcademicUnitID: testOtherUnit}, ""},
+		{"reporte: otra escuela se oculta", ResourceUnitReport, ActionRead, coordinator, Target{SchoolID: testOtherSch, AcademicUnitID: testOtherUnit}, errors.ErrorCodeNotFound},
+		{"reporte: sin contexto activo se oculta", ResourceUnitReport, ActionRead, Subject{UserID: testUserID}, Target{SchoolID: testSchoolID, AcademicUnitID: testUnitID}, errors.ErrorCodeNotFound},
+
+		// learning_path:read|update|delete
+		{"ruta: lectura en la escuela", ResourceLearningPath, ActionRead, teacher, Target{SchoolID: testSchoolID}, ""},
+		{"ruta: lectura de otra escuela se oculta", ResourceLearningPath, ActionRead, teacher, Target{SchoolID: testOtherSch}, errors.ErrorCodeNotFound},
+		{"ruta: edición en la escuela", ResourceLearningPath, ActionUpdate, Subject{SchoolID: testSchoolID}, Target{SchoolID: testSchoolID}, ""},
+		{"ruta: edición de otra escuela se oculta", ResourceLearningPath, ActionUpdate, Subject{SchoolID: testSchoolID}, Target{SchoolID: testOtherSch}, errors.ErrorCodeNotFound},
+		{"ruta: borrado en la escuela", ResourceLearningPath, ActionDelete, Subject{SchoolID: testSchoolID}, Target{SchoolID: testSchoolID}, ""},
+		{"ruta: borrado de otra escuela se oculta", ResourceLearningPath, ActionDelete, Subject{SchoolID: testSchoolID}, Target{SchoolID: testOtherSch}, errors.ErrorCodeNotFound},
+		{"ruta: sin escuela se oculta", ResourceLearningPath, ActionDelete, Subject{}, Target{}, errors.ErrorCodeNotFound},
+
+		// Sin regla declarada
+		{"recurso sin política se rechaza", Resource("unknown"), ActionRead, coordinator, Target{}, errors.ErrorCodeForbidden},
+		{"acción sin política se rechaza", ResourceMaterial, ActionDelete, teacher, Target{OwnerID: testUserID}, errors.ErrorCodeForbidden},
+	}
+
+	covered := make(map[ruleKey]bool)
+	for _, tt := range tests {
+		covered[ruleKey{tt.resource, tt.action}] = true
+		t.Run(tt.name, func(t *testing.T) {
+			err := Authorize(tt.subject, tt.resource, tt.action, tt.target)
+
+			if tt.wantCode == "" {
+				assert.NoError(t, err)
+				return
+			}
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok, "expected AppError, got %v", err)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+		})
+	}
+
+	// Cada regla nueva debe agregar sus casos a la tabla
+	for _, rule := range DefaultRules() {
+		assert.True(t, covered[ruleKey{rule.Resource, rule.Action}], "rule %s:%s without test cases", rule.Resource, rule.Action)
+	}
+}
+
+// TestEngine_Authorize_RequirementOrder verifica que los requisitos se evalúen en orden
+// y que cada uno responda con su propio error
+func TestEngine_Authorize_RequirementOrder(t *testing.T) {
+	engine := NewEngine(Rule{
+		Resource: "doc",
+		Action:   ActionRead,
+		Requirements: []Requirement{
+			Conceal(SameSchool, "doc"),
+			Require(IsOwner, "not your doc"),
+		},
+	})
+
+	err := engine.Authorize(Subject{UserID: testUserID, SchoolID: testSchoolID}, "doc", ActionRead, Target{SchoolID: testOtherSch, OwnerID: testOtherUser})
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeNotFound, appErr.Code)
+	assert.Equal(t, "doc not found", appErr.Message)
+
+	err = engine.Authorize(Subject{UserID: testUserID, SchoolID: testSchoolID}, "doc", ActionRead, Target{SchoolID: testSchoolID, OwnerID: testOtherUser})
+	appErr, ok = errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeForbidden, appErr.Code)
+	assert.Equal(t, "not your doc", appErr.Message)
+
+	assert.NoError(t, engine.Authorize(Subject{UserID: testUserID, SchoolID: testSchoolID}, "doc", ActionRead, Target{SchoolID: testSchoolID, OwnerID: testUserID}))
+}
+
+// TestNewSubject verifica que el Subject tome escuela, unidad y permisos del contexto activo
+func TestNewSubject(t *testing.T) {
+	subject := NewSubject(testUserID, &auth.UserContext{
+		RoleName:       "teacher",
+		SchoolID:       testSchoolID,
+		AcademicUnitID: testUnitID,
+		Permissions:    []string{"stats:unit"},
+	})
+	assert.Equal(t, Subject{
+		UserID:         testUserID,
+		SchoolID:       testSchoolID,
+		AcademicUnitID: testUnitID,
+		Permissions:    []string{"stats:unit"},
+	}, subject)
+
+	// Sin contexto activo solo queda el usuario
+	assert.Equal(t, Subject{UserID: testUserID}, NewSubject(testUserID, nil))
+}
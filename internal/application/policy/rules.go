@@ -0,0 +1,57 @@
+package policy
+
+import "github.com/EduGoGroup/edugo-shared/common/types/enum"
+
+// Recursos protegidos
+const (
+	ResourceMaterial          Resource = "material"
+	ResourceAssessmentAttempt Resource = "assessment_attempt"
+	ResourceUnitReport        Resource = "unit_report"
+	ResourceLearningPath      Resource = "learning_path"
+)
+
+// Acciones
+const (
+	ActionRead   Action = "read"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// DefaultRules reglas de la aplicación por (recurso, acción)
+func DefaultRules() []Rule {
+	// Las rutas de otra escuela se tratan como inexistentes
+	learningPathSchool := []Requirement{Conceal(SameSchool, "learning path")}
+	materialSchool := Conceal(SameSchool, "material")
+
+	return []Rule{
+		{
+			// Los materiales de otra escuela se tratan como inexistentes
+			Resource: ResourceMaterial, Action: ActionRead,
+			Requirements: []Requirement{materialSchool},
+		},
+		{
+			// Solo el docente que subió el material puede editarlo, dentro de la escuela activa
+			Resource: ResourceMaterial, Action: ActionUpdate,
+			Requirements: []Requirement{materialSchool, Require(IsOwner, "only the material creator can update it")},
+		},
+		{
+			// Los resultados de un intento solo los ve el estudiante que lo rindió
+			Resource: ResourceAssessmentAttempt, Action: ActionRead,
+			Requirements: []Requirement{Require(IsOwner, "attempt does not belong to user")},
+		},
+		{
+			// Unidades de la escuela activa; sin stats:school solo la unidad del contexto activo
+			Resource: ResourceUnitReport, Action: ActionRead,
+			Requirements: []Requirement{
+				Conceal(SameSchool, "academic unit"),
+				Require(
+					AnyOf(SameAcademicUnit, HasPermission(enum.PermissionStatsSchool)),
+					"cannot access reports of this academic unit",
+				),
+			},
+		},
+		{Resource: ResourceLearningPath, Action: ActionRead, Requirements: learningPathSchool},
+		{Resource: ResourceLearningPath, Action: ActionUpdate, Requirements: learningPathSchool},
+		{Resource: ResourceLearningPath, Action: ActionDelete, Requirements: learningPathSchool},
+	}
+}
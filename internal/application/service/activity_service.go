@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	activityDateLayout = "2006-01-02"
+
+	// defaultActivityRangeDays rango por defecto de la serie (incluye el día de hoy)
+	defaultActivityRangeDays = 30
+
+	// maxActivityRangeDays rango máximo permitido por consulta
+	maxActivityRangeDays = 366
+)
+
+// ActivityService construye series diarias de actividad de aprendizaje y rachas
+type ActivityService interface {
+	// GetUserActivity retorna la serie diaria y las rachas del usuario
+	// from y to son fechas YYYY-MM-DD inclusivas en la zona horaria timezone (IANA, default UTC)
+	GetUserActivity(ctx context.Context, userID, from, to, timezone string) (*dto.UserActivityResponse, error)
+
+	// GetActivityStats retorna la serie diaria del sistema o de una escuela si schoolID no está vacío
+	GetActivityStats(ctx context.Context, schoolID, from, to, timezone string) (*dto.ActivityStatsResponse, error)
+
+	// HandleProgressEvent registra eventos progress.updated y material.completed del bus
+	HandleProgressEvent(ctx context.Context, event eventbus.Event) error
+}
+
+type activityService struct {
+	activityRepo repository.LearningActivityRepository
+	logger       logger.Logger
+	now          func() time.Time
+}
+
+// NewActivityService crea el servicio de actividad de aprendizaje
+func NewActivityService(activityRepo repository.LearningActivityRepository, logger logger.Logger) ActivityService {
+	return &activityService{
+		activityRepo: activityRepo,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// activityRange rango de días resuelto en una zona horaria
+type activityRange struct {
+	location *time.Location
+	from     time.Time // Medianoche local del primer día
+	to       time.Time // Medianoche local del día siguiente al último
+}
+
+func (s *activityService) GetUserActivity(ctx context.Context, userID, from, to, timezone string) (*dto.UserActivityResponse, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errors.NewValidationError("invalid user_id")
+	}
+
+	rng, err := s.resolveRange(from, to, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := s.activityRepo.GetDailyActivity(ctx, repository.ActivityQuery{
+		UserID:   userID,
+		From:     rng.from,
+		To:       rng.to,
+		Location: rng.location,
+	})
+	if err != nil {
+		s.logger.Error("failed to get user activity", "user_id", userID, "error", err)
+		return nil, errors.NewDatabaseError("get daily activity", err)
+	}
+
+	activeDays, err := s.activityRepo.ListActiveDays(ctx, userID, rng.location)
+	if err != nil {
+		s.logger.Error("failed to list active days", "user_id", userID, "error", err)
+		return nil, errors.NewDatabaseError("list active days", err)
+	}
+
+	series, totals := buildActivitySeries(rng, days, false)
+	return &dto.UserActivityResponse{
+		Timezone: rng.location.String(),
+		From:     rng.from.Format(activityDateLayout),
+		To:       rng.to.AddDate(0, 0, -1).Format(activityDateLayout),
+		Days:     series,
+		Totals:   totals,
+		Streak:   calculateStreak(activeDays, s.now().In(rng.location)),
+	}, nil
+}
+
+func (s *activityService) GetActivityStats(ctx context.Context, schoolID, from, to, timezone string) (*dto.ActivityStatsResponse, error) {
+	if schoolID != "" {
+		if _, err := uuid.Parse(schoolID); err != nil {
+			return nil, errors.NewValidationError("invalid school_id")
+		}
+	}
+
+	rng, err := s.resolveRange(from, to, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := s.activityRepo.GetDailyActivity(ctx, repository.ActivityQuery{
+		SchoolID: schoolID,
+		From:     rng.from,
+		To:       rng.to,
+		Location: rng.location,
+	})
+	if err != nil {
+		s.logger.Error("failed to get activity stats", "school_id", schoolID, "error", err)
+		return nil, errors.NewDatabaseError("get daily activity", err)
+	}
+
+	series, totals := buildActivitySeries(rng, days, true)
+	return &dto.ActivityStatsResponse{
+		SchoolID: schoolID,
+		Timezone: rng.location.String(),
+		From:     rng.from.Format(activityDateLayout),
+		To:       rng.to.AddDate(0, 0, -1).Format(activityDateLayout),
+		Days:     series,
+		Totals:   totals,
+	}, nil
+}
+
+// progressEventPayload campos comunes de progress.updated y material.completed
+type progressEventPayload struct {
+	UserID      string    `json:"user_id"`
+	SchoolID    string    `json:"school_id"`
+	MaterialID  string    `json:"material_id"`
+	Percentage  *int      `json:"percentage"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+func (s *activityService) HandleProgressEvent(ctx context.Context, event eventbus.Event) error {
+	var envelope struct {
+		EventID   string               `json:"event_id"`
+		Timestamp time.Time            `json:"timestamp"`
+		Payload   progressEventPayload `json:"payload"`
+	}
+	if err := json.Unmarshal(event.Body, &envelope); err != nil {
+		return fmt.Errorf("activity: invalid event %s: %w", event.RoutingKey, err)
+	}
+
+	payload := envelope.Payload
+	if payload.UserID == "" || payload.MaterialID == "" {
+		return fmt.Errorf("activity: event %s without user_id or material_id", event.RoutingKey)
+	}
+
+	activity := &repository.ProgressActivity{
+		EventID:    envelope.EventID,
+		UserID:     payload.UserID,
+		SchoolID:   payload.SchoolID,
+		MaterialID: payload.MaterialID,
+		Percentage: 100, // material.completed no incluye porcentaje
+		OccurredAt: firstNonZeroTime(payload.UpdatedAt, payload.CompletedAt, envelope.Timestamp, event.PublishedAt),
+	}
+	if payload.Percentage != nil {
+		activity.Percentage = *payload.Percentage
+	}
+	if activity.EventID == "" {
+		activity.EventID = uuid.NewString()
+	}
+
+	if err := s.activityRepo.RecordProgressActivity(ctx, activity); err != nil {
+		s.logger.Error("failed to record learning activity",
+			"user_id", activity.UserID,
+			"material_id", activity.MaterialID,
+			"error", err,
+		)
+		return err
+	}
+	return nil
+}
+
+// resolveRange valida zona horaria y fechas; por defecto los últimos 30 días incluyendo hoy
+func (s *activityService) resolveRange(from, to, timezone string) (activityRange, error) {
+	rng := activityRange{location: time.UTC}
+	if timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil || timezone == "Local" {
+			return rng, errors.NewValidationError("invalid timezone: " + timezone)
+		}
+		rng.location = location
+	}
+
+	now := s.now().In(rng.location)
+	lastDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, rng.location)
+	if to != "" {
+		parsed, err := time.ParseInLocation(activityDateLayout, to, rng.location)
+		if err != nil {
+			return rng, errors.NewValidationError("to must be a date in YYYY-MM-DD format")
+		}
+		lastDay = parsed
+	}
+
+	firstDay := lastDay.AddDate(0, 0, -(defaultActivityRangeDays - 1))
+	if from != "" {
+		parsed, err := time.ParseInLocation(activityDateLayout, from, rng.location)
+		if err != nil {
+			return rng, errors.NewValidationError("from must be a date in YYYY-MM-DD format")
+		}
+		firstDay = parsed
+	}
+
+	if firstDay.After(lastDay) {
+		return rng, errors.NewValidationError("from must not be after to")
+	}
+	if daysBetween(firstDay, lastDay)+1 > maxActivityRangeDays {
+		return rng, errors.NewValidationError(fmt.Sprintf("range must not exceed %d days", maxActivityRangeDays))
+	}
+
+	rng.from = firstDay
+	rng.to = lastDay.AddDate(0, 0, 1)
+	return rng, nil
+}
+
+// buildActivitySeries completa con ceros los días sin actividad para que la serie sea continua
+func buildActivitySeries(rng activityRange, days []repository.DailyActivity, includeUsers bool) ([]dto.DailyActivityDTO, dto.ActivityTotalsDTO) {
+	byDay := make(map[string]repository.DailyActivity, len(days))
+	for _, day := range days {
+		byDay[day.Day] = day
+	}
+
+	var totals dto.ActivityTotalsDTO
+	series := make([]dto.DailyActivityDTO, 0, daysBetween(rng.from, rng.to))
+	for day := rng.from; day.Before(rng.to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(activityDateLayout)
+		activity := byDay[key]
+
+		point := dto.DailyActivityDTO{
+			Date:             key,
+			MinutesStudied:   math.Round(float64(activity.StudySeconds)/60*10) / 10,
+			MaterialsTouched: activity.MaterialsTouched,
+			Attempts:         activity.Attempts,
+		}
+		if includeUsers {
+			point.ActiveUsers = activity.ActiveUsers
+		}
+		series = append(series, point)
+
+		if activity.MaterialsTouched > 0 || activity.Attempts > 0 {
+			totals.ActiveDays++
+		}
+		totals.MinutesStudied += point.MinutesStudied
+		totals.Attempts += activity.Attempts
+	}
+	totals.MinutesStudied = math.Round(totals.MinutesStudied*10) / 10
+
+	return series, totals
+}
+
+// calculateStreak calcula la racha actual y la más larga a partir de días activos ordenados
+// La racha actual se mantiene si el último día activo es hoy o ayer (el día de hoy aún puede completarse)
+func calculateStreak(activeDays []string, today time.Time) dto.StreakDTO {
+	var streak dto.StreakDTO
+	if len(activeDays) == 0 {
+		return streak
+	}
+
+	run := 0
+	var previous time.Time
+	for i, key := range activeDays {
+		day, err := time.Parse(activityDateLayout, key)
+		if err != nil {
+			continue
+		}
+		if i > 0 && daysBetween(previous, day) == 1 {
+			run++
+		} else {
+			run = 1
+		}
+		if run > streak.Longest {
+			streak.Longest = run
+		}
+		previous = day
+	}
+
+	streak.LastActiveDate = previous.Format(activityDateLayout)
+	todayDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if gap := daysBetween(previous, todayDate); gap == 0 || gap == 1 {
+		streak.Current = run
+	}
+	return streak
+}
+
+// daysBetween cuenta días de calendario entre dos medianoches, tolerando cambios de horario (DST)
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+func firstNonZeroTime(candidates ...time.Time) time.Time {
+	for _, t := range candidates {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Now()
+}
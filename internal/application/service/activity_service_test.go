@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// MockLearningActivityRepository es un mock del registro de actividad
+type MockLearningActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockLearningActivityRepository) RecordProgressActivity(ctx context.Context, activity *repository.ProgressActivity) error {
+	args := m.Called(ctx, activity)
+	return args.Error(0)
+}
+
+func (m *MockLearningActivityRepository) GetDailyActivity(ctx context.Context, query repository.ActivityQuery) ([]repository.DailyActivity, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.DailyActivity), args.Error(1)
+}
+
+func (m *MockLearningActivityRepository) ListActiveDays(ctx context.Context, userID string, location *time.Location) ([]string, error) {
+	args := m.Called(ctx, userID, location)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+const activityTestUserID = "660e8400-e29b-41d4-a716-446655440001"
+
+func newTestActivityService(repo *MockLearningActivityRepository, now time.Time) *activityService {
+	svc := NewActivityService(repo, new(MockLogger)).(*activityService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+// TestActivityService_GetUserActivity_Series verifica la serie continua, totales y la zona horaria
+func TestActivityService_GetUserActivity_Series(t *testing.T) {
+	bogota, err := time.LoadLocation("America/Bogota")
+	require.NoError(t, err)
+
+	repo := new(MockLearningActivityRepository)
+	repo.On("GetDailyActivity", mock.Anything, mock.MatchedBy(func(q repository.ActivityQuery) bool {
+		// Medianoche de Bogotá (UTC-5) del primer día y del día siguiente al último
+		return q.UserID == activityTestUserID &&
+			q.From.Equal(time.Date(2026, 3, 8, 5, 0, 0, 0, time.UTC)) &&
+			q.To.Equal(time.Date(2026, 3, 11, 5, 0, 0, 0, time.UTC)) &&
+			q.Location.String() == "America/Bogota"
+	})).Return([]repository.DailyActivity{
+		{Day: "2026-03-08", StudySeconds: 1500, MaterialsTouched: 2, Attempts: 1, ActiveUsers: 1},
+		{Day: "2026-03-10", StudySeconds: 90, MaterialsTouched: 1, ActiveUsers: 1},
+	}, nil)
+	repo.On("ListActiveDays", mock.Anything, activityTestUserID, bogota).Return([]string{"2026-03-08", "2026-03-10"}, nil)
+
+	svc := newTestActivityService(repo, time.Date(2026, 3, 11, 3, 0, 0, 0, time.UTC)) // 10 de marzo en Bogotá
+
+	result, err := svc.GetUserActivity(context.Background(), activityTestUserID, "2026-03-08", "2026-03-10", "America/Bogota")
+	require.NoError(t, err)
+
+	assert.Equal(t, "America/Bogota", result.Timezone)
+	assert.Equal(t, "2026-03-08", result.From)
+	assert.Equal(t, "2026-03-10", result.To)
+	require.Len(t, result.Days, 3)
+	assert.Equal(t, 25.0, result.Days[0].MinutesStudied)
+	assert.Equal(t, "2026-03-09", result.Days[1].Date)
+	assert.Zero(t, result.Days[1].MaterialsTouched)
+	assert.Zero(t, result.Days[0].ActiveUsers, "la serie del usuario no expone usuarios activos")
+
+	assert.Equal(t, 2, result.Totals.ActiveDays)
+	assert.Equal(t, 26.5, result.Totals.MinutesStudied)
+	assert.Equal(t, 1, result.Totals.Attempts)
+
+	assert.Equal(t, 1, result.Streak.Current)
+	assert.Equal(t, 1, result.Streak.Longest)
+	assert.Equal(t, "2026-03-10", result.Streak.LastActiveDate)
+}
+
+// TestActivityService_GetUserActivity_DefaultRange verifica los últimos 30 días incluyendo hoy
+func TestActivityService_GetUserActivity_DefaultRange(t *testing.T) {
+	repo := new(MockLearningActivityRepository)
+	repo.On("GetDailyActivity", mock.Anything, mock.Anything).Return([]repository.DailyActivity{}, nil)
+	repo.On("ListActiveDays", mock.Anything, activityTestUserID, mock.Anything).Return([]string{}, nil)
+
+	svc := newTestActivityService(repo, time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC))
+
+	result, err := svc.GetUserActivity(context.Background(), activityTestUserID, "", "", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "UTC", result.Timezone)
+	assert.Equal(t, "2026-02-09", result.From)
+	assert.Equal(t, "2026-03-10", result.To)
+	assert.Len(t, result.Days, 30)
+	assert.Equal(t, 0, result.Streak.Current)
+}
+
+// TestActivityService_GetUserActivity_Validation verifica errores de parámetros
+func TestActivityService_GetUserActivity_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		from     string
+		to       string
+		timezone string
+	}{
+		{name: "user_id inválido", userID: "abc"},
+		{name: "zona horaria desconocida", userID: activityTestUserID, timezone: "Mars/Olympus"},
+		{name: "zona horaria Local", userID: activityTestUserID, timezone: "Local"},
+		{name: "fecha inválida", userID: activityTestUserID, from: "10/03/2026"},
+		{name: "rango invertido", userID: activityTestUserID, from: "2026-03-10", to: "2026-03-01"},
+		{name: "rango excesivo", userID: activityTestUserID, from: "2024-01-01", to: "2026-03-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockLearningActivityRepository)
+			svc := newTestActivityService(repo, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+
+			_, err := svc.GetUserActivity(context.Background(), tt.userID, tt.from, tt.to, tt.timezone)
+
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+			repo.AssertNotCalled(t, "GetDailyActivity", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// TestActivityService_GetActivityStats verifica la serie por escuela con usuarios activos
+func TestActivityService_GetActivityStats(t *testing.T) {
+	schoolID := "770e8400-e29b-41d4-a716-446655440002"
+	repo := new(MockLearningActivityRepository)
+	repo.On("GetDailyActivity", mock.Anything, mock.MatchedBy(func(q repository.ActivityQuery) bool {
+		return q.SchoolID == schoolID && q.UserID == ""
+	})).Return([]repository.DailyActivity{
+		{Day: "2026-03-10", StudySeconds: 6000, MaterialsTouched: 4, Attempts: 3, ActiveUsers: 12},
+	}, nil)
+
+	svc := newTestActivityService(repo, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+
+	result, err := svc.GetActivityStats(context.Background(), schoolID, "2026-03-09", "2026-03-10", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, schoolID, result.SchoolID)
+	require.Len(t, result.Days, 2)
+	assert.Equal(t, 12, result.Days[1].ActiveUsers)
+	assert.Equal(t, 100.0, result.Totals.MinutesStudied)
+}
+
+// TestActivityService_GetActivityStats_DatabaseError verifica el mapeo de errores del repositorio
+func TestActivityService_GetActivityStats_DatabaseError(t *testing.T) {
+	repo := new(MockLearningActivityRepository)
+	repo.On("GetDailyActivity", mock.Anything, mock.Anything).Return(nil, stderrors.New("timeout"))
+	logger := new(MockLogger)
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+	svc := NewActivityService(repo, logger)
+
+	_, err := svc.GetActivityStats(context.Background(), "", "", "", "")
+
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeDatabaseError, appErr.Code)
+}
+
+// TestCalculateStreak verifica rachas actuales y máximas
+func TestCalculateStreak(t *testing.T) {
+	today := time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		days            []string
+		expectedCurrent int
+		expectedLongest int
+	}{
+		{name: "sin actividad", days: nil},
+		{name: "activo hoy", days: []string{"2026-03-08", "2026-03-09", "2026-03-10"}, expectedCurrent: 3, expectedLongest: 3},
+		{name: "activo hasta ayer mantiene la racha", days: []string{"2026-03-08", "2026-03-09"}, expectedCurrent: 2, expectedLongest: 2},
+		{name: "racha rota", days: []string{"2026-03-01", "2026-03-02", "2026-03-03", "2026-03-07"}, expectedCurrent: 0, expectedLongest: 3},
+		{name: "racha actual menor a la máxima", days: []string{"2026-02-01", "2026-02-02", "2026-02-03", "2026-02-04", "2026-03-09", "2026-03-10"}, expectedCurrent: 2, expectedLongest: 4},
+		{name: "cruce de mes", days: []string{"2026-02-27", "2026-02-28", "2026-03-01"}, expectedCurrent: 0, expectedLongest: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streak := calculateStreak(tt.days, today)
+			assert.Equal(t, tt.expectedCurrent, streak.Current)
+			assert.Equal(t, tt.expectedLongest, streak.Longest)
+		})
+	}
+}
+
+// TestActivityService_HandleProgressEvent verifica el registro de eventos del bus
+func TestActivityService_HandleProgressEvent(t *testing.T) {
+	updatedAt := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+
+	t.Run("progress.updated", func(t *testing.T) {
+		event := rabbitmq.NewProgressUpdatedEvent(rabbitmq.ProgressUpdatedPayload{
+			MaterialID: "m1", SchoolID: "s1", UserID: activityTestUserID, Percentage: 40, UpdatedAt: updatedAt,
+		})
+		body, err := json.Marshal(event)
+		require.NoError(t, err)
+
+		repo := new(MockLearningActivityRepository)
+		repo.On("RecordProgressActivity", mock.Anything, &repository.ProgressActivity{
+			EventID: event.EventID, UserID: activityTestUserID, SchoolID: "s1", MaterialID: "m1", Percentage: 40, OccurredAt: updatedAt,
+		}).Return(nil)
+		svc := NewActivityService(repo, new(MockLogger))
+
+		require.NoError(t, svc.HandleProgressEvent(context.Background(), eventbus.Event{RoutingKey: "progress.updated", Body: body}))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("material.completed", func(t *testing.T) {
+		event := rabbitmq.NewMaterialCompletedEvent(rabbitmq.MaterialCompletedPayload{
+			MaterialID: "m1", SchoolID: "s1", UserID: activityTestUserID, CompletedAt: updatedAt,
+		})
+		body, err := json.Marshal(event)
+		require.NoError(t, err)
+
+		repo := new(MockLearningActivityRepository)
+		repo.On("RecordProgressActivity", mock.Anything, mock.MatchedBy(func(a *repository.ProgressActivity) bool {
+			return a.Percentage == 100 && a.OccurredAt.Equal(updatedAt)
+		})).Return(nil)
+		svc := NewActivityService(repo, new(MockLogger))
+
+		require.NoError(t, svc.HandleProgressEvent(context.Background(), eventbus.Event{RoutingKey: "material.completed", Body: body}))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("evento inválido", func(t *testing.T) {
+		repo := new(MockLearningActivityRepository)
+		svc := NewActivityService(repo, new(MockLogger))
+
+		err := svc.HandleProgressEvent(context.Background(), eventbus.Event{RoutingKey: "progress.updated", Body: []byte(`{"payload":{}}`)})
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "RecordProgressActivity", mock.Anything, mock.Anything)
+	})
+}
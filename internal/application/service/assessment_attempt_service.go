@@ -8,8 +8,12 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repositories"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	domainServices "github.com/EduGoGroup/edugo-api-mobile/internal/domain/services"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
 	mongoRepo "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mongodb/repository"
 	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
@@ -20,16 +24,29 @@ import (
 // Orquesta repositorios PostgreSQL y MongoDB
 type AssessmentAttemptService interface {
 	// GetAssessmentByMaterialID obtiene un assessment SIN respuestas correctas (sanitizado)
-	GetAssessmentByMaterialID(ctx context.Context, materialID uuid.UUID) (*dto.AssessmentResponse, error)
+	// GetAssessmentByMaterialID, CreateAttempt y CreateOfflineAttempt evalúan material:read (un material
+	// de otra escuela responde 404) y responden 403 MATERIAL_LOCKED si una ruta de aprendizaje bloquea
+	// el material al subject
+	GetAssessmentByMaterialID(ctx context.Context, materialID uuid.UUID, subject policy.Subject) (*dto.AssessmentResponse, error)
 
 	// CreateAttempt crea un intento, valida respuestas y calcula score en servidor
-	CreateAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest) (*dto.AttemptResultResponse, error)
+	// subject es el estudiante studentID con su contexto activo
+	CreateAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, subject policy.Subject) (*dto.AttemptResultResponse, error)
+
+	// CreateOfflineAttempt crea un intento rendido offline con la hora de finalización del dispositivo
+	// (sincronización). completedAt no puede ser futura, anterior a la creación del material ni más
+	// antigua que maxOfflineAttemptAge
+	CreateOfflineAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, completedAt time.Time, subject policy.Subject) (*dto.AttemptResultResponse, error)
 
 	// GetAttemptResult obtiene los resultados de un intento específico
-	GetAttemptResult(ctx context.Context, attemptID, studentID uuid.UUID) (*dto.AttemptResultResponse, error)
+	GetAttemptResult(ctx context.Context, attemptID uuid.UUID, subject policy.Subject) (*dto.AttemptResultResponse, error)
 
 	// GetAttemptHistory obtiene el historial de intentos de un estudiante
 	GetAttemptHistory(ctx context.Context, studentID uuid.UUID, limit, offset int) (*dto.AttemptHistoryResponse, error)
+
+	// GetItemAnalysis calcula métricas por pregunta (dificultad, discriminación, distractores) para docentes
+	// Evalúa material:read: un material de otra escuela responde 404
+	GetItemAnalysis(ctx context.Context, materialID uuid.UUID, subject policy.Subject) (*dto.ItemAnalysisResponse, error)
 }
 
 type assessmentAttemptService struct {
@@ -37,17 +54,24 @@ type assessmentAttemptService struct {
 	attemptRepo         repositories.AttemptRepository
 	answerRepo          repositories.AnswerRepository
 	mongoRepo           mongoRepo.AssessmentDocumentRepository
+	materialRepo        repository.MaterialReader
 	assessmentDomainSvc *domainServices.AssessmentDomainService
 	attemptDomainSvc    *domainServices.AttemptDomainService
+	publisher           rabbitmq.Publisher
+	lockChecker         MaterialLockChecker
 	logger              logger.Logger
 }
 
 // NewAssessmentAttemptService crea una nueva instancia del servicio
+// lockChecker aplica el bloqueo de las rutas de aprendizaje a la evaluación y a los intentos
 func NewAssessmentAttemptService(
 	assessmentRepo repositories.AssessmentRepository,
 	attemptRepo repositories.AttemptRepository,
 	answerRepo repositories.AnswerRepository,
 	mongoRepo mongoRepo.AssessmentDocumentRepository,
+	materialRepo repository.MaterialReader,
+	publisher rabbitmq.Publisher,
+	lockChecker MaterialLockChecker,
 	logger logger.Logger,
 ) AssessmentAttemptService {
 	return &assessmentAttemptService{
@@ -55,14 +79,24 @@ func NewAssessmentAttemptService(
 		attemptRepo:         attemptRepo,
 		answerRepo:          answerRepo,
 		mongoRepo:           mongoRepo,
+		materialRepo:        materialRepo,
 		assessmentDomainSvc: domainServices.NewAssessmentDomainService(),
 		attemptDomainSvc:    domainServices.NewAttemptDomainService(),
+		publisher:           publisher,
+		lockChecker:         lockChecker,
 		logger:              logger,
 	}
 }
 
 // GetAssessmentByMaterialID obtiene assessment SIN respuestas correctas
-func (s *assessmentAttemptService) GetAssessmentByMaterialID(ctx context.Context, materialID uuid.UUID) (*dto.AssessmentResponse, error) {
+func (s *assessmentAttemptService) GetAssessmentByMaterialID(ctx context.Context, materialID uuid.UUID, subject policy.Subject) (*dto.AssessmentResponse, error) {
+	if err := s.authorizeMaterialRead(ctx, materialID, subject); err != nil {
+		return nil, err
+	}
+	if err := ensureMaterialUnlocked(ctx, s.lockChecker, materialID.String(), subject); err != nil {
+		return nil, err
+	}
+
 	// 1. Buscar assessment en PostgreSQL
 	assessment, err := s.assessmentRepo.FindByMaterialID(ctx, materialID)
 	if err != nil {
@@ -116,10 +150,38 @@ func (s *assessmentAttemptService) GetAssessmentByMaterialID(ctx context.Context
 	}, nil
 }
 
+// maxOfflineAttemptAge antigüedad máxima de un intento rendido offline al sincronizarse
+const maxOfflineAttemptAge = 30 * 24 * time.Hour
+
 // CreateAttempt crea un intento, valida respuestas y calcula score en servidor
-func (s *assessmentAttemptService) CreateAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest) (*dto.AttemptResultResponse, error) {
+func (s *assessmentAttemptService) CreateAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, subject policy.Subject) (*dto.AttemptResultResponse, error) {
+	return s.createAttempt(ctx, studentID, materialID, req, nil, subject)
+}
+
+// CreateOfflineAttempt crea un intento rendido offline que terminó en completedAt
+func (s *assessmentAttemptService) CreateOfflineAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, completedAt time.Time, subject policy.Subject) (*dto.AttemptResultResponse, error) {
+	return s.createAttempt(ctx, studentID, materialID, req, &completedAt, subject)
+}
+
+// createAttempt crea el intento; offlineCompletedAt es la hora del dispositivo (nil = ahora)
+func (s *assessmentAttemptService) createAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, offlineCompletedAt *time.Time, subject policy.Subject) (*dto.AttemptResultResponse, error) {
 	startTime := time.Now()
 
+	// Un intento no puede saltarse la escuela del material ni el bloqueo de la ruta,
+	// tampoco desde la sincronización offline
+	if err := s.authorizeMaterialRead(ctx, materialID, subject); err != nil {
+		return nil, err
+	}
+	if err := ensureMaterialUnlocked(ctx, s.lockChecker, materialID.String(), subject); err != nil {
+		return nil, err
+	}
+
+	if offlineCompletedAt != nil {
+		if err := s.validateOfflineCompletedAt(ctx, materialID, *offlineCompletedAt, startTime); err != nil {
+			return nil, err
+		}
+	}
+
 	// 1. Buscar assessment
 	assessment, err := s.assessmentRepo.FindByMaterialID(ctx, materialID)
 	if err != nil || assessment == nil {
@@ -152,7 +214,12 @@ func (s *assessmentAttemptService) CreateAttempt(ctx context.Context, studentID,
 	answers, correctCount, feedback := s.validateAndScoreAnswers(mongoDoc.Questions, req.Answers)
 
 	// 6. Calcular timestamps y score
+	// Un intento rendido offline conserva la hora del dispositivo (ya validada)
 	completedAt := startTime.Add(time.Duration(req.TimeSpentSeconds) * time.Second)
+	if offlineCompletedAt != nil {
+		completedAt = offlineCompletedAt.UTC()
+		startTime = completedAt.Add(-time.Duration(req.TimeSpentSeconds) * time.Second)
+	}
 	score := s.attemptDomainSvc.CalculateScore(answers)
 	percentage := score // Score ya es porcentaje (0-100)
 	maxScore := 100.0
@@ -224,7 +291,12 @@ func (s *assessmentAttemptService) CreateAttempt(ctx context.Context, studentID,
 		passThreshold = *assessment.PassThreshold
 	}
 
-	// 13. Retornar resultado con feedback
+	passed := s.attemptDomainSvc.IsPassed(attempt, passThreshold)
+
+	// 13. Notificar el intento completado (stream en tiempo real, analítica)
+	s.publishAttemptCompleted(ctx, attempt, materialID, passed)
+
+	// 14. Retornar resultado con feedback
 	return &dto.AttemptResultResponse{
 		AttemptID:         attempt.ID,
 		AssessmentID:      assessment.ID,
@@ -233,7 +305,7 @@ func (s *assessmentAttemptService) CreateAttempt(ctx context.Context, studentID,
 		CorrectAnswers:    correctCount,
 		TotalQuestions:    len(mongoDoc.Questions),
 		PassThreshold:     passThreshold, // DTO espera int, no *int
-		Passed:            s.attemptDomainSvc.IsPassed(attempt, passThreshold),
+		Passed:            passed,
 		TimeSpentSeconds:  timeSpent,
 		StartedAt:         attempt.StartedAt,
 		CompletedAt:       *attempt.CompletedAt, // Desreferenciar *time.Time
@@ -243,8 +315,31 @@ func (s *assessmentAttemptService) CreateAttempt(ctx context.Context, studentID,
 	}, nil
 }
 
+// publishAttemptCompleted publica assessment.attempt.completed
+// Un error de publicación solo se registra: el intento ya fue persistido
+func (s *assessmentAttemptService) publishAttemptCompleted(ctx context.Context, attempt *pgentities.AssessmentAttempt, materialID uuid.UUID, passed bool) {
+	event := rabbitmq.NewAttemptCompletedEvent(rabbitmq.AttemptCompletedPayload{
+		AttemptID:    attempt.ID.String(),
+		AssessmentID: attempt.AssessmentID.String(),
+		MaterialID:   materialID.String(),
+		UserID:       attempt.StudentID.String(),
+		Score:        *attempt.Score,
+		Passed:       passed,
+		CompletedAt:  *attempt.CompletedAt,
+	})
+	eventJSON, err := event.ToJSON()
+	if err != nil {
+		s.logger.Warn("failed to serialize attempt completed event", "attempt_id", attempt.ID.String(), "error", err)
+		return
+	}
+
+	if err := s.publisher.Publish(ctx, "edugo.events", "assessment.attempt.completed", eventJSON); err != nil {
+		s.logger.Warn("failed to publish attempt completed event", "attempt_id", attempt.ID.String(), "error", err)
+	}
+}
+
 // GetAttemptResult obtiene los resultados de un intento específico
-func (s *assessmentAttemptService) GetAttemptResult(ctx context.Context, attemptID, studentID uuid.UUID) (*dto.AttemptResultResponse, error) {
+func (s *assessmentAttemptService) GetAttemptResult(ctx context.Context, attemptID uuid.UUID, subject policy.Subject) (*dto.AttemptResultResponse, error) {
 	// 1. Buscar intento
 	attempt, err := s.attemptRepo.FindByID(ctx, attemptID)
 	if err != nil {
@@ -256,8 +351,10 @@ func (s *assessmentAttemptService) GetAttemptResult(ctx context.Context, attempt
 	}
 
 	// 2. Verificar que el intento pertenece al estudiante (autorización)
-	if attempt.StudentID != studentID {
-		return nil, errors.NewForbiddenError("attempt does not belong to user")
+	if err := policy.Authorize(subject, policy.ResourceAssessmentAttempt, policy.ActionRead, policy.Target{
+		OwnerID: attempt.StudentID.String(),
+	}); err != nil {
+		return nil, err
 	}
 
 	// 3. Buscar assessment para obtener metadata
@@ -283,12 +380,12 @@ func (s *assessmentAttemptService) GetAttemptResult(ctx context.Context, attempt
 	feedback := s.generateFeedback(mongoDoc.Questions, answers)
 
 	// 7. Verificar si puede hacer más intentos
-	attemptCount, _ := s.attemptRepo.CountByStudentAndAssessment(ctx, studentID, assessment.ID)
+	attemptCount, _ := s.attemptRepo.CountByStudentAndAssessment(ctx, attempt.StudentID, assessment.ID)
 	canRetake := s.assessmentDomainSvc.CanAttempt(assessment, attemptCount)
 
 	// 8. Calcular previous best score
 	var previousBestScore *int
-	previousAttempts, _ := s.attemptRepo.FindByStudentAndAssessment(ctx, studentID, assessment.ID)
+	previousAttempts, _ := s.attemptRepo.FindByStudentAndAssessment(ctx, attempt.StudentID, assessment.ID)
 	if len(previousAttempts) > 1 {
 		best := 0.0
 		for _, prev := range previousAttempts {
@@ -407,6 +504,54 @@ func (s *assessmentAttemptService) GetAttemptHistory(ctx context.Context, studen
 	}, nil
 }
 
+// GetItemAnalysis calcula el análisis de ítems de la evaluación de un material
+// Incluye la respuesta correcta: solo debe exponerse a docentes (ver router) de la escuela del material
+func (s *assessmentAttemptService) GetItemAnalysis(ctx context.Context, materialID uuid.UUID, subject policy.Subject) (*dto.ItemAnalysisResponse, error) {
+	// 0. Autorizar la lectura del material (escuela del contexto activo)
+	if err := s.authorizeMaterialRead(ctx, materialID, subject); err != nil {
+		return nil, err
+	}
+
+	// 1. Buscar assessment en PostgreSQL
+	assessment, err := s.assessmentRepo.FindByMaterialID(ctx, materialID)
+	if err != nil {
+		s.logger.Error("failed to find assessment", "error", err)
+		return nil, errors.NewDatabaseError("find assessment", err)
+	}
+	if assessment == nil {
+		return nil, errors.NewNotFoundError("assessment")
+	}
+
+	// 2. Buscar preguntas (con respuestas correctas) en MongoDB
+	mongoDoc, err := s.mongoRepo.FindByID(ctx, assessment.MongoDocumentID)
+	if err != nil {
+		s.logger.Error("failed to find mongo document", "error", err)
+		return nil, errors.NewDatabaseError("find mongo document", err)
+	}
+	if mongoDoc == nil {
+		return nil, errors.NewNotFoundError("assessment questions")
+	}
+
+	// 3. Cargar respuestas del primer intento de cada estudiante
+	responses, err := s.answerRepo.FindItemResponses(ctx, assessment.ID)
+	if err != nil {
+		s.logger.Error("failed to find item responses", "error", err)
+		return nil, errors.NewDatabaseError("find item responses", err)
+	}
+
+	// 4. Calcular métricas por pregunta
+	questions, respondents, groupSize := analyzeItems(mongoDoc.Questions, responses)
+
+	return &dto.ItemAnalysisResponse{
+		AssessmentID:     assessment.ID,
+		MaterialID:       assessment.MaterialID,
+		TotalRespondents: respondents,
+		GroupSize:        groupSize,
+		Questions:        questions,
+		GeneratedAt:      time.Now(),
+	}, nil
+}
+
 // ========== HELPERS ==========
 
 // sanitizeQuestions remueve correct_answer y feedback de las preguntas
@@ -560,3 +705,42 @@ func (s *assessmentAttemptService) generateFeedback(questions []mongoRepo.Questi
 
 	return feedback
 }
+
+// validateOfflineCompletedAt acota la hora de finalización informada por el dispositivo:
+// no futura (con tolerancia de reloj), no anterior a la creación del material y dentro de la
+// ventana offline
+func (s *assessmentAttemptService) validateOfflineCompletedAt(ctx context.Context, materialID uuid.UUID, completedAt, now time.Time) error {
+	if completedAt.After(now.Add(maxClientClockSkew)) {
+		return errors.NewValidationError("completed_at must not be in the future")
+	}
+	if completedAt.Before(now.Add(-maxOfflineAttemptAge)) {
+		return errors.NewValidationError("completed_at is older than the offline window")
+	}
+
+	matID, err := valueobject.MaterialIDFromString(materialID.String())
+	if err != nil {
+		return errors.NewValidationError("invalid material_id")
+	}
+	material, err := s.materialRepo.FindByID(ctx, matID)
+	if err != nil {
+		s.logger.Error("failed to find material", "material_id", materialID.String(), "error", err)
+		return errors.NewDatabaseError("find material", err)
+	}
+	if material == nil {
+		return errors.NewNotFoundError("material")
+	}
+	if completedAt.Before(material.CreatedAt) {
+		return errors.NewValidationError("completed_at must not be before the material was created")
+	}
+	return nil
+}
+
+// authorizeMaterialRead carga el material y evalúa material:read para el usuario
+func (s *assessmentAttemptService) authorizeMaterialRead(ctx context.Context, materialID uuid.UUID, subject policy.Subject) error {
+	matID, err := valueobject.MaterialIDFromString(materialID.String())
+	if err != nil {
+		return errors.NewValidationError("invalid material_id")
+	}
+	_, err = authorizeMaterialRead(ctx, s.materialRepo, matID, subject, s.logger)
+	return err
+}
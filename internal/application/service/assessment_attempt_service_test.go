@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// TestValidateOfflineCompletedAt verifica los límites de la hora de finalización de un intento offline
+func TestValidateOfflineCompletedAt(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	materialID := uuid.New()
+	createdAt := now.Add(-72 * time.Hour)
+
+	tests := []struct {
+		name        string
+		completedAt time.Time
+		wantErr     bool
+	}{
+		{"dentro de la ventana", now.Add(-time.Hour), false},
+		{"tolerancia de reloj", now.Add(maxClientClockSkew - time.Second), false},
+		{"futura", now.Add(time.Hour), true},
+		{"antes de crear el material", createdAt.Add(-time.Minute), true},
+		{"fuera de la ventana offline", now.Add(-maxOfflineAttemptAge - time.Hour), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			materials := new(MockMaterialRepository)
+			materials.On("FindByID", mock.Anything, mock.Anything).
+				Return(&pgentities.Material{ID: materialID, CreatedAt: createdAt}, nil).Maybe()
+			svc := &assessmentAttemptService{materialRepo: materials}
+
+			err := svc.validateOfflineCompletedAt(context.Background(), materialID, tt.completedAt, now)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok, "expected AppError, got %v", err)
+			assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+		})
+	}
+}
+
+// TestValidateOfflineCompletedAt_MaterialNotFound verifica el rechazo de intentos de materiales inexistentes
+func TestValidateOfflineCompletedAt_MaterialNotFound(t *testing.T) {
+	materials := new(MockMaterialRepository)
+	materials.On("FindByID", mock.Anything, mock.Anything).Return(nil, nil)
+	svc := &assessmentAttemptService{materialRepo: materials}
+
+	now := time.Now()
+	err := svc.validateOfflineCompletedAt(context.Background(), uuid.New(), now.Add(-time.Hour), now)
+
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok, "expected AppError, got %v", err)
+	assert.Equal(t, errors.ErrorCodeNotFound, appErr.Code)
+}
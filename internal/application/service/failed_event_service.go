@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// FailedEventService administra el dead-letter store (inspección y replay)
+type FailedEventService interface {
+	ListFailedEvents(ctx context.Context, filter repository.FailedEventFilter) (*dto.FailedEventListResponse, error)
+	ReplayFailedEvent(ctx context.Context, id string) (*dto.FailedEventDTO, error)
+}
+
+type failedEventService struct {
+	repo      repository.FailedEventRepository
+	publisher rabbitmq.Publisher // Publisher sin dead-letter: un replay fallido actualiza el mismo registro
+	logger    logger.Logger
+}
+
+// NewFailedEventService crea el servicio de dead-letter
+func NewFailedEventService(
+	repo repository.FailedEventRepository,
+	publisher rabbitmq.Publisher,
+	logger logger.Logger,
+) FailedEventService {
+	return &failedEventService{
+		repo:      repo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (s *failedEventService) ListFailedEvents(ctx context.Context, filter repository.FailedEventFilter) (*dto.FailedEventListResponse, error) {
+	switch filter.Status {
+	case "", repository.FailedEventStatusPending, repository.FailedEventStatusReplayed:
+	default:
+		return nil, errors.NewValidationError("status must be one of: pending, replayed")
+	}
+
+	events, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list failed events", err)
+	}
+
+	response := &dto.FailedEventListResponse{
+		Events:     make([]dto.FailedEventDTO, 0, len(events)),
+		TotalCount: total,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, toFailedEventDTO(event))
+	}
+
+	return response, nil
+}
+
+func (s *failedEventService) ReplayFailedEvent(ctx context.Context, id string) (*dto.FailedEventDTO, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.NewValidationError("invalid failed event id")
+	}
+
+	event, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find failed event", err)
+	}
+	if event == nil {
+		return nil, errors.NewNotFoundError("failed event")
+	}
+	if event.Status == repository.FailedEventStatusReplayed {
+		return nil, errors.NewConflictError("failed event was already replayed")
+	}
+	if len(event.Payload) == 0 {
+		return nil, errors.NewBusinessRuleError("failed event has no payload to replay")
+	}
+
+	event.Attempts++
+	publishErr := s.publisher.Publish(ctx, event.Exchange, event.RoutingKey, event.Payload)
+
+	now := time.Now().UTC()
+	if publishErr != nil {
+		event.Error = publishErr.Error()
+		event.LastFailedAt = now
+	} else {
+		event.Status = repository.FailedEventStatusReplayed
+		event.ReplayedAt = &now
+	}
+
+	if err := s.repo.Update(ctx, event); err != nil {
+		return nil, errors.NewDatabaseError("update failed event", err)
+	}
+
+	if publishErr != nil {
+		s.logger.Warn("failed event replay failed",
+			"failed_event_id", event.ID,
+			"routing_key", event.RoutingKey,
+			"attempts", event.Attempts,
+			"error", publishErr,
+		)
+		return nil, errors.NewInternalError("failed to replay event", publishErr)
+	}
+
+	s.logger.Info("failed event replayed",
+		"failed_event_id", event.ID,
+		"exchange", event.Exchange,
+		"routing_key", event.RoutingKey,
+		"attempts", event.Attempts,
+	)
+
+	result := toFailedEventDTO(event)
+	return &result, nil
+}
+
+// toFailedEventDTO convierte la entidad al DTO de respuesta
+func toFailedEventDTO(event *repository.FailedEvent) dto.FailedEventDTO {
+	result := dto.FailedEventDTO{
+		ID:            event.ID,
+		Exchange:      event.Exchange,
+		RoutingKey:    event.RoutingKey,
+		Error:         event.Error,
+		Attempts:      event.Attempts,
+		Status:        string(event.Status),
+		FirstFailedAt: event.FirstFailedAt,
+		LastFailedAt:  event.LastFailedAt,
+		ReplayedAt:    event.ReplayedAt,
+	}
+	if len(event.Payload) > 0 {
+		if json.Valid(event.Payload) {
+			result.Payload = json.RawMessage(event.Payload)
+		} else {
+			result.PayloadRaw = string(event.Payload)
+		}
+	}
+	return result
+}
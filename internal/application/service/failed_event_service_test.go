@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	mockMongo "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/mongodb"
+	appErrors "github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func seedFailedEvent(t *testing.T, repo repository.FailedEventRepository, id string, payload []byte, status repository.FailedEventStatus) {
+	t.Helper()
+	now := time.Now().UTC()
+	require.NoError(t, repo.Save(context.Background(), &repository.FailedEvent{
+		ID:            id,
+		Exchange:      "edugo.events",
+		RoutingKey:    "material.completed",
+		Payload:       payload,
+		Error:         "broker down",
+		Attempts:      1,
+		Status:        status,
+		FirstFailedAt: now,
+		LastFailedAt:  now,
+	}))
+}
+
+func TestFailedEventService_ListFailedEvents(t *testing.T) {
+	repo := mockMongo.NewMockFailedEventRepository()
+	seedFailedEvent(t, repo, "11111111-1111-1111-1111-111111111111", []byte(`{"id":"1"}`), repository.FailedEventStatusPending)
+	seedFailedEvent(t, repo, "22222222-2222-2222-2222-222222222222", []byte("not json"), repository.FailedEventStatusReplayed)
+
+	svc := NewFailedEventService(repo, new(MockPublisher), logger.NewZapLogger("error", "json"))
+
+	result, err := svc.ListFailedEvents(context.Background(), repository.FailedEventFilter{Status: repository.FailedEventStatusPending, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Events, 1)
+	assert.Equal(t, int64(1), result.TotalCount)
+	assert.JSONEq(t, `{"id":"1"}`, string(result.Events[0].Payload))
+
+	result, err = svc.ListFailedEvents(context.Background(), repository.FailedEventFilter{Status: repository.FailedEventStatusReplayed, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Events, 1)
+	assert.Equal(t, "not json", result.Events[0].PayloadRaw)
+
+	_, err = svc.ListFailedEvents(context.Background(), repository.FailedEventFilter{Status: "unknown"})
+	appErr, ok := appErrors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, appErrors.ErrorCodeValidation, appErr.Code)
+}
+
+func TestFailedEventService_ReplayFailedEvent_Success(t *testing.T) {
+	id := "11111111-1111-1111-1111-111111111111"
+	payload := []byte(`{"material_id":"m-1"}`)
+	repo := mockMongo.NewMockFailedEventRepository()
+	seedFailedEvent(t, repo, id, payload, repository.FailedEventStatusPending)
+
+	publisher := new(MockPublisher)
+	publisher.On("Publish", mock.Anything, "edugo.events", "material.completed", payload).Return(nil)
+
+	svc := NewFailedEventService(repo, publisher, logger.NewZapLogger("error", "json"))
+
+	result, err := svc.ReplayFailedEvent(context.Background(), id)
+
+	require.NoError(t, err)
+	assert.Equal(t, string(repository.FailedEventStatusReplayed), result.Status)
+	assert.Equal(t, 2, result.Attempts)
+	assert.NotNil(t, result.ReplayedAt)
+	publisher.AssertExpectations(t)
+
+	// Un segundo replay se rechaza
+	_, err = svc.ReplayFailedEvent(context.Background(), id)
+	appErr, ok := appErrors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, appErrors.ErrorCodeConflict, appErr.Code)
+}
+
+func TestFailedEventService_ReplayFailedEvent_PublishFails(t *testing.T) {
+	id := "11111111-1111-1111-1111-111111111111"
+	payload := []byte(`{"material_id":"m-1"}`)
+	repo := mockMongo.NewMockFailedEventRepository()
+	seedFailedEvent(t, repo, id, payload, repository.FailedEventStatusPending)
+
+	publisher := new(MockPublisher)
+	publisher.On("Publish", mock.Anything, "edugo.events", "material.completed", payload).Return(errors.New("still down"))
+
+	svc := NewFailedEventService(repo, publisher, logger.NewZapLogger("error", "json"))
+
+	_, err := svc.ReplayFailedEvent(context.Background(), id)
+	require.Error(t, err)
+
+	stored, err := repo.FindByID(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, repository.FailedEventStatusPending, stored.Status)
+	assert.Equal(t, 2, stored.Attempts)
+	assert.Equal(t, "still down", stored.Error)
+}
+
+func TestFailedEventService_ReplayFailedEvent_Errors(t *testing.T) {
+	repo := mockMongo.NewMockFailedEventRepository()
+	seedFailedEvent(t, repo, "33333333-3333-3333-3333-333333333333", nil, repository.FailedEventStatusPending)
+	svc := NewFailedEventService(repo, new(MockPublisher), logger.NewZapLogger("error", "json"))
+
+	tests := []struct {
+		name string
+		id   string
+		code appErrors.ErrorCode
+	}{
+		{"invalid id", "not-a-uuid", appErrors.ErrorCodeValidation},
+		{"not found", "44444444-4444-4444-4444-444444444444", appErrors.ErrorCodeNotFound},
+		{"serialization failure has no payload", "33333333-3333-3333-3333-333333333333", appErrors.ErrorCodeBusinessRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ReplayFailedEvent(context.Background(), tt.id)
+			appErr, ok := appErrors.GetAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repositories"
+	mongoRepo "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mongodb/repository"
+)
+
+const (
+	// discriminationGroupRatio proporción de estudiantes en los grupos superior e inferior (Kelley, 27%)
+	discriminationGroupRatio = 0.27
+
+	// minRespondentsForFlags mínimo de estudiantes para marcar preguntas; con menos los índices son ruido
+	minRespondentsForFlags = 10
+)
+
+// Marcas que identifican preguntas potencialmente mal redactadas
+const (
+	ItemFlagTooEasy                = "too_easy"
+	ItemFlagTooHard                = "too_hard"
+	ItemFlagLowDiscrimination      = "low_discrimination"
+	ItemFlagNegativeDiscrimination = "negative_discrimination"
+	ItemFlagUnusedDistractor       = "unused_distractor"
+)
+
+// analyzeItems calcula dificultad, discriminación, distractores y tiempo promedio por pregunta
+// Retorna las métricas por pregunta, la cantidad de estudiantes y el tamaño de cada grupo extremo
+func analyzeItems(questions []mongoRepo.Question, responses []*repositories.ItemResponse) ([]dto.QuestionAnalysisDTO, int, int) {
+	// Ordenar intentos por puntaje total para formar los grupos superior e inferior
+	scores := make(map[string]float64)
+	for _, r := range responses {
+		scores[r.AttemptID.String()] = r.AttemptScore
+	}
+	attempts := make([]string, 0, len(scores))
+	for id := range scores {
+		attempts = append(attempts, id)
+	}
+	sort.Slice(attempts, func(i, j int) bool {
+		if scores[attempts[i]] != scores[attempts[j]] {
+			return scores[attempts[i]] > scores[attempts[j]]
+		}
+		return attempts[i] < attempts[j]
+	})
+
+	respondents := len(attempts)
+	groupSize := int(math.Round(float64(respondents) * discriminationGroupRatio))
+	if groupSize == 0 && respondents >= 2 {
+		groupSize = 1
+	}
+
+	upper := make(map[string]bool, groupSize)
+	lower := make(map[string]bool, groupSize)
+	for i := 0; i < groupSize; i++ {
+		upper[attempts[i]] = true
+		lower[attempts[respondents-1-i]] = true
+	}
+
+	type accumulator struct {
+		responses    int
+		correct      int
+		upperCorrect int
+		lowerCorrect int
+		timeTotal    int
+		timeCount    int
+		picks        map[string]int
+	}
+	acc := make([]accumulator, len(questions))
+	for i := range acc {
+		acc[i].picks = make(map[string]int)
+	}
+
+	for _, r := range responses {
+		if r.QuestionIndex < 0 || r.QuestionIndex >= len(questions) {
+			continue
+		}
+		a := &acc[r.QuestionIndex]
+		a.responses++
+		a.picks[r.StudentAnswer]++
+		if r.TimeSpentSeconds != nil {
+			a.timeTotal += *r.TimeSpentSeconds
+			a.timeCount++
+		}
+		if !r.IsCorrect {
+			continue
+		}
+		a.correct++
+		attemptID := r.AttemptID.String()
+		if upper[attemptID] {
+			a.upperCorrect++
+		}
+		if lower[attemptID] {
+			a.lowerCorrect++
+		}
+	}
+
+	results := make([]dto.QuestionAnalysisDTO, len(questions))
+	for i, q := range questions {
+		a := acc[i]
+		item := dto.QuestionAnalysisDTO{
+			QuestionIndex: i,
+			QuestionID:    q.ID,
+			QuestionText:  q.Text,
+			Type:          q.Type,
+			CorrectAnswer: q.CorrectAnswer,
+			Responses:     a.responses,
+			Distractors:   make([]dto.DistractorDTO, 0, len(q.Options)),
+			Flags:         make([]string, 0),
+		}
+
+		if a.responses > 0 {
+			item.DifficultyIndex = float64(a.correct) * 100 / float64(a.responses)
+		}
+		if groupSize > 0 {
+			item.DiscriminationIndex = float64(a.upperCorrect-a.lowerCorrect) / float64(groupSize)
+		}
+		if a.timeCount > 0 {
+			item.AvgTimeSpentSeconds = float64(a.timeTotal) / float64(a.timeCount)
+		}
+
+		unusedDistractor := false
+		for _, opt := range q.Options {
+			if opt.ID == q.CorrectAnswer {
+				continue
+			}
+			distractor := dto.DistractorDTO{OptionID: opt.ID, Text: opt.Text, Count: a.picks[opt.ID]}
+			if a.responses > 0 {
+				distractor.Rate = float64(distractor.Count) * 100 / float64(a.responses)
+			}
+			if distractor.Count == 0 {
+				unusedDistractor = true
+			}
+			item.Distractors = append(item.Distractors, distractor)
+		}
+
+		if respondents >= minRespondentsForFlags {
+			item.Flags = itemFlags(item, unusedDistractor)
+		}
+
+		results[i] = item
+	}
+
+	return results, respondents, groupSize
+}
+
+// itemFlags aplica umbrales clásicos de análisis de ítems (dificultad 20-90%, discriminación >= 0.2)
+func itemFlags(item dto.QuestionAnalysisDTO, unusedDistractor bool) []string {
+	flags := make([]string, 0)
+	switch {
+	case item.DifficultyIndex > 90:
+		flags = append(flags, ItemFlagTooEasy)
+	case item.DifficultyIndex < 20:
+		flags = append(flags, ItemFlagTooHard)
+	}
+	switch {
+	case item.DiscriminationIndex < 0:
+		flags = append(flags, ItemFlagNegativeDiscrimination)
+	case item.DiscriminationIndex < 0.2:
+		flags = append(flags, ItemFlagLowDiscrimination)
+	}
+	if unusedDistractor {
+		flags = append(flags, ItemFlagUnusedDistractor)
+	}
+	return flags
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repositories"
+	mongoRepo "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mongodb/repository"
+)
+
+func itemAnalysisQuestions() []mongoRepo.Question {
+	options := []mongoRepo.Option{{ID: "a", Text: "A"}, {ID: "b", Text: "B"}, {ID: "c", Text: "C"}}
+	return []mongoRepo.Question{
+		{ID: "q1", Text: "Pregunta 1", Type: "multiple_choice", Options: options, CorrectAnswer: "a"},
+		{ID: "q2", Text: "Pregunta 2", Type: "multiple_choice", Options: options, CorrectAnswer: "b"},
+	}
+}
+
+// newItemResponses genera respuestas de n estudiantes; answer decide la opción elegida por índice de estudiante
+func newItemResponses(n int, score func(i int) float64, answers map[int]func(i int) string, correct map[int]string) []*repositories.ItemResponse {
+	var responses []*repositories.ItemResponse
+	for i := 0; i < n; i++ {
+		attemptID := uuid.New()
+		studentID := uuid.New()
+		for q, answer := range answers {
+			timeSpent := 10 * (q + 1)
+			selected := answer(i)
+			responses = append(responses, &repositories.ItemResponse{
+				AttemptID:        attemptID,
+				StudentID:        studentID,
+				AttemptScore:     score(i),
+				QuestionIndex:    q,
+				StudentAnswer:    selected,
+				IsCorrect:        selected == correct[q],
+				TimeSpentSeconds: &timeSpent,
+			})
+		}
+	}
+	return responses
+}
+
+// TestAnalyzeItems_DifficultyDiscriminationAndDistractors valida las métricas sobre una cohorte de 10 estudiantes
+func TestAnalyzeItems_DifficultyDiscriminationAndDistractors(t *testing.T) {
+	// Los estudiantes 0-4 tienen puntaje alto; q1 la aciertan solo ellos, q2 la aciertan todos menos los mejores
+	responses := newItemResponses(10,
+		func(i int) float64 { return float64(100 - i*10) },
+		map[int]func(i int) string{
+			0: func(i int) string {
+				if i < 5 {
+					return "a"
+				}
+				return "b"
+			},
+			1: func(i int) string {
+				if i < 3 {
+					return "c"
+				}
+				return "b"
+			},
+		},
+		map[int]string{0: "a", 1: "b"},
+	)
+
+	items, respondents, groupSize := analyzeItems(itemAnalysisQuestions(), responses)
+
+	require.Len(t, items, 2)
+	assert.Equal(t, 10, respondents)
+	assert.Equal(t, 3, groupSize, "27% de 10 redondeado")
+
+	q1 := items[0]
+	assert.Equal(t, 10, q1.Responses)
+	assert.Equal(t, 50.0, q1.DifficultyIndex)
+	assert.Equal(t, 1.0, q1.DiscriminationIndex)
+	assert.Equal(t, 10.0, q1.AvgTimeSpentSeconds)
+	require.Len(t, q1.Distractors, 2)
+	assert.Equal(t, "b", q1.Distractors[0].OptionID)
+	assert.Equal(t, 5, q1.Distractors[0].Count)
+	assert.Equal(t, 50.0, q1.Distractors[0].Rate)
+	assert.Equal(t, 0, q1.Distractors[1].Count)
+	assert.Equal(t, []string{ItemFlagUnusedDistractor}, q1.Flags)
+
+	q2 := items[1]
+	assert.Equal(t, 70.0, q2.DifficultyIndex)
+	assert.Equal(t, -1.0, q2.DiscriminationIndex)
+	assert.Contains(t, q2.Flags, ItemFlagNegativeDiscrimination)
+}
+
+// TestAnalyzeItems_SmallCohortHasNoFlags valida que con pocos estudiantes no se marcan preguntas
+func TestAnalyzeItems_SmallCohortHasNoFlags(t *testing.T) {
+	responses := newItemResponses(2,
+		func(i int) float64 { return float64(100 - i*50) },
+		map[int]func(i int) string{0: func(i int) string { return "a" }},
+		map[int]string{0: "a"},
+	)
+
+	items, respondents, groupSize := analyzeItems(itemAnalysisQuestions(), responses)
+
+	assert.Equal(t, 2, respondents)
+	assert.Equal(t, 1, groupSize)
+	assert.Equal(t, 100.0, items[0].DifficultyIndex)
+	assert.Empty(t, items[0].Flags)
+	assert.Equal(t, 0, items[1].Responses, "pregunta sin respuestas")
+	assert.Zero(t, items[1].DifficultyIndex)
+}
+
+// TestAnalyzeItems_NoResponses valida que una evaluación sin intentos retorna métricas vacías
+func TestAnalyzeItems_NoResponses(t *testing.T) {
+	items, respondents, groupSize := analyzeItems(itemAnalysisQuestions(), nil)
+
+	assert.Len(t, items, 2)
+	assert.Zero(t, respondents)
+	assert.Zero(t, groupSize)
+	assert.Zero(t, items[0].DiscriminationIndex)
+}
@@ -0,0 +1,410 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// PathLearner identifica al usuario cuyo estado de desbloqueo se calcula
+// AcademicUnitID vacío significa que el usuario no está acotado a una unidad
+type PathLearner struct {
+	UserID         string
+	SchoolID       string
+	AcademicUnitID string
+}
+
+// subject retorna el usuario para evaluar las políticas de acceso
+func (l PathLearner) subject() policy.Subject {
+	return policy.Subject{UserID: l.UserID, SchoolID: l.SchoolID, AcademicUnitID: l.AcademicUnitID}
+}
+
+// LearningPathService gestiona rutas de aprendizaje y calcula su desbloqueo por estudiante
+// El estado se deriva de Progress y AssessmentAttempt; no se persiste
+type LearningPathService interface {
+	CreatePath(ctx context.Context, req dto.LearningPathRequest, authorID, schoolID string) (*dto.LearningPathResponse, error)
+	// UpdatePath reemplaza título, descripción, unidad y pasos de una ruta de la escuela
+	UpdatePath(ctx context.Context, pathID string, req dto.LearningPathRequest, schoolID string) (*dto.LearningPathResponse, error)
+	DeletePath(ctx context.Context, pathID, schoolID string) error
+
+	// ListPaths lista las rutas que aplican al usuario con su estado de desbloqueo
+	ListPaths(ctx context.Context, learner PathLearner) ([]dto.LearningPathResponse, error)
+	GetPath(ctx context.Context, pathID string, learner PathLearner) (*dto.LearningPathResponse, error)
+
+	// GetMaterialLock retorna por qué el material está bloqueado para el usuario, o nil si puede accederlo
+	// Un material está bloqueado si lo está en cualquiera de las rutas que le aplican
+	GetMaterialLock(ctx context.Context, materialID string, learner PathLearner) (*dto.MaterialLock, error)
+}
+
+type learningPathService struct {
+	pathRepo     repository.LearningPathRepository
+	materialRepo repository.MaterialReader
+	logger       logger.Logger
+}
+
+// NewLearningPathService crea el servicio de rutas de aprendizaje
+func NewLearningPathService(
+	pathRepo repository.LearningPathRepository,
+	materialRepo repository.MaterialReader,
+	logger logger.Logger,
+) LearningPathService {
+	return &learningPathService{
+		pathRepo:     pathRepo,
+		materialRepo: materialRepo,
+		logger:       logger,
+	}
+}
+
+func (s *learningPathService) CreatePath(ctx context.Context, req dto.LearningPathRequest, authorID, schoolID string) (*dto.LearningPathResponse, error) {
+	steps, err := s.buildSteps(ctx, req.Steps, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	path := &repository.LearningPath{
+		ID:             uuid.NewString(),
+		SchoolID:       schoolID,
+		AcademicUnitID: req.AcademicUnitID,
+		Title:          req.Title,
+		Description:    req.Description,
+		CreatedBy:      authorID,
+		Steps:          steps,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.pathRepo.Create(ctx, path); err != nil {
+		s.logger.Error("failed to create learning path", "school_id", schoolID, "error", err)
+		return nil, errors.NewDatabaseError("create learning path", err)
+	}
+
+	s.logger.Info("learning path created", "path_id", path.ID, "steps", len(steps), "author_id", authorID)
+	return s.reload(ctx, path.ID)
+}
+
+func (s *learningPathService) UpdatePath(ctx context.Context, pathID string, req dto.LearningPathRequest, schoolID string) (*dto.LearningPathResponse, error) {
+	path, err := s.findSchoolPath(ctx, pathID, policy.Subject{SchoolID: schoolID}, policy.ActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := s.buildSteps(ctx, req.Steps, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	path.AcademicUnitID = req.AcademicUnitID
+	path.Title = req.Title
+	path.Description = req.Description
+	path.Steps = steps
+	path.UpdatedAt = time.Now()
+	if err := s.pathRepo.Update(ctx, path); err != nil {
+		s.logger.Error("failed to update learning path", "path_id", pathID, "error", err)
+		return nil, errors.NewDatabaseError("update learning path", err)
+	}
+
+	s.logger.Info("learning path updated", "path_id", pathID, "steps", len(steps))
+	return s.reload(ctx, pathID)
+}
+
+func (s *learningPathService) DeletePath(ctx context.Context, pathID, schoolID string) error {
+	if _, err := s.findSchoolPath(ctx, pathID, policy.Subject{SchoolID: schoolID}, policy.ActionDelete); err != nil {
+		return err
+	}
+
+	deleted, err := s.pathRepo.Delete(ctx, pathID)
+	if err != nil {
+		s.logger.Error("failed to delete learning path", "path_id", pathID, "error", err)
+		return errors.NewDatabaseError("delete learning path", err)
+	}
+	if !deleted {
+		return errors.NewNotFoundError("learning path")
+	}
+
+	s.logger.Info("learning path deleted", "path_id", pathID)
+	return nil
+}
+
+func (s *learningPathService) ListPaths(ctx context.Context, learner PathLearner) ([]dto.LearningPathResponse, error) {
+	paths, err := s.pathRepo.ListBySchool(ctx, learner.SchoolID)
+	if err != nil {
+		s.logger.Error("failed to list learning paths", "school_id", learner.SchoolID, "error", err)
+		return nil, errors.NewDatabaseError("list learning paths", err)
+	}
+
+	applicable := make([]*repository.LearningPath, 0, len(paths))
+	for _, p := range paths {
+		if pathAppliesTo(p, learner) {
+			applicable = append(applicable, p)
+		}
+	}
+
+	results, err := s.stepResults(ctx, learner.UserID, applicable...)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.LearningPathResponse, 0, len(applicable))
+	for _, p := range applicable {
+		response = append(response, *buildLearningPathResponse(p, results))
+	}
+	return response, nil
+}
+
+func (s *learningPathService) GetPath(ctx context.Context, pathID string, learner PathLearner) (*dto.LearningPathResponse, error) {
+	path, err := s.findSchoolPath(ctx, pathID, learner.subject(), policy.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.stepResults(ctx, learner.UserID, path)
+	if err != nil {
+		return nil, err
+	}
+	return buildLearningPathResponse(path, results), nil
+}
+
+func (s *learningPathService) GetMaterialLock(ctx context.Context, materialID string, learner PathLearner) (*dto.MaterialLock, error) {
+	paths, err := s.pathRepo.ListByMaterial(ctx, materialID)
+	if err != nil {
+		s.logger.Error("failed to list learning paths for material", "material_id", materialID, "error", err)
+		return nil, errors.NewDatabaseError("list learning paths", err)
+	}
+
+	applicable := make([]*repository.LearningPath, 0, len(paths))
+	for _, p := range paths {
+		if p.SchoolID == learner.SchoolID && pathAppliesTo(p, learner) {
+			applicable = append(applicable, p)
+		}
+	}
+	if len(applicable) == 0 {
+		return nil, nil
+	}
+
+	results, err := s.stepResults(ctx, learner.UserID, applicable...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range applicable {
+		completed := completedSteps(p, results)
+		for _, step := range p.Steps {
+			if step.MaterialID != materialID || completed[step.MaterialID] {
+				continue
+			}
+			if missing := missingPrerequisites(step, completed); len(missing) > 0 {
+				return &dto.MaterialLock{
+					MaterialID:   materialID,
+					PathID:       p.ID,
+					PathTitle:    p.Title,
+					MissingSteps: missing,
+				}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// buildSteps valida los pasos pedidos y resuelve los requisitos por defecto (paso anterior)
+// Los materiales deben existir, pertenecer a la escuela y no repetirse en la ruta
+func (s *learningPathService) buildSteps(ctx context.Context, requested []dto.LearningPathStepRequest, schoolID string) ([]repository.LearningPathStep, error) {
+	if len(requested) == 0 {
+		return nil, errors.NewValidationError("a learning path needs at least one step")
+	}
+
+	steps := make([]repository.LearningPathStep, 0, len(requested))
+	positions := make(map[string]int, len(requested))
+	for i, req := range requested {
+		materialID, err := valueobject.MaterialIDFromString(req.MaterialID)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("step %d: invalid material_id", i+1))
+		}
+		if _, dup := positions[req.MaterialID]; dup {
+			return nil, errors.NewValidationError(fmt.Sprintf("step %d: material %s appears more than once", i+1, req.MaterialID))
+		}
+		if req.MinScore != nil && (*req.MinScore < 0 || *req.MinScore > 100) {
+			return nil, errors.NewValidationError(fmt.Sprintf("step %d: min_score must be between 0 and 100", i+1))
+		}
+
+		material, err := s.materialRepo.FindByID(ctx, materialID)
+		if err != nil {
+			s.logger.Error("failed to find learning path material", "material_id", req.MaterialID, "error", err)
+			return nil, errors.NewDatabaseError("find material", err)
+		}
+		if material == nil || material.SchoolID.String() != schoolID {
+			return nil, errors.NewValidationError(fmt.Sprintf("step %d: material %s not found", i+1, req.MaterialID))
+		}
+
+		prerequisites := req.Requires
+		if prerequisites == nil && i > 0 {
+			prerequisites = []string{requested[i-1].MaterialID}
+		}
+		// Solo pasos anteriores: garantiza que la ruta no tenga ciclos
+		for _, prereq := range prerequisites {
+			if _, earlier := positions[prereq]; !earlier {
+				return nil, errors.NewValidationError(fmt.Sprintf("step %d: requirement %s must be an earlier step of the path", i+1, prereq))
+			}
+		}
+
+		positions[req.MaterialID] = i + 1
+		steps = append(steps, repository.LearningPathStep{
+			Position:      i + 1,
+			MaterialID:    req.MaterialID,
+			MinScore:      req.MinScore,
+			Prerequisites: append([]string{}, prerequisites...),
+		})
+	}
+	return steps, nil
+}
+
+// findSchoolPath obtiene una ruta autorizando la acción; una ruta de otra escuela se trata como inexistente
+func (s *learningPathService) findSchoolPath(ctx context.Context, pathID string, subject policy.Subject, action policy.Action) (*repository.LearningPath, error) {
+	if _, err := uuid.Parse(pathID); err != nil {
+		return nil, errors.NewValidationError("invalid path_id")
+	}
+
+	path, err := s.pathRepo.FindByID(ctx, pathID)
+	if err != nil {
+		s.logger.Error("failed to find learning path", "path_id", pathID, "error", err)
+		return nil, errors.NewDatabaseError("find learning path", err)
+	}
+	if path == nil {
+		return nil, errors.NewNotFoundError("learning path")
+	}
+	if err := policy.Authorize(subject, policy.ResourceLearningPath, action, policy.Target{SchoolID: path.SchoolID}); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+// reload relee la ruta recién escrita (con títulos de materiales) sin estado de estudiante
+func (s *learningPathService) reload(ctx context.Context, pathID string) (*dto.LearningPathResponse, error) {
+	path, err := s.pathRepo.FindByID(ctx, pathID)
+	if err != nil {
+		s.logger.Error("failed to reload learning path", "path_id", pathID, "error", err)
+		return nil, errors.NewDatabaseError("find learning path", err)
+	}
+	if path == nil {
+		return nil, errors.NewNotFoundError("learning path")
+	}
+	return buildLearningPathResponse(path, nil), nil
+}
+
+// stepResults obtiene en una sola consulta los resultados del usuario en los materiales de las rutas
+func (s *learningPathService) stepResults(ctx context.Context, userID string, paths ...*repository.LearningPath) (map[string]repository.LearningPathStepResult, error) {
+	seen := make(map[string]bool)
+	materialIDs := make([]string, 0)
+	for _, p := range paths {
+		for _, step := range p.Steps {
+			if !seen[step.MaterialID] {
+				seen[step.MaterialID] = true
+				materialIDs = append(materialIDs, step.MaterialID)
+			}
+		}
+	}
+
+	results := make(map[string]repository.LearningPathStepResult, len(materialIDs))
+	if len(materialIDs) == 0 {
+		return results, nil
+	}
+
+	rows, err := s.pathRepo.GetStepResults(ctx, userID, materialIDs)
+	if err != nil {
+		s.logger.Error("failed to get learning path step results", "user_id", userID, "error", err)
+		return nil, errors.NewDatabaseError("get learning path step results", err)
+	}
+	for _, r := range rows {
+		results[r.MaterialID] = r
+	}
+	return results, nil
+}
+
+// pathAppliesTo indica si una ruta acotada a una unidad aplica al usuario
+func pathAppliesTo(path *repository.LearningPath, learner PathLearner) bool {
+	return path.AcademicUnitID == nil || learner.AcademicUnitID == "" || *path.AcademicUnitID == learner.AcademicUnitID
+}
+
+// stepCompleted indica si el usuario leyó el material completo y, si se exige, aprobó su evaluación
+func stepCompleted(step repository.LearningPathStep, result repository.LearningPathStepResult) bool {
+	if result.Percentage < 100 {
+		return false
+	}
+	if step.MinScore == nil {
+		return true
+	}
+	return result.BestScore != nil && *result.BestScore >= float64(*step.MinScore)
+}
+
+func completedSteps(path *repository.LearningPath, results map[string]repository.LearningPathStepResult) map[string]bool {
+	completed := make(map[string]bool, len(path.Steps))
+	for _, step := range path.Steps {
+		completed[step.MaterialID] = stepCompleted(step, results[step.MaterialID])
+	}
+	return completed
+}
+
+func missingPrerequisites(step repository.LearningPathStep, completed map[string]bool) []string {
+	missing := make([]string, 0)
+	for _, prereq := range step.Prerequisites {
+		if !completed[prereq] {
+			missing = append(missing, prereq)
+		}
+	}
+	return missing
+}
+
+// buildLearningPathResponse calcula el estado de cada paso a partir de los resultados del usuario
+// Un paso completado se informa como tal aunque sus requisitos se hayan completado después
+func buildLearningPathResponse(path *repository.LearningPath, results map[string]repository.LearningPathStepResult) *dto.LearningPathResponse {
+	completed := completedSteps(path, results)
+	response := &dto.LearningPathResponse{
+		ID:             path.ID,
+		Title:          path.Title,
+		Description:    path.Description,
+		AcademicUnitID: path.AcademicUnitID,
+		CreatedBy:      path.CreatedBy,
+		Steps:          make([]dto.LearningPathStepDTO, 0, len(path.Steps)),
+		TotalSteps:     len(path.Steps),
+		CreatedAt:      path.CreatedAt,
+		UpdatedAt:      path.UpdatedAt,
+	}
+
+	for _, step := range path.Steps {
+		result := results[step.MaterialID]
+		state := dto.PathStepAvailable
+		switch {
+		case completed[step.MaterialID]:
+			state = dto.PathStepCompleted
+			response.CompletedSteps++
+		case len(missingPrerequisites(step, completed)) > 0:
+			state = dto.PathStepLocked
+		case result.Percentage > 0 || result.BestScore != nil:
+			state = dto.PathStepInProgress
+		}
+
+		requires := step.Prerequisites
+		if requires == nil {
+			requires = []string{}
+		}
+		response.Steps = append(response.Steps, dto.LearningPathStepDTO{
+			Position:           step.Position,
+			MaterialID:         step.MaterialID,
+			MaterialTitle:      step.MaterialTitle,
+			MinScore:           step.MinScore,
+			Requires:           requires,
+			State:              state,
+			ProgressPercentage: result.Percentage,
+			BestScore:          result.BestScore,
+		})
+	}
+	return response
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/fixtures"
+	mockPostgres "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/postgres"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// pathResultsRepository reemplaza los resultados por paso del repositorio en memoria
+type pathResultsRepository struct {
+	repository.LearningPathRepository
+	results map[string]repository.LearningPathStepResult
+}
+
+func (r *pathResultsRepository) GetStepResults(ctx context.Context, userID string, materialIDs []string) ([]repository.LearningPathStepResult, error) {
+	rows := make([]repository.LearningPathStepResult, 0)
+	for _, id := range materialIDs {
+		if result, ok := r.results[id]; ok {
+			rows = append(rows, result)
+		}
+	}
+	return rows, nil
+}
+
+var (
+	pathMaterialA = fixtures.MaterialGuidaSumasID.String()
+	pathMaterialB = fixtures.MaterialGuiaRestasID.String()
+	pathMaterialC = fixtures.MaterialLasPlantasID.String()
+)
+
+func newLearningPathTestService(t *testing.T) (LearningPathService, *pathResultsRepository, string) {
+	t.Helper()
+
+	logger := new(MockLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Maybe()
+	logger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	repo := &pathResultsRepository{
+		LearningPathRepository: mockPostgres.NewMockLearningPathRepository(),
+		results:                make(map[string]repository.LearningPathStepResult),
+	}
+	schoolID := fixtures.GetDefaultMaterials()[fixtures.MaterialGuidaSumasID].SchoolID.String()
+	return NewLearningPathService(repo, mockPostgres.NewMockMaterialRepository(), logger), repo, schoolID
+}
+
+func intPtr(v int) *int { return &v }
+
+func floatPtr(v float64) *float64 { return &v }
+
+// TestLearningPathService_CreatePath_DefaultsToPreviousStep verifica requisitos por defecto y explícitos
+func TestLearningPathService_CreatePath_DefaultsToPreviousStep(t *testing.T) {
+	svc, _, schoolID := newLearningPathTestService(t)
+
+	path, err := svc.CreatePath(context.Background(), dto.LearningPathRequest{
+		Title: "Unidad 1",
+		Steps: []dto.LearningPathStepRequest{
+			{MaterialID: pathMaterialA, MinScore: intPtr(70)},
+			{MaterialID: pathMaterialB},
+			{MaterialID: pathMaterialC, Requires: []string{}},
+		},
+	}, "teacher-1", schoolID)
+	require.NoError(t, err)
+
+	require.Len(t, path.Steps, 3)
+	assert.Empty(t, path.Steps[0].Requires)
+	assert.Equal(t, []string{pathMaterialA}, path.Steps[1].Requires, "sin requires, el paso depende del anterior")
+	assert.Empty(t, path.Steps[2].Requires, "una lista vacía deja el paso libre")
+	assert.NotEmpty(t, path.Steps[0].MaterialTitle)
+	assert.Equal(t, "teacher-1", path.CreatedBy)
+}
+
+// TestLearningPathService_CreatePath_Validation verifica las reglas de armado de la ruta
+func TestLearningPathService_CreatePath_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []dto.LearningPathStepRequest
+	}{
+		{"material repetido", []dto.LearningPathStepRequest{{MaterialID: pathMaterialA}, {MaterialID: pathMaterialA}}},
+		{"material inexistente", []dto.LearningPathStepRequest{{MaterialID: "00000000-0000-0000-0000-000000000001"}}},
+		{"requisito posterior", []dto.LearningPathStepRequest{{MaterialID: pathMaterialA, Requires: []string{pathMaterialB}}, {MaterialID: pathMaterialB}}},
+		{"requisito propio", []dto.LearningPathStepRequest{{MaterialID: pathMaterialA, Requires: []string{pathMaterialA}}}},
+		{"puntaje fuera de rango", []dto.LearningPathStepRequest{{MaterialID: pathMaterialA, MinScore: intPtr(120)}}},
+		{"sin pasos", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, schoolID := newLearningPathTestService(t)
+
+			_, err := svc.CreatePath(context.Background(), dto.LearningPathRequest{Title: "Ruta", Steps: tt.steps}, "teacher-1", schoolID)
+
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+		})
+	}
+
+	t.Run("material de otra escuela", func(t *testing.T) {
+		svc, _, _ := newLearningPathTestService(t)
+		_, err := svc.CreatePath(context.Background(), dto.LearningPathRequest{
+			Title: "Ruta",
+			Steps: []dto.LearningPathStepRequest{{MaterialID: pathMaterialA}},
+		}, "teacher-1", "00000000-0000-0000-0000-000000000099")
+
+		appErr, ok := errors.GetAppError(err)
+		require.True(t, ok)
+		assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+	})
+}
+
+// TestLearningPathService_GetPath_StepStates verifica el desbloqueo según progreso y puntaje
+func TestLearningPathService_GetPath_StepStates(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []repository.LearningPathStepResult
+		states  []string
+	}{
+		{
+			name:   "sin actividad",
+			states: []string{dto.PathStepAvailable, dto.PathStepLocked, dto.PathStepLocked},
+		},
+		{
+			name:    "lectura completa sin aprobar",
+			results: []repository.LearningPathStepResult{{MaterialID: pathMaterialA, Percentage: 100, BestScore: floatPtr(65)}},
+			states:  []string{dto.PathStepInProgress, dto.PathStepLocked, dto.PathStepLocked},
+		},
+		{
+			name:    "aprobado sin terminar la lectura",
+			results: []repository.LearningPathStepResult{{MaterialID: pathMaterialA, Percentage: 80, BestScore: floatPtr(90)}},
+			states:  []string{dto.PathStepInProgress, dto.PathStepLocked, dto.PathStepLocked},
+		},
+		{
+			name:    "primer paso completo",
+			results: []repository.LearningPathStepResult{{MaterialID: pathMaterialA, Percentage: 100, BestScore: floatPtr(70)}},
+			states:  []string{dto.PathStepCompleted, dto.PathStepAvailable, dto.PathStepLocked},
+		},
+		{
+			name: "segundo paso en curso",
+			results: []repository.LearningPathStepResult{
+				{MaterialID: pathMaterialA, Percentage: 100, BestScore: floatPtr(95)},
+				{MaterialID: pathMaterialB, Percentage: 40},
+			},
+			states: []string{dto.PathStepCompleted, dto.PathStepInProgress, dto.PathStepLocked},
+		},
+		{
+			name: "ruta completa",
+			results: []repository.LearningPathStepResult{
+				{MaterialID: pathMaterialA, Percentage: 100, BestScore: floatPtr(70)},
+				{MaterialID: pathMaterialB, Percentage: 100},
+				{MaterialID: pathMaterialC, Percentage: 100},
+			},
+			states: []string{dto.PathStepCompleted, dto.PathStepCompleted, dto.PathStepCompleted},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, schoolID := newLearningPathTestService(t)
+			created, err := svc.CreatePath(context.Background(), dto.LearningPathRequest{
+				Title: "Unidad 1",
+				Steps: []dto.LearningPathStepRequest{
+					{MaterialID: pathMaterialA, MinScore: intPtr(70)},
+					{MaterialID: pathMaterialB},
+					{MaterialID: pathMaterialC},
+				},
+			}, "teacher-1", schoolID)
+			require.NoError(t, err)
+			for _, r := range tt.results {
+				repo.results[r.MaterialID] = r
+			}
+
+			path, err := svc.GetPath(context.Background(), created.ID, PathLearner{UserID: "student-1", SchoolID: schoolID})
+			require.NoError(t, err)
+
+			states := make([]string, len(path.Steps))
+			completed := 0
+			for i, step := range path.Steps {
+				states[i] = step.State
+				if step.State == dto.PathStepCompleted {
+					completed++
+				}
+			}
+			assert.Equal(t, tt.states, states)
+			assert.Equal(t, completed, path.CompletedSteps)
+		})
+	}
+}
+
+// TestLearningPathService_GetMaterialLock verifica el bloqueo de materiales por rutas que aplican al usuario
+func TestLearningPathService_GetMaterialLock(t *testing.T) {
+	svc, repo, schoolID := newLearningPathTestService(t)
+	unitID := "880e8400-e29b-41d4-a716-446655440003"
+	_, err := svc.CreatePath(context.Background(), dto.LearningPathRequest{
+		Title:          "Unidad 1",
+		AcademicUnitID: &unitID,
+		Steps: []dto.LearningPathStepRequest{
+			{MaterialID: pathMaterialA, MinScore: intPtr(70)},
+			{MaterialID: pathMaterialB},
+		},
+	}, "teacher-1", schoolID)
+	require.NoError(t, err)
+
+	learner := PathLearner{UserID: "student-1", SchoolID: schoolID, AcademicUnitID: unitID}
+
+	lock, err := svc.GetMaterialLock(context.Background(), pathMaterialB, learner)
+	require.NoError(t, err)
+	require.NotNil(t, lock)
+	assert.Equal(t, "Unidad 1", lock.PathTitle)
+	assert.Equal(t, []string{pathMaterialA}, lock.MissingSteps)
+
+	lock, err = svc.GetMaterialLock(context.Background(), pathMaterialA, learner)
+	require.NoError(t, err)
+	assert.Nil(t, lock, "el primer paso siempre está disponible")
+
+	lock, err = svc.GetMaterialLock(context.Background(), pathMaterialC, learner)
+	require.NoError(t, err)
+	assert.Nil(t, lock, "un material fuera de rutas no se bloquea")
+
+	otherUnit := learner
+	otherUnit.AcademicUnitID = "990e8400-e29b-41d4-a716-446655440004"
+	lock, err = svc.GetMaterialLock(context.Background(), pathMaterialB, otherUnit)
+	require.NoError(t, err)
+	assert.Nil(t, lock, "la ruta solo aplica a su unidad")
+
+	repo.results[pathMaterialA] = repository.LearningPathStepResult{MaterialID: pathMaterialA, Percentage: 100, BestScore: floatPtr(72)}
+	lock, err = svc.GetMaterialLock(context.Background(), pathMaterialB, learner)
+	require.NoError(t, err)
+	assert.Nil(t, lock, "aprobar el paso anterior desbloquea el material")
+}
+
+// TestLearningPathService_OtherSchoolIsNotFound verifica que no se expongan rutas de otras escuelas
+func TestLearningPathService_OtherSchoolIsNotFound(t *testing.T) {
+	svc, _, schoolID := newLearningPathTestService(t)
+	created, err := svc.CreatePath(context.Background(), dto.LearningPathRequest{
+		Title: "Unidad 1",
+		Steps: []dto.LearningPathStepRequest{{MaterialID: pathMaterialA}},
+	}, "teacher-1", schoolID)
+	require.NoError(t, err)
+
+	otherSchool := "00000000-0000-0000-0000-000000000099"
+	_, err = svc.GetPath(context.Background(), created.ID, PathLearner{UserID: "student-1", SchoolID: otherSchool})
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeNotFound, appErr.Code)
+
+	err = svc.DeletePath(context.Background(), created.ID, otherSchool)
+	appErr, ok = errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeNotFound, appErr.Code)
+
+	paths, err := svc.ListPaths(context.Background(), PathLearner{UserID: "student-1", SchoolID: otherSchool})
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+
+	require.NoError(t, svc.DeletePath(context.Background(), created.ID, schoolID))
+	paths, err = svc.ListPaths(context.Background(), PathLearner{UserID: "student-1", SchoolID: schoolID})
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
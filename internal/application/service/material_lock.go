@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+)
+
+// ErrorCodeMaterialLocked código del error 403 de un material bloqueado por una ruta de aprendizaje
+const ErrorCodeMaterialLocked errors.ErrorCode = "MATERIAL_LOCKED"
+
+// materialLockField campo del AppError que lleva el *dto.MaterialLock
+const materialLockField = "lock"
+
+// MaterialLockChecker resuelve si una ruta de aprendizaje bloquea un material para un usuario
+// Lo implementa LearningPathService
+type MaterialLockChecker interface {
+	GetMaterialLock(ctx context.Context, materialID string, learner PathLearner) (*dto.MaterialLock, error)
+}
+
+// ensureMaterialUnlocked retorna un error MATERIAL_LOCKED (403) si el material está bloqueado para el subject
+// Quienes pueden crear materiales (docentes) no están sujetos a las rutas; un checker nil no bloquea
+func ensureMaterialUnlocked(ctx context.Context, checker MaterialLockChecker, materialID string, subject policy.Subject) error {
+	if checker == nil || subject.HasPermission(enum.PermissionMaterialsCreate) {
+		return nil
+	}
+
+	learner := PathLearner{UserID: subject.UserID, SchoolID: subject.SchoolID, AcademicUnitID: subject.AcademicUnitID}
+	lock, err := checker.GetMaterialLock(ctx, materialID, learner)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		return nil
+	}
+	return NewMaterialLockedError(lock)
+}
+
+// NewMaterialLockedError crea el error MATERIAL_LOCKED (403) con el detalle del bloqueo
+func NewMaterialLockedError(lock *dto.MaterialLock) *errors.AppError {
+	appErr := errors.NewForbiddenError(fmt.Sprintf("material is locked until the required steps of learning path %q are completed", lock.PathTitle))
+	appErr.Code = ErrorCodeMaterialLocked
+	return appErr.WithField(materialLockField, lock)
+}
+
+// MaterialLockFromError retorna el detalle del bloqueo si err es MATERIAL_LOCKED, o nil en otro caso
+func MaterialLockFromError(err error) *dto.MaterialLock {
+	appErr, ok := errors.GetAppError(err)
+	if !ok || appErr.Code != ErrorCodeMaterialLocked {
+		return nil
+	}
+	lock, _ := appErr.Fields[materialLockField].(*dto.MaterialLock)
+	return lock
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/fixtures"
+	mockPostgres "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/postgres"
+	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+)
+
+// stubLockChecker bloquea todos los materiales con lock y registra los usuarios consultados
+type stubLockChecker struct {
+	lock     *dto.MaterialLock
+	learners []PathLearner
+}
+
+func (s *stubLockChecker) GetMaterialLock(ctx context.Context, materialID string, learner PathLearner) (*dto.MaterialLock, error) {
+	s.learners = append(s.learners, learner)
+	return s.lock, nil
+}
+
+// schoolMaterials resuelve cualquier material como perteneciente a schoolID, para que material:read
+// no interfiera en los tests que no lo ejercitan
+type schoolMaterials struct {
+	repository.MaterialReader
+	schoolID uuid.UUID
+}
+
+func (r schoolMaterials) FindByID(ctx context.Context, id valueobject.MaterialID) (*pgentities.Material, error) {
+	return &pgentities.Material{ID: id.UUID().UUID, SchoolID: r.schoolID, Title: "Material"}, nil
+}
+
+func materialsInSchool(schoolID string) repository.MaterialReader {
+	return schoolMaterials{schoolID: uuid.MustParse(schoolID)}
+}
+
+func newStubLockChecker(materialID string) *stubLockChecker {
+	return &stubLockChecker{lock: &dto.MaterialLock{
+		MaterialID:   materialID,
+		PathID:       uuid.NewString(),
+		PathTitle:    "Matemáticas básicas",
+		MissingSteps: []string{uuid.NewString()},
+	}}
+}
+
+// assertMaterialLocked verifica el error 403 MATERIAL_LOCKED con el detalle del bloqueo
+func assertMaterialLocked(t *testing.T, err error, lock *dto.MaterialLock) {
+	t.Helper()
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok, "se esperaba un AppError, se obtuvo %v", err)
+	assert.Equal(t, ErrorCodeMaterialLocked, appErr.Code)
+	assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	assert.Equal(t, lock, MaterialLockFromError(err))
+}
+
+// TestEnsureMaterialUnlocked verifica el error de bloqueo, la exención docente y el checker opcional
+func TestEnsureMaterialUnlocked(t *testing.T) {
+	ctx := context.Background()
+	materialID := uuid.NewString()
+	student := policy.Subject{UserID: uuid.NewString(), SchoolID: uuid.NewString(), AcademicUnitID: uuid.NewString()}
+
+	t.Run("locked", func(t *testing.T) {
+		checker := newStubLockChecker(materialID)
+		err := ensureMaterialUnlocked(ctx, checker, materialID, student)
+		assertMaterialLocked(t, err, checker.lock)
+		assert.Equal(t, []PathLearner{{UserID: student.UserID, SchoolID: student.SchoolID, AcademicUnitID: student.AcademicUnitID}}, checker.learners)
+	})
+
+	t.Run("unlocked", func(t *testing.T) {
+		assert.NoError(t, ensureMaterialUnlocked(ctx, &stubLockChecker{}, materialID, student))
+	})
+
+	t.Run("teachers are not subject to learning paths", func(t *testing.T) {
+		checker := newStubLockChecker(materialID)
+		teacher := student
+		teacher.Permissions = []string{enum.PermissionMaterialsCreate.String()}
+		assert.NoError(t, ensureMaterialUnlocked(ctx, checker, materialID, teacher))
+		assert.Empty(t, checker.learners)
+	})
+
+	t.Run("nil checker", func(t *testing.T) {
+		assert.NoError(t, ensureMaterialUnlocked(ctx, nil, materialID, student))
+	})
+
+	t.Run("other errors carry no lock", func(t *testing.T) {
+		assert.Nil(t, MaterialLockFromError(errors.NewForbiddenError("forbidden")))
+		assert.Nil(t, MaterialLockFromError(nil))
+	})
+}
+
+// TestMaterialLock_ServicesRejectLockedMaterial verifica que todo acceso al contenido aplique el bloqueo:
+// material, versiones, resumen, evaluación e intentos (en línea y offline)
+func TestMaterialLock_ServicesRejectLockedMaterial(t *testing.T) {
+	ctx := context.Background()
+	materialID := valueobject.NewMaterialID()
+	schoolID := uuid.New()
+	subject := policy.Subject{UserID: uuid.NewString(), SchoolID: schoolID.String()}
+	checker := newStubLockChecker(materialID.String())
+
+	material := &pgentities.Material{ID: materialID.UUID().UUID, SchoolID: schoolID, Title: "Sumas"}
+	materialRepo := new(MockMaterialRepository)
+	materialRepo.On("FindByID", ctx, materialID).Return(material, nil)
+	materialRepo.On("FindByIDWithVersions", ctx, materialID).Return(material, []*pgentities.MaterialVersion{}, nil)
+	materials := NewMaterialService(materialRepo, new(MockPublisher), checker, new(MockLogger))
+
+	_, err := materials.GetMaterial(ctx, materialID.String(), subject)
+	assertMaterialLocked(t, err, checker.lock)
+
+	_, err = materials.GetMaterialWithVersions(ctx, materialID.String(), subject)
+	assertMaterialLocked(t, err, checker.lock)
+
+	// Los repositorios sin expectativas fallan si el servicio los consulta antes del bloqueo
+	summaries := NewSummaryService(new(MockSummaryRepository), materialRepo, checker, new(MockLogger))
+	_, err = summaries.GetSummary(ctx, materialID.String(), subject)
+	assertMaterialLocked(t, err, checker.lock)
+
+	attempts := NewAssessmentAttemptService(nil, nil, nil, nil, materialRepo, nil, checker, new(MockLogger))
+	studentID := uuid.MustParse(subject.UserID)
+	matID := materialID.UUID().UUID
+
+	_, err = attempts.GetAssessmentByMaterialID(ctx, matID, subject)
+	assertMaterialLocked(t, err, checker.lock)
+
+	_, err = attempts.CreateAttempt(ctx, studentID, matID, dto.CreateAttemptRequest{}, subject)
+	assertMaterialLocked(t, err, checker.lock)
+
+	_, err = attempts.CreateOfflineAttempt(ctx, studentID, matID, dto.CreateAttemptRequest{}, time.Now().Add(-time.Hour), subject)
+	assertMaterialLocked(t, err, checker.lock)
+}
+
+// progressStepResults resuelve los resultados de las rutas desde el progreso guardado
+type progressStepResults struct {
+	repository.LearningPathRepository
+	progress repository.ProgressRepository
+}
+
+func (r *progressStepResults) GetStepResults(ctx context.Context, userID string, materialIDs []string) ([]repository.LearningPathStepResult, error) {
+	uid, err := valueobject.UserIDFromString(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]repository.LearningPathStepResult, 0)
+	for _, id := range materialIDs {
+		mid, err := valueobject.MaterialIDFromString(id)
+		if err != nil {
+			return nil, err
+		}
+		progress, err := r.progress.FindByMaterialAndUser(ctx, mid, uid)
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			rows = append(rows, repository.LearningPathStepResult{MaterialID: id, Percentage: progress.Percentage})
+		}
+	}
+	return rows, nil
+}
+
+// TestMaterialLock_ProgressWritesCannotUnlockNextSteps verifica que reportar 100% sobre un material
+// bloqueado (en línea, con eventos de lectura u offline) no complete su paso ni desbloquee los siguientes
+func TestMaterialLock_ProgressWritesCannotUnlockNextSteps(t *testing.T) {
+	ctx := context.Background()
+	logger := new(MockProgressLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+	logger.On("Warn", mock.Anything, mock.Anything).Return().Maybe()
+	logger.On("Error", mock.Anything, mock.Anything).Return().Maybe()
+	publisher := new(MockPublisher)
+	publisher.On("Publish", mock.Anything, "edugo.events", mock.Anything, mock.Anything).Return(nil)
+
+	progressRepo := mockPostgres.NewMockProgressRepository()
+	paths := NewLearningPathService(
+		&progressStepResults{LearningPathRepository: mockPostgres.NewMockLearningPathRepository(), progress: progressRepo},
+		mockPostgres.NewMockMaterialRepository(),
+		logger,
+	)
+	progress := NewProgressService(progressRepo, mockPostgres.NewMockReadingEventRepository(), mockPostgres.NewMockMaterialRepository(), publisher, paths, logger)
+	sync := NewSyncService(progress, &stubAttemptService{}, progressRepo, mockPostgres.NewMockSyncOperationRepository(), logger)
+
+	// Ruta A → B → C sin nota mínima: leer el material completo completa el paso
+	schoolID := fixtures.GetDefaultMaterials()[fixtures.MaterialGuidaSumasID].SchoolID.String()
+	_, err := paths.CreatePath(ctx, dto.LearningPathRequest{
+		Title: "Unidad 1",
+		Steps: []dto.LearningPathStepRequest{
+			{MaterialID: pathMaterialA},
+			{MaterialID: pathMaterialB},
+			{MaterialID: pathMaterialC},
+		},
+	}, uuid.NewString(), schoolID)
+	require.NoError(t, err)
+
+	student := policy.Subject{UserID: uuid.NewString(), SchoolID: schoolID}
+	learner := PathLearner{UserID: student.UserID, SchoolID: schoolID}
+
+	assertLocked := func(err error) {
+		t.Helper()
+		lock := MaterialLockFromError(err)
+		require.NotNil(t, lock, "se esperaba MATERIAL_LOCKED, se obtuvo %v", err)
+		assert.Equal(t, []string{pathMaterialA}, lock.MissingSteps)
+	}
+
+	assertLocked(progress.UpdateProgress(ctx, pathMaterialB, student.UserID, schoolID, 100, 10, student))
+
+	_, err = progress.RecordReadingEvents(ctx, student.UserID, schoolID, dto.ReadingEventsRequest{
+		MaterialID: pathMaterialB,
+		Events:     pageEvents("b", 1, 10, 60),
+	}, student)
+	assertLocked(err)
+
+	offline, err := sync.Sync(ctx, student, dto.SyncRequest{Items: []dto.SyncItemDTO{
+		progressItem("offline-b", pathMaterialB, 100, 10, time.Now().Add(-time.Minute)),
+	}}, true)
+	require.NoError(t, err)
+	assert.Equal(t, dto.SyncResultRejected, offline.Results[0].Status)
+	assert.Equal(t, string(ErrorCodeMaterialLocked), offline.Results[0].Error.Code)
+
+	// Ninguna escritura completó B: C sigue bloqueado y B no tiene progreso
+	lock, err := paths.GetMaterialLock(ctx, pathMaterialC, learner)
+	require.NoError(t, err)
+	require.NotNil(t, lock)
+	assert.Equal(t, []string{pathMaterialB}, lock.MissingSteps)
+
+	matB, _ := valueobject.MaterialIDFromString(pathMaterialB)
+	userID, _ := valueobject.UserIDFromString(student.UserID)
+	stored, err := progressRepo.FindByMaterialAndUser(ctx, matB, userID)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	// Completar A desbloquea B, y entonces sí se registra su progreso
+	require.NoError(t, progress.UpdateProgress(ctx, pathMaterialA, student.UserID, schoolID, 100, 12, student))
+	require.NoError(t, progress.UpdateProgress(ctx, pathMaterialB, student.UserID, schoolID, 100, 10, student))
+	lock, err = paths.GetMaterialLock(ctx, pathMaterialC, learner)
+	require.NoError(t, err)
+	assert.Nil(t, lock)
+}
+
+// TestMaterialRead_OtherSchoolIsNotFound verifica que resumen, evaluación, intentos y progreso evalúen
+// material:read antes del bloqueo: un material de otra escuela responde 404 sin consultar las rutas
+func TestMaterialRead_OtherSchoolIsNotFound(t *testing.T) {
+	ctx := context.Background()
+	materialID := valueobject.NewMaterialID()
+	subject := policy.Subject{UserID: uuid.NewString(), SchoolID: uuid.NewString()}
+	checker := newStubLockChecker(materialID.String())
+	materials := materialsInSchool(uuid.NewString())
+
+	logger := new(MockProgressLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+	logger.On("Warn", mock.Anything, mock.Anything).Return().Maybe()
+	logger.On("Error", mock.Anything, mock.Anything).Return().Maybe()
+
+	assertNotFound := func(err error) {
+		t.Helper()
+		appErr, ok := errors.GetAppError(err)
+		require.True(t, ok, "se esperaba NOT_FOUND, se obtuvo %v", err)
+		assert.Equal(t, errors.ErrorCodeNotFound, appErr.Code)
+	}
+
+	// Los repositorios sin expectativas fallan si el servicio los consulta antes de autorizar
+	summaries := NewSummaryService(new(MockSummaryRepository), materials, checker, logger)
+	_, err := summaries.GetSummary(ctx, materialID.String(), subject)
+	assertNotFound(err)
+
+	attempts := NewAssessmentAttemptService(nil, nil, nil, nil, materials, nil, checker, logger)
+	studentID := uuid.MustParse(subject.UserID)
+	matID := materialID.UUID().UUID
+
+	_, err = attempts.GetAssessmentByMaterialID(ctx, matID, subject)
+	assertNotFound(err)
+
+	_, err = attempts.CreateAttempt(ctx, studentID, matID, dto.CreateAttemptRequest{}, subject)
+	assertNotFound(err)
+
+	_, err = attempts.CreateOfflineAttempt(ctx, studentID, matID, dto.CreateAttemptRequest{}, time.Now().Add(-time.Hour), subject)
+	assertNotFound(err)
+
+	progressRepo := mockPostgres.NewMockProgressRepository()
+	progress := NewProgressService(progressRepo, mockPostgres.NewMockReadingEventRepository(), materials, new(MockPublisher), checker, logger)
+
+	assertNotFound(progress.UpdateProgress(ctx, materialID.String(), subject.UserID, subject.SchoolID, 100, 10, subject))
+
+	_, err = progress.RecordReadingEvents(ctx, subject.UserID, subject.SchoolID, dto.ReadingEventsRequest{
+		MaterialID: materialID.String(),
+		Events:     pageEvents("x", 1, 10, 60),
+	}, subject)
+	assertNotFound(err)
+
+	assertNotFound(progress.ResetProgress(ctx, materialID.String(), subject.UserID, subject.SchoolID, subject))
+
+	sync := NewSyncService(progress, attempts, progressRepo, mockPostgres.NewMockSyncOperationRepository(), logger)
+	offline, err := sync.Sync(ctx, subject, dto.SyncRequest{Items: []dto.SyncItemDTO{
+		progressItem("offline-progress", materialID.String(), 100, 10, time.Now().Add(-time.Minute)),
+		{
+			ClientID:        "offline-attempt",
+			Type:            dto.SyncItemAttempt,
+			ClientTimestamp: time.Now().Add(-time.Minute),
+			Attempt:         &dto.SyncAttemptDTO{MaterialID: materialID.String(), CreateAttemptRequest: validAttemptRequest()},
+		},
+	}}, true)
+	require.NoError(t, err)
+	require.Len(t, offline.Results, 2)
+	for _, result := range offline.Results {
+		assert.Equal(t, dto.SyncResultRejected, result.Status, result.ClientID)
+		require.NotNil(t, result.Error, result.ClientID)
+		assert.Equal(t, string(errors.ErrorCodeNotFound), result.Error.Code, result.ClientID)
+	}
+
+	assert.Empty(t, checker.learners, "el bloqueo no debe evaluarse sobre materiales no visibles")
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
@@ -17,27 +18,34 @@ import (
 // MaterialService define las operaciones de negocio para materiales
 type MaterialService interface {
 	CreateMaterial(ctx context.Context, req dto.CreateMaterialRequest, authorID string, schoolID string) (*dto.MaterialResponse, error)
-	GetMaterial(ctx context.Context, id string) (*dto.MaterialResponse, error)
-	GetMaterialWithVersions(ctx context.Context, id string) (*dto.MaterialWithVersionsResponse, error)
+	// GetMaterial y GetMaterialWithVersions evalúan material:read; los materiales de otra escuela responden 404
+	// y los bloqueados por una ruta de aprendizaje responden 403 MATERIAL_LOCKED
+	GetMaterial(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error)
+	GetMaterialWithVersions(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialWithVersionsResponse, error)
 	NotifyUploadComplete(ctx context.Context, materialID string, req dto.UploadCompleteRequest) error
 	ListMaterials(ctx context.Context, filters repository.ListFilters) ([]*dto.MaterialResponse, error)
-	UpdateMaterial(ctx context.Context, materialID string, req dto.UpdateMaterialRequest, userID string) (*dto.MaterialResponse, error)
+	UpdateMaterial(ctx context.Context, materialID string, req dto.UpdateMaterialRequest, subject policy.Subject) (*dto.MaterialResponse, error)
 }
 
 type materialService struct {
 	materialRepo     repository.MaterialRepository
 	messagePublisher rabbitmq.Publisher
+	lockChecker      MaterialLockChecker
 	logger           logger.Logger
 }
 
+// NewMaterialService crea el servicio de materiales
+// lockChecker aplica el bloqueo de las rutas de aprendizaje a la lectura del material
 func NewMaterialService(
 	materialRepo repository.MaterialRepository,
 	messagePublisher rabbitmq.Publisher,
+	lockChecker MaterialLockChecker,
 	logger logger.Logger,
 ) MaterialService {
 	return &materialService{
 		materialRepo:     materialRepo,
 		messagePublisher: messagePublisher,
+		lockChecker:      lockChecker,
 		logger:           logger,
 	}
 }
@@ -118,7 +126,7 @@ func (s *materialService) CreateMaterial(
 	return dto.ToMaterialResponse(material), nil
 }
 
-func (s *materialService) GetMaterial(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+func (s *materialService) GetMaterial(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 	materialID, err := valueobject.MaterialIDFromString(id)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid material_id format")
@@ -129,6 +137,13 @@ func (s *materialService) GetMaterial(ctx context.Context, id string) (*dto.Mate
 		return nil, errors.NewNotFoundError("material")
 	}
 
+	if err := policy.Authorize(subject, policy.ResourceMaterial, policy.ActionRead, materialTarget(material)); err != nil {
+		return nil, err
+	}
+	if err := ensureMaterialUnlocked(ctx, s.lockChecker, id, subject); err != nil {
+		return nil, err
+	}
+
 	return dto.ToMaterialResponse(material), nil
 }
 
@@ -184,6 +199,10 @@ func (s *materialService) NotifyUploadComplete(
 			"description": material.Description,
 		},
 	}
+	if material.AcademicUnitID != nil {
+		// Permite enrutar el evento a los suscriptores de la clase (stream en tiempo real)
+		payload.Metadata["academic_unit_id"] = material.AcademicUnitID.String()
+	}
 
 	event := rabbitmq.NewMaterialUploadedEvent(payload)
 	eventJSON, err := event.ToJSON()
@@ -228,7 +247,7 @@ func (s *materialService) ListMaterials(ctx context.Context, filters repository.
 
 // GetMaterialWithVersions obtiene un material incluyendo su historial completo de versiones
 // Este método consulta el material junto con todas sus versiones en una sola operación de BD
-func (s *materialService) GetMaterialWithVersions(ctx context.Context, id string) (*dto.MaterialWithVersionsResponse, error) {
+func (s *materialService) GetMaterialWithVersions(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialWithVersionsResponse, error) {
 	// Registrar inicio de operación para medir tiempo de ejecución
 	startTime := time.Now()
 
@@ -260,6 +279,13 @@ func (s *materialService) GetMaterialWithVersions(ctx context.Context, id string
 		return nil, errors.NewNotFoundError("material")
 	}
 
+	if err := policy.Authorize(subject, policy.ResourceMaterial, policy.ActionRead, materialTarget(material)); err != nil {
+		return nil, err
+	}
+	if err := ensureMaterialUnlocked(ctx, s.lockChecker, id, subject); err != nil {
+		return nil, err
+	}
+
 	// Transformar entidades de domain a DTOs
 	response := dto.ToMaterialWithVersionsResponse(material, versions)
 
@@ -275,12 +301,12 @@ func (s *materialService) GetMaterialWithVersions(ctx context.Context, id string
 }
 
 // UpdateMaterial actualiza los campos de un material existente
-// Solo el creador del material puede actualizarlo
+// Solo el creador del material puede actualizarlo, desde un contexto de la escuela del material
 func (s *materialService) UpdateMaterial(
 	ctx context.Context,
 	materialIDStr string,
 	req dto.UpdateMaterialRequest,
-	userIDStr string,
+	subject policy.Subject,
 ) (*dto.MaterialResponse, error) {
 	// Validar request
 	if err := req.Validate(); err != nil {
@@ -294,8 +320,7 @@ func (s *materialService) UpdateMaterial(
 		return nil, errors.NewValidationError("invalid material_id format")
 	}
 
-	userID, err := valueobject.UserIDFromString(userIDStr)
-	if err != nil {
+	if _, err := valueobject.UserIDFromString(subject.UserID); err != nil {
 		return nil, errors.NewValidationError("invalid user_id format")
 	}
 
@@ -306,14 +331,14 @@ func (s *materialService) UpdateMaterial(
 		return nil, errors.NewNotFoundError("material")
 	}
 
-	// Verificar permisos: solo el creador puede actualizar
-	if material.UploadedByTeacherID.String() != userID.UUID().UUID.String() {
+	// Verificar permisos: escuela del contexto activo y creador del material
+	if err := policy.Authorize(subject, policy.ResourceMaterial, policy.ActionUpdate, materialTarget(material)); err != nil {
 		s.logger.Warn("unauthorized update attempt",
 			"material_id", materialIDStr,
 			"owner_id", material.UploadedByTeacherID.String(),
-			"user_id", userIDStr,
+			"user_id", subject.UserID,
 		)
-		return nil, errors.NewForbiddenError("only the material creator can update it")
+		return nil, err
 	}
 
 	// Aplicar cambios solo si fueron provistos
@@ -359,8 +384,38 @@ func (s *materialService) UpdateMaterial(
 
 	s.logger.Info("material updated successfully",
 		"material_id", materialIDStr,
-		"user_id", userIDStr,
+		"user_id", subject.UserID,
 	)
 
 	return dto.ToMaterialResponse(material), nil
 }
+
+// materialTarget arma el recurso de las políticas de material (autor, escuela y unidad académica)
+func materialTarget(material *pgentities.Material) policy.Target {
+	target := policy.Target{
+		OwnerID:  material.UploadedByTeacherID.String(),
+		SchoolID: material.SchoolID.String(),
+	}
+	if material.AcademicUnitID != nil {
+		target.AcademicUnitID = material.AcademicUnitID.String()
+	}
+	return target
+}
+
+// authorizeMaterialRead carga el material y evalúa material:read para el subject
+// Todo acceso al contenido del material (evaluación, intentos, resumen, progreso) pasa por aquí
+// antes del bloqueo de las rutas: un material de otra escuela responde 404, igual que uno inexistente
+func authorizeMaterialRead(ctx context.Context, materials repository.MaterialReader, materialID valueobject.MaterialID, subject policy.Subject, log logger.Logger) (*pgentities.Material, error) {
+	material, err := materials.FindByID(ctx, materialID)
+	if err != nil {
+		log.Error("failed to find material", "material_id", materialID.String(), "error", err)
+		return nil, errors.NewDatabaseError("find material", err)
+	}
+	if material == nil {
+		return nil, errors.NewNotFoundError("material")
+	}
+	if err := policy.Authorize(subject, policy.ResourceMaterial, policy.ActionRead, materialTarget(material)); err != nil {
+		return nil, err
+	}
+	return material, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
 	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
@@ -155,7 +156,7 @@ func TestMaterialService_CreateMaterial_Success(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	authorID := valueobject.NewUserID()
@@ -194,7 +195,7 @@ func TestMaterialService_CreateMaterial_ValidationError_EmptyTitle(t *testing.T)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	authorID := valueobject.NewUserID()
@@ -226,7 +227,7 @@ func TestMaterialService_CreateMaterial_ValidationError_TitleTooShort(t *testing
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	authorID := valueobject.NewUserID()
@@ -254,7 +255,7 @@ func TestMaterialService_CreateMaterial_InvalidAuthorID(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	schoolID := uuid.New()
@@ -283,7 +284,7 @@ func TestMaterialService_CreateMaterial_RepositoryError(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	authorID := valueobject.NewUserID()
@@ -326,11 +327,12 @@ func TestMaterialService_GetMaterial_Success(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
 	authorID := valueobject.NewUserID()
+	schoolID := uuid.New()
 
 	now := time.Now()
 	material := &pgentities.Material{
@@ -338,6 +340,7 @@ func TestMaterialService_GetMaterial_Success(t *testing.T) {
 		Title:               "Test Material",
 		Description:         stringPtr("Description"),
 		UploadedByTeacherID: authorID.UUID().UUID,
+		SchoolID:            schoolID,
 		Subject:             stringPtr(""),
 		FileURL:             "https://s3.url",
 		Status:              string(enum.MaterialStatusPublished),
@@ -349,7 +352,7 @@ func TestMaterialService_GetMaterial_Success(t *testing.T) {
 	mockRepo.On("FindByID", ctx, materialID).Return(material, nil)
 
 	// Act
-	result, err := service.GetMaterial(ctx, materialID.String())
+	result, err := service.GetMaterial(ctx, materialID.String(), policy.Subject{SchoolID: schoolID.String()})
 
 	// Assert
 	assert.NoError(t, err)
@@ -366,12 +369,12 @@ func TestMaterialService_GetMaterial_InvalidID(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 
 	// Act
-	result, err := service.GetMaterial(ctx, "invalid-uuid")
+	result, err := service.GetMaterial(ctx, "invalid-uuid", policy.Subject{})
 
 	// Assert
 	assert.Error(t, err)
@@ -390,7 +393,7 @@ func TestMaterialService_GetMaterial_NotFound(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
@@ -398,7 +401,7 @@ func TestMaterialService_GetMaterial_NotFound(t *testing.T) {
 	mockRepo.On("FindByID", ctx, materialID).Return(nil, nil)
 
 	// Act
-	result, err := service.GetMaterial(ctx, materialID.String())
+	result, err := service.GetMaterial(ctx, materialID.String(), policy.Subject{})
 
 	// Assert
 	assert.Error(t, err)
@@ -417,7 +420,7 @@ func TestMaterialService_GetMaterial_RepositoryError(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
@@ -426,7 +429,7 @@ func TestMaterialService_GetMaterial_RepositoryError(t *testing.T) {
 	mockRepo.On("FindByID", ctx, materialID).Return(nil, dbError)
 
 	// Act
-	result, err := service.GetMaterial(ctx, materialID.String())
+	result, err := service.GetMaterial(ctx, materialID.String(), policy.Subject{})
 
 	// Assert
 	assert.Error(t, err)
@@ -441,13 +444,79 @@ func TestMaterialService_GetMaterial_RepositoryError(t *testing.T) {
 
 // Tests para NotifyUploadComplete
 
+func TestMaterialService_GetMaterial_OtherSchoolIsConcealed(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockMaterialRepository)
+	mockPublisher := new(MockPublisher)
+	mockLogger := new(MockLogger)
+
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
+
+	ctx := context.Background()
+	materialID := valueobject.NewMaterialID()
+	material := &pgentities.Material{
+		ID:                  materialID.UUID().UUID,
+		Title:               "Test Material",
+		UploadedByTeacherID: uuid.New(),
+		SchoolID:            uuid.New(),
+	}
+
+	mockRepo.On("FindByID", ctx, materialID).Return(material, nil)
+
+	// Act
+	result, err := service.GetMaterial(ctx, materialID.String(), policy.Subject{UserID: uuid.NewString(), SchoolID: uuid.NewString()})
+
+	// Assert
+	assert.Nil(t, result)
+	appErr, ok := apperrors.GetAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, apperrors.ErrorCodeNotFound, appErr.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMaterialService_UpdateMaterial_OwnerFromOtherSchoolIsConcealed(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockMaterialRepository)
+	mockPublisher := new(MockPublisher)
+	mockLogger := new(MockLogger)
+
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
+
+	ctx := context.Background()
+	materialID := valueobject.NewMaterialID()
+	authorID := uuid.New()
+	material := &pgentities.Material{
+		ID:                  materialID.UUID().UUID,
+		Title:               "Test Material",
+		UploadedByTeacherID: authorID,
+		SchoolID:            uuid.New(),
+	}
+
+	mockRepo.On("FindByID", ctx, materialID).Return(material, nil)
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Return()
+
+	// Act: el creador actúa con un contexto activo de otra escuela
+	title := "Nuevo título"
+	subject := policy.Subject{UserID: authorID.String(), SchoolID: uuid.NewString()}
+	result, err := service.UpdateMaterial(ctx, materialID.String(), dto.UpdateMaterialRequest{Title: &title}, subject)
+
+	// Assert
+	assert.Nil(t, result)
+	appErr, ok := apperrors.GetAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, apperrors.ErrorCodeNotFound, appErr.Code)
+
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
 func TestMaterialService_NotifyUploadComplete_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockMaterialRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
@@ -494,7 +563,7 @@ func TestMaterialService_NotifyUploadComplete_ValidationError_EmptyS3Key(t *test
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
@@ -519,7 +588,7 @@ func TestMaterialService_NotifyUploadComplete_ValidationError_InvalidURL(t *test
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
@@ -544,7 +613,7 @@ func TestMaterialService_NotifyUploadComplete_InvalidMaterialID(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 
@@ -571,7 +640,7 @@ func TestMaterialService_NotifyUploadComplete_MaterialNotFound(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
@@ -604,7 +673,7 @@ func TestMaterialService_NotifyUploadComplete_UpdateError(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
@@ -656,7 +725,7 @@ func TestMaterialService_ListMaterials_Success(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	filters := repository.ListFilters{
@@ -717,7 +786,7 @@ func TestMaterialService_ListMaterials_EmptyList(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	filters := repository.ListFilters{
@@ -744,7 +813,7 @@ func TestMaterialService_ListMaterials_RepositoryError(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	filters := repository.ListFilters{
@@ -778,11 +847,12 @@ func TestMaterialService_GetMaterialWithVersions_Success_WithVersions(t *testing
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
 	authorID := valueobject.NewUserID()
+	schoolID := uuid.New()
 	changedByID := valueobject.NewUserID()
 
 	// Material de prueba
@@ -792,6 +862,7 @@ func TestMaterialService_GetMaterialWithVersions_Success_WithVersions(t *testing
 		Title:               "Test Material",
 		Description:         stringPtr("Description"),
 		UploadedByTeacherID: authorID.UUID().UUID,
+		SchoolID:            schoolID,
 		Subject:             stringPtr(""),
 		FileURL:             "https://s3.url",
 		Status:              string(enum.MaterialStatusPublished),
@@ -828,7 +899,7 @@ func TestMaterialService_GetMaterialWithVersions_Success_WithVersions(t *testing
 	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 
 	// Act
-	result, err := service.GetMaterialWithVersions(ctx, materialID.String())
+	result, err := service.GetMaterialWithVersions(ctx, materialID.String(), policy.Subject{SchoolID: schoolID.String()})
 
 	// Assert
 	assert.NoError(t, err)
@@ -848,11 +919,12 @@ func TestMaterialService_GetMaterialWithVersions_Success_WithoutVersions(t *test
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
 	authorID := valueobject.NewUserID()
+	schoolID := uuid.New()
 
 	// Material sin versiones
 	now := time.Now()
@@ -861,6 +933,7 @@ func TestMaterialService_GetMaterialWithVersions_Success_WithoutVersions(t *test
 		Title:               "Test Material",
 		Description:         stringPtr("Description"),
 		UploadedByTeacherID: authorID.UUID().UUID,
+		SchoolID:            schoolID,
 		Subject:             stringPtr(""),
 		FileURL:             "https://s3.url",
 		Status:              string(enum.MaterialStatusPublished),
@@ -876,7 +949,7 @@ func TestMaterialService_GetMaterialWithVersions_Success_WithoutVersions(t *test
 	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 
 	// Act
-	result, err := service.GetMaterialWithVersions(ctx, materialID.String())
+	result, err := service.GetMaterialWithVersions(ctx, materialID.String(), policy.Subject{SchoolID: schoolID.String()})
 
 	// Assert
 	assert.NoError(t, err)
@@ -893,7 +966,7 @@ func TestMaterialService_GetMaterialWithVersions_MaterialNotFound(t *testing.T)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
@@ -903,7 +976,7 @@ func TestMaterialService_GetMaterialWithVersions_MaterialNotFound(t *testing.T)
 	mockLogger.On("Warn", mock.Anything, mock.Anything).Return()
 
 	// Act
-	result, err := service.GetMaterialWithVersions(ctx, materialID.String())
+	result, err := service.GetMaterialWithVersions(ctx, materialID.String(), policy.Subject{})
 
 	// Assert
 	assert.Error(t, err)
@@ -923,7 +996,7 @@ func TestMaterialService_GetMaterialWithVersions_InvalidMaterialID(t *testing.T)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	invalidID := "not-a-valid-uuid"
@@ -932,7 +1005,7 @@ func TestMaterialService_GetMaterialWithVersions_InvalidMaterialID(t *testing.T)
 	mockLogger.On("Warn", mock.Anything, mock.Anything).Return()
 
 	// Act
-	result, err := service.GetMaterialWithVersions(ctx, invalidID)
+	result, err := service.GetMaterialWithVersions(ctx, invalidID, policy.Subject{})
 
 	// Assert
 	assert.Error(t, err)
@@ -953,7 +1026,7 @@ func TestMaterialService_GetMaterialWithVersions_DatabaseError(t *testing.T) {
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockLogger)
 
-	service := NewMaterialService(mockRepo, mockPublisher, mockLogger)
+	service := NewMaterialService(mockRepo, mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := valueobject.NewMaterialID()
@@ -964,7 +1037,7 @@ func TestMaterialService_GetMaterialWithVersions_DatabaseError(t *testing.T) {
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 
 	// Act
-	result, err := service.GetMaterialWithVersions(ctx, materialID.String())
+	result, err := service.GetMaterialWithVersions(ctx, materialID.String(), policy.Subject{})
 
 	// Assert
 	assert.Error(t, err)
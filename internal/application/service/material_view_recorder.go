@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+const (
+	// materialViewQueueSize visualizaciones pendientes antes de empezar a descartar
+	materialViewQueueSize = 1024
+	// materialViewWriteTimeout limita cada escritura para que un MongoDB lento no frene la cola
+	materialViewWriteTimeout = 2 * time.Second
+)
+
+// materialView visualización pendiente de registrar
+type materialView struct {
+	materialID string
+	userID     string
+	schoolID   string
+}
+
+// MaterialViewRecorder registra las visualizaciones de materiales en segundo plano
+// Record nunca bloquea la lectura del material: encola la visualización y la descarta si la
+// cola está llena (las visualizaciones son métricas, no datos del alumno). Un worker las escribe
+// con StatsService.RecordMaterialView y un timeout propio por escritura
+type MaterialViewRecorder struct {
+	stats  StatsService
+	logger logger.Logger
+
+	views    chan materialView
+	dropped  atomic.Int64
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMaterialViewRecorder crea el recorder sin iniciar; queueSize <= 0 usa materialViewQueueSize
+func NewMaterialViewRecorder(stats StatsService, queueSize int, logger logger.Logger) *MaterialViewRecorder {
+	if queueSize <= 0 {
+		queueSize = materialViewQueueSize
+	}
+	return &MaterialViewRecorder{
+		stats:  stats,
+		logger: logger,
+		views:  make(chan materialView, queueSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start inicia el worker en una goroutine
+func (r *MaterialViewRecorder) Start() {
+	go r.run()
+}
+
+// Stop detiene el worker y espera a que termine la escritura en curso
+// Las visualizaciones que quedan en la cola se descartan
+func (r *MaterialViewRecorder) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+	<-r.done
+}
+
+// Record encola la visualización; retorna false si la cola está llena y se descartó
+func (r *MaterialViewRecorder) Record(materialID, userID, schoolID string) bool {
+	select {
+	case r.views <- materialView{materialID: materialID, userID: userID, schoolID: schoolID}:
+		return true
+	default:
+		r.dropped.Add(1)
+		return false
+	}
+}
+
+// Dropped retorna cuántas visualizaciones se descartaron por cola llena desde el arranque
+func (r *MaterialViewRecorder) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+func (r *MaterialViewRecorder) run() {
+	defer close(r.done)
+
+	for {
+		select {
+		case <-r.stop:
+			if pending := len(r.views); pending > 0 {
+				r.logger.Warn("visualizaciones de materiales descartadas al detener", "pending", pending)
+			}
+			return
+		case view := <-r.views:
+			r.write(view)
+		}
+	}
+}
+
+func (r *MaterialViewRecorder) write(view materialView) {
+	ctx, cancel := context.WithTimeout(context.Background(), materialViewWriteTimeout)
+	defer cancel()
+
+	if err := r.stats.RecordMaterialView(ctx, view.materialID, view.userID, view.schoolID); err != nil {
+		r.logger.Warn("error al registrar visualización de material", "material_id", view.materialID, "error", err)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingViewStatsService registra las visualizaciones; release bloquea cada escritura hasta recibir
+type blockingViewStatsService struct {
+	StatsService
+	release chan struct{}
+
+	mu        sync.Mutex
+	views     []string
+	deadlines []bool
+}
+
+func (s *blockingViewStatsService) RecordMaterialView(ctx context.Context, materialID, userID, schoolID string) error {
+	if s.release != nil {
+		<-s.release
+	}
+	_, hasDeadline := ctx.Deadline()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.views = append(s.views, materialID+"|"+userID+"|"+schoolID)
+	s.deadlines = append(s.deadlines, hasDeadline)
+	return nil
+}
+
+func (s *blockingViewStatsService) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.views...)
+}
+
+// TestMaterialViewRecorder_WritesInBackground verifica que las visualizaciones se escriben con timeout propio
+func TestMaterialViewRecorder_WritesInBackground(t *testing.T) {
+	stats := &blockingViewStatsService{}
+	recorder := NewMaterialViewRecorder(stats, 0, new(MockLogger))
+	recorder.Start()
+	defer recorder.Stop()
+
+	assert.True(t, recorder.Record("material-1", "user-1", "school-1"))
+	assert.True(t, recorder.Record("material-2", "user-1", "school-1"))
+
+	require.Eventually(t, func() bool { return len(stats.recorded()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"material-1|user-1|school-1", "material-2|user-1|school-1"}, stats.recorded())
+	assert.Equal(t, []bool{true, true}, stats.deadlines, "cada escritura lleva su propio timeout")
+}
+
+// TestMaterialViewRecorder_DropsWhenFull verifica que Record no bloquea cuando MongoDB está lento
+func TestMaterialViewRecorder_DropsWhenFull(t *testing.T) {
+	stats := &blockingViewStatsService{release: make(chan struct{})}
+	logger := new(MockLogger)
+	logger.On("Warn", mock.Anything, mock.Anything).Return().Maybe()
+
+	recorder := NewMaterialViewRecorder(stats, 1, logger)
+	recorder.Start()
+
+	// La primera queda bloqueada en la escritura y la segunda ocupa la cola
+	require.True(t, recorder.Record("material-1", "user-1", "school-1"))
+	require.Eventually(t, func() bool { return len(recorder.views) == 0 }, time.Second, 5*time.Millisecond)
+	require.True(t, recorder.Record("material-2", "user-1", "school-1"))
+
+	start := time.Now()
+	assert.False(t, recorder.Record("material-3", "user-1", "school-1"))
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, int64(1), recorder.Dropped())
+
+	close(stats.release)
+	require.Eventually(t, func() bool { return len(stats.recorded()) == 2 }, time.Second, 5*time.Millisecond)
+	recorder.Stop()
+	assert.Equal(t, []string{"material-1|user-1|school-1", "material-2|user-1|school-1"}, stats.recorded())
+}
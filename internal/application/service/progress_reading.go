@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
+	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+const (
+	// maxReadingEventsPerBatch eventos aceptados por lote
+	maxReadingEventsPerBatch = 200
+	// maxReadingDwellSeconds tiempo máximo acreditado por evento: una pestaña olvidada no suma horas
+	maxReadingDwellSeconds = 300
+
+	// suspiciousJumpPoints subida mínima de porcentaje en un lote para evaluar si es sospechosa
+	suspiciousJumpPoints = 50
+	// minSecondsPerPercentPoint tiempo en pantalla esperado por punto de porcentaje ganado
+	minSecondsPerPercentPoint = 2
+)
+
+// RecordReadingEvents registra un lote de eventos de lectura y deriva el progreso en el servidor.
+// El porcentaje sale de la cobertura (páginas o secciones distintas, segundos de video vistos)
+// sobre el tamaño del material calculado en su procesamiento; los eventos fuera de ese tamaño
+// se rechazan. El tiempo en pantalla del lote no supera el tiempo que el servidor vio pasar
+// desde el lote anterior del usuario en el material. El progreso guardado nunca retrocede
+// salvo ResetProgress.
+// Un salto grande con poco tiempo en pantalla se marca para revisión sin rechazar el lote.
+// Un material de otra escuela responde 404 y uno bloqueado por una ruta de aprendizaje no acepta eventos.
+func (s *progressService) RecordReadingEvents(ctx context.Context, userIDStr, schoolID string, req dto.ReadingEventsRequest, subject policy.Subject) (*dto.ReadingEventsResponse, error) {
+	matID, err := valueobject.MaterialIDFromString(req.MaterialID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid material_id")
+	}
+	userID, err := valueobject.UserIDFromString(userIDStr)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id")
+	}
+	if _, err := authorizeMaterialRead(ctx, s.materialRepo, matID, subject, s.logger); err != nil {
+		return nil, err
+	}
+	if err := ensureMaterialUnlocked(ctx, s.lockChecker, req.MaterialID, subject); err != nil {
+		return nil, err
+	}
+	if len(req.Events) == 0 || len(req.Events) > maxReadingEventsPerBatch {
+		return nil, errors.NewValidationError("events must contain between 1 and 200 items")
+	}
+
+	extent, err := s.readingRepo.GetMaterialExtent(ctx, req.MaterialID)
+	if err != nil {
+		s.logger.Error("failed to get material extent", "material_id", req.MaterialID, "error", err)
+		return nil, errors.NewDatabaseError("get material extent", err)
+	}
+
+	now := s.now()
+	events, err := buildReadingEvents(req.Events, userIDStr, schoolID, req.MaterialID, *extent, now)
+	if err != nil {
+		return nil, err
+	}
+
+	lastReceived, err := s.readingRepo.LastReadingEventReceivedAt(ctx, userIDStr, req.MaterialID)
+	if err != nil {
+		s.logger.Error("failed to get last reading event", "material_id", req.MaterialID, "user_id", userIDStr, "error", err)
+		return nil, errors.NewDatabaseError("get last reading event", err)
+	}
+	batchSeconds := creditDwellSeconds(events, elapsedSeconds(lastReceived, now))
+
+	previous, err := s.progressRepo.FindByMaterialAndUser(ctx, matID, userID)
+	if err != nil {
+		s.logger.Error("failed to load progress", "material_id", req.MaterialID, "user_id", userIDStr, "error", err)
+		return nil, errors.NewDatabaseError("find progress", err)
+	}
+
+	accepted, err := s.readingRepo.AppendReadingEvents(ctx, events)
+	if err != nil {
+		s.logger.Error("failed to append reading events", "material_id", req.MaterialID, "user_id", userIDStr, "error", err)
+		return nil, errors.NewDatabaseError("append reading events", err)
+	}
+
+	coverage, err := s.readingRepo.GetReadingCoverage(ctx, userIDStr, req.MaterialID)
+	if err != nil {
+		s.logger.Error("failed to get reading coverage", "material_id", req.MaterialID, "user_id", userIDStr, "error", err)
+		return nil, errors.NewDatabaseError("get reading coverage", err)
+	}
+
+	derived := derivedReadingPercentage(coverage, *extent)
+	previousPercentage, lastPage := 0, coverage.LastPage
+	if previous != nil {
+		previousPercentage = previous.Percentage
+		if lastPage == 0 {
+			lastPage = previous.LastPage
+		}
+	}
+
+	var flags []string
+	if accepted > 0 && isSuspiciousJump(previousPercentage, derived, batchSeconds) {
+		flags = append(flags, repository.ReadingFlagRapidJump)
+		s.logger.Warn("suspicious reading progress jump",
+			"material_id", req.MaterialID,
+			"user_id", userIDStr,
+			"from", previousPercentage,
+			"to", derived,
+			"batch_seconds", batchSeconds,
+		)
+		flag := repository.ReadingFlag{
+			UserID:            userIDStr,
+			MaterialID:        req.MaterialID,
+			SchoolID:          schoolID,
+			Reason:            repository.ReadingFlagRapidJump,
+			FromPercentage:    previousPercentage,
+			ToPercentage:      derived,
+			TimeOnTaskSeconds: batchSeconds,
+			FlaggedAt:         time.Now(),
+		}
+		if err := s.readingRepo.RecordReadingFlag(ctx, flag); err != nil {
+			// La marca es informativa: no se pierde el lote por no poder guardarla
+			s.logger.Warn("failed to record reading flag", "material_id", req.MaterialID, "user_id", userIDStr, "error", err)
+		}
+	}
+
+	// El acceso es el del evento más reciente: un lote sincronizado tarde no adelanta last_accessed_at
+	lastAccess := events[0].OccurredAt
+	for _, e := range events[1:] {
+		if e.OccurredAt.After(lastAccess) {
+			lastAccess = e.OccurredAt
+		}
+	}
+
+	saved, err := s.progressRepo.Upsert(ctx, &pgentities.Progress{
+		MaterialID:     matID.UUID().UUID,
+		UserID:         userID.UUID().UUID,
+		Percentage:     derived,
+		LastPage:       lastPage,
+		Status:         progressStatus(derived),
+		LastAccessedAt: lastAccess,
+		UpdatedAt:      time.Now(),
+	})
+	if err != nil {
+		s.logger.Error("failed to upsert progress", "material_id", req.MaterialID, "user_id", userIDStr, "error", err)
+		return nil, errors.NewDatabaseError("upsert progress", err)
+	}
+
+	switch {
+	case saved.Percentage == 100 && previousPercentage < 100:
+		s.publishMaterialCompleted(ctx, saved, req.MaterialID, userIDStr, schoolID)
+	case previous == nil || saved.Percentage != previous.Percentage || saved.LastPage != previous.LastPage:
+		s.publishProgressUpdated(ctx, saved, req.MaterialID, userIDStr, schoolID)
+	}
+
+	return &dto.ReadingEventsResponse{
+		MaterialID:         req.MaterialID,
+		ProgressPercentage: saved.Percentage,
+		DerivedPercentage:  derived,
+		Status:             saved.Status,
+		LastPage:           saved.LastPage,
+		TimeOnTaskSeconds:  coverage.TimeOnTaskSeconds,
+		PagesViewed:        coverage.PagesViewed,
+		SectionsViewed:     coverage.SectionsViewed,
+		WatchedSeconds:     min(coverage.WatchedSeconds, extent.DurationSeconds),
+		Accepted:           accepted,
+		Duplicates:         len(events) - accepted,
+		Flags:              flags,
+	}, nil
+}
+
+// ResetProgress reinicia explícitamente el progreso de un usuario en un material.
+// Registra un evento reset (los eventos anteriores dejan de contar) y lleva el progreso a 0
+func (s *progressService) ResetProgress(ctx context.Context, materialID, userIDStr, schoolID string, subject policy.Subject) error {
+	matID, err := valueobject.MaterialIDFromString(materialID)
+	if err != nil {
+		return errors.NewValidationError("invalid material_id")
+	}
+	userID, err := valueobject.UserIDFromString(userIDStr)
+	if err != nil {
+		return errors.NewValidationError("invalid user_id")
+	}
+	if _, err := authorizeMaterialRead(ctx, s.materialRepo, matID, subject, s.logger); err != nil {
+		return err
+	}
+
+	now := s.now()
+	_, err = s.readingRepo.AppendReadingEvents(ctx, []repository.ReadingEvent{{
+		ClientEventID: "reset-" + uuid.NewString(),
+		UserID:        userIDStr,
+		MaterialID:    materialID,
+		SchoolID:      schoolID,
+		Kind:          repository.ReadingEventReset,
+		OccurredAt:    now,
+		ReceivedAt:    now,
+	}})
+	if err != nil {
+		s.logger.Error("failed to append reset event", "material_id", materialID, "user_id", userIDStr, "error", err)
+		return errors.NewDatabaseError("append reset event", err)
+	}
+
+	progress, err := s.progressRepo.Reset(ctx, matID, userID)
+	if err != nil {
+		s.logger.Error("failed to reset progress", "material_id", materialID, "user_id", userIDStr, "error", err)
+		return errors.NewDatabaseError("reset progress", err)
+	}
+	if progress == nil {
+		return errors.NewNotFoundError("progress")
+	}
+
+	s.logger.Info("progress reset", "material_id", materialID, "user_id", userIDStr)
+	s.publishProgressUpdated(ctx, progress, materialID, userIDStr, schoolID)
+	return nil
+}
+
+// buildReadingEvents valida el lote contra el tamaño del material y lo convierte a eventos de dominio.
+// El tiempo en pantalla de cada evento queda acotado por maxReadingDwellSeconds
+func buildReadingEvents(items []dto.ReadingEventDTO, userID, schoolID, materialID string, extent repository.ReadingExtent, now time.Time) ([]repository.ReadingEvent, error) {
+	events := make([]repository.ReadingEvent, 0, len(items))
+
+	for _, item := range items {
+		if item.ClientEventID == "" || len(item.ClientEventID) > 64 {
+			return nil, errors.NewValidationError("client_event_id is required (max 64 characters)")
+		}
+		if item.DwellSeconds < 0 {
+			return nil, errors.NewValidationError("dwell_seconds must not be negative")
+		}
+
+		event := repository.ReadingEvent{
+			ClientEventID: item.ClientEventID,
+			UserID:        userID,
+			MaterialID:    materialID,
+			SchoolID:      schoolID,
+			Kind:          item.Type,
+			DwellSeconds:  min(item.DwellSeconds, maxReadingDwellSeconds),
+			OccurredAt:    now,
+			ReceivedAt:    now,
+		}
+		if item.OccurredAt != nil {
+			if item.OccurredAt.After(now.Add(maxClientClockSkew)) {
+				return nil, errors.NewValidationError("occurred_at must not be in the future")
+			}
+			event.OccurredAt = *item.OccurredAt
+		}
+
+		switch item.Type {
+		case repository.ReadingEventPage:
+			if extent.TotalPages == 0 {
+				return nil, errors.NewValidationError("material has no pages")
+			}
+			if item.Page < 1 || item.Page > extent.TotalPages {
+				return nil, errors.NewValidationError("page is outside the material")
+			}
+			event.Page = item.Page
+		case repository.ReadingEventSection:
+			if extent.TotalSections == 0 {
+				return nil, errors.NewValidationError("material has no sections")
+			}
+			if item.SectionID == "" || len(item.SectionID) > 128 {
+				return nil, errors.NewValidationError("section_id is required (max 128 characters)")
+			}
+			event.SectionID = item.SectionID
+		case repository.ReadingEventVideo:
+			if extent.DurationSeconds == 0 {
+				return nil, errors.NewValidationError("material has no video")
+			}
+			if item.PositionSeconds < 0 || item.PositionSeconds > extent.DurationSeconds {
+				return nil, errors.NewValidationError("position_seconds is outside the video")
+			}
+			event.PositionSeconds = item.PositionSeconds
+		default:
+			return nil, errors.NewValidationError("type must be page, section or video")
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// elapsedSeconds segundos de servidor desde el último lote recibido; 0 si es el primero
+func elapsedSeconds(lastReceived *time.Time, now time.Time) int {
+	if lastReceived == nil || !now.After(*lastReceived) {
+		return 0
+	}
+	return int(now.Sub(*lastReceived) / time.Second)
+}
+
+// creditDwellSeconds reparte entre los eventos, en orden, a lo sumo budget segundos de tiempo
+// en pantalla: el cliente no puede declarar más tiempo del que transcurrió en el servidor.
+// Ajusta DwellSeconds de cada evento y retorna el total acreditado al lote
+func creditDwellSeconds(events []repository.ReadingEvent, budget int) int {
+	credited := 0
+	for i := range events {
+		events[i].DwellSeconds = min(events[i].DwellSeconds, budget-credited)
+		credited += events[i].DwellSeconds
+	}
+	return credited
+}
+
+// derivedReadingPercentage calcula el porcentaje a partir de la cobertura
+// Si el material mezcla tipos de contenido se toma el más avanzado; se redondea hacia abajo
+// para que 100 signifique contenido completo
+func derivedReadingPercentage(coverage *repository.ReadingCoverage, extent repository.ReadingExtent) int {
+	percentage := 0
+	ratio := func(done, total int) int {
+		if total <= 0 {
+			return 0
+		}
+		return min(done, total) * 100 / total
+	}
+
+	percentage = max(percentage, ratio(coverage.PagesViewed, extent.TotalPages))
+	percentage = max(percentage, ratio(coverage.SectionsViewed, extent.TotalSections))
+	percentage = max(percentage, ratio(coverage.WatchedSeconds, extent.DurationSeconds))
+	return percentage
+}
+
+// isSuspiciousJump detecta saltos grandes de porcentaje con poco tiempo en pantalla (p. ej. 0→100 en segundos)
+func isSuspiciousJump(from, to, batchSeconds int) bool {
+	gain := to - from
+	return gain >= suspiciousJumpPoints && batchSeconds < gain*minSecondsPerPercentPoint
+}
+
+// progressStatus determina el status según el porcentaje
+func progressStatus(percentage int) string {
+	switch percentage {
+	case 0:
+		return "not_started"
+	case 100:
+		return "completed"
+	default:
+		return "in_progress"
+	}
+}
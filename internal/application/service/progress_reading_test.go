@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
+	mockPostgres "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/postgres"
+	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// readingTestEnv arma un ProgressService sobre los repositorios en memoria con reloj controlado
+type readingTestEnv struct {
+	service     *progressService
+	readingRepo *extentReadingRepo
+	publisher   *MockPublisher
+	materialID  string
+	userID      string
+	schoolID    string
+	now         time.Time
+}
+
+// extentReadingRepo fija el tamaño de contenido de los materiales de cada test
+type extentReadingRepo struct {
+	repository.ReadingEventRepository
+	extents map[string]repository.ReadingExtent
+}
+
+func (r *extentReadingRepo) GetMaterialExtent(ctx context.Context, materialID string) (*repository.ReadingExtent, error) {
+	extent := r.extents[materialID]
+	return &extent, nil
+}
+
+func newReadingTestEnv(t *testing.T) *readingTestEnv {
+	t.Helper()
+
+	publisher := new(MockPublisher)
+	publisher.On("Publish", mock.Anything, "edugo.events", mock.Anything, mock.Anything).Return(nil)
+	logger := new(MockProgressLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Warn", mock.Anything, mock.Anything).Return()
+
+	readingRepo := &extentReadingRepo{
+		ReadingEventRepository: mockPostgres.NewMockReadingEventRepository(),
+		extents:                make(map[string]repository.ReadingExtent),
+	}
+	schoolID := uuid.NewString()
+	env := &readingTestEnv{
+		readingRepo: readingRepo,
+		publisher:   publisher,
+		materialID:  uuid.NewString(),
+		userID:      uuid.NewString(),
+		schoolID:    schoolID,
+		now:         time.Now(),
+	}
+	env.service = NewProgressService(mockPostgres.NewMockProgressRepository(), readingRepo, materialsInSchool(schoolID), publisher, nil, logger).(*progressService)
+	env.service.now = func() time.Time { return env.now }
+	return env
+}
+
+// subject estudiante del test, sin rutas de aprendizaje que bloqueen el material
+func (e *readingTestEnv) subject() policy.Subject {
+	return policy.Subject{UserID: e.userID, SchoolID: e.schoolID}
+}
+
+// withExtent fija el tamaño del material del test, como lo dejaría su procesamiento
+func (e *readingTestEnv) withExtent(extent repository.ReadingExtent) *readingTestEnv {
+	e.readingRepo.extents[e.materialID] = extent
+	return e
+}
+
+// previousBatchAgo simula un lote anterior del usuario recibido hace d (un reinicio, sin cobertura):
+// el siguiente lote puede acreditar hasta d de tiempo en pantalla
+func (e *readingTestEnv) previousBatchAgo(t *testing.T, d time.Duration) {
+	t.Helper()
+	_, err := e.readingRepo.AppendReadingEvents(context.Background(), []repository.ReadingEvent{{
+		ClientEventID: "previous-" + uuid.NewString(),
+		UserID:        e.userID,
+		MaterialID:    e.materialID,
+		Kind:          repository.ReadingEventReset,
+		OccurredAt:    e.now.Add(-d),
+		ReceivedAt:    e.now.Add(-d),
+	}})
+	require.NoError(t, err)
+}
+
+func (e *readingTestEnv) record(t *testing.T, req dto.ReadingEventsRequest) *dto.ReadingEventsResponse {
+	t.Helper()
+	req.MaterialID = e.materialID
+	result, err := e.service.RecordReadingEvents(context.Background(), e.userID, e.schoolID, req, e.subject())
+	require.NoError(t, err)
+	return result
+}
+
+// pageEvents genera eventos de página consecutivos con el mismo tiempo en pantalla
+func pageEvents(prefix string, from, to, dwell int) []dto.ReadingEventDTO {
+	events := make([]dto.ReadingEventDTO, 0, to-from+1)
+	for page := from; page <= to; page++ {
+		events = append(events, dto.ReadingEventDTO{
+			ClientEventID: prefix + "-" + uuid.NewString()[:8],
+			Type:          repository.ReadingEventPage,
+			Page:          page,
+			DwellSeconds:  dwell,
+		})
+	}
+	return events
+}
+
+func (e *readingTestEnv) publishedRoutingKeys() []string {
+	var keys []string
+	for _, call := range e.publisher.Calls {
+		keys = append(keys, call.Arguments.String(2))
+	}
+	return keys
+}
+
+// TestRecordReadingEvents_DerivesPercentageFromDistinctPages verifica porcentaje, tiempo en pantalla e idempotencia
+func TestRecordReadingEvents_DerivesPercentageFromDistinctPages(t *testing.T) {
+	env := newReadingTestEnv(t).withExtent(repository.ReadingExtent{TotalPages: 40})
+	env.previousBatchAgo(t, time.Hour)
+
+	events := pageEvents("a", 1, 10, 60)
+	events = append(events, dto.ReadingEventDTO{ClientEventID: "reread", Type: "page", Page: 3, DwellSeconds: 30})
+	result := env.record(t, dto.ReadingEventsRequest{Events: events})
+
+	assert.Equal(t, 25, result.DerivedPercentage, "10 páginas distintas de 40")
+	assert.Equal(t, 25, result.ProgressPercentage)
+	assert.Equal(t, "in_progress", result.Status)
+	assert.Equal(t, 10, result.PagesViewed)
+	assert.Equal(t, 630, result.TimeOnTaskSeconds)
+	assert.Equal(t, 11, result.Accepted)
+	assert.Empty(t, result.Flags)
+
+	// Reenvío del mismo lote (reintento del cliente): nada nuevo
+	again := env.record(t, dto.ReadingEventsRequest{Events: events})
+	assert.Equal(t, 0, again.Accepted)
+	assert.Equal(t, 11, again.Duplicates)
+	assert.Equal(t, 630, again.TimeOnTaskSeconds)
+
+	assert.Equal(t, []string{"progress.updated"}, env.publishedRoutingKeys(), "sin cambios no se vuelve a publicar")
+}
+
+// TestRecordReadingEvents_FlagsRapidJump verifica la marca de saltos 0→100 en segundos
+func TestRecordReadingEvents_FlagsRapidJump(t *testing.T) {
+	env := newReadingTestEnv(t).withExtent(repository.ReadingExtent{TotalPages: 50})
+
+	result := env.record(t, dto.ReadingEventsRequest{Events: pageEvents("skim", 1, 50, 0)})
+
+	assert.Equal(t, 100, result.ProgressPercentage)
+	assert.Equal(t, "completed", result.Status)
+	assert.Equal(t, []string{repository.ReadingFlagRapidJump}, result.Flags)
+	assert.Equal(t, []string{"material.completed"}, env.publishedRoutingKeys())
+}
+
+// TestRecordReadingEvents_ClaimedDwellBoundedByServerTime verifica que declarar el tope de tiempo
+// por evento no evite la marca: solo cuenta el tiempo que transcurrió en el servidor
+func TestRecordReadingEvents_ClaimedDwellBoundedByServerTime(t *testing.T) {
+	env := newReadingTestEnv(t).withExtent(repository.ReadingExtent{TotalPages: 50})
+	env.previousBatchAgo(t, 20*time.Second)
+
+	result := env.record(t, dto.ReadingEventsRequest{Events: pageEvents("skim", 1, 50, maxReadingDwellSeconds)})
+
+	assert.Equal(t, 100, result.ProgressPercentage)
+	assert.Equal(t, 20, result.TimeOnTaskSeconds, "50 eventos de 300s declarados en 20s reales")
+	assert.Equal(t, []string{repository.ReadingFlagRapidJump}, result.Flags)
+
+	// El primer lote de otro usuario no tiene referencia: no acredita tiempo
+	otherID := uuid.NewString()
+	other, err := env.service.RecordReadingEvents(context.Background(), otherID, env.schoolID, dto.ReadingEventsRequest{
+		MaterialID: env.materialID,
+		Events:     pageEvents("other", 1, 50, maxReadingDwellSeconds),
+	}, policy.Subject{UserID: otherID, SchoolID: env.schoolID})
+	require.NoError(t, err)
+	assert.Equal(t, 0, other.TimeOnTaskSeconds)
+	assert.Equal(t, []string{repository.ReadingFlagRapidJump}, other.Flags)
+}
+
+// TestRecordReadingEvents_StaysMonotonicUntilReset verifica que el progreso no baje salvo reinicio explícito
+func TestRecordReadingEvents_StaysMonotonicUntilReset(t *testing.T) {
+	env := newReadingTestEnv(t).withExtent(repository.ReadingExtent{TotalPages: 100})
+	ctx := context.Background()
+
+	// El cliente reportó 60% con el endpoint clásico
+	require.NoError(t, env.service.UpdateProgress(ctx, env.materialID, env.userID, env.schoolID, 60, 30, env.subject()))
+	env.previousBatchAgo(t, time.Hour)
+
+	result := env.record(t, dto.ReadingEventsRequest{Events: pageEvents("a", 1, 10, 30)})
+	assert.Equal(t, 10, result.DerivedPercentage)
+	assert.Equal(t, 60, result.ProgressPercentage, "el progreso guardado no retrocede")
+
+	require.NoError(t, env.service.ResetProgress(ctx, env.materialID, env.userID, env.schoolID, env.subject()))
+	env.now = env.now.Add(time.Hour)
+
+	result = env.record(t, dto.ReadingEventsRequest{Events: pageEvents("b", 1, 5, 30)})
+	assert.Equal(t, 5, result.PagesViewed, "los eventos previos al reinicio no cuentan")
+	assert.Equal(t, 5, result.ProgressPercentage)
+	assert.Equal(t, 150, result.TimeOnTaskSeconds)
+}
+
+// TestRecordReadingEvents_VideoCoverage verifica la cobertura por tramos y el tope de tiempo por evento
+func TestRecordReadingEvents_VideoCoverage(t *testing.T) {
+	env := newReadingTestEnv(t).withExtent(repository.ReadingExtent{DurationSeconds: 200})
+	env.previousBatchAgo(t, time.Hour)
+
+	result := env.record(t, dto.ReadingEventsRequest{
+		Events: []dto.ReadingEventDTO{
+			{ClientEventID: "v1", Type: "video", PositionSeconds: 30, DwellSeconds: 30},
+			{ClientEventID: "v2", Type: "video", PositionSeconds: 60, DwellSeconds: 30},
+			{ClientEventID: "v3", Type: "video", PositionSeconds: 40, DwellSeconds: 20}, // repaso: ya cubierto
+			{ClientEventID: "v4", Type: "video", PositionSeconds: 150, DwellSeconds: 0}, // salto sin reproducir
+			{ClientEventID: "v5", Type: "video", PositionSeconds: 100, DwellSeconds: 3600},
+		},
+	})
+
+	assert.Equal(t, 100, result.WatchedSeconds, "0-60 más 0-100 acotado por el tope de tiempo por evento")
+	assert.Equal(t, 50, result.DerivedPercentage)
+	assert.Equal(t, 30+30+20+maxReadingDwellSeconds, result.TimeOnTaskSeconds)
+}
+
+// TestRecordReadingEvents_UsesMaterialExtent verifica que el tamaño sea el del material y que
+// los eventos fuera de él se rechacen
+func TestRecordReadingEvents_UsesMaterialExtent(t *testing.T) {
+	env := newReadingTestEnv(t).withExtent(repository.ReadingExtent{TotalPages: 10})
+	ctx := context.Background()
+
+	// Un cliente que cree que el material tiene más páginas no puede registrarlas
+	_, err := env.service.RecordReadingEvents(ctx, env.userID, env.schoolID, dto.ReadingEventsRequest{
+		MaterialID: env.materialID,
+		Events:     pageEvents("inflated", 11, 11, 60),
+	}, env.subject())
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok, "expected AppError, got %v", err)
+	assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+
+	result := env.record(t, dto.ReadingEventsRequest{Events: pageEvents("a", 1, 5, 60)})
+	assert.Equal(t, 50, result.DerivedPercentage, "5 de las 10 páginas del material")
+
+	// Un material sin tamaño calculado no acepta eventos
+	_, err = env.service.RecordReadingEvents(ctx, env.userID, env.schoolID, dto.ReadingEventsRequest{
+		MaterialID: uuid.NewString(),
+		Events:     pageEvents("b", 1, 1, 60),
+	}, env.subject())
+	appErr, ok = errors.GetAppError(err)
+	require.True(t, ok, "expected AppError, got %v", err)
+	assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+}
+
+// TestRecordReadingEvents_ValidationErrors verifica el rechazo de lotes inválidos
+func TestRecordReadingEvents_ValidationErrors(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	pages := repository.ReadingExtent{TotalPages: 5}
+
+	tests := []struct {
+		name   string
+		extent repository.ReadingExtent
+		req    dto.ReadingEventsRequest
+	}{
+		{"material size unknown", repository.ReadingExtent{}, dto.ReadingEventsRequest{Events: pageEvents("a", 1, 1, 10)}},
+		{"page out of range", pages, dto.ReadingEventsRequest{Events: pageEvents("a", 6, 6, 10)}},
+		{"video on a paged material", pages, dto.ReadingEventsRequest{Events: []dto.ReadingEventDTO{{ClientEventID: "x", Type: "video", PositionSeconds: 10}}}},
+		{"missing client event id", pages, dto.ReadingEventsRequest{Events: []dto.ReadingEventDTO{{Type: "page", Page: 1}}}},
+		{"negative dwell", pages, dto.ReadingEventsRequest{Events: []dto.ReadingEventDTO{{ClientEventID: "x", Type: "page", Page: 1, DwellSeconds: -1}}}},
+		{"section without id", repository.ReadingExtent{TotalSections: 3}, dto.ReadingEventsRequest{Events: []dto.ReadingEventDTO{{ClientEventID: "x", Type: "section"}}}},
+		{"video position past duration", repository.ReadingExtent{DurationSeconds: 60}, dto.ReadingEventsRequest{Events: []dto.ReadingEventDTO{{ClientEventID: "x", Type: "video", PositionSeconds: 61}}}},
+		{"future event", pages, dto.ReadingEventsRequest{Events: []dto.ReadingEventDTO{{ClientEventID: "x", Type: "page", Page: 1, OccurredAt: &future}}}},
+		{"unknown type", pages, dto.ReadingEventsRequest{Events: []dto.ReadingEventDTO{{ClientEventID: "x", Type: "scroll"}}}},
+		{"empty batch", pages, dto.ReadingEventsRequest{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newReadingTestEnv(t).withExtent(tt.extent)
+			tt.req.MaterialID = env.materialID
+
+			_, err := env.service.RecordReadingEvents(context.Background(), env.userID, env.schoolID, tt.req, env.subject())
+
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok, "expected AppError, got %v", err)
+			assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+			assert.Empty(t, env.publisher.Calls)
+		})
+	}
+}
+
+// TestResetProgress_NotFound verifica el reinicio sin progreso previo
+func TestResetProgress_NotFound(t *testing.T) {
+	env := newReadingTestEnv(t)
+
+	err := env.service.ResetProgress(context.Background(), env.materialID, env.userID, env.schoolID, env.subject())
+
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeNotFound, appErr.Code)
+}
+
+// TestUpdateProgress_LowerValueAfterCompletionDoesNotRepublish verifica que un reporte atrasado no repita material.completed
+func TestUpdateProgress_LowerValueAfterCompletionDoesNotRepublish(t *testing.T) {
+	mockRepo := new(MockProgressRepository)
+	mockPublisher := new(MockPublisher)
+	mockLogger := new(MockProgressLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
+
+	ctx := context.Background()
+	materialID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "660e8400-e29b-41d4-a716-446655440001"
+	matID, _ := valueobject.MaterialIDFromString(materialID)
+	uID, _ := valueobject.UserIDFromString(userID)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Upsert", ctx, mock.Anything).Return(&pgentities.Progress{
+		MaterialID: matID.UUID().UUID,
+		UserID:     uID.UUID().UUID,
+		Percentage: 100,
+		LastPage:   12,
+		Status:     "completed",
+		UpdatedAt:  time.Now(),
+	}, nil)
+	mockPublisher.On("Publish", ctx, "edugo.events", "progress.updated", mock.Anything).Return(nil)
+
+	err := service.UpdateProgress(ctx, materialID, userID, "", 40, 12, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
+
+	assert.NoError(t, err)
+	mockPublisher.AssertNotCalled(t, "Publish", ctx, "edugo.events", "material.completed", mock.Anything)
+	mockPublisher.AssertExpectations(t)
+}
+
+// TestDerivedReadingPercentage verifica el cálculo de porcentaje por tipo de contenido
+func TestDerivedReadingPercentage(t *testing.T) {
+	tests := []struct {
+		name     string
+		coverage repository.ReadingCoverage
+		extent   repository.ReadingExtent
+		want     int
+	}{
+		{"unknown size", repository.ReadingCoverage{PagesViewed: 10}, repository.ReadingExtent{}, 0},
+		{"pages rounded down", repository.ReadingCoverage{PagesViewed: 2}, repository.ReadingExtent{TotalPages: 3}, 66},
+		{"all pages", repository.ReadingCoverage{PagesViewed: 3}, repository.ReadingExtent{TotalPages: 3}, 100},
+		{"sections", repository.ReadingCoverage{SectionsViewed: 1}, repository.ReadingExtent{TotalSections: 4}, 25},
+		{"video last bucket past duration", repository.ReadingCoverage{WatchedSeconds: 70}, repository.ReadingExtent{DurationSeconds: 65}, 100},
+		{"mixed content takes furthest",
+			repository.ReadingCoverage{PagesViewed: 1, WatchedSeconds: 30},
+			repository.ReadingExtent{TotalPages: 10, DurationSeconds: 60}, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, derivedReadingPercentage(&tt.coverage, tt.extent))
+		})
+	}
+}
+
+// TestCreditDwellSeconds verifica el reparto del tiempo observado por el servidor entre los eventos
+func TestCreditDwellSeconds(t *testing.T) {
+	events := []repository.ReadingEvent{{DwellSeconds: 60}, {DwellSeconds: 30}, {DwellSeconds: 45}}
+
+	assert.Equal(t, 100, creditDwellSeconds(events, 100))
+	assert.Equal(t, []int{60, 30, 10}, []int{events[0].DwellSeconds, events[1].DwellSeconds, events[2].DwellSeconds})
+
+	assert.Equal(t, 0, creditDwellSeconds(events, 0))
+	assert.Equal(t, 135, creditDwellSeconds([]repository.ReadingEvent{{DwellSeconds: 60}, {DwellSeconds: 30}, {DwellSeconds: 45}}, 3600))
+}
+
+// TestIsSuspiciousJump verifica el umbral de saltos sospechosos
+func TestIsSuspiciousJump(t *testing.T) {
+	tests := []struct {
+		name              string
+		from, to, seconds int
+		want              bool
+	}{
+		{"0 to 100 in seconds", 0, 100, 10, true},
+		{"0 to 100 with enough time", 0, 100, 200, false},
+		{"small gain", 0, 40, 0, false},
+		{"jump from 40 to 95", 40, 95, 60, true},
+		{"no gain", 100, 100, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isSuspiciousJump(tt.from, tt.to, tt.seconds))
+		})
+	}
+}
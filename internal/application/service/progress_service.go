@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
@@ -13,27 +15,72 @@ import (
 )
 
 type ProgressService interface {
-	UpdateProgress(ctx context.Context, materialID string, userID string, schoolID string, percentage int, lastPage int) error
+	// UpdateProgress evalúa material:read (un material de otra escuela responde 404) y aplica el
+	// bloqueo de las rutas de aprendizaje para subject (quien escribe); igual RecordReadingEvents,
+	// ResetProgress y MergeProgress
+	UpdateProgress(ctx context.Context, materialID string, userID string, schoolID string, percentage int, lastPage int, subject policy.Subject) error
+	// ListUserProgress lista los materiales iniciados por el usuario, más recientes primero
+	ListUserProgress(ctx context.Context, userID string, status string, limit, offset int) (*dto.UserProgressListResponse, error)
+	// RecordReadingEvents registra eventos de lectura y deriva porcentaje y tiempo en pantalla
+	RecordReadingEvents(ctx context.Context, userID, schoolID string, req dto.ReadingEventsRequest, subject policy.Subject) (*dto.ReadingEventsResponse, error)
+	// ResetProgress reinicia el progreso de un usuario en un material (única forma de que baje)
+	ResetProgress(ctx context.Context, materialID, userID, schoolID string, subject policy.Subject) error
+	// MergeProgress aplica un progreso con hora de acceso del cliente (sincronización offline)
+	MergeProgress(ctx context.Context, materialID, userID, schoolID string, percentage, lastPage int, accessedAt time.Time, subject policy.Subject) (*dto.ProgressStateDTO, error)
 }
 
 type progressService struct {
 	progressRepo repository.ProgressRepository
+	readingRepo  repository.ReadingEventRepository
+	materialRepo repository.MaterialReader
 	publisher    rabbitmq.Publisher
+	lockChecker  MaterialLockChecker
 	logger       logger.Logger
+	now          func() time.Time
 }
 
-func NewProgressService(progressRepo repository.ProgressRepository, publisher rabbitmq.Publisher, logger logger.Logger) ProgressService {
+// NewProgressService crea el servicio de progreso
+// materialRepo resuelve la escuela del material para material:read; lockChecker aplica el bloqueo de las rutas de aprendizaje a las escrituras de progreso: sin él,
+// reportar 100% en un material bloqueado completaría su paso y desbloquearía los siguientes
+func NewProgressService(progressRepo repository.ProgressRepository, readingRepo repository.ReadingEventRepository, materialRepo repository.MaterialReader, publisher rabbitmq.Publisher, lockChecker MaterialLockChecker, logger logger.Logger) ProgressService {
 	return &progressService{
 		progressRepo: progressRepo,
+		readingRepo:  readingRepo,
+		materialRepo: materialRepo,
 		publisher:    publisher,
+		lockChecker:  lockChecker,
 		logger:       logger,
+		now:          time.Now,
 	}
 }
 
 // UpdateProgress actualiza el progreso de un usuario en un material de forma idempotente.
 // Usa operación UPSERT para evitar duplicados y simplificar lógica de cliente.
-// Si progress=100, se publica evento "material_completed" a RabbitMQ.
-func (s *progressService) UpdateProgress(ctx context.Context, materialID string, userIDStr string, schoolID string, percentage int, lastPage int) error {
+// Si progress=100, se publica evento "material_completed" a RabbitMQ;
+// en cualquier otro caso se publica "progress.updated" (stream en tiempo real).
+// El porcentaje guardado nunca retrocede: un valor menor solo actualiza last_page.
+func (s *progressService) UpdateProgress(ctx context.Context, materialID string, userIDStr string, schoolID string, percentage int, lastPage int, subject policy.Subject) error {
+	_, err := s.applyProgress(ctx, materialID, userIDStr, schoolID, percentage, lastPage, time.Now(), subject)
+	return err
+}
+
+// MergeProgress aplica un progreso reportado por el cliente con su propia hora de acceso.
+// El porcentaje se combina por máximo y last_page por last-writer-wins según accessedAt,
+// de modo que reportes offline atrasados no pisan lo que otro dispositivo ya guardó
+func (s *progressService) MergeProgress(ctx context.Context, materialID, userID, schoolID string, percentage, lastPage int, accessedAt time.Time, subject policy.Subject) (*dto.ProgressStateDTO, error) {
+	if lastPage < 0 {
+		return nil, errors.NewValidationError("last_page must not be negative")
+	}
+
+	progress, err := s.applyProgress(ctx, materialID, userID, schoolID, percentage, lastPage, accessedAt, subject)
+	if err != nil {
+		return nil, err
+	}
+	return progressStateDTO(progress), nil
+}
+
+// applyProgress valida, guarda y publica el progreso; accessedAt es la hora de acceso informada
+func (s *progressService) applyProgress(ctx context.Context, materialID string, userIDStr string, schoolID string, percentage int, lastPage int, accessedAt time.Time, subject policy.Subject) (*pgentities.Progress, error) {
 	startTime := time.Now()
 
 	// Logging de entrada con contexto
@@ -50,34 +97,34 @@ func (s *progressService) UpdateProgress(ctx context.Context, materialID string,
 			"percentage", percentage,
 			"user_id", userIDStr,
 		)
-		return errors.NewValidationError("percentage must be between 0 and 100")
+		return nil, errors.NewValidationError("percentage must be between 0 and 100")
 	}
 
 	// Validar materialID
 	matID, err := valueobject.MaterialIDFromString(materialID)
 	if err != nil {
 		s.logger.Error("invalid material_id", "error", err)
-		return errors.NewValidationError("invalid material_id")
+		return nil, errors.NewValidationError("invalid material_id")
 	}
 
 	// Validar userID
 	userID, err := valueobject.UserIDFromString(userIDStr)
 	if err != nil {
 		s.logger.Error("invalid user_id", "error", err)
-		return errors.NewValidationError("invalid user_id")
+		return nil, errors.NewValidationError("invalid user_id")
 	}
 
-	// Determinar status basado en porcentaje
-	var status string
-	switch percentage {
-	case 0:
-		status = "not_started"
-	case 100:
-		status = "completed"
-	default:
-		status = "in_progress"
+	// Un material de otra escuela o bloqueado por una ruta no acumula progreso
+	if _, err := authorizeMaterialRead(ctx, s.materialRepo, matID, subject, s.logger); err != nil {
+		return nil, err
+	}
+	if err := ensureMaterialUnlocked(ctx, s.lockChecker, materialID, subject); err != nil {
+		return nil, err
 	}
 
+	// Determinar status basado en porcentaje
+	status := progressStatus(percentage)
+
 	// Crear nueva entidad Progress con valores actualizados
 	progress := &pgentities.Progress{
 		MaterialID:     matID.UUID().UUID,
@@ -85,7 +132,7 @@ func (s *progressService) UpdateProgress(ctx context.Context, materialID string,
 		Percentage:     percentage,
 		LastPage:       lastPage,
 		Status:         status,
-		LastAccessedAt: time.Now(),
+		LastAccessedAt: accessedAt,
 		UpdatedAt:      time.Now(),
 	}
 
@@ -97,51 +144,16 @@ func (s *progressService) UpdateProgress(ctx context.Context, materialID string,
 			"material_id", materialID,
 			"user_id", userIDStr,
 		)
-		return errors.NewDatabaseError("upsert progress", err)
+		return nil, errors.NewDatabaseError("upsert progress", err)
 	}
 
 	// Verificar si material fue completado (progress = 100)
-	isCompleted := updatedProgress.Percentage == 100
+	// Un reporte menor sobre un material ya completado no vuelve a publicar la finalización
+	isCompleted := updatedProgress.Percentage == 100 && percentage == 100
 	if isCompleted {
-		s.logger.Info("material completed by user",
-			"material_id", materialID,
-			"user_id", userIDStr,
-			"completed_at", updatedProgress.UpdatedAt,
-		)
-
-		// Publicar evento "material.completed" a RabbitMQ
-		payload := rabbitmq.MaterialCompletedPayload{
-			MaterialID:  materialID,
-			SchoolID:    schoolID,
-			UserID:      userIDStr,
-			CompletedAt: updatedProgress.UpdatedAt,
-		}
-		event := rabbitmq.NewMaterialCompletedEvent(payload)
-		eventJSON, err := event.ToJSON()
-		if err != nil {
-			s.logger.Error("failed to serialize material.completed event",
-				"error", err,
-				"material_id", materialID,
-				"user_id", userIDStr,
-			)
-			// No retornamos error para no afectar el flujo principal
-			// El progreso ya fue actualizado exitosamente
-		} else {
-			if err := s.publisher.Publish(ctx, "edugo.events", "material.completed", eventJSON); err != nil {
-				s.logger.Error("failed to publish material.completed event",
-					"error", err,
-					"material_id", materialID,
-					"user_id", userIDStr,
-				)
-				// No retornamos error - el progreso ya fue guardado
-			} else {
-				s.logger.Info("material.completed event published",
-					"material_id", materialID,
-					"user_id", userIDStr,
-					"event_id", event.EventID,
-				)
-			}
-		}
+		s.publishMaterialCompleted(ctx, updatedProgress, materialID, userIDStr, schoolID)
+	} else {
+		s.publishProgressUpdated(ctx, updatedProgress, materialID, userIDStr, schoolID)
 	}
 
 	// Logging de éxito con métricas de performance
@@ -154,5 +166,139 @@ func (s *progressService) UpdateProgress(ctx context.Context, materialID string,
 		"elapsed_ms", elapsed,
 	)
 
-	return nil
+	return updatedProgress, nil
+}
+
+// publishMaterialCompleted publica el evento material.completed sin afectar el flujo principal
+func (s *progressService) publishMaterialCompleted(ctx context.Context, progress *pgentities.Progress, materialID, userID, schoolID string) {
+	s.logger.Info("material completed by user",
+		"material_id", materialID,
+		"user_id", userID,
+		"completed_at", progress.UpdatedAt,
+	)
+
+	// Publicar evento "material.completed" a RabbitMQ
+	payload := rabbitmq.MaterialCompletedPayload{
+		MaterialID:  materialID,
+		SchoolID:    schoolID,
+		UserID:      userID,
+		CompletedAt: progress.UpdatedAt,
+	}
+	event := rabbitmq.NewMaterialCompletedEvent(payload)
+	eventJSON, err := event.ToJSON()
+	if err != nil {
+		s.logger.Error("failed to serialize material.completed event",
+			"error", err,
+			"material_id", materialID,
+			"user_id", userID,
+		)
+		// No retornamos error para no afectar el flujo principal
+		// El progreso ya fue actualizado exitosamente
+		return
+	}
+
+	if err := s.publisher.Publish(ctx, "edugo.events", "material.completed", eventJSON); err != nil {
+		s.logger.Error("failed to publish material.completed event",
+			"error", err,
+			"material_id", materialID,
+			"user_id", userID,
+		)
+		// No retornamos error - el progreso ya fue guardado
+		return
+	}
+
+	s.logger.Info("material.completed event published",
+		"material_id", materialID,
+		"user_id", userID,
+		"event_id", event.EventID,
+	)
+}
+
+// publishProgressUpdated publica el evento progress.updated sin afectar el flujo principal
+func (s *progressService) publishProgressUpdated(ctx context.Context, progress *pgentities.Progress, materialID, userID, schoolID string) {
+	event := rabbitmq.NewProgressUpdatedEvent(rabbitmq.ProgressUpdatedPayload{
+		MaterialID: materialID,
+		SchoolID:   schoolID,
+		UserID:     userID,
+		Percentage: progress.Percentage,
+		LastPage:   progress.LastPage,
+		Status:     progress.Status,
+		UpdatedAt:  progress.UpdatedAt,
+	})
+	eventJSON, err := event.ToJSON()
+	if err != nil {
+		s.logger.Warn("failed to serialize progress.updated event",
+			"error", err,
+			"material_id", materialID,
+			"user_id", userID,
+		)
+		return
+	}
+
+	if err := s.publisher.Publish(ctx, "edugo.events", "progress.updated", eventJSON); err != nil {
+		s.logger.Warn("failed to publish progress.updated event",
+			"error", err,
+			"material_id", materialID,
+			"user_id", userID,
+		)
+	}
+}
+
+// progressStateDTO convierte la entidad al estado expuesto en la sincronización
+func progressStateDTO(p *pgentities.Progress) *dto.ProgressStateDTO {
+	return &dto.ProgressStateDTO{
+		MaterialID:         p.MaterialID.String(),
+		ProgressPercentage: p.Percentage,
+		LastPage:           p.LastPage,
+		Status:             p.Status,
+		LastAccessedAt:     p.LastAccessedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
+
+// ListUserProgress lista el progreso del usuario en todos los materiales iniciados
+// status filtra por "in_progress" o "completed" ("" = todos)
+func (s *progressService) ListUserProgress(ctx context.Context, userIDStr string, status string, limit, offset int) (*dto.UserProgressListResponse, error) {
+	userID, err := valueobject.UserIDFromString(userIDStr)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id")
+	}
+
+	switch status {
+	case "", repository.UserProgressStatusInProgress, repository.UserProgressStatusCompleted:
+	default:
+		return nil, errors.NewValidationError("status must be in_progress or completed")
+	}
+
+	items, total, err := s.progressRepo.ListByUser(ctx, userID, repository.UserProgressFilter{
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		s.logger.Error("failed to list user progress", "user_id", userIDStr, "error", err)
+		return nil, errors.NewDatabaseError("list user progress", err)
+	}
+
+	response := &dto.UserProgressListResponse{
+		Items:      make([]dto.UserProgressDTO, 0, len(items)),
+		TotalCount: total,
+		Limit:      limit,
+		Offset:     offset,
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, dto.UserProgressDTO{
+			MaterialID:         item.MaterialID,
+			MaterialTitle:      item.MaterialTitle,
+			ProgressPercentage: item.Percentage,
+			LastPage:           item.LastPage,
+			Status:             item.Status,
+			LastAccessedAt:     item.LastAccessedAt,
+			AttemptsCount:      item.AttemptsCount,
+			BestScore:          item.BestScore,
+			Passed:             item.Passed,
+		})
+	}
+
+	return response, nil
 }
@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
 	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/logger"
@@ -44,6 +47,22 @@ func (m *MockProgressRepository) Upsert(ctx context.Context, progress *pgentitie
 	return args.Get(0).(*pgentities.Progress), args.Error(1)
 }
 
+func (m *MockProgressRepository) ListChangedSince(ctx context.Context, userID valueobject.UserID, after repository.ProgressCursor, limit int) ([]*pgentities.Progress, error) {
+	args := m.Called(ctx, userID, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*pgentities.Progress), args.Error(1)
+}
+
+func (m *MockProgressRepository) Reset(ctx context.Context, materialID valueobject.MaterialID, userID valueobject.UserID) (*pgentities.Progress, error) {
+	args := m.Called(ctx, materialID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pgentities.Progress), args.Error(1)
+}
+
 func (m *MockProgressRepository) CountActiveUsers(ctx context.Context) (int64, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(int64), args.Error(1)
@@ -54,6 +73,22 @@ func (m *MockProgressRepository) CalculateAverageProgress(ctx context.Context) (
 	return args.Get(0).(float64), args.Error(1)
 }
 
+func (m *MockProgressRepository) ListByUser(ctx context.Context, userID valueobject.UserID, filter repository.UserProgressFilter) ([]*repository.UserMaterialProgress, int64, error) {
+	args := m.Called(ctx, userID, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*repository.UserMaterialProgress), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProgressRepository) GetMaterialProgressStats(ctx context.Context, materialID valueobject.MaterialID) (*repository.MaterialProgressStats, error) {
+	args := m.Called(ctx, materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.MaterialProgressStats), args.Error(1)
+}
+
 // MockLogger es un mock del logger
 type MockProgressLogger struct {
 	mock.Mock
@@ -92,13 +127,16 @@ func (m *MockProgressLogger) With(keysAndValues ...interface{}) logger.Logger {
 // MockPublisher ya está definido en material_service_test.go
 // Se reutiliza para evitar duplicación
 
+// progressTestSchoolID escuela del estudiante y de los materiales de los tests de progreso
+const progressTestSchoolID = "770e8400-e29b-41d4-a716-446655440002"
+
 // TestUpdateProgress_Success_ValidProgress prueba actualización exitosa con progreso válido
 func TestUpdateProgress_Success_ValidProgress(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -126,10 +164,11 @@ func TestUpdateProgress_Success_ValidProgress(t *testing.T) {
 	mockLogger.On("Info", "updating progress", mock.Anything).Return()
 	mockRepo.On("Upsert", ctx, mock.Anything).
 		Return(&expectedProgress, nil)
+	mockPublisher.On("Publish", ctx, "edugo.events", "progress.updated", mock.Anything).Return(nil)
 	mockLogger.On("Info", "progress updated successfully", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.NoError(t, err)
@@ -137,13 +176,73 @@ func TestUpdateProgress_Success_ValidProgress(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 }
 
+// TestUpdateProgress_PublishesProgressUpdatedEvent verifica el evento progress.updated para progreso parcial
+func TestUpdateProgress_PublishesProgressUpdatedEvent(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockProgressRepository)
+	mockPublisher := new(MockPublisher)
+	mockLogger := new(MockProgressLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
+
+	ctx := context.Background()
+	materialID := "550e8400-e29b-41d4-a716-446655440000"
+	userID := "660e8400-e29b-41d4-a716-446655440001"
+	schoolID := "770e8400-e29b-41d4-a716-446655440002"
+
+	matID, _ := valueobject.MaterialIDFromString(materialID)
+	uID, _ := valueobject.UserIDFromString(userID)
+	savedProgress := pgentities.Progress{
+		MaterialID: matID.UUID().UUID,
+		UserID:     uID.UUID().UUID,
+		Percentage: 40,
+		LastPage:   8,
+		Status:     "in_progress",
+		UpdatedAt:  time.Now(),
+	}
+
+	mockLogger.On("Info", "updating progress", mock.Anything).Return()
+	mockRepo.On("Upsert", ctx, mock.Anything).Return(&savedProgress, nil)
+	mockPublisher.On("Publish", ctx, "edugo.events", "progress.updated", mock.MatchedBy(func(body []byte) bool {
+		var event struct {
+			EventType string `json:"event_type"`
+			Payload   struct {
+				MaterialID string `json:"material_id"`
+				SchoolID   string `json:"school_id"`
+				UserID     string `json:"user_id"`
+				Percentage int    `json:"percentage"`
+				LastPage   int    `json:"last_page"`
+				Status     string `json:"status"`
+			} `json:"payload"`
+		}
+		if err := json.Unmarshal(body, &event); err != nil {
+			return false
+		}
+		return event.EventType == "progress.updated" &&
+			event.Payload.MaterialID == materialID &&
+			event.Payload.SchoolID == schoolID &&
+			event.Payload.UserID == userID &&
+			event.Payload.Percentage == 40 &&
+			event.Payload.LastPage == 8 &&
+			event.Payload.Status == "in_progress"
+	})).Return(nil)
+	mockLogger.On("Info", "progress updated successfully", mock.Anything).Return()
+
+	// Act
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, 40, 8, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
+
+	// Assert
+	assert.NoError(t, err)
+	mockPublisher.AssertExpectations(t)
+	mockPublisher.AssertNotCalled(t, "Publish", ctx, "edugo.events", "material.completed", mock.Anything)
+}
+
 // TestUpdateProgress_Success_CompletedMaterial prueba completar material (percentage = 100)
 func TestUpdateProgress_Success_CompletedMaterial(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -177,7 +276,7 @@ func TestUpdateProgress_Success_CompletedMaterial(t *testing.T) {
 	mockLogger.On("Info", "progress updated successfully", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.NoError(t, err)
@@ -193,7 +292,7 @@ func TestUpdateProgress_Error_InvalidPercentageNegative(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -207,7 +306,7 @@ func TestUpdateProgress_Error_InvalidPercentageNegative(t *testing.T) {
 	mockLogger.On("Warn", "invalid percentage value", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.Error(t, err)
@@ -222,7 +321,7 @@ func TestUpdateProgress_Error_InvalidPercentageOver100(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -236,7 +335,7 @@ func TestUpdateProgress_Error_InvalidPercentageOver100(t *testing.T) {
 	mockLogger.On("Warn", "invalid percentage value", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.Error(t, err)
@@ -251,7 +350,7 @@ func TestUpdateProgress_Error_InvalidMaterialID(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "invalid-uuid"
@@ -265,7 +364,7 @@ func TestUpdateProgress_Error_InvalidMaterialID(t *testing.T) {
 	mockLogger.On("Error", "invalid material_id", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.Error(t, err)
@@ -280,7 +379,7 @@ func TestUpdateProgress_Error_InvalidUserID(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -294,7 +393,7 @@ func TestUpdateProgress_Error_InvalidUserID(t *testing.T) {
 	mockLogger.On("Error", "invalid user_id", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.Error(t, err)
@@ -309,7 +408,7 @@ func TestUpdateProgress_Error_DatabaseError(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -325,7 +424,7 @@ func TestUpdateProgress_Error_DatabaseError(t *testing.T) {
 	mockLogger.On("Error", "failed to upsert progress", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.Error(t, err)
@@ -340,7 +439,7 @@ func TestUpdateProgress_Idempotency_MultipleCallsSameProgress(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -368,12 +467,13 @@ func TestUpdateProgress_Idempotency_MultipleCallsSameProgress(t *testing.T) {
 	mockLogger.On("Info", "updating progress", mock.Anything).Return().Times(3)
 	mockRepo.On("Upsert", ctx, mock.Anything).
 		Return(&expectedProgress, nil).Times(3)
+	mockPublisher.On("Publish", ctx, "edugo.events", "progress.updated", mock.Anything).Return(nil)
 	mockLogger.On("Info", "progress updated successfully", mock.Anything).Return().Times(3)
 
 	// Act - Llamar UpdateProgress 3 veces con mismos parámetros
-	err1 := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
-	err2 := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
-	err3 := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err1 := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
+	err2 := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
+	err3 := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.NoError(t, err1)
@@ -391,7 +491,7 @@ func TestUpdateProgress_Idempotency_DifferentPercentages(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -432,6 +532,8 @@ func TestUpdateProgress_Idempotency_DifferentPercentages(t *testing.T) {
 			mockLogger.On("Info", "material completed by user", mock.Anything).Return().Once()
 			mockPublisher.On("Publish", ctx, "edugo.events", "material.completed", mock.Anything).Return(nil).Once()
 			mockLogger.On("Info", "material.completed event published", mock.Anything).Return().Once()
+		} else {
+			mockPublisher.On("Publish", ctx, "edugo.events", "progress.updated", mock.Anything).Return(nil).Once()
 		}
 
 		mockLogger.On("Info", "progress updated successfully", mock.Anything).Return().Once()
@@ -439,7 +541,7 @@ func TestUpdateProgress_Idempotency_DifferentPercentages(t *testing.T) {
 
 	// Act - Actualizar progreso incrementalmente
 	for _, p := range percentages {
-		err := service.UpdateProgress(ctx, materialID, userID, schoolID, p, p/5)
+		err := service.UpdateProgress(ctx, materialID, userID, schoolID, p, p/5, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 		assert.NoError(t, err)
 	}
 
@@ -457,7 +559,7 @@ func TestUpdateProgress_EdgeCase_ZeroPercentage(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -485,10 +587,11 @@ func TestUpdateProgress_EdgeCase_ZeroPercentage(t *testing.T) {
 	mockLogger.On("Info", "updating progress", mock.Anything).Return()
 	mockRepo.On("Upsert", ctx, mock.Anything).
 		Return(&expectedProgress, nil)
+	mockPublisher.On("Publish", ctx, "edugo.events", "progress.updated", mock.Anything).Return(nil)
 	mockLogger.On("Info", "progress updated successfully", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.NoError(t, err)
@@ -503,7 +606,7 @@ func TestUpdateProgress_EdgeCase_ExactlyOneHundredPercentage(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -537,7 +640,7 @@ func TestUpdateProgress_EdgeCase_ExactlyOneHundredPercentage(t *testing.T) {
 	mockLogger.On("Info", "progress updated successfully", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.NoError(t, err)
@@ -554,7 +657,7 @@ func TestUpdateProgress_EdgeCase_BoundaryPercentageOne(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -582,10 +685,11 @@ func TestUpdateProgress_EdgeCase_BoundaryPercentageOne(t *testing.T) {
 	mockLogger.On("Info", "updating progress", mock.Anything).Return()
 	mockRepo.On("Upsert", ctx, mock.Anything).
 		Return(&expectedProgress, nil)
+	mockPublisher.On("Publish", ctx, "edugo.events", "progress.updated", mock.Anything).Return(nil)
 	mockLogger.On("Info", "progress updated successfully", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.NoError(t, err)
@@ -600,7 +704,7 @@ func TestUpdateProgress_EdgeCase_BoundaryPercentageNinetyNine(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -628,10 +732,11 @@ func TestUpdateProgress_EdgeCase_BoundaryPercentageNinetyNine(t *testing.T) {
 	mockLogger.On("Info", "updating progress", mock.Anything).Return()
 	mockRepo.On("Upsert", ctx, mock.Anything).
 		Return(&expectedProgress, nil)
+	mockPublisher.On("Publish", ctx, "edugo.events", "progress.updated", mock.Anything).Return(nil)
 	mockLogger.On("Info", "progress updated successfully", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.NoError(t, err)
@@ -650,7 +755,7 @@ func TestUpdateProgress_EdgeCase_NegativeLastPage(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -668,7 +773,7 @@ func TestUpdateProgress_EdgeCase_NegativeLastPage(t *testing.T) {
 	mockLogger.On("Error", "failed to update progress entity", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.Error(t, err)
@@ -682,7 +787,7 @@ func TestUpdateProgress_EdgeCase_EmptyMaterialID(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := ""
@@ -696,7 +801,7 @@ func TestUpdateProgress_EdgeCase_EmptyMaterialID(t *testing.T) {
 	mockLogger.On("Error", "invalid material_id", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.Error(t, err)
@@ -711,7 +816,7 @@ func TestUpdateProgress_EdgeCase_EmptyUserID(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -725,7 +830,7 @@ func TestUpdateProgress_EdgeCase_EmptyUserID(t *testing.T) {
 	mockLogger.On("Error", "invalid user_id", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.Error(t, err)
@@ -740,7 +845,7 @@ func TestUpdateProgress_EdgeCase_VeryLargeLastPage(t *testing.T) {
 	mockRepo := new(MockProgressRepository)
 	mockPublisher := new(MockPublisher)
 	mockLogger := new(MockProgressLogger)
-	service := NewProgressService(mockRepo, mockPublisher, mockLogger)
+	service := NewProgressService(mockRepo, nil, materialsInSchool(progressTestSchoolID), mockPublisher, nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -768,10 +873,11 @@ func TestUpdateProgress_EdgeCase_VeryLargeLastPage(t *testing.T) {
 	mockLogger.On("Info", "updating progress", mock.Anything).Return()
 	mockRepo.On("Upsert", ctx, mock.Anything).
 		Return(&expectedProgress, nil)
+	mockPublisher.On("Publish", ctx, "edugo.events", "progress.updated", mock.Anything).Return(nil)
 	mockLogger.On("Info", "progress updated successfully", mock.Anything).Return()
 
 	// Act
-	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage)
+	err := service.UpdateProgress(ctx, materialID, userID, schoolID, percentage, lastPage, policy.Subject{UserID: userID, SchoolID: progressTestSchoolID})
 
 	// Assert
 	assert.NoError(t, err)
@@ -779,3 +885,87 @@ func TestUpdateProgress_EdgeCase_VeryLargeLastPage(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
+
+// TestListUserProgress_MapsRepositoryResults valida el filtro enviado al repositorio y el mapeo a DTO
+func TestListUserProgress_MapsRepositoryResults(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockProgressRepository)
+	mockLogger := new(MockProgressLogger)
+	service := NewProgressService(mockRepo, nil, nil, new(MockPublisher), nil, mockLogger)
+
+	ctx := context.Background()
+	userID := "660e8400-e29b-41d4-a716-446655440001"
+	uID, _ := valueobject.UserIDFromString(userID)
+	bestScore := 72.5
+	passed := true
+	lastAccessed := time.Now()
+
+	mockRepo.On("ListByUser", ctx, uID, repository.UserProgressFilter{Status: "in_progress", Limit: 10, Offset: 20}).
+		Return([]*repository.UserMaterialProgress{
+			{
+				MaterialID:     "550e8400-e29b-41d4-a716-446655440000",
+				MaterialTitle:  "Álgebra",
+				Percentage:     40,
+				LastPage:       12,
+				Status:         "in_progress",
+				LastAccessedAt: lastAccessed,
+				AttemptsCount:  1,
+				BestScore:      &bestScore,
+				Passed:         &passed,
+			},
+			{MaterialID: "770e8400-e29b-41d4-a716-446655440002", Percentage: 10, Status: "in_progress"},
+		}, int64(22), nil)
+
+	// Act
+	result, err := service.ListUserProgress(ctx, userID, "in_progress", 10, 20)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(22), result.TotalCount)
+	assert.Equal(t, 10, result.Limit)
+	assert.Equal(t, 20, result.Offset)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, "Álgebra", result.Items[0].MaterialTitle)
+	assert.Equal(t, 40, result.Items[0].ProgressPercentage)
+	assert.Equal(t, lastAccessed, result.Items[0].LastAccessedAt)
+	assert.Equal(t, &bestScore, result.Items[0].BestScore)
+	assert.Nil(t, result.Items[1].BestScore, "sin intentos no hay mejor puntaje")
+	mockRepo.AssertExpectations(t)
+}
+
+// TestListUserProgress_InvalidInput valida user_id y filtro de estado
+func TestListUserProgress_InvalidInput(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockProgressRepository)
+	service := NewProgressService(mockRepo, nil, nil, new(MockPublisher), nil, new(MockProgressLogger))
+	ctx := context.Background()
+
+	// Act
+	_, errUser := service.ListUserProgress(ctx, "invalid-uuid", "", 10, 0)
+	_, errStatus := service.ListUserProgress(ctx, "660e8400-e29b-41d4-a716-446655440001", "archived", 10, 0)
+
+	// Assert
+	assert.Error(t, errUser)
+	assert.Contains(t, errStatus.Error(), "status must be")
+	mockRepo.AssertNotCalled(t, "ListByUser")
+}
+
+// TestListUserProgress_RepositoryError valida el mapeo a error de base de datos
+func TestListUserProgress_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockProgressRepository)
+	mockLogger := new(MockProgressLogger)
+	service := NewProgressService(mockRepo, nil, nil, new(MockPublisher), nil, mockLogger)
+	ctx := context.Background()
+
+	mockRepo.On("ListByUser", ctx, mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("connection refused"))
+	mockLogger.On("Error", "failed to list user progress", mock.Anything).Return()
+
+	// Act
+	result, err := service.ListUserProgress(ctx, "660e8400-e29b-41d4-a716-446655440001", "", 10, 0)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockLogger.AssertExpectations(t)
+}
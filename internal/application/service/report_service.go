@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// ReportService genera reportes docentes por unidad académica
+type ReportService interface {
+	// GetUnitProgressReport arma el libro de calificaciones de una unidad académica
+	// schoolID es la escuela del contexto activo; una unidad de otra escuela se trata como inexistente
+	GetUnitProgressReport(ctx context.Context, academicUnitID string, subject policy.Subject) (*dto.UnitProgressReport, error)
+}
+
+type reportService struct {
+	reportRepo repository.UnitReportRepository
+	logger     logger.Logger
+}
+
+// NewReportService crea el servicio de reportes
+func NewReportService(reportRepo repository.UnitReportRepository, logger logger.Logger) ReportService {
+	return &reportService{
+		reportRepo: reportRepo,
+		logger:     logger,
+	}
+}
+
+func (s *reportService) GetUnitProgressReport(ctx context.Context, academicUnitID string, subject policy.Subject) (*dto.UnitProgressReport, error) {
+	if _, err := uuid.Parse(academicUnitID); err != nil {
+		return nil, errors.NewValidationError("invalid academic_unit_id")
+	}
+
+	gradebook, err := s.reportRepo.GetUnitGradebook(ctx, academicUnitID)
+	if err != nil {
+		s.logger.Error("failed to load unit gradebook", "academic_unit_id", academicUnitID, "error", err)
+		return nil, errors.NewDatabaseError("get unit gradebook", err)
+	}
+	if gradebook == nil {
+		return nil, errors.NewNotFoundError("academic unit")
+	}
+	// Unidades de otras escuelas responden 404; otras unidades sin stats:school, 403
+	if err := policy.Authorize(subject, policy.ResourceUnitReport, policy.ActionRead, policy.Target{
+		SchoolID:       gradebook.SchoolID,
+		AcademicUnitID: gradebook.AcademicUnitID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return buildUnitProgressReport(gradebook), nil
+}
+
+// buildUnitProgressReport cruza estudiantes × materiales y calcula promedios por fila y columna
+// Un estudiante sin actividad en un material cuenta como 0% de progreso
+func buildUnitProgressReport(gradebook *repository.UnitGradebook) *dto.UnitProgressReport {
+	type resultKey struct{ student, material string }
+	results := make(map[resultKey]repository.UnitReportResult, len(gradebook.Results))
+	for _, r := range gradebook.Results {
+		results[resultKey{r.StudentID, r.MaterialID}] = r
+	}
+
+	report := &dto.UnitProgressReport{
+		AcademicUnitID:   gradebook.AcademicUnitID,
+		AcademicUnitName: gradebook.AcademicUnitName,
+		Materials:        make([]dto.ReportMaterialDTO, len(gradebook.Materials)),
+		Students:         make([]dto.StudentReportDTO, 0, len(gradebook.Students)),
+		GeneratedAt:      time.Now(),
+	}
+
+	materialProgress := make([]int, len(gradebook.Materials))
+	materialScores := make([]float64, len(gradebook.Materials))
+	materialScored := make([]int, len(gradebook.Materials))
+	for i, m := range gradebook.Materials {
+		report.Materials[i] = dto.ReportMaterialDTO{MaterialID: m.ID, Title: m.Title, HasAssessment: m.HasAssessment}
+	}
+
+	for _, student := range gradebook.Students {
+		row := dto.StudentReportDTO{
+			StudentID: student.ID,
+			FirstName: student.FirstName,
+			LastName:  student.LastName,
+			Email:     student.Email,
+			Materials: make([]dto.StudentMaterialResultDTO, len(gradebook.Materials)),
+		}
+
+		progressTotal := 0
+		scoreTotal, scored := 0.0, 0
+		for i, material := range gradebook.Materials {
+			cell := dto.StudentMaterialResultDTO{MaterialID: material.ID}
+			if r, ok := results[resultKey{student.ID, material.ID}]; ok {
+				cell.ProgressPercentage = r.Percentage
+				cell.LastAccessedAt = r.LastAccessedAt
+				cell.AttemptsCount = r.AttemptsCount
+				cell.BestScore = r.BestScore
+				if r.BestScore != nil {
+					passed := *r.BestScore >= float64(r.PassThreshold)
+					cell.Passed = &passed
+				}
+			}
+			row.Materials[i] = cell
+
+			progressTotal += cell.ProgressPercentage
+			materialProgress[i] += cell.ProgressPercentage
+			if cell.ProgressPercentage > 0 || cell.AttemptsCount > 0 {
+				report.Materials[i].StartedCount++
+			}
+			if cell.ProgressPercentage == 100 {
+				row.CompletedMaterials++
+				report.Materials[i].CompletedCount++
+			}
+			if cell.BestScore != nil {
+				scoreTotal += *cell.BestScore
+				scored++
+				materialScores[i] += *cell.BestScore
+				materialScored[i]++
+				if *cell.Passed {
+					report.Materials[i].PassedCount++
+				}
+			}
+		}
+
+		if len(gradebook.Materials) > 0 {
+			row.AvgProgress = float64(progressTotal) / float64(len(gradebook.Materials))
+		}
+		row.AvgBestScore = average(scoreTotal, scored)
+		report.Students = append(report.Students, row)
+	}
+
+	for i := range report.Materials {
+		if len(gradebook.Students) > 0 {
+			report.Materials[i].AvgProgress = float64(materialProgress[i]) / float64(len(gradebook.Students))
+		}
+		report.Materials[i].AvgBestScore = average(materialScores[i], materialScored[i])
+	}
+
+	return report
+}
+
+// average retorna total/count o nil si no hay datos
+func average(total float64, count int) *float64 {
+	if count == 0 {
+		return nil
+	}
+	avg := total / float64(count)
+	return &avg
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// MockUnitReportRepository es un mock del repositorio de reportes por unidad
+type MockUnitReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockUnitReportRepository) GetUnitGradebook(ctx context.Context, academicUnitID string) (*repository.UnitGradebook, error) {
+	args := m.Called(ctx, academicUnitID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UnitGradebook), args.Error(1)
+}
+
+const (
+	reportTestUnitID   = "880e8400-e29b-41d4-a716-446655440003"
+	reportTestSchoolID = "770e8400-e29b-41d4-a716-446655440002"
+)
+
+// reportTestTeacher docente con la unidad del reporte como contexto activo
+var reportTestTeacher = policy.Subject{
+	UserID:         "660e8400-e29b-41d4-a716-446655440001",
+	SchoolID:       reportTestSchoolID,
+	AcademicUnitID: reportTestUnitID,
+	Permissions:    []string{"stats:unit"},
+}
+
+func sampleGradebook() *repository.UnitGradebook {
+	best1, best2 := 90.0, 50.0
+	accessed := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	return &repository.UnitGradebook{
+		AcademicUnitID:   reportTestUnitID,
+		AcademicUnitName: "5to A",
+		SchoolID:         reportTestSchoolID,
+		Materials: []repository.UnitReportMaterial{
+			{ID: "m1", Title: "Fotosíntesis", HasAssessment: true},
+			{ID: "m2", Title: "Células"},
+		},
+		Students: []repository.UnitReportStudent{
+			{ID: "s1", FirstName: "Ana", LastName: "Pérez"},
+			{ID: "s2", FirstName: "Luis", LastName: "Gómez"},
+			{ID: "s3", FirstName: "Eva", LastName: "Ruiz"},
+		},
+		Results: []repository.UnitReportResult{
+			{StudentID: "s1", MaterialID: "m1", Percentage: 100, LastAccessedAt: &accessed, AttemptsCount: 2, BestScore: &best1, PassThreshold: 70},
+			{StudentID: "s1", MaterialID: "m2", Percentage: 40},
+			{StudentID: "s2", MaterialID: "m1", Percentage: 60, AttemptsCount: 1, BestScore: &best2, PassThreshold: 70},
+		},
+	}
+}
+
+// TestReportService_GetUnitProgressReport_Success verifica el cruce estudiantes × materiales y los promedios
+func TestReportService_GetUnitProgressReport_Success(t *testing.T) {
+	repo := new(MockUnitReportRepository)
+	repo.On("GetUnitGradebook", mock.Anything, reportTestUnitID).Return(sampleGradebook(), nil)
+	svc := NewReportService(repo, new(MockLogger))
+
+	report, err := svc.GetUnitProgressReport(context.Background(), reportTestUnitID, reportTestTeacher)
+	require.NoError(t, err)
+
+	assert.Equal(t, "5to A", report.AcademicUnitName)
+	require.Len(t, report.Materials, 2)
+	require.Len(t, report.Students, 3)
+
+	// Columna material m1: dos estudiantes iniciaron, uno completó y aprobó
+	m1 := report.Materials[0]
+	assert.Equal(t, 2, m1.StartedCount)
+	assert.Equal(t, 1, m1.CompletedCount)
+	assert.Equal(t, 1, m1.PassedCount)
+	assert.InDelta(t, 160.0/3, m1.AvgProgress, 0.001)
+	require.NotNil(t, m1.AvgBestScore)
+	assert.InDelta(t, 70.0, *m1.AvgBestScore, 0.001)
+
+	// Columna material m2: sin evaluaciones
+	assert.Nil(t, report.Materials[1].AvgBestScore)
+	assert.Equal(t, 1, report.Materials[1].StartedCount)
+
+	// Fila del estudiante s1 alineada al orden de materiales
+	s1 := report.Students[0]
+	require.Len(t, s1.Materials, 2)
+	assert.Equal(t, "m1", s1.Materials[0].MaterialID)
+	require.NotNil(t, s1.Materials[0].Passed)
+	assert.True(t, *s1.Materials[0].Passed)
+	assert.Equal(t, 1, s1.CompletedMaterials)
+	assert.InDelta(t, 70.0, s1.AvgProgress, 0.001)
+
+	// s2 reprobó; s3 sin actividad queda en cero
+	require.NotNil(t, report.Students[1].Materials[0].Passed)
+	assert.False(t, *report.Students[1].Materials[0].Passed)
+	s3 := report.Students[2]
+	assert.Zero(t, s3.AvgProgress)
+	assert.Nil(t, s3.AvgBestScore)
+	assert.Nil(t, s3.Materials[0].Passed)
+}
+
+// TestReportService_GetUnitProgressReport_InvalidID verifica la validación del ID
+func TestReportService_GetUnitProgressReport_InvalidID(t *testing.T) {
+	repo := new(MockUnitReportRepository)
+	svc := NewReportService(repo, new(MockLogger))
+
+	_, err := svc.GetUnitProgressReport(context.Background(), "not-a-uuid", reportTestTeacher)
+
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+	repo.AssertNotCalled(t, "GetUnitGradebook", mock.Anything, mock.Anything)
+}
+
+// TestReportService_GetUnitProgressReport_NotFound verifica unidades inexistentes o de otra escuela
+func TestReportService_GetUnitProgressReport_NotFound(t *testing.T) {
+	tests := []struct {
+		name      string
+		gradebook *repository.UnitGradebook
+		schoolID  string
+	}{
+		{name: "unidad inexistente", gradebook: nil, schoolID: reportTestSchoolID},
+		{name: "unidad de otra escuela", gradebook: sampleGradebook(), schoolID: "aa0e8400-e29b-41d4-a716-446655440000"},
+		{name: "sin contexto activo", gradebook: sampleGradebook(), schoolID: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockUnitReportRepository)
+			repo.On("GetUnitGradebook", mock.Anything, reportTestUnitID).Return(tt.gradebook, nil)
+			svc := NewReportService(repo, new(MockLogger))
+
+			_, err := svc.GetUnitProgressReport(context.Background(), reportTestUnitID, policy.Subject{SchoolID: tt.schoolID})
+
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, errors.ErrorCodeNotFound, appErr.Code)
+		})
+	}
+}
+
+// TestReportService_GetUnitProgressReport_OtherUnit verifica el alcance por unidad académica activa
+func TestReportService_GetUnitProgressReport_OtherUnit(t *testing.T) {
+	teacher := reportTestTeacher
+	teacher.AcademicUnitID = "990e8400-e29b-41d4-a716-446655440009"
+	coordinator := teacher
+	coordinator.Permissions = []string{"stats:unit", "stats:school"}
+
+	tests := []struct {
+		name     string
+		subject  policy.Subject
+		wantCode errors.ErrorCode
+	}{
+		{name: "sin stats:school es forbidden", subject: teacher, wantCode: errors.ErrorCodeForbidden},
+		{name: "con stats:school se permite", subject: coordinator},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockUnitReportRepository)
+			repo.On("GetUnitGradebook", mock.Anything, reportTestUnitID).Return(sampleGradebook(), nil)
+			svc := NewReportService(repo, new(MockLogger))
+
+			report, err := svc.GetUnitProgressReport(context.Background(), reportTestUnitID, tt.subject)
+
+			if tt.wantCode == "" {
+				require.NoError(t, err)
+				assert.Equal(t, reportTestUnitID, report.AcademicUnitID)
+				return
+			}
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+		})
+	}
+}
+
+// TestReportService_GetUnitProgressReport_DatabaseError verifica el mapeo de errores del repositorio
+func TestReportService_GetUnitProgressReport_DatabaseError(t *testing.T) {
+	repo := new(MockUnitReportRepository)
+	repo.On("GetUnitGradebook", mock.Anything, reportTestUnitID).Return(nil, stderrors.New("connection refused"))
+	logger := new(MockLogger)
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+	svc := NewReportService(repo, logger)
+
+	_, err := svc.GetUnitProgressReport(context.Background(), reportTestUnitID, reportTestTeacher)
+
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeDatabaseError, appErr.Code)
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repositories"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	mongoRepo "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mongodb/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// Parámetros del algoritmo SM-2
+const (
+	initialEaseFactor = 2.5
+	minEaseFactor     = 1.3
+	minRecallGrade    = 3 // Calificaciones menores cuentan como olvido
+	maxRecallGrade    = 5
+
+	// firstReviewDelay demora del primer repaso: el estudiante acaba de ver la corrección o el material
+	firstReviewDelay = 24 * time.Hour
+
+	defaultReviewQueueLimit = 20
+	maxReviewQueueLimit     = 100
+)
+
+// ReviewService gestiona la cola de repaso espaciado de cada estudiante
+// Se alimenta de preguntas falladas (assessment.attempt.completed) y del glosario
+// de los materiales completados (material.completed)
+type ReviewService interface {
+	// GetReviewQueue retorna los ítems vencidos, del más atrasado al más reciente
+	GetReviewQueue(ctx context.Context, userID string, limit int) (*dto.ReviewQueueResponse, error)
+
+	// GradeReview registra la calificación de recuerdo (0-5) y reprograma el ítem
+	GradeReview(ctx context.Context, userID, itemID string, grade int) (*dto.ReviewItemDTO, error)
+
+	// HandleAttemptCompleted encola las preguntas respondidas incorrectamente en un intento
+	HandleAttemptCompleted(ctx context.Context, event eventbus.Event) error
+
+	// HandleMaterialCompleted encola los términos del glosario del material completado
+	HandleMaterialCompleted(ctx context.Context, event eventbus.Event) error
+}
+
+type reviewService struct {
+	reviewRepo     repository.ReviewItemRepository
+	answerRepo     repositories.AnswerRepository
+	assessmentRepo repositories.AssessmentRepository
+	documentRepo   mongoRepo.AssessmentDocumentRepository
+	summaryRepo    repository.SummaryReader
+	logger         logger.Logger
+	now            func() time.Time
+}
+
+// NewReviewService crea el servicio de repaso espaciado
+func NewReviewService(
+	reviewRepo repository.ReviewItemRepository,
+	answerRepo repositories.AnswerRepository,
+	assessmentRepo repositories.AssessmentRepository,
+	documentRepo mongoRepo.AssessmentDocumentRepository,
+	summaryRepo repository.SummaryReader,
+	logger logger.Logger,
+) ReviewService {
+	return &reviewService{
+		reviewRepo:     reviewRepo,
+		answerRepo:     answerRepo,
+		assessmentRepo: assessmentRepo,
+		documentRepo:   documentRepo,
+		summaryRepo:    summaryRepo,
+		logger:         logger,
+		now:            time.Now,
+	}
+}
+
+func (s *reviewService) GetReviewQueue(ctx context.Context, userID string, limit int) (*dto.ReviewQueueResponse, error) {
+	if limit <= 0 {
+		limit = defaultReviewQueueLimit
+	}
+	limit = min(limit, maxReviewQueueLimit)
+
+	now := s.now()
+	items, err := s.reviewRepo.ListDueReviewItems(ctx, userID, now, limit)
+	if err != nil {
+		s.logger.Error("failed to list review items", "user_id", userID, "error", err)
+		return nil, errors.NewDatabaseError("list review items", err)
+	}
+	dueCount, err := s.reviewRepo.CountDueReviewItems(ctx, userID, now)
+	if err != nil {
+		s.logger.Error("failed to count review items", "user_id", userID, "error", err)
+		return nil, errors.NewDatabaseError("count review items", err)
+	}
+
+	response := &dto.ReviewQueueResponse{
+		Items:    make([]dto.ReviewItemDTO, 0, len(items)),
+		DueCount: dueCount,
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, toReviewItemDTO(item))
+	}
+	return response, nil
+}
+
+func (s *reviewService) GradeReview(ctx context.Context, userID, itemID string, grade int) (*dto.ReviewItemDTO, error) {
+	if grade < 0 || grade > maxRecallGrade {
+		return nil, errors.NewValidationError("grade must be between 0 and 5")
+	}
+	if _, err := uuid.Parse(itemID); err != nil {
+		return nil, errors.NewValidationError("invalid item_id")
+	}
+
+	item, err := s.reviewRepo.FindReviewItem(ctx, userID, itemID)
+	if err != nil {
+		s.logger.Error("failed to find review item", "item_id", itemID, "error", err)
+		return nil, errors.NewDatabaseError("find review item", err)
+	}
+	if item == nil {
+		return nil, errors.NewNotFoundError("review item")
+	}
+
+	now := s.now()
+	scheduleReview(item, grade, now)
+	err = s.reviewRepo.SaveReview(ctx, item, repository.ReviewGrade{
+		ItemID:     item.ID,
+		UserID:     userID,
+		Grade:      grade,
+		ReviewedAt: now,
+	})
+	if err != nil {
+		s.logger.Error("failed to save review", "item_id", itemID, "error", err)
+		return nil, errors.NewDatabaseError("save review", err)
+	}
+
+	result := toReviewItemDTO(item)
+	return &result, nil
+}
+
+// scheduleReview aplica SM-2: una calificación < 3 reinicia las repeticiones (vuelve mañana);
+// si no, el intervalo pasa a 1, 6 y luego intervalo × facilidad. La facilidad se ajusta
+// en cada repaso y nunca baja de 1.3
+func scheduleReview(item *repository.ReviewItem, grade int, now time.Time) {
+	if item.EaseFactor == 0 {
+		item.EaseFactor = initialEaseFactor
+	}
+
+	if grade < minRecallGrade {
+		item.Repetitions = 0
+		item.IntervalDays = 1
+		item.Lapses++
+	} else {
+		item.Repetitions++
+		switch item.Repetitions {
+		case 1:
+			item.IntervalDays = 1
+		case 2:
+			item.IntervalDays = 6
+		default:
+			item.IntervalDays = int(math.Round(float64(max(item.IntervalDays, 1)) * item.EaseFactor))
+		}
+	}
+
+	miss := float64(maxRecallGrade - grade)
+	item.EaseFactor = math.Max(minEaseFactor, item.EaseFactor+0.1-miss*(0.08+miss*0.02))
+	item.DueAt = now.AddDate(0, 0, item.IntervalDays)
+	reviewedAt := now
+	item.LastReviewedAt = &reviewedAt
+}
+
+func (s *reviewService) HandleAttemptCompleted(ctx context.Context, event eventbus.Event) error {
+	var envelope struct {
+		Timestamp time.Time `json:"timestamp"`
+		Payload   struct {
+			AttemptID    string `json:"attempt_id"`
+			AssessmentID string `json:"assessment_id"`
+			MaterialID   string `json:"material_id"`
+			UserID       string `json:"user_id"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(event.Body, &envelope); err != nil {
+		return fmt.Errorf("review: invalid event %s: %w", event.RoutingKey, err)
+	}
+	payload := envelope.Payload
+
+	attemptID, err := uuid.Parse(payload.AttemptID)
+	if err != nil {
+		return fmt.Errorf("review: event %s with invalid attempt_id: %w", event.RoutingKey, err)
+	}
+	assessmentID, err := uuid.Parse(payload.AssessmentID)
+	if err != nil {
+		return fmt.Errorf("review: event %s with invalid assessment_id: %w", event.RoutingKey, err)
+	}
+
+	answers, err := s.answerRepo.FindByAttemptID(ctx, attemptID)
+	if err != nil {
+		return fmt.Errorf("review: loading answers of attempt %s: %w", attemptID, err)
+	}
+	missed := make([]int, 0)
+	for _, a := range answers {
+		if a.IsCorrect != nil && !*a.IsCorrect {
+			missed = append(missed, a.QuestionIndex)
+		}
+	}
+	if len(missed) == 0 {
+		return nil
+	}
+	sort.Ints(missed)
+
+	assessment, err := s.assessmentRepo.FindByID(ctx, assessmentID)
+	if err != nil {
+		return fmt.Errorf("review: loading assessment %s: %w", assessmentID, err)
+	}
+	if assessment == nil {
+		return nil
+	}
+	document, err := s.documentRepo.FindByID(ctx, assessment.MongoDocumentID)
+	if err != nil {
+		return fmt.Errorf("review: loading questions of assessment %s: %w", assessmentID, err)
+	}
+	if document == nil {
+		return nil
+	}
+
+	occurredAt := eventTime(envelope.Timestamp, s.now())
+	items := make([]*repository.ReviewItem, 0, len(missed))
+	for _, index := range missed {
+		if index < 0 || index >= len(document.Questions) {
+			s.logger.Warn("review: invalid question_index", "attempt_id", attemptID.String(), "index", index)
+			continue
+		}
+		items = append(items, questionReviewItem(payload.UserID, payload.MaterialID, assessmentID.String(), index, document.Questions[index], occurredAt))
+	}
+
+	return s.enqueue(ctx, items, "attempt_id", attemptID.String())
+}
+
+func (s *reviewService) HandleMaterialCompleted(ctx context.Context, event eventbus.Event) error {
+	var envelope struct {
+		Timestamp time.Time `json:"timestamp"`
+		Payload   struct {
+			MaterialID string `json:"material_id"`
+			UserID     string `json:"user_id"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(event.Body, &envelope); err != nil {
+		return fmt.Errorf("review: invalid event %s: %w", event.RoutingKey, err)
+	}
+	payload := envelope.Payload
+	if payload.UserID == "" {
+		return fmt.Errorf("review: event %s without user_id", event.RoutingKey)
+	}
+
+	materialID, err := valueobject.MaterialIDFromString(payload.MaterialID)
+	if err != nil {
+		return fmt.Errorf("review: event %s with invalid material_id: %w", event.RoutingKey, err)
+	}
+	summary, err := s.summaryRepo.FindByMaterialID(ctx, materialID)
+	if err != nil {
+		return fmt.Errorf("review: loading summary of material %s: %w", payload.MaterialID, err)
+	}
+	if summary == nil || len(summary.Glossary) == 0 {
+		return nil
+	}
+
+	// Orden estable para que la cola no dependa del orden del mapa
+	terms := make([]string, 0, len(summary.Glossary))
+	for term := range summary.Glossary {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	occurredAt := eventTime(envelope.Timestamp, s.now())
+	items := make([]*repository.ReviewItem, 0, len(terms))
+	for _, term := range terms {
+		items = append(items, newReviewItem(payload.UserID, payload.MaterialID, repository.ReviewItemGlossary,
+			payload.MaterialID+":"+term, term, summary.Glossary[term], occurredAt))
+	}
+
+	return s.enqueue(ctx, items, "material_id", payload.MaterialID)
+}
+
+func (s *reviewService) enqueue(ctx context.Context, items []*repository.ReviewItem, sourceKey, sourceID string) error {
+	if len(items) == 0 {
+		return nil
+	}
+	inserted, err := s.reviewRepo.EnqueueReviewItems(ctx, items)
+	if err != nil {
+		return fmt.Errorf("review: enqueuing items for %s %s: %w", sourceKey, sourceID, err)
+	}
+	s.logger.Info("review items enqueued", sourceKey, sourceID, "items", len(items), "new", inserted)
+	return nil
+}
+
+// questionReviewItem arma el ítem de una pregunta fallada con su respuesta correcta
+// Si la respuesta correcta es un ID de opción, se muestra el texto de la opción
+func questionReviewItem(userID, materialID, assessmentID string, index int, question mongoRepo.Question, occurredAt time.Time) *repository.ReviewItem {
+	questionKey := question.ID
+	if questionKey == "" {
+		questionKey = strconv.Itoa(index)
+	}
+
+	answer := question.CorrectAnswer
+	options := make([]repository.ReviewOption, 0, len(question.Options))
+	for _, opt := range question.Options {
+		options = append(options, repository.ReviewOption{ID: opt.ID, Text: opt.Text})
+		if opt.ID == question.CorrectAnswer {
+			answer = opt.Text
+		}
+	}
+
+	item := newReviewItem(userID, materialID, repository.ReviewItemQuestion, assessmentID+":"+questionKey, question.Text, answer, occurredAt)
+	item.Options = options
+	item.Explanation = question.Feedback.Incorrect
+	return item
+}
+
+// newReviewItem arma un ítem nuevo a partir del evento que lo origina
+// CreatedAt es la hora del evento: un evento repetido no reinicia un ítem calificado después (ver EnqueueReviewItems)
+func newReviewItem(userID, materialID, kind, sourceKey, prompt, answer string, occurredAt time.Time) *repository.ReviewItem {
+	return &repository.ReviewItem{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		MaterialID: materialID,
+		Kind:       kind,
+		SourceKey:  sourceKey,
+		Prompt:     prompt,
+		Answer:     answer,
+		EaseFactor: initialEaseFactor,
+		DueAt:      occurredAt.Add(firstReviewDelay),
+		CreatedAt:  occurredAt,
+	}
+}
+
+// eventTime usa la hora del evento si viene informada
+func eventTime(timestamp, fallback time.Time) time.Time {
+	if timestamp.IsZero() {
+		return fallback
+	}
+	return timestamp
+}
+
+func toReviewItemDTO(item *repository.ReviewItem) dto.ReviewItemDTO {
+	result := dto.ReviewItemDTO{
+		ID:             item.ID,
+		Kind:           item.Kind,
+		MaterialID:     item.MaterialID,
+		Prompt:         item.Prompt,
+		Answer:         item.Answer,
+		Explanation:    item.Explanation,
+		DueAt:          item.DueAt,
+		IntervalDays:   item.IntervalDays,
+		Repetitions:    item.Repetitions,
+		EaseFactor:     math.Round(item.EaseFactor*100) / 100,
+		LastReviewedAt: item.LastReviewedAt,
+	}
+	for _, opt := range item.Options {
+		result.Options = append(result.Options, dto.ReviewOptionDTO{ID: opt.ID, Text: opt.Text})
+	}
+	return result
+}
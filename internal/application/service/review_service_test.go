@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repositories"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	mockPostgres "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/postgres"
+	mongoRepo "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mongodb/repository"
+	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+const reviewTestUserID = "11111111-1111-1111-1111-111111111111"
+
+// reviewAnswerRepository respuestas fijas de un intento
+type reviewAnswerRepository struct {
+	repositories.AnswerRepository
+	answers []*pgentities.AssessmentAttemptAnswer
+}
+
+func (r *reviewAnswerRepository) FindByAttemptID(ctx context.Context, attemptID uuid.UUID) ([]*pgentities.AssessmentAttemptAnswer, error) {
+	return r.answers, nil
+}
+
+// reviewAssessmentRepository evaluación fija
+type reviewAssessmentRepository struct {
+	repositories.AssessmentRepository
+	assessment *pgentities.Assessment
+}
+
+func (r *reviewAssessmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*pgentities.Assessment, error) {
+	return r.assessment, nil
+}
+
+// reviewDocumentRepository documento de preguntas fijo
+type reviewDocumentRepository struct {
+	mongoRepo.AssessmentDocumentRepository
+	document *mongoRepo.AssessmentDocument
+}
+
+func (r *reviewDocumentRepository) FindByID(ctx context.Context, objectID string) (*mongoRepo.AssessmentDocument, error) {
+	return r.document, nil
+}
+
+type reviewTestEnv struct {
+	service      *reviewService
+	repo         repository.ReviewItemRepository
+	summaries    *MockSummaryRepository
+	assessmentID uuid.UUID
+	materialID   uuid.UUID
+	now          time.Time
+}
+
+func newReviewTestEnv(t *testing.T, answers ...*pgentities.AssessmentAttemptAnswer) *reviewTestEnv {
+	t.Helper()
+
+	mockLogger := new(MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	env := &reviewTestEnv{
+		repo:         mockPostgres.NewMockReviewItemRepository(),
+		summaries:    new(MockSummaryRepository),
+		assessmentID: uuid.New(),
+		materialID:   uuid.New(),
+		now:          time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+	}
+	document := &mongoRepo.AssessmentDocument{
+		Questions: []mongoRepo.Question{
+			{
+				ID: "q1", Text: "¿Cuánto es 2 + 3?",
+				Options:       []mongoRepo.Option{{ID: "a", Text: "4"}, {ID: "b", Text: "5"}},
+				CorrectAnswer: "b",
+				Feedback:      mongoRepo.Feedback{Incorrect: "Cuenta de a uno desde el 2"},
+			},
+			{ID: "q2", Text: "¿Cuánto es 1 + 1?", CorrectAnswer: "2"},
+		},
+	}
+
+	env.service = NewReviewService(
+		env.repo,
+		&reviewAnswerRepository{answers: answers},
+		&reviewAssessmentRepository{assessment: &pgentities.Assessment{ID: env.assessmentID, MaterialID: env.materialID, MongoDocumentID: "doc-1"}},
+		&reviewDocumentRepository{document: document},
+		env.summaries,
+		mockLogger,
+	).(*reviewService)
+	env.service.now = func() time.Time { return env.now }
+	return env
+}
+
+func (env *reviewTestEnv) attemptCompletedEvent(t *testing.T) eventbus.Event {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{
+		"timestamp": env.now,
+		"payload": map[string]string{
+			"attempt_id":    uuid.NewString(),
+			"assessment_id": env.assessmentID.String(),
+			"material_id":   env.materialID.String(),
+			"user_id":       reviewTestUserID,
+		},
+	})
+	require.NoError(t, err)
+	return eventbus.Event{RoutingKey: "assessment.attempt.completed", Body: body}
+}
+
+func reviewAnswer(index int, correct bool) *pgentities.AssessmentAttemptAnswer {
+	return &pgentities.AssessmentAttemptAnswer{ID: uuid.New(), QuestionIndex: index, IsCorrect: &correct}
+}
+
+func TestScheduleReview(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		grades        []int
+		wantInterval  int
+		wantReps      int
+		wantLapses    int
+		wantEaseRound float64
+	}{
+		{name: "primer repaso correcto vuelve en 1 día", grades: []int{4}, wantInterval: 1, wantReps: 1, wantEaseRound: 2.5},
+		{name: "segundo repaso correcto vuelve en 6 días", grades: []int{4, 4}, wantInterval: 6, wantReps: 2, wantEaseRound: 2.5},
+		{name: "tercer repaso multiplica por la facilidad", grades: []int{4, 4, 4}, wantInterval: 15, wantReps: 3, wantEaseRound: 2.5},
+		{name: "respuesta perfecta aumenta la facilidad", grades: []int{5}, wantInterval: 1, wantReps: 1, wantEaseRound: 2.6},
+		{name: "olvido reinicia repeticiones y suma lapso", grades: []int{4, 4, 1}, wantInterval: 1, wantReps: 0, wantLapses: 1, wantEaseRound: 1.96},
+		{name: "la facilidad no baja de 1.3", grades: []int{0, 0, 0, 0}, wantInterval: 1, wantReps: 0, wantLapses: 4, wantEaseRound: 1.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &repository.ReviewItem{EaseFactor: initialEaseFactor}
+			for _, grade := range tt.grades {
+				scheduleReview(item, grade, now)
+			}
+
+			assert.Equal(t, tt.wantInterval, item.IntervalDays)
+			assert.Equal(t, tt.wantReps, item.Repetitions)
+			assert.Equal(t, tt.wantLapses, item.Lapses)
+			assert.InDelta(t, tt.wantEaseRound, item.EaseFactor, 0.001)
+			assert.Equal(t, now.AddDate(0, 0, tt.wantInterval), item.DueAt)
+			require.NotNil(t, item.LastReviewedAt)
+			assert.Equal(t, now, *item.LastReviewedAt)
+		})
+	}
+}
+
+func TestReviewService_HandleAttemptCompleted_EnqueuesMissedQuestions(t *testing.T) {
+	env := newReviewTestEnv(t, reviewAnswer(0, false), reviewAnswer(1, true))
+	ctx := context.Background()
+
+	require.NoError(t, env.service.HandleAttemptCompleted(ctx, env.attemptCompletedEvent(t)))
+
+	// Aún no vence: el primer repaso es al día siguiente
+	queue, err := env.service.GetReviewQueue(ctx, reviewTestUserID, 0)
+	require.NoError(t, err)
+	assert.Empty(t, queue.Items)
+
+	env.now = env.now.Add(firstReviewDelay)
+	queue, err = env.service.GetReviewQueue(ctx, reviewTestUserID, 0)
+	require.NoError(t, err)
+	require.Len(t, queue.Items, 1)
+	assert.Equal(t, 1, queue.DueCount)
+
+	item := queue.Items[0]
+	assert.Equal(t, repository.ReviewItemQuestion, item.Kind)
+	assert.Equal(t, env.materialID.String(), item.MaterialID)
+	assert.Equal(t, "¿Cuánto es 2 + 3?", item.Prompt)
+	assert.Equal(t, "5", item.Answer, "la respuesta muestra el texto de la opción correcta")
+	assert.Equal(t, "Cuenta de a uno desde el 2", item.Explanation)
+	assert.Len(t, item.Options, 2)
+}
+
+func TestReviewService_HandleAttemptCompleted_RefailRelearnsQuestion(t *testing.T) {
+	env := newReviewTestEnv(t, reviewAnswer(0, false))
+	ctx := context.Background()
+
+	require.NoError(t, env.service.HandleAttemptCompleted(ctx, env.attemptCompletedEvent(t)))
+	env.now = env.now.Add(firstReviewDelay)
+	queue, err := env.service.GetReviewQueue(ctx, reviewTestUserID, 0)
+	require.NoError(t, err)
+	require.Len(t, queue.Items, 1)
+
+	// Dos repasos correctos lo alejan 6 días
+	itemID := queue.Items[0].ID
+	_, err = env.service.GradeReview(ctx, reviewTestUserID, itemID, 5)
+	require.NoError(t, err)
+	graded, err := env.service.GradeReview(ctx, reviewTestUserID, itemID, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 6, graded.IntervalDays)
+
+	// Fallarla de nuevo (en un intento posterior a los repasos) la devuelve a la cola sin duplicarla
+	env.now = env.now.Add(time.Hour)
+	require.NoError(t, env.service.HandleAttemptCompleted(ctx, env.attemptCompletedEvent(t)))
+	env.now = env.now.Add(firstReviewDelay)
+	queue, err = env.service.GetReviewQueue(ctx, reviewTestUserID, 0)
+	require.NoError(t, err)
+	require.Len(t, queue.Items, 1)
+	assert.Equal(t, itemID, queue.Items[0].ID)
+	assert.Equal(t, 0, queue.Items[0].Repetitions)
+}
+
+// TestReviewService_HandleAttemptCompleted_ReplayAfterGradeKeepsSchedule verifica que la reentrega
+// de un evento ya procesado no reinicie una pregunta repasada después del intento
+func TestReviewService_HandleAttemptCompleted_ReplayAfterGradeKeepsSchedule(t *testing.T) {
+	env := newReviewTestEnv(t, reviewAnswer(0, false))
+	ctx := context.Background()
+	event := env.attemptCompletedEvent(t)
+
+	require.NoError(t, env.service.HandleAttemptCompleted(ctx, event))
+	env.now = env.now.Add(firstReviewDelay)
+	queue, err := env.service.GetReviewQueue(ctx, reviewTestUserID, 0)
+	require.NoError(t, err)
+	require.Len(t, queue.Items, 1)
+
+	graded, err := env.service.GradeReview(ctx, reviewTestUserID, queue.Items[0].ID, 5)
+	require.NoError(t, err)
+	require.Equal(t, 1, graded.Repetitions)
+
+	// El bus reentrega el mismo evento (at-least-once) después del repaso
+	env.now = env.now.Add(time.Hour)
+	require.NoError(t, env.service.HandleAttemptCompleted(ctx, event))
+
+	queue, err = env.service.GetReviewQueue(ctx, reviewTestUserID, 0)
+	require.NoError(t, err)
+	assert.Empty(t, queue.Items, "el ítem conserva la programación del repaso")
+
+	env.now = graded.DueAt
+	queue, err = env.service.GetReviewQueue(ctx, reviewTestUserID, 0)
+	require.NoError(t, err)
+	require.Len(t, queue.Items, 1)
+	assert.Equal(t, 1, queue.Items[0].Repetitions)
+	assert.Equal(t, 1, queue.Items[0].IntervalDays)
+}
+
+func TestReviewService_HandleAttemptCompleted_NoMissedQuestions(t *testing.T) {
+	env := newReviewTestEnv(t, reviewAnswer(0, true), reviewAnswer(1, true))
+	ctx := context.Background()
+
+	require.NoError(t, env.service.HandleAttemptCompleted(ctx, env.attemptCompletedEvent(t)))
+
+	env.now = env.now.Add(30 * 24 * time.Hour)
+	queue, err := env.service.GetReviewQueue(ctx, reviewTestUserID, 0)
+	require.NoError(t, err)
+	assert.Empty(t, queue.Items)
+}
+
+func TestReviewService_HandleMaterialCompleted_EnqueuesGlossaryOnce(t *testing.T) {
+	env := newReviewTestEnv(t)
+	ctx := context.Background()
+
+	env.summaries.On("FindByMaterialID", mock.Anything, mock.AnythingOfType("valueobject.MaterialID")).Return(&repository.MaterialSummary{
+		Glossary: map[string]string{
+			"sumando": "Cada número que se suma",
+			"suma":    "Resultado de sumar",
+		},
+	}, nil)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"payload": map[string]string{"material_id": env.materialID.String(), "user_id": reviewTestUserID},
+	})
+	require.NoError(t, err)
+	event := eventbus.Event{RoutingKey: "material.completed", Body: body}
+
+	require.NoError(t, env.service.HandleMaterialCompleted(ctx, event))
+	require.NoError(t, env.service.HandleMaterialCompleted(ctx, event))
+
+	env.now = env.now.Add(firstReviewDelay)
+	queue, err := env.service.GetReviewQueue(ctx, reviewTestUserID, 0)
+	require.NoError(t, err)
+	require.Len(t, queue.Items, 2)
+	assert.Equal(t, 2, queue.DueCount)
+	for _, item := range queue.Items {
+		assert.Equal(t, repository.ReviewItemGlossary, item.Kind)
+		assert.NotEmpty(t, item.Answer)
+	}
+}
+
+func TestReviewService_HandleMaterialCompleted_InvalidMaterial(t *testing.T) {
+	env := newReviewTestEnv(t)
+
+	body := []byte(`{"payload":{"material_id":"no-es-uuid","user_id":"` + reviewTestUserID + `"}}`)
+	err := env.service.HandleMaterialCompleted(context.Background(), eventbus.Event{RoutingKey: "material.completed", Body: body})
+	assert.Error(t, err)
+	env.summaries.AssertNotCalled(t, "FindByMaterialID", mock.Anything, mock.Anything)
+}
+
+func TestReviewService_GetReviewQueue_LimitsAndCounts(t *testing.T) {
+	env := newReviewTestEnv(t)
+	ctx := context.Background()
+
+	items := make([]*repository.ReviewItem, 0, 3)
+	for i := 0; i < 3; i++ {
+		item := newReviewItem(reviewTestUserID, env.materialID.String(), repository.ReviewItemGlossary,
+			uuid.NewString(), "término", "definición", env.now.Add(-firstReviewDelay-time.Duration(i)*time.Hour))
+		items = append(items, item)
+	}
+	_, err := env.repo.EnqueueReviewItems(ctx, items)
+	require.NoError(t, err)
+
+	queue, err := env.service.GetReviewQueue(ctx, reviewTestUserID, 2)
+	require.NoError(t, err)
+	require.Len(t, queue.Items, 2)
+	assert.Equal(t, 3, queue.DueCount)
+	assert.Equal(t, items[2].ID, queue.Items[0].ID, "primero el más atrasado")
+}
+
+func TestReviewService_GradeReview_Errors(t *testing.T) {
+	env := newReviewTestEnv(t)
+	ctx := context.Background()
+
+	item := newReviewItem(reviewTestUserID, env.materialID.String(), repository.ReviewItemGlossary, "suma", "suma", "resultado", env.now)
+	_, err := env.repo.EnqueueReviewItems(ctx, []*repository.ReviewItem{item})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		userID   string
+		itemID   string
+		grade    int
+		wantCode errors.ErrorCode
+	}{
+		{name: "calificación fuera de rango", userID: reviewTestUserID, itemID: item.ID, grade: 6, wantCode: errors.ErrorCodeValidation},
+		{name: "calificación negativa", userID: reviewTestUserID, itemID: item.ID, grade: -1, wantCode: errors.ErrorCodeValidation},
+		{name: "item_id inválido", userID: reviewTestUserID, itemID: "abc", grade: 3, wantCode: errors.ErrorCodeValidation},
+		{name: "ítem inexistente", userID: reviewTestUserID, itemID: uuid.NewString(), grade: 3, wantCode: errors.ErrorCodeNotFound},
+		{name: "ítem de otro usuario", userID: uuid.NewString(), itemID: item.ID, grade: 3, wantCode: errors.ErrorCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := env.service.GradeReview(ctx, tt.userID, tt.itemID, tt.grade)
+			assert.Nil(t, result)
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// MaxBundleSinceScreens cantidad máxima de versiones que el cliente puede enviar para pedir un delta
+const MaxBundleSinceScreens = 1000
+
+// ScreenBundleDTO navegación y pantallas alcanzables por el usuario en una sola respuesta
+// Con Delta=true solo trae lo que cambió respecto del bundle indicado en since:
+// Navigation se omite si no cambió, Screens trae las pantallas nuevas o modificadas
+// y Removed las que el usuario ya no alcanza. Versions siempre lista todas las pantallas
+type ScreenBundleDTO struct {
+	Hash           string                            `json:"hash"`
+	Platform       string                            `json:"platform"`
+	Locale         string                            `json:"locale"`
+	Delta          bool                              `json:"delta"`
+	NavigationHash string                            `json:"navigationHash"`
+	Navigation     *NavigationConfigDTO              `json:"navigation,omitempty"`
+	Screens        map[string]*dto.CombinedScreenDTO `json:"screens"`
+	Versions       map[string]string                 `json:"versions"`
+	Removed        []string                          `json:"removed,omitempty"`
+
+	// Experiments variantes asignadas por pantalla (siempre completo, como Versions)
+	Experiments map[string]*ScreenExperimentAssignmentDTO `json:"experiments,omitempty"`
+}
+
+// BundleSince bundle que el cliente ya tiene, con los campos tal como los recibió
+// El servidor no recuerda los bundles entregados: el delta se calcula contra lo que envía el cliente,
+// así cualquier instancia responde lo mismo. Solo con Hash (sin Versions) se responde un delta vacío
+// si el bundle no cambió y el bundle completo si cambió
+type BundleSince struct {
+	Hash           string            `json:"hash"`
+	Platform       string            `json:"platform"`
+	Locale         string            `json:"locale"`
+	NavigationHash string            `json:"navigationHash"`
+	Versions       map[string]string `json:"versions"`
+}
+
+// bundleManifest resume un bundle: hash de la navegación y versión de cada pantalla
+// scope (plataforma e idioma) forma parte del hash del bundle
+type bundleManifest struct {
+	hash           string
+	navigationHash string
+	versions       map[string]string
+}
+
+// ScreenFingerprint identifica la versión de una pantalla para el usuario: versión del template,
+// última edición de la instancia o del template y las preferencias del usuario (que viajan
+// en la respuesta). Cambia en cuanto api-admin edita la pantalla o el usuario sus preferencias
+func ScreenFingerprint(screen *dto.CombinedScreenDTO) string {
+	prefs := fnv.New32a()
+	_, _ = prefs.Write(screen.UserPreferences)
+	return fmt.Sprintf("v%d-%d-%08x", screen.Version, screen.UpdatedAt.UnixNano(), prefs.Sum32())
+}
+
+// GetScreenBundle arma la navegación y todas las pantallas que los permisos del usuario alcanzan
+// (pantalla por defecto y secundarias de cada recurso permitido), resueltas para la plataforma
+// y con las preferencias y variantes de experimento del usuario. Si since describe un bundle
+// de la misma plataforma e idioma, retorna solo el delta
+func (s *screenService) GetScreenBundle(ctx context.Context, audience ScreenAudience, permissions []string, since *BundleSince) (*ScreenBundleDTO, error) {
+	if since != nil && len(since.Versions) > MaxBundleSinceScreens {
+		return nil, errors.NewValidationError(fmt.Sprintf("since.versions exceeds %d screens", MaxBundleSinceScreens))
+	}
+
+	platform, locale := audience.Platform, audience.locale()
+	allowedResources, mappings, err := s.reachableMenu(ctx, permissions)
+	if err != nil {
+		return nil, err
+	}
+	allowedResources = s.localizeMenu(ctx, allowedResources, locale)
+	navigation := newNavigationConfig(allowedResources, mappings, platform)
+
+	// Pantallas alcanzables, sin duplicados y en orden estable para el hash
+	keys := make([]string, 0, len(mappings))
+	seen := make(map[string]bool, len(mappings))
+	for _, m := range mappings {
+		if m.ScreenKey != "" && !seen[m.ScreenKey] {
+			seen[m.ScreenKey] = true
+			keys = append(keys, m.ScreenKey)
+		}
+	}
+	sort.Strings(keys)
+
+	screens := make(map[string]*dto.CombinedScreenDTO, len(keys))
+	versions := make(map[string]string, len(keys))
+	var experiments map[string]*ScreenExperimentAssignmentDTO
+	for _, key := range keys {
+		screen, assignment, err := s.resolveScreen(ctx, key, audience)
+		if err != nil {
+			// Un mapping hacia una pantalla inactiva no debe tumbar el arranque de la app
+			if appErr, ok := errors.GetAppError(err); ok && appErr.Code == errors.ErrorCodeNotFound {
+				s.logger.Warn("bundle skips missing screen", "screen_key", key)
+				continue
+			}
+			return nil, err
+		}
+		screens[key] = screen
+		versions[key] = ScreenFingerprint(screen)
+		if assignment != nil {
+			// Cambiar de variante cambia la versión aunque ambas instancias coincidan en fecha
+			versions[key] += "-" + assignment.Variant
+			if experiments == nil {
+				experiments = make(map[string]*ScreenExperimentAssignmentDTO)
+			}
+			experiments[key] = assignment
+		}
+	}
+
+	manifest, err := newBundleManifest(platform+"/"+locale, navigation, versions)
+	if err != nil {
+		return nil, errors.NewInternalError("build screen bundle", err)
+	}
+
+	bundle := &ScreenBundleDTO{
+		Hash:           manifest.hash,
+		Platform:       platform,
+		Locale:         locale,
+		NavigationHash: manifest.navigationHash,
+		Navigation:     navigation,
+		Screens:        screens,
+		Versions:       versions,
+
+		Experiments: experiments,
+	}
+
+	if since == nil || since.Hash == "" {
+		return bundle, nil
+	}
+
+	previous := since
+	if since.Hash == manifest.hash {
+		// El hash depende solo del contenido (incluidos plataforma e idioma): el cliente ya tiene todo
+		previous = &BundleSince{NavigationHash: manifest.navigationHash, Versions: versions}
+	} else if since.Versions == nil || since.Platform != platform || since.Locale != locale {
+		// Sin versiones no hay contra qué comparar; otro idioma o plataforma cambia el contenido
+		return bundle, nil
+	}
+
+	// Delta respecto del bundle que el cliente ya tiene
+	bundle.Delta = true
+	if previous.NavigationHash == manifest.navigationHash {
+		bundle.Navigation = nil
+	}
+	for key, version := range versions {
+		if previous.Versions[key] == version {
+			delete(bundle.Screens, key)
+		}
+	}
+	for key := range previous.Versions {
+		if _, ok := versions[key]; !ok {
+			bundle.Removed = append(bundle.Removed, key)
+		}
+	}
+	sort.Strings(bundle.Removed)
+
+	return bundle, nil
+}
+
+// newBundleManifest calcula los hashes del bundle: cambia si cambia el scope, la navegación o alguna pantalla
+func newBundleManifest(scope string, navigation *NavigationConfigDTO, versions map[string]string) (*bundleManifest, error) {
+	navJSON, err := json.Marshal(navigation)
+	if err != nil {
+		return nil, err
+	}
+	navSum := sha256.Sum256(navJSON)
+	navigationHash := hex.EncodeToString(navSum[:8])
+
+	keys := make([]string, 0, len(versions))
+	for key := range versions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\n%s\n", scope, navigationHash)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(h, "%s=%s\n", key, versions[key])
+	}
+
+	return &bundleManifest{
+		hash:           hex.EncodeToString(h.Sum(nil)[:16]),
+		navigationHash: navigationHash,
+		versions:       versions,
+	}, nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	apperrors "github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// bundleTestEnv menú con dashboard (system) y materials (school); materials tiene una pantalla
+// secundaria y un mapping hacia una pantalla inactiva
+type bundleTestEnv struct {
+	ctx     context.Context
+	userID  uuid.UUID
+	repo    *MockScreenRepository
+	screens map[string]*repository.CombinedScreen
+	svc     ScreenService
+
+	resources *MockResourceReader
+	logger    *MockLogger
+}
+
+func newBundleTestEnv(t *testing.T) *bundleTestEnv {
+	t.Helper()
+	env := &bundleTestEnv{
+		ctx:     context.Background(),
+		userID:  uuid.New(),
+		repo:    new(MockScreenRepository),
+		screens: make(map[string]*repository.CombinedScreen),
+	}
+	resourceReader := new(MockResourceReader)
+	mockLogger := new(MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	resourceReader.On("GetMenuResources", env.ctx).Return([]*repository.MenuResource{
+		{ID: "r1", Key: "dashboard", DisplayName: "Home", SortOrder: 0, Scope: "system"},
+		{ID: "r2", Key: "materials", DisplayName: "Materials", SortOrder: 1, Scope: "school"},
+	}, nil)
+	dashboard := &repository.ResourceScreenMapping{ResourceKey: "dashboard", ScreenKey: "dashboard-teacher", IsDefault: true}
+	resourceReader.On("GetResourceScreenMappings", env.ctx, []string{"dashboard"}).
+		Return([]*repository.ResourceScreenMapping{dashboard}, nil)
+	resourceReader.On("GetResourceScreenMappings", env.ctx, []string{"dashboard", "materials"}).
+		Return([]*repository.ResourceScreenMapping{
+			dashboard,
+			{ResourceKey: "materials", ScreenKey: "materials-list", IsDefault: true},
+			{ResourceKey: "materials", ScreenKey: "material-detail"},
+			{ResourceKey: "materials", ScreenKey: "materials-list"},
+			{ResourceKey: "materials", ScreenKey: "materials-archived"},
+		}, nil)
+
+	for _, key := range []string{"dashboard-teacher", "materials-list", "material-detail"} {
+		env.screens[key] = newTestCombinedScreen(key)
+		env.repo.On("GetCombinedScreen", env.ctx, key, env.userID).Return(env.screens[key], nil)
+	}
+	env.repo.On("GetCombinedScreen", env.ctx, "materials-archived", env.userID).Return(nil, nil)
+	env.repo.On("GetUserPreferences", env.ctx, mock.Anything, env.userID).Return(json.RawMessage(`{}`), nil).Maybe()
+
+	env.resources, env.logger = resourceReader, mockLogger
+	env.svc = NewScreenService(env.repo, resourceReader, mockLogger)
+	return env
+}
+
+func (env *bundleTestEnv) bundle(t *testing.T, permissions []string, since *BundleSince) *ScreenBundleDTO {
+	t.Helper()
+	bundle, err := env.svc.GetScreenBundle(env.ctx, ScreenAudience{UserID: env.userID, Platform: "ios"}, permissions, since)
+	require.NoError(t, err)
+	return bundle
+}
+
+// sinceOf arma lo que el cliente reenvía del bundle que ya tiene
+func sinceOf(bundle *ScreenBundleDTO) *BundleSince {
+	return &BundleSince{
+		Hash:           bundle.Hash,
+		Platform:       bundle.Platform,
+		Locale:         bundle.Locale,
+		NavigationHash: bundle.NavigationHash,
+		Versions:       bundle.Versions,
+	}
+}
+
+func TestScreenService_GetScreenBundle_Full(t *testing.T) {
+	env := newBundleTestEnv(t)
+
+	bundle := env.bundle(t, []string{"materials:read"}, nil)
+
+	assert.False(t, bundle.Delta)
+	assert.NotEmpty(t, bundle.Hash)
+	assert.Equal(t, "ios", bundle.Platform)
+	require.NotNil(t, bundle.Navigation)
+	assert.Len(t, bundle.Navigation.BottomNav, 2)
+
+	// Pantallas por defecto y secundarias, sin duplicados; la inactiva se omite
+	assert.ElementsMatch(t, []string{"dashboard-teacher", "materials-list", "material-detail"}, mapKeys(bundle.Screens))
+	assert.ElementsMatch(t, mapKeys(bundle.Screens), mapKeys(bundle.Versions))
+	assert.Equal(t, ScreenFingerprint(bundle.Screens["material-detail"]), bundle.Versions["material-detail"])
+
+	again := env.bundle(t, []string{"materials:read"}, nil)
+	assert.Equal(t, bundle.Hash, again.Hash, "el hash es estable mientras nada cambie")
+}
+
+func TestScreenService_GetScreenBundle_Delta(t *testing.T) {
+	tests := []struct {
+		name           string
+		change         func(env *bundleTestEnv)
+		permissions    []string
+		wantScreens    []string
+		wantRemoved    []string
+		wantNavigation bool
+	}{
+		{
+			name:        "sin cambios",
+			permissions: []string{"materials:read"},
+		},
+		{
+			name: "pantalla editada",
+			change: func(env *bundleTestEnv) {
+				env.screens["materials-list"].Version = 2
+				env.svc.InvalidateScreens("materials-list")
+			},
+			permissions: []string{"materials:read"},
+			wantScreens: []string{"materials-list"},
+		},
+		{
+			name:           "permiso revocado",
+			permissions:    nil,
+			wantRemoved:    []string{"material-detail", "materials-list"},
+			wantNavigation: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newBundleTestEnv(t)
+			first := env.bundle(t, []string{"materials:read"}, nil)
+			if tt.change != nil {
+				tt.change(env)
+			}
+
+			// Una instancia nueva, sin estado, responde el mismo delta
+			env.svc = NewScreenService(env.repo, env.resources, env.logger)
+			delta := env.bundle(t, tt.permissions, sinceOf(first))
+
+			assert.True(t, delta.Delta)
+			assert.ElementsMatch(t, tt.wantScreens, mapKeys(delta.Screens))
+			assert.Equal(t, tt.wantRemoved, delta.Removed)
+			assert.Equal(t, tt.wantNavigation, delta.Navigation != nil)
+			if tt.change == nil && tt.wantRemoved == nil {
+				assert.Equal(t, first.Hash, delta.Hash)
+			} else {
+				assert.NotEqual(t, first.Hash, delta.Hash)
+			}
+		})
+	}
+}
+
+func TestScreenService_GetScreenBundle_UnknownSinceReturnsFull(t *testing.T) {
+	env := newBundleTestEnv(t)
+
+	bundle := env.bundle(t, []string{"materials:read"}, &BundleSince{Hash: "unknown-hash"})
+
+	assert.False(t, bundle.Delta)
+	assert.NotNil(t, bundle.Navigation)
+	assert.Len(t, bundle.Screens, 3)
+}
+
+func TestScreenService_GetScreenBundle_HashOnlySince(t *testing.T) {
+	env := newBundleTestEnv(t)
+	first := env.bundle(t, []string{"materials:read"}, nil)
+
+	// Mismo hash: delta vacío, calculado sin recordar el bundle anterior
+	same := env.bundle(t, []string{"materials:read"}, &BundleSince{Hash: first.Hash})
+	assert.True(t, same.Delta)
+	assert.Nil(t, same.Navigation)
+	assert.Empty(t, same.Screens)
+	assert.Empty(t, same.Removed)
+
+	// Hash distinto sin versiones: no hay contra qué comparar
+	changed := env.bundle(t, nil, &BundleSince{Hash: first.Hash})
+	assert.False(t, changed.Delta)
+	assert.NotNil(t, changed.Navigation)
+}
+
+func TestScreenService_GetScreenBundle_TooManySinceVersions(t *testing.T) {
+	env := newBundleTestEnv(t)
+	versions := make(map[string]string, MaxBundleSinceScreens+1)
+	for i := 0; i <= MaxBundleSinceScreens; i++ {
+		versions[fmt.Sprintf("screen-%d", i)] = "v1"
+	}
+
+	_, err := env.svc.GetScreenBundle(env.ctx, ScreenAudience{UserID: env.userID, Platform: "ios"}, nil, &BundleSince{Hash: "h", Versions: versions})
+
+	appErr, ok := apperrors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.ErrorCodeValidation, appErr.Code)
+}
+
+func TestScreenService_GetScreenBundle_ScreenError(t *testing.T) {
+	env := newBundleTestEnv(t)
+	env.repo.ExpectedCalls = nil
+	env.repo.On("GetCombinedScreen", env.ctx, mock.Anything, env.userID).Return(nil, fmt.Errorf("connection refused"))
+
+	_, err := env.svc.GetScreenBundle(env.ctx, ScreenAudience{UserID: env.userID, Platform: "ios"}, nil, nil)
+
+	assert.Error(t, err)
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+)
+
+// screenCacheEntry es una pantalla resuelta (sin preferencias de usuario) con su vencimiento
+type screenCacheEntry struct {
+	key       string
+	screenKey string
+	data      *dto.CombinedScreenDTO
+	expiresAt time.Time
+}
+
+// screenLRU cache en memoria de pantallas resueltas, acotado por cantidad de entradas
+// Al llenarse descarta la pantalla usada hace más tiempo. Una misma pantalla puede tener
+// varias entradas (una por plataforma); invalidate las elimina todas
+type screenLRU struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	order      *list.List // Frente: usada más recientemente
+	entries    map[string]*list.Element
+	now        func() time.Time
+}
+
+func newScreenLRU(maxEntries int, ttl time.Duration) *screenLRU {
+	return &screenLRU{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// get retorna la entrada si existe y no venció, marcándola como usada
+func (c *screenLRU) get(key string) *dto.CombinedScreenDTO {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*screenCacheEntry)
+	if c.now().After(entry.expiresAt) {
+		c.remove(elem)
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	return entry.data
+}
+
+// set guarda la entrada y descarta las menos usadas si se supera el tope
+func (c *screenLRU) set(key, screenKey string, data *dto.CombinedScreenDTO) {
+	if c.ttl <= 0 || c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &screenCacheEntry{key: key, screenKey: screenKey, data: data, expiresAt: c.now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// invalidate elimina todas las entradas de las pantallas indicadas y retorna cuántas eliminó
+func (c *screenLRU) invalidate(screenKeys ...string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make(map[string]bool, len(screenKeys))
+	for _, key := range screenKeys {
+		keys[key] = true
+	}
+
+	removed := 0
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+		if keys[elem.Value.(*screenCacheEntry).screenKey] {
+			c.remove(elem)
+			removed++
+		}
+		elem = next
+	}
+	return removed
+}
+
+// purge vacía el cache y retorna cuántas entradas eliminó
+func (c *screenLRU) purge() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := c.order.Len()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	return removed
+}
+
+// len retorna la cantidad de entradas (incluyendo vencidas aún no descartadas)
+func (c *screenLRU) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *screenLRU) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*screenCacheEntry).key)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+)
+
+func TestScreenLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newScreenLRU(2, time.Hour)
+
+	cache.set("a", "screen-a", &dto.CombinedScreenDTO{ScreenKey: "screen-a"})
+	cache.set("b", "screen-b", &dto.CombinedScreenDTO{ScreenKey: "screen-b"})
+	require.NotNil(t, cache.get("a")) // "a" pasa a ser la más reciente
+	cache.set("c", "screen-c", &dto.CombinedScreenDTO{ScreenKey: "screen-c"})
+
+	assert.Equal(t, 2, cache.len())
+	assert.NotNil(t, cache.get("a"))
+	assert.Nil(t, cache.get("b"), "la menos usada se descarta")
+	assert.NotNil(t, cache.get("c"))
+}
+
+func TestScreenLRU_ExpiresEntries(t *testing.T) {
+	cache := newScreenLRU(10, time.Minute)
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.set("a", "screen-a", &dto.CombinedScreenDTO{})
+	require.NotNil(t, cache.get("a"))
+
+	now = now.Add(2 * time.Minute)
+	assert.Nil(t, cache.get("a"))
+	assert.Equal(t, 0, cache.len(), "la entrada vencida se descarta al leerla")
+}
+
+func TestScreenLRU_InvalidateRemovesEveryPlatform(t *testing.T) {
+	cache := newScreenLRU(10, time.Hour)
+	cache.set("screen:a:platform:", "a", &dto.CombinedScreenDTO{})
+	cache.set("screen:a:platform:desktop", "a", &dto.CombinedScreenDTO{})
+	cache.set("screen:b:platform:", "b", &dto.CombinedScreenDTO{})
+
+	assert.Equal(t, 2, cache.invalidate("a"))
+	assert.Nil(t, cache.get("screen:a:platform:desktop"))
+	assert.NotNil(t, cache.get("screen:b:platform:"))
+
+	assert.Equal(t, 1, cache.purge())
+	assert.Equal(t, 0, cache.len())
+}
+
+func TestScreenLRU_DisabledWithoutTTLOrSize(t *testing.T) {
+	for _, cache := range []*screenLRU{newScreenLRU(0, time.Hour), newScreenLRU(10, 0)} {
+		cache.set("a", "screen-a", &dto.CombinedScreenDTO{})
+		assert.Nil(t, cache.get("a"))
+	}
+}
+
+func TestScreenService_HandleScreenUpdated(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantErr    bool
+		wantReload []string // Pantallas que vuelven a cargarse de BD
+	}{
+		{name: "sobre con screen_key", body: `{"payload": {"screen_key": "screen-a"}}`, wantReload: []string{"screen-a"}},
+		{name: "sin sobre con screen_keys", body: `{"screen_keys": ["screen-a", "screen-b"]}`, wantReload: []string{"screen-a", "screen-b"}},
+		{name: "sin screen_key vacía todo", body: `{"payload": {"template_id": "t-1"}}`, wantReload: []string{"screen-a", "screen-b"}},
+		{name: "cuerpo vacío vacía todo", body: ``, wantReload: []string{"screen-a", "screen-b"}},
+		{name: "cuerpo inválido vacía todo", body: `screen-a`, wantErr: true, wantReload: []string{"screen-a", "screen-b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			userID := uuid.New()
+			mockRepo := new(MockScreenRepository)
+			mockLogger := new(MockLogger)
+			mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+			for _, key := range []string{"screen-a", "screen-b"} {
+				mockRepo.On("GetCombinedScreen", ctx, key, userID).Return(newTestCombinedScreen(key), nil)
+			}
+			mockRepo.On("GetUserPreferences", ctx, mock.Anything, userID).Return(json.RawMessage(`{}`), nil)
+
+			svc := NewScreenService(mockRepo, nil, mockLogger)
+			for _, key := range []string{"screen-a", "screen-b"} {
+				_, err := svc.GetScreen(ctx, key, userID, "")
+				require.NoError(t, err)
+			}
+
+			err := svc.HandleScreenUpdated(ctx, eventbus.Event{RoutingKey: "screen.updated", Body: []byte(tt.body)})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			for _, key := range []string{"screen-a", "screen-b"} {
+				_, err := svc.GetScreen(ctx, key, userID, "")
+				require.NoError(t, err)
+			}
+
+			loads := make(map[string]int)
+			for _, call := range mockRepo.Calls {
+				if call.Method == "GetCombinedScreen" {
+					loads[call.Arguments.String(1)]++
+				}
+			}
+			for _, key := range []string{"screen-a", "screen-b"} {
+				want := 1
+				if slices.Contains(tt.wantReload, key) {
+					want = 2
+				}
+				assert.Equal(t, want, loads[key], key)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+)
+
+// Endpoints internos que una pantalla puede resolver en proceso con ?include=data
+const (
+	ScreenDataEndpointMaterials  = "/v1/materials"
+	ScreenDataEndpointMyProgress = "/v1/users/me/progress"
+	ScreenDataEndpointMyAttempts = "/v1/users/me/attempts"
+)
+
+// ScreenDataRequest usuario y parámetros con los que se resuelven los datos de una pantalla
+type ScreenDataRequest struct {
+	UserID      uuid.UUID
+	Permissions []string
+	// Params combina la query del DataEndpoint con los params de DataConfig
+	Params url.Values
+}
+
+// ScreenDataFetcher obtiene los datos de un endpoint interno
+type ScreenDataFetcher func(ctx context.Context, req ScreenDataRequest) (any, error)
+
+// ScreenDataProvider resuelve un endpoint interno exigiendo el mismo permiso que su ruta HTTP
+type ScreenDataProvider struct {
+	Permission enum.Permission
+	Fetch      ScreenDataFetcher
+}
+
+// ScreenDataRegistry proveedores de datos indexados por endpoint (ruta sin query, p. ej. /v1/materials)
+// Las pantallas cuyo DataEndpoint no está registrado se sirven sin datos: el cliente los pide como siempre
+type ScreenDataRegistry struct {
+	providers map[string]ScreenDataProvider
+}
+
+// NewScreenDataRegistry crea un registro vacío
+func NewScreenDataRegistry() *ScreenDataRegistry {
+	return &ScreenDataRegistry{providers: make(map[string]ScreenDataProvider)}
+}
+
+// NewDefaultScreenDataRegistry registra los endpoints de lectura que usan las pantallas de la app:
+// lista de materiales, mi progreso e historial de intentos
+func NewDefaultScreenDataRegistry(
+	materials MaterialService,
+	progress ProgressService,
+	attempts AssessmentAttemptService,
+) *ScreenDataRegistry {
+	registry := NewScreenDataRegistry()
+
+	registry.Register(ScreenDataEndpointMaterials, ScreenDataProvider{
+		Permission: enum.PermissionMaterialsRead,
+		Fetch: func(ctx context.Context, req ScreenDataRequest) (any, error) {
+			return materials.ListMaterials(ctx, repository.ListFilters{
+				Limit:  intParam(req.Params, "limit", 0, 100),
+				Offset: intParam(req.Params, "offset", 0, -1),
+			})
+		},
+	})
+	registry.Register(ScreenDataEndpointMyProgress, ScreenDataProvider{
+		Permission: enum.PermissionProgressRead,
+		Fetch: func(ctx context.Context, req ScreenDataRequest) (any, error) {
+			return progress.ListUserProgress(ctx, req.UserID.String(), req.Params.Get("status"),
+				intParam(req.Params, "limit", 20, 100), intParam(req.Params, "offset", 0, -1))
+		},
+	})
+	registry.Register(ScreenDataEndpointMyAttempts, ScreenDataProvider{
+		Permission: enum.PermissionAssessmentsViewResults,
+		Fetch: func(ctx context.Context, req ScreenDataRequest) (any, error) {
+			return attempts.GetAttemptHistory(ctx, req.UserID,
+				intParam(req.Params, "limit", 10, 100), intParam(req.Params, "offset", 0, -1))
+		},
+	})
+
+	return registry
+}
+
+// Register asocia un proveedor a un endpoint; reemplaza el anterior si ya existía
+func (r *ScreenDataRegistry) Register(endpoint string, provider ScreenDataProvider) {
+	r.providers[normalizeDataEndpoint(endpoint)] = provider
+}
+
+// lookup retorna el proveedor del DataEndpoint y los parámetros combinados con DataConfig
+// ok=false si el endpoint es externo, no es GET o no tiene proveedor
+func (r *ScreenDataRegistry) lookup(dataEndpoint string, dataConfig json.RawMessage) (ScreenDataProvider, url.Values, bool) {
+	if r == nil || dataEndpoint == "" {
+		return ScreenDataProvider{}, nil, false
+	}
+
+	endpoint, err := url.Parse(dataEndpoint)
+	if err != nil || endpoint.Host != "" {
+		return ScreenDataProvider{}, nil, false
+	}
+	provider, ok := r.providers[normalizeDataEndpoint(endpoint.Path)]
+	if !ok {
+		return ScreenDataProvider{}, nil, false
+	}
+
+	var config struct {
+		Method string         `json:"method"`
+		Params map[string]any `json:"params"`
+	}
+	if len(dataConfig) > 0 {
+		if err := json.Unmarshal(dataConfig, &config); err != nil {
+			return ScreenDataProvider{}, nil, false
+		}
+	}
+	if config.Method != "" && !strings.EqualFold(config.Method, http.MethodGet) {
+		return ScreenDataProvider{}, nil, false
+	}
+
+	params := endpoint.Query()
+	for key, value := range config.Params {
+		params.Set(key, fmt.Sprint(value))
+	}
+	return provider, params, true
+}
+
+// normalizeDataEndpoint acepta el endpoint con o sin el prefijo /api y barra final
+func normalizeDataEndpoint(endpoint string) string {
+	endpoint = strings.TrimSuffix(strings.TrimPrefix(endpoint, "/api"), "/")
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	return endpoint
+}
+
+// intParam lee un entero no negativo; fuera de rango retorna def (max < 0 = sin máximo)
+func intParam(params url.Values, name string, def, max int) int {
+	value, err := strconv.Atoi(params.Get(name))
+	if err != nil || value < 0 || (max >= 0 && value > max) {
+		return def
+	}
+	return value
+}
+
+// ScreenWithDataDTO pantalla con los datos de su DataEndpoint resueltos en el servidor
+// DataIncluded=false indica que el cliente debe pedir los datos como siempre
+// (endpoint externo o sin proveedor); DataError informa por qué no se pudieron resolver
+type ScreenWithDataDTO struct {
+	*dto.CombinedScreenDTO
+	DataIncluded bool                `json:"dataIncluded"`
+	Data         any                 `json:"data,omitempty"`
+	DataError    *ScreenDataErrorDTO `json:"dataError,omitempty"`
+
+	Experiment *ScreenExperimentAssignmentDTO `json:"experiment,omitempty"`
+	Locale     string                         `json:"locale"`
+}
+
+// ScreenDataErrorDTO error al resolver los datos; la pantalla se entrega igual
+type ScreenDataErrorDTO struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// GetScreenWithData retorna la pantalla (con la variante asignada) y, si su DataEndpoint es interno,
+// sus datos resueltos en proceso con los permisos del usuario. Un error en los datos no impide
+// entregar la pantalla
+func (s *screenService) GetScreenWithData(ctx context.Context, screenKey string, audience ScreenAudience, permissions []string) (*ScreenWithDataDTO, error) {
+	screen, assignment, err := s.resolveScreen(ctx, screenKey, audience)
+	if err != nil {
+		return nil, err
+	}
+	recordExposure(assignment, audience.Platform)
+	result := &ScreenWithDataDTO{CombinedScreenDTO: screen, Experiment: assignment, Locale: audience.locale()}
+
+	provider, params, ok := s.data.lookup(screen.DataEndpoint, screen.DataConfig)
+	if !ok {
+		return result, nil
+	}
+
+	if !slices.Contains(permissions, provider.Permission.String()) {
+		result.DataError = &ScreenDataErrorDTO{
+			Code:    "FORBIDDEN",
+			Message: "missing permission " + provider.Permission.String(),
+		}
+		return result, nil
+	}
+
+	data, err := provider.Fetch(ctx, ScreenDataRequest{UserID: audience.UserID, Permissions: permissions, Params: params})
+	if err != nil {
+		s.logger.Warn("failed to resolve screen data",
+			"screen_key", screenKey,
+			"data_endpoint", screen.DataEndpoint,
+			"error", err,
+		)
+		result.DataError = &ScreenDataErrorDTO{Code: "INTERNAL_ERROR", Message: "data could not be resolved"}
+		if appErr, ok := errors.GetAppError(err); ok {
+			result.DataError = &ScreenDataErrorDTO{Code: string(appErr.Code), Message: appErr.Message}
+		}
+		return result, nil
+	}
+
+	result.DataIncluded = true
+	result.Data = data
+	return result, nil
+}
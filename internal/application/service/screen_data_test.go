@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+)
+
+func TestScreenService_GetScreenWithData(t *testing.T) {
+	materials := []string{"m-1", "m-2"}
+
+	tests := []struct {
+		name         string
+		endpoint     string
+		dataConfig   string
+		permissions  []string
+		fetchErr     error
+		wantIncluded bool
+		wantErrCode  string
+		wantParams   url.Values
+	}{
+		{
+			name:         "endpoint interno con permiso",
+			endpoint:     "/v1/materials?limit=5",
+			dataConfig:   `{"method": "GET", "params": {"offset": 10}}`,
+			permissions:  []string{"materials:read"},
+			wantIncluded: true,
+			wantParams:   url.Values{"limit": {"5"}, "offset": {"10"}},
+		},
+		{
+			name:         "prefijo /api y barra final",
+			endpoint:     "/api/v1/materials/",
+			permissions:  []string{"materials:read"},
+			wantIncluded: true,
+			wantParams:   url.Values{},
+		},
+		{
+			name:        "sin permiso",
+			endpoint:    "/v1/materials",
+			permissions: []string{"screens:read"},
+			wantErrCode: "FORBIDDEN",
+		},
+		{
+			name:        "error del proveedor",
+			endpoint:    "/v1/materials",
+			permissions: []string{"materials:read"},
+			fetchErr:    errors.NewDatabaseError("list materials", fmt.Errorf("timeout")),
+			wantErrCode: string(errors.ErrorCodeDatabaseError),
+		},
+		{
+			name:        "endpoint sin proveedor",
+			endpoint:    "/v1/schools/current",
+			permissions: []string{"materials:read"},
+		},
+		{
+			name:        "endpoint externo",
+			endpoint:    "https://example.com/v1/materials",
+			permissions: []string{"materials:read"},
+		},
+		{
+			name:        "método distinto de GET",
+			endpoint:    "/v1/materials",
+			dataConfig:  `{"method": "POST"}`,
+			permissions: []string{"materials:read"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			userID := uuid.New()
+			mockRepo := new(MockScreenRepository)
+			mockLogger := new(MockLogger)
+			mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+			mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+			screen := newTestCombinedScreen("materials-list")
+			screen.DataEndpoint = tt.endpoint
+			screen.DataConfig = json.RawMessage(tt.dataConfig)
+			mockRepo.On("GetCombinedScreen", ctx, "materials-list", userID).Return(screen, nil)
+
+			var fetched *ScreenDataRequest
+			registry := NewScreenDataRegistry()
+			registry.Register(ScreenDataEndpointMaterials, ScreenDataProvider{
+				Permission: enum.PermissionMaterialsRead,
+				Fetch: func(ctx context.Context, req ScreenDataRequest) (any, error) {
+					fetched = &req
+					if tt.fetchErr != nil {
+						return nil, tt.fetchErr
+					}
+					return materials, nil
+				},
+			})
+			svc := NewScreenServiceWithCache(mockRepo, nil, DefaultScreenCacheConfig(), ScreenServiceOptions{Data: registry}, mockLogger)
+
+			result, err := svc.GetScreenWithData(ctx, "materials-list", ScreenAudience{UserID: userID}, tt.permissions)
+
+			require.NoError(t, err)
+			assert.Equal(t, "materials-list", result.ScreenKey)
+			assert.Equal(t, tt.wantIncluded, result.DataIncluded)
+			if tt.wantIncluded {
+				assert.Equal(t, materials, result.Data)
+				require.NotNil(t, fetched)
+				assert.Equal(t, userID, fetched.UserID)
+				assert.Equal(t, tt.wantParams, fetched.Params)
+			} else {
+				assert.Nil(t, result.Data)
+			}
+			if tt.wantErrCode != "" {
+				require.NotNil(t, result.DataError)
+				assert.Equal(t, tt.wantErrCode, result.DataError.Code)
+			} else {
+				assert.Nil(t, result.DataError)
+			}
+			if tt.wantErrCode == "FORBIDDEN" {
+				assert.Nil(t, fetched, "sin permiso no se consulta el proveedor")
+			}
+		})
+	}
+}
+
+func TestScreenService_GetScreenWithData_ScreenNotFound(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	mockRepo := new(MockScreenRepository)
+	mockRepo.On("GetCombinedScreen", ctx, "missing", userID).Return(nil, nil)
+
+	svc := NewScreenServiceWithCache(mockRepo, nil, DefaultScreenCacheConfig(), ScreenServiceOptions{Data: NewScreenDataRegistry()}, new(MockLogger))
+
+	_, err := svc.GetScreenWithData(ctx, "missing", ScreenAudience{UserID: userID}, nil)
+
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeNotFound, appErr.Code)
+}
+
+func TestIntParam(t *testing.T) {
+	params := url.Values{"limit": {"50"}, "big": {"500"}, "neg": {"-1"}, "text": {"abc"}}
+
+	assert.Equal(t, 50, intParam(params, "limit", 20, 100))
+	assert.Equal(t, 20, intParam(params, "big", 20, 100))
+	assert.Equal(t, 500, intParam(params, "big", 0, -1))
+	assert.Equal(t, 0, intParam(params, "neg", 0, -1))
+	assert.Equal(t, 20, intParam(params, "text", 20, 100))
+	assert.Equal(t, 20, intParam(params, "missing", 20, 100))
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// screenExperimentsRefreshInterval cada cuánto se recargan los experimentos activos
+// screen.updated también fuerza la recarga (ver InvalidateScreens)
+const screenExperimentsRefreshInterval = time.Minute
+
+// screenExperimentExposuresTotal cuenta las pantallas servidas con una variante asignada
+// El bundle no registra exposiciones: precarga pantallas que el usuario quizá no abra
+var screenExperimentExposuresTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "screen_experiment_exposures_total",
+		Help: "Total number of screens served with an experiment variant",
+	},
+	[]string{"experiment", "variant", "platform"},
+)
+
+// ScreenAudience usuario que solicita una pantalla: define sus preferencias, el idioma y el
+// targeting de los experimentos. SchoolID y Role salen del contexto activo ("" si no hay)
+type ScreenAudience struct {
+	UserID   uuid.UUID
+	Platform string
+	SchoolID string
+	Role     string
+	// Locale idioma negociado con Accept-Language; vacío = i18n.DefaultLocale
+	Locale string
+}
+
+// ScreenExperimentAssignmentDTO variante de experimento asignada al usuario para una pantalla
+// ScreenKey es la instancia servida: las preferencias de la variante se guardan con esa clave
+type ScreenExperimentAssignmentDTO struct {
+	Experiment string `json:"experiment"`
+	Variant    string `json:"variant"`
+	ScreenKey  string `json:"screenKey"`
+}
+
+// ScreenVariantDTO pantalla servida al usuario con el experimento que la eligió (si lo hay)
+// y el idioma de sus textos. ScreenKey conserva la clave solicitada aunque se sirva otra instancia
+type ScreenVariantDTO struct {
+	*dto.CombinedScreenDTO
+	Experiment *ScreenExperimentAssignmentDTO `json:"experiment,omitempty"`
+	Locale     string                         `json:"locale"`
+}
+
+// GetScreenVariant retorna la pantalla con la variante de experimento asignada al usuario
+// y registra la exposición
+func (s *screenService) GetScreenVariant(ctx context.Context, screenKey string, audience ScreenAudience) (*ScreenVariantDTO, error) {
+	screen, assignment, err := s.resolveScreen(ctx, screenKey, audience)
+	if err != nil {
+		return nil, err
+	}
+	recordExposure(assignment, audience.Platform)
+	return &ScreenVariantDTO{CombinedScreenDTO: screen, Experiment: assignment, Locale: audience.locale()}, nil
+}
+
+// resolveScreen asigna la variante y carga su instancia; si la instancia de la variante no se
+// puede cargar se sirve la pantalla original sin asignación (no cuenta como exposición)
+func (s *screenService) resolveScreen(ctx context.Context, screenKey string, audience ScreenAudience) (*dto.CombinedScreenDTO, *ScreenExperimentAssignmentDTO, error) {
+	assignment := s.experiments.assign(ctx, s.logger, screenKey, audience)
+	if assignment != nil && assignment.ScreenKey != screenKey {
+		variant, err := s.getScreen(ctx, assignment.ScreenKey, audience.UserID, audience.Platform, audience.locale())
+		if err == nil {
+			// Copia: GetScreen puede retornar la entrada del cache
+			result := *variant
+			result.ScreenKey = screenKey
+			return &result, assignment, nil
+		}
+		s.logger.Warn("failed to load screen experiment variant, serving original screen",
+			"screen_key", screenKey,
+			"experiment", assignment.Experiment,
+			"variant", assignment.Variant,
+			"error", err,
+		)
+		assignment = nil
+	}
+
+	screen, err := s.getScreen(ctx, screenKey, audience.UserID, audience.Platform, audience.locale())
+	if err != nil {
+		return nil, nil, err
+	}
+	return screen, assignment, nil
+}
+
+// recordExposure incrementa la métrica de exposiciones de la variante asignada
+func recordExposure(assignment *ScreenExperimentAssignmentDTO, platform string) {
+	if assignment == nil {
+		return
+	}
+	screenExperimentExposuresTotal.WithLabelValues(assignment.Experiment, assignment.Variant, platformLabel(platform)).Inc()
+}
+
+// platformLabel acota la cardinalidad de la etiqueta platform (viene de la query del cliente)
+func platformLabel(platform string) string {
+	switch platform = strings.ToLower(platform); platform {
+	case "mobile", "ios", "android", "web", "desktop":
+		return platform
+	case "":
+		return "unknown"
+	default:
+		return "other"
+	}
+}
+
+// screenExperiments snapshot en memoria de los experimentos activos indexados por pantalla
+// Un repositorio nil deshabilita los experimentos
+type screenExperiments struct {
+	repo     repository.ScreenExperimentRepository
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	byScreen map[string][]*repository.ScreenExperiment
+	loadedAt time.Time
+}
+
+func newScreenExperiments(repo repository.ScreenExperimentRepository) *screenExperiments {
+	if repo == nil {
+		return nil
+	}
+	return &screenExperiments{repo: repo, interval: screenExperimentsRefreshInterval, now: time.Now}
+}
+
+// forScreen retorna los experimentos de la pantalla, recargando el snapshot si venció
+// Ante un error de BD se conserva el snapshot anterior hasta el próximo intervalo
+func (e *screenExperiments) forScreen(ctx context.Context, log logger.Logger, screenKey string) []*repository.ScreenExperiment {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	if e.loadedAt.IsZero() || now.Sub(e.loadedAt) >= e.interval {
+		e.loadedAt = now
+		experiments, err := e.repo.GetActiveExperiments(ctx)
+		if err != nil {
+			log.Warn("failed to load screen experiments", "error", err)
+		} else {
+			e.byScreen = make(map[string][]*repository.ScreenExperiment, len(experiments))
+			for _, exp := range experiments {
+				e.byScreen[exp.ScreenKey] = append(e.byScreen[exp.ScreenKey], exp)
+			}
+		}
+	}
+	return e.byScreen[screenKey]
+}
+
+// invalidate fuerza la recarga en la próxima asignación
+func (e *screenExperiments) invalidate() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	e.loadedAt = time.Time{}
+	e.mu.Unlock()
+}
+
+// assign retorna la variante del primer experimento de la pantalla cuyo targeting aplica
+// al usuario, o nil si ninguno aplica
+func (e *screenExperiments) assign(ctx context.Context, log logger.Logger, screenKey string, audience ScreenAudience) *ScreenExperimentAssignmentDTO {
+	for _, exp := range e.forScreen(ctx, log, screenKey) {
+		if !experimentTargets(exp, audience) {
+			continue
+		}
+		variant, ok := bucketVariant(exp, audience.UserID)
+		if !ok {
+			continue
+		}
+		served := variant.ScreenKey
+		if served == "" {
+			served = screenKey
+		}
+		return &ScreenExperimentAssignmentDTO{Experiment: exp.Key, Variant: variant.Key, ScreenKey: served}
+	}
+	return nil
+}
+
+// experimentTargets evalúa el targeting por plataforma, escuela y rol (lista vacía = todos)
+func experimentTargets(exp *repository.ScreenExperiment, audience ScreenAudience) bool {
+	return targetMatches(exp.Platforms, audience.Platform) &&
+		targetMatches(exp.SchoolIDs, audience.SchoolID) &&
+		targetMatches(exp.Roles, audience.Role)
+}
+
+func targetMatches(targets []string, value string) bool {
+	if len(targets) == 0 {
+		return true
+	}
+	return value != "" && slices.ContainsFunc(targets, func(t string) bool {
+		return strings.EqualFold(t, value)
+	})
+}
+
+// bucketVariant asigna la variante de forma determinística: el hash de experimento + usuario
+// elige un punto en la suma de pesos. El mismo usuario recibe siempre la misma variante mientras
+// los pesos no cambien, y experimentos distintos reparten a los usuarios de forma independiente
+func bucketVariant(exp *repository.ScreenExperiment, userID uuid.UUID) (repository.ScreenExperimentVariant, bool) {
+	total := 0
+	for _, v := range exp.Variants {
+		total += max(v.Weight, 0)
+	}
+	if total == 0 {
+		return repository.ScreenExperimentVariant{}, false
+	}
+
+	sum := sha256.Sum256([]byte(exp.Key + ":" + userID.String()))
+	point := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+	for _, v := range exp.Variants {
+		if v.Weight <= 0 {
+			continue
+		}
+		if point < v.Weight {
+			return v, true
+		}
+		point -= v.Weight
+	}
+	return repository.ScreenExperimentVariant{}, false
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// MockScreenExperimentRepository mock del repositorio de experimentos
+type MockScreenExperimentRepository struct {
+	mock.Mock
+}
+
+func (m *MockScreenExperimentRepository) GetActiveExperiments(ctx context.Context) ([]*repository.ScreenExperiment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.ScreenExperiment), args.Error(1)
+}
+
+// newTestExperiment experimento 50/50 sobre materials-list: control y grilla
+func newTestExperiment() *repository.ScreenExperiment {
+	return &repository.ScreenExperiment{
+		ID:        "exp-1",
+		Key:       "materials-layout",
+		ScreenKey: "materials-list",
+		Variants: []repository.ScreenExperimentVariant{
+			{Key: "control", Weight: 50},
+			{Key: "grid", Weight: 50, ScreenKey: "materials-list-grid"},
+		},
+	}
+}
+
+func TestBucketVariant(t *testing.T) {
+	tests := []struct {
+		name      string
+		variants  []repository.ScreenExperimentVariant
+		wantShare map[string]float64 // proporción esperada por variante (±3%)
+		wantNone  bool
+	}{
+		{
+			name:      "50/50",
+			variants:  []repository.ScreenExperimentVariant{{Key: "a", Weight: 50}, {Key: "b", Weight: 50}},
+			wantShare: map[string]float64{"a": 0.5, "b": 0.5},
+		},
+		{
+			name:      "90/10",
+			variants:  []repository.ScreenExperimentVariant{{Key: "a", Weight: 9}, {Key: "b", Weight: 1}},
+			wantShare: map[string]float64{"a": 0.9, "b": 0.1},
+		},
+		{
+			name:      "peso cero nunca se asigna",
+			variants:  []repository.ScreenExperimentVariant{{Key: "a", Weight: 0}, {Key: "b", Weight: 3}, {Key: "c", Weight: -1}},
+			wantShare: map[string]float64{"b": 1},
+		},
+		{
+			name:     "sin pesos positivos",
+			variants: []repository.ScreenExperimentVariant{{Key: "a", Weight: 0}},
+			wantNone: true,
+		},
+		{
+			name:     "sin variantes",
+			wantNone: true,
+		},
+	}
+
+	const users = 10000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp := &repository.ScreenExperiment{Key: "exp", Variants: tt.variants}
+			counts := make(map[string]int)
+			for i := 0; i < users; i++ {
+				userID := uuid.New()
+				variant, ok := bucketVariant(exp, userID)
+				if tt.wantNone {
+					require.False(t, ok)
+					return
+				}
+				require.True(t, ok)
+				again, _ := bucketVariant(exp, userID)
+				require.Equal(t, variant.Key, again.Key, "la asignación debe ser determinística")
+				counts[variant.Key]++
+			}
+			for key, share := range tt.wantShare {
+				assert.InDelta(t, share, float64(counts[key])/users, 0.03, "variante %s", key)
+			}
+			assert.Len(t, counts, len(tt.wantShare))
+		})
+	}
+}
+
+func TestBucketVariant_IndependentPerExperiment(t *testing.T) {
+	first := &repository.ScreenExperiment{Key: "exp-a", Variants: []repository.ScreenExperimentVariant{{Key: "a", Weight: 1}, {Key: "b", Weight: 1}}}
+	second := &repository.ScreenExperiment{Key: "exp-b", Variants: first.Variants}
+
+	same := 0
+	const users = 2000
+	for i := 0; i < users; i++ {
+		userID := uuid.New()
+		v1, _ := bucketVariant(first, userID)
+		v2, _ := bucketVariant(second, userID)
+		if v1.Key == v2.Key {
+			same++
+		}
+	}
+	// Con repartos independientes coinciden ~50% de las veces, no siempre
+	assert.InDelta(t, 0.5, float64(same)/users, 0.05)
+}
+
+func TestExperimentTargets(t *testing.T) {
+	audience := ScreenAudience{UserID: uuid.New(), Platform: "ios", SchoolID: "school-1", Role: "teacher"}
+
+	tests := []struct {
+		name      string
+		platforms []string
+		schoolIDs []string
+		roles     []string
+		audience  ScreenAudience
+		want      bool
+	}{
+		{name: "sin targeting", audience: audience, want: true},
+		{name: "plataforma incluida", platforms: []string{"android", "iOS"}, audience: audience, want: true},
+		{name: "plataforma excluida", platforms: []string{"android"}, audience: audience, want: false},
+		{name: "escuela incluida", schoolIDs: []string{"school-1"}, audience: audience, want: true},
+		{name: "escuela excluida", schoolIDs: []string{"school-2"}, audience: audience, want: false},
+		{name: "rol incluido", roles: []string{"student", "teacher"}, audience: audience, want: true},
+		{name: "rol excluido", roles: []string{"student"}, audience: audience, want: false},
+		{name: "todas las dimensiones", platforms: []string{"ios"}, schoolIDs: []string{"school-1"}, roles: []string{"teacher"}, audience: audience, want: true},
+		{name: "sin contexto activo con targeting por escuela", schoolIDs: []string{"school-1"}, audience: ScreenAudience{Platform: "ios"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp := &repository.ScreenExperiment{Platforms: tt.platforms, SchoolIDs: tt.schoolIDs, Roles: tt.roles}
+			assert.Equal(t, tt.want, experimentTargets(exp, tt.audience))
+		})
+	}
+}
+
+// findUser busca un usuario al que el experimento asigna la variante indicada
+func findUser(t *testing.T, exp *repository.ScreenExperiment, variant string) uuid.UUID {
+	t.Helper()
+	for i := 0; i < 1000; i++ {
+		userID := uuid.New()
+		if v, _ := bucketVariant(exp, userID); v.Key == variant {
+			return userID
+		}
+	}
+	t.Fatalf("no user found for variant %s", variant)
+	return uuid.Nil
+}
+
+func newExperimentTestService(repo *MockScreenRepository, experiments *MockScreenExperimentRepository) *screenService {
+	mockLogger := new(MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	return NewScreenServiceWithCache(repo, nil, DefaultScreenCacheConfig(), ScreenServiceOptions{Experiments: experiments}, mockLogger).(*screenService)
+}
+
+func TestScreenService_GetScreenVariant(t *testing.T) {
+	ctx := context.Background()
+	exp := newTestExperiment()
+
+	tests := []struct {
+		name        string
+		variant     string
+		platform    string
+		wantScreen  string // instancia servida
+		wantVariant string
+	}{
+		{name: "variante con instancia propia", variant: "grid", platform: "ios", wantScreen: "materials-list-grid", wantVariant: "grid"},
+		{name: "control sirve la pantalla original", variant: "control", platform: "android", wantScreen: "materials-list", wantVariant: "control"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := findUser(t, exp, tt.variant)
+			repo := new(MockScreenRepository)
+			experiments := new(MockScreenExperimentRepository)
+			experiments.On("GetActiveExperiments", ctx).Return([]*repository.ScreenExperiment{exp}, nil)
+			served := newTestCombinedScreen(tt.wantScreen)
+			served.ID = "si-" + tt.wantScreen
+			repo.On("GetCombinedScreen", ctx, tt.wantScreen, userID).Return(served, nil)
+
+			exposures := testutil.ToFloat64(screenExperimentExposuresTotal.WithLabelValues(exp.Key, tt.wantVariant, tt.platform))
+			svc := newExperimentTestService(repo, experiments)
+
+			result, err := svc.GetScreenVariant(ctx, "materials-list", ScreenAudience{UserID: userID, Platform: tt.platform})
+
+			require.NoError(t, err)
+			assert.Equal(t, "materials-list", result.ScreenKey, "el cliente ve la clave solicitada")
+			assert.Equal(t, "si-"+tt.wantScreen, result.ScreenID)
+			require.NotNil(t, result.Experiment)
+			assert.Equal(t, ScreenExperimentAssignmentDTO{Experiment: exp.Key, Variant: tt.wantVariant, ScreenKey: tt.wantScreen}, *result.Experiment)
+			assert.Equal(t, exposures+1, testutil.ToFloat64(screenExperimentExposuresTotal.WithLabelValues(exp.Key, tt.wantVariant, tt.platform)))
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScreenService_GetScreenVariant_NotTargeted(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	exp := newTestExperiment()
+	exp.Roles = []string{"student"}
+
+	repo := new(MockScreenRepository)
+	experiments := new(MockScreenExperimentRepository)
+	experiments.On("GetActiveExperiments", ctx).Return([]*repository.ScreenExperiment{exp}, nil)
+	repo.On("GetCombinedScreen", ctx, "materials-list", userID).Return(newTestCombinedScreen("materials-list"), nil)
+
+	svc := newExperimentTestService(repo, experiments)
+	result, err := svc.GetScreenVariant(ctx, "materials-list", ScreenAudience{UserID: userID, Role: "teacher"})
+
+	require.NoError(t, err)
+	assert.Nil(t, result.Experiment)
+	assert.Equal(t, "materials-list", result.ScreenKey)
+}
+
+func TestScreenService_GetScreenVariant_MissingVariantFallsBack(t *testing.T) {
+	ctx := context.Background()
+	exp := newTestExperiment()
+	userID := findUser(t, exp, "grid")
+
+	repo := new(MockScreenRepository)
+	experiments := new(MockScreenExperimentRepository)
+	experiments.On("GetActiveExperiments", ctx).Return([]*repository.ScreenExperiment{exp}, nil)
+	repo.On("GetCombinedScreen", ctx, "materials-list-grid", userID).Return(nil, nil)
+	repo.On("GetCombinedScreen", ctx, "materials-list", userID).Return(newTestCombinedScreen("materials-list"), nil)
+
+	svc := newExperimentTestService(repo, experiments)
+	result, err := svc.GetScreenVariant(ctx, "materials-list", ScreenAudience{UserID: userID})
+
+	require.NoError(t, err)
+	assert.Nil(t, result.Experiment, "sin la instancia de la variante no hay asignación")
+	assert.Equal(t, "materials-list", result.ScreenKey)
+}
+
+func TestScreenService_Experiments_RefreshAndInvalidate(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	repo := new(MockScreenRepository)
+	repo.On("GetCombinedScreen", ctx, mock.Anything, userID).Return(newTestCombinedScreen("materials-list"), nil)
+	repo.On("GetUserPreferences", ctx, mock.Anything, userID).Return(nil, nil).Maybe()
+	experiments := new(MockScreenExperimentRepository)
+	experiments.On("GetActiveExperiments", ctx).Return(nil, fmt.Errorf("connection refused")).Once()
+	experiments.On("GetActiveExperiments", ctx).Return([]*repository.ScreenExperiment{newTestExperiment()}, nil)
+
+	svc := newExperimentTestService(repo, experiments)
+	svc.experiments.now = func() time.Time { return now }
+	audience := ScreenAudience{UserID: userID}
+
+	// Error de BD: sin experimentos hasta el próximo intervalo
+	result, err := svc.GetScreenVariant(ctx, "materials-list", audience)
+	require.NoError(t, err)
+	assert.Nil(t, result.Experiment)
+
+	now = now.Add(screenExperimentsRefreshInterval / 2)
+	result, err = svc.GetScreenVariant(ctx, "materials-list", audience)
+	require.NoError(t, err)
+	assert.Nil(t, result.Experiment)
+	experiments.AssertNumberOfCalls(t, "GetActiveExperiments", 1)
+
+	// screen.updated fuerza la recarga
+	svc.InvalidateScreens("materials-list")
+	result, err = svc.GetScreenVariant(ctx, "materials-list", audience)
+	require.NoError(t, err)
+	assert.NotNil(t, result.Experiment)
+
+	now = now.Add(screenExperimentsRefreshInterval)
+	_, err = svc.GetScreenVariant(ctx, "materials-list", audience)
+	require.NoError(t, err)
+	experiments.AssertNumberOfCalls(t, "GetActiveExperiments", 3)
+}
+
+func TestPlatformLabel(t *testing.T) {
+	assert.Equal(t, "ios", platformLabel("iOS"))
+	assert.Equal(t, "unknown", platformLabel(""))
+	assert.Equal(t, "other", platformLabel("smart-fridge"))
+}
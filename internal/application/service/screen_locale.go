@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/i18n"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// locale idioma soportado de la audiencia; uno desconocido o vacío cae en el idioma original
+func (a ScreenAudience) locale() string {
+	if locale, ok := i18n.Supported(a.Locale); ok {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+// localizeSlots reemplaza los textos de los slots por sus traducciones; las claves sin traducción
+// conservan el texto original. ok=false si las traducciones no se pudieron leer (se usa el original)
+// Los textos literales del template no se traducen: deben declararse como slots
+func (s *screenService) localizeSlots(ctx context.Context, screenKey, locale string, slotData json.RawMessage) (json.RawMessage, bool) {
+	if locale == i18n.DefaultLocale || s.translations == nil {
+		return slotData, true
+	}
+
+	translations, err := s.translations.GetSlotTranslations(ctx, screenKey, locale)
+	if err != nil {
+		s.logger.Warn("failed to get slot translations, serving original texts",
+			"screen_key", screenKey,
+			"locale", locale,
+			"error", err,
+		)
+		return slotData, false
+	}
+	if len(translations) == 0 {
+		return slotData, true
+	}
+
+	slots := make(map[string]any, len(translations))
+	if len(slotData) > 0 {
+		if err := json.Unmarshal(slotData, &slots); err != nil || slots == nil {
+			// Slots ilegibles: ResolveSlots tampoco los usará
+			return slotData, true
+		}
+	}
+	for key, text := range translations {
+		slots[key] = text
+	}
+
+	localized, err := json.Marshal(slots)
+	if err != nil {
+		return slotData, true
+	}
+	return localized, true
+}
+
+// localizeMenu retorna copias de los recursos con la etiqueta traducida cuando existe
+func (s *screenService) localizeMenu(ctx context.Context, resources []*repository.MenuResource, locale string) []*repository.MenuResource {
+	if locale == i18n.DefaultLocale || s.translations == nil || len(resources) == 0 {
+		return resources
+	}
+
+	labels, err := s.translations.GetResourceTranslations(ctx, locale)
+	if err != nil {
+		s.logger.Warn("failed to get resource translations, serving original labels", "locale", locale, "error", err)
+		return resources
+	}
+	if len(labels) == 0 {
+		return resources
+	}
+
+	localized := make([]*repository.MenuResource, len(resources))
+	for i, res := range resources {
+		copied := *res
+		if label, ok := labels[res.Key]; ok && label != "" {
+			copied.DisplayName = label
+		}
+		localized[i] = &copied
+	}
+	return localized
+}
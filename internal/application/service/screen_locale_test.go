@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// MockScreenTranslationRepository mock del repositorio de traducciones
+type MockScreenTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockScreenTranslationRepository) GetSlotTranslations(ctx context.Context, screenKey, locale string) (map[string]string, error) {
+	args := m.Called(ctx, screenKey, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockScreenTranslationRepository) GetResourceTranslations(ctx context.Context, locale string) (map[string]string, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func newLocaleTestService(repo *MockScreenRepository, reader *MockResourceReader, translations *MockScreenTranslationRepository) ScreenService {
+	mockLogger := new(MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	return NewScreenServiceWithCache(repo, reader, DefaultScreenCacheConfig(), ScreenServiceOptions{Translations: translations}, mockLogger)
+}
+
+// screenTitle extrae el título resuelto del template de newTestCombinedScreen
+func screenTitle(t *testing.T, template json.RawMessage) string {
+	t.Helper()
+	var def struct {
+		Navigation struct {
+			TopBar struct {
+				Title string `json:"title"`
+			} `json:"topBar"`
+		} `json:"navigation"`
+	}
+	require.NoError(t, json.Unmarshal(template, &def))
+	return def.Navigation.TopBar.Title
+}
+
+func TestScreenService_GetScreenVariant_Localized(t *testing.T) {
+	tests := []struct {
+		name         string
+		locale       string
+		translations map[string]string
+		wantTitle    string
+		wantLocale   string
+	}{
+		{name: "traducción disponible", locale: "pt", translations: map[string]string{"page_title": "Lista de materiais"}, wantTitle: "Lista de materiais", wantLocale: "pt"},
+		{name: "slot sin traducción usa el original", locale: "en", translations: map[string]string{"other": "Other"}, wantTitle: "Materials List", wantLocale: "en"},
+		{name: "idioma original no consulta traducciones", locale: "es", wantTitle: "Materials List", wantLocale: "es"},
+		{name: "idioma desconocido cae al original", locale: "fr", wantTitle: "Materials List", wantLocale: "es"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			userID := uuid.New()
+			repo := new(MockScreenRepository)
+			repo.On("GetCombinedScreen", ctx, "materials-list", userID).Return(newTestCombinedScreen("materials-list"), nil)
+			translations := new(MockScreenTranslationRepository)
+			if tt.translations != nil {
+				translations.On("GetSlotTranslations", ctx, "materials-list", tt.locale).Return(tt.translations, nil)
+			}
+
+			svc := newLocaleTestService(repo, nil, translations)
+			result, err := svc.GetScreenVariant(ctx, "materials-list", ScreenAudience{UserID: userID, Locale: tt.locale})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLocale, result.Locale)
+			assert.Equal(t, tt.wantTitle, screenTitle(t, result.Template))
+			translations.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScreenService_GetScreenVariant_CachePerLocale(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	repo := new(MockScreenRepository)
+	repo.On("GetCombinedScreen", ctx, "materials-list", userID).Return(newTestCombinedScreen("materials-list"), nil)
+	repo.On("GetUserPreferences", ctx, "materials-list", userID).Return(json.RawMessage(`{}`), nil)
+	translations := new(MockScreenTranslationRepository)
+	translations.On("GetSlotTranslations", ctx, "materials-list", "pt").Return(map[string]string{"page_title": "Lista de materiais"}, nil)
+	translations.On("GetSlotTranslations", ctx, "materials-list", "en").Return(nil, fmt.Errorf("connection refused"))
+
+	svc := newLocaleTestService(repo, nil, translations)
+	fetch := func(locale string) string {
+		result, err := svc.GetScreenVariant(ctx, "materials-list", ScreenAudience{UserID: userID, Locale: locale})
+		require.NoError(t, err)
+		return screenTitle(t, result.Template)
+	}
+
+	assert.Equal(t, "Lista de materiais", fetch("pt"))
+	assert.Equal(t, "Materials List", fetch("es"), "el cache de pt no se sirve en es")
+	assert.Equal(t, "Lista de materiais", fetch("pt"))
+	repo.AssertNumberOfCalls(t, "GetCombinedScreen", 2)
+
+	// Si las traducciones fallan se sirve el original sin cachearlo
+	assert.Equal(t, "Materials List", fetch("en"))
+	assert.Equal(t, "Materials List", fetch("en"))
+	repo.AssertNumberOfCalls(t, "GetCombinedScreen", 4)
+	translations.AssertNumberOfCalls(t, "GetSlotTranslations", 3)
+}
+
+func TestScreenService_GetNavigationConfig_Localized(t *testing.T) {
+	ctx := context.Background()
+	reader := new(MockResourceReader)
+	reader.On("GetMenuResources", ctx).Return([]*repository.MenuResource{
+		{ID: "r1", Key: "dashboard", DisplayName: "Inicio", SortOrder: 0, Scope: "system"},
+		{ID: "r2", Key: "materials", DisplayName: "Materiales", SortOrder: 1, Scope: "school"},
+	}, nil)
+	reader.On("GetResourceScreenMappings", ctx, []string{"dashboard", "materials"}).Return([]*repository.ResourceScreenMapping{
+		{ResourceKey: "dashboard", ScreenKey: "dashboard-teacher", IsDefault: true},
+		{ResourceKey: "materials", ScreenKey: "materials-list", IsDefault: true},
+	}, nil)
+	translations := new(MockScreenTranslationRepository)
+	translations.On("GetResourceTranslations", ctx, "en").Return(map[string]string{"dashboard": "Home"}, nil)
+	translations.On("GetResourceTranslations", ctx, "pt").Return(nil, fmt.Errorf("timeout"))
+
+	svc := newLocaleTestService(new(MockScreenRepository), reader, translations)
+	labels := func(locale string) []string {
+		nav, err := svc.GetNavigationConfig(ctx, ScreenAudience{Platform: "mobile", Locale: locale}, []string{"materials:read"})
+		require.NoError(t, err)
+		var result []string
+		for _, item := range nav.BottomNav {
+			result = append(result, item.Label)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Home", "Materiales"}, labels("en"), "sin traducción se usa la etiqueta original")
+	assert.Equal(t, []string{"Inicio", "Materiales"}, labels("pt"), "error de BD: etiquetas originales")
+	assert.Equal(t, []string{"Inicio", "Materiales"}, labels(""))
+}
+
+func TestScreenService_GetScreenBundle_LocaleChangeReturnsFullBundle(t *testing.T) {
+	env := newBundleTestEnv(t)
+	permissions := []string{"materials:read"}
+
+	first, err := env.svc.GetScreenBundle(env.ctx, ScreenAudience{UserID: env.userID, Platform: "ios"}, permissions, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "es", first.Locale)
+
+	// Sin repositorio de traducciones el contenido coincide, pero el bundle es de otro idioma
+	second, err := env.svc.GetScreenBundle(env.ctx, ScreenAudience{UserID: env.userID, Platform: "ios", Locale: "en"}, permissions, &BundleSince{
+		Hash: first.Hash, Platform: first.Platform, Locale: first.Locale, NavigationHash: first.NavigationHash, Versions: first.Versions,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, second.Delta)
+	assert.Equal(t, "en", second.Locale)
+	assert.NotEqual(t, first.Hash, second.Hash)
+	assert.Len(t, second.Screens, len(first.Screens))
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// MaxScreenPreferencesBytes tamaño máximo de las preferencias de un usuario para una pantalla
+const MaxScreenPreferencesBytes = 16 * 1024
+
+// Límites comunes de los schemas de preferencias
+const (
+	maxPreferenceKeyLength   = 64
+	maxPreferenceValueLength = 256
+	maxPreferenceItems       = 50
+)
+
+// ResetUserPreferences elimina las preferencias del usuario; la pantalla vuelve a sus valores por defecto
+func (s *screenService) ResetUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID) error {
+	if err := s.repo.DeleteUserPreferences(ctx, screenKey, userID); err != nil {
+		s.logger.Error("failed to reset user preferences",
+			"screen_key", screenKey,
+			"user_id", userID.String(),
+			"error", err,
+		)
+		return errors.NewDatabaseError("reset user preferences", err)
+	}
+
+	s.logger.Info("user preferences reset", "screen_key", screenKey, "user_id", userID.String())
+	return nil
+}
+
+// PatchUserPreferences aplica un JSON Merge Patch (RFC 7386) sobre las preferencias actuales
+// y guarda el resultado validado. Retorna las preferencias resultantes
+func (s *screenService) PatchUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID, patch json.RawMessage) (json.RawMessage, error) {
+	if len(patch) > MaxScreenPreferencesBytes {
+		return nil, preferencesTooLargeError()
+	}
+	var patchDoc any
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, errors.NewValidationError("preferences patch must be valid JSON")
+	}
+
+	current, err := s.repo.GetUserPreferences(ctx, screenKey, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get user preferences", err)
+	}
+	var currentDoc any = map[string]any{}
+	if len(current) > 0 {
+		if err := json.Unmarshal(current, &currentDoc); err != nil {
+			// Preferencias guardadas antes de validar: el patch parte de cero
+			s.logger.Warn("discarding unreadable user preferences", "screen_key", screenKey, "user_id", userID.String())
+			currentDoc = map[string]any{}
+		}
+	}
+
+	merged, err := json.Marshal(mergePatch(currentDoc, patchDoc))
+	if err != nil {
+		return nil, errors.NewInternalError("merge user preferences", err)
+	}
+
+	if err := s.SaveUserPreferences(ctx, screenKey, userID, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// GetUserPreferencesHistory retorna las versiones anteriores de las preferencias, más recientes primero
+func (s *screenService) GetUserPreferencesHistory(ctx context.Context, screenKey string, userID uuid.UUID) ([]dto.ScreenPreferencesVersionDTO, error) {
+	versions, err := s.repo.GetUserPreferencesHistory(ctx, screenKey, userID)
+	if err != nil {
+		s.logger.Error("failed to get user preferences history", "screen_key", screenKey, "error", err)
+		return nil, errors.NewDatabaseError("get user preferences history", err)
+	}
+
+	result := make([]dto.ScreenPreferencesVersionDTO, 0, len(versions))
+	for _, v := range versions {
+		result = append(result, dto.ScreenPreferencesVersionDTO{
+			Preferences: v.Preferences,
+			SavedAt:     v.SavedAt,
+			ReplacedAt:  v.ReplacedAt,
+		})
+	}
+	return result, nil
+}
+
+// validatePreferences verifica tamaño, que sea un objeto JSON y el schema derivado del template de la pantalla
+func (s *screenService) validatePreferences(ctx context.Context, screenKey string, userID uuid.UUID, prefs json.RawMessage) error {
+	if len(prefs) > MaxScreenPreferencesBytes {
+		return preferencesTooLargeError()
+	}
+	if trimmed := bytes.TrimSpace(prefs); len(trimmed) == 0 || trimmed[0] != '{' || !json.Valid(trimmed) {
+		return errors.NewValidationError("preferences must be a JSON object")
+	}
+
+	// La pantalla (cacheada) define las zonas que las preferencias pueden referenciar y sus claves permitidas
+	screen, err := s.GetScreen(ctx, screenKey, userID, "")
+	if err != nil {
+		return err
+	}
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(preferencesSchema(screen.Template)))
+	if err != nil {
+		return errors.NewInternalError("build preferences schema", err)
+	}
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(prefs))
+	if err != nil {
+		return errors.NewValidationError("preferences must be a JSON object")
+	}
+	if !result.Valid() {
+		details := make([]string, 0, len(result.Errors()))
+		for _, e := range result.Errors() {
+			details = append(details, e.String())
+		}
+		return errors.NewValidationError("invalid preferences: " + strings.Join(details, "; "))
+	}
+	return nil
+}
+
+func preferencesTooLargeError() error {
+	return errors.NewValidationError(fmt.Sprintf("preferences exceed %d bytes", MaxScreenPreferencesBytes))
+}
+
+// preferencesSchema deriva el JSON Schema de las preferencias a partir del template:
+//   - las claves de zonas (hiddenZones, zoneOrder, collapsedZones) solo referencian zonas del template
+//   - si el template declara un bloque "preferences" (clave -> JSON Schema), solo se aceptan esas claves
+//   - sin bloque, cualquier clave se acepta con límites de tamaño y tipo: escalares, listas de escalares
+//     u objetos planos de escalares
+func preferencesSchema(template json.RawMessage) map[string]any {
+	zone := map[string]any{"type": "string", "maxLength": maxPreferenceKeyLength}
+	if ids := templateZoneIDs(template); len(ids) > 0 {
+		zone = map[string]any{"enum": ids}
+	}
+	zoneList := map[string]any{"type": "array", "items": zone, "uniqueItems": true, "maxItems": maxPreferenceItems}
+
+	properties := map[string]any{
+		"hiddenZones":    zoneList,
+		"zoneOrder":      zoneList,
+		"collapsedZones": zoneList,
+	}
+
+	declared := templatePreferences(template)
+	for key, schema := range declared {
+		if _, reserved := properties[key]; !reserved {
+			properties[key] = schema
+		}
+	}
+
+	var additional any = false
+	if declared == nil {
+		scalar := map[string]any{
+			"type":      []any{"string", "number", "boolean", "null"},
+			"maxLength": maxPreferenceValueLength,
+		}
+		additional = map[string]any{
+			"anyOf": []any{
+				scalar,
+				map[string]any{"type": "array", "items": scalar, "maxItems": maxPreferenceItems},
+				map[string]any{
+					"type":                 "object",
+					"maxProperties":        maxPreferenceItems,
+					"propertyNames":        map[string]any{"maxLength": maxPreferenceKeyLength},
+					"additionalProperties": scalar,
+				},
+			},
+		}
+	}
+
+	return map[string]any{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"type":                 "object",
+		"maxProperties":        maxPreferenceItems,
+		"propertyNames":        map[string]any{"maxLength": maxPreferenceKeyLength},
+		"properties":           properties,
+		"additionalProperties": additional,
+	}
+}
+
+// templatePreferences retorna el bloque "preferences" del template (clave -> JSON Schema),
+// o nil si el template no lo declara
+func templatePreferences(template json.RawMessage) map[string]any {
+	var root struct {
+		Preferences map[string]any `json:"preferences"`
+	}
+	if err := json.Unmarshal(template, &root); err != nil {
+		return nil
+	}
+	return root.Preferences
+}
+
+// templateZoneIDs recolecta los ids de zonas del template, incluyendo zonas anidadas
+func templateZoneIDs(template json.RawMessage) []any {
+	type zoneNode struct {
+		ID    string     `json:"id"`
+		Zones []zoneNode `json:"zones"`
+	}
+	var root struct {
+		Zones []zoneNode `json:"zones"`
+	}
+	if err := json.Unmarshal(template, &root); err != nil {
+		return nil
+	}
+
+	var ids []any
+	seen := make(map[string]bool)
+	var walk func(zones []zoneNode)
+	walk = func(zones []zoneNode) {
+		for _, z := range zones {
+			if z.ID != "" && !seen[z.ID] {
+				seen[z.ID] = true
+				ids = append(ids, z.ID)
+			}
+			walk(z.Zones)
+		}
+	}
+	walk(root.Zones)
+	return ids
+}
+
+// mergePatch aplica JSON Merge Patch (RFC 7386): null elimina la clave y los objetos se fusionan
+// recursivamente; cualquier otro valor reemplaza al actual
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+	return targetObj
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// listPreferencesBlock bloque "preferences" de un template de lista
+const listPreferencesBlock = `{
+	"sortBy": {"type": "string", "maxLength": 64},
+	"sortOrder": {"enum": ["asc", "desc"]},
+	"pageSize": {"type": "integer", "minimum": 5, "maximum": 100}
+}`
+
+// newPreferencesTestService servicio con una pantalla de zonas header/list_content
+// preferences es el bloque "preferences" del template; vacío = el template no lo declara
+func newPreferencesTestService(t *testing.T, preferences string) (ScreenService, *MockScreenRepository, uuid.UUID) {
+	t.Helper()
+	userID := uuid.New()
+	mockRepo := new(MockScreenRepository)
+	mockLogger := new(MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	screen := newTestCombinedScreen("materials-list")
+	screen.Pattern = "list"
+	definition := `{"zones": [{"id": "header", "zones": [{"id": "search_bar"}]}, {"id": "list_content"}]`
+	if preferences != "" {
+		definition += `, "preferences": ` + preferences
+	}
+	screen.Definition = json.RawMessage(definition + `}`)
+	mockRepo.On("GetCombinedScreen", mock.Anything, "materials-list", userID).Return(screen, nil)
+
+	return NewScreenService(mockRepo, nil, mockLogger), mockRepo, userID
+}
+
+func TestScreenService_SaveUserPreferences_Schema(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences string // Bloque "preferences" del template
+		prefs       string
+		wantErr     string // Fragmento del mensaje; vacío = válido
+	}{
+		// Sin bloque: cualquier clave con límites de tamaño y tipo
+		{name: "claves libres", prefs: `{"sortBy": "title", "theme": "dark", "visibleColumns": ["title", "status"], "filters": {"status": "published"}}`},
+		{name: "zonas del template", prefs: `{"hiddenZones": ["search_bar"], "zoneOrder": ["list_content", "header"]}`},
+		{name: "objeto vacío", prefs: `{}`},
+		{name: "zona inexistente", prefs: `{"hiddenZones": ["footer"]}`, wantErr: "hiddenZones"},
+		{name: "filtro anidado", prefs: `{"filters": {"status": {"in": ["a"]}}}`, wantErr: "filters"},
+		{name: "valor demasiado largo", prefs: `{"theme": "` + strings.Repeat("x", maxPreferenceValueLength+1) + `"}`, wantErr: "theme"},
+		{name: "clave demasiado larga", prefs: `{"` + strings.Repeat("k", maxPreferenceKeyLength+1) + `": 1}`, wantErr: "length"},
+		{name: "no es objeto", prefs: `["sortBy"]`, wantErr: "JSON object"},
+		{name: "demasiado grande", prefs: `{"notes": "` + strings.Repeat("x", MaxScreenPreferencesBytes) + `"}`, wantErr: "exceed"},
+
+		// Con bloque: solo las claves declaradas por el template, más las de zonas
+		{name: "claves del template", preferences: listPreferencesBlock, prefs: `{"sortBy": "title", "sortOrder": "desc", "pageSize": 20, "hiddenZones": ["header"]}`},
+		{name: "clave no declarada", preferences: listPreferencesBlock, prefs: `{"theme": "dark"}`, wantErr: "theme"},
+		{name: "pageSize fuera de rango", preferences: listPreferencesBlock, prefs: `{"pageSize": 1000}`, wantErr: "pageSize"},
+		{name: "bloque vacío", preferences: `{}`, prefs: `{"sortBy": "title"}`, wantErr: "sortBy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockRepo, userID := newPreferencesTestService(t, tt.preferences)
+			prefs := json.RawMessage(tt.prefs)
+			mockRepo.On("SaveUserPreferences", mock.Anything, "materials-list", userID, prefs).Return(nil).Maybe()
+
+			err := svc.SaveUserPreferences(context.Background(), "materials-list", userID, prefs)
+
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				mockRepo.AssertCalled(t, "SaveUserPreferences", mock.Anything, "materials-list", userID, prefs)
+				return
+			}
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok, "se esperaba AppError, se obtuvo %v", err)
+			assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+			assert.Contains(t, appErr.Message, tt.wantErr)
+			mockRepo.AssertNotCalled(t, "SaveUserPreferences", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestScreenService_PatchUserPreferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		patch    string
+		want     string
+		wantCode errors.ErrorCode
+	}{
+		{
+			name:    "fusiona, reemplaza y elimina",
+			current: `{"sortBy": "title", "viewMode": "list", "filters": {"status": "draft", "subject": "math"}}`,
+			patch:   `{"sortBy": null, "viewMode": "grid", "filters": {"subject": null, "level": 2}}`,
+			want:    `{"viewMode": "grid", "filters": {"status": "draft", "level": 2}}`,
+		},
+		{
+			name:    "sin preferencias previas",
+			current: `{}`,
+			patch:   `{"pageSize": 50}`,
+			want:    `{"pageSize": 50}`,
+		},
+		{
+			name:    "preferencias previas ilegibles",
+			current: `not json`,
+			patch:   `{"sortOrder": "asc"}`,
+			want:    `{"sortOrder": "asc"}`,
+		},
+		{
+			name:     "resultado fuera del schema",
+			current:  `{"sortBy": "title"}`,
+			patch:    `{"hiddenZones": ["footer"]}`,
+			wantCode: errors.ErrorCodeValidation,
+		},
+		{
+			name:     "patch no es JSON",
+			current:  `{}`,
+			patch:    `{sortBy}`,
+			wantCode: errors.ErrorCodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockRepo, userID := newPreferencesTestService(t, "")
+			ctx := context.Background()
+			mockRepo.On("GetUserPreferences", ctx, "materials-list", userID).Return(json.RawMessage(tt.current), nil)
+
+			var saved json.RawMessage
+			mockRepo.On("SaveUserPreferences", ctx, "materials-list", userID, mock.Anything).
+				Run(func(args mock.Arguments) { saved = args.Get(3).(json.RawMessage) }).
+				Return(nil).Maybe()
+
+			result, err := svc.PatchUserPreferences(ctx, "materials-list", userID, json.RawMessage(tt.patch))
+
+			if tt.wantCode != "" {
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				assert.Nil(t, saved, "no se guarda un resultado inválido")
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(result))
+			assert.JSONEq(t, tt.want, string(saved))
+		})
+	}
+}
+
+func TestMergePatch_RFC7386Examples(t *testing.T) {
+	tests := []struct {
+		target, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.patch, func(t *testing.T) {
+			var target, patch any
+			require.NoError(t, json.Unmarshal([]byte(tt.target), &target))
+			require.NoError(t, json.Unmarshal([]byte(tt.patch), &patch))
+
+			got, err := json.Marshal(mergePatch(target, patch))
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestScreenService_ResetUserPreferences(t *testing.T) {
+	svc, mockRepo, userID := newPreferencesTestService(t, "")
+	ctx := context.Background()
+
+	mockRepo.On("DeleteUserPreferences", ctx, "materials-list", userID).Return(nil).Once()
+	require.NoError(t, svc.ResetUserPreferences(ctx, "materials-list", userID))
+
+	mockRepo.On("DeleteUserPreferences", ctx, "materials-list", userID).Return(fmt.Errorf("connection refused")).Once()
+	err := svc.ResetUserPreferences(ctx, "materials-list", userID)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeDatabaseError, appErr.Code)
+}
+
+func TestScreenService_GetUserPreferencesHistory(t *testing.T) {
+	svc, mockRepo, userID := newPreferencesTestService(t, "")
+	ctx := context.Background()
+	replacedAt := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	mockRepo.On("GetUserPreferencesHistory", ctx, "materials-list", userID).Return([]*repository.ScreenPreferencesVersion{
+		{Preferences: json.RawMessage(`{"sortBy":"date"}`), SavedAt: replacedAt.Add(-time.Hour), ReplacedAt: replacedAt},
+		{Preferences: json.RawMessage(`{"sortBy":"title"}`), SavedAt: replacedAt.Add(-2 * time.Hour), ReplacedAt: replacedAt.Add(-time.Hour)},
+	}, nil)
+
+	history, err := svc.GetUserPreferencesHistory(ctx, "materials-list", userID)
+
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.JSONEq(t, `{"sortBy":"date"}`, string(history[0].Preferences))
+	assert.Equal(t, replacedAt, history[0].ReplacedAt)
+}
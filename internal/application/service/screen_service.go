@@ -5,13 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/i18n"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/EduGoGroup/edugo-shared/logger"
 	"github.com/EduGoGroup/edugo-shared/screenconfig"
@@ -20,9 +21,33 @@ import (
 // ScreenService define las operaciones de negocio para pantallas
 type ScreenService interface {
 	GetScreen(ctx context.Context, screenKey string, userID uuid.UUID, platform string) (*dto.CombinedScreenDTO, error)
-	GetNavigationConfig(ctx context.Context, userID uuid.UUID, platform string, permissions []string) (*NavigationConfigDTO, error)
+
+	// GetScreenVariant retorna la pantalla con la variante de experimento asignada al usuario
+	GetScreenVariant(ctx context.Context, screenKey string, audience ScreenAudience) (*ScreenVariantDTO, error)
+
+	// GetScreenWithData retorna la pantalla (con su variante) y los datos de su DataEndpoint resueltos en proceso
+	GetScreenWithData(ctx context.Context, screenKey string, audience ScreenAudience, permissions []string) (*ScreenWithDataDTO, error)
+	GetNavigationConfig(ctx context.Context, audience ScreenAudience, permissions []string) (*NavigationConfigDTO, error)
 	SaveUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID, prefs json.RawMessage) error
+
+	// PatchUserPreferences aplica un JSON Merge Patch sobre las preferencias y retorna el resultado
+	PatchUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID, patch json.RawMessage) (json.RawMessage, error)
+
+	// ResetUserPreferences elimina las preferencias del usuario (quedan en el historial)
+	ResetUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID) error
+
+	// GetUserPreferencesHistory retorna las versiones anteriores de las preferencias
+	GetUserPreferencesHistory(ctx context.Context, screenKey string, userID uuid.UUID) ([]dto.ScreenPreferencesVersionDTO, error)
 	GetScreensForResource(ctx context.Context, resourceKey string) ([]*dto.ResourceScreenDTO, error)
+
+	// GetScreenBundle retorna navegación y pantallas alcanzables; con since (el bundle que tiene el cliente), solo el delta
+	GetScreenBundle(ctx context.Context, audience ScreenAudience, permissions []string, since *BundleSince) (*ScreenBundleDTO, error)
+
+	// InvalidateScreens descarta del cache las pantallas indicadas; sin claves vacía todo el cache
+	InvalidateScreens(screenKeys ...string)
+
+	// HandleScreenUpdated invalida el cache al recibir screen.updated (api-admin editó una pantalla)
+	HandleScreenUpdated(ctx context.Context, event eventbus.Event) error
 }
 
 // NavigationConfigDTO representa la configuracion de navegacion
@@ -42,42 +67,82 @@ type NavItemDTO struct {
 	Children  []NavItemDTO `json:"children,omitempty"`
 }
 
-// screenCache es una entrada de cache con TTL
-type screenCache struct {
-	data      *dto.CombinedScreenDTO
-	expiresAt time.Time
+// ScreenCacheConfig configura el cache en memoria de pantallas resueltas
+// Con TTL o MaxEntries en 0 no se cachea
+type ScreenCacheConfig struct {
+	TTL        time.Duration
+	MaxEntries int
+}
+
+// DefaultScreenCacheConfig retorna la configuración del cache de pantallas por defecto
+func DefaultScreenCacheConfig() ScreenCacheConfig {
+	return ScreenCacheConfig{
+		TTL:        1 * time.Hour,
+		MaxEntries: 500,
+	}
+}
+
+// ScreenServiceOptions dependencias opcionales del servicio de pantallas (nil = deshabilitada)
+type ScreenServiceOptions struct {
+	// Data endpoints internos que se resuelven con ?include=data
+	Data *ScreenDataRegistry
+	// Experiments experimentos A/B de pantallas
+	Experiments repository.ScreenExperimentRepository
+	// Translations traducciones de slots y etiquetas de menú
+	Translations repository.ScreenTranslationRepository
 }
 
 type screenService struct {
 	repo           repository.ScreenRepository
 	resourceReader repository.ResourceReader
+	translations   repository.ScreenTranslationRepository
 	logger         logger.Logger
 
-	mu    sync.RWMutex
-	cache map[string]*screenCache
-	ttl   time.Duration
+	cache       *screenLRU
+	data        *ScreenDataRegistry
+	experiments *screenExperiments
 }
 
-// NewScreenService crea una nueva instancia del servicio de pantallas
+// NewScreenService crea una nueva instancia del servicio de pantallas con el cache por defecto
 func NewScreenService(
 	repo repository.ScreenRepository,
 	resourceReader repository.ResourceReader,
 	logger logger.Logger,
+) ScreenService {
+	return NewScreenServiceWithCache(repo, resourceReader, DefaultScreenCacheConfig(), ScreenServiceOptions{}, logger)
+}
+
+// NewScreenServiceWithCache crea el servicio de pantallas con una configuración de cache explícita
+// y sus dependencias opcionales (datos en proceso, experimentos y traducciones)
+func NewScreenServiceWithCache(
+	repo repository.ScreenRepository,
+	resourceReader repository.ResourceReader,
+	cacheConfig ScreenCacheConfig,
+	opts ScreenServiceOptions,
+	logger logger.Logger,
 ) ScreenService {
 	return &screenService{
 		repo:           repo,
 		resourceReader: resourceReader,
+		translations:   opts.Translations,
 		logger:         logger,
-		cache:          make(map[string]*screenCache),
-		ttl:            1 * time.Hour,
+		cache:          newScreenLRU(cacheConfig.MaxEntries, cacheConfig.TTL),
+		data:           opts.Data,
+		experiments:    newScreenExperiments(opts.Experiments),
 	}
 }
 
 // GetScreen retorna la definicion de pantalla combinada para el renderizado del frontend
+// en el idioma original (i18n.DefaultLocale)
 func (s *screenService) GetScreen(ctx context.Context, screenKey string, userID uuid.UUID, platform string) (*dto.CombinedScreenDTO, error) {
-	// 1. Verificar cache (incluyendo platform para evitar servir overrides incorrectos)
-	cacheKey := fmt.Sprintf("screen:%s:platform:%s", screenKey, platform)
-	if cached := s.getCached(cacheKey); cached != nil {
+	return s.getScreen(ctx, screenKey, userID, platform, i18n.DefaultLocale)
+}
+
+// getScreen resuelve la pantalla para la plataforma con los slots traducidos al idioma indicado
+func (s *screenService) getScreen(ctx context.Context, screenKey string, userID uuid.UUID, platform, locale string) (*dto.CombinedScreenDTO, error) {
+	// 1. Verificar cache (incluyendo platform e idioma para evitar servir overrides o textos incorrectos)
+	cacheKey := fmt.Sprintf("screen:%s:platform:%s:locale:%s", screenKey, platform, locale)
+	if cached := s.cache.get(cacheKey); cached != nil {
 		s.logger.Info("screen cache hit", "screen_key", screenKey)
 		// Fusionar preferencias de usuario sobre la copia cacheada
 		return s.withUserPreferences(ctx, cached, screenKey, userID)
@@ -93,8 +158,9 @@ func (s *screenService) GetScreen(ctx context.Context, screenKey string, userID
 		return nil, errors.NewNotFoundError("screen")
 	}
 
-	// 3. Resolver referencias de slot
-	resolvedDefinition := screenconfig.ResolveSlots(combined.Definition, combined.SlotData)
+	// 3. Traducir los slots y resolver referencias de slot
+	slotData, localized := s.localizeSlots(ctx, screenKey, locale, combined.SlotData)
+	resolvedDefinition := screenconfig.ResolveSlots(combined.Definition, slotData)
 
 	// 4. Aplicar platformOverrides si se proporciona platform
 	if platform != "" {
@@ -118,7 +184,10 @@ func (s *screenService) GetScreen(ctx context.Context, screenKey string, userID
 	}
 
 	// 6. Cachear resultado (sin preferencias de usuario para compartir entre usuarios)
-	s.setCache(cacheKey, result)
+	// Si las traducciones fallaron se sirve el original sin cachearlo
+	if localized {
+		s.cache.set(cacheKey, screenKey, result)
+	}
 
 	s.logger.Info("screen loaded from database",
 		"screen_key", screenKey,
@@ -130,20 +199,28 @@ func (s *screenService) GetScreen(ctx context.Context, screenKey string, userID
 }
 
 // GetNavigationConfig retorna la estructura de navegacion dinamica basada en permisos del usuario
-func (s *screenService) GetNavigationConfig(ctx context.Context, userID uuid.UUID, platform string, permissions []string) (*NavigationConfigDTO, error) {
+// con las etiquetas en el idioma del usuario
+func (s *screenService) GetNavigationConfig(ctx context.Context, audience ScreenAudience, permissions []string) (*NavigationConfigDTO, error) {
+	allowedResources, mappings, err := s.reachableMenu(ctx, permissions)
+	if err != nil {
+		return nil, err
+	}
+	allowedResources = s.localizeMenu(ctx, allowedResources, audience.locale())
+	return newNavigationConfig(allowedResources, mappings, audience.Platform), nil
+}
+
+// reachableMenu retorna los recursos de menú visibles con los permisos del usuario (incluyendo
+// sus ancestros) y todos los mappings de pantalla de esos recursos
+func (s *screenService) reachableMenu(ctx context.Context, permissions []string) ([]*repository.MenuResource, []*repository.ResourceScreenMapping, error) {
 	// 1. Obtener todos los recursos visibles en menu
 	resources, err := s.resourceReader.GetMenuResources(ctx)
 	if err != nil {
 		s.logger.Error("failed to get menu resources", "error", err)
-		return nil, errors.NewDatabaseError("get menu resources", err)
+		return nil, nil, errors.NewDatabaseError("get menu resources", err)
 	}
 
 	if len(resources) == 0 {
-		return &NavigationConfigDTO{
-			BottomNav:   []NavItemDTO{},
-			DrawerItems: []NavItemDTO{},
-			Version:     1,
-		}, nil
+		return nil, nil, nil
 	}
 
 	// 2. Filtrar recursos por permisos del usuario
@@ -209,18 +286,27 @@ func (s *screenService) GetNavigationConfig(ctx context.Context, userID uuid.UUI
 	}
 
 	if len(allowedResources) == 0 {
-		return &NavigationConfigDTO{
-			BottomNav:   []NavItemDTO{},
-			DrawerItems: []NavItemDTO{},
-			Version:     1,
-		}, nil
+		return nil, nil, nil
 	}
 
 	// 3. Obtener mappings de pantalla para los recursos permitidos
 	mappings, err := s.resourceReader.GetResourceScreenMappings(ctx, resourceKeys)
 	if err != nil {
 		s.logger.Error("failed to get resource screen mappings", "error", err)
-		return nil, errors.NewDatabaseError("get resource screen mappings", err)
+		return nil, nil, errors.NewDatabaseError("get resource screen mappings", err)
+	}
+
+	return allowedResources, mappings, nil
+}
+
+// newNavigationConfig arma la navegación con la pantalla por defecto de cada recurso permitido
+func newNavigationConfig(allowedResources []*repository.MenuResource, mappings []*repository.ResourceScreenMapping, platform string) *NavigationConfigDTO {
+	if len(allowedResources) == 0 {
+		return &NavigationConfigDTO{
+			BottomNav:   []NavItemDTO{},
+			DrawerItems: []NavItemDTO{},
+			Version:     1,
+		}
 	}
 
 	screenMap := make(map[string]string) // resourceKey -> screenKey
@@ -230,14 +316,14 @@ func (s *screenService) GetNavigationConfig(ctx context.Context, userID uuid.UUI
 		}
 	}
 
-	// 4. Construir arbol de navegacion
+	// Construir arbol de navegacion
 	bottomNav, drawerItems := buildNavigationTree(allowedResources, screenMap, platform)
 
 	return &NavigationConfigDTO{
 		BottomNav:   bottomNav,
 		DrawerItems: drawerItems,
 		Version:     1,
-	}, nil
+	}
 }
 
 // toMenuNodes convierte MenuResource del repositorio a screenconfig.MenuNode del shared.
@@ -312,7 +398,12 @@ func buildNavigationTree(resources []*repository.MenuResource, screenMap map[str
 }
 
 // SaveUserPreferences almacena las preferencias de pantalla especificas del usuario
+// Se validan contra el schema del patrón de la pantalla y el límite de tamaño
 func (s *screenService) SaveUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID, prefs json.RawMessage) error {
+	if err := s.validatePreferences(ctx, screenKey, userID, prefs); err != nil {
+		return err
+	}
+
 	if err := s.repo.SaveUserPreferences(ctx, screenKey, userID, prefs); err != nil {
 		s.logger.Error("failed to save user preferences",
 			"screen_key", screenKey,
@@ -352,28 +443,51 @@ func (s *screenService) GetScreensForResource(ctx context.Context, resourceKey s
 	return dtos, nil
 }
 
-// getCached obtiene una entrada del cache si existe y no ha expirado
-func (s *screenService) getCached(key string) *dto.CombinedScreenDTO {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	entry, ok := s.cache[key]
-	if !ok || time.Now().After(entry.expiresAt) {
-		return nil
+// InvalidateScreens descarta del cache las pantallas indicadas, o todas si no se indica ninguna
+// Los experimentos se recargan siempre: api-admin publica screen.updated al editarlos
+func (s *screenService) InvalidateScreens(screenKeys ...string) {
+	s.experiments.invalidate()
+	if len(screenKeys) == 0 {
+		removed := s.cache.purge()
+		s.logger.Info("screen cache purged", "entries", removed)
+		return
 	}
 
-	return entry.data
+	removed := s.cache.invalidate(screenKeys...)
+	s.logger.Info("screen cache invalidated", "screen_keys", strings.Join(screenKeys, ","), "entries", removed)
 }
 
-// setCache almacena una entrada en el cache con TTL
-func (s *screenService) setCache(key string, data *dto.CombinedScreenDTO) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// HandleScreenUpdated procesa screen.updated publicado por api-admin o una notificación de Postgres
+// El cuerpo puede venir con el sobre de eventos ({"payload": {...}}) o sin él. Sin screen_key
+// (p. ej. se editó un template compartido por varias pantallas) se vacía todo el cache
+func (s *screenService) HandleScreenUpdated(ctx context.Context, event eventbus.Event) error {
+	type screenUpdate struct {
+		ScreenKey  string   `json:"screen_key"`
+		ScreenKeys []string `json:"screen_keys"`
+	}
+	var body struct {
+		screenUpdate
+		Payload *screenUpdate `json:"payload"`
+	}
+	if len(event.Body) > 0 {
+		if err := json.Unmarshal(event.Body, &body); err != nil {
+			// Ante un cuerpo ilegible es más seguro vaciar el cache que servir pantallas viejas
+			s.InvalidateScreens()
+			return fmt.Errorf("screen: invalid event %s: %w", event.RoutingKey, err)
+		}
+	}
 
-	s.cache[key] = &screenCache{
-		data:      data,
-		expiresAt: time.Now().Add(s.ttl),
+	update := body.screenUpdate
+	if body.Payload != nil {
+		update = *body.Payload
 	}
+	keys := update.ScreenKeys
+	if update.ScreenKey != "" {
+		keys = append(keys, update.ScreenKey)
+	}
+
+	s.InvalidateScreens(keys...)
+	return nil
 }
 
 // withUserPreferences fusiona las preferencias del usuario sobre una pantalla cacheada
@@ -47,7 +47,7 @@ func TestScreenService_GetNavigationConfig_FullPermissions(t *testing.T) {
 	permissions := []string{"materials:read", "assessments:read"}
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, permissions)
 
 	// Assert
 	require.NoError(t, err)
@@ -90,7 +90,7 @@ func TestScreenService_GetNavigationConfig_PartialPermissions(t *testing.T) {
 	permissions := []string{"materials:read"}
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, permissions)
 
 	// Assert
 	require.NoError(t, err)
@@ -130,7 +130,7 @@ func TestScreenService_GetNavigationConfig_NoPermissions(t *testing.T) {
 	permissions := []string{}
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, permissions)
 
 	// Assert
 	require.NoError(t, err)
@@ -169,7 +169,7 @@ func TestScreenService_GetNavigationConfig_SystemScopeAlwaysVisible(t *testing.T
 	permissions := []string{}
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, permissions)
 
 	// Assert
 	require.NoError(t, err)
@@ -208,7 +208,7 @@ func TestScreenService_GetNavigationConfig_DesktopPlatform_AllInDrawer(t *testin
 	permissions := []string{"materials:read"}
 
 	// Act - platform desktop
-	result, err := svc.GetNavigationConfig(ctx, userID, "desktop", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "desktop"}, permissions)
 
 	// Assert
 	require.NoError(t, err)
@@ -235,7 +235,7 @@ func TestScreenService_GetNavigationConfig_EmptyResources(t *testing.T) {
 	permissions := []string{"materials:read"}
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, permissions)
 
 	// Assert
 	require.NoError(t, err)
@@ -265,7 +265,7 @@ func TestScreenService_GetNavigationConfig_ResourceReaderError(t *testing.T) {
 	permissions := []string{"materials:read"}
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, permissions)
 
 	// Assert
 	assert.Error(t, err)
@@ -303,7 +303,7 @@ func TestScreenService_GetNavigationConfig_WithParentChild(t *testing.T) {
 	permissions := []string{}
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, permissions)
 
 	// Assert
 	require.NoError(t, err)
@@ -349,7 +349,7 @@ func TestScreenService_GetNavigationConfig_ParentIncludedWhenChildHasPermission(
 	// Solo tiene permiso sobre el hijo "materials", no sobre el padre ni sobre "reports"
 	permissions := []string{"materials:read"}
 
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, permissions)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -404,7 +404,7 @@ func TestScreenService_GetNavigationConfig_MobileMax5BottomNav(t *testing.T) {
 	mockResourceReader.On("GetResourceScreenMappings", ctx, expectedKeys).Return(mappings, nil)
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", []string{})
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, []string{})
 
 	// Assert
 	require.NoError(t, err)
@@ -439,7 +439,7 @@ func TestScreenService_GetNavigationConfig_DesktopAllInDrawerNoBottomNav(t *test
 	mockResourceReader.On("GetResourceScreenMappings", ctx, []string{"dashboard", "materials"}).Return(mappings, nil)
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "desktop", []string{})
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "desktop"}, []string{})
 
 	// Assert
 	require.NoError(t, err)
@@ -78,6 +78,19 @@ func (m *MockScreenRepository) SaveUserPreferences(ctx context.Context, screenKe
 	return args.Error(0)
 }
 
+func (m *MockScreenRepository) DeleteUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID) error {
+	args := m.Called(ctx, screenKey, userID)
+	return args.Error(0)
+}
+
+func (m *MockScreenRepository) GetUserPreferencesHistory(ctx context.Context, screenKey string, userID uuid.UUID) ([]*repository.ScreenPreferencesVersion, error) {
+	args := m.Called(ctx, screenKey, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.ScreenPreferencesVersion), args.Error(1)
+}
+
 // ============================================
 // Helper: crear CombinedScreen de prueba
 // ============================================
@@ -441,7 +454,7 @@ func TestScreenService_GetNavigationConfig_Success(t *testing.T) {
 	permissions := []string{"materials:read", "assessments:read"}
 
 	// Act
-	result, err := svc.GetNavigationConfig(ctx, userID, "mobile", permissions)
+	result, err := svc.GetNavigationConfig(ctx, ScreenAudience{UserID: userID, Platform: "mobile"}, permissions)
 
 	// Assert
 	require.NoError(t, err)
@@ -477,6 +490,7 @@ func TestScreenService_SaveUserPreferences_Success(t *testing.T) {
 	screenKey := "materials-list"
 	prefs := json.RawMessage(`{"sortBy": "title", "viewMode": "grid"}`)
 
+	mockRepo.On("GetCombinedScreen", ctx, screenKey, userID).Return(newTestCombinedScreen(screenKey), nil)
 	mockRepo.On("SaveUserPreferences", ctx, screenKey, userID, prefs).Return(nil)
 	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 
@@ -502,7 +516,9 @@ func TestScreenService_SaveUserPreferences_DatabaseError(t *testing.T) {
 	prefs := json.RawMessage(`{"sortBy": "title"}`)
 
 	dbErr := fmt.Errorf("database error")
+	mockRepo.On("GetCombinedScreen", ctx, screenKey, userID).Return(newTestCombinedScreen(screenKey), nil)
 	mockRepo.On("SaveUserPreferences", ctx, screenKey, userID, prefs).Return(dbErr)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 
 	// Act
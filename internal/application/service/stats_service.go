@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repositories"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
@@ -13,59 +14,221 @@ import (
 	"github.com/EduGoGroup/edugo-shared/logger"
 )
 
+// MaterialStats estadísticas agregadas de un material
+// Las tasas (CompletionRate, PassRate) son porcentajes 0-100 y se calculan por estudiante, no por intento
 type MaterialStats struct {
-	TotalViews    int     `json:"total_views"`
-	AvgProgress   float64 `json:"avg_progress"`
-	TotalAttempts int     `json:"total_attempts"`
-	AvgScore      float64 `json:"avg_score"`
+	TotalViews             int                 `json:"total_views"`
+	UniqueViewers          int                 `json:"unique_viewers"`
+	UniqueLearners         int                 `json:"unique_learners"`
+	CompletedLearners      int                 `json:"completed_learners"`
+	CompletionRate         float64             `json:"completion_rate"`
+	AvgProgress            float64             `json:"avg_progress"`
+	TotalAttempts          int                 `json:"total_attempts"`
+	UniqueAttempters       int                 `json:"unique_attempters"`
+	PassedStudents         int                 `json:"passed_students"`
+	PassRate               float64             `json:"pass_rate"`
+	AvgScore               float64             `json:"avg_score"`
+	MedianTimeSpentSeconds float64             `json:"median_time_spent_seconds"`
+	ScoreDistribution      []ScoreHistogramBin `json:"score_distribution"`
+}
+
+// ScoreHistogramBin rango del histograma de puntajes [Min, Max]
+type ScoreHistogramBin struct {
+	Min   int   `json:"min"`
+	Max   int   `json:"max"`
+	Count int64 `json:"count"`
 }
 
 type StatsService interface {
-	GetMaterialStats(ctx context.Context, materialID string) (*MaterialStats, error)
+	// GetMaterialStats evalúa material:read; un material inexistente o de otra escuela responde 404
+	GetMaterialStats(ctx context.Context, materialID string, subject policy.Subject) (*MaterialStats, error)
+	// GetGlobalStats sirve desde cache o snapshot; GeneratedAt indica la antigüedad de los datos
 	GetGlobalStats(ctx context.Context) (*dto.GlobalStatsDTO, error)
+	// RefreshGlobalStats recalcula las estadísticas globales y actualiza snapshot y cache
+	// Si el snapshot compartido tiene menos de minAge (otra instancia acaba de refrescar) lo reutiliza
+	// sin recalcular; minAge 0 fuerza el recálculo
+	RefreshGlobalStats(ctx context.Context, minAge time.Duration) (*dto.GlobalStatsDTO, error)
+	RecordMaterialView(ctx context.Context, materialID, userID, schoolID string) error
+}
+
+// GlobalStatsCacheConfig controla el cache en memoria y la antigüedad aceptada del snapshot
+// Con valores cero (o sin repositorio de snapshots) cada request recalcula las estadísticas
+type GlobalStatsCacheConfig struct {
+	CacheTTL       time.Duration
+	SnapshotMaxAge time.Duration
 }
 
 type statsService struct {
 	logger          logger.Logger
 	materialStats   repository.MaterialStats     // ISP: Solo necesita estadísticas (PostgreSQL)
+	materials       repository.MaterialReader    // ISP: Solo lectura, para autorizar el material (PostgreSQL)
 	assessmentStats repositories.AssessmentStats // ISP: Solo necesita estadísticas (PostgreSQL)
 	progressStats   repository.ProgressStats     // ISP: Solo necesita estadísticas (PostgreSQL)
+	materialViews   repository.MaterialViewRepository
+	snapshots       repository.StatsSnapshotRepository
+	cacheConfig     GlobalStatsCacheConfig
+
+	cacheMu   sync.Mutex
+	cached    *dto.GlobalStatsDTO
+	cachedAt  time.Time
+	refreshMu sync.Mutex // Evita que requests concurrentes recalculen a la vez
 }
 
 func NewStatsService(
 	logger logger.Logger,
 	materialStats repository.MaterialStats, // ISP: Solo necesita estadísticas (PostgreSQL)
+	materials repository.MaterialReader, // ISP: Solo lectura, para autorizar el material (PostgreSQL)
 	assessmentStats repositories.AssessmentStats, // ISP: Solo necesita estadísticas (PostgreSQL)
 	progressStats repository.ProgressStats, // ISP: Solo necesita estadísticas (PostgreSQL)
+	materialViews repository.MaterialViewRepository, // Tracking de visualizaciones (MongoDB)
+	snapshots repository.StatsSnapshotRepository, // Snapshot compartido de estadísticas globales (PostgreSQL)
+	cacheConfig GlobalStatsCacheConfig,
 ) StatsService {
 	return &statsService{
 		logger:          logger,
 		materialStats:   materialStats,
+		materials:       materials,
 		assessmentStats: assessmentStats,
 		progressStats:   progressStats,
+		materialViews:   materialViews,
+		snapshots:       snapshots,
+		cacheConfig:     cacheConfig,
 	}
 }
 
-func (s *statsService) GetMaterialStats(ctx context.Context, materialID string) (*MaterialStats, error) {
-	_, err := valueobject.MaterialIDFromString(materialID)
+// GetMaterialStats agrega progreso, intentos y visualizaciones de un material
+// Ejecuta las tres queries en paralelo, igual que GetGlobalStats
+func (s *statsService) GetMaterialStats(ctx context.Context, materialID string, subject policy.Subject) (*MaterialStats, error) {
+	matID, err := valueobject.MaterialIDFromString(materialID)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid material_id")
 	}
 
-	// Mock stats por ahora (en prod hacer queries a DB)
+	// Sin esta verificación un id inexistente devolvería estadísticas en cero
+	material, err := s.materials.FindByID(ctx, matID)
+	if err != nil {
+		s.logger.Error("error al obtener el material", "material_id", materialID, "error", err)
+		return nil, errors.NewDatabaseError("find material", err)
+	}
+	if material == nil {
+		return nil, errors.NewNotFoundError("material")
+	}
+	if err := policy.Authorize(subject, policy.ResourceMaterial, policy.ActionRead, materialTarget(material)); err != nil {
+		return nil, err
+	}
+
+	var (
+		progress    *repository.MaterialProgressStats
+		attempts    *repositories.MaterialAttemptStats
+		views       *repository.MaterialViewCounts
+		queryErrors []error
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+	)
+
+	addError := func(msg string, err error) {
+		mu.Lock()
+		queryErrors = append(queryErrors, err)
+		mu.Unlock()
+		s.logger.Error(msg, "material_id", materialID, "error", err)
+	}
+
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		result, err := s.progressStats.GetMaterialProgressStats(ctx, matID)
+		if err != nil {
+			addError("error al obtener progreso del material", err)
+			return
+		}
+		progress = result
+	}()
+
+	go func() {
+		defer wg.Done()
+		result, err := s.assessmentStats.GetMaterialAttemptStats(ctx, matID.UUID().UUID)
+		if err != nil {
+			addError("error al obtener intentos del material", err)
+			return
+		}
+		attempts = result
+	}()
+
+	go func() {
+		defer wg.Done()
+		result, err := s.materialViews.CountViews(ctx, materialID)
+		if err != nil {
+			addError("error al contar visualizaciones del material", err)
+			return
+		}
+		views = result
+	}()
+
+	wg.Wait()
+
+	if len(queryErrors) > 0 {
+		return nil, errors.NewInternalError("error al obtener estadísticas del material", queryErrors[0])
+	}
+
 	stats := &MaterialStats{
-		TotalViews:    150,
-		AvgProgress:   67.5,
-		TotalAttempts: 45,
-		AvgScore:      78.3,
+		TotalViews:             int(views.TotalViews),
+		UniqueViewers:          int(views.UniqueViewers),
+		UniqueLearners:         int(progress.Learners),
+		CompletedLearners:      int(progress.Completed),
+		CompletionRate:         percentage(progress.Completed, progress.Learners),
+		AvgProgress:            progress.AvgProgress,
+		TotalAttempts:          int(attempts.TotalAttempts),
+		UniqueAttempters:       int(attempts.UniqueStudents),
+		PassedStudents:         int(attempts.PassedStudents),
+		PassRate:               percentage(attempts.PassedStudents, attempts.UniqueStudents),
+		AvgScore:               attempts.AvgScore,
+		MedianTimeSpentSeconds: attempts.MedianTimeSpentSeconds,
+		ScoreDistribution:      scoreDistribution(attempts.ScoreHistogram),
 	}
 
 	return stats, nil
 }
 
-// GetGlobalStats obtiene estadísticas globales del sistema ejecutando queries en paralelo
+// RecordMaterialView registra una apertura del material para las estadísticas de visualización
+func (s *statsService) RecordMaterialView(ctx context.Context, materialID, userID, schoolID string) error {
+	if _, err := valueobject.MaterialIDFromString(materialID); err != nil {
+		return errors.NewValidationError("invalid material_id")
+	}
+
+	view := &repository.MaterialView{
+		MaterialID: materialID,
+		UserID:     userID,
+		SchoolID:   schoolID,
+		ViewedAt:   time.Now(),
+	}
+	if err := s.materialViews.RecordView(ctx, view); err != nil {
+		return errors.NewDatabaseError("record material view", err)
+	}
+	return nil
+}
+
+// percentage calcula part/total en escala 0-100, 0 si no hay base
+func percentage(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+// scoreDistribution convierte el histograma del repositorio en rangos legibles (0-9, 10-19, ..., 90-100)
+func scoreDistribution(histogram [repositories.ScoreHistogramBuckets]int64) []ScoreHistogramBin {
+	bins := make([]ScoreHistogramBin, len(histogram))
+	for i, count := range histogram {
+		bins[i] = ScoreHistogramBin{Min: i * 10, Max: i*10 + 9, Count: count}
+	}
+	bins[len(bins)-1].Max = 100
+	return bins
+}
+
+// computeGlobalStats obtiene estadísticas globales del sistema ejecutando queries en paralelo
 // Usa goroutines con sync.WaitGroup para optimizar performance
-func (s *statsService) GetGlobalStats(ctx context.Context) (*dto.GlobalStatsDTO, error) {
+func (s *statsService) computeGlobalStats(ctx context.Context) (*dto.GlobalStatsDTO, error) {
 	startTime := time.Now()
 
 	s.logger.Info("iniciando obtención de estadísticas globales")
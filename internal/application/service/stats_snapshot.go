@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// GetGlobalStats resuelve en tres niveles para no ejecutar las agregaciones en cada request:
+//  1. Cache en memoria (CacheTTL)
+//  2. Snapshot compartido en PostgreSQL (SnapshotMaxAge)
+//  3. Recalcular en vivo y guardar un nuevo snapshot
+//
+// Solo un request a la vez baja a los niveles 2 y 3; los concurrentes esperan y reutilizan el resultado
+func (s *statsService) GetGlobalStats(ctx context.Context) (*dto.GlobalStatsDTO, error) {
+	if stats := s.cachedGlobalStats(); stats != nil {
+		return stats, nil
+	}
+
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	// Otro request pudo haber completado el refresco mientras esperábamos
+	if stats := s.cachedGlobalStats(); stats != nil {
+		return stats, nil
+	}
+
+	if stats := s.freshSnapshot(ctx, s.cacheConfig.SnapshotMaxAge); stats != nil {
+		s.storeCachedGlobalStats(stats)
+		return copyGlobalStats(stats), nil
+	}
+
+	return s.refreshLocked(ctx)
+}
+
+func (s *statsService) RefreshGlobalStats(ctx context.Context, minAge time.Duration) (*dto.GlobalStatsDTO, error) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	// Todas las instancias reciben los mismos eventos y ticks: solo la primera recalcula
+	if stats := s.freshSnapshot(ctx, minAge); stats != nil {
+		s.storeCachedGlobalStats(stats)
+		return copyGlobalStats(stats), nil
+	}
+
+	return s.refreshLocked(ctx)
+}
+
+// refreshLocked recalcula, guarda el snapshot y actualiza el cache; requiere refreshMu
+func (s *statsService) refreshLocked(ctx context.Context) (*dto.GlobalStatsDTO, error) {
+	stats, err := s.computeGlobalStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.snapshots != nil {
+		// Un fallo al guardar no invalida el resultado: el próximo refresco lo reintenta
+		if err := s.snapshots.SaveGlobalSnapshot(ctx, snapshotFromDTO(stats)); err != nil {
+			s.logger.Warn("no se pudo guardar el snapshot de estadísticas globales", "error", err)
+		}
+	}
+
+	s.storeCachedGlobalStats(stats)
+	return copyGlobalStats(stats), nil
+}
+
+// freshSnapshot retorna el snapshot guardado si no supera maxAge
+func (s *statsService) freshSnapshot(ctx context.Context, maxAge time.Duration) *dto.GlobalStatsDTO {
+	if s.snapshots == nil || maxAge <= 0 {
+		return nil
+	}
+
+	snapshot, err := s.snapshots.GetGlobalSnapshot(ctx)
+	if err != nil {
+		s.logger.Warn("no se pudo leer el snapshot de estadísticas globales, recalculando", "error", err)
+		return nil
+	}
+	if snapshot == nil || time.Since(snapshot.GeneratedAt) > maxAge {
+		return nil
+	}
+	return dtoFromSnapshot(snapshot)
+}
+
+func (s *statsService) cachedGlobalStats() *dto.GlobalStatsDTO {
+	if s.cacheConfig.CacheTTL <= 0 {
+		return nil
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	// Los datos servidos pueden tener hasta SnapshotMaxAge + CacheTTL de antigüedad
+	if s.cached == nil || time.Since(s.cachedAt) > s.cacheConfig.CacheTTL {
+		return nil
+	}
+	return copyGlobalStats(s.cached)
+}
+
+func (s *statsService) storeCachedGlobalStats(stats *dto.GlobalStatsDTO) {
+	if s.cacheConfig.CacheTTL <= 0 {
+		return
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	s.cached = copyGlobalStats(stats)
+	s.cachedAt = time.Now()
+}
+
+// copyGlobalStats evita que los llamadores modifiquen el valor cacheado
+func copyGlobalStats(stats *dto.GlobalStatsDTO) *dto.GlobalStatsDTO {
+	clone := *stats
+	return &clone
+}
+
+func snapshotFromDTO(stats *dto.GlobalStatsDTO) *repository.GlobalStatsSnapshot {
+	return &repository.GlobalStatsSnapshot{
+		TotalPublishedMaterials:   stats.TotalPublishedMaterials,
+		TotalCompletedAssessments: stats.TotalCompletedAssessments,
+		AverageAssessmentScore:    stats.AverageAssessmentScore,
+		ActiveUsersLast30Days:     stats.ActiveUsersLast30Days,
+		AverageProgress:           stats.AverageProgress,
+		GeneratedAt:               stats.GeneratedAt,
+	}
+}
+
+func dtoFromSnapshot(snapshot *repository.GlobalStatsSnapshot) *dto.GlobalStatsDTO {
+	return &dto.GlobalStatsDTO{
+		TotalPublishedMaterials:   snapshot.TotalPublishedMaterials,
+		TotalCompletedAssessments: snapshot.TotalCompletedAssessments,
+		AverageAssessmentScore:    snapshot.AverageAssessmentScore,
+		ActiveUsersLast30Days:     snapshot.ActiveUsersLast30Days,
+		AverageProgress:           snapshot.AverageProgress,
+		GeneratedAt:               snapshot.GeneratedAt,
+	}
+}
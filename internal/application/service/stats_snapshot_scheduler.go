@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// statsRefreshTimeout limita cada refresco en segundo plano
+const statsRefreshTimeout = 2 * time.Minute
+
+// StatsSnapshotEventPatterns son los eventos que cambian las estadísticas globales
+var StatsSnapshotEventPatterns = []string{
+	"material.*",
+	"progress.updated",
+	"assessment.attempt.completed",
+}
+
+// StatsSnapshotScheduler refresca el snapshot de estadísticas globales en segundo plano
+// Refresca al iniciar, cada Interval y Debounce después de un evento relevante;
+// los eventos que llegan durante la espera se agrupan en un solo refresco.
+// Cada instancia omite el recálculo si el snapshot compartido es más reciente que la espera
+// correspondiente, así N pods no recalculan N veces por cada evento o tick
+type StatsSnapshotScheduler struct {
+	stats    StatsService
+	interval time.Duration
+	debounce time.Duration
+	logger   logger.Logger
+
+	dirty    chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewStatsSnapshotScheduler crea el scheduler; interval 0 deshabilita el refresco periódico
+func NewStatsSnapshotScheduler(stats StatsService, interval, debounce time.Duration, logger logger.Logger) *StatsSnapshotScheduler {
+	return &StatsSnapshotScheduler{
+		stats:    stats,
+		interval: interval,
+		debounce: debounce,
+		logger:   logger,
+		dirty:    make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start inicia el loop de refresco en una goroutine
+func (s *StatsSnapshotScheduler) Start() {
+	go s.run()
+}
+
+// Stop detiene el loop y espera a que termine el refresco en curso
+func (s *StatsSnapshotScheduler) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+// HandleEvent marca el snapshot como desactualizado; nunca bloquea al publicador del bus
+func (s *StatsSnapshotScheduler) HandleEvent(_ context.Context, _ eventbus.Event) error {
+	select {
+	case s.dirty <- struct{}{}:
+	default:
+		// Ya hay un refresco pendiente
+	}
+	return nil
+}
+
+// Subscribe registra el scheduler en el bus para los eventos de StatsSnapshotEventPatterns
+func (s *StatsSnapshotScheduler) Subscribe(bus eventbus.EventBus) {
+	for _, pattern := range StatsSnapshotEventPatterns {
+		bus.Subscribe(pattern, s.HandleEvent)
+	}
+}
+
+func (s *StatsSnapshotScheduler) run() {
+	defer close(s.done)
+
+	var tick <-chan time.Time
+	if s.interval > 0 {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	s.refresh("startup", s.intervalMinAge())
+
+	var debounce *time.Timer
+	var debounceC <-chan time.Time
+	defer func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-tick:
+			s.refresh("interval", s.intervalMinAge())
+		case <-s.dirty:
+			if debounceC != nil {
+				continue
+			}
+			debounce = time.NewTimer(s.debounce)
+			debounceC = debounce.C
+		case <-debounceC:
+			debounceC = nil
+			s.refresh("event", s.debounce)
+		}
+	}
+}
+
+// intervalMinAge es la antigüedad bajo la cual el refresco periódico reutiliza el snapshot
+// Es la mitad del intervalo para que el tick propio no se salte por unos milisegundos de diferencia
+func (s *StatsSnapshotScheduler) intervalMinAge() time.Duration {
+	return s.interval / 2
+}
+
+func (s *StatsSnapshotScheduler) refresh(reason string, minAge time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), statsRefreshTimeout)
+	defer cancel()
+
+	stats, err := s.stats.RefreshGlobalStats(ctx, minAge)
+	if err != nil {
+		s.logger.Warn("no se pudo refrescar el snapshot de estadísticas globales", "reason", reason, "error", err)
+		return
+	}
+	s.logger.Debug("snapshot de estadísticas globales refrescado", "reason", reason, "generated_at", stats.GeneratedAt)
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+)
+
+// MockStatsSnapshotRepository es un mock del repositorio de snapshots
+type MockStatsSnapshotRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatsSnapshotRepository) GetGlobalSnapshot(ctx context.Context) (*repository.GlobalStatsSnapshot, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.GlobalStatsSnapshot), args.Error(1)
+}
+
+func (m *MockStatsSnapshotRepository) SaveGlobalSnapshot(ctx context.Context, snapshot *repository.GlobalStatsSnapshot) error {
+	args := m.Called(ctx, snapshot)
+	return args.Error(0)
+}
+
+// newSnapshotTestService arma un statsService cuyas agregaciones solo pueden ejecutarse `times` veces
+func newSnapshotTestService(times int, snapshots repository.StatsSnapshotRepository, cfg GlobalStatsCacheConfig) *statsService {
+	mockMaterialRepo := new(MockMaterialRepository)
+	mockAssessmentRepo := new(MockAssessmentRepository)
+	mockProgressRepo := new(MockProgressRepository)
+	mockLogger := new(MockLogger)
+
+	mockMaterialRepo.On("CountPublishedMaterials", mock.Anything).Return(int64(150), nil).Times(times)
+	mockAssessmentRepo.On("CountCompletedAssessments", mock.Anything).Return(int64(320), nil).Times(times)
+	mockAssessmentRepo.On("CalculateAverageScore", mock.Anything).Return(float64(78.5), nil).Times(times)
+	mockProgressRepo.On("CountActiveUsers", mock.Anything).Return(int64(85), nil).Times(times)
+	mockProgressRepo.On("CalculateAverageProgress", mock.Anything).Return(float64(62.3), nil).Times(times)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+
+	svc := NewStatsService(mockLogger, mockMaterialRepo, mockMaterialRepo, mockAssessmentRepo, mockProgressRepo, nil, snapshots, cfg)
+	return svc.(*statsService)
+}
+
+// TestGetGlobalStats_CacheHit verifica que dentro del TTL no se repitan las agregaciones
+func TestGetGlobalStats_CacheHit(t *testing.T) {
+	svc := newSnapshotTestService(1, nil, GlobalStatsCacheConfig{CacheTTL: time.Minute})
+
+	first, err := svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+	second, err := svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, first.GeneratedAt, second.GeneratedAt)
+	assert.Equal(t, int64(150), second.TotalPublishedMaterials)
+
+	// Modificar el resultado no debe alterar el valor cacheado
+	second.TotalPublishedMaterials = 0
+	third, err := svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), third.TotalPublishedMaterials)
+}
+
+// TestGetGlobalStats_CacheExpired verifica que un cache vencido recalcule
+func TestGetGlobalStats_CacheExpired(t *testing.T) {
+	svc := newSnapshotTestService(2, nil, GlobalStatsCacheConfig{CacheTTL: time.Minute})
+
+	_, err := svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+
+	svc.cacheMu.Lock()
+	svc.cachedAt = time.Now().Add(-2 * time.Minute)
+	svc.cacheMu.Unlock()
+
+	_, err = svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+}
+
+// TestGetGlobalStats_FreshSnapshot verifica que un snapshot vigente evite las agregaciones
+func TestGetGlobalStats_FreshSnapshot(t *testing.T) {
+	generatedAt := time.Now().Add(-5 * time.Minute)
+	snapshots := new(MockStatsSnapshotRepository)
+	snapshots.On("GetGlobalSnapshot", mock.Anything).Return(&repository.GlobalStatsSnapshot{
+		TotalPublishedMaterials: 99,
+		AverageProgress:         40,
+		GeneratedAt:             generatedAt,
+	}, nil).Once()
+
+	svc := newSnapshotTestService(0, snapshots, GlobalStatsCacheConfig{CacheTTL: time.Minute, SnapshotMaxAge: 15 * time.Minute})
+
+	stats, err := svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(99), stats.TotalPublishedMaterials)
+	assert.True(t, stats.GeneratedAt.Equal(generatedAt), "generated_at refleja la antigüedad del snapshot")
+
+	// La segunda lectura sale del cache en memoria
+	_, err = svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+	snapshots.AssertExpectations(t)
+}
+
+// TestGetGlobalStats_StaleSnapshot verifica que un snapshot vencido se recalcule y se guarde
+func TestGetGlobalStats_StaleSnapshot(t *testing.T) {
+	snapshots := new(MockStatsSnapshotRepository)
+	snapshots.On("GetGlobalSnapshot", mock.Anything).Return(&repository.GlobalStatsSnapshot{
+		TotalPublishedMaterials: 99,
+		GeneratedAt:             time.Now().Add(-time.Hour),
+	}, nil)
+	snapshots.On("SaveGlobalSnapshot", mock.Anything, mock.MatchedBy(func(s *repository.GlobalStatsSnapshot) bool {
+		return s.TotalPublishedMaterials == 150 && time.Since(s.GeneratedAt) < time.Minute
+	})).Return(nil).Once()
+
+	svc := newSnapshotTestService(1, snapshots, GlobalStatsCacheConfig{SnapshotMaxAge: 15 * time.Minute})
+
+	stats, err := svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), stats.TotalPublishedMaterials)
+	snapshots.AssertExpectations(t)
+}
+
+// TestGetGlobalStats_SnapshotErrorsDegrade verifica que fallas del snapshot no rompan el endpoint
+func TestGetGlobalStats_SnapshotErrorsDegrade(t *testing.T) {
+	snapshots := new(MockStatsSnapshotRepository)
+	snapshots.On("GetGlobalSnapshot", mock.Anything).Return(nil, errors.New("relation does not exist"))
+	snapshots.On("SaveGlobalSnapshot", mock.Anything, mock.Anything).Return(errors.New("relation does not exist"))
+
+	svc := newSnapshotTestService(1, snapshots, GlobalStatsCacheConfig{SnapshotMaxAge: 15 * time.Minute})
+
+	stats, err := svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), stats.TotalPublishedMaterials)
+}
+
+// TestGetGlobalStats_ConcurrentMissComputesOnce verifica que requests concurrentes no multipliquen las agregaciones
+func TestGetGlobalStats_ConcurrentMissComputesOnce(t *testing.T) {
+	svc := newSnapshotTestService(1, nil, GlobalStatsCacheConfig{CacheTTL: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.GetGlobalStats(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+// TestRefreshGlobalStats verifica que el refresco ignore el cache y lo actualice
+func TestRefreshGlobalStats(t *testing.T) {
+	snapshots := new(MockStatsSnapshotRepository)
+	snapshots.On("SaveGlobalSnapshot", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	svc := newSnapshotTestService(2, snapshots, GlobalStatsCacheConfig{CacheTTL: time.Minute, SnapshotMaxAge: time.Minute})
+
+	_, err := svc.RefreshGlobalStats(context.Background(), 0)
+	require.NoError(t, err)
+	refreshed, err := svc.RefreshGlobalStats(context.Background(), 0)
+	require.NoError(t, err)
+
+	cached, err := svc.GetGlobalStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, refreshed.GeneratedAt, cached.GeneratedAt)
+	snapshots.AssertExpectations(t)
+}
+
+// TestRefreshGlobalStats_SharedSnapshotYoungerThanMinAge verifica que otra instancia que acaba
+// de refrescar evite el recálculo; un snapshot más viejo que minAge sí se recalcula
+func TestRefreshGlobalStats_SharedSnapshotYoungerThanMinAge(t *testing.T) {
+	recent := &repository.GlobalStatsSnapshot{TotalPublishedMaterials: 7, GeneratedAt: time.Now().Add(-10 * time.Second)}
+	snapshots := new(MockStatsSnapshotRepository)
+	snapshots.On("GetGlobalSnapshot", mock.Anything).Return(recent, nil)
+	snapshots.On("SaveGlobalSnapshot", mock.Anything, mock.Anything).Return(nil).Once()
+
+	svc := newSnapshotTestService(1, snapshots, GlobalStatsCacheConfig{CacheTTL: time.Minute, SnapshotMaxAge: time.Minute})
+
+	stats, err := svc.RefreshGlobalStats(context.Background(), 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), stats.TotalPublishedMaterials, "reutiliza el snapshot compartido")
+
+	stats, err = svc.RefreshGlobalStats(context.Background(), 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), stats.TotalPublishedMaterials, "snapshot más viejo que minAge se recalcula")
+	snapshots.AssertExpectations(t)
+}
+
+// countingStatsService cuenta refrescos para los tests del scheduler
+type countingStatsService struct {
+	StatsService
+	refreshes  atomic.Int32
+	lastMinAge atomic.Int64
+}
+
+func (c *countingStatsService) RefreshGlobalStats(ctx context.Context, minAge time.Duration) (*dto.GlobalStatsDTO, error) {
+	c.refreshes.Add(1)
+	c.lastMinAge.Store(int64(minAge))
+	return &dto.GlobalStatsDTO{GeneratedAt: time.Now()}, nil
+}
+
+// TestStatsSnapshotScheduler_DebouncesEvents verifica el refresco inicial y la agrupación de eventos
+func TestStatsSnapshotScheduler_DebouncesEvents(t *testing.T) {
+	stats := &countingStatsService{}
+	logger := new(MockLogger)
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	scheduler := NewStatsSnapshotScheduler(stats, 0, 50*time.Millisecond, logger)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	require.Eventually(t, func() bool { return stats.refreshes.Load() == 1 }, time.Second, 5*time.Millisecond, "refresco inicial")
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, scheduler.HandleEvent(context.Background(), eventbus.Event{RoutingKey: "progress.updated"}))
+	}
+
+	require.Eventually(t, func() bool { return stats.refreshes.Load() == 2 }, time.Second, 5*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(2), stats.refreshes.Load(), "la ráfaga de eventos produce un solo refresco")
+	assert.Equal(t, int64(50*time.Millisecond), stats.lastMinAge.Load(), "el refresco por evento omite snapshots más recientes que el debounce")
+}
+
+// TestStatsSnapshotScheduler_Interval verifica el refresco periódico
+func TestStatsSnapshotScheduler_Interval(t *testing.T) {
+	stats := &countingStatsService{}
+	logger := new(MockLogger)
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	scheduler := NewStatsSnapshotScheduler(stats, 20*time.Millisecond, time.Second, logger)
+	scheduler.Start()
+
+	require.Eventually(t, func() bool { return stats.refreshes.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	scheduler.Stop()
+	stopped := stats.refreshes.Load()
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, stopped, stats.refreshes.Load(), "Stop detiene el loop")
+}
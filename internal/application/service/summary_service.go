@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
@@ -11,27 +12,40 @@ import (
 
 // SummaryService define operaciones para summaries
 type SummaryService interface {
-	GetSummary(ctx context.Context, materialID string) (*repository.MaterialSummary, error)
+	// GetSummary evalúa material:read (un material de otra escuela responde 404) y responde
+	// 403 MATERIAL_LOCKED si una ruta de aprendizaje bloquea el material al subject
+	GetSummary(ctx context.Context, materialID string, subject policy.Subject) (*repository.MaterialSummary, error)
 }
 
 type summaryService struct {
-	summaryRepo repository.SummaryRepository
-	logger      logger.Logger
+	summaryRepo  repository.SummaryRepository
+	materialRepo repository.MaterialReader
+	lockChecker  MaterialLockChecker
+	logger       logger.Logger
 }
 
-func NewSummaryService(summaryRepo repository.SummaryRepository, logger logger.Logger) SummaryService {
+func NewSummaryService(summaryRepo repository.SummaryRepository, materialRepo repository.MaterialReader, lockChecker MaterialLockChecker, logger logger.Logger) SummaryService {
 	return &summaryService{
-		summaryRepo: summaryRepo,
-		logger:      logger,
+		summaryRepo:  summaryRepo,
+		materialRepo: materialRepo,
+		lockChecker:  lockChecker,
+		logger:       logger,
 	}
 }
 
-func (s *summaryService) GetSummary(ctx context.Context, materialID string) (*repository.MaterialSummary, error) {
+func (s *summaryService) GetSummary(ctx context.Context, materialID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 	matID, err := valueobject.MaterialIDFromString(materialID)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid material_id")
 	}
 
+	if _, err := authorizeMaterialRead(ctx, s.materialRepo, matID, subject, s.logger); err != nil {
+		return nil, err
+	}
+	if err := ensureMaterialUnlocked(ctx, s.lockChecker, materialID, subject); err != nil {
+		return nil, err
+	}
+
 	summary, err := s.summaryRepo.FindByMaterialID(ctx, matID)
 	if err != nil {
 		s.logger.Error("failed to get summary", "error", err)
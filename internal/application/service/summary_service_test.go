@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
 )
@@ -40,12 +41,18 @@ func (m *MockSummaryRepository) Delete(ctx context.Context, materialID valueobje
 	return args.Error(0)
 }
 
+// summaryTestSchoolID escuela del material y del estudiante de los tests de resumen
+const summaryTestSchoolID = "770e8400-e29b-41d4-a716-446655440002"
+
+// summaryTestSubject estudiante con material:read sobre los materiales de su escuela
+var summaryTestSubject = policy.Subject{UserID: "660e8400-e29b-41d4-a716-446655440001", SchoolID: summaryTestSchoolID}
+
 // TestNewSummaryService verifica que el constructor inicialice correctamente
 func TestNewSummaryService(t *testing.T) {
 	mockRepo := new(MockSummaryRepository)
 	mockLogger := new(MockLogger)
 
-	service := NewSummaryService(mockRepo, mockLogger)
+	service := NewSummaryService(mockRepo, materialsInSchool(summaryTestSchoolID), nil, mockLogger)
 
 	assert.NotNil(t, service)
 }
@@ -54,7 +61,7 @@ func TestNewSummaryService(t *testing.T) {
 func TestGetSummary_Success(t *testing.T) {
 	mockRepo := new(MockSummaryRepository)
 	mockLogger := new(MockLogger)
-	service := NewSummaryService(mockRepo, mockLogger)
+	service := NewSummaryService(mockRepo, materialsInSchool(summaryTestSchoolID), nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -68,7 +75,7 @@ func TestGetSummary_Success(t *testing.T) {
 
 	mockRepo.On("FindByMaterialID", ctx, matID).Return(expectedSummary, nil)
 
-	result, err := service.GetSummary(ctx, materialID)
+	result, err := service.GetSummary(ctx, materialID, summaryTestSubject)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -80,12 +87,12 @@ func TestGetSummary_Success(t *testing.T) {
 func TestGetSummary_InvalidMaterialID(t *testing.T) {
 	mockRepo := new(MockSummaryRepository)
 	mockLogger := new(MockLogger)
-	service := NewSummaryService(mockRepo, mockLogger)
+	service := NewSummaryService(mockRepo, materialsInSchool(summaryTestSchoolID), nil, mockLogger)
 
 	ctx := context.Background()
 	invalidID := "invalid-uuid"
 
-	result, err := service.GetSummary(ctx, invalidID)
+	result, err := service.GetSummary(ctx, invalidID, summaryTestSubject)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -96,7 +103,7 @@ func TestGetSummary_InvalidMaterialID(t *testing.T) {
 func TestGetSummary_NotFound(t *testing.T) {
 	mockRepo := new(MockSummaryRepository)
 	mockLogger := new(MockLogger)
-	service := NewSummaryService(mockRepo, mockLogger)
+	service := NewSummaryService(mockRepo, materialsInSchool(summaryTestSchoolID), nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -104,7 +111,7 @@ func TestGetSummary_NotFound(t *testing.T) {
 
 	mockRepo.On("FindByMaterialID", ctx, matID).Return(nil, nil)
 
-	result, err := service.GetSummary(ctx, materialID)
+	result, err := service.GetSummary(ctx, materialID, summaryTestSubject)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -117,7 +124,7 @@ func TestGetSummary_DatabaseError(t *testing.T) {
 	mockRepo := new(MockSummaryRepository)
 	mockLogger := new(MockLogger)
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
-	service := NewSummaryService(mockRepo, mockLogger)
+	service := NewSummaryService(mockRepo, materialsInSchool(summaryTestSchoolID), nil, mockLogger)
 
 	ctx := context.Background()
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
@@ -126,7 +133,7 @@ func TestGetSummary_DatabaseError(t *testing.T) {
 	dbError := errors.New("database connection failed")
 	mockRepo.On("FindByMaterialID", ctx, matID).Return(nil, dbError)
 
-	result, err := service.GetSummary(ctx, materialID)
+	result, err := service.GetSummary(ctx, materialID, summaryTestSubject)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
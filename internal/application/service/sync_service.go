@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+const (
+	// maxClientClockSkew tolerancia para timestamps del cliente en el futuro
+	maxClientClockSkew = 5 * time.Minute
+
+	// syncChangesPageSize cambios del servidor retornados por sincronización
+	syncChangesPageSize = 200
+)
+
+// SyncService aplica lotes de cambios hechos offline y retorna los cambios hechos en otros dispositivos
+type SyncService interface {
+	// Sync aplica los ítems en orden de client_timestamp y retorna un resultado por ítem en el orden
+	// recibido, más el progreso modificado en el servidor desde el cursor.
+	// subject es el usuario con la escuela resuelta; allowAttempts indica si puede registrar intentos de evaluación
+	Sync(ctx context.Context, subject policy.Subject, req dto.SyncRequest, allowAttempts bool) (*dto.SyncResponse, error)
+}
+
+type syncService struct {
+	progressService ProgressService
+	attemptService  AssessmentAttemptService
+	progressRepo    repository.ProgressRepository
+	syncOps         repository.SyncOperationRepository
+	logger          logger.Logger
+	now             func() time.Time
+}
+
+// NewSyncService crea el servicio de sincronización offline
+// Los ítems se delegan a ProgressService y AssessmentAttemptService; syncOps los hace idempotentes
+func NewSyncService(
+	progressService ProgressService,
+	attemptService AssessmentAttemptService,
+	progressRepo repository.ProgressRepository,
+	syncOps repository.SyncOperationRepository,
+	logger logger.Logger,
+) SyncService {
+	return &syncService{
+		progressService: progressService,
+		attemptService:  attemptService,
+		progressRepo:    progressRepo,
+		syncOps:         syncOps,
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+func (s *syncService) Sync(ctx context.Context, subject policy.Subject, req dto.SyncRequest, allowAttempts bool) (*dto.SyncResponse, error) {
+	userIDStr := subject.UserID
+	userID, err := valueobject.UserIDFromString(userIDStr)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id")
+	}
+
+	// El cursor se valida antes de aplicar nada: un cursor corrupto no deja el lote a medias
+	cursor, err := decodeSyncCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orden causal del dispositivo: last-writer-wins depende de aplicar en orden de client_timestamp
+	order := make([]int, len(req.Items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return req.Items[order[a]].ClientTimestamp.Before(req.Items[order[b]].ClientTimestamp)
+	})
+
+	results := make([]dto.SyncItemResultDTO, len(req.Items))
+	for _, i := range order {
+		results[i] = s.applyItem(ctx, subject, req.Items[i], allowAttempts)
+	}
+
+	changes, err := s.progressRepo.ListChangedSince(ctx, userID, cursor, syncChangesPageSize+1)
+	if err != nil {
+		s.logger.Error("failed to list progress changes", "user_id", userIDStr, "error", err)
+		return nil, errors.NewDatabaseError("list progress changes", err)
+	}
+
+	hasMore := len(changes) > syncChangesPageSize
+	if hasMore {
+		changes = changes[:syncChangesPageSize]
+	}
+
+	response := &dto.SyncResponse{
+		Results:    results,
+		Changes:    make([]dto.ProgressStateDTO, 0, len(changes)),
+		Cursor:     req.Cursor,
+		HasMore:    hasMore,
+		ServerTime: s.now().UTC(),
+	}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, *progressStateDTO(change))
+	}
+	if len(changes) > 0 {
+		last := changes[len(changes)-1]
+		response.Cursor = encodeSyncCursor(repository.ProgressCursor{
+			UpdatedAt:  last.UpdatedAt,
+			MaterialID: last.MaterialID.String(),
+		})
+	}
+
+	s.logger.Info("sync completed",
+		"user_id", userIDStr,
+		"items", len(req.Items),
+		"changes", len(response.Changes),
+		"has_more", hasMore,
+	)
+	return response, nil
+}
+
+// applyItem aplica un ítem una sola vez por client_id
+// Los errores permanentes quedan registrados (un reenvío recibe el mismo rechazo);
+// los transitorios liberan el reclamo para que el cliente reintente
+func (s *syncService) applyItem(ctx context.Context, subject policy.Subject, item dto.SyncItemDTO, allowAttempts bool) dto.SyncItemResultDTO {
+	result := dto.SyncItemResultDTO{ClientID: item.ClientID, Type: item.Type}
+	userID := subject.UserID
+
+	if err := s.validateItem(item, allowAttempts); err != nil {
+		return rejectedSyncResult(result, err)
+	}
+
+	existing, err := s.syncOps.ClaimSyncOperation(ctx, &repository.SyncOperation{
+		UserID:   userID,
+		ClientID: item.ClientID,
+		Type:     item.Type,
+	})
+	if err != nil {
+		s.logger.Error("failed to claim sync operation", "user_id", userID, "client_id", item.ClientID, "error", err)
+		return failedSyncResult(result, errors.NewDatabaseError("claim sync operation", err))
+	}
+	if existing != nil {
+		return duplicateSyncResult(result, existing)
+	}
+
+	payload, err := s.executeItem(ctx, subject, item)
+	if err != nil {
+		appErr, ok := errors.GetAppError(err)
+		if !ok || appErr.Code == errors.ErrorCodeDatabaseError || appErr.Code == errors.ErrorCodeInternal {
+			if releaseErr := s.syncOps.ReleaseSyncOperation(ctx, userID, item.ClientID); releaseErr != nil {
+				s.logger.Error("failed to release sync operation", "client_id", item.ClientID, "error", releaseErr)
+			}
+			return failedSyncResult(result, err)
+		}
+
+		s.completeOperation(ctx, &repository.SyncOperation{
+			UserID:       userID,
+			ClientID:     item.ClientID,
+			Type:         item.Type,
+			Status:       repository.SyncStatusRejected,
+			ErrorCode:    string(appErr.Code),
+			ErrorMessage: appErr.Message,
+		})
+		return rejectedSyncResult(result, err)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Error("failed to serialize sync result", "client_id", item.ClientID, "error", err)
+		body = nil
+	}
+	s.completeOperation(ctx, &repository.SyncOperation{
+		UserID:   userID,
+		ClientID: item.ClientID,
+		Type:     item.Type,
+		Status:   repository.SyncStatusApplied,
+		Result:   body,
+	})
+
+	result.Status = dto.SyncResultApplied
+	result.Result = body
+	return result
+}
+
+// validateItem verifica que el ítem traiga el payload de su tipo y un client_timestamp razonable
+func (s *syncService) validateItem(item dto.SyncItemDTO, allowAttempts bool) error {
+	if strings.TrimSpace(item.ClientID) == "" || len(item.ClientID) > 64 {
+		return errors.NewValidationError("client_id is required (max 64 characters)")
+	}
+	if item.ClientTimestamp.IsZero() {
+		return errors.NewValidationError("client_timestamp is required")
+	}
+	if item.ClientTimestamp.After(s.now().Add(maxClientClockSkew)) {
+		return errors.NewValidationError("client_timestamp must not be in the future")
+	}
+
+	switch item.Type {
+	case dto.SyncItemProgress:
+		if item.Progress == nil {
+			return errors.NewValidationError("progress payload is required")
+		}
+		if item.Progress.MaterialID == "" {
+			return errors.NewValidationError("material_id is required")
+		}
+	case dto.SyncItemReadingEvents:
+		if item.ReadingEvents == nil {
+			return errors.NewValidationError("reading_events payload is required")
+		}
+		if item.ReadingEvents.MaterialID == "" {
+			return errors.NewValidationError("material_id is required")
+		}
+	case dto.SyncItemAttempt:
+		if item.Attempt == nil {
+			return errors.NewValidationError("attempt payload is required")
+		}
+		if !allowAttempts {
+			return errors.NewForbiddenError("missing permission to submit assessment attempts")
+		}
+		return validateSyncAttempt(item.Attempt)
+	default:
+		return errors.NewValidationError("type must be progress, reading_events or attempt")
+	}
+	return nil
+}
+
+// validateSyncAttempt aplica al intento las reglas que CreateAttemptRequest valida al bindear
+func validateSyncAttempt(attempt *dto.SyncAttemptDTO) error {
+	if attempt.MaterialID == "" {
+		return errors.NewValidationError("material_id is required")
+	}
+	if len(attempt.Answers) == 0 {
+		return errors.NewValidationError("answers must not be empty")
+	}
+	if attempt.TimeSpentSeconds < 1 || attempt.TimeSpentSeconds > 7200 {
+		return errors.NewValidationError("time_spent_seconds must be between 1 and 7200")
+	}
+	for _, answer := range attempt.Answers {
+		if answer.QuestionID == "" || answer.SelectedAnswerID == "" {
+			return errors.NewValidationError("question_id and selected_answer_id are required")
+		}
+		if answer.TimeSpentSeconds < 0 {
+			return errors.NewValidationError("answer time_spent_seconds must not be negative")
+		}
+	}
+	return nil
+}
+
+// executeItem delega el ítem en el servicio del endpoint equivalente usando la hora del cliente
+func (s *syncService) executeItem(ctx context.Context, subject policy.Subject, item dto.SyncItemDTO) (interface{}, error) {
+	userID, schoolID := subject.UserID, subject.SchoolID
+	switch item.Type {
+	case dto.SyncItemProgress:
+		p := item.Progress
+		return s.progressService.MergeProgress(ctx, p.MaterialID, userID, schoolID,
+			p.ProgressPercentage, p.LastPage, item.ClientTimestamp, subject)
+
+	case dto.SyncItemReadingEvents:
+		req := *item.ReadingEvents
+		req.Events = make([]dto.ReadingEventDTO, len(item.ReadingEvents.Events))
+		for i, e := range item.ReadingEvents.Events {
+			if e.OccurredAt == nil {
+				ts := item.ClientTimestamp
+				e.OccurredAt = &ts
+			}
+			req.Events[i] = e
+		}
+		return s.progressService.RecordReadingEvents(ctx, userID, schoolID, req, subject)
+
+	case dto.SyncItemAttempt:
+		materialID, err := uuid.Parse(item.Attempt.MaterialID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid material_id")
+		}
+		studentID, err := uuid.Parse(userID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid user_id")
+		}
+		completedAt := item.ClientTimestamp
+		if item.Attempt.CompletedAt != nil {
+			completedAt = *item.Attempt.CompletedAt
+		}
+		return s.attemptService.CreateOfflineAttempt(ctx, studentID, materialID, item.Attempt.CreateAttemptRequest, completedAt, subject)
+	}
+	return nil, errors.NewValidationError("unsupported sync item type")
+}
+
+// completeOperation registra el resultado final; si falla, el reclamo vence con SyncClaimTimeout
+func (s *syncService) completeOperation(ctx context.Context, op *repository.SyncOperation) {
+	if err := s.syncOps.CompleteSyncOperation(ctx, op); err != nil {
+		s.logger.Error("failed to complete sync operation",
+			"user_id", op.UserID,
+			"client_id", op.ClientID,
+			"status", op.Status,
+			"error", err,
+		)
+	}
+}
+
+func rejectedSyncResult(result dto.SyncItemResultDTO, err error) dto.SyncItemResultDTO {
+	result.Status = dto.SyncResultRejected
+	result.Error = syncErrorDTO(err)
+	return result
+}
+
+func failedSyncResult(result dto.SyncItemResultDTO, err error) dto.SyncItemResultDTO {
+	result.Status = dto.SyncResultFailed
+	result.Error = syncErrorDTO(err)
+	return result
+}
+
+func duplicateSyncResult(result dto.SyncItemResultDTO, existing *repository.SyncOperation) dto.SyncItemResultDTO {
+	if existing.Status == repository.SyncStatusPending {
+		result.Status = dto.SyncResultInProgress
+		return result
+	}
+
+	result.Status = dto.SyncResultDuplicate
+	result.Result = existing.Result
+	if existing.Status == repository.SyncStatusRejected {
+		result.Error = &dto.SyncErrorDTO{Code: existing.ErrorCode, Message: existing.ErrorMessage}
+	}
+	return result
+}
+
+func syncErrorDTO(err error) *dto.SyncErrorDTO {
+	if appErr, ok := errors.GetAppError(err); ok {
+		return &dto.SyncErrorDTO{Code: string(appErr.Code), Message: appErr.Message}
+	}
+	return &dto.SyncErrorDTO{Code: string(errors.ErrorCodeInternal), Message: "internal server error"}
+}
+
+// encodeSyncCursor serializa la posición en el feed de cambios como texto opaco
+func encodeSyncCursor(cursor repository.ProgressCursor) string {
+	raw := cursor.UpdatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.MaterialID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeSyncCursor interpreta el cursor retornado por una sincronización previa ("" = desde el inicio)
+func decodeSyncCursor(cursor string) (repository.ProgressCursor, error) {
+	if cursor == "" {
+		return repository.ProgressCursor{}, nil
+	}
+
+	invalid := errors.NewValidationError("invalid cursor")
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return repository.ProgressCursor{}, invalid
+	}
+	updatedAt, materialID, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return repository.ProgressCursor{}, invalid
+	}
+	ts, err := time.Parse(time.RFC3339Nano, updatedAt)
+	if err != nil {
+		return repository.ProgressCursor{}, invalid
+	}
+	if materialID != "" {
+		if _, err := uuid.Parse(materialID); err != nil {
+			return repository.ProgressCursor{}, invalid
+		}
+	}
+	return repository.ProgressCursor{UpdatedAt: ts, MaterialID: materialID}, nil
+}
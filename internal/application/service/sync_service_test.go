@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/fixtures"
+	mockPostgres "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/postgres"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// stubAttemptService registra intentos sin MongoDB para los tests de sincronización
+type stubAttemptService struct {
+	AssessmentAttemptService
+	createAttempt func(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, completedAt time.Time) (*dto.AttemptResultResponse, error)
+	subjects      []policy.Subject
+}
+
+func (s *stubAttemptService) CreateOfflineAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, completedAt time.Time, subject policy.Subject) (*dto.AttemptResultResponse, error) {
+	s.subjects = append(s.subjects, subject)
+	return s.createAttempt(ctx, studentID, materialID, req, completedAt)
+}
+
+type syncTestEnv struct {
+	service   SyncService
+	attempts  *stubAttemptService
+	publisher *MockPublisher
+	userID    string
+	schoolID  string
+}
+
+func newSyncTestEnv(t *testing.T) *syncTestEnv {
+	t.Helper()
+
+	publisher := new(MockPublisher)
+	publisher.On("Publish", mock.Anything, "edugo.events", mock.Anything, mock.Anything).Return(nil)
+	logger := new(MockProgressLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Warn", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	schoolID := uuid.NewString()
+	progressRepo := mockPostgres.NewMockProgressRepository()
+	progressService := NewProgressService(progressRepo, mockPostgres.NewMockReadingEventRepository(), materialsInSchool(schoolID), publisher, nil, logger)
+	attempts := &stubAttemptService{
+		createAttempt: func(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, completedAt time.Time) (*dto.AttemptResultResponse, error) {
+			return &dto.AttemptResultResponse{AttemptID: uuid.New(), Score: 80, CompletedAt: completedAt}, nil
+		},
+	}
+
+	return &syncTestEnv{
+		service:   NewSyncService(progressService, attempts, progressRepo, mockPostgres.NewMockSyncOperationRepository(), logger),
+		attempts:  attempts,
+		publisher: publisher,
+		userID:    uuid.NewString(),
+		schoolID:  schoolID,
+	}
+}
+
+// subject usuario del lote con la escuela resuelta
+func (e *syncTestEnv) subject() policy.Subject {
+	return policy.Subject{UserID: e.userID, SchoolID: e.schoolID}
+}
+
+func (e *syncTestEnv) sync(t *testing.T, req dto.SyncRequest) *dto.SyncResponse {
+	t.Helper()
+	response, err := e.service.Sync(context.Background(), e.subject(), req, true)
+	require.NoError(t, err)
+	return response
+}
+
+func validAttemptRequest() dto.CreateAttemptRequest {
+	return dto.CreateAttemptRequest{
+		Answers:          []dto.UserAnswerDTO{{QuestionID: "q1", SelectedAnswerID: "a", TimeSpentSeconds: 30}},
+		TimeSpentSeconds: 30,
+	}
+}
+
+func progressItem(clientID, materialID string, percentage, lastPage int, ts time.Time) dto.SyncItemDTO {
+	return dto.SyncItemDTO{
+		ClientID:        clientID,
+		Type:            dto.SyncItemProgress,
+		ClientTimestamp: ts,
+		Progress:        &dto.SyncProgressDTO{MaterialID: materialID, ProgressPercentage: percentage, LastPage: lastPage},
+	}
+}
+
+// TestSync_MergesProgressInClientOrder verifica max-merge del porcentaje y last-writer-wins de la página
+func TestSync_MergesProgressInClientOrder(t *testing.T) {
+	env := newSyncTestEnv(t)
+	materialID := uuid.NewString()
+	base := time.Now().Add(-2 * time.Hour)
+
+	response := env.sync(t, dto.SyncRequest{Items: []dto.SyncItemDTO{
+		progressItem("later", materialID, 30, 12, base.Add(time.Hour)),
+		progressItem("earlier", materialID, 50, 5, base),
+	}})
+
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, "later", response.Results[0].ClientID, "resultados en el orden recibido")
+	assert.Equal(t, dto.SyncResultApplied, response.Results[0].Status)
+	assert.Equal(t, dto.SyncResultApplied, response.Results[1].Status)
+
+	require.Len(t, response.Changes, 1)
+	change := response.Changes[0]
+	assert.Equal(t, 50, change.ProgressPercentage, "el porcentaje mayor gana aunque sea más viejo")
+	assert.Equal(t, 12, change.LastPage, "la página del cambio más reciente gana")
+	assert.WithinDuration(t, base.Add(time.Hour), change.LastAccessedAt, time.Millisecond)
+
+	// Un reporte offline atrasado que llega después tampoco pisa la página
+	late := env.sync(t, dto.SyncRequest{Items: []dto.SyncItemDTO{progressItem("stale", materialID, 10, 2, base.Add(-time.Hour))}})
+	require.Len(t, late.Changes, 1)
+	assert.Equal(t, 50, late.Changes[0].ProgressPercentage)
+	assert.Equal(t, 12, late.Changes[0].LastPage)
+}
+
+// TestSync_IsIdempotentByClientID verifica que un reenvío repita resultados sin volver a aplicar
+func TestSync_IsIdempotentByClientID(t *testing.T) {
+	env := newSyncTestEnv(t)
+	ts := time.Now().Add(-time.Minute)
+	items := []dto.SyncItemDTO{
+		progressItem("p1", uuid.NewString(), 40, 8, ts),
+		progressItem("bad", uuid.NewString(), 140, 8, ts),
+	}
+
+	first := env.sync(t, dto.SyncRequest{Items: items})
+	assert.Equal(t, dto.SyncResultApplied, first.Results[0].Status)
+	assert.Equal(t, dto.SyncResultRejected, first.Results[1].Status)
+	assert.Equal(t, string(errors.ErrorCodeValidation), first.Results[1].Error.Code)
+	publishes := len(env.publisher.Calls)
+
+	second := env.sync(t, dto.SyncRequest{Items: items})
+	assert.Equal(t, dto.SyncResultDuplicate, second.Results[0].Status)
+	assert.JSONEq(t, string(first.Results[0].Result), string(second.Results[0].Result))
+	assert.Equal(t, dto.SyncResultDuplicate, second.Results[1].Status)
+	assert.Equal(t, first.Results[1].Error, second.Results[1].Error, "el rechazo se repite")
+	assert.Len(t, env.publisher.Calls, publishes, "nada se vuelve a aplicar")
+}
+
+// TestSync_TransientFailureCanBeRetried verifica que un error de base de datos libere el client_id
+func TestSync_TransientFailureCanBeRetried(t *testing.T) {
+	env := newSyncTestEnv(t)
+	calls := 0
+	env.attempts.createAttempt = func(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, completedAt time.Time) (*dto.AttemptResultResponse, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.NewDatabaseError("save attempt", assert.AnError)
+		}
+		return &dto.AttemptResultResponse{Score: 90}, nil
+	}
+	item := dto.SyncItemDTO{
+		ClientID:        "attempt-1",
+		Type:            dto.SyncItemAttempt,
+		ClientTimestamp: time.Now().Add(-time.Hour),
+		Attempt:         &dto.SyncAttemptDTO{MaterialID: uuid.NewString(), CreateAttemptRequest: validAttemptRequest()},
+	}
+
+	first := env.sync(t, dto.SyncRequest{Items: []dto.SyncItemDTO{item}})
+	assert.Equal(t, dto.SyncResultFailed, first.Results[0].Status)
+
+	retry := env.sync(t, dto.SyncRequest{Items: []dto.SyncItemDTO{item}})
+	assert.Equal(t, dto.SyncResultApplied, retry.Results[0].Status)
+	assert.Equal(t, 2, calls)
+}
+
+// TestSync_AttemptsUseClientTimestampAndPermission verifica la hora de finalización y el permiso por ítem
+func TestSync_AttemptsUseClientTimestampAndPermission(t *testing.T) {
+	env := newSyncTestEnv(t)
+	completedAt := time.Now().Add(-3 * time.Hour).UTC()
+	materialID := uuid.New()
+	env.attempts.createAttempt = func(ctx context.Context, studentID, matID uuid.UUID, req dto.CreateAttemptRequest, at time.Time) (*dto.AttemptResultResponse, error) {
+		assert.Equal(t, env.userID, studentID.String())
+		assert.Equal(t, materialID, matID)
+		assert.True(t, at.Equal(completedAt))
+		return &dto.AttemptResultResponse{Score: 70, CompletedAt: at}, nil
+	}
+	req := dto.SyncRequest{Items: []dto.SyncItemDTO{{
+		ClientID:        "attempt-offline",
+		Type:            dto.SyncItemAttempt,
+		ClientTimestamp: completedAt,
+		Attempt:         &dto.SyncAttemptDTO{MaterialID: materialID.String(), CreateAttemptRequest: validAttemptRequest()},
+	}}}
+
+	denied, err := env.service.Sync(context.Background(), env.subject(), req, false)
+	require.NoError(t, err)
+	assert.Equal(t, dto.SyncResultRejected, denied.Results[0].Status)
+	assert.Equal(t, string(errors.ErrorCodeForbidden), denied.Results[0].Error.Code)
+
+	allowed := env.sync(t, req)
+	assert.Equal(t, dto.SyncResultApplied, allowed.Results[0].Status, "un rechazo por permisos no queda registrado")
+
+	var result dto.AttemptResultResponse
+	require.NoError(t, json.Unmarshal(allowed.Results[0].Result, &result))
+	assert.Equal(t, 70, result.Score)
+	// El servicio de intentos recibe el subject para aplicar el bloqueo de las rutas de aprendizaje
+	assert.Equal(t, []policy.Subject{env.subject()}, env.attempts.subjects)
+}
+
+// TestSync_ReadingEventsDefaultToClientTimestamp verifica que los eventos sin hora usen la del ítem
+func TestSync_ReadingEventsDefaultToClientTimestamp(t *testing.T) {
+	env := newSyncTestEnv(t)
+	ts := time.Now().Add(-30 * time.Minute)
+
+	response := env.sync(t, dto.SyncRequest{Items: []dto.SyncItemDTO{{
+		ClientID:        "reading-1",
+		Type:            dto.SyncItemReadingEvents,
+		ClientTimestamp: ts,
+		ReadingEvents: &dto.ReadingEventsRequest{
+			MaterialID: fixtures.MaterialGuiaRestasID.String(), // 10 páginas
+			Events:     pageEvents("r", 1, 3, 40),
+		},
+	}}})
+
+	require.Equal(t, dto.SyncResultApplied, response.Results[0].Status)
+	require.Len(t, response.Changes, 1)
+	assert.Equal(t, 30, response.Changes[0].ProgressPercentage)
+	assert.WithinDuration(t, ts, response.Changes[0].LastAccessedAt, time.Millisecond)
+}
+
+// TestSync_CursorReturnsOnlyNewChanges verifica el feed de cambios del servidor
+func TestSync_CursorReturnsOnlyNewChanges(t *testing.T) {
+	env := newSyncTestEnv(t)
+	ts := time.Now().Add(-time.Minute)
+
+	first := env.sync(t, dto.SyncRequest{Items: []dto.SyncItemDTO{
+		progressItem("a", uuid.NewString(), 10, 1, ts),
+		progressItem("b", uuid.NewString(), 20, 2, ts),
+	}})
+	require.Len(t, first.Changes, 2)
+	assert.False(t, first.HasMore)
+	require.NotEmpty(t, first.Cursor)
+
+	pull := env.sync(t, dto.SyncRequest{Cursor: first.Cursor})
+	assert.Empty(t, pull.Changes)
+	assert.Equal(t, first.Cursor, pull.Cursor, "sin cambios el cursor no avanza")
+
+	// Cambio hecho desde otro dispositivo
+	other := env.sync(t, dto.SyncRequest{Items: []dto.SyncItemDTO{progressItem("c", uuid.NewString(), 5, 1, ts)}})
+	pull = env.sync(t, dto.SyncRequest{Cursor: first.Cursor})
+	require.Len(t, pull.Changes, 1)
+	assert.Equal(t, other.Cursor, pull.Cursor)
+}
+
+// TestSync_ValidationErrors verifica errores del lote completo y de ítems individuales
+func TestSync_ValidationErrors(t *testing.T) {
+	env := newSyncTestEnv(t)
+
+	_, err := env.service.Sync(context.Background(), env.subject(), dto.SyncRequest{Cursor: "%%%"}, true)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorCodeValidation, appErr.Code)
+
+	response := env.sync(t, dto.SyncRequest{Items: []dto.SyncItemDTO{
+		progressItem("future", uuid.NewString(), 10, 1, time.Now().Add(time.Hour)),
+		{ClientID: "no-payload", Type: dto.SyncItemProgress, ClientTimestamp: time.Now()},
+		progressItem("bad-material", "not-a-uuid", 10, 1, time.Now()),
+	}})
+	for _, result := range response.Results {
+		assert.Equal(t, dto.SyncResultRejected, result.Status, result.ClientID)
+		assert.Equal(t, string(errors.ErrorCodeValidation), result.Error.Code, result.ClientID)
+	}
+}
+
+// TestSync_InvalidItemsAreRejectedIndividually verifica que un ítem inválido no rechace el lote
+func TestSync_InvalidItemsAreRejectedIndividually(t *testing.T) {
+	env := newSyncTestEnv(t)
+	now := time.Now()
+	completedAt := now.Add(-2 * time.Hour)
+	attempt := func(clientID string, req dto.CreateAttemptRequest) dto.SyncItemDTO {
+		return dto.SyncItemDTO{
+			ClientID:        clientID,
+			Type:            dto.SyncItemAttempt,
+			ClientTimestamp: now.Add(-time.Hour),
+			Attempt:         &dto.SyncAttemptDTO{MaterialID: uuid.NewString(), CompletedAt: &completedAt, CreateAttemptRequest: req},
+		}
+	}
+	var received time.Time
+	env.attempts.createAttempt = func(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, at time.Time) (*dto.AttemptResultResponse, error) {
+		received = at
+		return &dto.AttemptResultResponse{Score: 60}, nil
+	}
+
+	response := env.sync(t, dto.SyncRequest{Items: []dto.SyncItemDTO{
+		{ClientID: "", Type: dto.SyncItemProgress, ClientTimestamp: now, Progress: &dto.SyncProgressDTO{MaterialID: uuid.NewString()}},
+		{ClientID: "unknown-type", Type: "quiz", ClientTimestamp: now},
+		progressItem("no-material", "", 10, 1, now),
+		attempt("no-answers", dto.CreateAttemptRequest{TimeSpentSeconds: 30}),
+		attempt("too-long", dto.CreateAttemptRequest{Answers: validAttemptRequest().Answers, TimeSpentSeconds: 7201}),
+		attempt("valid-attempt", validAttemptRequest()),
+		progressItem("valid-progress", uuid.NewString(), 10, 1, now),
+	}})
+
+	require.Len(t, response.Results, 7)
+	for _, result := range response.Results[:5] {
+		assert.Equal(t, dto.SyncResultRejected, result.Status, result.ClientID)
+		assert.Equal(t, string(errors.ErrorCodeValidation), result.Error.Code, result.ClientID)
+	}
+	assert.Equal(t, dto.SyncResultApplied, response.Results[5].Status)
+	assert.True(t, received.Equal(completedAt), "completed_at del ítem tiene prioridad sobre client_timestamp")
+	assert.Equal(t, dto.SyncResultApplied, response.Results[6].Status)
+}
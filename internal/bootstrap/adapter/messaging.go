@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/tracing"
 	"github.com/EduGoGroup/edugo-shared/logger"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -35,20 +36,24 @@ func NewMessagePublisherAdapter(
 }
 
 // Publish publica un mensaje al exchange especificado con el routing key dado
+// Propaga el request_id y el contexto de traza W3C en los headers AMQP
 // Implementa la interfaz rabbitmq.Publisher
 func (a *MessagePublisherAdapter) Publish(
 	ctx context.Context,
 	exchange string,
 	routingKey string,
 	body []byte,
-) error {
+) (err error) {
 	// Si no se especifica exchange, usar el default del adapter
 	if exchange == "" {
 		exchange = a.exchange
 	}
 
+	ctx, span := tracing.StartPublishSpan(ctx, exchange, routingKey, len(body))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	// Publicar mensaje con contexto
-	err := a.channel.PublishWithContext(
+	err = a.channel.PublishWithContext(
 		ctx,
 		exchange,   // exchange
 		routingKey, // routing key
@@ -58,6 +63,7 @@ func (a *MessagePublisherAdapter) Publish(
 			ContentType:  "application/json",
 			Body:         body,
 			DeliveryMode: amqp.Persistent, // Mensajes persistentes
+			Headers:      rabbitmq.MessageHeaders(ctx),
 		},
 	)
 
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	infraS3 "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/storage/s3"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/tracing"
 	"github.com/EduGoGroup/edugo-shared/logger"
 	awsS3 "github.com/aws/aws-sdk-go-v2/service/s3"
 )
@@ -52,9 +53,11 @@ func (a *StorageClientAdapter) GeneratePresignedUploadURL(
 	}
 
 	// Generar URL presignada
+	ctx, span := tracing.StartS3Span(ctx, "PresignPutObject", a.bucketName, key)
 	request, err := a.presignClient.PresignPutObject(ctx, input, func(opts *awsS3.PresignOptions) {
 		opts.Expires = expires
 	})
+	tracing.EndSpan(span, err)
 
 	if err != nil {
 		a.logger.Error("failed to generate presigned upload URL",
@@ -88,9 +91,11 @@ func (a *StorageClientAdapter) GeneratePresignedDownloadURL(
 	}
 
 	// Generar URL presignada
+	ctx, span := tracing.StartS3Span(ctx, "PresignGetObject", a.bucketName, key)
 	request, err := a.presignClient.PresignGetObject(ctx, input, func(opts *awsS3.PresignOptions) {
 		opts.Expires = expires
 	})
+	tracing.EndSpan(span, err)
 
 	if err != nil {
 		a.logger.Error("failed to generate presigned download URL",
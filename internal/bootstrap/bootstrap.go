@@ -46,7 +46,8 @@ func New(cfg *config.Config, opts ...BootstrapOption) *Bootstrapper {
 // MODO MOCK: Si development.use_mock_repositories=true, salta la inicialización de DB
 // y retorna recursos mínimos (solo logger). Perfecto para desarrollo frontend sin Docker.
 //
-// MODO REAL: Delega a shared/bootstrap vía bridgeToSharedBootstrap()
+// MODO REAL: Delega a shared/bootstrap vía bridgeToSharedBootstrap() y luego aplica las
+// migraciones propias de api-mobile (database.postgres.auto_migrate)
 func (b *Bootstrapper) InitializeInfrastructure(ctx context.Context) (*Resources, func() error, error) {
 	startTime := time.Now()
 
@@ -61,16 +62,18 @@ func (b *Bootstrapper) InitializeInfrastructure(ctx context.Context) (*Resources
 		return nil, nil, fmt.Errorf("failed to initialize infrastructure via shared/bootstrap: %w", err)
 	}
 
-	// Log de completado
-	duration := time.Since(startTime)
-	resources.Logger.Info("infrastructure initialization completed",
-		"duration", duration.String(),
-	)
-
 	// Crear función de cleanup que usa el lifecycle manager de shared
 	cleanup := func() error {
 		resources.Logger.Info("starting infrastructure cleanup")
 
+		// El publisher con reconexión administra su propia conexión AMQP:
+		// cerrarlo detiene los reintentos antes de liberar el resto de recursos
+		if resources.RabbitMQMonitor != nil && resources.RabbitMQPublisher != nil {
+			if err := resources.RabbitMQPublisher.Close(); err != nil {
+				resources.Logger.Warn("error closing RabbitMQ publisher", "error", err.Error())
+			}
+		}
+
 		// shared/lifecycle.Cleanup() no toma contexto
 		err := lifecycleManager.Cleanup()
 		if err != nil {
@@ -84,6 +87,20 @@ func (b *Bootstrapper) InitializeInfrastructure(ctx context.Context) (*Resources
 		return nil
 	}
 
+	// Tablas propias de api-mobile, después de las de edugo-infrastructure
+	if b.config.Database.Postgres.AutoMigrate && resources.PostgreSQL != nil {
+		if err := applyMobileMigrations(ctx, resources.PostgreSQL, resources.Logger); err != nil {
+			_ = cleanup()
+			return nil, nil, err
+		}
+	}
+
+	// Log de completado
+	duration := time.Since(startTime)
+	resources.Logger.Info("infrastructure initialization completed",
+		"duration", duration.String(),
+	)
+
 	return resources, cleanup, nil
 }
 
@@ -99,6 +99,13 @@ func bridgeToSharedBootstrap(
 	}
 
 	wrapper := newCustomFactoriesWrapper(sharedFactories)
+	if cfg.Tracing.Enabled {
+		wrapper.enableTracing(
+			cfg.Database.Postgres.GetConnectionString(),
+			cfg.Database.Postgres.MaxConnections,
+			cfg.Database.MongoDB.Timeout,
+		)
+	}
 	customFactories := createCustomFactories(wrapper)
 
 	// 4. Crear lifecycle manager de shared (sin logger por ahora, lo configuraremos después)
@@ -167,18 +174,16 @@ func adaptSharedResources(
 		mongoDatabase = wrapper.mongoClient.Database(cfg.Database.MongoDB.Database)
 	}
 
-	// 4. RabbitMQ: crear adapter con el channel retenido y envolver con circuit breaker
+	// 4. RabbitMQ: crear publisher con reconexión automática y envolver con circuit breaker
 	// Si está deshabilitado, usar noop publisher
 	var rabbitMQPublisher rabbitmq.Publisher
+	var rabbitMQMonitor rabbitmq.ConnectionMonitor
 	if opts.IsResourceDisabled("rabbitmq") {
 		loggerAdapter.Info("RabbitMQ está deshabilitado, usando noop publisher")
 		// rabbitMQPublisher permanece nil, el container usará noop
 	} else if wrapper.rabbitChannel != nil {
-		basePublisher := adapter.NewMessagePublisherAdapter(
-			wrapper.rabbitChannel,
-			cfg.Messaging.RabbitMQ.Exchanges.Materials,
-			loggerAdapter,
-		)
+		var basePublisher rabbitmq.Publisher
+		basePublisher, rabbitMQMonitor = createBasePublisher(wrapper, loggerAdapter, cfg)
 
 		// Envolver con Circuit Breaker si está habilitado
 		cbConfig := cfg.Messaging.RabbitMQ.CircuitBreaker
@@ -240,6 +245,7 @@ func adaptSharedResources(
 		PostgreSQL:        wrapper.sqlDB,
 		MongoDB:           mongoDatabase,
 		RabbitMQPublisher: rabbitMQPublisher,
+		RabbitMQMonitor:   rabbitMQMonitor,
 		S3Client:          s3Storage,
 		JWTSecret:         cfg.Auth.JWT.Secret,
 		AuthConfig:        cfg.Auth,
@@ -248,3 +254,50 @@ func adaptSharedResources(
 
 	return resources, nil
 }
+
+// createBasePublisher crea el publisher base de RabbitMQ.
+// Prefiere un RabbitMQPublisher que se recupera si el broker se reinicia. Publica sobre la conexión
+// que abrió shared/bootstrap (no abre una segunda) y solo abre una propia al reconectar;
+// si no logra conectarse, usa el channel retenido de shared/bootstrap (sin reconexión).
+func createBasePublisher(
+	wrapper *customFactoriesWrapper,
+	loggerAdapter sharedLogger.Logger,
+	cfg *config.Config,
+) (rabbitmq.Publisher, rabbitmq.ConnectionMonitor) {
+	reconnectConfig := rabbitmq.DefaultReconnectConfig()
+	rcConfig := cfg.Messaging.RabbitMQ.Reconnect
+	if rcConfig.InitialInterval > 0 {
+		reconnectConfig.InitialInterval = rcConfig.InitialInterval
+	}
+	if rcConfig.MaxInterval > 0 {
+		reconnectConfig.MaxInterval = rcConfig.MaxInterval
+	}
+	if rcConfig.PublishTimeout > 0 {
+		reconnectConfig.PublishTimeout = rcConfig.PublishTimeout
+	}
+
+	publisher, err := rabbitmq.NewRabbitMQPublisherOnConnection(
+		wrapper.rabbitConn,
+		cfg.Messaging.RabbitMQ.URL,
+		cfg.Messaging.RabbitMQ.Exchanges.Materials,
+		reconnectConfig,
+		loggerAdapter,
+	)
+	if err != nil {
+		loggerAdapter.Warn("no se pudo crear publisher con reconexión, usando channel de shared/bootstrap",
+			"error", err,
+		)
+		return adapter.NewMessagePublisherAdapter(
+			wrapper.rabbitChannel,
+			cfg.Messaging.RabbitMQ.Exchanges.Materials,
+			loggerAdapter,
+		), nil
+	}
+
+	loggerAdapter.Info("RabbitMQ publisher con reconexión automática inicializado",
+		"initial_interval", reconnectConfig.InitialInterval.String(),
+		"max_interval", reconnectConfig.MaxInterval.String(),
+		"publish_timeout", reconnectConfig.PublishTimeout.String(),
+	)
+	return publisher, publisher
+}
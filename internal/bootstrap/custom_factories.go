@@ -3,12 +3,17 @@ package bootstrap
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/tracing"
 	"github.com/EduGoGroup/edugo-shared/bootstrap"
 	sharedLogger "github.com/EduGoGroup/edugo-shared/logger"
 	awsS3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.mongodb.org/mongo-driver/v2/event"
 	mongov2 "go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"gorm.io/gorm"
 )
 
@@ -21,9 +26,16 @@ type customFactoriesWrapper struct {
 	// Referencias a tipos concretos que necesitamos retener
 	sqlDB         *sql.DB
 	mongoClient   *mongov2.Client
+	rabbitConn    *amqp.Connection
 	rabbitChannel *amqp.Channel
 	s3Client      *awsS3.Client
 	sharedLogger  sharedLogger.Logger
+
+	// Instrumentación OpenTelemetry (vacía si el tracing está deshabilitado)
+	postgresTracedDSN string
+	postgresMaxConns  int
+	mongoMonitor      *event.CommandMonitor
+	mongoTimeout      time.Duration
 }
 
 // newCustomFactoriesWrapper crea un wrapper de factories que retiene los tipos concretos
@@ -33,10 +45,21 @@ func newCustomFactoriesWrapper(sharedFactories *bootstrap.Factories) *customFact
 	}
 }
 
+// enableTracing activa la instrumentación de PostgreSQL y MongoDB en las factories
+func (w *customFactoriesWrapper) enableTracing(postgresDSN string, maxConnections int, mongoTimeout time.Duration) {
+	w.postgresTracedDSN = postgresDSN
+	w.postgresMaxConns = maxConnections
+	w.mongoMonitor = tracing.NewMongoMonitor()
+	w.mongoTimeout = mongoTimeout
+}
+
 // PostgreSQLFactory wrapper - usa CreateRawConnection para obtener *sql.DB
+// Con tracing habilitado, los repositorios reciben un *sql.DB instrumentado con otelsql
 type customPostgreSQLFactory struct {
-	shared bootstrap.PostgreSQLFactory
-	sqlDB  **sql.DB // puntero al puntero para poder guardar la referencia
+	shared    bootstrap.PostgreSQLFactory
+	sqlDB     **sql.DB // puntero al puntero para poder guardar la referencia
+	tracedDSN string   // DSN para la conexión instrumentada ("" = sin tracing)
+	maxConns  int
 }
 
 func (f *customPostgreSQLFactory) CreateConnection(ctx context.Context, config bootstrap.PostgreSQLConfig) (*gorm.DB, error) {
@@ -52,6 +75,27 @@ func (f *customPostgreSQLFactory) CreateConnection(ctx context.Context, config b
 	}
 	*f.sqlDB = sqlDB
 
+	if f.tracedDSN != "" {
+		tracedDB, err := tracing.OpenPostgres(f.tracedDSN, f.maxConns)
+		if err != nil {
+			return nil, err
+		}
+		if err := tracedDB.PingContext(ctx); err != nil {
+			_ = tracedDB.Close()
+			return nil, fmt.Errorf("failed to ping instrumented PostgreSQL connection: %w", err)
+		}
+
+		// El pool instrumentado reemplaza al de gorm: shared/lifecycle hace ping y cierra el
+		// mismo pool que usan los repositorios y no quedan dos pools abiertos contra PostgreSQL
+		gormDB.ConnPool = tracedDB
+		gormDB.Statement.ConnPool = tracedDB
+		if err := sqlDB.Close(); err != nil {
+			_ = tracedDB.Close()
+			return nil, fmt.Errorf("failed to close uninstrumented PostgreSQL pool: %w", err)
+		}
+		*f.sqlDB = tracedDB
+	}
+
 	return gormDB, nil
 }
 
@@ -75,13 +119,22 @@ func (f *customPostgreSQLFactory) Close(db *gorm.DB) error {
 }
 
 // MongoDBFactory wrapper - retiene el client (usa mongo driver v2)
+// Con tracing habilitado, crea el client con un CommandMonitor que genera spans por comando
 type customMongoDBFactory struct {
 	shared      bootstrap.MongoDBFactory
 	mongoClient **mongov2.Client // puntero al puntero para poder guardar la referencia
+	monitor     *event.CommandMonitor
+	timeout     time.Duration // database.mongodb.timeout
 }
 
 func (f *customMongoDBFactory) CreateConnection(ctx context.Context, config bootstrap.MongoDBConfig) (*mongov2.Client, error) {
-	client, err := f.shared.CreateConnection(ctx, config)
+	var client *mongov2.Client
+	var err error
+	if f.monitor != nil {
+		client, err = f.connectWithMonitor(ctx, config)
+	} else {
+		client, err = f.shared.CreateConnection(ctx, config)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +144,30 @@ func (f *customMongoDBFactory) CreateConnection(ctx context.Context, config boot
 	return client, nil
 }
 
+// connectWithMonitor crea el client instrumentado
+// La factory de shared no admite un CommandMonitor: se conecta con las mismas opciones
+// (URI con pool y credenciales, timeout configurado) y se valida con el Ping de shared
+func (f *customMongoDBFactory) connectWithMonitor(ctx context.Context, config bootstrap.MongoDBConfig) (*mongov2.Client, error) {
+	client, err := mongov2.Connect(mongoClientOptions(config.URI, f.timeout).SetMonitor(f.monitor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect instrumented MongoDB client: %w", err)
+	}
+	if err := f.shared.Ping(ctx, client); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, fmt.Errorf("failed to ping instrumented MongoDB client: %w", err)
+	}
+	return client, nil
+}
+
+// mongoClientOptions opciones del client de MongoDB: el pool y las credenciales vienen en la URI
+func mongoClientOptions(uri string, timeout time.Duration) *options.ClientOptions {
+	opts := options.Client().ApplyURI(uri)
+	if timeout > 0 {
+		opts.SetConnectTimeout(timeout).SetServerSelectionTimeout(timeout)
+	}
+	return opts
+}
+
 func (f *customMongoDBFactory) GetDatabase(client *mongov2.Client, dbName string) *mongov2.Database {
 	return f.shared.GetDatabase(client, dbName)
 }
@@ -106,11 +183,19 @@ func (f *customMongoDBFactory) Close(ctx context.Context, client *mongov2.Client
 // RabbitMQFactory wrapper - retiene el channel
 type customRabbitMQFactory struct {
 	shared  bootstrap.RabbitMQFactory
-	channel **amqp.Channel // puntero al puntero para poder guardar la referencia
+	conn    **amqp.Connection // conexión que reutiliza el publisher con reconexión
+	channel **amqp.Channel    // puntero al puntero para poder guardar la referencia
 }
 
 func (f *customRabbitMQFactory) CreateConnection(ctx context.Context, config bootstrap.RabbitMQConfig) (*amqp.Connection, error) {
-	return f.shared.CreateConnection(ctx, config)
+	conn, err := f.shared.CreateConnection(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	*f.conn = conn
+
+	return conn, nil
 }
 
 func (f *customRabbitMQFactory) CreateChannel(conn *amqp.Connection) (*amqp.Channel, error) {
@@ -182,15 +267,20 @@ func createCustomFactories(wrapper *customFactoriesWrapper) *bootstrap.Factories
 			logger: &wrapper.sharedLogger,
 		},
 		PostgreSQL: &customPostgreSQLFactory{
-			shared: wrapper.sharedFactories.PostgreSQL,
-			sqlDB:  &wrapper.sqlDB,
+			shared:    wrapper.sharedFactories.PostgreSQL,
+			sqlDB:     &wrapper.sqlDB,
+			tracedDSN: wrapper.postgresTracedDSN,
+			maxConns:  wrapper.postgresMaxConns,
 		},
 		MongoDB: &customMongoDBFactory{
 			shared:      wrapper.sharedFactories.MongoDB,
 			mongoClient: &wrapper.mongoClient,
+			monitor:     wrapper.mongoMonitor,
+			timeout:     wrapper.mongoTimeout,
 		},
 		RabbitMQ: &customRabbitMQFactory{
 			shared:  wrapper.sharedFactories.RabbitMQ,
+			conn:    &wrapper.rabbitConn,
 			channel: &wrapper.rabbitChannel,
 		},
 		S3: &customS3Factory{
@@ -50,6 +50,7 @@ type Resources struct {
 	PostgreSQL        *sql.DB
 	MongoDB           *mongov2.Database
 	RabbitMQPublisher rabbitmq.Publisher
+	RabbitMQMonitor   rabbitmq.ConnectionMonitor // Estado real de la conexión AMQP (nil si no hay conexión propia)
 	S3Client          S3Storage
 	JWTSecret         string
 	AuthConfig        config.AuthConfig // Configuración de autenticación (api-admin)
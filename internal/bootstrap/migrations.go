@@ -0,0 +1,33 @@
+package bootstrap
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/postgres/migrations"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// migrationsTimeout limita la espera del advisory lock y la aplicación de los scripts al arrancar
+const migrationsTimeout = 5 * time.Minute
+
+// applyMobileMigrations crea las tablas propias de api-mobile
+// Las migraciones de edugo-infrastructure las aplica el despliegue antes de arrancar el servicio
+func applyMobileMigrations(ctx context.Context, db *sql.DB, log logger.Logger) error {
+	ctx, cancel := context.WithTimeout(ctx, migrationsTimeout)
+	defer cancel()
+
+	applied, err := migrations.ApplyAll(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to apply api-mobile migrations: %w", err)
+	}
+
+	if len(applied) > 0 {
+		log.Info("api-mobile migrations applied", "scripts", applied)
+	} else {
+		log.Debug("api-mobile migrations up to date")
+	}
+	return nil
+}
@@ -1,6 +1,6 @@
 // Package client proporciona clientes HTTP para comunicación con otros servicios.
-// AuthClient valida tokens JWT de forma local usando el mismo secret que api-admin,
-// con fallback opcional a validación remota.
+// AuthClient valida tokens JWT de forma local usando el mismo secret que api-admin
+// o sus claves públicas (JWKS), con fallback opcional a validación remota.
 package client
 
 import (
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/sony/gobreaker"
 )
 
@@ -25,6 +26,8 @@ type TokenInfo struct {
 	UserID        string            `json:"user_id,omitempty"`
 	Email         string            `json:"email,omitempty"`
 	ExpiresAt     time.Time         `json:"expires_at,omitempty"`
+	IssuedAt      time.Time         `json:"issued_at,omitempty"`
+	TokenID       string            `json:"token_id,omitempty"` // Claim jti, para revocar el token
 	Error         string            `json:"error,omitempty"`
 	ActiveContext *auth.UserContext `json:"active_context,omitempty"`
 }
@@ -35,6 +38,13 @@ type AuthClientConfig struct {
 	JWTSecret string // Secret para validación JWT local (MISMO que api-admin)
 	JWTIssuer string // Issuer esperado (debe ser "edugo-central" para compatibilidad con api-admin)
 
+	// Claves públicas de api-admin para tokens RS*/ES* (JWKS). Los tokens HS* siguen
+	// validándose con JWTSecret; con ambos configurados se aceptan los dos durante la migración
+	JWKSURL                string        // URL del JWKS de api-admin (ej: http://localhost:8082/.well-known/jwks.json)
+	JWKSFile               string        // Alternativa a JWKSURL: JWKS en un archivo local
+	JWKSRefreshInterval    time.Duration // Recarga periódica del JWKS (default: 10m)
+	JWKSMinRefreshInterval time.Duration // Mínimo entre recargas por kid desconocido (default: 30s)
+
 	// Configuración para validación REMOTA (fallback opcional)
 	BaseURL         string        // URL base de api-admin (ej: http://localhost:8082)
 	Timeout         time.Duration // Timeout para requests HTTP (default: 5s)
@@ -55,19 +65,21 @@ type CircuitBreakerConfig struct {
 }
 
 // AuthClient cliente para validar tokens JWT
-// Prioriza validación LOCAL usando el mismo JWT secret que api-admin
+// Prioriza validación LOCAL usando el mismo JWT secret que api-admin (HS*) o el JWKS (RS*/ES*)
 // Opcionalmente puede usar validación REMOTA como fallback
 type AuthClient struct {
-	jwtManager     *auth.JWTManager // Para validación local
+	jwtManager     *auth.JWTManager // Para validación local (HS*)
+	jwks           *jwksKeySet      // Para validación local (RS*/ES*), nil si no hay JWKS
 	baseURL        string
 	httpClient     *http.Client
 	cache          *tokenCache
+	revocations    *revocationList // Tokens revocados por api-admin (ver RevokeTokens)
 	circuitBreaker *gobreaker.CircuitBreaker
 	config         AuthClientConfig
 }
 
 // NewAuthClient crea una nueva instancia del cliente de autenticación
-// Requiere JWTSecret y JWTIssuer para validación local
+// Requiere JWTSecret y/o JWKSURL (o JWKSFile) y JWTIssuer para validación local
 func NewAuthClient(config AuthClientConfig) *AuthClient {
 	// Valores por defecto
 	if config.Timeout == 0 {
@@ -79,6 +91,12 @@ func NewAuthClient(config AuthClientConfig) *AuthClient {
 	if config.JWTIssuer == "" {
 		config.JWTIssuer = "edugo-central" // Issuer por defecto compatible con api-admin
 	}
+	if config.JWKSRefreshInterval == 0 {
+		config.JWKSRefreshInterval = defaultJWKSRefreshInterval
+	}
+	if config.JWKSMinRefreshInterval == 0 {
+		config.JWKSMinRefreshInterval = defaultJWKSMinRefreshInterval
+	}
 
 	// Crear JWTManager para validación local
 	var jwtManager *auth.JWTManager
@@ -116,13 +134,17 @@ func NewAuthClient(config AuthClientConfig) *AuthClient {
 		cb = gobreaker.NewCircuitBreaker(cbSettings)
 	}
 
+	httpClient := &http.Client{
+		Timeout: config.Timeout,
+	}
+
 	return &AuthClient{
-		jwtManager: jwtManager,
-		baseURL:    config.BaseURL,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
+		jwtManager:     jwtManager,
+		jwks:           newJWKSKeySet(config, httpClient),
+		baseURL:        config.BaseURL,
+		httpClient:     httpClient,
 		cache:          newTokenCache(config.CacheTTL),
+		revocations:    newRevocationList(),
 		circuitBreaker: cb,
 		config:         config,
 	}
@@ -131,7 +153,7 @@ func NewAuthClient(config AuthClientConfig) *AuthClient {
 // ValidateToken valida un token JWT
 // Estrategia:
 // 1. Si hay cache habilitado, verificar cache primero
-// 2. Validar localmente usando JWTManager (preferido)
+// 2. Validar localmente usando JWTManager o el JWKS según el algoritmo (preferido)
 // 3. Si falla y FallbackEnabled, intentar validación remota
 func (c *AuthClient) ValidateToken(ctx context.Context, token string) (*TokenInfo, error) {
 	// 1. Verificar cache primero
@@ -143,11 +165,11 @@ func (c *AuthClient) ValidateToken(ctx context.Context, token string) (*TokenInf
 	}
 
 	// 2. Validar localmente (método preferido)
-	if c.jwtManager != nil {
-		info, err := c.validateTokenLocally(token)
+	if c.jwtManager != nil || c.jwks != nil {
+		info, err := c.validateTokenLocally(ctx, token)
 		if err == nil && info.Valid {
-			// Token válido localmente
-			if c.config.CacheEnabled {
+			// Token válido localmente (no se cachea si está revocado)
+			if c.config.CacheEnabled && !c.revocations.isRevoked(info) {
 				c.cache.Set(cacheKey, info)
 			}
 			return info, nil
@@ -179,7 +201,7 @@ func (c *AuthClient) ValidateToken(ctx context.Context, token string) (*TokenInf
 		}
 
 		info := result.(*TokenInfo)
-		if c.config.CacheEnabled && info.Valid {
+		if c.config.CacheEnabled && info.Valid && !c.revocations.isRevoked(info) {
 			c.cache.Set(cacheKey, info)
 		}
 		return info, nil
@@ -188,12 +210,15 @@ func (c *AuthClient) ValidateToken(ctx context.Context, token string) (*TokenInf
 	// No hay forma de validar el token
 	return &TokenInfo{
 		Valid: false,
-		Error: "no hay método de validación disponible (JWTSecret no configurado y RemoteEnabled=false)",
+		Error: "no hay método de validación disponible (JWTSecret/JWKS no configurado y RemoteEnabled=false)",
 	}, nil
 }
 
-// validateTokenLocally valida el token usando el JWTManager local
-func (c *AuthClient) validateTokenLocally(token string) (*TokenInfo, error) {
+// validateTokenLocally valida el token usando el JWKS (RS*/ES*) o el JWTManager local (HS*)
+func (c *AuthClient) validateTokenLocally(ctx context.Context, token string) (*TokenInfo, error) {
+	if c.jwks != nil && isAsymmetricToken(token) {
+		return c.validateTokenWithJWKS(ctx, token)
+	}
 	if c.jwtManager == nil {
 		return nil, fmt.Errorf("JWTManager no configurado")
 	}
@@ -206,19 +231,60 @@ func (c *AuthClient) validateTokenLocally(token string) (*TokenInfo, error) {
 		}, err
 	}
 
-	// Extraer ExpiresAt de los claims
-	var expiresAt time.Time
+	return tokenInfoFromClaims(claims), nil
+}
+
+// validateTokenWithJWKS verifica la firma con la clave del kid del token
+func (c *AuthClient) validateTokenWithJWKS(ctx context.Context, token string) (*TokenInfo, error) {
+	claims := &auth.Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, c.jwks.keyfunc(ctx),
+		jwt.WithIssuer(c.config.JWTIssuer),
+		jwt.WithValidMethods(jwksSigningMethods),
+	)
+	if err != nil {
+		return &TokenInfo{
+			Valid: false,
+			Error: err.Error(),
+		}, err
+	}
+
+	return tokenInfoFromClaims(claims), nil
+}
+
+// isAsymmetricToken indica si el header del token declara un algoritmo RS*/ES*
+func isAsymmetricToken(token string) bool {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return false
+	}
+	switch parsed.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		return true
+	default:
+		return false
+	}
+}
+
+// tokenInfoFromClaims construye el TokenInfo de un token válido
+func tokenInfoFromClaims(claims *auth.Claims) *TokenInfo {
+	// Extraer ExpiresAt e IssuedAt de los claims
+	var expiresAt, issuedAt time.Time
 	if claims.ExpiresAt != nil {
 		expiresAt = claims.ExpiresAt.Time
 	}
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
 
 	return &TokenInfo{
 		Valid:         true,
 		UserID:        claims.UserID,
 		Email:         claims.Email,
 		ExpiresAt:     expiresAt,
+		IssuedAt:      issuedAt,
+		TokenID:       claims.ID,
 		ActiveContext: claims.ActiveContext,
-	}, nil
+	}
 }
 
 // doValidateTokenRemote realiza la llamada HTTP a api-admin /v1/auth/verify
@@ -257,10 +323,36 @@ func (c *AuthClient) doValidateTokenRemote(ctx context.Context, token string) (*
 	if err := json.Unmarshal(body, &info); err != nil {
 		return nil, fmt.Errorf("error parsing response: %w", err)
 	}
+	if info.Valid {
+		fillClaimsUnverified(&info, token)
+	}
 
 	return &info, nil
 }
 
+// fillClaimsUnverified completa iat y jti que la respuesta remota no informa, leyendo los claims
+// sin verificar (api-admin ya validó la firma). Sin iat, una revocación por usuario
+// consideraría revocados todos sus tokens
+func fillClaimsUnverified(info *TokenInfo, token string) {
+	if !info.IssuedAt.IsZero() && info.TokenID != "" {
+		return
+	}
+	claims := &auth.Claims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return
+	}
+	// Solo se completan si el token corresponde al usuario validado
+	if info.UserID != "" && claims.UserID != info.UserID {
+		return
+	}
+	if info.IssuedAt.IsZero() && claims.IssuedAt != nil {
+		info.IssuedAt = claims.IssuedAt.Time
+	}
+	if info.TokenID == "" {
+		info.TokenID = claims.ID
+	}
+}
+
 // hashToken genera un hash SHA256 del token para usar como cache key
 func (c *AuthClient) hashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
@@ -319,6 +411,21 @@ func (c *tokenCache) Set(key string, info *TokenInfo) {
 	}
 }
 
+// evict descarta las validaciones que cumplen match; retorna cuántas se descartaron
+func (c *tokenCache) evict(match func(*TokenInfo) bool) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	evicted := 0
+	for key, entry := range c.entries {
+		if match(entry.info) {
+			delete(c.entries, key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
 func (c *tokenCache) cleanupLoop() {
 	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
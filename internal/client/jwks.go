@@ -0,0 +1,322 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// defaultJWKSRefreshInterval cada cuánto se recarga el JWKS aunque no aparezcan kid nuevos
+	defaultJWKSRefreshInterval = 10 * time.Minute
+	// defaultJWKSMinRefreshInterval tiempo mínimo entre recargas: un kid desconocido no fuerza
+	// una recarga por request (tokens forjados con kid aleatorio no deben saturar api-admin)
+	defaultJWKSMinRefreshInterval = 30 * time.Second
+	// maxJWKSSize tamaño máximo aceptado para el documento JWKS
+	maxJWKSSize = 1 << 20
+)
+
+// jwksSigningMethods algoritmos asimétricos aceptados con claves del JWKS
+var jwksSigningMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+// jwksKey clave pública del JWKS; alg vacío = cualquier algoritmo compatible con el tipo de clave
+type jwksKey struct {
+	alg string
+	key any // *rsa.PublicKey o *ecdsa.PublicKey
+}
+
+// jwksKeySet claves públicas de api-admin indexadas por kid
+// Durante una rotación api-admin publica la clave nueva y la anterior: ambas se aceptan
+// hasta que la anterior desaparece del documento
+type jwksKeySet struct {
+	load               func(ctx context.Context) ([]byte, error)
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	now                func() time.Time
+
+	mu          sync.RWMutex
+	keys        map[string][]jwksKey
+	loadedAt    time.Time // último intento de carga (exitoso o no)
+	lastLoadErr error
+
+	// refreshMu serializa las recargas: requests concurrentes con un kid nuevo esperan
+	// a la misma recarga en lugar de pedir el documento cada una
+	refreshMu sync.Mutex
+}
+
+// newJWKSKeySet crea el conjunto de claves a partir de la URL o el archivo configurados
+// Retorna nil si no hay JWKS configurado (solo HMAC)
+func newJWKSKeySet(config AuthClientConfig, httpClient *http.Client) *jwksKeySet {
+	var load func(ctx context.Context) ([]byte, error)
+	switch {
+	case config.JWKSURL != "":
+		url := config.JWKSURL
+		load = func(ctx context.Context) ([]byte, error) {
+			return fetchJWKS(ctx, httpClient, url)
+		}
+	case config.JWKSFile != "":
+		path := config.JWKSFile
+		load = func(context.Context) ([]byte, error) {
+			return os.ReadFile(path)
+		}
+	default:
+		return nil
+	}
+
+	return &jwksKeySet{
+		load:               load,
+		refreshInterval:    config.JWKSRefreshInterval,
+		minRefreshInterval: config.JWKSMinRefreshInterval,
+		now:                time.Now,
+	}
+}
+
+// fetchJWKS descarga el documento JWKS de api-admin
+func fetchJWKS(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching JWKS: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching JWKS: status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+}
+
+// keyfunc resuelve la clave de verificación por el kid del header del token
+func (s *jwksKeySet) keyfunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		keys, err := s.lookup(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+
+		var compatible []jwt.VerificationKey
+		for _, k := range keys {
+			if k.compatible(token.Method) {
+				compatible = append(compatible, k.key)
+			}
+		}
+		switch len(compatible) {
+		case 0:
+			return nil, fmt.Errorf("ninguna clave del JWKS admite %s (kid %q)", token.Method.Alg(), kid)
+		case 1:
+			return compatible[0], nil
+		default:
+			return jwt.VerificationKeySet{Keys: compatible}, nil
+		}
+	}
+}
+
+// lookup retorna las claves del kid (todas si el token no trae kid)
+// Recarga el documento si venció el intervalo o si el kid no se conoce
+func (s *jwksKeySet) lookup(ctx context.Context, kid string) ([]jwksKey, error) {
+	if s.stale() {
+		_ = s.refresh(ctx)
+	}
+	if keys, ok := s.find(kid); ok {
+		return keys, nil
+	}
+
+	// kid desconocido: puede ser una clave recién rotada en api-admin
+	if err := s.refresh(ctx); err != nil {
+		return nil, fmt.Errorf("kid %q desconocido y no se pudo recargar el JWKS: %w", kid, err)
+	}
+	if keys, ok := s.find(kid); ok {
+		return keys, nil
+	}
+	return nil, fmt.Errorf("kid %q desconocido", kid)
+}
+
+func (s *jwksKeySet) find(kid string) ([]jwksKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid != "" {
+		keys, ok := s.keys[kid]
+		return keys, ok
+	}
+	var all []jwksKey
+	for _, keys := range s.keys {
+		all = append(all, keys...)
+	}
+	return all, len(all) > 0
+}
+
+func (s *jwksKeySet) stale() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.loadedAt.IsZero() || s.now().Sub(s.loadedAt) >= s.refreshInterval
+}
+
+// refresh recarga el documento respetando minRefreshInterval desde el último intento
+// Ante un error se conservan las claves anteriores
+func (s *jwksKeySet) refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	s.mu.RLock()
+	loadedAt, lastErr := s.loadedAt, s.lastLoadErr
+	s.mu.RUnlock()
+	if !loadedAt.IsZero() && s.now().Sub(loadedAt) < s.minRefreshInterval {
+		return lastErr
+	}
+
+	keys, err := s.fetch(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadedAt = s.now()
+	s.lastLoadErr = err
+	if err == nil {
+		s.keys = keys
+	}
+	return err
+}
+
+func (s *jwksKeySet) fetch(ctx context.Context) (map[string][]jwksKey, error) {
+	data, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return parseJWKS(data)
+}
+
+// jsonWebKey clave en formato JWK (RFC 7517); solo se leen los campos de claves RSA y EC
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS interpreta el documento JWKS. Las claves que no son de firma o cuyo tipo no se
+// soporta se ignoran; el documento es inválido solo si no queda ninguna clave utilizable
+func parseJWKS(data []byte) (map[string][]jwksKey, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("JWKS inválido: %w", err)
+	}
+
+	keys := make(map[string][]jwksKey, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = append(keys[jwk.Kid], jwksKey{alg: jwk.Alg, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS sin claves de firma RSA o EC válidas")
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeJWKInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeJWKInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponente RSA inválido")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("curva no soportada: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, fmt.Errorf("coordenadas EC de tamaño inválido")
+		}
+		// Formato sin comprimir (0x04 || X || Y): valida que el punto esté en la curva
+		return ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+
+	default:
+		return nil, fmt.Errorf("tipo de clave no soportado: %s", k.Kty)
+	}
+}
+
+func decodeJWKInt(value string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("entero JWK vacío")
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
+
+// compatible indica si la clave puede verificar el algoritmo del token
+func (k jwksKey) compatible(method jwt.SigningMethod) bool {
+	if k.alg != "" && k.alg != method.Alg() {
+		return false
+	}
+	switch key := k.key.(type) {
+	case *rsa.PublicKey:
+		_, ok := method.(*jwt.SigningMethodRSA)
+		return ok
+	case *ecdsa.PublicKey:
+		m, ok := method.(*jwt.SigningMethodECDSA)
+		return ok && key.Curve.Params().BitSize == m.CurveBits
+	default:
+		return false
+	}
+}
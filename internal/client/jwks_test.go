@@ -0,0 +1,326 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-shared/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testSigningKey clave privada de prueba con su kid, como las que publica api-admin
+type testSigningKey struct {
+	kid    string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+func newRSASigningKey(t *testing.T, kid string) testSigningKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generando clave RSA: %v", err)
+	}
+	return testSigningKey{kid: kid, method: jwt.SigningMethodRS256, key: key}
+}
+
+func newECSigningKey(t *testing.T, kid string) testSigningKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generando clave EC: %v", err)
+	}
+	return testSigningKey{kid: kid, method: jwt.SigningMethodES256, key: key}
+}
+
+// sign firma un token con los claims de api-admin
+func (k testSigningKey) sign(t *testing.T, userID, issuer string, expiresIn time.Duration) string {
+	t.Helper()
+	now := time.Now()
+	claims := &auth.Claims{
+		UserID: userID,
+		Email:  userID + "@test.com",
+		ActiveContext: &auth.UserContext{
+			RoleID:      "role-teacher",
+			RoleName:    "teacher",
+			Permissions: []string{"read"},
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiresIn)),
+		},
+	}
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.kid != "" {
+		token.Header["kid"] = k.kid
+	}
+	signed, err := token.SignedString(k.key)
+	if err != nil {
+		t.Fatalf("Error firmando token: %v", err)
+	}
+	return signed
+}
+
+// jwk representación pública de la clave en formato JWK
+func (k testSigningKey) jwk() map[string]string {
+	b64 := base64.RawURLEncoding.EncodeToString
+	switch pub := k.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA", "kid": k.kid, "use": "sig", "alg": k.method.Alg(),
+			"n": b64(pub.N.Bytes()), "e": b64(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		raw, _ := pub.Bytes() // 0x04 || X || Y
+		size := (len(raw) - 1) / 2
+		return map[string]string{
+			"kty": "EC", "kid": k.kid, "use": "sig", "alg": k.method.Alg(), "crv": "P-256",
+			"x": b64(raw[1 : 1+size]), "y": b64(raw[1+size:]),
+		}
+	}
+	return nil
+}
+
+func jwksDocument(keys ...testSigningKey) []byte {
+	doc := struct {
+		Keys []map[string]string `json:"keys"`
+	}{}
+	for _, k := range keys {
+		doc.Keys = append(doc.Keys, k.jwk())
+	}
+	data, _ := json.Marshal(doc)
+	return data
+}
+
+// jwksStub servidor local que publica el JWKS como api-admin
+type jwksStub struct {
+	*httptest.Server
+	mu       sync.Mutex
+	document []byte
+	status   int
+	fetches  atomic.Int32
+}
+
+func newJWKSStub(t *testing.T, keys ...testSigningKey) *jwksStub {
+	t.Helper()
+	stub := &jwksStub{document: jwksDocument(keys...), status: http.StatusOK}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stub.fetches.Add(1)
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(stub.status)
+		_, _ = w.Write(stub.document)
+	}))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+// publish reemplaza las claves publicadas (rotación en api-admin)
+func (s *jwksStub) publish(keys ...testSigningKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.document = jwksDocument(keys...)
+	s.status = http.StatusOK
+}
+
+func (s *jwksStub) fail(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+// newJWKSTestClient crea un cliente con reloj controlable para las recargas del JWKS
+func newJWKSTestClient(config AuthClientConfig) (*AuthClient, *time.Time) {
+	config.JWTIssuer = testJWTIssuer
+	client := NewAuthClient(config)
+	now := time.Now()
+	client.jwks.now = func() time.Time { return now }
+	return client, &now
+}
+
+func validate(t *testing.T, client *AuthClient, token string) *TokenInfo {
+	t.Helper()
+	info, err := client.ValidateToken(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	return info
+}
+
+func TestAuthClient_ValidateToken_JWKS(t *testing.T) {
+	rsaKey := newRSASigningKey(t, "rsa-1")
+	ecKey := newECSigningKey(t, "ec-1")
+	otherKey := newRSASigningKey(t, "rsa-1") // mismo kid, clave no publicada
+	noKid := newECSigningKey(t, "")
+	stub := newJWKSStub(t, rsaKey, ecKey)
+
+	testCases := []struct {
+		name      string
+		token     string
+		wantValid bool
+	}{
+		{"RS256", rsaKey.sign(t, "user-rsa", testJWTIssuer, 15*time.Minute), true},
+		{"ES256", ecKey.sign(t, "user-ec", testJWTIssuer, 15*time.Minute), true},
+		{"Firma con clave no publicada", otherKey.sign(t, "user-rsa", testJWTIssuer, 15*time.Minute), false},
+		{"Issuer distinto", rsaKey.sign(t, "user-rsa", "other-issuer", 15*time.Minute), false},
+		{"Token expirado", ecKey.sign(t, "user-ec", testJWTIssuer, -time.Minute), false},
+		{"Sin kid y clave no publicada", noKid.sign(t, "user-ec", testJWTIssuer, 15*time.Minute), false},
+		{"Token HS256 sin JWTSecret", generateTestToken(t, "user-hs", "hs@test.com", "teacher", 15*time.Minute), false},
+	}
+
+	client, _ := newJWKSTestClient(AuthClientConfig{JWKSURL: stub.URL})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			info := validate(t, client, tc.token)
+			if info.Valid != tc.wantValid {
+				t.Errorf("Valid = %v, esperado %v (error: %s)", info.Valid, tc.wantValid, info.Error)
+			}
+			if tc.wantValid && (info.UserID == "" || info.ActiveContext == nil || info.ExpiresAt.IsZero()) {
+				t.Errorf("Claims incompletos: %+v", info)
+			}
+		})
+	}
+
+	if got := stub.fetches.Load(); got != 1 {
+		t.Errorf("El JWKS debería descargarse una vez (kid conocidos en cache), se descargó %d", got)
+	}
+}
+
+func TestAuthClient_ValidateToken_JWKSAndSecret(t *testing.T) {
+	rsaKey := newRSASigningKey(t, "rsa-1")
+	stub := newJWKSStub(t, rsaKey)
+
+	// Durante la migración se aceptan tokens HS256 y RS256
+	client, _ := newJWKSTestClient(AuthClientConfig{JWTSecret: testJWTSecret, JWKSURL: stub.URL})
+
+	if info := validate(t, client, generateTestToken(t, "user-hs", "hs@test.com", "teacher", 15*time.Minute)); !info.Valid {
+		t.Errorf("Token HS256 debería ser válido: %s", info.Error)
+	}
+	if info := validate(t, client, rsaKey.sign(t, "user-rsa", testJWTIssuer, 15*time.Minute)); !info.Valid {
+		t.Errorf("Token RS256 debería ser válido: %s", info.Error)
+	}
+}
+
+func TestAuthClient_ValidateToken_JWKSRotation(t *testing.T) {
+	oldKey := newRSASigningKey(t, "2026-01")
+	newKey := newECSigningKey(t, "2026-02")
+	stub := newJWKSStub(t, oldKey)
+	client, now := newJWKSTestClient(AuthClientConfig{JWKSURL: stub.URL})
+
+	oldToken := oldKey.sign(t, "user-1", testJWTIssuer, time.Hour)
+	newToken := newKey.sign(t, "user-2", testJWTIssuer, time.Hour)
+
+	if info := validate(t, client, oldToken); !info.Valid {
+		t.Fatalf("Token con la clave actual debería ser válido: %s", info.Error)
+	}
+
+	// api-admin publica la clave nueva junto a la anterior
+	stub.publish(oldKey, newKey)
+
+	// Un kid desconocido dentro del intervalo mínimo no recarga
+	if info := validate(t, client, newToken); info.Valid {
+		t.Error("No debería recargar el JWKS antes del intervalo mínimo")
+	}
+
+	*now = now.Add(defaultJWKSMinRefreshInterval)
+	if info := validate(t, client, newToken); !info.Valid {
+		t.Fatalf("Kid nuevo debería recargar el JWKS: %s", info.Error)
+	}
+	if info := validate(t, client, oldToken); !info.Valid {
+		t.Errorf("La clave anterior sigue publicada y debería aceptarse: %s", info.Error)
+	}
+
+	// La clave anterior se retira: deja de aceptarse en la recarga periódica
+	stub.publish(newKey)
+	*now = now.Add(defaultJWKSRefreshInterval)
+	if info := validate(t, client, oldToken); info.Valid {
+		t.Error("Token con clave retirada debería ser inválido")
+	}
+	if info := validate(t, client, newToken); !info.Valid {
+		t.Errorf("Token con la clave nueva debería ser válido: %s", info.Error)
+	}
+	if got := stub.fetches.Load(); got != 3 {
+		t.Errorf("Descargas del JWKS: esperado 3, obtenido %d", got)
+	}
+}
+
+func TestAuthClient_ValidateToken_JWKSUnavailableKeepsKeys(t *testing.T) {
+	key := newRSASigningKey(t, "rsa-1")
+	stub := newJWKSStub(t, key)
+	client, now := newJWKSTestClient(AuthClientConfig{JWKSURL: stub.URL})
+	token := key.sign(t, "user-1", testJWTIssuer, time.Hour)
+
+	if info := validate(t, client, token); !info.Valid {
+		t.Fatalf("Token debería ser válido: %s", info.Error)
+	}
+
+	stub.fail(http.StatusServiceUnavailable)
+	*now = now.Add(defaultJWKSRefreshInterval)
+	if info := validate(t, client, token); !info.Valid {
+		t.Errorf("Si api-admin no responde se conservan las claves anteriores: %s", info.Error)
+	}
+}
+
+func TestAuthClient_ValidateToken_JWKSFile(t *testing.T) {
+	key := newECSigningKey(t, "ec-file")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwksDocument(key), 0o600); err != nil {
+		t.Fatalf("Error escribiendo JWKS: %v", err)
+	}
+
+	client, _ := newJWKSTestClient(AuthClientConfig{JWKSFile: path})
+	if info := validate(t, client, key.sign(t, "user-1", testJWTIssuer, time.Hour)); !info.Valid {
+		t.Errorf("Token firmado con la clave del archivo debería ser válido: %s", info.Error)
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey := newRSASigningKey(t, "rsa-1")
+
+	keys, err := parseJWKS([]byte(`{"keys":[
+		{"kty":"oct","kid":"hmac","k":"c2VjcmV0"},
+		{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"},
+		{"kty":"EC","kid":"bad-curve","crv":"P-256","x":"AQAB","y":"AQAB"},
+		` + string(mustJSON(t, rsaKey.jwk())) + `
+	]}`))
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	if len(keys) != 1 || len(keys["rsa-1"]) != 1 {
+		t.Errorf("Solo la clave RSA de firma es utilizable, obtenido: %v", keys)
+	}
+
+	if _, err := parseJWKS([]byte(`{"keys":[{"kty":"oct","kid":"hmac"}]}`)); err == nil {
+		t.Error("Un JWKS sin claves utilizables debería ser inválido")
+	}
+	if _, err := parseJWKS([]byte(`not json`)); err == nil {
+		t.Error("Un JWKS mal formado debería ser inválido")
+	}
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Error serializando: %v", err)
+	}
+	return data
+}
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+const (
+	// defaultRevocationRetention vigencia de una revocación que no indica ExpiresAt
+	// (debe superar la duración de un access token)
+	defaultRevocationRetention = 24 * time.Hour
+
+	// revocationPollTimeout limita cada consulta del RevocationPoller
+	revocationPollTimeout = 10 * time.Second
+	// revocationPollOverlap se vuelve a consultar este margen hacia atrás: filas con revoked_at
+	// anterior pueden confirmarse después de la consulta previa (aplicarlas dos veces es inocuo)
+	revocationPollOverlap = time.Minute
+)
+
+// revocationList revocaciones vigentes de access tokens
+type revocationList struct {
+	mu     sync.RWMutex
+	tokens map[string]time.Time // jti -> vencimiento de la revocación
+	users  map[string]userRevocation
+	now    func() time.Time
+}
+
+type userRevocation struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+func newRevocationList() *revocationList {
+	return &revocationList{
+		tokens: make(map[string]time.Time),
+		users:  make(map[string]userRevocation),
+		now:    time.Now,
+	}
+}
+
+// add registra la revocación; si el usuario ya tenía una se conserva la más amplia
+func (l *revocationList) add(rev repository.TokenRevocation) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	expiresAt := rev.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(defaultRevocationRetention)
+	}
+	if rev.TokenID != "" && expiresAt.After(l.tokens[rev.TokenID]) {
+		l.tokens[rev.TokenID] = expiresAt
+	}
+	if rev.UserID != "" && !rev.IssuedBefore.IsZero() {
+		current := l.users[rev.UserID]
+		if rev.IssuedBefore.After(current.issuedBefore) {
+			current.issuedBefore = rev.IssuedBefore
+		}
+		if expiresAt.After(current.expiresAt) {
+			current.expiresAt = expiresAt
+		}
+		l.users[rev.UserID] = current
+	}
+}
+
+// isRevoked indica si el token validado está revocado por jti o por usuario
+func (l *revocationList) isRevoked(info *TokenInfo) bool {
+	if info == nil {
+		return false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	now := l.now()
+	if info.TokenID != "" {
+		if expiresAt, ok := l.tokens[info.TokenID]; ok && now.Before(expiresAt) {
+			return true
+		}
+	}
+	if rev, ok := l.users[info.UserID]; ok && now.Before(rev.expiresAt) {
+		// iat tiene precisión de segundos: se compara truncando para no revocar un login hecho
+		// en el mismo segundo que "cerrar todas las sesiones". Sin iat se considera revocado
+		return info.IssuedAt.IsZero() || info.IssuedAt.Before(rev.issuedBefore.Truncate(time.Second))
+	}
+	return false
+}
+
+// prune descarta las revocaciones vencidas (los tokens afectados ya expiraron)
+func (l *revocationList) prune(now time.Time) {
+	for jti, expiresAt := range l.tokens {
+		if !now.Before(expiresAt) {
+			delete(l.tokens, jti)
+		}
+	}
+	for userID, rev := range l.users {
+		if !now.Before(rev.expiresAt) {
+			delete(l.users, userID)
+		}
+	}
+}
+
+// RevokeTokens registra una revocación y descarta de inmediato las validaciones cacheadas
+// que afecta. Retorna cuántas entradas del cache se descartaron
+func (c *AuthClient) RevokeTokens(rev repository.TokenRevocation) int {
+	c.revocations.add(rev)
+	return c.cache.evict(c.revocations.isRevoked)
+}
+
+// IsRevoked indica si un token validado fue revocado (logout o "cerrar todas las sesiones")
+func (c *AuthClient) IsRevoked(info *TokenInfo) bool {
+	return c.revocations.isRevoked(info)
+}
+
+// HandleTokenRevoked procesa token.revoked publicado por api-admin
+// El cuerpo puede venir con el sobre de eventos ({"payload": {...}}) o sin él e indica
+// jti (+ expires_at) o user_id + issued_before
+func (c *AuthClient) HandleTokenRevoked(_ context.Context, routingKey string, body []byte) error {
+	type revocation struct {
+		TokenID      string    `json:"jti"`
+		UserID       string    `json:"user_id"`
+		IssuedBefore time.Time `json:"issued_before"`
+		ExpiresAt    time.Time `json:"expires_at"`
+	}
+	var event struct {
+		revocation
+		Payload *revocation `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("auth: invalid event %s: %w", routingKey, err)
+	}
+
+	rev := event.revocation
+	if event.Payload != nil {
+		rev = *event.Payload
+	}
+	if rev.TokenID == "" && (rev.UserID == "" || rev.IssuedBefore.IsZero()) {
+		return fmt.Errorf("auth: event %s without jti or user_id/issued_before", routingKey)
+	}
+
+	c.RevokeTokens(repository.TokenRevocation{
+		TokenID:      rev.TokenID,
+		UserID:       rev.UserID,
+		IssuedBefore: rev.IssuedBefore,
+		ExpiresAt:    rev.ExpiresAt,
+	})
+	return nil
+}
+
+// RevocationPoller sincroniza las revocaciones de la tabla de api-admin con el AuthClient
+// Carga las vigentes al iniciar y luego las nuevas cada Interval
+type RevocationPoller struct {
+	repo     repository.TokenRevocationRepository
+	client   *AuthClient
+	interval time.Duration
+	logger   logger.Logger
+
+	since    time.Time
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRevocationPoller crea el poller; Start inicia la sincronización
+func NewRevocationPoller(repo repository.TokenRevocationRepository, client *AuthClient, interval time.Duration, logger logger.Logger) *RevocationPoller {
+	return &RevocationPoller{
+		repo:     repo,
+		client:   client,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start inicia la sincronización en una goroutine
+func (p *RevocationPoller) Start() {
+	go p.run()
+}
+
+// Close detiene la sincronización y espera a que termine la consulta en curso
+func (p *RevocationPoller) Close() error {
+	p.stopOnce.Do(func() { close(p.stop) })
+	<-p.done
+	return nil
+}
+
+func (p *RevocationPoller) run() {
+	defer close(p.done)
+
+	p.poll()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.poll()
+		}
+	}
+}
+
+// poll aplica las revocaciones registradas desde la última consulta
+func (p *RevocationPoller) poll() {
+	ctx, cancel := context.WithTimeout(context.Background(), revocationPollTimeout)
+	defer cancel()
+
+	since := p.since
+	if !since.IsZero() {
+		since = since.Add(-revocationPollOverlap)
+	}
+	revocations, err := p.repo.GetRevocationsSince(ctx, since)
+	if err != nil {
+		p.logger.Warn("no se pudieron consultar las revocaciones de tokens", "error", err)
+		return
+	}
+
+	evicted := 0
+	for _, rev := range revocations {
+		evicted += p.client.RevokeTokens(*rev)
+		if rev.RevokedAt.After(p.since) {
+			p.since = rev.RevokedAt
+		}
+	}
+	if evicted > 0 {
+		p.logger.Info("validaciones de tokens revocados descartadas del cache", "entries", evicted)
+	}
+}
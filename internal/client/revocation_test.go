@@ -0,0 +1,241 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+func TestRevocationList_IsRevoked(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 500_000_000, time.UTC)
+	list := newRevocationList()
+	list.now = func() time.Time { return now }
+
+	list.add(repository.TokenRevocation{TokenID: "jti-logout", ExpiresAt: now.Add(time.Hour)})
+	list.add(repository.TokenRevocation{UserID: "user-sessions", IssuedBefore: now})
+	list.add(repository.TokenRevocation{TokenID: "jti-expired", ExpiresAt: now.Add(-time.Minute)})
+
+	testCases := []struct {
+		name string
+		info *TokenInfo
+		want bool
+	}{
+		{"Token revocado por jti", &TokenInfo{TokenID: "jti-logout", UserID: "user-1", IssuedAt: now}, true},
+		{"Otro jti del mismo usuario", &TokenInfo{TokenID: "jti-other", UserID: "user-1", IssuedAt: now}, false},
+		{"Revocación vencida", &TokenInfo{TokenID: "jti-expired", UserID: "user-1"}, false},
+		{"Emitido antes de cerrar sesiones", &TokenInfo{UserID: "user-sessions", IssuedAt: now.Add(-time.Minute)}, true},
+		{"Emitido en el mismo segundo (iat truncado)", &TokenInfo{UserID: "user-sessions", IssuedAt: now.Truncate(time.Second)}, false},
+		{"Emitido después de cerrar sesiones", &TokenInfo{UserID: "user-sessions", IssuedAt: now.Add(time.Minute)}, false},
+		{"Sin iat se considera revocado", &TokenInfo{UserID: "user-sessions"}, true},
+		{"Otro usuario", &TokenInfo{UserID: "user-1", IssuedAt: now.Add(-time.Minute)}, false},
+		{"Sin info", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := list.isRevoked(tc.info); got != tc.want {
+				t.Errorf("isRevoked = %v, esperado %v", got, tc.want)
+			}
+		})
+	}
+
+	// La revocación por usuario conserva el IssuedBefore más reciente y vence a las 24h
+	list.add(repository.TokenRevocation{UserID: "user-sessions", IssuedBefore: now.Add(-time.Hour)})
+	if !list.isRevoked(&TokenInfo{UserID: "user-sessions", IssuedAt: now.Add(-time.Minute)}) {
+		t.Error("Una revocación anterior no debería acortar la vigente")
+	}
+	now = now.Add(defaultRevocationRetention)
+	if list.isRevoked(&TokenInfo{UserID: "user-sessions", IssuedAt: now.Add(-48 * time.Hour)}) {
+		t.Error("La revocación sin ExpiresAt debería vencer tras la retención por defecto")
+	}
+	list.add(repository.TokenRevocation{TokenID: "jti-new", ExpiresAt: now.Add(time.Hour)})
+	if len(list.tokens) != 1 || len(list.users) != 0 {
+		t.Errorf("Las revocaciones vencidas deberían descartarse: tokens=%d users=%d", len(list.tokens), len(list.users))
+	}
+}
+
+func TestAuthClient_RevokeTokens_EvictsCache(t *testing.T) {
+	client := NewAuthClient(AuthClientConfig{
+		JWTSecret:    testJWTSecret,
+		JWTIssuer:    testJWTIssuer,
+		CacheEnabled: true,
+		CacheTTL:     time.Minute,
+	})
+	ctx := context.Background()
+
+	tokens := []string{
+		generateTestToken(t, "user-1", "u1@test.com", enum.SystemRoleTeacher, 15*time.Minute),
+		generateTestToken(t, "user-1", "u1@test.com", enum.SystemRoleTeacher, 15*time.Minute),
+		generateTestToken(t, "user-2", "u2@test.com", enum.SystemRoleStudent, 15*time.Minute),
+	}
+	for _, token := range tokens {
+		if info, _ := client.ValidateToken(ctx, token); !info.Valid {
+			t.Fatalf("Token debería ser válido: %s", info.Error)
+		}
+	}
+
+	if evicted := client.RevokeTokens(repository.TokenRevocation{UserID: "user-1", IssuedBefore: time.Now().Add(time.Second)}); evicted != 2 {
+		t.Errorf("Deberían descartarse las 2 validaciones de user-1, se descartaron %d", evicted)
+	}
+	if total, _ := client.cache.Stats(); total != 1 {
+		t.Errorf("El cache debería conservar solo la validación de user-2, tiene %d", total)
+	}
+
+	// Un token revocado no vuelve a cachearse al revalidarse
+	info, _ := client.ValidateToken(ctx, tokens[0])
+	if !client.IsRevoked(info) {
+		t.Error("El token de user-1 debería estar revocado")
+	}
+	if total, _ := client.cache.Stats(); total != 1 {
+		t.Errorf("El token revocado no debería cachearse, el cache tiene %d entradas", total)
+	}
+}
+
+func TestAuthClient_HandleTokenRevoked(t *testing.T) {
+	testCases := []struct {
+		name    string
+		body    string
+		info    *TokenInfo
+		wantErr bool
+	}{
+		{"Logout con sobre de eventos", `{"event_type":"token.revoked","payload":{"jti":"jti-1","expires_at":"2099-01-01T00:00:00Z"}}`, &TokenInfo{TokenID: "jti-1"}, false},
+		{"Cerrar sesiones sin sobre", `{"user_id":"user-1","issued_before":"2099-01-01T00:00:00Z"}`, &TokenInfo{UserID: "user-1", IssuedAt: time.Now()}, false},
+		{"Sin jti ni usuario", `{"payload":{"user_id":"user-1"}}`, nil, true},
+		{"Cuerpo ilegible", `not json`, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := NewAuthClient(AuthClientConfig{JWTSecret: testJWTSecret})
+
+			err := client.HandleTokenRevoked(context.Background(), "token.revoked", []byte(tc.body))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, esperado error: %v", err, tc.wantErr)
+			}
+			if tc.info != nil && !client.IsRevoked(tc.info) {
+				t.Error("El token debería quedar revocado")
+			}
+		})
+	}
+}
+
+// stubRevocationRepository retorna las revocaciones posteriores a since
+type stubRevocationRepository struct {
+	mu          sync.Mutex
+	revocations []*repository.TokenRevocation
+	err         error
+	calls       []time.Time
+}
+
+func (r *stubRevocationRepository) GetRevocationsSince(_ context.Context, since time.Time) ([]*repository.TokenRevocation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, since)
+	if r.err != nil {
+		return nil, r.err
+	}
+	var result []*repository.TokenRevocation
+	for _, rev := range r.revocations {
+		if rev.RevokedAt.After(since) {
+			result = append(result, rev)
+		}
+	}
+	return result, nil
+}
+
+func TestRevocationPoller_Poll(t *testing.T) {
+	revokedAt := time.Now().Add(-10 * time.Minute)
+	repo := &stubRevocationRepository{revocations: []*repository.TokenRevocation{
+		{TokenID: "jti-1", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: revokedAt},
+	}}
+	client := NewAuthClient(AuthClientConfig{JWTSecret: testJWTSecret})
+	poller := NewRevocationPoller(repo, client, time.Hour, logger.NewZapLogger("error", "json"))
+
+	// Al iniciar carga todas las vigentes
+	poller.poll()
+	if !client.IsRevoked(&TokenInfo{TokenID: "jti-1"}) {
+		t.Error("La revocación existente debería aplicarse al iniciar")
+	}
+
+	// Luego consulta desde la última revocación vista (con margen) y aplica las nuevas
+	repo.revocations = append(repo.revocations, &repository.TokenRevocation{
+		UserID: "user-1", IssuedBefore: time.Now(), ExpiresAt: time.Now().Add(time.Hour), RevokedAt: time.Now(),
+	})
+	poller.poll()
+	if !client.IsRevoked(&TokenInfo{UserID: "user-1", IssuedAt: time.Now().Add(-time.Hour)}) {
+		t.Error("La revocación nueva debería aplicarse")
+	}
+	if !repo.calls[0].IsZero() || !repo.calls[1].Equal(revokedAt.Add(-revocationPollOverlap)) {
+		t.Errorf("Consultas inesperadas: %v", repo.calls)
+	}
+
+	// Un error de BD conserva lo ya aplicado
+	repo.err = errors.New("connection refused")
+	poller.poll()
+	if !client.IsRevoked(&TokenInfo{TokenID: "jti-1"}) {
+		t.Error("Un error de BD no debería descartar las revocaciones aplicadas")
+	}
+}
+
+func TestRevocationPoller_StartClose(t *testing.T) {
+	repo := &stubRevocationRepository{}
+	poller := NewRevocationPoller(repo, NewAuthClient(AuthClientConfig{}), time.Hour, logger.NewZapLogger("error", "json"))
+
+	poller.Start()
+	if err := poller.Close(); err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	_ = poller.Close() // Cerrar dos veces no debe bloquear ni entrar en pánico
+}
+
+func TestAuthClient_ValidateToken_RemoteFillsRevocationClaims(t *testing.T) {
+	// api-admin responde sin issued_at ni token_id
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TokenInfo{Valid: true, UserID: "user-1", Email: "u1@test.com"})
+	}))
+	defer server.Close()
+
+	client := NewAuthClient(AuthClientConfig{
+		BaseURL:       server.URL,
+		RemoteEnabled: true,
+		Timeout:       5 * time.Second,
+	})
+	ctx := context.Background()
+
+	token := generateTestToken(t, "user-1", "u1@test.com", enum.SystemRoleStudent, 15*time.Minute)
+	info, _ := client.ValidateToken(ctx, token)
+	if !info.Valid {
+		t.Fatalf("Token debería ser válido (remoto), error: %s", info.Error)
+	}
+	if info.IssuedAt.IsZero() || info.TokenID == "" {
+		t.Fatalf("iat y jti deberían completarse desde los claims: iat=%v jti=%q", info.IssuedAt, info.TokenID)
+	}
+
+	// Una revocación por usuario anterior al login no afecta al token
+	client.RevokeTokens(repository.TokenRevocation{UserID: "user-1", IssuedBefore: info.IssuedAt.Add(-time.Minute)})
+	if client.IsRevoked(info) {
+		t.Error("El token emitido después de cerrar sesiones no debería estar revocado")
+	}
+
+	client.RevokeTokens(repository.TokenRevocation{TokenID: info.TokenID, ExpiresAt: time.Now().Add(time.Hour)})
+	if !client.IsRevoked(info) {
+		t.Error("El token debería estar revocado por jti")
+	}
+
+	// Un token de otro usuario no completa los claims de la validación remota
+	other := generateTestToken(t, "user-2", "u2@test.com", enum.SystemRoleStudent, 15*time.Minute)
+	info, _ = client.ValidateToken(ctx, other)
+	if !info.IssuedAt.IsZero() || info.TokenID != "" {
+		t.Errorf("No deberían completarse claims de otro usuario: iat=%v jti=%q", info.IssuedAt, info.TokenID)
+	}
+}
@@ -19,6 +19,11 @@ type Config struct {
 	Auth        AuthConfig        `mapstructure:"auth"`
 	Bootstrap   BootstrapConfig   `mapstructure:"bootstrap"`
 	Development DevelopmentConfig `mapstructure:"development"`
+	Tracing     TracingConfig     `mapstructure:"tracing"`
+	Stream      StreamConfig      `mapstructure:"stream"`
+	Stats       StatsConfig       `mapstructure:"stats"`
+	Screens     ScreensConfig     `mapstructure:"screens"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
 }
 
 // ServerConfig configuración del servidor HTTP
@@ -27,6 +32,9 @@ type ServerConfig struct {
 	Host         string        `mapstructure:"host"`
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	// IP del cliente (rate limiting y auditoría): solo se acepta X-Forwarded-For de estos proxies
+	TrustedProxies  []string `mapstructure:"trusted_proxies"`  // ENV: SERVER_TRUSTED_PROXIES (IPs o CIDR separados por coma; vacío = ninguno)
+	TrustedPlatform string   `mapstructure:"trusted_platform"` // ENV: SERVER_TRUSTED_PLATFORM (header con la IP del cliente, p. ej. CF-Connecting-IP)
 }
 
 // DatabaseConfig configuración de bases de datos
@@ -44,6 +52,7 @@ type PostgresConfig struct {
 	Password       string `mapstructure:"password"` // ENV: DATABASE_POSTGRES_PASSWORD (required)
 	MaxConnections int    `mapstructure:"max_connections"`
 	SSLMode        string `mapstructure:"ssl_mode"`
+	AutoMigrate    bool   `mapstructure:"auto_migrate"` // ENV: DATABASE_POSTGRES_AUTO_MIGRATE (aplica las tablas propias de api-mobile al arrancar)
 }
 
 // MongoDBConfig configuración de MongoDB
@@ -56,6 +65,17 @@ type MongoDBConfig struct {
 // MessagingConfig configuración de RabbitMQ
 type MessagingConfig struct {
 	RabbitMQ RabbitMQConfig `mapstructure:"rabbitmq"`
+	EventBus EventBusConfig `mapstructure:"event_bus"`
+}
+
+// EventBusConfig configuración del bus de eventos
+// Transport selecciona el destino de los eventos publicados:
+//   - amqp: RabbitMQ (default)
+//   - inprocess: solo suscriptores dentro del proceso, sin broker
+//   - file: archivo JSON-lines en FilePath, útil para depurar
+type EventBusConfig struct {
+	Transport string `mapstructure:"transport"` // ENV: MESSAGING_EVENT_BUS_TRANSPORT (amqp|inprocess|file)
+	FilePath  string `mapstructure:"file_path"` // ENV: MESSAGING_EVENT_BUS_FILE_PATH (solo transport=file)
 }
 
 // RabbitMQConfig configuración de RabbitMQ
@@ -65,6 +85,14 @@ type RabbitMQConfig struct {
 	Exchanges      ExchangeConfig       `mapstructure:"exchanges"`
 	PrefetchCount  int                  `mapstructure:"prefetch_count"`
 	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	Reconnect      ReconnectConfig      `mapstructure:"reconnect"`
+}
+
+// ReconnectConfig configuración de la reconexión automática del publisher de RabbitMQ
+type ReconnectConfig struct {
+	InitialInterval time.Duration `mapstructure:"initial_interval"` // Espera antes del primer reintento (default: 500ms)
+	MaxInterval     time.Duration `mapstructure:"max_interval"`     // Tope del backoff exponencial (default: 30s)
+	PublishTimeout  time.Duration `mapstructure:"publish_timeout"`  // Tiempo que Publish bloquea esperando reconexión (default: 10s)
 }
 
 // CircuitBreakerConfig configuración del circuit breaker para servicios externos
@@ -107,17 +135,113 @@ type LoggingConfig struct {
 	Format string `mapstructure:"format"` // json, text
 }
 
+// TracingConfig configuración de trazas distribuidas (OpenTelemetry)
+// Con Enabled=false solo se propaga el contexto W3C (traceparent) sin exportar spans.
+// Exporter "noop" crea los spans pero los descarta (tests, entornos sin collector).
+type TracingConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`      // ENV: TRACING_ENABLED
+	Exporter    string  `mapstructure:"exporter"`     // ENV: TRACING_EXPORTER (otlp|noop)
+	Endpoint    string  `mapstructure:"endpoint"`     // ENV: TRACING_ENDPOINT (host:port del collector OTLP/HTTP)
+	Insecure    bool    `mapstructure:"insecure"`     // ENV: TRACING_INSECURE (sin TLS hacia el collector)
+	ServiceName string  `mapstructure:"service_name"` // ENV: TRACING_SERVICE_NAME
+	SampleRatio float64 `mapstructure:"sample_ratio"` // ENV: TRACING_SAMPLE_RATIO (0 < ratio <= 1)
+}
+
+// StreamConfig configuración del endpoint de eventos en tiempo real (SSE, GET /v1/stream)
+type StreamConfig struct {
+	HeartbeatInterval     time.Duration `mapstructure:"heartbeat_interval"`       // ENV: STREAM_HEARTBEAT_INTERVAL
+	ClientBufferSize      int           `mapstructure:"client_buffer_size"`       // ENV: STREAM_CLIENT_BUFFER_SIZE (mensajes pendientes antes de desconectar a un cliente lento)
+	MaxConnectionsPerUser int           `mapstructure:"max_connections_per_user"` // ENV: STREAM_MAX_CONNECTIONS_PER_USER (0 = sin límite)
+}
+
+// StatsConfig configuración del cache y los snapshots de estadísticas globales (GET /v1/stats/global)
+type StatsConfig struct {
+	CacheTTL        time.Duration `mapstructure:"cache_ttl"`         // ENV: STATS_CACHE_TTL (cache en memoria por instancia; 0 = sin cache)
+	SnapshotMaxAge  time.Duration `mapstructure:"snapshot_max_age"`  // ENV: STATS_SNAPSHOT_MAX_AGE (snapshots más viejos se recalculan al leer; 0 = siempre recalcular)
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`  // ENV: STATS_REFRESH_INTERVAL (refresco periódico del snapshot; 0 = deshabilitado)
+	EventDebounce   time.Duration `mapstructure:"event_debounce"`    // ENV: STATS_EVENT_DEBOUNCE (espera tras un evento antes de refrescar, agrupa ráfagas)
+	RefreshOnEvents bool          `mapstructure:"refresh_on_events"` // ENV: STATS_REFRESH_ON_EVENTS
+}
+
+// Fuentes de invalidación del cache de pantallas (screens.invalidation)
+const (
+	ScreenInvalidationRabbitMQ = "rabbitmq" // Evento screen.updated publicado por api-admin
+	ScreenInvalidationPostgres = "postgres" // LISTEN/NOTIFY sobre screens.notify_channel
+	ScreenInvalidationNone     = "none"     // Solo vence por TTL
+)
+
+// ScreensConfig configuración del cache de pantallas dinámicas (GET /v1/screens/*)
+// Cada instancia cachea las pantallas resueltas; cuando api-admin edita una pantalla
+// el cache se invalida según Invalidation
+type ScreensConfig struct {
+	CacheTTL        time.Duration `mapstructure:"cache_ttl"`         // ENV: SCREENS_CACHE_TTL (0 = sin cache)
+	CacheMaxEntries int           `mapstructure:"cache_max_entries"` // ENV: SCREENS_CACHE_MAX_ENTRIES (al llenarse descarta la menos usada)
+	Invalidation    string        `mapstructure:"invalidation"`      // ENV: SCREENS_INVALIDATION (rabbitmq|postgres|none)
+	Exchange        string        `mapstructure:"exchange"`          // ENV: SCREENS_EXCHANGE (exchange donde api-admin publica screen.updated)
+	NotifyChannel   string        `mapstructure:"notify_channel"`    // ENV: SCREENS_NOTIFY_CHANNEL (canal de NOTIFY con invalidation=postgres)
+}
+
+// Claves de rate limiting (rate_limit.*.key)
+const (
+	RateLimitKeyUser = "user" // user_id del JWT
+	RateLimitKeyIP   = "ip"   // IP del cliente
+)
+
+// RateLimitConfig configuración del rate limiting de la API (token bucket por usuario o IP)
+// Cada ruta usa su política de Routes o, si no tiene, Default
+type RateLimitConfig struct {
+	Enabled bool                   `mapstructure:"enabled"`  // ENV: RATE_LIMIT_ENABLED
+	Default RateLimitPolicyConfig  `mapstructure:"default"`  // ENV: RATE_LIMIT_DEFAULT_* (requests 0 = sin límite)
+	PreAuth RateLimitPolicyConfig  `mapstructure:"pre_auth"` // ENV: RATE_LIMIT_PRE_AUTH_* (por IP, antes de validar el token; requests 0 = sin límite)
+	Routes  []RateLimitRouteConfig `mapstructure:"routes"`   // Solo por YAML
+}
+
+// RateLimitPolicyConfig límite de una política: Requests por Period con ráfagas de hasta Burst
+type RateLimitPolicyConfig struct {
+	Requests int           `mapstructure:"requests"` // 0 = sin límite
+	Period   time.Duration `mapstructure:"period"`
+	Burst    int           `mapstructure:"burst"` // Capacidad del bucket (default: requests)
+	Key      string        `mapstructure:"key"`   // user|ip (default: user)
+}
+
+// RateLimitRouteConfig política de una ruta: "METHOD /v1/ruta/:param" o solo la ruta
+type RateLimitRouteConfig struct {
+	Route                 string `mapstructure:"route"`
+	RateLimitPolicyConfig `mapstructure:",squash"`
+}
+
 // AuthConfig configuración de autenticación
 type AuthConfig struct {
-	JWT      JWTConfig      `mapstructure:"jwt"`
-	APIAdmin APIAdminConfig `mapstructure:"api_admin"`
+	JWT        JWTConfig             `mapstructure:"jwt"`
+	APIAdmin   APIAdminConfig        `mapstructure:"api_admin"`
+	Revocation TokenRevocationConfig `mapstructure:"revocation"`
 }
 
 // JWTConfig configuración de JWT
 // El Secret e Issuer deben coincidir con los de api-admin para validación local
+// Con JWKSURL o JWKSFile se validan también tokens RS*/ES* con las claves públicas de api-admin
 type JWTConfig struct {
-	Secret string `mapstructure:"secret"` // ENV: AUTH_JWT_SECRET (DEBE ser el mismo que api-admin)
-	Issuer string `mapstructure:"issuer"` // ENV: AUTH_JWT_ISSUER (default: "edugo-central", DEBE ser el mismo que api-admin)
+	Secret              string        `mapstructure:"secret"`                // ENV: AUTH_JWT_SECRET (DEBE ser el mismo que api-admin)
+	Issuer              string        `mapstructure:"issuer"`                // ENV: AUTH_JWT_ISSUER (default: "edugo-central", DEBE ser el mismo que api-admin)
+	JWKSURL             string        `mapstructure:"jwks_url"`              // ENV: AUTH_JWT_JWKS_URL (JWKS publicado por api-admin)
+	JWKSFile            string        `mapstructure:"jwks_file"`             // ENV: AUTH_JWT_JWKS_FILE (alternativa a jwks_url)
+	JWKSRefreshInterval time.Duration `mapstructure:"jwks_refresh_interval"` // Recarga periódica del JWKS (default: 10m)
+}
+
+// Fuentes de revocación de access tokens (auth.revocation.source)
+const (
+	TokenRevocationRabbitMQ = "rabbitmq" // Evento token.revoked publicado por api-admin
+	TokenRevocationPostgres = "postgres" // Consulta periódica de la tabla token_revocations
+	TokenRevocationNone     = "none"     // Los tokens valen hasta expirar
+)
+
+// TokenRevocationConfig configuración de la lista de tokens revocados
+// api-admin revoca tokens en logout y al cerrar todas las sesiones de un usuario; cada
+// instancia mantiene la lista en memoria y descarta del cache las validaciones afectadas
+type TokenRevocationConfig struct {
+	Source       string        `mapstructure:"source"`        // ENV: AUTH_REVOCATION_SOURCE (rabbitmq|postgres|none)
+	Exchange     string        `mapstructure:"exchange"`      // ENV: AUTH_REVOCATION_EXCHANGE (exchange donde api-admin publica token.revoked)
+	PollInterval time.Duration `mapstructure:"poll_interval"` // ENV: AUTH_REVOCATION_POLL_INTERVAL (consulta de la tabla con source=postgres)
 }
 
 // APIAdminConfig configuración del cliente de autenticación remota (api-admin)
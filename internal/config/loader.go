@@ -111,10 +111,16 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.postgres.port", 5432)
 	v.SetDefault("database.postgres.max_connections", 25)
 	v.SetDefault("database.postgres.ssl_mode", "disable")
+	v.SetDefault("database.postgres.auto_migrate", true)
 
 	v.SetDefault("database.mongodb.timeout", "10s")
 
 	v.SetDefault("messaging.rabbitmq.prefetch_count", 10)
+	v.SetDefault("messaging.rabbitmq.reconnect.initial_interval", "500ms")
+	v.SetDefault("messaging.rabbitmq.reconnect.max_interval", "30s")
+	v.SetDefault("messaging.rabbitmq.reconnect.publish_timeout", "10s")
+	v.SetDefault("messaging.event_bus.transport", "amqp")
+	v.SetDefault("messaging.event_bus.file_path", "logs/events.jsonl")
 
 	v.SetDefault("storage.s3.region", "us-east-1")
 	v.SetDefault("storage.s3.endpoint", "")
@@ -122,8 +128,44 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.format", "json")
 
+	// Tracing - OpenTelemetry (deshabilitado por defecto)
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.exporter", "otlp")
+	v.SetDefault("tracing.endpoint", "localhost:4318")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.service_name", "edugo-api-mobile")
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	// Stream - eventos en tiempo real (SSE)
+	v.SetDefault("stream.heartbeat_interval", "15s")
+	v.SetDefault("stream.client_buffer_size", 64)
+	v.SetDefault("stream.max_connections_per_user", 5)
+
+	// Stats - cache y snapshots de estadísticas globales
+	v.SetDefault("stats.cache_ttl", "30s")
+	v.SetDefault("stats.snapshot_max_age", "15m")
+	v.SetDefault("stats.refresh_interval", "5m")
+	v.SetDefault("stats.event_debounce", "30s")
+	v.SetDefault("stats.refresh_on_events", true)
+
+	// Screens - cache de pantallas dinámicas e invalidación
+	v.SetDefault("screens.cache_ttl", "1h")
+	v.SetDefault("screens.cache_max_entries", 500)
+	v.SetDefault("screens.invalidation", "rabbitmq")
+	v.SetDefault("screens.exchange", "edugo.screens")
+	v.SetDefault("screens.notify_channel", "screen_updated")
+
+	// Rate limiting (las políticas por ruta se definen en YAML)
+	v.SetDefault("rate_limit.enabled", true)
+	v.SetDefault("rate_limit.default.requests", 300)
+	v.SetDefault("rate_limit.default.period", "1m")
+	v.SetDefault("rate_limit.default.key", "user")
+	v.SetDefault("rate_limit.pre_auth.requests", 1200)
+	v.SetDefault("rate_limit.pre_auth.period", "1m")
+
 	// Auth - JWT defaults
 	v.SetDefault("auth.jwt.issuer", "edugo-central") // DEBE coincidir con api-admin
+	v.SetDefault("auth.jwt.jwks_refresh_interval", "10m")
 
 	// Auth - API Admin defaults (validación remota deshabilitada por defecto)
 	v.SetDefault("auth.api_admin.timeout", "5s")
@@ -132,6 +174,11 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("auth.api_admin.remote_enabled", false)   // Validación local preferida
 	v.SetDefault("auth.api_admin.fallback_enabled", false) // Fallback deshabilitado por defecto
 
+	// Auth - Revocación de tokens
+	v.SetDefault("auth.revocation.source", "rabbitmq")
+	v.SetDefault("auth.revocation.exchange", "edugo.auth")
+	v.SetDefault("auth.revocation.poll_interval", "15s")
+
 	// Bootstrap - Optional resources (default: true for RabbitMQ, S3, and MongoDB)
 	v.SetDefault("bootstrap.optional_resources.rabbitmq", true)
 	v.SetDefault("bootstrap.optional_resources.s3", true)
@@ -146,6 +193,8 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("server.host")
 	_ = v.BindEnv("server.read_timeout")
 	_ = v.BindEnv("server.write_timeout")
+	_ = v.BindEnv("server.trusted_proxies", "SERVER_TRUSTED_PROXIES")
+	_ = v.BindEnv("server.trusted_platform", "SERVER_TRUSTED_PLATFORM")
 
 	// Database - Postgres
 	_ = v.BindEnv("database.postgres.host")
@@ -155,6 +204,7 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("database.postgres.password")
 	_ = v.BindEnv("database.postgres.max_connections")
 	_ = v.BindEnv("database.postgres.ssl_mode")
+	_ = v.BindEnv("database.postgres.auto_migrate")
 
 	// Database - MongoDB
 	_ = v.BindEnv("database.mongodb.uri")
@@ -167,6 +217,13 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("messaging.rabbitmq.queues.assessment_attempt")
 	_ = v.BindEnv("messaging.rabbitmq.exchanges.materials")
 	_ = v.BindEnv("messaging.rabbitmq.prefetch_count")
+	_ = v.BindEnv("messaging.rabbitmq.reconnect.initial_interval")
+	_ = v.BindEnv("messaging.rabbitmq.reconnect.max_interval")
+	_ = v.BindEnv("messaging.rabbitmq.reconnect.publish_timeout")
+
+	// Messaging - Event bus
+	_ = v.BindEnv("messaging.event_bus.transport")
+	_ = v.BindEnv("messaging.event_bus.file_path")
 
 	// Storage - S3
 	_ = v.BindEnv("storage.s3.region")
@@ -179,6 +236,43 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("logging.level")
 	_ = v.BindEnv("logging.format")
 
+	// Tracing
+	_ = v.BindEnv("tracing.enabled")
+	_ = v.BindEnv("tracing.exporter")
+	_ = v.BindEnv("tracing.endpoint")
+	_ = v.BindEnv("tracing.insecure")
+	_ = v.BindEnv("tracing.service_name")
+	_ = v.BindEnv("tracing.sample_ratio")
+
+	// Stream
+	_ = v.BindEnv("stream.heartbeat_interval")
+	_ = v.BindEnv("stream.client_buffer_size")
+	_ = v.BindEnv("stream.max_connections_per_user")
+
+	// Stats
+	_ = v.BindEnv("stats.cache_ttl")
+	_ = v.BindEnv("stats.snapshot_max_age")
+	_ = v.BindEnv("stats.refresh_interval")
+	_ = v.BindEnv("stats.event_debounce")
+	_ = v.BindEnv("stats.refresh_on_events")
+
+	// Screens
+	_ = v.BindEnv("screens.cache_ttl")
+	_ = v.BindEnv("screens.cache_max_entries")
+	_ = v.BindEnv("screens.invalidation")
+	_ = v.BindEnv("screens.exchange")
+	_ = v.BindEnv("screens.notify_channel")
+
+	// Rate limiting
+	_ = v.BindEnv("rate_limit.enabled", "RATE_LIMIT_ENABLED")
+	_ = v.BindEnv("rate_limit.default.requests", "RATE_LIMIT_DEFAULT_REQUESTS")
+	_ = v.BindEnv("rate_limit.default.period", "RATE_LIMIT_DEFAULT_PERIOD")
+	_ = v.BindEnv("rate_limit.default.burst", "RATE_LIMIT_DEFAULT_BURST")
+	_ = v.BindEnv("rate_limit.default.key", "RATE_LIMIT_DEFAULT_KEY")
+	_ = v.BindEnv("rate_limit.pre_auth.requests", "RATE_LIMIT_PRE_AUTH_REQUESTS")
+	_ = v.BindEnv("rate_limit.pre_auth.period", "RATE_LIMIT_PRE_AUTH_PERIOD")
+	_ = v.BindEnv("rate_limit.pre_auth.burst", "RATE_LIMIT_PRE_AUTH_BURST")
+
 	// Auth - JWT
 	// JWT_SECRET mapeado a auth.jwt.secret (compatibilidad con docker-compose)
 	_ = v.BindEnv("auth.jwt.secret", "JWT_SECRET")
@@ -186,6 +280,10 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("auth.jwt.secret", "AUTH_JWT_SECRET")
 	// AUTH_JWT_ISSUER para el issuer
 	_ = v.BindEnv("auth.jwt.issuer", "AUTH_JWT_ISSUER")
+	// JWKS de api-admin para tokens firmados con claves asimétricas
+	_ = v.BindEnv("auth.jwt.jwks_url", "AUTH_JWT_JWKS_URL")
+	_ = v.BindEnv("auth.jwt.jwks_file", "AUTH_JWT_JWKS_FILE")
+	_ = v.BindEnv("auth.jwt.jwks_refresh_interval", "AUTH_JWT_JWKS_REFRESH_INTERVAL")
 
 	// Auth - API Admin (validación remota opcional)
 	_ = v.BindEnv("auth.api_admin.base_url", "AUTH_API_ADMIN_BASE_URL")
@@ -195,6 +293,11 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("auth.api_admin.remote_enabled", "AUTH_API_ADMIN_REMOTE_ENABLED")
 	_ = v.BindEnv("auth.api_admin.fallback_enabled", "AUTH_API_ADMIN_FALLBACK_ENABLED")
 
+	// Auth - Revocación de tokens
+	_ = v.BindEnv("auth.revocation.source", "AUTH_REVOCATION_SOURCE")
+	_ = v.BindEnv("auth.revocation.exchange", "AUTH_REVOCATION_EXCHANGE")
+	_ = v.BindEnv("auth.revocation.poll_interval", "AUTH_REVOCATION_POLL_INTERVAL")
+
 	// Bootstrap - Optional resources
 	_ = v.BindEnv("bootstrap.optional_resources.rabbitmq")
 	_ = v.BindEnv("bootstrap.optional_resources.s3")
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/EduGoGroup/edugo-shared/common/errors"
@@ -28,7 +29,8 @@ func Validate(cfg *Config) error {
 		validationErrors = append(validationErrors, "STORAGE_S3_SECRET_ACCESS_KEY is required")
 	}
 	// Secret para firmar JWT (vía auth.jwt.secret -> ENV JWT_SECRET)
-	if cfg.Auth.JWT.Secret == "" {
+	// Opcional si los tokens se validan solo con el JWKS de api-admin
+	if cfg.Auth.JWT.Secret == "" && cfg.Auth.JWT.JWKSURL == "" && cfg.Auth.JWT.JWKSFile == "" {
 		validationErrors = append(validationErrors, "JWT_SECRET is required")
 	}
 
@@ -42,6 +44,63 @@ func Validate(cfg *Config) error {
 	if cfg.Storage.S3.BucketName == "" {
 		validationErrors = append(validationErrors, "storage.s3.bucket_name is required")
 	}
+	if cfg.Auth.JWT.JWKSURL != "" && cfg.Auth.JWT.JWKSFile != "" {
+		validationErrors = append(validationErrors, "auth.jwt.jwks_url and auth.jwt.jwks_file are mutually exclusive")
+	}
+	for _, proxy := range cfg.Server.TrustedProxies {
+		if !validIPOrCIDR(proxy) {
+			validationErrors = append(validationErrors, fmt.Sprintf("server.trusted_proxies: %q is not an IP or CIDR", proxy))
+		}
+	}
+	validationErrors = append(validationErrors, validateRateLimit(cfg.RateLimit)...)
+	switch cfg.Auth.Revocation.Source {
+	case "", TokenRevocationRabbitMQ, TokenRevocationNone:
+	case TokenRevocationPostgres:
+		if cfg.Auth.Revocation.PollInterval <= 0 {
+			validationErrors = append(validationErrors, "auth.revocation.poll_interval must be positive when source is postgres")
+		}
+	default:
+		validationErrors = append(validationErrors, "auth.revocation.source must be one of: rabbitmq, postgres, none")
+	}
+	switch cfg.Messaging.EventBus.Transport {
+	case "", "amqp", "inprocess":
+	case "file":
+		if cfg.Messaging.EventBus.FilePath == "" {
+			validationErrors = append(validationErrors, "messaging.event_bus.file_path is required when transport is file")
+		}
+	default:
+		validationErrors = append(validationErrors, "messaging.event_bus.transport must be one of: amqp, inprocess, file")
+	}
+	if cfg.Tracing.Enabled {
+		switch cfg.Tracing.Exporter {
+		case "", "otlp", "noop":
+		default:
+			validationErrors = append(validationErrors, "tracing.exporter must be one of: otlp, noop")
+		}
+		if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+			validationErrors = append(validationErrors, "tracing.sample_ratio must be between 0 and 1")
+		}
+	}
+	if cfg.Stream.HeartbeatInterval < 0 {
+		validationErrors = append(validationErrors, "stream.heartbeat_interval must not be negative")
+	}
+	if cfg.Stream.ClientBufferSize < 0 {
+		validationErrors = append(validationErrors, "stream.client_buffer_size must not be negative")
+	}
+	if cfg.Stream.MaxConnectionsPerUser < 0 {
+		validationErrors = append(validationErrors, "stream.max_connections_per_user must not be negative")
+	}
+	if cfg.Stats.CacheTTL < 0 || cfg.Stats.SnapshotMaxAge < 0 || cfg.Stats.RefreshInterval < 0 || cfg.Stats.EventDebounce < 0 {
+		validationErrors = append(validationErrors, "stats durations must not be negative")
+	}
+	if cfg.Screens.CacheTTL < 0 || cfg.Screens.CacheMaxEntries < 0 {
+		validationErrors = append(validationErrors, "screens.cache_ttl and screens.cache_max_entries must not be negative")
+	}
+	switch cfg.Screens.Invalidation {
+	case "", ScreenInvalidationRabbitMQ, ScreenInvalidationPostgres, ScreenInvalidationNone:
+	default:
+		validationErrors = append(validationErrors, "screens.invalidation must be one of: rabbitmq, postgres, none")
+	}
 
 	// Si hay errores, retornar un error compuesto con mensaje claro
 	if len(validationErrors) > 0 {
@@ -52,3 +111,46 @@ func Validate(cfg *Config) error {
 
 	return nil
 }
+
+// validateRateLimit valida las políticas de rate limiting
+func validateRateLimit(cfg RateLimitConfig) []string {
+	var errs []string
+	validatePolicy := func(name string, policy RateLimitPolicyConfig) {
+		if policy.Requests < 0 || policy.Burst < 0 {
+			errs = append(errs, fmt.Sprintf("rate_limit %s: requests and burst must not be negative", name))
+		}
+		if policy.Requests > 0 && policy.Period <= 0 {
+			errs = append(errs, fmt.Sprintf("rate_limit %s: period must be positive", name))
+		}
+		switch policy.Key {
+		case "", RateLimitKeyUser, RateLimitKeyIP:
+		default:
+			errs = append(errs, fmt.Sprintf("rate_limit %s: key must be one of: user, ip", name))
+		}
+	}
+
+	validatePolicy("default", cfg.Default)
+	validatePolicy("pre_auth", cfg.PreAuth)
+	seen := make(map[string]bool, len(cfg.Routes))
+	for _, route := range cfg.Routes {
+		if route.Route == "" {
+			errs = append(errs, "rate_limit routes: route is required")
+			continue
+		}
+		if seen[route.Route] {
+			errs = append(errs, fmt.Sprintf("rate_limit %s: duplicated route", route.Route))
+		}
+		seen[route.Route] = true
+		validatePolicy(route.Route, route.RateLimitPolicyConfig)
+	}
+	return errs
+}
+
+// validIPOrCIDR indica si value es una IP o un rango CIDR
+func validIPOrCIDR(value string) bool {
+	if strings.Contains(value, "/") {
+		_, _, err := net.ParseCIDR(value)
+		return err == nil
+	}
+	return net.ParseIP(value) != nil
+}
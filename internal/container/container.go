@@ -1,7 +1,15 @@
 package container
 
 import (
+	"context"
+	"io"
+
 	"github.com/EduGoGroup/edugo-api-mobile/internal/bootstrap"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/deadletter"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/realtime"
 )
 
 // Container es el contenedor raíz de dependencias de API Mobile
@@ -24,6 +32,13 @@ type Container struct {
 	Repositories   *RepositoryContainer
 	Services       *ServiceContainer
 	Handlers       *HandlerContainer
+
+	// screenInvalidation escucha las ediciones de pantallas (RabbitMQ o LISTEN/NOTIFY); nil si no aplica
+	screenInvalidation io.Closer
+	// tokenRevocation recibe las revocaciones de tokens de api-admin (RabbitMQ o PostgreSQL); nil si no aplica
+	tokenRevocation io.Closer
+	// streamFeed alimenta el hub del stream desde RabbitMQ; nil si el bus no usa RabbitMQ
+	streamFeed io.Closer
 }
 
 // NewContainer crea un nuevo contenedor e inicializa todas las dependencias
@@ -44,19 +59,63 @@ func NewContainer(resources *bootstrap.Resources) *Container {
 	infra := NewInfrastructureContainer(
 		resources.PostgreSQL,
 		resources.MongoDB,
-		resources.RabbitMQPublisher,
+		newEventBus(resources),
 		resources.S3Client,
 		resources.JWTSecret,
 		resources.AuthConfig,
 		resources.Logger,
 	)
 
+	// El hub del stream escucha el bus para reenviar eventos a clientes SSE, y RabbitMQ
+	// para recibir los de otras instancias; los duplicados se descartan por event_id
+	infra.StreamHub = newStreamHub(resources)
+	infra.StreamHub.Attach(infra.EventBus)
+	streamFeed := startStreamFeed(resources, infra.EventBus, infra.StreamHub)
+
+	// Límite de requests por usuario/IP de las rutas protegidas
+	infra.RateLimiter = newRateLimiter(resources)
+	infra.PreAuthLimiter = newPreAuthRateLimiter(resources)
+	if resources.Config != nil {
+		infra.ClientIP = middleware.ClientIPConfig{
+			TrustedProxies:  resources.Config.Server.TrustedProxies,
+			TrustedPlatform: resources.Config.Server.TrustedPlatform,
+		}
+	}
+
 	// Paso 2: Inicializar repositorios (dependen de infraestructura y config)
 	// NOTA: Ahora recibe Config para determinar si usar mocks o implementaciones reales
 	repos := NewRepositoryContainer(infra, resources.Config)
 
+	// Los eventos que fallen al publicarse quedan en el dead-letter store para replay
+	infra.MessagePublisher = deadletter.NewPublisher(infra.MessagePublisher, repos.FailedEventRepository, infra.Logger)
+
 	// Paso 3: Inicializar servicios (dependen de repositorios e infraestructura)
-	services := NewServiceContainer(infra, repos)
+	services := NewServiceContainer(infra, repos, resources.Config)
+
+	// El registro de actividad escucha las actualizaciones de progreso (material.completed reemplaza a progress.updated al 100%)
+	infra.EventBus.Subscribe("progress.updated", services.ActivityService.HandleProgressEvent)
+	infra.EventBus.Subscribe("material.completed", services.ActivityService.HandleProgressEvent)
+
+	// La cola de repaso encola preguntas falladas y el glosario de los materiales completados
+	infra.EventBus.Subscribe("assessment.attempt.completed", services.ReviewService.HandleAttemptCompleted)
+	infra.EventBus.Subscribe("material.completed", services.ReviewService.HandleMaterialCompleted)
+
+	// El snapshot de estadísticas globales se refresca periódicamente y tras eventos relevantes
+	if resources.Config != nil && resources.Config.Stats.RefreshOnEvents {
+		services.StatsSnapshotScheduler.Subscribe(infra.EventBus)
+	}
+	services.StatsSnapshotScheduler.Start()
+	services.MaterialViewRecorder.Start()
+
+	// El cache de pantallas se invalida cuando api-admin edita una pantalla (evento local o remoto)
+	infra.EventBus.Subscribe(screenUpdatedRoutingKey, services.ScreenService.HandleScreenUpdated)
+	screenInvalidation := startScreenInvalidation(resources, services.ScreenService)
+
+	// Los tokens revocados en api-admin (logout, cerrar sesiones) dejan de aceptarse de inmediato
+	infra.EventBus.Subscribe(tokenRevokedRoutingKey, func(ctx context.Context, event eventbus.Event) error {
+		return infra.AuthClient.HandleTokenRevoked(ctx, event.RoutingKey, event.Body)
+	})
+	tokenRevocation := startTokenRevocation(resources, infra.AuthClient, repos.TokenRevocationRepository)
 
 	// Paso 4: Inicializar handlers (dependen de servicios e infraestructura)
 	handlers := NewHandlerContainer(infra, services)
@@ -67,11 +126,66 @@ func NewContainer(resources *bootstrap.Resources) *Container {
 		Repositories:   repos,
 		Services:       services,
 		Handlers:       handlers,
+
+		screenInvalidation: screenInvalidation,
+		tokenRevocation:    tokenRevocation,
+		streamFeed:         streamFeed,
 	}
 }
 
 // Close cierra los recursos del contenedor
-// Delega el cierre al InfrastructureContainer que gestiona las conexiones
+// Detiene primero los procesos en segundo plano de los servicios y luego
+// delega el cierre al InfrastructureContainer que gestiona las conexiones
 func (c *Container) Close() error {
+	if c.screenInvalidation != nil {
+		if err := c.screenInvalidation.Close(); err != nil {
+			c.Infrastructure.Logger.Warn("error cerrando la invalidación de pantallas", "error", err)
+		}
+	}
+	if c.tokenRevocation != nil {
+		if err := c.tokenRevocation.Close(); err != nil {
+			c.Infrastructure.Logger.Warn("error cerrando la revocación de tokens", "error", err)
+		}
+	}
+	if c.streamFeed != nil {
+		if err := c.streamFeed.Close(); err != nil {
+			c.Infrastructure.Logger.Warn("error cerrando la suscripción del stream a RabbitMQ", "error", err)
+		}
+	}
+	c.Services.Close()
 	return c.Infrastructure.Close()
 }
+
+// newEventBus crea el bus de eventos según config.MessagingConfig.EventBus
+// Si el transporte configurado no se puede inicializar (p. ej. archivo inaccesible),
+// degrada a transporte en proceso para que la aplicación arranque igual
+func newEventBus(resources *bootstrap.Resources) eventbus.EventBus {
+	var busConfig config.EventBusConfig
+	if resources.Config != nil {
+		busConfig = resources.Config.Messaging.EventBus
+	}
+
+	bus, err := eventbus.NewFromConfig(busConfig, resources.RabbitMQPublisher, resources.Logger)
+	if err != nil {
+		resources.Logger.Error("no se pudo inicializar el event bus, usando transporte en proceso",
+			"transport", busConfig.Transport,
+			"error", err,
+		)
+		return eventbus.New(eventbus.NewInProcessTransport(), resources.Logger)
+	}
+	return bus
+}
+
+// newStreamHub crea el hub de eventos en tiempo real según config.StreamConfig
+func newStreamHub(resources *bootstrap.Resources) *realtime.Hub {
+	var streamConfig config.StreamConfig
+	if resources.Config != nil {
+		streamConfig = resources.Config.Stream
+	}
+
+	return realtime.NewHub(realtime.Config{
+		HeartbeatInterval:     streamConfig.HeartbeatInterval,
+		ClientBufferSize:      streamConfig.ClientBufferSize,
+		MaxConnectionsPerUser: streamConfig.MaxConnectionsPerUser,
+	}, resources.Logger)
+}
@@ -115,6 +115,36 @@ func (f *RepositoryFactory) CreateScreenRepository() repository.ScreenRepository
 	return postgresRepo.NewPostgresScreenRepository(f.infra.DB)
 }
 
+func (f *RepositoryFactory) CreateScreenExperimentRepository() repository.ScreenExperimentRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockScreenExperimentRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresScreenExperimentRepository(f.infra.DB)
+}
+
+func (f *RepositoryFactory) CreateScreenTranslationRepository() repository.ScreenTranslationRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockScreenTranslationRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresScreenTranslationRepository(f.infra.DB)
+}
+
+func (f *RepositoryFactory) CreateTokenRevocationRepository() repository.TokenRevocationRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockTokenRevocationRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresTokenRevocationRepository(f.infra.DB)
+}
+
 func (f *RepositoryFactory) CreateResourceReader() repository.ResourceReader {
 	if f.config.Development.UseMockRepositories {
 		return mockPostgres.NewMockResourceReader()
@@ -125,6 +155,76 @@ func (f *RepositoryFactory) CreateResourceReader() repository.ResourceReader {
 	return postgresRepo.NewPostgresResourceRepository(f.infra.DB)
 }
 
+func (f *RepositoryFactory) CreateUnitReportRepository() repository.UnitReportRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockUnitReportRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresUnitReportRepository(f.infra.DB)
+}
+
+func (f *RepositoryFactory) CreateLearningActivityRepository() repository.LearningActivityRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockLearningActivityRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresLearningActivityRepository(f.infra.DB)
+}
+
+func (f *RepositoryFactory) CreateStatsSnapshotRepository() repository.StatsSnapshotRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockStatsSnapshotRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresStatsSnapshotRepository(f.infra.DB)
+}
+
+func (f *RepositoryFactory) CreateReadingEventRepository() repository.ReadingEventRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockReadingEventRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresReadingEventRepository(f.infra.DB)
+}
+
+func (f *RepositoryFactory) CreateSyncOperationRepository() repository.SyncOperationRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockSyncOperationRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresSyncOperationRepository(f.infra.DB)
+}
+
+func (f *RepositoryFactory) CreateLearningPathRepository() repository.LearningPathRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockLearningPathRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresLearningPathRepository(f.infra.DB)
+}
+
+func (f *RepositoryFactory) CreateReviewItemRepository() repository.ReviewItemRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockPostgres.NewMockReviewItemRepository()
+	}
+	if f.infra.DB == nil {
+		panic("PostgreSQL DB connection is nil but mock repositories are disabled")
+	}
+	return postgresRepo.NewPostgresReviewItemRepository(f.infra.DB)
+}
+
 func (f *RepositoryFactory) CreateSummaryRepository() repository.SummaryRepository {
 	if f.config.Development.UseMockRepositories {
 		return mockMongo.NewMockSummaryRepository()
@@ -148,3 +248,27 @@ func (f *RepositoryFactory) CreateAssessmentDocumentRepository() mongoRepo.Asses
 	}
 	return mongoRepo.NewMongoAssessmentDocumentRepository(f.infra.MongoDB)
 }
+
+func (f *RepositoryFactory) CreateFailedEventRepository() repository.FailedEventRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockMongo.NewMockFailedEventRepository()
+	}
+	// Si MongoDB es opcional y no está disponible, usar store en memoria como fallback
+	if f.infra.MongoDB == nil {
+		f.infra.Logger.Warn("MongoDB not available, using in-memory FailedEventRepository as fallback")
+		return mockMongo.NewMockFailedEventRepository()
+	}
+	return mongoRepo.NewMongoFailedEventRepository(f.infra.MongoDB)
+}
+
+func (f *RepositoryFactory) CreateMaterialViewRepository() repository.MaterialViewRepository {
+	if f.config.Development.UseMockRepositories {
+		return mockMongo.NewMockMaterialViewRepository()
+	}
+	// Si MongoDB es opcional y no está disponible, usar tracking en memoria como fallback
+	if f.infra.MongoDB == nil {
+		f.infra.Logger.Warn("MongoDB not available, using in-memory MaterialViewRepository as fallback")
+		return mockMongo.NewMockMaterialViewRepository()
+	}
+	return mongoRepo.NewMongoMaterialViewRepository(f.infra.MongoDB)
+}
@@ -8,12 +8,19 @@ import (
 // Responsabilidad: Gestionar la capa de presentación HTTP (REST API)
 // Implementa el patrón Adapter entre HTTP y servicios de aplicación
 type HandlerContainer struct {
-	MaterialHandler   *handler.MaterialHandler
-	ProgressHandler   *handler.ProgressHandler
-	SummaryHandler    *handler.SummaryHandler
-	AssessmentHandler *handler.AssessmentHandler
-	StatsHandler      *handler.StatsHandler
-	ScreenHandler     *handler.ScreenHandler // Dynamic UI - Phase 1
+	MaterialHandler     *handler.MaterialHandler
+	ProgressHandler     *handler.ProgressHandler
+	SummaryHandler      *handler.SummaryHandler
+	AssessmentHandler   *handler.AssessmentHandler
+	StatsHandler        *handler.StatsHandler
+	ScreenHandler       *handler.ScreenHandler // Dynamic UI - Phase 1
+	AdminEventHandler   *handler.AdminEventHandler
+	StreamHandler       *handler.StreamHandler
+	ReportHandler       *handler.ReportHandler
+	ActivityHandler     *handler.ActivityHandler
+	SyncHandler         *handler.SyncHandler
+	LearningPathHandler *handler.LearningPathHandler
+	ReviewHandler       *handler.ReviewHandler
 }
 
 // NewHandlerContainer crea y configura todos los handlers HTTP
@@ -53,6 +60,7 @@ func NewHandlerContainer(infra *InfrastructureContainer, services *ServiceContai
 		// StatsHandler gestiona estadísticas globales y por material
 		StatsHandler: handler.NewStatsHandler(
 			services.StatsService,
+			services.MaterialViewRecorder,
 			infra.Logger,
 		),
 
@@ -61,5 +69,48 @@ func NewHandlerContainer(infra *InfrastructureContainer, services *ServiceContai
 			services.ScreenService,
 			infra.Logger,
 		),
+
+		// AdminEventHandler expone el dead-letter store de eventos (admin)
+		AdminEventHandler: handler.NewAdminEventHandler(
+			services.FailedEventService,
+			infra.Logger,
+		),
+
+		// StreamHandler expone eventos en tiempo real (SSE) a usuarios y clases
+		StreamHandler: handler.NewStreamHandler(
+			infra.StreamHub,
+			services.MaterialService,
+			infra.Logger,
+		),
+
+		// ReportHandler expone reportes docentes en JSON, CSV y XLSX
+		ReportHandler: handler.NewReportHandler(
+			services.ReportService,
+			infra.Logger,
+		),
+
+		// ActivityHandler expone la actividad diaria y rachas de aprendizaje
+		ActivityHandler: handler.NewActivityHandler(
+			services.ActivityService,
+			infra.Logger,
+		),
+
+		// SyncHandler recibe lotes de cambios hechos offline
+		SyncHandler: handler.NewSyncHandler(
+			services.SyncService,
+			infra.Logger,
+		),
+
+		// LearningPathHandler gestiona rutas de aprendizaje y bloquea materiales no desbloqueados
+		LearningPathHandler: handler.NewLearningPathHandler(
+			services.LearningPathService,
+			infra.Logger,
+		),
+
+		// ReviewHandler expone la cola de repaso espaciado del usuario
+		ReviewHandler: handler.NewReviewHandler(
+			services.ReviewService,
+			infra.Logger,
+		),
 	}
 }
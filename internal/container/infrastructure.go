@@ -6,9 +6,13 @@ import (
 	"github.com/EduGoGroup/edugo-api-mobile/internal/bootstrap"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/client"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/realtime"
 	"github.com/EduGoGroup/edugo-shared/auth"
 	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/gin-gonic/gin"
 	mongov2 "go.mongodb.org/mongo-driver/v2/mongo"
 )
 
@@ -19,8 +23,13 @@ type InfrastructureContainer struct {
 	MongoDB          *mongov2.Database
 	Logger           logger.Logger
 	JWTManager       *auth.JWTManager
-	AuthClient       *client.AuthClient // Cliente para validar tokens JWT (local + fallback remoto opcional)
-	MessagePublisher rabbitmq.Publisher
+	AuthClient       *client.AuthClient        // Cliente para validar tokens JWT (local + fallback remoto opcional)
+	MessagePublisher rabbitmq.Publisher        // Publica a través del EventBus con el transporte configurado
+	EventBus         eventbus.EventBus         // Bus de eventos con suscriptores en proceso
+	StreamHub        *realtime.Hub             // Distribuye eventos del bus a clientes SSE (GET /v1/stream)
+	RateLimiter      gin.HandlerFunc           // Rate limiting de las rutas protegidas; nil si está deshabilitado
+	PreAuthLimiter   gin.HandlerFunc           // Rate limiting por IP antes de validar el token; nil si está deshabilitado
+	ClientIP         middleware.ClientIPConfig // Proxies de los que se acepta X-Forwarded-For (server.trusted_proxies)
 	S3Client         bootstrap.S3Storage
 }
 
@@ -28,7 +37,7 @@ type InfrastructureContainer struct {
 // Parámetros:
 //   - db: Conexión a PostgreSQL
 //   - mongoDB: Conexión a MongoDB
-//   - publisher: Cliente de RabbitMQ para mensajería
+//   - bus: Bus de eventos (RabbitMQ, en proceso o archivo según config)
 //   - s3Client: Cliente de AWS S3 para almacenamiento (interfaz S3Storage)
 //   - jwtSecret: Secret para generación de tokens JWT (DEBE ser el mismo que api-admin)
 //   - authConfig: Configuración de autenticación (incluye JWT issuer y api-admin opcional)
@@ -36,7 +45,7 @@ type InfrastructureContainer struct {
 func NewInfrastructureContainer(
 	db *sql.DB,
 	mongoDB *mongov2.Database,
-	publisher rabbitmq.Publisher,
+	bus eventbus.EventBus,
 	s3Client bootstrap.S3Storage,
 	jwtSecret string,
 	authConfig config.AuthConfig,
@@ -55,6 +64,11 @@ func NewInfrastructureContainer(
 		JWTSecret: jwtSecret,
 		JWTIssuer: jwtIssuer,
 
+		// Claves públicas de api-admin (tokens RS*/ES*), opcional
+		JWKSURL:             authConfig.JWT.JWKSURL,
+		JWKSFile:            authConfig.JWT.JWKSFile,
+		JWKSRefreshInterval: authConfig.JWT.JWKSRefreshInterval,
+
 		// Validación REMOTA (fallback opcional)
 		BaseURL:         authConfig.APIAdmin.BaseURL,
 		Timeout:         authConfig.APIAdmin.Timeout,
@@ -72,15 +86,25 @@ func NewInfrastructureContainer(
 		Logger:           logger,
 		JWTManager:       auth.NewJWTManager(jwtSecret, jwtIssuer),
 		AuthClient:       authClient,
-		MessagePublisher: publisher,
+		MessagePublisher: bus,
+		EventBus:         bus,
 		S3Client:         s3Client,
 	}
 }
 
 // Close cierra los recursos de infraestructura
-// Actualmente solo cierra la conexión a PostgreSQL
+// Desconecta los clientes del stream, cierra el bus de eventos y la conexión a PostgreSQL
 // MongoDB se gestiona externamente por el driver
 func (ic *InfrastructureContainer) Close() error {
+	if ic.StreamHub != nil {
+		ic.StreamHub.Close()
+	}
+	// El bus cierra su transporte (el archivo de eventos con transport=file)
+	if ic.EventBus != nil {
+		if err := ic.EventBus.Close(); err != nil {
+			ic.Logger.Warn("error cerrando el bus de eventos", "error", err)
+		}
+	}
 	if ic.DB != nil {
 		return ic.DB.Close()
 	}
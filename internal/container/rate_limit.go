@@ -0,0 +1,68 @@
+package container
+
+import (
+	"github.com/EduGoGroup/edugo-api-mobile/internal/bootstrap"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// newRateLimiter crea el middleware de rate limiting según config.RateLimitConfig
+// Retorna nil si está deshabilitado. Los buckets viven en memoria: con varias instancias
+// el límite efectivo es por instancia hasta contar con un RateLimitStore compartido
+func newRateLimiter(resources *bootstrap.Resources) gin.HandlerFunc {
+	if resources.Config == nil || !resources.Config.RateLimit.Enabled {
+		return nil
+	}
+	cfg := resources.Config.RateLimit
+
+	policies := make([]middleware.RateLimitPolicy, 0, len(cfg.Routes))
+	for _, route := range cfg.Routes {
+		policies = append(policies, rateLimitPolicy(route.Route, route.RateLimitPolicyConfig))
+	}
+	defaultPolicy := rateLimitPolicy("", cfg.Default)
+
+	resources.Logger.Info("rate limiting habilitado",
+		"default_requests", cfg.Default.Requests,
+		"default_period", cfg.Default.Period,
+		"routes", len(policies),
+	)
+	return middleware.RateLimitMiddleware(middleware.RateLimitConfig{
+		Store:    middleware.NewMemoryRateLimitStore(),
+		Policies: policies,
+		Default:  &defaultPolicy,
+		Logger:   resources.Logger,
+	})
+}
+
+// newPreAuthRateLimiter crea el limitador por IP que se aplica antes de validar el token,
+// para que requests con tokens inválidos no lleguen a RemoteAuthMiddleware sin límite
+// Retorna nil si el rate limiting está deshabilitado o pre_auth no tiene límite
+func newPreAuthRateLimiter(resources *bootstrap.Resources) gin.HandlerFunc {
+	if resources.Config == nil || !resources.Config.RateLimit.Enabled || resources.Config.RateLimit.PreAuth.Requests <= 0 {
+		return nil
+	}
+	preAuth := rateLimitPolicy(preAuthRateLimitPolicy, resources.Config.RateLimit.PreAuth)
+	preAuth.Key = middleware.RateLimitKeyIP
+
+	return middleware.RateLimitMiddleware(middleware.RateLimitConfig{
+		Store:   middleware.NewMemoryRateLimitStore(),
+		Default: &preAuth,
+		Logger:  resources.Logger,
+	})
+}
+
+// preAuthRateLimitPolicy nombre de la política pre-auth (prefijo de buckets y label de métricas)
+const preAuthRateLimitPolicy = "pre_auth"
+
+func rateLimitPolicy(route string, policy config.RateLimitPolicyConfig) middleware.RateLimitPolicy {
+	return middleware.RateLimitPolicy{
+		Route: route,
+		Key:   policy.Key,
+		RateLimit: middleware.RateLimit{
+			Requests: policy.Requests,
+			Period:   policy.Period,
+			Burst:    policy.Burst,
+		},
+	}
+}
@@ -26,12 +26,44 @@ type RepositoryContainer struct {
 	// Screen Config Repository (Dynamic UI - Phase 1)
 	ScreenRepository repository.ScreenRepository
 
+	// Experimentos A/B sobre pantallas (PostgreSQL, administrados por api-admin)
+	ScreenExperimentRepository repository.ScreenExperimentRepository
+
+	// Traducciones de slots de pantallas y etiquetas de menú (PostgreSQL, administradas por api-admin)
+	ScreenTranslationRepository repository.ScreenTranslationRepository
+
+	// Revocaciones de access tokens (PostgreSQL, registradas por api-admin)
+	TokenRevocationRepository repository.TokenRevocationRepository
+
 	// Resource Reader (Dynamic UI - Phase 2: Dynamic Navigation)
 	ResourceReader repository.ResourceReader
 
+	// Reportes docentes por unidad académica (PostgreSQL)
+	UnitReportRepository repository.UnitReportRepository
+
+	// Registro de actividad de aprendizaje (PostgreSQL, append-only)
+	LearningActivityRepository repository.LearningActivityRepository
+
+	// Snapshot compartido de estadísticas globales (PostgreSQL)
+	StatsSnapshotRepository repository.StatsSnapshotRepository
+
+	// Eventos de lectura por página, sección o video (PostgreSQL, append-only)
+	ReadingEventRepository repository.ReadingEventRepository
+
+	// Registro idempotente de ítems de sincronización offline (PostgreSQL)
+	SyncOperationRepository repository.SyncOperationRepository
+
+	// Rutas de aprendizaje con pasos y requisitos (PostgreSQL)
+	LearningPathRepository repository.LearningPathRepository
+
+	// Cola de repaso espaciado por estudiante (PostgreSQL)
+	ReviewItemRepository repository.ReviewItemRepository
+
 	// MongoDB Repositories
 	SummaryRepository      repository.SummaryRepository
 	AssessmentDocumentRepo mongoRepo.AssessmentDocumentRepository
+	FailedEventRepository  repository.FailedEventRepository  // Dead-letter store de eventos
+	MaterialViewRepository repository.MaterialViewRepository // Tracking de visualizaciones de materiales
 }
 
 // NewRepositoryContainer crea y configura todos los repositorios
@@ -61,11 +93,43 @@ func NewRepositoryContainer(infra *InfrastructureContainer, cfg *config.Config)
 		// Screen Config repository (Dynamic UI - Phase 1) - creado vía factory
 		ScreenRepository: factory.CreateScreenRepository(),
 
+		// Experimentos A/B sobre pantallas - creado vía factory
+		ScreenExperimentRepository: factory.CreateScreenExperimentRepository(),
+
+		// Traducciones de pantallas y menú - creado vía factory
+		ScreenTranslationRepository: factory.CreateScreenTranslationRepository(),
+
+		// Revocaciones de tokens - creado vía factory
+		TokenRevocationRepository: factory.CreateTokenRevocationRepository(),
+
 		// Resource Reader (Dynamic UI - Phase 2) - creado vía factory
 		ResourceReader: factory.CreateResourceReader(),
 
+		// Reportes docentes (PostgreSQL) - creado vía factory
+		UnitReportRepository: factory.CreateUnitReportRepository(),
+
+		// Registro de actividad (PostgreSQL) - creado vía factory
+		LearningActivityRepository: factory.CreateLearningActivityRepository(),
+
+		// Snapshot de estadísticas globales (PostgreSQL) - creado vía factory
+		StatsSnapshotRepository: factory.CreateStatsSnapshotRepository(),
+
+		// Eventos de lectura (PostgreSQL) - creado vía factory
+		ReadingEventRepository: factory.CreateReadingEventRepository(),
+
+		// Operaciones de sincronización (PostgreSQL) - creado vía factory
+		SyncOperationRepository: factory.CreateSyncOperationRepository(),
+
+		// Rutas de aprendizaje (PostgreSQL) - creado vía factory
+		LearningPathRepository: factory.CreateLearningPathRepository(),
+
+		// Cola de repaso (PostgreSQL) - creado vía factory
+		ReviewItemRepository: factory.CreateReviewItemRepository(),
+
 		// MongoDB repositories - creados vía factory
 		SummaryRepository:      factory.CreateSummaryRepository(),
 		AssessmentDocumentRepo: factory.CreateAssessmentDocumentRepository(),
+		FailedEventRepository:  factory.CreateFailedEventRepository(),
+		MaterialViewRepository: factory.CreateMaterialViewRepository(),
 	}
 }
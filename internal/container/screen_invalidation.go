@@ -0,0 +1,83 @@
+package container
+
+import (
+	"context"
+	"io"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/bootstrap"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/postgres/notify"
+)
+
+// screenUpdatedRoutingKey es el evento que api-admin publica al editar una pantalla o un template
+const screenUpdatedRoutingKey = "screen.updated"
+
+// startScreenInvalidation conecta el cache de pantallas con la fuente configurada en
+// config.ScreensConfig.Invalidation para que todas las instancias descarten las pantallas editadas
+// Retorna nil si no hay fuente remota (invalidation=none, mocks o sin RabbitMQ); el cache
+// sigue venciendo por TTL. Un fallo al conectar no impide arrancar la aplicación
+func startScreenInvalidation(resources *bootstrap.Resources, screens service.ScreenService) io.Closer {
+	cfg := resources.Config
+	if cfg == nil || cfg.Development.UseMockRepositories {
+		return nil
+	}
+	log := resources.Logger
+
+	// Tras una reconexión se perdieron las notificaciones intermedias: vaciar el cache
+	resync := func() { screens.InvalidateScreens() }
+
+	switch cfg.Screens.Invalidation {
+	case config.ScreenInvalidationNone:
+		return nil
+
+	case config.ScreenInvalidationPostgres:
+		listener, err := notify.NewListener(
+			cfg.Database.Postgres.GetConnectionString(),
+			cfg.Screens.NotifyChannel,
+			func(ctx context.Context, payload string) error {
+				return screens.HandleScreenUpdated(ctx, eventbus.Event{RoutingKey: screenUpdatedRoutingKey, Body: []byte(payload)})
+			},
+			resync,
+			log,
+		)
+		if err != nil {
+			log.Error("no se pudo escuchar la invalidación de pantallas, el cache solo vencerá por TTL",
+				"channel", cfg.Screens.NotifyChannel,
+				"error", err,
+			)
+			return nil
+		}
+		listener.Start()
+		log.Info("invalidación de pantallas por LISTEN/NOTIFY", "channel", cfg.Screens.NotifyChannel)
+		return listener
+
+	default:
+		if resources.RabbitMQPublisher == nil || cfg.Messaging.RabbitMQ.URL == "" {
+			log.Warn("RabbitMQ no disponible, el cache de pantallas solo vencerá por TTL")
+			return nil
+		}
+
+		subscriber := rabbitmq.NewBroadcastSubscriber(
+			cfg.Messaging.RabbitMQ.URL,
+			rabbitmq.SubscriberConfig{
+				Exchange:    cfg.Screens.Exchange,
+				BindingKeys: []string{screenUpdatedRoutingKey},
+				Reconnect: rabbitmq.ReconnectConfig{
+					InitialInterval: cfg.Messaging.RabbitMQ.Reconnect.InitialInterval,
+					MaxInterval:     cfg.Messaging.RabbitMQ.Reconnect.MaxInterval,
+				},
+				OnReconnect: resync,
+			},
+			func(ctx context.Context, routingKey string, body []byte) error {
+				return screens.HandleScreenUpdated(ctx, eventbus.Event{Exchange: cfg.Screens.Exchange, RoutingKey: routingKey, Body: body})
+			},
+			log,
+		)
+		subscriber.Start()
+		log.Info("invalidación de pantallas por RabbitMQ", "exchange", cfg.Screens.Exchange, "routing_key", screenUpdatedRoutingKey)
+		return subscriber
+	}
+}
@@ -2,6 +2,8 @@ package container
 
 import (
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
 )
 
 // ServiceContainer encapsula todos los servicios de aplicación
@@ -14,64 +16,193 @@ type ServiceContainer struct {
 	AssessmentAttemptService service.AssessmentAttemptService // Sprint-04
 	StatsService             service.StatsService
 	ScreenService            service.ScreenService // Dynamic UI - Phase 1
+	FailedEventService       service.FailedEventService
+	ReportService            service.ReportService
+	ActivityService          service.ActivityService
+	SyncService              service.SyncService
+	LearningPathService      service.LearningPathService
+	ReviewService            service.ReviewService
+
+	// StatsSnapshotScheduler refresca en segundo plano el snapshot de estadísticas globales
+	StatsSnapshotScheduler *service.StatsSnapshotScheduler
+
+	// MaterialViewRecorder registra en segundo plano las visualizaciones de materiales
+	MaterialViewRecorder *service.MaterialViewRecorder
 }
 
 // NewServiceContainer crea y configura todos los servicios de aplicación
 // Parámetros:
 //   - infra: Contenedor de infraestructura (Logger, JWTManager, MessagePublisher)
 //   - repos: Contenedor de repositorios para acceso a datos
+//   - cfg: Configuración de la aplicación (cache y snapshots de estadísticas, cache de pantallas)
 //
 // Retorna un contenedor con todos los servicios inicializados
 // Cada servicio recibe sus dependencias específicas según el principio DIP
-func NewServiceContainer(infra *InfrastructureContainer, repos *RepositoryContainer) *ServiceContainer {
-	return &ServiceContainer{
+// El scheduler de estadísticas y el recorder de visualizaciones se crean sin iniciar; NewContainer los inicia
+func NewServiceContainer(infra *InfrastructureContainer, repos *RepositoryContainer, cfg *config.Config) *ServiceContainer {
+	var statsConfig config.StatsConfig
+	screenCacheConfig := service.DefaultScreenCacheConfig()
+	if cfg != nil {
+		statsConfig = cfg.Stats
+		screenCacheConfig = service.ScreenCacheConfig{
+			TTL:        cfg.Screens.CacheTTL,
+			MaxEntries: cfg.Screens.CacheMaxEntries,
+		}
+	}
+
+	// LearningPathService gestiona rutas de aprendizaje y el desbloqueo de sus materiales
+	// Los servicios de material, progreso, resumen e intentos lo usan para aplicar el bloqueo
+	learningPaths := service.NewLearningPathService(
+		repos.LearningPathRepository,
+		repos.MaterialRepository,
+		infra.Logger,
+	)
+
+	services := &ServiceContainer{
+		LearningPathService: learningPaths,
+
 		// MaterialService gestiona materiales educativos y versionado
 		MaterialService: service.NewMaterialService(
 			repos.MaterialRepository,
 			infra.MessagePublisher,
+			learningPaths,
 			infra.Logger,
 		),
 
 		// ProgressService gestiona el progreso de lectura de estudiantes
 		// Publica evento material.completed cuando progress = 100%
+		// Deriva el progreso de los eventos de lectura (páginas, secciones, video)
 		ProgressService: service.NewProgressService(
 			repos.ProgressRepository,
+			repos.ReadingEventRepository,
+			repos.MaterialRepository,
 			infra.MessagePublisher,
+			learningPaths,
 			infra.Logger,
 		),
 
 		// SummaryService gestiona resúmenes de materiales (MongoDB)
 		SummaryService: service.NewSummaryService(
 			repos.SummaryRepository,
+			repos.MaterialRepository,
+			learningPaths,
 			infra.Logger,
 		),
 
 		// AssessmentAttemptService gestiona intentos de evaluación (Sprint-04)
 		// Orquesta repositorios de PostgreSQL (Sprint-03) y MongoDB
 		// Valida respuestas servidor-side y calcula scores
+		// Publica assessment.attempt.completed al calificar un intento
 		AssessmentAttemptService: service.NewAssessmentAttemptService(
 			repos.AssessmentRepoV2,
 			repos.AttemptRepo,
 			repos.AnswerRepo,
 			repos.AssessmentDocumentRepo,
+			repos.MaterialRepository,
+			infra.MessagePublisher,
+			learningPaths,
 			infra.Logger,
 		),
 
 		// StatsService gestiona estadísticas globales y por material
 		// Usa queries paralelas con goroutines para optimización
-		// ISP: Solo necesita interfaces Stats segregadas (todas PostgreSQL)
+		// ISP: Solo necesita interfaces Stats segregadas (PostgreSQL + visualizaciones en MongoDB)
 		StatsService: service.NewStatsService(
 			infra.Logger,
-			repos.MaterialRepository, // MaterialStats (PostgreSQL)
-			repos.AttemptRepo,        // AssessmentStats (PostgreSQL) - migrado de MongoDB
-			repos.ProgressRepository, // ProgressStats (PostgreSQL)
+			repos.MaterialRepository,      // MaterialStats (PostgreSQL)
+			repos.MaterialRepository,      // MaterialReader (PostgreSQL)
+			repos.AttemptRepo,             // AssessmentStats (PostgreSQL) - migrado de MongoDB
+			repos.ProgressRepository,      // ProgressStats (PostgreSQL)
+			repos.MaterialViewRepository,  // MaterialViewRepository (MongoDB)
+			repos.StatsSnapshotRepository, // Snapshot de estadísticas globales (PostgreSQL)
+			service.GlobalStatsCacheConfig{
+				CacheTTL:       statsConfig.CacheTTL,
+				SnapshotMaxAge: statsConfig.SnapshotMaxAge,
+			},
+		),
+
+		// FailedEventService inspecciona y re-publica eventos del dead-letter store
+		// Re-publica solo por el transporte del bus: los suscriptores locales ya recibieron el evento,
+		// y sin dead-letter un replay fallido actualiza el registro existente
+		FailedEventService: service.NewFailedEventService(
+			repos.FailedEventRepository,
+			eventbus.NewTransportPublisher(infra.EventBus.Transport()),
+			infra.Logger,
+		),
+
+		// ReportService arma libros de calificaciones por unidad académica
+		ReportService: service.NewReportService(
+			repos.UnitReportRepository,
+			infra.Logger,
 		),
 
-		// ScreenService gestiona definiciones de pantalla dinámicas (Dynamic UI - Phase 2)
-		ScreenService: service.NewScreenService(
-			repos.ScreenRepository,
-			repos.ResourceReader,
+		// ActivityService construye series diarias de actividad y rachas
+		// Se alimenta de los eventos de progreso del bus (ver NewContainer)
+		ActivityService: service.NewActivityService(
+			repos.LearningActivityRepository,
 			infra.Logger,
 		),
+
+		// ReviewService programa repasos SM-2 de preguntas falladas y glosarios
+		// Se alimenta de los eventos de intentos y materiales completados (ver NewContainer)
+		ReviewService: service.NewReviewService(
+			repos.ReviewItemRepository,
+			repos.AnswerRepo,
+			repos.AssessmentRepoV2,
+			repos.AssessmentDocumentRepo,
+			repos.SummaryRepository,
+			infra.Logger,
+		),
+	}
+
+	// ScreenService gestiona definiciones de pantalla dinámicas (Dynamic UI - Phase 2)
+	// Cache LRU por instancia, invalidado por screen.updated (ver NewContainer)
+	// Con ?include=data resuelve en proceso los endpoints de materiales, progreso e intentos
+	// Los experimentos A/B eligen la instancia a servir según usuario, plataforma, escuela y rol
+	// Slots y etiquetas de menú se traducen al idioma negociado con Accept-Language
+	services.ScreenService = service.NewScreenServiceWithCache(
+		repos.ScreenRepository,
+		repos.ResourceReader,
+		screenCacheConfig,
+		service.ScreenServiceOptions{
+			Data: service.NewDefaultScreenDataRegistry(
+				services.MaterialService,
+				services.ProgressService,
+				services.AssessmentAttemptService,
+			),
+			Experiments:  repos.ScreenExperimentRepository,
+			Translations: repos.ScreenTranslationRepository,
+		},
+		infra.Logger,
+	)
+
+	// SyncService aplica lotes offline reutilizando los servicios de progreso e intentos
+	services.SyncService = service.NewSyncService(
+		services.ProgressService,
+		services.AssessmentAttemptService,
+		repos.ProgressRepository,
+		repos.SyncOperationRepository,
+		infra.Logger,
+	)
+
+	services.StatsSnapshotScheduler = service.NewStatsSnapshotScheduler(
+		services.StatsService,
+		statsConfig.RefreshInterval,
+		statsConfig.EventDebounce,
+		infra.Logger,
+	)
+
+	services.MaterialViewRecorder = service.NewMaterialViewRecorder(services.StatsService, 0, infra.Logger)
+
+	return services
+}
+
+// Close detiene los procesos en segundo plano de los servicios
+func (sc *ServiceContainer) Close() {
+	if sc.StatsSnapshotScheduler != nil {
+		sc.StatsSnapshotScheduler.Stop()
+	}
+	if sc.MaterialViewRecorder != nil {
+		sc.MaterialViewRecorder.Stop()
 	}
 }
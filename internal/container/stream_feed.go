@@ -0,0 +1,70 @@
+package container
+
+import (
+	"errors"
+	"io"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/bootstrap"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/realtime"
+)
+
+// eventsExchange es el exchange donde los servicios publican progreso, materiales completados e intentos
+const eventsExchange = "edugo.events"
+
+// startStreamFeed alimenta el hub del stream desde RabbitMQ para que cada instancia reciba
+// también los eventos publicados por otras instancias y por el worker, no solo los de su bus local
+// Retorna nil si el bus no usa RabbitMQ (transport inprocess/file, mocks o sin conexión):
+// en ese caso el hub solo ve los eventos del proceso
+func startStreamFeed(resources *bootstrap.Resources, bus eventbus.EventBus, hub *realtime.Hub) io.Closer {
+	cfg := resources.Config
+	if cfg == nil || cfg.Development.UseMockRepositories {
+		return nil
+	}
+	if bus.Transport().Name() != eventbus.TransportAMQP || cfg.Messaging.RabbitMQ.URL == "" {
+		return nil
+	}
+
+	exchanges := []string{eventsExchange}
+	if materials := cfg.Messaging.RabbitMQ.Exchanges.Materials; materials != "" && materials != eventsExchange {
+		exchanges = append(exchanges, materials)
+	}
+
+	feed := make(closers, 0, len(exchanges))
+	for _, exchange := range exchanges {
+		// Los eventos publicados mientras no hay cola se pierden para el stream;
+		// los clientes ya deben tolerar desconexiones y reconsultar al reconectar
+		subscriber := rabbitmq.NewBroadcastSubscriber(
+			cfg.Messaging.RabbitMQ.URL,
+			rabbitmq.SubscriberConfig{
+				Exchange:    exchange,
+				BindingKeys: realtime.BindingKeys(),
+				Reconnect: rabbitmq.ReconnectConfig{
+					InitialInterval: cfg.Messaging.RabbitMQ.Reconnect.InitialInterval,
+					MaxInterval:     cfg.Messaging.RabbitMQ.Reconnect.MaxInterval,
+				},
+			},
+			hub.HandleDelivery,
+			resources.Logger,
+		)
+		subscriber.Start()
+		feed = append(feed, subscriber)
+	}
+
+	resources.Logger.Info("stream alimentado desde RabbitMQ", "exchanges", exchanges)
+	return feed
+}
+
+// closers cierra varios recursos como uno solo
+type closers []io.Closer
+
+func (c closers) Close() error {
+	var errs []error
+	for _, closer := range c {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
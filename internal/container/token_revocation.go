@@ -0,0 +1,63 @@
+package container
+
+import (
+	"io"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/bootstrap"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/client"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+)
+
+// tokenRevokedRoutingKey es el evento que api-admin publica en logout o al cerrar todas las sesiones
+const tokenRevokedRoutingKey = "token.revoked"
+
+// startTokenRevocation conecta la lista de tokens revocados del AuthClient con la fuente
+// configurada en config.TokenRevocationConfig.Source
+// Retorna nil si no hay fuente remota (source=none, mocks o sin RabbitMQ); en ese caso los
+// tokens revocados siguen siendo válidos hasta expirar. Un fallo no impide arrancar la aplicación
+func startTokenRevocation(resources *bootstrap.Resources, authClient *client.AuthClient, repo repository.TokenRevocationRepository) io.Closer {
+	cfg := resources.Config
+	if cfg == nil || cfg.Development.UseMockRepositories {
+		return nil
+	}
+	log := resources.Logger
+	revocation := cfg.Auth.Revocation
+
+	switch revocation.Source {
+	case config.TokenRevocationNone:
+		return nil
+
+	case config.TokenRevocationPostgres:
+		poller := client.NewRevocationPoller(repo, authClient, revocation.PollInterval, log)
+		poller.Start()
+		log.Info("revocación de tokens por consulta a PostgreSQL", "poll_interval", revocation.PollInterval)
+		return poller
+
+	default:
+		if resources.RabbitMQPublisher == nil || cfg.Messaging.RabbitMQ.URL == "" {
+			log.Warn("RabbitMQ no disponible, los tokens revocados serán válidos hasta expirar")
+			return nil
+		}
+
+		// Las revocaciones publicadas mientras no hay conexión se pierden: esos tokens
+		// siguen siendo válidos hasta expirar (source=postgres no tiene esa limitación)
+		subscriber := rabbitmq.NewBroadcastSubscriber(
+			cfg.Messaging.RabbitMQ.URL,
+			rabbitmq.SubscriberConfig{
+				Exchange:    revocation.Exchange,
+				BindingKeys: []string{tokenRevokedRoutingKey},
+				Reconnect: rabbitmq.ReconnectConfig{
+					InitialInterval: cfg.Messaging.RabbitMQ.Reconnect.InitialInterval,
+					MaxInterval:     cfg.Messaging.RabbitMQ.Reconnect.MaxInterval,
+				},
+			},
+			authClient.HandleTokenRevoked,
+			log,
+		)
+		subscriber.Start()
+		log.Info("revocación de tokens por RabbitMQ", "exchange", revocation.Exchange, "routing_key", tokenRevokedRoutingKey)
+		return subscriber
+	}
+}
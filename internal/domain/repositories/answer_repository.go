@@ -19,4 +19,20 @@ type AnswerRepository interface {
 	// FindByQuestionID busca todas las respuestas para una pregunta específica
 	// Útil para analytics: identificar preguntas difíciles
 	FindByQuestionID(ctx context.Context, questionID string, limit, offset int) ([]*pgentities.AssessmentAttemptAnswer, error)
+
+	// FindItemResponses retorna las respuestas del primer intento completado de cada estudiante
+	// en una evaluación, junto al puntaje total del intento. Base del análisis de ítems
+	FindItemResponses(ctx context.Context, assessmentID uuid.UUID) ([]*ItemResponse, error)
+}
+
+// ItemResponse respuesta a una pregunta con el puntaje total del intento al que pertenece
+// El puntaje del intento permite separar grupos superior/inferior (índice de discriminación)
+type ItemResponse struct {
+	AttemptID        uuid.UUID
+	StudentID        uuid.UUID
+	AttemptScore     float64
+	QuestionIndex    int
+	StudentAnswer    string
+	IsCorrect        bool
+	TimeSpentSeconds *int
 }
@@ -31,4 +31,23 @@ type AssessmentStats interface {
 
 	// CalculateAverageScore calcula el promedio de puntajes de evaluaciones completadas
 	CalculateAverageScore(ctx context.Context) (float64, error)
+
+	// GetMaterialAttemptStats agrega los intentos completados de la evaluación de un material
+	GetMaterialAttemptStats(ctx context.Context, materialID uuid.UUID) (*MaterialAttemptStats, error)
+}
+
+// ScoreHistogramBuckets cantidad de rangos del histograma de puntajes (0-10, 10-20, ..., 90-100)
+const ScoreHistogramBuckets = 10
+
+// DefaultPassThreshold umbral de aprobación cuando la evaluación no define pass_threshold
+const DefaultPassThreshold = 60
+
+// MaterialAttemptStats agregados de los intentos completados sobre un material
+type MaterialAttemptStats struct {
+	TotalAttempts          int64
+	UniqueStudents         int64
+	PassedStudents         int64   // Estudiantes con al menos un intento aprobado
+	AvgScore               float64 // Puntaje promedio (0-100)
+	MedianTimeSpentSeconds float64
+	ScoreHistogram         [ScoreHistogramBuckets]int64 // Intentos por rango de 10 puntos; 100 cuenta en el último
 }
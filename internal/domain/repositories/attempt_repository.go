@@ -33,4 +33,7 @@ type AttemptRepository interface {
 
 	// CalculateAverageScore calcula el promedio de puntajes de evaluaciones completadas
 	CalculateAverageScore(ctx context.Context) (float64, error)
+
+	// GetMaterialAttemptStats agrega los intentos completados de la evaluación de un material
+	GetMaterialAttemptStats(ctx context.Context, materialID uuid.UUID) (*MaterialAttemptStats, error)
 }
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// FailedEventStatus estado de un evento en el dead-letter store
+type FailedEventStatus string
+
+const (
+	// FailedEventStatusPending evento fallido pendiente de revisión o replay
+	FailedEventStatusPending FailedEventStatus = "pending"
+	// FailedEventStatusReplayed evento re-publicado exitosamente
+	FailedEventStatusReplayed FailedEventStatus = "replayed"
+)
+
+// FailedEvent representa un evento que no pudo publicarse o procesarse (dead-letter)
+// Payload es nil cuando la falla ocurrió al serializar el evento
+type FailedEvent struct {
+	ID            string
+	Exchange      string
+	RoutingKey    string
+	Payload       []byte
+	Error         string
+	Attempts      int
+	Status        FailedEventStatus
+	FirstFailedAt time.Time
+	LastFailedAt  time.Time
+	ReplayedAt    *time.Time
+}
+
+// FailedEventFilter filtros para listar eventos fallidos
+type FailedEventFilter struct {
+	Status     FailedEventStatus // "" = todos
+	RoutingKey string            // "" = todos
+	Limit      int
+	Offset     int
+}
+
+// FailedEventReader define operaciones de lectura del dead-letter store
+type FailedEventReader interface {
+	// FindByID busca un evento fallido por ID (nil si no existe)
+	FindByID(ctx context.Context, id string) (*FailedEvent, error)
+
+	// List lista eventos fallidos ordenados por última falla descendente
+	// Retorna también el total de eventos que cumplen el filtro (sin paginar)
+	List(ctx context.Context, filter FailedEventFilter) ([]*FailedEvent, int64, error)
+}
+
+// FailedEventWriter define operaciones de escritura del dead-letter store
+type FailedEventWriter interface {
+	// Save registra un nuevo evento fallido
+	Save(ctx context.Context, event *FailedEvent) error
+
+	// Update actualiza intentos, error y estado de un evento fallido
+	Update(ctx context.Context, event *FailedEvent) error
+}
+
+// FailedEventRepository agrega todas las capacidades del dead-letter store
+type FailedEventRepository interface {
+	FailedEventReader
+	FailedEventWriter
+}
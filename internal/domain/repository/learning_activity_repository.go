@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ActivitySessionGap es la pausa máxima entre dos actualizaciones de progreso que se cuenta como estudio
+// Pausas más largas se consideran el inicio de una nueva sesión y no suman minutos
+const ActivitySessionGap = 15 * time.Minute
+
+// ProgressActivity registra una actualización de progreso (append-only)
+// EventID es el ID del evento de origen y hace idempotente el registro ante replays
+type ProgressActivity struct {
+	EventID    string
+	UserID     string
+	SchoolID   string
+	MaterialID string
+	Percentage int
+	OccurredAt time.Time
+}
+
+// ActivityQuery filtra la serie diaria de actividad
+// UserID y SchoolID son opcionales; vacíos significan "todos"
+// Los días se cortan en la zona horaria Location
+type ActivityQuery struct {
+	UserID   string
+	SchoolID string
+	From     time.Time // Inclusivo
+	To       time.Time // Exclusivo
+	Location *time.Location
+}
+
+// DailyActivity agregados de un día con actividad
+// Day usa formato YYYY-MM-DD en la zona horaria de la consulta
+type DailyActivity struct {
+	Day              string
+	StudySeconds     int64
+	MaterialsTouched int
+	Attempts         int
+	ActiveUsers      int
+}
+
+// LearningActivityWriter define operaciones de escritura del registro de actividad
+type LearningActivityWriter interface {
+	// RecordProgressActivity agrega una actualización de progreso; ignora eventos ya registrados
+	RecordProgressActivity(ctx context.Context, activity *ProgressActivity) error
+}
+
+// LearningActivityStats define consultas de series de actividad
+// La actividad combina actualizaciones de progreso e intentos de evaluación completados
+type LearningActivityStats interface {
+	// GetDailyActivity retorna solo los días con actividad, en orden ascendente
+	GetDailyActivity(ctx context.Context, query ActivityQuery) ([]DailyActivity, error)
+
+	// ListActiveDays retorna todos los días (YYYY-MM-DD) con actividad del usuario, en orden ascendente
+	ListActiveDays(ctx context.Context, userID string, location *time.Location) ([]string, error)
+}
+
+// LearningActivityRepository agrega todas las capacidades del registro de actividad
+type LearningActivityRepository interface {
+	LearningActivityWriter
+	LearningActivityStats
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// LearningPath secuencia ordenada de materiales de una escuela
+// Si AcademicUnitID no es nil, la ruta solo aplica a esa unidad académica
+type LearningPath struct {
+	ID             string
+	SchoolID       string
+	AcademicUnitID *string
+	Title          string
+	Description    string
+	CreatedBy      string
+	Steps          []LearningPathStep // Ordenados por Position
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// LearningPathStep material de una ruta con sus requisitos de desbloqueo
+// Un paso se completa al leer el material al 100% y, si MinScore no es nil,
+// al obtener al menos MinScore en su evaluación
+type LearningPathStep struct {
+	Position      int // 1..n
+	MaterialID    string
+	MaterialTitle string // Solo lectura
+	MinScore      *int
+	Prerequisites []string // IDs de materiales de pasos anteriores que deben estar completos
+}
+
+// LearningPathStepResult progreso y mejor intento de un usuario en el material de un paso
+// BestScore es nil si el usuario no completó intentos de la evaluación
+type LearningPathStepResult struct {
+	MaterialID string
+	Percentage int
+	BestScore  *float64
+}
+
+// LearningPathReader define operaciones de lectura para rutas de aprendizaje
+type LearningPathReader interface {
+	// FindByID obtiene una ruta con sus pasos; retorna nil si no existe
+	FindByID(ctx context.Context, id string) (*LearningPath, error)
+
+	// ListBySchool lista las rutas de una escuela con sus pasos, ordenadas por título
+	ListBySchool(ctx context.Context, schoolID string) ([]*LearningPath, error)
+
+	// ListByMaterial lista las rutas que contienen un material, con sus pasos
+	ListByMaterial(ctx context.Context, materialID string) ([]*LearningPath, error)
+
+	// GetStepResults obtiene el progreso y mejor puntaje del usuario en los materiales indicados
+	// Los materiales sin actividad no aparecen en el resultado
+	GetStepResults(ctx context.Context, userID string, materialIDs []string) ([]LearningPathStepResult, error)
+}
+
+// LearningPathWriter define operaciones de escritura para rutas de aprendizaje
+type LearningPathWriter interface {
+	// Create persiste la ruta y sus pasos en una transacción
+	Create(ctx context.Context, path *LearningPath) error
+
+	// Update actualiza título, descripción y unidad, y reemplaza los pasos
+	Update(ctx context.Context, path *LearningPath) error
+
+	// Delete elimina la ruta; retorna false si no existía
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+// LearningPathRepository agrega lectura y escritura de rutas de aprendizaje (PostgreSQL)
+type LearningPathRepository interface {
+	LearningPathReader
+	LearningPathWriter
+}
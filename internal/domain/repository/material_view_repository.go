@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// MaterialView registra una apertura de material por un usuario (append-only)
+type MaterialView struct {
+	MaterialID string
+	UserID     string
+	SchoolID   string
+	ViewedAt   time.Time
+}
+
+// MaterialViewCounts agregados de visualizaciones de un material
+type MaterialViewCounts struct {
+	TotalViews    int64
+	UniqueViewers int64
+}
+
+// MaterialViewWriter define operaciones de escritura del tracking de visualizaciones
+type MaterialViewWriter interface {
+	// RecordView agrega una visualización
+	RecordView(ctx context.Context, view *MaterialView) error
+}
+
+// MaterialViewStats define operaciones de estadísticas de visualizaciones
+type MaterialViewStats interface {
+	// CountViews cuenta visualizaciones totales y usuarios únicos de un material
+	CountViews(ctx context.Context, materialID string) (*MaterialViewCounts, error)
+}
+
+// MaterialViewRepository agrega todas las capacidades del tracking de visualizaciones
+type MaterialViewRepository interface {
+	MaterialViewWriter
+	MaterialViewStats
+}
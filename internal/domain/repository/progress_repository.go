@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
 	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
@@ -11,6 +12,54 @@ import (
 // Principio ISP: Separar lectura de escritura y estadísticas
 type ProgressReader interface {
 	FindByMaterialAndUser(ctx context.Context, materialID valueobject.MaterialID, userID valueobject.UserID) (*pgentities.Progress, error)
+
+	// ListByUser lista los materiales iniciados por un usuario con su mejor intento de evaluación
+	// Ordenado por último acceso (más reciente primero). Retorna la página y el total filtrado
+	ListByUser(ctx context.Context, userID valueobject.UserID, filter UserProgressFilter) ([]*UserMaterialProgress, int64, error)
+
+	// ListChangedSince lista el progreso del usuario modificado en el servidor después del cursor,
+	// ordenado por (updated_at, material_id). Solo incluye cambios con más de ProgressChangeSafetyLag
+	// de antigüedad según el reloj de la base. Usado por la sincronización offline
+	ListChangedSince(ctx context.Context, userID valueobject.UserID, after ProgressCursor, limit int) ([]*pgentities.Progress, error)
+}
+
+// ProgressChangeSafetyLag margen antes de exponer un cambio en el feed de sincronización
+// updated_at es NOW() de la transacción que escribe, que puede confirmarse después de otra con
+// un NOW() mayor; sin el margen un cursor podría saltear la fila confirmada tarde
+const ProgressChangeSafetyLag = 10 * time.Second
+
+// ProgressCursor posición en el feed de cambios de progreso de un usuario
+// UpdatedAt lo asigna la base (no el reloj de cada instancia). El valor cero representa el inicio del feed
+type ProgressCursor struct {
+	UpdatedAt  time.Time
+	MaterialID string
+}
+
+// Filtros de estado para el progreso de un usuario
+const (
+	UserProgressStatusInProgress = "in_progress"
+	UserProgressStatusCompleted  = "completed"
+)
+
+// UserProgressFilter filtros para listar el progreso de un usuario
+type UserProgressFilter struct {
+	Status string // "" = todos, UserProgressStatusInProgress o UserProgressStatusCompleted
+	Limit  int
+	Offset int
+}
+
+// UserMaterialProgress progreso de un usuario en un material junto a su mejor intento
+// BestScore y Passed son nil si el usuario no completó ningún intento de la evaluación
+type UserMaterialProgress struct {
+	MaterialID     string
+	MaterialTitle  string
+	Percentage     int
+	LastPage       int
+	Status         string
+	LastAccessedAt time.Time
+	AttemptsCount  int
+	BestScore      *float64
+	Passed         *bool
 }
 
 // ProgressWriter define operaciones de escritura para Progress
@@ -18,7 +67,12 @@ type ProgressWriter interface {
 	Save(ctx context.Context, progress *pgentities.Progress) error
 	Update(ctx context.Context, progress *pgentities.Progress) error
 	// Upsert realiza INSERT o UPDATE idempotente usando ON CONFLICT de PostgreSQL
+	// El porcentaje es monótono: nunca baja respecto del registrado (ver Reset).
+	// last_page sigue last-writer-wins según LastAccessedAt (hora del cliente);
+	// updated_at lo asigna la base (NOW()) e ignora progress.UpdatedAt
 	Upsert(ctx context.Context, progress *pgentities.Progress) (*pgentities.Progress, error)
+	// Reset lleva el progreso a 0 (not_started); retorna nil si el usuario no tenía progreso
+	Reset(ctx context.Context, materialID valueobject.MaterialID, userID valueobject.UserID) (*pgentities.Progress, error)
 }
 
 // ProgressStats define operaciones de estadísticas para Progress
@@ -28,6 +82,16 @@ type ProgressStats interface {
 
 	// CalculateAverageProgress calcula el promedio de progreso de todos los usuarios
 	CalculateAverageProgress(ctx context.Context) (float64, error)
+
+	// GetMaterialProgressStats agrega el progreso de todos los usuarios en un material
+	GetMaterialProgressStats(ctx context.Context, materialID valueobject.MaterialID) (*MaterialProgressStats, error)
+}
+
+// MaterialProgressStats agregados de progreso de un material
+type MaterialProgressStats struct {
+	Learners    int64   // Usuarios con progreso registrado en el material
+	Completed   int64   // Usuarios con progreso 100%
+	AvgProgress float64 // Promedio de porcentaje entre los Learners
 }
 
 // ProgressRepository agrega todas las capacidades de Progress
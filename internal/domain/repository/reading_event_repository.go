@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Tipos de eventos de lectura
+const (
+	ReadingEventPage    = "page"    // Página vista (PDF, documento paginado)
+	ReadingEventSection = "section" // Sección vista (contenido estructurado)
+	ReadingEventVideo   = "video"   // Posición de reproducción de un video
+	ReadingEventReset   = "reset"   // Reinicio explícito del progreso; solo cuentan los eventos posteriores
+)
+
+// VideoCoverageBucketSeconds tamaño de los tramos en que se mide la cobertura de un video
+const VideoCoverageBucketSeconds = 10
+
+// Motivos de marca de actividad de lectura sospechosa
+const (
+	ReadingFlagRapidJump = "rapid_jump" // El porcentaje subió mucho con poco tiempo en pantalla
+)
+
+// ReadingEvent evento de lectura reportado por el cliente
+// Los eventos son append-only y se deduplican por (UserID, ClientEventID)
+type ReadingEvent struct {
+	ClientEventID   string
+	UserID          string
+	MaterialID      string
+	SchoolID        string
+	Kind            string
+	Page            int    // Solo ReadingEventPage (1..n)
+	SectionID       string // Solo ReadingEventSection
+	PositionSeconds int    // Solo ReadingEventVideo: posición al emitir el evento
+	DwellSeconds    int    // Tiempo en pantalla (o reproducido) acreditado por el servidor
+	OccurredAt      time.Time
+	ReceivedAt      time.Time // Hora del servidor al recibir el lote
+}
+
+// ReadingExtent tamaño del contenido de un material, usado para derivar el porcentaje
+// Lo calcula el procesamiento del material (nunca el cliente). Un valor 0 significa desconocido
+type ReadingExtent struct {
+	TotalPages      int
+	TotalSections   int
+	DurationSeconds int
+}
+
+// ReadingCoverage agregado de los eventos de un usuario en un material desde el último reinicio
+type ReadingCoverage struct {
+	PagesViewed       int // Páginas distintas vistas
+	SectionsViewed    int // Secciones distintas vistas
+	WatchedSeconds    int // Segundos distintos de video cubiertos (en tramos de VideoCoverageBucketSeconds)
+	TimeOnTaskSeconds int // Suma de DwellSeconds
+	LastPage          int // Página del evento más reciente (0 si no hay eventos de página)
+}
+
+// ReadingFlag marca de actividad sospechosa para revisión docente
+// No invalida el progreso: queda registrada junto al salto observado
+type ReadingFlag struct {
+	UserID            string
+	MaterialID        string
+	SchoolID          string
+	Reason            string
+	FromPercentage    int
+	ToPercentage      int
+	TimeOnTaskSeconds int
+	FlaggedAt         time.Time
+}
+
+// ReadingEventWriter define operaciones de escritura de eventos de lectura
+type ReadingEventWriter interface {
+	// AppendReadingEvents agrega eventos ignorando los ya registrados; retorna cuántos se insertaron
+	AppendReadingEvents(ctx context.Context, events []ReadingEvent) (int, error)
+
+	// RecordReadingFlag registra una marca de actividad sospechosa
+	RecordReadingFlag(ctx context.Context, flag ReadingFlag) error
+}
+
+// ReadingEventReader define operaciones de lectura de eventos de lectura
+type ReadingEventReader interface {
+	// GetReadingCoverage agrega los eventos de un usuario en un material posteriores al último reinicio
+	GetReadingCoverage(ctx context.Context, userID, materialID string) (*ReadingCoverage, error)
+
+	// GetMaterialExtent retorna el tamaño del contenido del material; sin datos retorna todo en 0
+	GetMaterialExtent(ctx context.Context, materialID string) (*ReadingExtent, error)
+
+	// LastReadingEventReceivedAt retorna cuándo recibió el servidor el último evento del usuario
+	// en el material, incluido un reinicio; nil si no hay eventos
+	LastReadingEventReceivedAt(ctx context.Context, userID, materialID string) (*time.Time, error)
+}
+
+// ReadingEventRepository agrega todas las capacidades de eventos de lectura
+type ReadingEventRepository interface {
+	ReadingEventWriter
+	ReadingEventReader
+}
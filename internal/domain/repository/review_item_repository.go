@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Tipos de ítem de repaso
+const (
+	ReviewItemQuestion = "question" // Pregunta de evaluación respondida incorrectamente
+	ReviewItemGlossary = "glossary" // Término del glosario de un material completado
+)
+
+// ReviewItem ítem de repaso espaciado de un estudiante (algoritmo SM-2)
+// SourceKey identifica el origen dentro del tipo ("<assessment_id>:<question_id>" o el término)
+// y es único por usuario: un mismo origen nunca se encola dos veces
+type ReviewItem struct {
+	ID             string
+	UserID         string
+	MaterialID     string
+	Kind           string
+	SourceKey      string
+	Prompt         string
+	Answer         string
+	Options        []ReviewOption
+	Explanation    string
+	EaseFactor     float64
+	IntervalDays   int
+	Repetitions    int
+	Lapses         int
+	DueAt          time.Time
+	LastReviewedAt *time.Time
+	CreatedAt      time.Time // Hora del evento que originó el ítem
+}
+
+// ReviewOption opción de una pregunta de repaso de opción múltiple
+type ReviewOption struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// ReviewGrade calificación de recuerdo registrada en el historial de repasos
+type ReviewGrade struct {
+	ItemID     string
+	UserID     string
+	Grade      int // 0-5 según SM-2
+	ReviewedAt time.Time
+}
+
+// ReviewItemRepository define la cola de repaso espaciado por estudiante (PostgreSQL)
+type ReviewItemRepository interface {
+	// EnqueueReviewItems inserta ítems nuevos y retorna cuántos se insertaron
+	// Una pregunta ya encolada vuelve a aprenderse (repeticiones e intervalo en 0, vence a más tardar
+	// en su nuevo DueAt) solo si no se repasó desde CreatedAt, la hora del evento: la reentrega de un
+	// evento viejo no deshace un repaso posterior. Un término de glosario existente no cambia
+	EnqueueReviewItems(ctx context.Context, items []*ReviewItem) (int, error)
+
+	// ListDueReviewItems lista los ítems vencidos a la fecha indicada, del más atrasado al más reciente
+	ListDueReviewItems(ctx context.Context, userID string, now time.Time, limit int) ([]*ReviewItem, error)
+
+	// CountDueReviewItems cuenta los ítems vencidos a la fecha indicada
+	CountDueReviewItems(ctx context.Context, userID string, now time.Time) (int, error)
+
+	// FindReviewItem obtiene un ítem del usuario; retorna nil si no existe o es de otro usuario
+	FindReviewItem(ctx context.Context, userID, itemID string) (*ReviewItem, error)
+
+	// SaveReview guarda la nueva programación del ítem y agrega la calificación al historial
+	SaveReview(ctx context.Context, item *ReviewItem, grade ReviewGrade) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import "context"
+
+// ScreenExperiment experimento A/B sobre una pantalla: reparte a los usuarios entre variantes
+// Un targeting vacío incluye a todos los valores de esa dimensión
+type ScreenExperiment struct {
+	ID        string
+	Key       string // Identificador estable; junto al usuario define la asignación
+	ScreenKey string // Pantalla solicitada por el cliente
+	Platforms []string
+	SchoolIDs []string
+	Roles     []string
+	Variants  []ScreenExperimentVariant
+}
+
+// ScreenExperimentVariant variante de un experimento con su peso de tráfico
+type ScreenExperimentVariant struct {
+	Key    string
+	Weight int
+	// ScreenKey instancia que se sirve para la variante; vacío = la pantalla original (control)
+	ScreenKey string
+}
+
+// ScreenExperimentRepository define operaciones de lectura para experimentos de pantallas
+type ScreenExperimentRepository interface {
+	// GetActiveExperiments retorna los experimentos activos y vigentes con sus variantes,
+	// ordenados por prioridad (el primero cuyo targeting aplica gana)
+	GetActiveExperiments(ctx context.Context) ([]*ScreenExperiment, error)
+}
@@ -34,6 +34,16 @@ type ResourceScreenInfo struct {
 	IsDefault   bool
 }
 
+// ScreenPreferencesHistoryLimit versiones anteriores de preferencias que se conservan por usuario y pantalla
+const ScreenPreferencesHistoryLimit = 10
+
+// ScreenPreferencesVersion versión anterior de las preferencias de un usuario para una pantalla
+type ScreenPreferencesVersion struct {
+	Preferences json.RawMessage
+	SavedAt     time.Time // Cuándo se guardó esta versión
+	ReplacedAt  time.Time // Cuándo la reemplazó otra versión o un reset
+}
+
 // ScreenRepository define operaciones de lectura para pantallas
 type ScreenRepository interface {
 	// GetCombinedScreen carga template + instancia + preferencias de usuario en una sola consulta
@@ -46,5 +56,12 @@ type ScreenRepository interface {
 	GetUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID) (json.RawMessage, error)
 
 	// SaveUserPreferences guarda las preferencias especificas del usuario
+	// La versión anterior pasa al historial (se conservan las últimas ScreenPreferencesHistoryLimit)
 	SaveUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID, prefs json.RawMessage) error
+
+	// DeleteUserPreferences elimina las preferencias del usuario archivando la versión actual
+	DeleteUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID) error
+
+	// GetUserPreferencesHistory retorna las versiones anteriores, más recientes primero
+	GetUserPreferencesHistory(ctx context.Context, screenKey string, userID uuid.UUID) ([]*ScreenPreferencesVersion, error)
 }
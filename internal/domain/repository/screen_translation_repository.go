@@ -0,0 +1,14 @@
+package repository
+
+import "context"
+
+// ScreenTranslationRepository define operaciones de lectura para traducciones de pantallas y menú
+// El contenido original (i18n.DefaultLocale) vive en slot_data y en resources.display_name;
+// una clave sin traducción se sirve en el idioma original
+type ScreenTranslationRepository interface {
+	// GetSlotTranslations retorna los textos traducidos de los slots de una pantalla: slotKey -> texto
+	GetSlotTranslations(ctx context.Context, screenKey, locale string) (map[string]string, error)
+
+	// GetResourceTranslations retorna las etiquetas de menú traducidas: resourceKey -> texto
+	GetResourceTranslations(ctx context.Context, locale string) (map[string]string, error)
+}
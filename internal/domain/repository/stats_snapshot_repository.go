@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// GlobalStatsSnapshot estadísticas globales precalculadas
+// GeneratedAt indica cuándo se ejecutaron las queries de agregación
+type GlobalStatsSnapshot struct {
+	TotalPublishedMaterials   int64
+	TotalCompletedAssessments int64
+	AverageAssessmentScore    float64
+	ActiveUsersLast30Days     int64
+	AverageProgress           float64
+	GeneratedAt               time.Time
+}
+
+// StatsSnapshotRepository persiste el último snapshot de estadísticas globales
+// El snapshot se comparte entre instancias para que solo una ejecute las agregaciones costosas
+type StatsSnapshotRepository interface {
+	// GetGlobalSnapshot retorna el último snapshot o nil si aún no se generó ninguno
+	GetGlobalSnapshot(ctx context.Context) (*GlobalStatsSnapshot, error)
+
+	// SaveGlobalSnapshot reemplaza el snapshot; ignora snapshots más viejos que el guardado
+	SaveGlobalSnapshot(ctx context.Context, snapshot *GlobalStatsSnapshot) error
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Estados de una operación de sincronización
+const (
+	SyncStatusPending  = "pending"  // Reclamada, aplicándose
+	SyncStatusApplied  = "applied"  // Aplicada; Result guarda la respuesta
+	SyncStatusRejected = "rejected" // Rechazada de forma permanente (validación, no encontrado, permisos)
+)
+
+// SyncClaimTimeout antigüedad a partir de la cual un reclamo pendiente se considera abandonado
+// (instancia caída a mitad de un lote) y puede volver a reclamarse
+const SyncClaimTimeout = 5 * time.Minute
+
+// SyncOperation registro de un ítem de sincronización offline, identificado por el ID del cliente
+// Permite responder lo mismo ante reenvíos sin volver a aplicar el ítem
+type SyncOperation struct {
+	UserID       string
+	ClientID     string
+	Type         string
+	Status       string
+	Result       json.RawMessage
+	ErrorCode    string
+	ErrorMessage string
+	ClaimedAt    time.Time
+}
+
+// SyncOperationRepository registro idempotente de operaciones de sincronización
+type SyncOperationRepository interface {
+	// ClaimSyncOperation reserva (UserID, ClientID) para aplicarlo.
+	// Retorna nil si el reclamo fue exitoso, o la operación ya registrada en caso contrario
+	ClaimSyncOperation(ctx context.Context, op *SyncOperation) (*SyncOperation, error)
+
+	// CompleteSyncOperation guarda el resultado final (applied o rejected) de una operación reclamada
+	CompleteSyncOperation(ctx context.Context, op *SyncOperation) error
+
+	// ReleaseSyncOperation libera un reclamo tras un error transitorio para que el cliente pueda reintentar
+	ReleaseSyncOperation(ctx context.Context, userID, clientID string) error
+}
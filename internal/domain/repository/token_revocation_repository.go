@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocation revocación de access tokens registrada por api-admin
+// TokenID revoca un token (claim jti, p. ej. logout); UserID con IssuedBefore revoca todos los
+// tokens del usuario emitidos antes de esa fecha ("cerrar todas las sesiones", cambio de password)
+type TokenRevocation struct {
+	TokenID      string
+	UserID       string
+	IssuedBefore time.Time
+	// ExpiresAt a partir de aquí los tokens afectados ya expiraron y la revocación se puede olvidar
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+// TokenRevocationRepository define operaciones de lectura de las revocaciones de tokens
+// Las revocaciones las escribe api-admin; api-mobile solo las consulta
+type TokenRevocationRepository interface {
+	// GetRevocationsSince retorna las revocaciones vigentes registradas después de since,
+	// ordenadas por RevokedAt
+	GetRevocationsSince(ctx context.Context, since time.Time) ([]*TokenRevocation, error)
+}
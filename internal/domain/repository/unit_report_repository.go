@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// UnitReportRepository define lecturas agregadas para reportes de una unidad académica
+// Solo lectura: los reportes se calculan sobre progress, materials y assessment_attempt
+type UnitReportRepository interface {
+	// GetUnitGradebook obtiene materiales, estudiantes y resultados de una unidad académica
+	// Retorna nil si la unidad no existe
+	GetUnitGradebook(ctx context.Context, academicUnitID string) (*UnitGradebook, error)
+}
+
+// UnitGradebook datos crudos del libro de calificaciones de una unidad académica
+type UnitGradebook struct {
+	AcademicUnitID   string
+	AcademicUnitName string
+	SchoolID         string
+	Materials        []UnitReportMaterial // Materiales de la unidad, en orden de creación
+	Students         []UnitReportStudent  // Estudiantes activos de la unidad
+	Results          []UnitReportResult   // Solo pares estudiante/material con actividad
+}
+
+// UnitReportMaterial material de la unidad incluido en el reporte
+type UnitReportMaterial struct {
+	ID            string
+	Title         string
+	HasAssessment bool
+}
+
+// UnitReportStudent estudiante de la unidad incluido en el reporte
+type UnitReportStudent struct {
+	ID        string
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+// UnitReportResult progreso y mejor intento de un estudiante en un material
+// BestScore es nil si el estudiante no completó intentos de la evaluación
+type UnitReportResult struct {
+	StudentID      string
+	MaterialID     string
+	Percentage     int
+	LastAccessedAt *time.Time
+	AttemptsCount  int
+	BestScore      *float64
+	PassThreshold  int
+}
@@ -0,0 +1,114 @@
+// Package export serializa tablas de reportes a formatos descargables (CSV y XLSX)
+// XLSX se genera con archive/zip y SpreadsheetML mínimo para no agregar dependencias
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Content types de los formatos soportados
+const (
+	ContentTypeCSV  = "text/csv; charset=utf-8"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Table representa una tabla rectangular con encabezados
+// Los valores de Rows pueden ser string, int, int64, float64, bool, time.Time o nil (celda vacía);
+// punteros a esos tipos se desreferencian
+type Table struct {
+	Headers []string
+	Rows    [][]any
+}
+
+// WriteCSV escribe la tabla como CSV (RFC 4180)
+// Los textos que una planilla interpretaría como fórmula se prefijan con ' (CSV injection);
+// los encabezados también, porque pueden incluir títulos de materiales
+func WriteCSV(w io.Writer, t Table) error {
+	writer := csv.NewWriter(w)
+
+	record := make([]string, len(t.Headers))
+	for i, header := range t.Headers {
+		record[i] = csvCell(header)
+	}
+	if err := writer.Write(record); err != nil {
+		return fmt.Errorf("export: error writing csv header: %w", err)
+	}
+
+	for _, row := range t.Rows {
+		for i := range record {
+			record[i] = ""
+			if i < len(row) {
+				record[i] = csvCell(row[i])
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("export: error writing csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvCell(value any) string {
+	text, numeric := formatCell(value)
+	if !numeric && text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// formatCell convierte un valor a texto e indica si es numérico
+func formatCell(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, false
+	case *string:
+		if v == nil {
+			return "", false
+		}
+		return *v, false
+	case int:
+		return strconv.Itoa(v), true
+	case *int:
+		if v == nil {
+			return "", false
+		}
+		return strconv.Itoa(*v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case *float64:
+		if v == nil {
+			return "", false
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), false
+	case *bool:
+		if v == nil {
+			return "", false
+		}
+		return strconv.FormatBool(*v), false
+	case time.Time:
+		if v.IsZero() {
+			return "", false
+		}
+		return v.UTC().Format(time.RFC3339), false
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return "", false
+		}
+		return v.UTC().Format(time.RFC3339), false
+	default:
+		return fmt.Sprint(v), false
+	}
+}
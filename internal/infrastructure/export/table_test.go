@@ -0,0 +1,109 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleTable() Table {
+	score := 87.5
+	var missing *float64
+	return Table{
+		Headers: []string{"name", "progress", "score", "passed", "at"},
+		Rows: [][]any{
+			{"Ana <Pérez>", 100, &score, true, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)},
+			{"=HYPERLINK(\"x\")", 0, missing, nil, nil},
+		},
+	}
+}
+
+// TestWriteCSV verifica formato de celdas y la protección contra CSV injection
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, sampleTable()))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"name", "progress", "score", "passed", "at"}, records[0])
+	assert.Equal(t, []string{"Ana <Pérez>", "100", "87.5", "true", "2026-03-01T10:00:00Z"}, records[1])
+	assert.Equal(t, []string{"'=HYPERLINK(\"x\")", "0", "", "", ""}, records[2])
+}
+
+// TestWriteCSV_HeadersEscaped verifica que un título de material malicioso en el encabezado no se ejecute como fórmula
+func TestWriteCSV_HeadersEscaped(t *testing.T) {
+	var buf bytes.Buffer
+	table := Table{
+		Headers: []string{"student", "=cmd|' /C calc'!A0", "@SUM(1+1)"},
+		Rows:    [][]any{{"Ana", 80, 90}},
+	}
+	require.NoError(t, WriteCSV(&buf, table))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"student", "'=cmd|' /C calc'!A0", "'@SUM(1+1)"}, records[0])
+	assert.Equal(t, []string{"Ana", "80", "90"}, records[1])
+}
+
+// TestWriteCSV_NegativeNumbersNotEscaped verifica que los números negativos no se prefijen
+func TestWriteCSV_NegativeNumbersNotEscaped(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, Table{Headers: []string{"delta"}, Rows: [][]any{{-5}}}))
+
+	assert.Equal(t, "delta\n-5\n", buf.String())
+}
+
+// TestWriteXLSX verifica las partes del paquete y el contenido de la hoja
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteXLSX(&buf, "5to A: Ciencias", sampleTable()))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		_ = rc.Close()
+		parts[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/workbook.xml", "xl/worksheets/sheet1.xml"} {
+		assert.Contains(t, parts, name)
+	}
+	assert.Contains(t, parts["xl/workbook.xml"], `name="5to A- Ciencias"`)
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">Ana &lt;Pérez&gt;</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2"><v>100</v></c>`)
+	assert.Contains(t, sheet, `<c r="C2"><v>87.5</v></c>`)
+	// Las celdas vacías se omiten
+	assert.False(t, strings.Contains(sheet, `r="C3"`))
+}
+
+// TestColumnName verifica la conversión de índices a letras de columna
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for index, expected := range tests {
+		assert.Equal(t, expected, columnName(index))
+	}
+}
+
+// TestSanitizeSheetName verifica caracteres inválidos y largo máximo
+func TestSanitizeSheetName(t *testing.T) {
+	assert.Equal(t, "Sheet1", sanitizeSheetName(""))
+	assert.Equal(t, "a-b-c", sanitizeSheetName("a/b?c"))
+	assert.Len(t, []rune(sanitizeSheetName(strings.Repeat("x", 40))), maxSheetNameLength)
+}
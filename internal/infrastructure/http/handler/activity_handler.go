@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+// timezoneHeader permite a los clientes móviles enviar su zona horaria sin repetirla en cada query
+const timezoneHeader = "X-Timezone"
+
+type ActivityHandler struct {
+	activityService service.ActivityService
+	logger          logger.Logger
+}
+
+func NewActivityHandler(activityService service.ActivityService, logger logger.Logger) *ActivityHandler {
+	return &ActivityHandler{
+		activityService: activityService,
+		logger:          logger,
+	}
+}
+
+// GetMyActivity godoc
+// @Summary Get my learning activity
+// @Description Daily series of minutes studied, materials touched and attempts made by the authenticated user, plus current and longest streak of active days. Days are cut in the requested timezone
+// @Tags progress
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD), default 29 days before to"
+// @Param to query string false "Last day (YYYY-MM-DD), default today"
+// @Param tz query string false "IANA timezone (e.g. America/Bogota); falls back to the X-Timezone header, default UTC"
+// @Success 200 {object} dto.UserActivityResponse "User activity"
+// @Failure 400 {object} ErrorResponse "Invalid date range or timezone"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/users/me/activity [get]
+// @Security BearerAuth
+func (h *ActivityHandler) GetMyActivity(c *gin.Context) {
+	userID := ginmiddleware.MustGetUserID(c)
+
+	activity, err := h.activityService.GetUserActivity(c.Request.Context(), userID, c.Query("from"), c.Query("to"), requestTimezone(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, activity)
+}
+
+// GetActivityStats godoc
+// @Summary Get learning activity statistics
+// @Description Daily series of active users, minutes studied, materials touched and attempts for the whole system or a single school (solo admins)
+// @Tags stats
+// @Produce json
+// @Param school_id query string false "Restrict to a school (UUID)"
+// @Param from query string false "First day (YYYY-MM-DD), default 29 days before to"
+// @Param to query string false "Last day (YYYY-MM-DD), default today"
+// @Param tz query string false "IANA timezone, default UTC"
+// @Success 200 {object} dto.ActivityStatsResponse "Activity statistics"
+// @Failure 400 {object} ErrorResponse "Invalid date range, timezone or school_id"
+// @Failure 403 {object} ErrorResponse "Forbidden - solo admins"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/stats/activity [get]
+// @Security BearerAuth
+func (h *ActivityHandler) GetActivityStats(c *gin.Context) {
+	stats, err := h.activityService.GetActivityStats(c.Request.Context(), c.Query("school_id"), c.Query("from"), c.Query("to"), requestTimezone(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *ActivityHandler) respondError(c *gin.Context, err error) {
+	if appErr, ok := errors.GetAppError(err); ok {
+		c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+		return
+	}
+	h.logger.Error("unexpected error getting activity", "error", err)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+}
+
+// requestTimezone prioriza ?tz= sobre el header X-Timezone
+func requestTimezone(c *gin.Context) string {
+	if tz := c.Query("tz"); tz != "" {
+		return tz
+	}
+	return c.GetHeader(timezoneHeader)
+}
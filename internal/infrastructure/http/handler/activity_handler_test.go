@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// TestActivityHandler_GetMyActivity_Success verifica que se usen el usuario autenticado y los parámetros de rango
+func TestActivityHandler_GetMyActivity_Success(t *testing.T) {
+	// Arrange
+	authenticatedUserID := "550e8400-e29b-41d4-a716-446655440000"
+	mockService := &MockActivityService{
+		GetUserActivityFunc: func(ctx context.Context, userID, from, to, timezone string) (*dto.UserActivityResponse, error) {
+			assert.Equal(t, authenticatedUserID, userID)
+			assert.Equal(t, "2026-03-01", from)
+			assert.Equal(t, "2026-03-10", to)
+			assert.Equal(t, "America/Bogota", timezone)
+			return &dto.UserActivityResponse{
+				Timezone: timezone,
+				From:     from,
+				To:       to,
+				Days:     []dto.DailyActivityDTO{{Date: "2026-03-10", MinutesStudied: 12.5, MaterialsTouched: 1}},
+				Streak:   dto.StreakDTO{Current: 3, Longest: 7, LastActiveDate: "2026-03-10"},
+			}, nil
+		},
+	}
+
+	handler := NewActivityHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/users/me/activity", MockAuthMiddleware(authenticatedUserID, "880e8400-e29b-41d4-a716-446655440003"), handler.GetMyActivity)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/me/activity?from=2026-03-01&to=2026-03-10&tz=America/Bogota", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.UserActivityResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.Streak.Current)
+	assert.Equal(t, 7, response.Streak.Longest)
+	require.Len(t, response.Days, 1)
+}
+
+// TestActivityHandler_GetMyActivity_TimezoneHeader verifica el fallback al header X-Timezone
+func TestActivityHandler_GetMyActivity_TimezoneHeader(t *testing.T) {
+	var gotTimezone string
+	mockService := &MockActivityService{
+		GetUserActivityFunc: func(ctx context.Context, userID, from, to, timezone string) (*dto.UserActivityResponse, error) {
+			gotTimezone = timezone
+			return &dto.UserActivityResponse{}, nil
+		},
+	}
+
+	handler := NewActivityHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/users/me/activity", MockAuthMiddleware("550e8400-e29b-41d4-a716-446655440000", ""), handler.GetMyActivity)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/me/activity", nil)
+	req.Header.Set("X-Timezone", "Europe/Madrid")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Europe/Madrid", gotTimezone)
+}
+
+// TestActivityHandler_GetMyActivity_ValidationError verifica la propagación de errores de validación
+func TestActivityHandler_GetMyActivity_ValidationError(t *testing.T) {
+	mockService := &MockActivityService{
+		GetUserActivityFunc: func(ctx context.Context, userID, from, to, timezone string) (*dto.UserActivityResponse, error) {
+			return nil, errors.NewValidationError("invalid timezone: Mars/Olympus")
+		},
+	}
+
+	handler := NewActivityHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/users/me/activity", MockAuthMiddleware("550e8400-e29b-41d4-a716-446655440000", ""), handler.GetMyActivity)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/me/activity?tz=Mars/Olympus", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// TestActivityHandler_GetActivityStats_Success verifica el filtro por escuela
+func TestActivityHandler_GetActivityStats_Success(t *testing.T) {
+	schoolID := "770e8400-e29b-41d4-a716-446655440002"
+	mockService := &MockActivityService{
+		GetActivityStatsFunc: func(ctx context.Context, gotSchoolID, from, to, timezone string) (*dto.ActivityStatsResponse, error) {
+			assert.Equal(t, schoolID, gotSchoolID)
+			return &dto.ActivityStatsResponse{
+				SchoolID: gotSchoolID,
+				Timezone: "UTC",
+				Days:     []dto.DailyActivityDTO{{Date: "2026-03-10", ActiveUsers: 25}},
+			}, nil
+		},
+	}
+
+	handler := NewActivityHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/stats/activity", handler.GetActivityStats)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/stats/activity?school_id="+schoolID, nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.ActivityStatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Days, 1)
+	assert.Equal(t, 25, response.Days[0].ActiveUsers)
+}
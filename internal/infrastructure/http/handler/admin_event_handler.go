@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// AdminEventHandler expone el dead-letter store de eventos para administradores
+type AdminEventHandler struct {
+	failedEventService service.FailedEventService
+	logger             logger.Logger
+}
+
+func NewAdminEventHandler(failedEventService service.FailedEventService, logger logger.Logger) *AdminEventHandler {
+	return &AdminEventHandler{
+		failedEventService: failedEventService,
+		logger:             logger,
+	}
+}
+
+// ListFailedEvents godoc
+// @Summary List failed events
+// @Description Lista los eventos que no pudieron publicarse (dead-letter), ordenados por última falla
+// @Tags admin
+// @Produce json
+// @Param status query string false "Filtrar por estado (pending, replayed)"
+// @Param routing_key query string false "Filtrar por routing key"
+// @Param limit query int false "Número máximo de resultados" default(20)
+// @Param offset query int false "Número de resultados a saltar" default(0)
+// @Success 200 {object} dto.FailedEventListResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 403 {object} ErrorResponse "Forbidden - requiere events:manage"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/admin/events/failed [get]
+// @Security BearerAuth
+func (h *AdminEventHandler) ListFailedEvents(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	filter := repository.FailedEventFilter{
+		Status:     repository.FailedEventStatus(c.Query("status")),
+		RoutingKey: c.Query("routing_key"),
+		Limit:      limit,
+		Offset:     offset,
+	}
+
+	events, err := h.failedEventService.ListFailedEvents(c.Request.Context(), filter)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// ReplayFailedEvent godoc
+// @Summary Replay a failed event
+// @Description Re-publica un evento fallido con su exchange, routing key y payload originales
+// @Tags admin
+// @Produce json
+// @Param id path string true "Failed event ID (UUID format)"
+// @Success 200 {object} dto.FailedEventDTO "Evento re-publicado"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 403 {object} ErrorResponse "Forbidden - requiere events:manage"
+// @Failure 404 {object} ErrorResponse "Failed event not found"
+// @Failure 409 {object} ErrorResponse "Event already replayed"
+// @Failure 500 {object} ErrorResponse "Replay failed"
+// @Router /v1/admin/events/failed/{id}/replay [post]
+// @Security BearerAuth
+func (h *AdminEventHandler) ReplayFailedEvent(c *gin.Context) {
+	id := c.Param("id")
+
+	event, err := h.failedEventService.ReplayFailedEvent(c.Request.Context(), id)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
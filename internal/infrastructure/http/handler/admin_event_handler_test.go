@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// MockFailedEventService para tests de admin_event_handler
+type MockFailedEventService struct {
+	ListFailedEventsFunc  func(ctx context.Context, filter repository.FailedEventFilter) (*dto.FailedEventListResponse, error)
+	ReplayFailedEventFunc func(ctx context.Context, id string) (*dto.FailedEventDTO, error)
+}
+
+func (m *MockFailedEventService) ListFailedEvents(ctx context.Context, filter repository.FailedEventFilter) (*dto.FailedEventListResponse, error) {
+	if m.ListFailedEventsFunc != nil {
+		return m.ListFailedEventsFunc(ctx, filter)
+	}
+	return &dto.FailedEventListResponse{Events: []dto.FailedEventDTO{}}, nil
+}
+
+func (m *MockFailedEventService) ReplayFailedEvent(ctx context.Context, id string) (*dto.FailedEventDTO, error) {
+	if m.ReplayFailedEventFunc != nil {
+		return m.ReplayFailedEventFunc(ctx, id)
+	}
+	return &dto.FailedEventDTO{ID: id, Status: "replayed"}, nil
+}
+
+// TestAdminEventHandler_ListFailedEvents verifica el parseo de filtros y la respuesta
+func TestAdminEventHandler_ListFailedEvents(t *testing.T) {
+	mockService := &MockFailedEventService{
+		ListFailedEventsFunc: func(ctx context.Context, filter repository.FailedEventFilter) (*dto.FailedEventListResponse, error) {
+			assert.Equal(t, repository.FailedEventStatusPending, filter.Status)
+			assert.Equal(t, "material.completed", filter.RoutingKey)
+			assert.Equal(t, 5, filter.Limit)
+			assert.Equal(t, 0, filter.Offset)
+			return &dto.FailedEventListResponse{
+				Events:     []dto.FailedEventDTO{{ID: "evt-1", RoutingKey: "material.completed", Attempts: 1}},
+				TotalCount: 1,
+				Limit:      5,
+			}, nil
+		},
+	}
+
+	handler := NewAdminEventHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/admin/events/failed", handler.ListFailedEvents)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/events/failed?status=pending&routing_key=material.completed&limit=5&offset=-3", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dto.FailedEventListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(1), response.TotalCount)
+	require.Len(t, response.Events, 1)
+	assert.Equal(t, "evt-1", response.Events[0].ID)
+}
+
+// TestAdminEventHandler_ReplayFailedEvent verifica el replay y el mapeo de errores
+func TestAdminEventHandler_ReplayFailedEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"not found", errors.NewNotFoundError("failed event"), http.StatusNotFound},
+		{"already replayed", errors.NewConflictError("failed event was already replayed"), http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockFailedEventService{
+				ReplayFailedEventFunc: func(ctx context.Context, id string) (*dto.FailedEventDTO, error) {
+					assert.Equal(t, "evt-1", id)
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &dto.FailedEventDTO{ID: id, Status: "replayed", Attempts: 2}, nil
+				},
+			}
+
+			handler := NewAdminEventHandler(mockService, NewTestLogger())
+			router := SetupTestRouter()
+			router.POST("/admin/events/failed/:id/replay", handler.ReplayFailedEvent)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/admin/events/failed/evt-1/replay", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/EduGoGroup/edugo-shared/logger"
 	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
@@ -39,7 +41,8 @@ func NewAssessmentHandler(
 // @Success 200 {object} dto.AssessmentResponse "Assessment obtenido exitosamente"
 // @Failure 400 {object} ErrorResponse "Invalid material ID"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Failure 404 {object} ErrorResponse "Assessment not found"
+// @Failure 403 {object} MaterialLockedResponse "Material locked by a learning path"
+// @Failure 404 {object} ErrorResponse "Material or assessment not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /v1/materials/{id}/assessment [get]
 func (h *AssessmentHandler) GetMaterialAssessment(c *gin.Context) {
@@ -49,8 +52,12 @@ func (h *AssessmentHandler) GetMaterialAssessment(c *gin.Context) {
 		return
 	}
 
-	assessment, err := h.assessmentAttemptService.GetAssessmentByMaterialID(c.Request.Context(), materialID)
+	subject := policy.NewSubject(middleware.GetUserID(c), middleware.GetActiveContext(c))
+	assessment, err := h.assessmentAttemptService.GetAssessmentByMaterialID(c.Request.Context(), materialID, subject)
 	if err != nil {
+		if respondMaterialLocked(c, err) {
+			return
+		}
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
 			return
@@ -62,6 +69,41 @@ func (h *AssessmentHandler) GetMaterialAssessment(c *gin.Context) {
 	c.JSON(http.StatusOK, assessment)
 }
 
+// GetAssessmentAnalytics godoc
+// @Summary Análisis de ítems de la evaluación de un material
+// @Description Retorna por pregunta el índice de dificultad, índice de discriminación (27% superior vs inferior), distractores elegidos y tiempo promedio. Solo docentes
+// @Tags Evaluaciones
+// @Security BearerAuth
+// @Param id path string true "Material ID (UUID)"
+// @Success 200 {object} dto.ItemAnalysisResponse "Análisis obtenido exitosamente"
+// @Failure 400 {object} ErrorResponse "Invalid material ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Material or assessment not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/materials/{id}/assessment/analytics [get]
+func (h *AssessmentHandler) GetAssessmentAnalytics(c *gin.Context) {
+	materialID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid material ID", Code: "INVALID_MATERIAL_ID"})
+		return
+	}
+
+	// El servicio evalúa material:read: los materiales de otra escuela responden 404
+	subject := policy.NewSubject(middleware.GetUserID(c), middleware.GetActiveContext(c))
+	analysis, err := h.assessmentAttemptService.GetItemAnalysis(c.Request.Context(), materialID, subject)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
+
 // CreateMaterialAttempt godoc
 // @Summary Crear intento de evaluación y obtener calificación
 // @Description Crea un intento, valida respuestas en servidor, calcula score y retorna resultados con feedback
@@ -72,7 +114,8 @@ func (h *AssessmentHandler) GetMaterialAssessment(c *gin.Context) {
 // @Success 201 {object} dto.AttemptResultResponse "Attempt creado exitosamente"
 // @Failure 400 {object} ErrorResponse "Invalid request or material ID"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Failure 404 {object} ErrorResponse "Assessment not found"
+// @Failure 403 {object} MaterialLockedResponse "Material locked by a learning path"
+// @Failure 404 {object} ErrorResponse "Material or assessment not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /v1/materials/{id}/assessment/attempts [post]
 func (h *AssessmentHandler) CreateMaterialAttempt(c *gin.Context) {
@@ -96,8 +139,12 @@ func (h *AssessmentHandler) CreateMaterialAttempt(c *gin.Context) {
 		return
 	}
 
-	result, err := h.assessmentAttemptService.CreateAttempt(c.Request.Context(), studentID, materialID, req)
+	subject := policy.NewSubject(studentIDStr, middleware.GetActiveContext(c))
+	result, err := h.assessmentAttemptService.CreateAttempt(c.Request.Context(), studentID, materialID, req, subject)
 	if err != nil {
+		if respondMaterialLocked(c, err) {
+			return
+		}
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
 			return
@@ -138,13 +185,13 @@ func (h *AssessmentHandler) GetAttemptResults(c *gin.Context) {
 
 	// Obtener student ID del JWT
 	studentIDStr := ginmiddleware.MustGetUserID(c)
-	studentID, err := uuid.Parse(studentIDStr)
-	if err != nil {
+	if _, err := uuid.Parse(studentIDStr); err != nil {
 		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "invalid user ID", Code: "INVALID_USER_ID"})
 		return
 	}
 
-	result, err := h.assessmentAttemptService.GetAttemptResult(c.Request.Context(), attemptID, studentID)
+	subject := policy.NewSubject(studentIDStr, middleware.GetActiveContext(c))
+	result, err := h.assessmentAttemptService.GetAttemptResult(c.Request.Context(), attemptID, subject)
 	if err != nil {
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
@@ -1,9 +1,19 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
 )
 
 // Tests de SubmitAssessment (legacy) fueron eliminados
@@ -24,3 +34,67 @@ func TestNewAssessmentHandler(t *testing.T) {
 	assert.Equal(t, mockAttemptService, handler.assessmentAttemptService)
 	assert.Equal(t, logger, handler.logger)
 }
+
+// TestAssessmentHandler_GetAssessmentAnalytics_Success verifica la respuesta del análisis de ítems
+// y que el servicio reciba la escuela del contexto activo para evaluar material:read
+func TestAssessmentHandler_GetAssessmentAnalytics_Success(t *testing.T) {
+	// Arrange
+	materialID := uuid.New()
+	var gotSubject policy.Subject
+	mockAttemptService := &MockAssessmentAttemptService{
+		GetItemAnalysisFunc: func(ctx context.Context, matID uuid.UUID, subject policy.Subject) (*dto.ItemAnalysisResponse, error) {
+			assert.Equal(t, materialID, matID)
+			gotSubject = subject
+			return &dto.ItemAnalysisResponse{
+				MaterialID:       matID,
+				TotalRespondents: 12,
+				Questions:        []dto.QuestionAnalysisDTO{{QuestionID: "q1", DifficultyIndex: 75}},
+			}, nil
+		},
+	}
+	handler := NewAssessmentHandler(mockAttemptService, NewTestLogger())
+
+	router := SetupTestRouter()
+	router.GET("/materials/:id/assessment/analytics", streamAuthMiddleware("stats:unit"), handler.GetAssessmentAnalytics)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/materials/"+materialID.String()+"/assessment/analytics", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, streamTestUserID, gotSubject.UserID)
+	assert.Equal(t, streamTestSchoolID, gotSubject.SchoolID)
+
+	var response dto.ItemAnalysisResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 12, response.TotalRespondents)
+	require.Len(t, response.Questions, 1)
+	assert.Equal(t, 75.0, response.Questions[0].DifficultyIndex)
+}
+
+// TestAssessmentHandler_GetAssessmentAnalytics_Errors verifica ID inválido y assessment inexistente
+func TestAssessmentHandler_GetAssessmentAnalytics_Errors(t *testing.T) {
+	// Arrange
+	mockAttemptService := &MockAssessmentAttemptService{
+		GetItemAnalysisFunc: func(ctx context.Context, matID uuid.UUID, subject policy.Subject) (*dto.ItemAnalysisResponse, error) {
+			return nil, errors.NewNotFoundError("assessment")
+		},
+	}
+	handler := NewAssessmentHandler(mockAttemptService, NewTestLogger())
+
+	router := SetupTestRouter()
+	router.GET("/materials/:id/assessment/analytics", handler.GetAssessmentAnalytics)
+
+	// Act & Assert
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/materials/not-a-uuid/assessment/analytics", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/materials/"+uuid.New().String()+"/assessment/analytics", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
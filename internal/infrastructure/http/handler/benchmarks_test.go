@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 )
@@ -52,7 +53,7 @@ func BenchmarkMaterialHandler_CreateMaterial(b *testing.B) {
 // BenchmarkMaterialHandler_GenerateUploadURL mide el rendimiento de generación de URLs presignadas
 func BenchmarkMaterialHandler_GenerateUploadURL(b *testing.B) {
 	mockService := &MockMaterialService{
-		GetMaterialFunc: func(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+		GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 			time.Sleep(5 * time.Millisecond) // Simular DB query
 			return &dto.MaterialResponse{ID: id, Title: "Benchmark Material"}, nil
 		},
@@ -89,7 +90,7 @@ func BenchmarkMaterialHandler_GenerateUploadURL(b *testing.B) {
 // BenchmarkMaterialHandler_GenerateUploadURL_Parallel mide rendimiento con concurrencia
 func BenchmarkMaterialHandler_GenerateUploadURL_Parallel(b *testing.B) {
 	mockService := &MockMaterialService{
-		GetMaterialFunc: func(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+		GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 			time.Sleep(5 * time.Millisecond)
 			return &dto.MaterialResponse{ID: id, Title: "Benchmark Material"}, nil
 		},
@@ -165,7 +166,7 @@ func BenchmarkMaterialHandler_ListMaterials(b *testing.B) {
 // BenchmarkMaterialHandler_GetMaterial mide el rendimiento de obtener un material
 func BenchmarkMaterialHandler_GetMaterial(b *testing.B) {
 	mockService := &MockMaterialService{
-		GetMaterialFunc: func(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+		GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 			time.Sleep(5 * time.Millisecond)
 			return &dto.MaterialResponse{
 				ID:    id,
@@ -54,6 +54,7 @@ type HealthResponse struct {
 	Version   string `json:"version"`
 	Postgres  string `json:"postgres"`
 	MongoDB   string `json:"mongodb"`
+	RabbitMQ  string `json:"rabbitmq,omitempty"`
 	Timestamp string `json:"timestamp"`
 }
 
@@ -113,6 +114,12 @@ func (h *HealthHandler) Check(c *gin.Context) {
 		}
 	}
 
+	// Verificar RabbitMQ (opcional, no afecta el estado general)
+	rabbitStatus := ""
+	if h.rabbitMQChecker != nil {
+		rabbitStatus = h.checkRabbitMQ(c.Request.Context()).Status
+	}
+
 	// Determinar estado general del sistema
 	status := "healthy"
 	if pgStatus == "unhealthy" || mongoStatus == "unhealthy" {
@@ -125,6 +132,7 @@ func (h *HealthHandler) Check(c *gin.Context) {
 		Version:   "1.0.0",
 		Postgres:  pgStatus,
 		MongoDB:   mongoStatus,
+		RabbitMQ:  rabbitStatus,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	}
 
@@ -46,6 +46,26 @@ func TestHealthHandler_Check_Basic(t *testing.T) {
 		assert.Equal(t, "mock", response.Postgres)
 		assert.Equal(t, "mock", response.MongoDB)
 		assert.Equal(t, "edugo-api-mobile", response.Service)
+		assert.Empty(t, response.RabbitMQ)
+	})
+
+	t.Run("reports RabbitMQ connection state without degrading status", func(t *testing.T) {
+		rabbitChecker := &MockHealthChecker{healthy: false, err: errors.New("reconnecting")}
+		handler := NewHealthHandlerWithCheckers(nil, nil, rabbitChecker, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/health", nil)
+
+		handler.Check(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response HealthResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "healthy", response.Status)
+		assert.Equal(t, "unhealthy", response.RabbitMQ)
 	})
 }
 
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+// MaterialLockedResponse error 403 de un material bloqueado por una ruta de aprendizaje
+type MaterialLockedResponse struct {
+	Error string            `json:"error"`
+	Code  string            `json:"code" example:"MATERIAL_LOCKED"`
+	Lock  *dto.MaterialLock `json:"lock"`
+}
+
+type LearningPathHandler struct {
+	pathService service.LearningPathService
+	logger      logger.Logger
+}
+
+func NewLearningPathHandler(pathService service.LearningPathService, logger logger.Logger) *LearningPathHandler {
+	return &LearningPathHandler{
+		pathService: pathService,
+		logger:      logger,
+	}
+}
+
+// ListPaths godoc
+// @Summary List learning paths
+// @Description Rutas de aprendizaje de la escuela que aplican al usuario, con el estado de cada paso (locked, available, in_progress, completed) calculado desde su progreso y sus intentos
+// @Tags paths
+// @Produce json
+// @Success 200 {array} dto.LearningPathResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/paths [get]
+// @Security BearerAuth
+func (h *LearningPathHandler) ListPaths(c *gin.Context) {
+	paths, err := h.pathService.ListPaths(c.Request.Context(), pathLearner(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, paths)
+}
+
+// GetPath godoc
+// @Summary Get learning path
+// @Description Ruta de aprendizaje con el estado de desbloqueo de cada paso para el usuario
+// @Tags paths
+// @Produce json
+// @Param id path string true "Path ID (UUID)"
+// @Success 200 {object} dto.LearningPathResponse
+// @Failure 400 {object} ErrorResponse "Invalid path ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Path not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/paths/{id} [get]
+// @Security BearerAuth
+func (h *LearningPathHandler) GetPath(c *gin.Context) {
+	path, err := h.pathService.GetPath(c.Request.Context(), c.Param("id"), pathLearner(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, path)
+}
+
+// CreatePath godoc
+// @Summary Create learning path
+// @Description Crea una ruta ordenada de materiales. Cada paso se completa al leer el material al 100% y, con min_score, al aprobar su evaluación con ese puntaje. Si requires se omite, el paso requiere el anterior
+// @Tags paths
+// @Accept json
+// @Produce json
+// @Param request body dto.LearningPathRequest true "Path definition"
+// @Success 201 {object} dto.LearningPathResponse
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/paths [post]
+// @Security BearerAuth
+func (h *LearningPathHandler) CreatePath(c *gin.Context) {
+	authorID := ginmiddleware.MustGetUserID(c)
+	schoolID := middleware.MustGetSchoolIDFromContext(c).String()
+
+	var req dto.LearningPathRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error(), Code: "INVALID_REQUEST"})
+		return
+	}
+
+	path, err := h.pathService.CreatePath(c.Request.Context(), req, authorID, schoolID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, path)
+}
+
+// UpdatePath godoc
+// @Summary Replace learning path
+// @Description Reemplaza título, descripción, unidad y pasos de una ruta. El estado de los estudiantes se recalcula con los nuevos pasos
+// @Tags paths
+// @Accept json
+// @Produce json
+// @Param id path string true "Path ID (UUID)"
+// @Param request body dto.LearningPathRequest true "Path definition"
+// @Success 200 {object} dto.LearningPathResponse
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Path not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/paths/{id} [put]
+// @Security BearerAuth
+func (h *LearningPathHandler) UpdatePath(c *gin.Context) {
+	schoolID := middleware.MustGetSchoolIDFromContext(c).String()
+
+	var req dto.LearningPathRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error(), Code: "INVALID_REQUEST"})
+		return
+	}
+
+	path, err := h.pathService.UpdatePath(c.Request.Context(), c.Param("id"), req, schoolID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, path)
+}
+
+// DeletePath godoc
+// @Summary Delete learning path
+// @Description Elimina la ruta; sus materiales dejan de estar bloqueados por ella
+// @Tags paths
+// @Param id path string true "Path ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorResponse "Invalid path ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Path not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/paths/{id} [delete]
+// @Security BearerAuth
+func (h *LearningPathHandler) DeletePath(c *gin.Context) {
+	schoolID := middleware.MustGetSchoolIDFromContext(c).String()
+
+	if err := h.pathService.DeletePath(c.Request.Context(), c.Param("id"), schoolID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondMaterialLocked responde 403 MATERIAL_LOCKED con el detalle del bloqueo si err lo es
+// Los servicios de material, resumen e intentos aplican el bloqueo de las rutas de aprendizaje
+func respondMaterialLocked(c *gin.Context, err error) bool {
+	lock := service.MaterialLockFromError(err)
+	if lock == nil {
+		return false
+	}
+	appErr, _ := errors.GetAppError(err)
+	c.JSON(http.StatusForbidden, MaterialLockedResponse{
+		Error: appErr.Message,
+		Code:  string(appErr.Code),
+		Lock:  lock,
+	})
+	return true
+}
+
+func (h *LearningPathHandler) respondError(c *gin.Context, err error) {
+	if appErr, ok := errors.GetAppError(err); ok {
+		c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+		return
+	}
+	h.logger.Error("unexpected learning path error", "error", err)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+}
+
+// pathLearner arma el usuario del estado de desbloqueo desde el contexto de autenticación
+func pathLearner(c *gin.Context) service.PathLearner {
+	learner := service.PathLearner{UserID: ginmiddleware.MustGetUserID(c)}
+	if schoolID, ok := middleware.GetSchoolIDFromContext(c); ok {
+		learner.SchoolID = schoolID.String()
+	}
+	if activeCtx := middleware.GetActiveContext(c); activeCtx != nil {
+		if learner.SchoolID == "" {
+			learner.SchoolID = activeCtx.SchoolID
+		}
+		learner.AcademicUnitID = activeCtx.AcademicUnitID
+	}
+	return learner
+}
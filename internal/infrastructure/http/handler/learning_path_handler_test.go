@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+)
+
+const pathTestMaterialID = "550e8400-e29b-41d4-a716-446655440000"
+
+// TestMaterialLocked_HandlersRespondWithLock verifica que cada lectura del contenido traduzca el
+// bloqueo de la ruta a 403 MATERIAL_LOCKED con su detalle, incluido el subject con la unidad activa
+func TestMaterialLocked_HandlersRespondWithLock(t *testing.T) {
+	lock := &dto.MaterialLock{MaterialID: pathTestMaterialID, PathID: "path-1", PathTitle: "Unidad 1", MissingSteps: []string{"material-a"}}
+	lockedFor := func(subject policy.Subject) error {
+		assert.Equal(t, streamTestUserID, subject.UserID)
+		assert.Equal(t, streamTestUnitID, subject.AcademicUnitID)
+		return service.NewMaterialLockedError(lock)
+	}
+
+	materials := &MockMaterialService{
+		GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
+			return nil, lockedFor(subject)
+		},
+		GetMaterialWithVersionsFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialWithVersionsResponse, error) {
+			return nil, lockedFor(subject)
+		},
+	}
+	summaries := &MockSummaryService{
+		GetSummaryFunc: func(ctx context.Context, materialID string, subject policy.Subject) (*repository.MaterialSummary, error) {
+			return nil, lockedFor(subject)
+		},
+	}
+	attempts := &MockAssessmentAttemptService{
+		GetAssessmentByMaterialIDFunc: func(ctx context.Context, materialID uuid.UUID, subject policy.Subject) (*dto.AssessmentResponse, error) {
+			return nil, lockedFor(subject)
+		},
+		CreateAttemptFunc: func(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, subject policy.Subject) (*dto.AttemptResultResponse, error) {
+			return nil, lockedFor(subject)
+		},
+	}
+
+	materialHandler := NewMaterialHandler(materials, &MockS3Storage{}, NewTestLogger())
+	summaryHandler := NewSummaryHandler(summaries, NewTestLogger())
+	assessmentHandler := NewAssessmentHandler(attempts, NewTestLogger())
+
+	router := SetupTestRouter()
+	group := router.Group("/materials", streamAuthMiddleware(enum.PermissionMaterialsRead.String()))
+	group.GET("/:id", materialHandler.GetMaterial)
+	group.GET("/:id/versions", materialHandler.GetMaterialWithVersions)
+	group.GET("/:id/download-url", materialHandler.GenerateDownloadURL)
+	group.GET("/:id/summary", summaryHandler.GetSummary)
+	group.GET("/:id/assessment", assessmentHandler.GetMaterialAssessment)
+	group.POST("/:id/assessment/attempts", assessmentHandler.CreateMaterialAttempt)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"GET", "", ""},
+		{"GET", "/versions", ""},
+		{"GET", "/download-url", ""},
+		{"GET", "/summary", ""},
+		{"GET", "/assessment", ""},
+		{"POST", "/assessment/attempts", `{"answers":[{"question_id":"q1","selected_answer_id":"a","time_spent_seconds":10}],"time_spent_seconds":10}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, "/materials/"+pathTestMaterialID+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			var response MaterialLockedResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "MATERIAL_LOCKED", response.Code)
+			require.NotNil(t, response.Lock)
+			assert.Equal(t, []string{"material-a"}, response.Lock.MissingSteps)
+		})
+	}
+}
+
+// TestLearningPathHandler_CreatePath verifica la creación y la validación del cuerpo
+func TestLearningPathHandler_CreatePath(t *testing.T) {
+	svc := &MockLearningPathService{
+		CreatePathFunc: func(ctx context.Context, req dto.LearningPathRequest, authorID, schoolID string) (*dto.LearningPathResponse, error) {
+			assert.Equal(t, streamTestUserID, authorID)
+			assert.Equal(t, streamTestSchoolID, schoolID)
+			require.Len(t, req.Steps, 2)
+			assert.Nil(t, req.Steps[1].Requires)
+			return &dto.LearningPathResponse{ID: "path-1", Title: req.Title, TotalSteps: len(req.Steps)}, nil
+		},
+	}
+	handler := NewLearningPathHandler(svc, NewTestLogger())
+	router := SetupTestRouter()
+	router.POST("/paths", MockAuthMiddleware(streamTestUserID, streamTestSchoolID), handler.CreatePath)
+
+	body := `{"title": "Unidad 1", "steps": [
+		{"material_id": "550e8400-e29b-41d4-a716-446655440000", "min_score": 70},
+		{"material_id": "550e8400-e29b-41d4-a716-446655440001"}
+	]}`
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/paths", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response dto.LearningPathResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "path-1", response.ID)
+	assert.Equal(t, 2, response.TotalSteps)
+
+	invalid := []string{
+		`{"title": "Unidad 1", "steps": []}`,
+		`{"title": "Unidad 1", "steps": [{"material_id": "not-a-uuid"}]}`,
+		`{"title": "Unidad 1", "steps": [{"material_id": "550e8400-e29b-41d4-a716-446655440000", "min_score": 150}]}`,
+	}
+	for _, body := range invalid {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/paths", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+// TestLearningPathHandler_DeletePath verifica el 204 y la propagación de NotFound
+func TestLearningPathHandler_DeletePath(t *testing.T) {
+	svc := &MockLearningPathService{
+		DeletePathFunc: func(ctx context.Context, pathID, schoolID string) error {
+			if pathID == "missing" {
+				return errors.NewNotFoundError("learning path")
+			}
+			return nil
+		},
+	}
+	handler := NewLearningPathHandler(svc, NewTestLogger())
+	router := SetupTestRouter()
+	router.DELETE("/paths/:id", MockAuthMiddleware(streamTestUserID, streamTestSchoolID), handler.DeletePath)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/paths/path-1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("DELETE", "/paths/missing", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
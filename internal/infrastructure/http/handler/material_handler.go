@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
@@ -85,6 +86,7 @@ func (h *MaterialHandler) CreateMaterial(c *gin.Context) {
 // @Param id path string true "Material ID (UUID format)"
 // @Success 200 {object} dto.MaterialResponse "Material found successfully"
 // @Failure 400 {object} ErrorResponse "Invalid material ID format"
+// @Failure 403 {object} MaterialLockedResponse "Material locked by a learning path"
 // @Failure 404 {object} ErrorResponse "Material not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /v1/materials/{id} [get]
@@ -92,8 +94,11 @@ func (h *MaterialHandler) CreateMaterial(c *gin.Context) {
 func (h *MaterialHandler) GetMaterial(c *gin.Context) {
 	id := c.Param("id")
 
-	material, err := h.materialService.GetMaterial(c.Request.Context(), id)
+	material, err := h.materialService.GetMaterial(c.Request.Context(), id, policy.NewSubject(middleware.GetUserID(c), middleware.GetActiveContext(c)))
 	if err != nil {
+		if respondMaterialLocked(c, err) {
+			return
+		}
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
 			return
@@ -113,6 +118,7 @@ func (h *MaterialHandler) GetMaterial(c *gin.Context) {
 // @Param id path string true "Material ID (UUID format)"
 // @Success 200 {object} dto.MaterialWithVersionsResponse
 // @Failure 400 {object} ErrorResponse "Invalid UUID format"
+// @Failure 403 {object} MaterialLockedResponse "Material locked by a learning path"
 // @Failure 404 {object} ErrorResponse "Material not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /v1/materials/{id}/versions [get]
@@ -122,8 +128,11 @@ func (h *MaterialHandler) GetMaterialWithVersions(c *gin.Context) {
 	id := c.Param("id")
 
 	// Invocar servicio para obtener material con versiones
-	result, err := h.materialService.GetMaterialWithVersions(c.Request.Context(), id)
+	result, err := h.materialService.GetMaterialWithVersions(c.Request.Context(), id, policy.NewSubject(middleware.GetUserID(c), middleware.GetActiveContext(c)))
 	if err != nil {
+		if respondMaterialLocked(c, err) {
+			return
+		}
 		// Convertir error de aplicación a respuesta HTTP apropiada
 		if appErr, ok := errors.GetAppError(err); ok {
 			h.logger.Warn("get material with versions failed",
@@ -243,7 +252,7 @@ func (h *MaterialHandler) GenerateUploadURL(c *gin.Context) {
 	}
 
 	// Verificar que el material existe
-	_, err := h.materialService.GetMaterial(c.Request.Context(), materialID)
+	_, err := h.materialService.GetMaterial(c.Request.Context(), materialID, policy.NewSubject(middleware.GetUserID(c), middleware.GetActiveContext(c)))
 	if err != nil {
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
@@ -307,6 +316,7 @@ func (h *MaterialHandler) GenerateUploadURL(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Material ID"
 // @Success 200 {object} dto.GenerateDownloadURLResponse
+// @Failure 403 {object} MaterialLockedResponse "Material locked by a learning path"
 // @Failure 404 {object} ErrorResponse
 // @Router /v1/materials/{id}/download-url [get]
 // @Security BearerAuth
@@ -314,8 +324,11 @@ func (h *MaterialHandler) GenerateDownloadURL(c *gin.Context) {
 	materialID := c.Param("id")
 
 	// Verificar que el material existe y obtener la S3 key
-	material, err := h.materialService.GetMaterial(c.Request.Context(), materialID)
+	material, err := h.materialService.GetMaterial(c.Request.Context(), materialID, policy.NewSubject(middleware.GetUserID(c), middleware.GetActiveContext(c)))
 	if err != nil {
+		if respondMaterialLocked(c, err) {
+			return
+		}
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
 			return
@@ -397,7 +410,7 @@ func (h *MaterialHandler) UpdateMaterial(c *gin.Context) {
 		c.Request.Context(),
 		materialID,
 		req,
-		userID,
+		policy.NewSubject(userID, middleware.GetActiveContext(c)),
 	)
 	if err != nil {
 		if appErr, ok := errors.GetAppError(err); ok {
@@ -432,7 +445,10 @@ func (h *MaterialHandler) UpdateMaterial(c *gin.Context) {
 	c.JSON(http.StatusOK, material)
 }
 
+// ErrorResponse cuerpo de error de la API
+// Con Accept-Language, middleware.Locale traduce Error y deja el mensaje original en Detail
 type ErrorResponse struct {
-	Error string `json:"error" example:"invalid request body"`
-	Code  string `json:"code" example:"INVALID_REQUEST"`
+	Error  string `json:"error" example:"invalid request body"`
+	Code   string `json:"code" example:"INVALID_REQUEST"`
+	Detail string `json:"detail,omitempty" example:"invalid request body"`
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 )
@@ -45,7 +46,7 @@ func TestNewMaterialHandler(t *testing.T) {
 func TestMaterialHandler_GenerateUploadURL_PathTraversalPrevention(t *testing.T) {
 	// Arrange
 	mockService := &MockMaterialService{
-		GetMaterialFunc: func(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+		GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 			return &dto.MaterialResponse{ID: id, Title: "Test Material"}, nil
 		},
 	}
@@ -168,7 +169,7 @@ func TestMaterialHandler_GenerateUploadURL_ValidFileNames(t *testing.T) {
 			expectedURL := "https://s3.amazonaws.com/bucket/" + tc.expectedFileURL + "?presigned-params"
 
 			mockService := &MockMaterialService{
-				GetMaterialFunc: func(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+				GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 					return &dto.MaterialResponse{ID: id, Title: "Test Material"}, nil
 				},
 			}
@@ -214,7 +215,7 @@ func TestMaterialHandler_GenerateUploadURL_ValidFileNames(t *testing.T) {
 func TestMaterialHandler_GenerateUploadURL_MaterialNotFound(t *testing.T) {
 	// Arrange
 	mockService := &MockMaterialService{
-		GetMaterialFunc: func(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+		GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 			// Simular error de material no encontrado
 			return nil, fmt.Errorf("material not found")
 		},
@@ -381,7 +382,7 @@ func TestMaterialHandler_GetMaterial_Success(t *testing.T) {
 	expectedTitle := "Test Material"
 
 	mockService := &MockMaterialService{
-		GetMaterialFunc: func(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+		GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 			assert.Equal(t, expectedID, id)
 			return &dto.MaterialResponse{
 				ID:    id,
@@ -417,7 +418,7 @@ func TestMaterialHandler_GetMaterial_Success(t *testing.T) {
 func TestMaterialHandler_GenerateDownloadURL_FileNotUploaded(t *testing.T) {
 	// Arrange
 	mockService := &MockMaterialService{
-		GetMaterialFunc: func(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+		GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 			return &dto.MaterialResponse{
 				ID:      id,
 				Title:   "Material sin archivo",
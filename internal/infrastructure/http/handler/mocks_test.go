@@ -5,19 +5,21 @@ import (
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
 	"github.com/google/uuid"
 )
 
 // MockMaterialService para tests de material_handler
 type MockMaterialService struct {
 	CreateMaterialFunc          func(ctx context.Context, req dto.CreateMaterialRequest, authorID string, schoolID string) (*dto.MaterialResponse, error)
-	GetMaterialFunc             func(ctx context.Context, id string) (*dto.MaterialResponse, error)
-	GetMaterialWithVersionsFunc func(ctx context.Context, id string) (*dto.MaterialWithVersionsResponse, error)
+	GetMaterialFunc             func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error)
+	GetMaterialWithVersionsFunc func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialWithVersionsResponse, error)
 	ListMaterialsFunc           func(ctx context.Context, filters repository.ListFilters) ([]*dto.MaterialResponse, error)
 	NotifyUploadCompleteFunc    func(ctx context.Context, id string, req dto.UploadCompleteRequest) error
-	UpdateMaterialFunc          func(ctx context.Context, materialID string, req dto.UpdateMaterialRequest, userID string) (*dto.MaterialResponse, error)
+	UpdateMaterialFunc          func(ctx context.Context, materialID string, req dto.UpdateMaterialRequest, subject policy.Subject) (*dto.MaterialResponse, error)
 }
 
 func (m *MockMaterialService) CreateMaterial(ctx context.Context, req dto.CreateMaterialRequest, authorID string, schoolID string) (*dto.MaterialResponse, error) {
@@ -27,9 +29,9 @@ func (m *MockMaterialService) CreateMaterial(ctx context.Context, req dto.Create
 	return &dto.MaterialResponse{ID: "test-id"}, nil
 }
 
-func (m *MockMaterialService) GetMaterial(ctx context.Context, id string) (*dto.MaterialResponse, error) {
+func (m *MockMaterialService) GetMaterial(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
 	if m.GetMaterialFunc != nil {
-		return m.GetMaterialFunc(ctx, id)
+		return m.GetMaterialFunc(ctx, id, subject)
 	}
 	return &dto.MaterialResponse{ID: id}, nil
 }
@@ -41,9 +43,9 @@ func (m *MockMaterialService) ListMaterials(ctx context.Context, filters reposit
 	return []*dto.MaterialResponse{}, nil
 }
 
-func (m *MockMaterialService) GetMaterialWithVersions(ctx context.Context, id string) (*dto.MaterialWithVersionsResponse, error) {
+func (m *MockMaterialService) GetMaterialWithVersions(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialWithVersionsResponse, error) {
 	if m.GetMaterialWithVersionsFunc != nil {
-		return m.GetMaterialWithVersionsFunc(ctx, id)
+		return m.GetMaterialWithVersionsFunc(ctx, id, subject)
 	}
 	return &dto.MaterialWithVersionsResponse{
 		Material: &dto.MaterialResponse{ID: id},
@@ -58,9 +60,9 @@ func (m *MockMaterialService) NotifyUploadComplete(ctx context.Context, id strin
 	return nil
 }
 
-func (m *MockMaterialService) UpdateMaterial(ctx context.Context, materialID string, req dto.UpdateMaterialRequest, userID string) (*dto.MaterialResponse, error) {
+func (m *MockMaterialService) UpdateMaterial(ctx context.Context, materialID string, req dto.UpdateMaterialRequest, subject policy.Subject) (*dto.MaterialResponse, error) {
 	if m.UpdateMaterialFunc != nil {
-		return m.UpdateMaterialFunc(ctx, materialID, req, userID)
+		return m.UpdateMaterialFunc(ctx, materialID, req, subject)
 	}
 	return &dto.MaterialResponse{ID: materialID}, nil
 }
@@ -122,25 +124,70 @@ func (m *MockAssessmentService) CalculateScore(ctx context.Context, assessmentID
 
 // MockProgressService para tests de progress_handler
 type MockProgressService struct {
-	UpdateProgressFunc func(ctx context.Context, materialID, userID, schoolID string, percentage, lastPage int) error
+	UpdateProgressFunc   func(ctx context.Context, materialID, userID, schoolID string, percentage, lastPage int) error
+	ListUserProgressFunc func(ctx context.Context, userID, status string, limit, offset int) (*dto.UserProgressListResponse, error)
+	RecordReadingFunc    func(ctx context.Context, userID, schoolID string, req dto.ReadingEventsRequest) (*dto.ReadingEventsResponse, error)
+	ResetProgressFunc    func(ctx context.Context, materialID, userID, schoolID string) error
+	MergeProgressFunc    func(ctx context.Context, materialID, userID, schoolID string, percentage, lastPage int, accessedAt time.Time) (*dto.ProgressStateDTO, error)
 }
 
-func (m *MockProgressService) UpdateProgress(ctx context.Context, materialID, userID, schoolID string, percentage, lastPage int) error {
+func (m *MockProgressService) UpdateProgress(ctx context.Context, materialID, userID, schoolID string, percentage, lastPage int, subject policy.Subject) error {
 	if m.UpdateProgressFunc != nil {
 		return m.UpdateProgressFunc(ctx, materialID, userID, schoolID, percentage, lastPage)
 	}
 	return nil
 }
 
+func (m *MockProgressService) ListUserProgress(ctx context.Context, userID, status string, limit, offset int) (*dto.UserProgressListResponse, error) {
+	if m.ListUserProgressFunc != nil {
+		return m.ListUserProgressFunc(ctx, userID, status, limit, offset)
+	}
+	return &dto.UserProgressListResponse{Items: []dto.UserProgressDTO{}, Limit: limit, Offset: offset}, nil
+}
+
+func (m *MockProgressService) RecordReadingEvents(ctx context.Context, userID, schoolID string, req dto.ReadingEventsRequest, subject policy.Subject) (*dto.ReadingEventsResponse, error) {
+	if m.RecordReadingFunc != nil {
+		return m.RecordReadingFunc(ctx, userID, schoolID, req)
+	}
+	return &dto.ReadingEventsResponse{MaterialID: req.MaterialID, Accepted: len(req.Events)}, nil
+}
+
+func (m *MockProgressService) ResetProgress(ctx context.Context, materialID, userID, schoolID string, subject policy.Subject) error {
+	if m.ResetProgressFunc != nil {
+		return m.ResetProgressFunc(ctx, materialID, userID, schoolID)
+	}
+	return nil
+}
+
+func (m *MockProgressService) MergeProgress(ctx context.Context, materialID, userID, schoolID string, percentage, lastPage int, accessedAt time.Time, subject policy.Subject) (*dto.ProgressStateDTO, error) {
+	if m.MergeProgressFunc != nil {
+		return m.MergeProgressFunc(ctx, materialID, userID, schoolID, percentage, lastPage, accessedAt)
+	}
+	return &dto.ProgressStateDTO{MaterialID: materialID, ProgressPercentage: percentage, LastPage: lastPage, LastAccessedAt: accessedAt}, nil
+}
+
+// MockSyncService para tests de sync_handler
+type MockSyncService struct {
+	SyncFunc func(ctx context.Context, subject policy.Subject, req dto.SyncRequest, allowAttempts bool) (*dto.SyncResponse, error)
+}
+
+func (m *MockSyncService) Sync(ctx context.Context, subject policy.Subject, req dto.SyncRequest, allowAttempts bool) (*dto.SyncResponse, error) {
+	if m.SyncFunc != nil {
+		return m.SyncFunc(ctx, subject, req, allowAttempts)
+	}
+	return &dto.SyncResponse{Results: []dto.SyncItemResultDTO{}, Changes: []dto.ProgressStateDTO{}}, nil
+}
+
 // MockStatsService para tests de stats_handler
 type MockStatsService struct {
-	GetMaterialStatsFunc func(ctx context.Context, materialID string) (*service.MaterialStats, error)
-	GetGlobalStatsFunc   func(ctx context.Context) (*dto.GlobalStatsDTO, error)
+	GetMaterialStatsFunc   func(ctx context.Context, materialID string, subject policy.Subject) (*service.MaterialStats, error)
+	GetGlobalStatsFunc     func(ctx context.Context) (*dto.GlobalStatsDTO, error)
+	RecordMaterialViewFunc func(ctx context.Context, materialID, userID, schoolID string) error
 }
 
-func (m *MockStatsService) GetMaterialStats(ctx context.Context, materialID string) (*service.MaterialStats, error) {
+func (m *MockStatsService) GetMaterialStats(ctx context.Context, materialID string, subject policy.Subject) (*service.MaterialStats, error) {
 	if m.GetMaterialStatsFunc != nil {
-		return m.GetMaterialStatsFunc(ctx, materialID)
+		return m.GetMaterialStatsFunc(ctx, materialID, subject)
 	}
 	return &service.MaterialStats{
 		TotalViews:    150,
@@ -150,6 +197,13 @@ func (m *MockStatsService) GetMaterialStats(ctx context.Context, materialID stri
 	}, nil
 }
 
+func (m *MockStatsService) RecordMaterialView(ctx context.Context, materialID, userID, schoolID string) error {
+	if m.RecordMaterialViewFunc != nil {
+		return m.RecordMaterialViewFunc(ctx, materialID, userID, schoolID)
+	}
+	return nil
+}
+
 func (m *MockStatsService) GetGlobalStats(ctx context.Context) (*dto.GlobalStatsDTO, error) {
 	if m.GetGlobalStatsFunc != nil {
 		return m.GetGlobalStatsFunc(ctx)
@@ -163,14 +217,18 @@ func (m *MockStatsService) GetGlobalStats(ctx context.Context) (*dto.GlobalStats
 	}, nil
 }
 
+func (m *MockStatsService) RefreshGlobalStats(ctx context.Context, minAge time.Duration) (*dto.GlobalStatsDTO, error) {
+	return m.GetGlobalStats(ctx)
+}
+
 // MockSummaryService para tests de summary_handler
 type MockSummaryService struct {
-	GetSummaryFunc func(ctx context.Context, materialID string) (*repository.MaterialSummary, error)
+	GetSummaryFunc func(ctx context.Context, materialID string, subject policy.Subject) (*repository.MaterialSummary, error)
 }
 
-func (m *MockSummaryService) GetSummary(ctx context.Context, materialID string) (*repository.MaterialSummary, error) {
+func (m *MockSummaryService) GetSummary(ctx context.Context, materialID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 	if m.GetSummaryFunc != nil {
-		return m.GetSummaryFunc(ctx, materialID)
+		return m.GetSummaryFunc(ctx, materialID, subject)
 	}
 	return &repository.MaterialSummary{
 		MainIdeas:   []string{"Idea principal 1", "Idea principal 2"},
@@ -185,29 +243,38 @@ func (m *MockSummaryService) GetSummary(ctx context.Context, materialID string)
 
 // MockAssessmentAttemptService para tests de assessment_handler (SPRINT-04)
 type MockAssessmentAttemptService struct {
-	GetAssessmentByMaterialIDFunc func(ctx context.Context, materialID uuid.UUID) (*dto.AssessmentResponse, error)
-	CreateAttemptFunc             func(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest) (*dto.AttemptResultResponse, error)
-	GetAttemptResultFunc          func(ctx context.Context, attemptID, studentID uuid.UUID) (*dto.AttemptResultResponse, error)
+	GetAssessmentByMaterialIDFunc func(ctx context.Context, materialID uuid.UUID, subject policy.Subject) (*dto.AssessmentResponse, error)
+	CreateAttemptFunc             func(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, subject policy.Subject) (*dto.AttemptResultResponse, error)
+	CreateOfflineAttemptFunc      func(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, completedAt time.Time, subject policy.Subject) (*dto.AttemptResultResponse, error)
+	GetAttemptResultFunc          func(ctx context.Context, attemptID uuid.UUID, subject policy.Subject) (*dto.AttemptResultResponse, error)
 	GetAttemptHistoryFunc         func(ctx context.Context, studentID uuid.UUID, limit, offset int) (*dto.AttemptHistoryResponse, error)
+	GetItemAnalysisFunc           func(ctx context.Context, materialID uuid.UUID, subject policy.Subject) (*dto.ItemAnalysisResponse, error)
 }
 
-func (m *MockAssessmentAttemptService) GetAssessmentByMaterialID(ctx context.Context, materialID uuid.UUID) (*dto.AssessmentResponse, error) {
+func (m *MockAssessmentAttemptService) GetAssessmentByMaterialID(ctx context.Context, materialID uuid.UUID, subject policy.Subject) (*dto.AssessmentResponse, error) {
 	if m.GetAssessmentByMaterialIDFunc != nil {
-		return m.GetAssessmentByMaterialIDFunc(ctx, materialID)
+		return m.GetAssessmentByMaterialIDFunc(ctx, materialID, subject)
 	}
 	return &dto.AssessmentResponse{}, nil
 }
 
-func (m *MockAssessmentAttemptService) CreateAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest) (*dto.AttemptResultResponse, error) {
+func (m *MockAssessmentAttemptService) CreateAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, subject policy.Subject) (*dto.AttemptResultResponse, error) {
 	if m.CreateAttemptFunc != nil {
-		return m.CreateAttemptFunc(ctx, studentID, materialID, req)
+		return m.CreateAttemptFunc(ctx, studentID, materialID, req, subject)
+	}
+	return &dto.AttemptResultResponse{}, nil
+}
+
+func (m *MockAssessmentAttemptService) CreateOfflineAttempt(ctx context.Context, studentID, materialID uuid.UUID, req dto.CreateAttemptRequest, completedAt time.Time, subject policy.Subject) (*dto.AttemptResultResponse, error) {
+	if m.CreateOfflineAttemptFunc != nil {
+		return m.CreateOfflineAttemptFunc(ctx, studentID, materialID, req, completedAt, subject)
 	}
 	return &dto.AttemptResultResponse{}, nil
 }
 
-func (m *MockAssessmentAttemptService) GetAttemptResult(ctx context.Context, attemptID, studentID uuid.UUID) (*dto.AttemptResultResponse, error) {
+func (m *MockAssessmentAttemptService) GetAttemptResult(ctx context.Context, attemptID uuid.UUID, subject policy.Subject) (*dto.AttemptResultResponse, error) {
 	if m.GetAttemptResultFunc != nil {
-		return m.GetAttemptResultFunc(ctx, attemptID, studentID)
+		return m.GetAttemptResultFunc(ctx, attemptID, subject)
 	}
 	return &dto.AttemptResultResponse{}, nil
 }
@@ -218,3 +285,126 @@ func (m *MockAssessmentAttemptService) GetAttemptHistory(ctx context.Context, st
 	}
 	return &dto.AttemptHistoryResponse{}, nil
 }
+
+func (m *MockAssessmentAttemptService) GetItemAnalysis(ctx context.Context, materialID uuid.UUID, subject policy.Subject) (*dto.ItemAnalysisResponse, error) {
+	if m.GetItemAnalysisFunc != nil {
+		return m.GetItemAnalysisFunc(ctx, materialID, subject)
+	}
+	return &dto.ItemAnalysisResponse{}, nil
+}
+
+// MockReportService para tests de report_handler
+type MockReportService struct {
+	GetUnitProgressReportFunc func(ctx context.Context, academicUnitID string, subject policy.Subject) (*dto.UnitProgressReport, error)
+}
+
+func (m *MockReportService) GetUnitProgressReport(ctx context.Context, academicUnitID string, subject policy.Subject) (*dto.UnitProgressReport, error) {
+	if m.GetUnitProgressReportFunc != nil {
+		return m.GetUnitProgressReportFunc(ctx, academicUnitID, subject)
+	}
+	return &dto.UnitProgressReport{AcademicUnitID: academicUnitID, GeneratedAt: time.Now()}, nil
+}
+
+// MockActivityService para tests de activity_handler
+type MockActivityService struct {
+	GetUserActivityFunc  func(ctx context.Context, userID, from, to, timezone string) (*dto.UserActivityResponse, error)
+	GetActivityStatsFunc func(ctx context.Context, schoolID, from, to, timezone string) (*dto.ActivityStatsResponse, error)
+}
+
+func (m *MockActivityService) GetUserActivity(ctx context.Context, userID, from, to, timezone string) (*dto.UserActivityResponse, error) {
+	if m.GetUserActivityFunc != nil {
+		return m.GetUserActivityFunc(ctx, userID, from, to, timezone)
+	}
+	return &dto.UserActivityResponse{Timezone: "UTC", Days: []dto.DailyActivityDTO{}}, nil
+}
+
+func (m *MockActivityService) GetActivityStats(ctx context.Context, schoolID, from, to, timezone string) (*dto.ActivityStatsResponse, error) {
+	if m.GetActivityStatsFunc != nil {
+		return m.GetActivityStatsFunc(ctx, schoolID, from, to, timezone)
+	}
+	return &dto.ActivityStatsResponse{Timezone: "UTC", Days: []dto.DailyActivityDTO{}}, nil
+}
+
+func (m *MockActivityService) HandleProgressEvent(ctx context.Context, event eventbus.Event) error {
+	return nil
+}
+
+// MockLearningPathService para tests de learning_path_handler
+type MockLearningPathService struct {
+	CreatePathFunc      func(ctx context.Context, req dto.LearningPathRequest, authorID, schoolID string) (*dto.LearningPathResponse, error)
+	UpdatePathFunc      func(ctx context.Context, pathID string, req dto.LearningPathRequest, schoolID string) (*dto.LearningPathResponse, error)
+	DeletePathFunc      func(ctx context.Context, pathID, schoolID string) error
+	ListPathsFunc       func(ctx context.Context, learner service.PathLearner) ([]dto.LearningPathResponse, error)
+	GetPathFunc         func(ctx context.Context, pathID string, learner service.PathLearner) (*dto.LearningPathResponse, error)
+	GetMaterialLockFunc func(ctx context.Context, materialID string, learner service.PathLearner) (*dto.MaterialLock, error)
+}
+
+func (m *MockLearningPathService) CreatePath(ctx context.Context, req dto.LearningPathRequest, authorID, schoolID string) (*dto.LearningPathResponse, error) {
+	if m.CreatePathFunc != nil {
+		return m.CreatePathFunc(ctx, req, authorID, schoolID)
+	}
+	return &dto.LearningPathResponse{}, nil
+}
+
+func (m *MockLearningPathService) UpdatePath(ctx context.Context, pathID string, req dto.LearningPathRequest, schoolID string) (*dto.LearningPathResponse, error) {
+	if m.UpdatePathFunc != nil {
+		return m.UpdatePathFunc(ctx, pathID, req, schoolID)
+	}
+	return &dto.LearningPathResponse{}, nil
+}
+
+func (m *MockLearningPathService) DeletePath(ctx context.Context, pathID, schoolID string) error {
+	if m.DeletePathFunc != nil {
+		return m.DeletePathFunc(ctx, pathID, schoolID)
+	}
+	return nil
+}
+
+func (m *MockLearningPathService) ListPaths(ctx context.Context, learner service.PathLearner) ([]dto.LearningPathResponse, error) {
+	if m.ListPathsFunc != nil {
+		return m.ListPathsFunc(ctx, learner)
+	}
+	return []dto.LearningPathResponse{}, nil
+}
+
+func (m *MockLearningPathService) GetPath(ctx context.Context, pathID string, learner service.PathLearner) (*dto.LearningPathResponse, error) {
+	if m.GetPathFunc != nil {
+		return m.GetPathFunc(ctx, pathID, learner)
+	}
+	return &dto.LearningPathResponse{}, nil
+}
+
+func (m *MockLearningPathService) GetMaterialLock(ctx context.Context, materialID string, learner service.PathLearner) (*dto.MaterialLock, error) {
+	if m.GetMaterialLockFunc != nil {
+		return m.GetMaterialLockFunc(ctx, materialID, learner)
+	}
+	return nil, nil
+}
+
+// MockReviewService es un mock de ReviewService
+type MockReviewService struct {
+	GetReviewQueueFunc func(ctx context.Context, userID string, limit int) (*dto.ReviewQueueResponse, error)
+	GradeReviewFunc    func(ctx context.Context, userID, itemID string, grade int) (*dto.ReviewItemDTO, error)
+}
+
+func (m *MockReviewService) GetReviewQueue(ctx context.Context, userID string, limit int) (*dto.ReviewQueueResponse, error) {
+	if m.GetReviewQueueFunc != nil {
+		return m.GetReviewQueueFunc(ctx, userID, limit)
+	}
+	return &dto.ReviewQueueResponse{Items: []dto.ReviewItemDTO{}}, nil
+}
+
+func (m *MockReviewService) GradeReview(ctx context.Context, userID, itemID string, grade int) (*dto.ReviewItemDTO, error) {
+	if m.GradeReviewFunc != nil {
+		return m.GradeReviewFunc(ctx, userID, itemID, grade)
+	}
+	return &dto.ReviewItemDTO{ID: itemID}, nil
+}
+
+func (m *MockReviewService) HandleAttemptCompleted(ctx context.Context, event eventbus.Event) error {
+	return nil
+}
+
+func (m *MockReviewService) HandleMaterialCompleted(ctx context.Context, event eventbus.Event) error {
+	return nil
+}
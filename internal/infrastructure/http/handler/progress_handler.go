@@ -2,9 +2,12 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
@@ -35,6 +38,8 @@ func NewProgressHandler(progressService service.ProgressService, logger logger.L
 // @Failure 400 {object} ErrorResponse "Invalid request (bad UUID, percentage out of range)"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 403 {object} ErrorResponse "Forbidden (user can only update own progress)"
+// @Failure 403 {object} MaterialLockedResponse "Material locked by a learning path"
+// @Failure 404 {object} ErrorResponse "Material not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /v1/progress [put]
 // @Security BearerAuth
@@ -100,9 +105,13 @@ func (h *ProgressHandler) UpsertProgress(c *gin.Context) {
 		schoolID.String(),
 		req.ProgressPercentage,
 		req.LastPage,
+		policy.NewSubject(authenticatedUserID, middleware.GetActiveContext(c)),
 	)
 
 	if err != nil {
+		if respondMaterialLocked(c, err) {
+			return
+		}
 		// Manejar errores de aplicación
 		if appErr, ok := errors.GetAppError(err); ok {
 			h.logger.Error("application error during progress update",
@@ -135,6 +144,133 @@ func (h *ProgressHandler) UpsertProgress(c *gin.Context) {
 	})
 }
 
+// ListMyProgress godoc
+// @Summary List my learning progress
+// @Description Lists every material the authenticated user has started with percentage, last page, last access, best assessment score and pass status. Sorted by most recent access
+// @Tags progress
+// @Produce json
+// @Param status query string false "Filter by state" Enums(in_progress, completed)
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.UserProgressListResponse "User progress"
+// @Failure 400 {object} ErrorResponse "Invalid status filter"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/users/me/progress [get]
+// @Security BearerAuth
+func (h *ProgressHandler) ListMyProgress(c *gin.Context) {
+	userID := ginmiddleware.MustGetUserID(c)
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	progress, err := h.progressService.ListUserProgress(c.Request.Context(), userID, c.Query("status"), limit, offset)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// RecordReadingEvents godoc
+// @Summary Record reading events
+// @Description Records a batch of reading events (page or section viewed with dwell seconds, or video playback position) for the authenticated user. Events are append-only and deduplicated by client_event_id. Percentage and time on task are derived server-side; stored progress never decreases unless reset. Large jumps with little time on task are flagged
+// @Tags progress
+// @Accept json
+// @Produce json
+// @Param request body dto.ReadingEventsRequest true "Reading events batch"
+// @Success 200 {object} dto.ReadingEventsResponse "Derived progress"
+// @Failure 400 {object} ErrorResponse "Invalid batch (bad UUID, unknown material size, page out of range)"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} MaterialLockedResponse "Material locked by a learning path"
+// @Failure 404 {object} ErrorResponse "Material not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/progress/events [post]
+// @Security BearerAuth
+func (h *ProgressHandler) RecordReadingEvents(c *gin.Context) {
+	userID := ginmiddleware.MustGetUserID(c)
+	schoolID := middleware.MustGetSchoolIDFromContext(c)
+
+	var req dto.ReadingEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid reading events body", "error", err.Error())
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	subject := policy.NewSubject(userID, middleware.GetActiveContext(c))
+	result, err := h.progressService.RecordReadingEvents(c.Request.Context(), userID, schoolID.String(), req, subject)
+	if err != nil {
+		if respondMaterialLocked(c, err) {
+			return
+		}
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		h.logger.Error("unexpected error recording reading events", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ResetProgress godoc
+// @Summary Reset my progress in a material
+// @Description Explicitly resets the authenticated user's progress in a material to 0. Reading events recorded before the reset no longer count toward the derived percentage
+// @Tags progress
+// @Accept json
+// @Produce json
+// @Param request body ResetProgressRequest true "Material to reset"
+// @Success 204 "Progress reset"
+// @Failure 400 {object} ErrorResponse "Invalid material_id"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Material not found or no progress in this material"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/progress/reset [post]
+// @Security BearerAuth
+func (h *ProgressHandler) ResetProgress(c *gin.Context) {
+	userID := ginmiddleware.MustGetUserID(c)
+	schoolID := middleware.MustGetSchoolIDFromContext(c)
+
+	var req ResetProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "material_id is required", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	subject := policy.NewSubject(userID, middleware.GetActiveContext(c))
+	if err := h.progressService.ResetProgress(c.Request.Context(), req.MaterialID, userID, schoolID.String(), subject); err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		h.logger.Error("unexpected error resetting progress", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResetProgressRequest representa la solicitud de reinicio de progreso
+type ResetProgressRequest struct {
+	MaterialID string `json:"material_id" binding:"required" example:"660e8400-e29b-41d4-a716-446655440001"`
+}
+
 // UpsertProgressRequest representa la solicitud de actualización de progreso
 type UpsertProgressRequest struct {
 	UserID             string `json:"user_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
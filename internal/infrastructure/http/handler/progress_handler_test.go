@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 )
 
@@ -558,3 +559,185 @@ func TestProgressHandler_UpsertProgress_Idempotency(t *testing.T) {
 	// Verificar que el servicio fue llamado 3 veces
 	assert.Equal(t, 3, callCount)
 }
+
+// TestProgressHandler_ListMyProgress_Success verifica el listado del progreso propio con filtros y paginación
+func TestProgressHandler_ListMyProgress_Success(t *testing.T) {
+	// Arrange
+	authenticatedUserID := "550e8400-e29b-41d4-a716-446655440000"
+	bestScore := 85.0
+	passed := true
+
+	mockService := &MockProgressService{
+		ListUserProgressFunc: func(ctx context.Context, userID, status string, limit, offset int) (*dto.UserProgressListResponse, error) {
+			assert.Equal(t, authenticatedUserID, userID)
+			assert.Equal(t, "completed", status)
+			assert.Equal(t, 5, limit)
+			assert.Equal(t, 0, offset, "offset inválido usa el default")
+			return &dto.UserProgressListResponse{
+				Items: []dto.UserProgressDTO{{
+					MaterialID:         "660e8400-e29b-41d4-a716-446655440001",
+					ProgressPercentage: 100,
+					BestScore:          &bestScore,
+					Passed:             &passed,
+				}},
+				TotalCount: 1,
+				Limit:      limit,
+				Offset:     offset,
+			}, nil
+		},
+	}
+
+	handler := NewProgressHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/users/me/progress", MockAuthMiddleware(authenticatedUserID, "880e8400-e29b-41d4-a716-446655440003"), handler.ListMyProgress)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/me/progress?status=completed&limit=5&offset=-3", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.UserProgressListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Items, 1)
+	assert.Equal(t, int64(1), response.TotalCount)
+	assert.Equal(t, 85.0, *response.Items[0].BestScore)
+	assert.True(t, *response.Items[0].Passed)
+}
+
+// TestProgressHandler_ListMyProgress_InvalidStatus verifica el mapeo del error de validación
+func TestProgressHandler_ListMyProgress_InvalidStatus(t *testing.T) {
+	// Arrange
+	mockService := &MockProgressService{
+		ListUserProgressFunc: func(ctx context.Context, userID, status string, limit, offset int) (*dto.UserProgressListResponse, error) {
+			return nil, errors.NewValidationError("status must be in_progress or completed")
+		},
+	}
+
+	handler := NewProgressHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/users/me/progress", MockAuthMiddleware("550e8400-e29b-41d4-a716-446655440000", "880e8400-e29b-41d4-a716-446655440003"), handler.ListMyProgress)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/me/progress?status=archived", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// TestProgressHandler_RecordReadingEvents_Success verifica que el lote llegue al servicio con el usuario autenticado
+func TestProgressHandler_RecordReadingEvents_Success(t *testing.T) {
+	// Arrange
+	authenticatedUserID := "550e8400-e29b-41d4-a716-446655440000"
+	schoolID := "880e8400-e29b-41d4-a716-446655440003"
+	mockService := &MockProgressService{
+		RecordReadingFunc: func(ctx context.Context, userID, schID string, req dto.ReadingEventsRequest) (*dto.ReadingEventsResponse, error) {
+			assert.Equal(t, authenticatedUserID, userID)
+			assert.Equal(t, schoolID, schID)
+			assert.Equal(t, "660e8400-e29b-41d4-a716-446655440001", req.MaterialID)
+			require.Len(t, req.Events, 2)
+			assert.Equal(t, "page", req.Events[1].Type)
+			return &dto.ReadingEventsResponse{
+				MaterialID:         req.MaterialID,
+				ProgressPercentage: 5,
+				DerivedPercentage:  5,
+				TimeOnTaskSeconds:  90,
+				Accepted:           2,
+			}, nil
+		},
+	}
+
+	handler := NewProgressHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.POST("/progress/events", MockAuthMiddleware(authenticatedUserID, schoolID), handler.RecordReadingEvents)
+
+	reqBody := `{
+		"material_id": "660e8400-e29b-41d4-a716-446655440001",
+		"events": [
+			{"client_event_id": "e1", "type": "page", "page": 1, "dwell_seconds": 45},
+			{"client_event_id": "e2", "type": "page", "page": 2, "dwell_seconds": 45}
+		]
+	}`
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/progress/events", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.ReadingEventsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 5, response.ProgressPercentage)
+	assert.Equal(t, 90, response.TimeOnTaskSeconds)
+}
+
+// TestProgressHandler_RecordReadingEvents_InvalidBody verifica la validación del lote antes del servicio
+func TestProgressHandler_RecordReadingEvents_InvalidBody(t *testing.T) {
+	bodies := map[string]string{
+		"no events":    `{"material_id": "660e8400-e29b-41d4-a716-446655440001", "events": []}`,
+		"unknown type": `{"material_id": "660e8400-e29b-41d4-a716-446655440001", "events": [{"client_event_id": "e1", "type": "scroll"}]}`,
+		"malformed":    `{"material_id":`,
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			mockService := &MockProgressService{
+				RecordReadingFunc: func(ctx context.Context, userID, schoolID string, req dto.ReadingEventsRequest) (*dto.ReadingEventsResponse, error) {
+					t.Fatal("service must not be called")
+					return nil, nil
+				},
+			}
+			handler := NewProgressHandler(mockService, NewTestLogger())
+			router := SetupTestRouter()
+			router.POST("/progress/events", MockAuthMiddleware("550e8400-e29b-41d4-a716-446655440000", "880e8400-e29b-41d4-a716-446655440003"), handler.RecordReadingEvents)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/progress/events", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+// TestProgressHandler_ResetProgress verifica el reinicio y el mapeo de progreso inexistente
+func TestProgressHandler_ResetProgress(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"reset", nil, http.StatusNoContent},
+		{"no progress", errors.NewNotFoundError("progress"), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockProgressService{
+				ResetProgressFunc: func(ctx context.Context, materialID, userID, schoolID string) error {
+					assert.Equal(t, "660e8400-e29b-41d4-a716-446655440001", materialID)
+					assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", userID)
+					return tt.serviceErr
+				},
+			}
+			handler := NewProgressHandler(mockService, NewTestLogger())
+			router := SetupTestRouter()
+			router.POST("/progress/reset", MockAuthMiddleware("550e8400-e29b-41d4-a716-446655440000", "880e8400-e29b-41d4-a716-446655440003"), handler.ResetProgress)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/progress/reset", strings.NewReader(`{"material_id": "660e8400-e29b-41d4-a716-446655440001"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/export"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// Formatos soportados por los reportes descargables
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+	reportFormatXLSX = "xlsx"
+)
+
+type ReportHandler struct {
+	reportService service.ReportService
+	logger        logger.Logger
+}
+
+func NewReportHandler(reportService service.ReportService, logger logger.Logger) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+		logger:        logger,
+	}
+}
+
+// GetUnitProgressReport godoc
+// @Summary Get academic unit progress report
+// @Description Libro de calificaciones de la unidad: progreso y mejor puntaje de cada estudiante en cada material. format=csv|xlsx descarga el reporte como archivo
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path string true "Academic unit ID (UUID format)"
+// @Param format query string false "Formato de salida: json (default), csv o xlsx"
+// @Success 200 {object} dto.UnitProgressReport "Report generated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid academic unit ID or format"
+// @Failure 403 {object} ErrorResponse "Academic unit outside the active context"
+// @Failure 404 {object} ErrorResponse "Academic unit not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/academic-units/{id}/reports/progress [get]
+// @Security BearerAuth
+func (h *ReportHandler) GetUnitProgressReport(c *gin.Context) {
+	unitID := c.Param("id")
+
+	format := c.DefaultQuery("format", reportFormatJSON)
+	if format != reportFormatJSON && format != reportFormatCSV && format != reportFormatXLSX {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "format must be json, csv or xlsx", Code: "INVALID_FORMAT"})
+		return
+	}
+
+	// El servicio aplica la política de acceso: sin stats:school solo la unidad del contexto activo
+	subject := policy.NewSubject(middleware.GetUserID(c), middleware.GetActiveContext(c))
+	report, err := h.reportService.GetUnitProgressReport(c.Request.Context(), unitID, subject)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	if format == reportFormatJSON {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	// Se serializa a memoria para poder responder 500 si falla la exportación
+	var buf bytes.Buffer
+	table := gradebookTable(report)
+	contentType := export.ContentTypeCSV
+	if format == reportFormatXLSX {
+		contentType = export.ContentTypeXLSX
+		err = export.WriteXLSX(&buf, report.AcademicUnitName, table)
+	} else {
+		err = export.WriteCSV(&buf, table)
+	}
+	if err != nil {
+		h.logger.Error("failed to export progress report", "academic_unit_id", unitID, "format", format, "error", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to export report", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	filename := fmt.Sprintf("gradebook-%s-%s.%s", unitID, report.GeneratedAt.Format("2006-01-02"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// gradebookTable aplana el reporte: una fila por estudiante y dos columnas (progreso, mejor puntaje) por material
+func gradebookTable(report *dto.UnitProgressReport) export.Table {
+	headers := []string{"student_id", "last_name", "first_name", "email"}
+	for _, m := range report.Materials {
+		headers = append(headers, m.Title+" - progress %", m.Title+" - best score")
+	}
+	headers = append(headers, "avg_progress", "completed_materials", "avg_best_score")
+
+	rows := make([][]any, 0, len(report.Students))
+	for _, s := range report.Students {
+		row := []any{s.StudentID, s.LastName, s.FirstName, s.Email}
+		for _, result := range s.Materials {
+			row = append(row, result.ProgressPercentage, roundScore(result.BestScore))
+		}
+		row = append(row, round2(s.AvgProgress), s.CompletedMaterials, roundScore(s.AvgBestScore))
+		rows = append(rows, row)
+	}
+
+	return export.Table{Headers: headers, Rows: rows}
+}
+
+func roundScore(score *float64) *float64 {
+	if score == nil {
+		return nil
+	}
+	rounded := round2(*score)
+	return &rounded
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
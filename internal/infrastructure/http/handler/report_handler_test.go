@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/export"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+func sampleUnitReport(unitID string) *dto.UnitProgressReport {
+	best := 85.0
+	passed := true
+	avg := 85.0
+	return &dto.UnitProgressReport{
+		AcademicUnitID:   unitID,
+		AcademicUnitName: "5to A",
+		Materials: []dto.ReportMaterialDTO{
+			{MaterialID: "m1", Title: "Fotosíntesis", HasAssessment: true, StartedCount: 1, CompletedCount: 1, AvgProgress: 50},
+			{MaterialID: "m2", Title: "Células"},
+		},
+		Students: []dto.StudentReportDTO{
+			{
+				StudentID: "s1", FirstName: "Ana", LastName: "Pérez", Email: "ana@edugo.test",
+				AvgProgress: 50, CompletedMaterials: 1, AvgBestScore: &avg,
+				Materials: []dto.StudentMaterialResultDTO{
+					{MaterialID: "m1", ProgressPercentage: 100, AttemptsCount: 2, BestScore: &best, Passed: &passed},
+					{MaterialID: "m2"},
+				},
+			},
+		},
+		GeneratedAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func newReportTestRouter(svc *MockReportService, permissions ...string) *gin.Engine {
+	handler := NewReportHandler(svc, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/academic-units/:id/reports/progress", streamAuthMiddleware(permissions...), handler.GetUnitProgressReport)
+	return router
+}
+
+// TestReportHandler_GetUnitProgressReport_JSON verifica el reporte por defecto y la escuela propagada
+func TestReportHandler_GetUnitProgressReport_JSON(t *testing.T) {
+	var gotSchool string
+	svc := &MockReportService{
+		GetUnitProgressReportFunc: func(ctx context.Context, unitID string, subject policy.Subject) (*dto.UnitProgressReport, error) {
+			gotSchool = subject.SchoolID
+			return sampleUnitReport(unitID), nil
+		},
+	}
+	router := newReportTestRouter(svc, "stats:unit")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/academic-units/"+streamTestUnitID+"/reports/progress", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, streamTestSchoolID, gotSchool)
+
+	var report dto.UnitProgressReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, streamTestUnitID, report.AcademicUnitID)
+	require.Len(t, report.Students, 1)
+	assert.Equal(t, 100, report.Students[0].Materials[0].ProgressPercentage)
+}
+
+// TestReportHandler_GetUnitProgressReport_CSV verifica encabezados de descarga y columnas por material
+func TestReportHandler_GetUnitProgressReport_CSV(t *testing.T) {
+	svc := &MockReportService{
+		GetUnitProgressReportFunc: func(ctx context.Context, unitID string, subject policy.Subject) (*dto.UnitProgressReport, error) {
+			return sampleUnitReport(unitID), nil
+		},
+	}
+	router := newReportTestRouter(svc, "stats:unit")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/academic-units/"+streamTestUnitID+"/reports/progress?format=csv", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, export.ContentTypeCSV, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "gradebook-"+streamTestUnitID+"-2026-03-10.csv")
+
+	records, err := csv.NewReader(bytes.NewReader(w.Body.Bytes())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{
+		"student_id", "last_name", "first_name", "email",
+		"Fotosíntesis - progress %", "Fotosíntesis - best score",
+		"Células - progress %", "Células - best score",
+		"avg_progress", "completed_materials", "avg_best_score",
+	}, records[0])
+	assert.Equal(t, []string{"s1", "Pérez", "Ana", "ana@edugo.test", "100", "85", "0", "", "50", "1", "85"}, records[1])
+}
+
+// TestReportHandler_GetUnitProgressReport_CSVMaliciousTitle verifica que un título de material con fórmula
+// se escape en las columnas del encabezado
+func TestReportHandler_GetUnitProgressReport_CSVMaliciousTitle(t *testing.T) {
+	svc := &MockReportService{
+		GetUnitProgressReportFunc: func(ctx context.Context, unitID string, subject policy.Subject) (*dto.UnitProgressReport, error) {
+			report := sampleUnitReport(unitID)
+			report.Materials[0].Title = "=HYPERLINK(\"http://evil.test\",\"click\")"
+			return report, nil
+		},
+	}
+	router := newReportTestRouter(svc, "stats:unit")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/academic-units/"+streamTestUnitID+"/reports/progress?format=csv", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	records, err := csv.NewReader(bytes.NewReader(w.Body.Bytes())).ReadAll()
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	assert.Equal(t, "'=HYPERLINK(\"http://evil.test\",\"click\") - progress %", records[0][4])
+	assert.Equal(t, "'=HYPERLINK(\"http://evil.test\",\"click\") - best score", records[0][5])
+}
+
+// TestReportHandler_GetUnitProgressReport_XLSX verifica que la descarga sea un libro XLSX válido
+func TestReportHandler_GetUnitProgressReport_XLSX(t *testing.T) {
+	svc := &MockReportService{
+		GetUnitProgressReportFunc: func(ctx context.Context, unitID string, subject policy.Subject) (*dto.UnitProgressReport, error) {
+			return sampleUnitReport(unitID), nil
+		},
+	}
+	router := newReportTestRouter(svc, "stats:unit")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/academic-units/"+streamTestUnitID+"/reports/progress?format=xlsx", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, export.ContentTypeXLSX, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".xlsx")
+
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.Contains(t, names, "xl/worksheets/sheet1.xml")
+}
+
+// TestReportHandler_GetUnitProgressReport_InvalidFormat verifica el rechazo de formatos desconocidos
+func TestReportHandler_GetUnitProgressReport_InvalidFormat(t *testing.T) {
+	router := newReportTestRouter(&MockReportService{}, "stats:unit")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/academic-units/"+streamTestUnitID+"/reports/progress?format=pdf", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// TestReportHandler_GetUnitProgressReport_OtherUnit verifica que el contexto activo llegue a la
+// política del servicio y que su rechazo responda 403
+func TestReportHandler_GetUnitProgressReport_OtherUnit(t *testing.T) {
+	otherUnit := "990e8400-e29b-41d4-a716-446655440009"
+	var gotSubject policy.Subject
+	svc := &MockReportService{
+		GetUnitProgressReportFunc: func(ctx context.Context, unitID string, subject policy.Subject) (*dto.UnitProgressReport, error) {
+			gotSubject = subject
+			return nil, errors.NewForbiddenError("cannot access reports of this academic unit")
+		},
+	}
+	router := newReportTestRouter(svc, "stats:unit")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/academic-units/"+otherUnit+"/reports/progress", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "FORBIDDEN")
+	assert.Equal(t, policy.Subject{
+		UserID:         streamTestUserID,
+		SchoolID:       streamTestSchoolID,
+		AcademicUnitID: streamTestUnitID,
+		Permissions:    []string{"stats:unit"},
+	}, gotSubject)
+}
+
+// TestReportHandler_GetUnitProgressReport_NotFound verifica la propagación de errores del servicio
+func TestReportHandler_GetUnitProgressReport_NotFound(t *testing.T) {
+	svc := &MockReportService{
+		GetUnitProgressReportFunc: func(ctx context.Context, unitID string, subject policy.Subject) (*dto.UnitProgressReport, error) {
+			return nil, errors.NewNotFoundError("academic unit")
+		},
+	}
+	router := newReportTestRouter(svc, "stats:unit")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/academic-units/"+streamTestUnitID+"/reports/progress?format=csv", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type ReviewHandler struct {
+	reviewService service.ReviewService
+	logger        logger.Logger
+}
+
+func NewReviewHandler(reviewService service.ReviewService, logger logger.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+		logger:        logger,
+	}
+}
+
+// GetMyReviewQueue godoc
+// @Summary Get my review queue
+// @Description Spaced-repetition items due for the authenticated user: questions answered wrong in assessments and glossary terms of completed materials, most overdue first. Scheduling follows SM-2
+// @Tags progress
+// @Produce json
+// @Param limit query int false "Max items (1-100)" default(20)
+// @Success 200 {object} dto.ReviewQueueResponse "Due review items"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/users/me/review [get]
+// @Security BearerAuth
+func (h *ReviewHandler) GetMyReviewQueue(c *gin.Context) {
+	userID := ginmiddleware.MustGetUserID(c)
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	queue, err := h.reviewService.GetReviewQueue(c.Request.Context(), userID, limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// GradeReviewItem godoc
+// @Summary Grade recall of a review item
+// @Description Records how well the user recalled the item (0-5, SM-2) and returns its new schedule. Grades below 3 bring the item back the next day
+// @Tags progress
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Review item ID (UUID)"
+// @Param request body dto.GradeReviewRequest true "Recall grade"
+// @Success 200 {object} dto.ReviewItemDTO "Rescheduled item"
+// @Failure 400 {object} ErrorResponse "Invalid grade or item ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Review item not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/users/me/review/{itemId} [post]
+// @Security BearerAuth
+func (h *ReviewHandler) GradeReviewItem(c *gin.Context) {
+	userID := ginmiddleware.MustGetUserID(c)
+
+	var req dto.GradeReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "grade must be an integer between 0 and 5", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	item, err := h.reviewService.GradeReview(c.Request.Context(), userID, c.Param("itemId"), *req.Grade)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *ReviewHandler) respondError(c *gin.Context, err error) {
+	if appErr, ok := errors.GetAppError(err); ok {
+		c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+		return
+	}
+	h.logger.Error("unexpected review error", "error", err)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+}
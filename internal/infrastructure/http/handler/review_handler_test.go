@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+const reviewTestItemID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+func newReviewTestRouter(svc *MockReviewService) *gin.Engine {
+	handler := NewReviewHandler(svc, NewTestLogger())
+	router := SetupTestRouter()
+	router.Use(MockAuthMiddleware(streamTestUserID, streamTestSchoolID))
+	router.GET("/users/me/review", handler.GetMyReviewQueue)
+	router.POST("/users/me/review/:itemId", handler.GradeReviewItem)
+	return router
+}
+
+// TestReviewHandler_GetMyReviewQueue verifica la cola y la normalización del límite
+func TestReviewHandler_GetMyReviewQueue(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantLimit int
+	}{
+		{name: "límite por defecto", query: "", wantLimit: 20},
+		{name: "límite explícito", query: "?limit=5", wantLimit: 5},
+		{name: "límite fuera de rango", query: "?limit=500", wantLimit: 20},
+		{name: "límite inválido", query: "?limit=abc", wantLimit: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &MockReviewService{
+				GetReviewQueueFunc: func(ctx context.Context, userID string, limit int) (*dto.ReviewQueueResponse, error) {
+					assert.Equal(t, streamTestUserID, userID)
+					assert.Equal(t, tt.wantLimit, limit)
+					return &dto.ReviewQueueResponse{
+						Items:    []dto.ReviewItemDTO{{ID: reviewTestItemID, Kind: "glossary", Prompt: "suma"}},
+						DueCount: 3,
+					}, nil
+				},
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/users/me/review"+tt.query, nil)
+			newReviewTestRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var response dto.ReviewQueueResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, 3, response.DueCount)
+			require.Len(t, response.Items, 1)
+		})
+	}
+}
+
+// TestReviewHandler_GradeReviewItem verifica la calificación y sus errores
+func TestReviewHandler_GradeReviewItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "calificación válida", body: `{"grade": 4}`, wantStatus: http.StatusOK},
+		{name: "calificación cero es válida", body: `{"grade": 0}`, wantStatus: http.StatusOK},
+		{name: "sin calificación", body: `{}`, wantStatus: http.StatusBadRequest, wantCode: "INVALID_REQUEST"},
+		{name: "calificación fuera de rango", body: `{"grade": 7}`, wantStatus: http.StatusBadRequest, wantCode: "INVALID_REQUEST"},
+		{name: "ítem inexistente", body: `{"grade": 3}`, serviceErr: errors.NewNotFoundError("review item"), wantStatus: http.StatusNotFound, wantCode: "NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			svc := &MockReviewService{
+				GradeReviewFunc: func(ctx context.Context, userID, itemID string, grade int) (*dto.ReviewItemDTO, error) {
+					called = true
+					assert.Equal(t, streamTestUserID, userID)
+					assert.Equal(t, reviewTestItemID, itemID)
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &dto.ReviewItemDTO{ID: itemID, IntervalDays: 1, Repetitions: 1}, nil
+				},
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/users/me/review/"+reviewTestItemID, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			newReviewTestRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantCode != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantCode, response.Code)
+			}
+			assert.Equal(t, tt.wantCode != "INVALID_REQUEST", called)
+		})
+	}
+}
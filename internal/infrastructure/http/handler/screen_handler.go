@@ -3,8 +3,11 @@ package handler
 import (
 	"crypto/md5"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -32,12 +35,15 @@ func NewScreenHandler(screenService service.ScreenService, logger logger.Logger)
 
 // GetScreen godoc
 // @Summary Get screen definition
-// @Description Retrieves a combined screen definition by screen key
+// @Description Retrieves a combined screen definition by screen key. When an active A/B experiment targets the user (by platform, school or role), the assigned variant's instance is served under the requested key and the assignment is returned in `experiment`. With include=data, internal data endpoints (materials list, my progress, attempt history) are resolved server-side with the same permission checks and returned inline; other endpoints come back with dataIncluded=false
 // @Tags screens
 // @Produce json
 // @Param screenKey path string true "Screen key identifier"
 // @Param platform query string false "Platform (ios, android, mobile, desktop, web)"
-// @Success 200 {object} screenconfig.CombinedScreenDTO "Screen definition"
+// @Param Accept-Language header string false "Preferred languages (es, en, pt); falls back to es"
+// @Param include query string false "Comma-separated extras to embed (data)"
+// @Param If-None-Match header string false "ETag of a previously downloaded screen (ignored with include=data)"
+// @Success 200 {object} service.ScreenVariantDTO "Screen definition (service.ScreenWithDataDTO with include=data)"
 // @Success 304 "Not Modified"
 // @Failure 404 {object} ErrorResponse "Screen not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -55,7 +61,13 @@ func (h *ScreenHandler) GetScreen(c *gin.Context) {
 		return
 	}
 
-	screen, err := h.screenService.GetScreen(c.Request.Context(), screenKey, userID, platform)
+	audience := screenAudience(c, userID, platform)
+	if includes(c.Query("include"), "data") {
+		h.getScreenWithData(c, screenKey, audience)
+		return
+	}
+
+	screen, err := h.screenService.GetScreenVariant(c.Request.Context(), screenKey, audience)
 	if err != nil {
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
@@ -66,25 +78,58 @@ func (h *ScreenHandler) GetScreen(c *gin.Context) {
 		return
 	}
 
-	// Generar ETag basado en el contenido
-	screenJSON, _ := json.Marshal(screen)
-	etag := fmt.Sprintf(`"%x"`, md5.Sum(screenJSON))
+	// Headers de cache (también en el 304 para que el cliente renueve su copia)
+	// La variante depende del usuario (experimento asignado, escuela, rol e idioma): sin cache compartido y
+	// revalidando siempre con el ETag, igual que la navegación y el bundle
+	etag := screenETag(screen)
+	c.Header("ETag", etag)
+	c.Header("Content-Language", screen.Locale)
+	c.Header("Last-Modified", screen.UpdatedAt.Format(http.TimeFormat))
+	c.Header("Cache-Control", "private, no-cache")
 
 	// Soporte If-None-Match para cache condicional
-	ifNoneMatch := c.GetHeader("If-None-Match")
-	if ifNoneMatch != "" && ifNoneMatch == etag {
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
 		c.Status(http.StatusNotModified)
 		return
 	}
 
-	// Headers de cache
-	c.Header("ETag", etag)
-	c.Header("Last-Modified", screen.UpdatedAt.Format(http.TimeFormat))
-	c.Header("Cache-Control", "max-age=3600")
+	c.JSON(http.StatusOK, screen)
+}
+
+// getScreenWithData responde la pantalla con sus datos embebidos
+// Los datos cambian sin que cambie la pantalla: la respuesta no lleva ETag ni se cachea
+func (h *ScreenHandler) getScreenWithData(c *gin.Context, screenKey string, audience service.ScreenAudience) {
+	var permissions []string
+	if uc := middleware.GetActiveContext(c); uc != nil {
+		permissions = uc.Permissions
+	}
+
+	screen, err := h.screenService.GetScreenWithData(c.Request.Context(), screenKey, audience, permissions)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		h.logger.Error("unexpected error getting screen with data", "screen_key", screenKey, "error", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
 
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Content-Language", screen.Locale)
 	c.JSON(http.StatusOK, screen)
 }
 
+// includes indica si value aparece en una lista separada por comas (?include=data,other)
+func includes(list, value string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if strings.EqualFold(strings.TrimSpace(item), value) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetScreensForResource godoc
 // @Summary Get screens for a resource
 // @Description Retrieves all screen configurations linked to a resource
@@ -118,7 +163,10 @@ func (h *ScreenHandler) GetScreensForResource(c *gin.Context) {
 // @Tags screens
 // @Produce json
 // @Param platform query string false "Platform (ios, android, mobile, desktop, web)"
+// @Param Accept-Language header string false "Preferred languages (es, en, pt); falls back to es"
+// @Param If-None-Match header string false "ETag of a previously downloaded navigation"
 // @Success 200 {object} service.NavigationConfigDTO "Navigation configuration"
+// @Success 304 "Not Modified"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /v1/screens/navigation [get]
 // @Security BearerAuth
@@ -139,7 +187,7 @@ func (h *ScreenHandler) GetNavigation(c *gin.Context) {
 		permissions = uc.Permissions
 	}
 
-	nav, err := h.screenService.GetNavigationConfig(c.Request.Context(), userID, platform, permissions)
+	nav, err := h.screenService.GetNavigationConfig(c.Request.Context(), screenAudience(c, userID, platform), permissions)
 	if err != nil {
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
@@ -150,19 +198,106 @@ func (h *ScreenHandler) GetNavigation(c *gin.Context) {
 		return
 	}
 
+	// La navegación depende de los permisos del usuario: el ETag se calcula sobre el contenido
+	navJSON, _ := json.Marshal(nav)
+	etag := fmt.Sprintf(`"%x"`, md5.Sum(navJSON))
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("Content-Language", middleware.GetLocale(c))
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, nav)
 }
 
+// maxBundleSinceBytes tamaño máximo del bundle previo enviado en POST /screens/bundle
+const maxBundleSinceBytes = 256 * 1024
+
+// GetBundle godoc
+// @Summary Get screen bundle
+// @Description Retrieves navigation plus every screen reachable with the user's permissions, resolved for the platform and merged with the user's preferences and experiment variants. With GET, `since` returns an empty delta when the bundle did not change and the full bundle otherwise. To receive only what changed, POST the `hash`, `platform`, `locale`, `navigationHash` and `versions` of the bundle the client already has. The response is gzip-compressed when the client accepts it
+// @Tags screens
+// @Accept json
+// @Produce json
+// @Param platform query string false "Platform (ios, android, mobile, desktop, web)"
+// @Param Accept-Language header string false "Preferred languages (es, en, pt); falls back to es"
+// @Param since query string false "Hash of the bundle the client already has (GET)"
+// @Param request body service.BundleSince false "Bundle the client already has (POST)"
+// @Param If-None-Match header string false "Hash of the bundle the client already has, as ETag"
+// @Success 200 {object} service.ScreenBundleDTO "Full bundle, or delta against the bundle the client sent"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} ErrorResponse "Invalid user or request body"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/screens/bundle [get]
+// @Router /v1/screens/bundle [post]
+// @Security BearerAuth
+func (h *ScreenHandler) GetBundle(c *gin.Context) {
+	platform := c.DefaultQuery("platform", "mobile")
+	userIDStr := ginmiddleware.MustGetUserID(c)
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.logger.Warn("invalid user_id format", "user_id", userIDStr, "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id", Code: "INVALID_USER_ID"})
+		return
+	}
+
+	// El delta se calcula contra el bundle que envía el cliente: ninguna instancia guarda bundles
+	since := &service.BundleSince{Hash: c.Query("since")}
+	if c.Request.Method == http.MethodPost {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBundleSinceBytes)
+		if err := c.ShouldBindJSON(since); err != nil {
+			h.logger.Warn("invalid bundle request body", "error", err)
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+			return
+		}
+	}
+
+	var permissions []string
+	if uc := middleware.GetActiveContext(c); uc != nil {
+		permissions = uc.Permissions
+	}
+
+	bundle, err := h.screenService.GetScreenBundle(c.Request.Context(), screenAudience(c, userID, platform), permissions, since)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		h.logger.Error("unexpected error getting screen bundle", "error", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	// El hash del bundle ya depende de permisos, plataforma y preferencias: sirve como ETag
+	etag := `"` + bundle.Hash + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("Content-Language", bundle.Locale)
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, bundle)
+}
+
 // SavePreferences godoc
 // @Summary Save user preferences for a screen
-// @Description Saves user-specific preferences for a screen
+// @Description Replaces the user-specific preferences for a screen. Preferences are validated against the `preferences` block of the screen template (or, without it, limited to flat values) and zones not present in the template are rejected. The body is limited to 16KB. The previous version is kept in the history
 // @Tags screens
 // @Accept json
 // @Produce json
 // @Param screenKey path string true "Screen key identifier"
 // @Param request body json.RawMessage true "User preferences JSON"
 // @Success 204 "Preferences saved"
-// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 400 {object} ErrorResponse "Invalid request body or preferences not matching the schema"
+// @Failure 404 {object} ErrorResponse "Screen not found"
+// @Failure 413 {object} ErrorResponse "Preferences too large"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /v1/screens/{screenKey}/preferences [put]
 // @Security BearerAuth
@@ -177,10 +312,8 @@ func (h *ScreenHandler) SavePreferences(c *gin.Context) {
 		return
 	}
 
-	var prefs json.RawMessage
-	if err := c.ShouldBindJSON(&prefs); err != nil {
-		h.logger.Warn("invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+	prefs, ok := h.readPreferencesBody(c)
+	if !ok {
 		return
 	}
 
@@ -196,3 +329,182 @@ func (h *ScreenHandler) SavePreferences(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// PatchPreferences godoc
+// @Summary Patch user preferences for a screen
+// @Description Applies a JSON Merge Patch (RFC 7386) to the user preferences: null removes a key and nested objects are merged. The result is validated like PUT and returned
+// @Tags screens
+// @Accept json
+// @Produce json
+// @Param screenKey path string true "Screen key identifier"
+// @Param request body json.RawMessage true "JSON Merge Patch"
+// @Success 200 {object} json.RawMessage "Resulting preferences"
+// @Failure 400 {object} ErrorResponse "Invalid patch or resulting preferences not matching the schema"
+// @Failure 404 {object} ErrorResponse "Screen not found"
+// @Failure 413 {object} ErrorResponse "Patch too large"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/screens/{screenKey}/preferences [patch]
+// @Security BearerAuth
+func (h *ScreenHandler) PatchPreferences(c *gin.Context) {
+	screenKey := c.Param("screenKey")
+	userIDStr := ginmiddleware.MustGetUserID(c)
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.logger.Warn("invalid user_id format", "user_id", userIDStr, "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id", Code: "INVALID_USER_ID"})
+		return
+	}
+
+	patch, ok := h.readPreferencesBody(c)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.screenService.PatchUserPreferences(c.Request.Context(), screenKey, userID, patch)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		h.logger.Error("unexpected error patching preferences", "screen_key", screenKey, "error", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", prefs)
+}
+
+// ResetPreferences godoc
+// @Summary Reset user preferences for a screen
+// @Description Deletes the user preferences so the screen renders with its defaults. The deleted version is kept in the history
+// @Tags screens
+// @Param screenKey path string true "Screen key identifier"
+// @Success 204 "Preferences reset"
+// @Failure 400 {object} ErrorResponse "Invalid user"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/screens/{screenKey}/preferences [delete]
+// @Security BearerAuth
+func (h *ScreenHandler) ResetPreferences(c *gin.Context) {
+	screenKey := c.Param("screenKey")
+	userIDStr := ginmiddleware.MustGetUserID(c)
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.logger.Warn("invalid user_id format", "user_id", userIDStr, "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id", Code: "INVALID_USER_ID"})
+		return
+	}
+
+	if err := h.screenService.ResetUserPreferences(c.Request.Context(), screenKey, userID); err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		h.logger.Error("unexpected error resetting preferences", "screen_key", screenKey, "error", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPreferencesHistory godoc
+// @Summary Get user preferences history for a screen
+// @Description Lists the previous versions of the user preferences, newest first. Restore one by sending it back with PUT
+// @Tags screens
+// @Produce json
+// @Param screenKey path string true "Screen key identifier"
+// @Success 200 {array} dto.ScreenPreferencesVersionDTO "Previous versions"
+// @Failure 400 {object} ErrorResponse "Invalid user"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/screens/{screenKey}/preferences/history [get]
+// @Security BearerAuth
+func (h *ScreenHandler) GetPreferencesHistory(c *gin.Context) {
+	screenKey := c.Param("screenKey")
+	userIDStr := ginmiddleware.MustGetUserID(c)
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.logger.Warn("invalid user_id format", "user_id", userIDStr, "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user_id", Code: "INVALID_USER_ID"})
+		return
+	}
+
+	history, err := h.screenService.GetUserPreferencesHistory(c.Request.Context(), screenKey, userID)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		h.logger.Error("unexpected error getting preferences history", "screen_key", screenKey, "error", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// readPreferencesBody lee el cuerpo JSON sin aceptar más de service.MaxScreenPreferencesBytes
+// Responde 413 o 400 y retorna false si el cuerpo no sirve
+func (h *ScreenHandler) readPreferencesBody(c *gin.Context) (json.RawMessage, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, service.MaxScreenPreferencesBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error: fmt.Sprintf("preferences exceed %d bytes", service.MaxScreenPreferencesBytes),
+				Code:  "PAYLOAD_TOO_LARGE",
+			})
+			return nil, false
+		}
+		h.logger.Warn("invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return nil, false
+	}
+
+	if !json.Valid(body) {
+		h.logger.Warn("invalid request body", "error", "malformed JSON")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return nil, false
+	}
+	return json.RawMessage(body), true
+}
+
+// screenETag identifica la versión de una pantalla para el usuario (ver service.ScreenFingerprint)
+// Incluye la variante asignada y el idioma: cambiar cualquiera invalida la copia del cliente
+func screenETag(screen *service.ScreenVariantDTO) string {
+	fingerprint := service.ScreenFingerprint(screen.CombinedScreenDTO)
+	if screen.Experiment != nil {
+		fingerprint += "-" + screen.Experiment.Variant
+	}
+	if screen.Locale != "" {
+		fingerprint += "-" + screen.Locale
+	}
+	return `"` + fingerprint + `"`
+}
+
+// screenAudience arma la audiencia con el idioma negociado y, para el targeting de experimentos,
+// la escuela y el rol del contexto activo
+func screenAudience(c *gin.Context, userID uuid.UUID, platform string) service.ScreenAudience {
+	audience := service.ScreenAudience{UserID: userID, Platform: platform, Locale: middleware.GetLocale(c)}
+	if uc := middleware.GetActiveContext(c); uc != nil {
+		audience.SchoolID = uc.SchoolID
+		audience.Role = uc.RoleName
+	}
+	return audience
+}
+
+// etagMatches evalúa If-None-Match: acepta una lista separada por comas, "*" y ETags débiles (W/)
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -16,6 +16,8 @@ import (
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/EduGoGroup/edugo-shared/screenconfig"
 )
@@ -26,9 +28,15 @@ import (
 
 type MockScreenService struct {
 	GetScreenFunc             func(ctx context.Context, screenKey string, userID uuid.UUID, platform string) (*dto.CombinedScreenDTO, error)
-	GetNavigationConfigFunc   func(ctx context.Context, userID uuid.UUID, platform string, permissions []string) (*service.NavigationConfigDTO, error)
+	GetNavigationConfigFunc   func(ctx context.Context, audience service.ScreenAudience, permissions []string) (*service.NavigationConfigDTO, error)
 	SaveUserPreferencesFunc   func(ctx context.Context, screenKey string, userID uuid.UUID, prefs json.RawMessage) error
+	PatchUserPreferencesFunc  func(ctx context.Context, screenKey string, userID uuid.UUID, patch json.RawMessage) (json.RawMessage, error)
+	ResetUserPreferencesFunc  func(ctx context.Context, screenKey string, userID uuid.UUID) error
+	GetPreferencesHistoryFunc func(ctx context.Context, screenKey string, userID uuid.UUID) ([]dto.ScreenPreferencesVersionDTO, error)
 	GetScreensForResourceFunc func(ctx context.Context, resourceKey string) ([]*dto.ResourceScreenDTO, error)
+	GetScreenVariantFunc      func(ctx context.Context, screenKey string, audience service.ScreenAudience) (*service.ScreenVariantDTO, error)
+	GetScreenWithDataFunc     func(ctx context.Context, screenKey string, audience service.ScreenAudience, permissions []string) (*service.ScreenWithDataDTO, error)
+	GetScreenBundleFunc       func(ctx context.Context, audience service.ScreenAudience, permissions []string, since *service.BundleSince) (*service.ScreenBundleDTO, error)
 }
 
 func (m *MockScreenService) GetScreen(ctx context.Context, screenKey string, userID uuid.UUID, platform string) (*dto.CombinedScreenDTO, error) {
@@ -38,9 +46,28 @@ func (m *MockScreenService) GetScreen(ctx context.Context, screenKey string, use
 	return &dto.CombinedScreenDTO{ScreenKey: screenKey}, nil
 }
 
-func (m *MockScreenService) GetNavigationConfig(ctx context.Context, userID uuid.UUID, platform string, permissions []string) (*service.NavigationConfigDTO, error) {
+// GetScreenVariant sin GetScreenVariantFunc delega en GetScreen (pantalla sin experimento)
+func (m *MockScreenService) GetScreenVariant(ctx context.Context, screenKey string, audience service.ScreenAudience) (*service.ScreenVariantDTO, error) {
+	if m.GetScreenVariantFunc != nil {
+		return m.GetScreenVariantFunc(ctx, screenKey, audience)
+	}
+	screen, err := m.GetScreen(ctx, screenKey, audience.UserID, audience.Platform)
+	if err != nil {
+		return nil, err
+	}
+	return &service.ScreenVariantDTO{CombinedScreenDTO: screen, Locale: audience.Locale}, nil
+}
+
+func (m *MockScreenService) GetScreenWithData(ctx context.Context, screenKey string, audience service.ScreenAudience, permissions []string) (*service.ScreenWithDataDTO, error) {
+	if m.GetScreenWithDataFunc != nil {
+		return m.GetScreenWithDataFunc(ctx, screenKey, audience, permissions)
+	}
+	return &service.ScreenWithDataDTO{CombinedScreenDTO: &dto.CombinedScreenDTO{ScreenKey: screenKey}}, nil
+}
+
+func (m *MockScreenService) GetNavigationConfig(ctx context.Context, audience service.ScreenAudience, permissions []string) (*service.NavigationConfigDTO, error) {
 	if m.GetNavigationConfigFunc != nil {
-		return m.GetNavigationConfigFunc(ctx, userID, platform, permissions)
+		return m.GetNavigationConfigFunc(ctx, audience, permissions)
 	}
 	return &service.NavigationConfigDTO{
 		BottomNav:   []service.NavItemDTO{},
@@ -56,6 +83,27 @@ func (m *MockScreenService) SaveUserPreferences(ctx context.Context, screenKey s
 	return nil
 }
 
+func (m *MockScreenService) PatchUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID, patch json.RawMessage) (json.RawMessage, error) {
+	if m.PatchUserPreferencesFunc != nil {
+		return m.PatchUserPreferencesFunc(ctx, screenKey, userID, patch)
+	}
+	return patch, nil
+}
+
+func (m *MockScreenService) ResetUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID) error {
+	if m.ResetUserPreferencesFunc != nil {
+		return m.ResetUserPreferencesFunc(ctx, screenKey, userID)
+	}
+	return nil
+}
+
+func (m *MockScreenService) GetUserPreferencesHistory(ctx context.Context, screenKey string, userID uuid.UUID) ([]dto.ScreenPreferencesVersionDTO, error) {
+	if m.GetPreferencesHistoryFunc != nil {
+		return m.GetPreferencesHistoryFunc(ctx, screenKey, userID)
+	}
+	return []dto.ScreenPreferencesVersionDTO{}, nil
+}
+
 func (m *MockScreenService) GetScreensForResource(ctx context.Context, resourceKey string) ([]*dto.ResourceScreenDTO, error) {
 	if m.GetScreensForResourceFunc != nil {
 		return m.GetScreensForResourceFunc(ctx, resourceKey)
@@ -63,6 +111,19 @@ func (m *MockScreenService) GetScreensForResource(ctx context.Context, resourceK
 	return []*dto.ResourceScreenDTO{}, nil
 }
 
+func (m *MockScreenService) GetScreenBundle(ctx context.Context, audience service.ScreenAudience, permissions []string, since *service.BundleSince) (*service.ScreenBundleDTO, error) {
+	if m.GetScreenBundleFunc != nil {
+		return m.GetScreenBundleFunc(ctx, audience, permissions, since)
+	}
+	return &service.ScreenBundleDTO{Hash: "empty", Platform: audience.Platform, Screens: map[string]*dto.CombinedScreenDTO{}, Versions: map[string]string{}}, nil
+}
+
+func (m *MockScreenService) InvalidateScreens(screenKeys ...string) {}
+
+func (m *MockScreenService) HandleScreenUpdated(ctx context.Context, event eventbus.Event) error {
+	return nil
+}
+
 // ============================================
 // Helper: crear CombinedScreenDTO de prueba
 // ============================================
@@ -142,7 +203,7 @@ func TestScreenHandler_GetScreen_Success(t *testing.T) {
 	// Verificar headers de cache
 	assert.NotEmpty(t, w.Header().Get("ETag"))
 	assert.NotEmpty(t, w.Header().Get("Last-Modified"))
-	assert.Equal(t, "max-age=3600", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
 }
 
 func TestScreenHandler_GetScreen_NotFound(t *testing.T) {
@@ -200,6 +261,151 @@ func TestScreenHandler_GetScreen_WithPlatform(t *testing.T) {
 	assert.Equal(t, "desktop", capturedPlatform)
 }
 
+func TestScreenHandler_GetScreen_IncludeData(t *testing.T) {
+	// Arrange
+	var captured service.ScreenAudience
+	var capturedPermissions []string
+	getScreenCalled := false
+	mockService := &MockScreenService{
+		GetScreenFunc: func(ctx context.Context, sk string, uid uuid.UUID, platform string) (*dto.CombinedScreenDTO, error) {
+			getScreenCalled = true
+			return newTestScreenDTO(sk), nil
+		},
+		GetScreenWithDataFunc: func(ctx context.Context, sk string, audience service.ScreenAudience, permissions []string) (*service.ScreenWithDataDTO, error) {
+			captured, capturedPermissions = audience, permissions
+			return &service.ScreenWithDataDTO{
+				CombinedScreenDTO: newTestScreenDTO(sk),
+				DataIncluded:      true,
+				Data:              []string{"m-1"},
+			}, nil
+		},
+	}
+
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/v1/screens/:screenKey", streamAuthMiddleware("screens:read", "materials:read"), handler.GetScreen)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/v1/screens/materials-list?include=slots,data", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, getScreenCalled)
+	assert.Equal(t, streamTestUserID, captured.UserID.String())
+	assert.Equal(t, []string{"screens:read", "materials:read"}, capturedPermissions)
+	assert.Empty(t, w.Header().Get("ETag"), "los datos no forman parte del ETag de la pantalla")
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "materials-list", body["screenKey"])
+	assert.Equal(t, true, body["dataIncluded"])
+	assert.Equal(t, []any{"m-1"}, body["data"])
+}
+
+func TestScreenHandler_GetScreen_Experiment(t *testing.T) {
+	// Arrange
+	var captured service.ScreenAudience
+	assignment := &service.ScreenExperimentAssignmentDTO{Experiment: "materials-layout", Variant: "grid", ScreenKey: "materials-list-grid"}
+	mockService := &MockScreenService{
+		GetScreenVariantFunc: func(ctx context.Context, sk string, audience service.ScreenAudience) (*service.ScreenVariantDTO, error) {
+			captured = audience
+			return &service.ScreenVariantDTO{CombinedScreenDTO: newTestScreenDTO(sk), Experiment: assignment}, nil
+		},
+	}
+
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/v1/screens/:screenKey", streamAuthMiddleware("screens:read"), handler.GetScreen)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/v1/screens/materials-list?platform=ios", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, streamTestUserID, captured.UserID.String())
+	assert.Equal(t, "ios", captured.Platform)
+	assert.Equal(t, streamTestSchoolID, captured.SchoolID)
+	assert.Equal(t, "teacher", captured.Role)
+	assert.Equal(t, screenETag(&service.ScreenVariantDTO{CombinedScreenDTO: newTestScreenDTO("materials-list"), Experiment: assignment}), w.Header().Get("ETag"))
+	assert.NotEqual(t, screenETag(&service.ScreenVariantDTO{CombinedScreenDTO: newTestScreenDTO("materials-list")}), w.Header().Get("ETag"), "la variante forma parte del ETag")
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "materials-list", body["screenKey"])
+	assert.Equal(t, map[string]any{"experiment": "materials-layout", "variant": "grid", "screenKey": "materials-list-grid"}, body["experiment"])
+}
+
+func TestScreenHandler_GetScreen_Locale(t *testing.T) {
+	// Arrange
+	var captured service.ScreenAudience
+	mockService := &MockScreenService{
+		GetScreenVariantFunc: func(ctx context.Context, sk string, audience service.ScreenAudience) (*service.ScreenVariantDTO, error) {
+			captured = audience
+			return &service.ScreenVariantDTO{CombinedScreenDTO: newTestScreenDTO(sk), Locale: "pt"}, nil
+		},
+	}
+
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/v1/screens/:screenKey", middleware.Locale(), streamAuthMiddleware("screens:read"), handler.GetScreen)
+
+	fetch := func(acceptLanguage, ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/screens/materials-list", nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act
+	w := fetch("pt-BR,pt;q=0.9,en;q=0.8", "")
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "pt", captured.Locale)
+	assert.Equal(t, "pt", w.Header().Get("Content-Language"))
+	assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+	assert.Contains(t, w.Header().Get("ETag"), "-pt")
+
+	// Una copia en otro idioma no coincide con el ETag
+	mockService.GetScreenVariantFunc = func(ctx context.Context, sk string, audience service.ScreenAudience) (*service.ScreenVariantDTO, error) {
+		return &service.ScreenVariantDTO{CombinedScreenDTO: newTestScreenDTO(sk), Locale: audience.Locale}, nil
+	}
+	assert.Equal(t, http.StatusOK, fetch("en", w.Header().Get("ETag")).Code)
+}
+
+func TestScreenHandler_GetScreen_LocalizedError(t *testing.T) {
+	// Arrange
+	mockService := &MockScreenService{
+		GetScreenFunc: func(ctx context.Context, sk string, uid uuid.UUID, platform string) (*dto.CombinedScreenDTO, error) {
+			return nil, errors.NewNotFoundError("screen")
+		},
+	}
+
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/v1/screens/:screenKey", middleware.Locale(), streamAuthMiddleware("screens:read"), handler.GetScreen)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/v1/screens/missing", nil)
+	req.Header.Set("Accept-Language", "es")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var body ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "NOT_FOUND", body.Code)
+	assert.Equal(t, "El recurso solicitado no existe", body.Error)
+	assert.NotEmpty(t, body.Detail)
+}
+
 func TestScreenHandler_GetScreen_ETag_ConditionalRequest_304(t *testing.T) {
 	// Arrange
 	testUserID := uuid.New().String()
@@ -264,6 +470,57 @@ func TestScreenHandler_GetScreen_ETag_DifferentContent_200(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestScreenHandler_GetScreen_ETag_ChangesWithVersionAndEdits(t *testing.T) {
+	// Arrange
+	testUserID := uuid.New().String()
+	screenDTO := newTestScreenDTO("materials-list")
+
+	mockService := &MockScreenService{
+		GetScreenFunc: func(ctx context.Context, sk string, uid uuid.UUID, platform string) (*dto.CombinedScreenDTO, error) {
+			copied := *screenDTO
+			return &copied, nil
+		},
+	}
+
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/v1/screens/:screenKey", MockAuthMiddleware(testUserID, "school-1"), handler.GetScreen)
+
+	fetch := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/screens/materials-list", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	original := fetch("").Header().Get("ETag")
+	require.NotEmpty(t, original)
+
+	// Act & Assert - listas, ETag débil y "*" también coinciden
+	for _, header := range []string{`"otro", ` + original, "W/" + original, "*"} {
+		w := fetch(header)
+		assert.Equal(t, http.StatusNotModified, w.Code, header)
+		assert.Equal(t, original, w.Header().Get("ETag"), "el 304 repite el ETag")
+	}
+
+	// Act & Assert - una edición en api-admin cambia el ETag
+	screenDTO.UpdatedAt = screenDTO.UpdatedAt.Add(time.Minute)
+	edited := fetch(original)
+	assert.Equal(t, http.StatusOK, edited.Code)
+	assert.NotEqual(t, original, edited.Header().Get("ETag"))
+
+	screenDTO.Version = 2
+	assert.Equal(t, http.StatusOK, fetch(edited.Header().Get("ETag")).Code)
+
+	// Act & Assert - las preferencias del usuario viajan en la respuesta y también cambian el ETag
+	current := fetch("").Header().Get("ETag")
+	screenDTO.UserPreferences = json.RawMessage(`{"theme": "dark"}`)
+	assert.Equal(t, http.StatusOK, fetch(current).Code)
+}
+
 func TestScreenHandler_GetScreen_InvalidUserID(t *testing.T) {
 	// Arrange - user_id no es UUID valido
 	mockService := &MockScreenService{}
@@ -405,8 +662,8 @@ func TestScreenHandler_GetNavigation_Success(t *testing.T) {
 	}
 
 	mockService := &MockScreenService{
-		GetNavigationConfigFunc: func(ctx context.Context, uid uuid.UUID, platform string, permissions []string) (*service.NavigationConfigDTO, error) {
-			assert.Equal(t, testUserID, uid.String())
+		GetNavigationConfigFunc: func(ctx context.Context, audience service.ScreenAudience, permissions []string) (*service.NavigationConfigDTO, error) {
+			assert.Equal(t, testUserID, audience.UserID.String())
 			return expectedNav, nil
 		},
 	}
@@ -435,6 +692,47 @@ func TestScreenHandler_GetNavigation_Success(t *testing.T) {
 	assert.Equal(t, 3, response.Version)
 }
 
+func TestScreenHandler_GetNavigation_ETag_304(t *testing.T) {
+	// Arrange
+	testUserID := uuid.New().String()
+	nav := &service.NavigationConfigDTO{
+		BottomNav:   []service.NavItemDTO{{Key: "dashboard", Label: "Home", ScreenKey: "dashboard-teacher"}},
+		DrawerItems: []service.NavItemDTO{},
+		Version:     1,
+	}
+
+	mockService := &MockScreenService{
+		GetNavigationConfigFunc: func(ctx context.Context, audience service.ScreenAudience, permissions []string) (*service.NavigationConfigDTO, error) {
+			return nav, nil
+		},
+	}
+
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/v1/screens/navigation", MockAuthMiddleware(testUserID, "school-1"), handler.GetNavigation)
+
+	fetch := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/screens/navigation", nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act
+	first := fetch("")
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// Assert
+	unchanged := fetch(etag)
+	assert.Equal(t, http.StatusNotModified, unchanged.Code)
+	assert.Empty(t, unchanged.Body.String())
+
+	nav.BottomNav = append(nav.BottomNav, service.NavItemDTO{Key: "materials", Label: "Materials"})
+	assert.Equal(t, http.StatusOK, fetch(etag).Code, "un cambio en el menú invalida el ETag")
+}
+
 func TestScreenHandler_GetNavigation_InvalidUserID(t *testing.T) {
 	// Arrange
 	mockService := &MockScreenService{}
@@ -459,7 +757,7 @@ func TestScreenHandler_GetNavigation_ServiceError(t *testing.T) {
 	testUserID := uuid.New().String()
 
 	mockService := &MockScreenService{
-		GetNavigationConfigFunc: func(ctx context.Context, uid uuid.UUID, platform string, permissions []string) (*service.NavigationConfigDTO, error) {
+		GetNavigationConfigFunc: func(ctx context.Context, audience service.ScreenAudience, permissions []string) (*service.NavigationConfigDTO, error) {
 			return nil, fmt.Errorf("unexpected error")
 		},
 	}
@@ -480,6 +778,142 @@ func TestScreenHandler_GetNavigation_ServiceError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "INTERNAL_ERROR")
 }
 
+// ============================================
+// Tests: GetBundle
+// ============================================
+
+func TestScreenHandler_GetBundle_Success(t *testing.T) {
+	// Arrange
+	testUserID := uuid.New()
+	var gotPlatform, gotSince string
+
+	mockService := &MockScreenService{
+		GetScreenBundleFunc: func(ctx context.Context, audience service.ScreenAudience, permissions []string, since *service.BundleSince) (*service.ScreenBundleDTO, error) {
+			assert.Equal(t, testUserID, audience.UserID)
+			platform := audience.Platform
+			gotPlatform, gotSince = platform, since.Hash
+			screen := newTestScreenDTO("dashboard-teacher")
+			return &service.ScreenBundleDTO{
+				Hash:       "abc123",
+				Platform:   platform,
+				Delta:      since.Hash != "",
+				Navigation: &service.NavigationConfigDTO{BottomNav: []service.NavItemDTO{}, DrawerItems: []service.NavItemDTO{}, Version: 1},
+				Screens:    map[string]*dto.CombinedScreenDTO{screen.ScreenKey: screen},
+				Versions:   map[string]string{screen.ScreenKey: service.ScreenFingerprint(screen)},
+			}, nil
+		},
+	}
+
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/v1/screens/bundle", MockAuthMiddleware(testUserID.String(), "school-1"), handler.GetBundle)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/v1/screens/bundle?platform=ios&since=old-hash", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ios", gotPlatform)
+	assert.Equal(t, "old-hash", gotSince)
+	assert.Equal(t, `"abc123"`, w.Header().Get("ETag"))
+
+	var bundle service.ScreenBundleDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &bundle))
+	assert.True(t, bundle.Delta)
+	assert.Contains(t, bundle.Screens, "dashboard-teacher")
+}
+
+func TestScreenHandler_GetBundle_PostSince(t *testing.T) {
+	// Arrange
+	testUserID := uuid.New()
+	var gotSince *service.BundleSince
+
+	mockService := &MockScreenService{
+		GetScreenBundleFunc: func(ctx context.Context, audience service.ScreenAudience, permissions []string, since *service.BundleSince) (*service.ScreenBundleDTO, error) {
+			gotSince = since
+			return &service.ScreenBundleDTO{Hash: "new-hash", Platform: audience.Platform, Delta: true, Screens: map[string]*dto.CombinedScreenDTO{}}, nil
+		},
+	}
+
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.POST("/v1/screens/bundle", MockAuthMiddleware(testUserID.String(), "school-1"), handler.GetBundle)
+
+	// Act
+	body := `{"hash":"old-hash","platform":"ios","locale":"es","navigationHash":"nav","versions":{"dashboard-teacher":"v1-1-00000000"}}`
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/v1/screens/bundle?platform=ios", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, gotSince)
+	assert.Equal(t, "old-hash", gotSince.Hash)
+	assert.Equal(t, "nav", gotSince.NavigationHash)
+	assert.Equal(t, map[string]string{"dashboard-teacher": "v1-1-00000000"}, gotSince.Versions)
+
+	// Cuerpo inválido
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/v1/screens/bundle", strings.NewReader(`{"versions":[`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestScreenHandler_GetBundle_ETag_304(t *testing.T) {
+	// Arrange
+	handler := NewScreenHandler(&MockScreenService{}, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/v1/screens/bundle", MockAuthMiddleware(uuid.New().String(), "school-1"), handler.GetBundle)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/v1/screens/bundle", nil)
+	req.Header.Set("If-None-Match", `"empty"`)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestScreenHandler_GetBundle_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "user_id inválido", userID: "invalid-uuid", wantStatus: http.StatusBadRequest, wantCode: "INVALID_USER_ID"},
+		{name: "error de BD", userID: uuid.New().String(), err: errors.NewDatabaseError("list resources", fmt.Errorf("boom")), wantStatus: http.StatusInternalServerError, wantCode: "DATABASE_ERROR"},
+		{name: "error inesperado", userID: uuid.New().String(), err: fmt.Errorf("unexpected error"), wantStatus: http.StatusInternalServerError, wantCode: "INTERNAL_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockScreenService{
+				GetScreenBundleFunc: func(ctx context.Context, audience service.ScreenAudience, permissions []string, since *service.BundleSince) (*service.ScreenBundleDTO, error) {
+					return nil, tt.err
+				},
+			}
+			handler := NewScreenHandler(mockService, NewTestLogger())
+			router := SetupTestRouter()
+			router.GET("/v1/screens/bundle", MockAuthMiddleware(tt.userID, "school-1"), handler.GetBundle)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/v1/screens/bundle", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantCode)
+		})
+	}
+}
+
 // ============================================
 // Tests: SavePreferences
 // ============================================
@@ -592,3 +1026,123 @@ func TestScreenHandler_SavePreferences_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	assert.Contains(t, w.Body.String(), "DATABASE_ERROR")
 }
+
+func TestScreenHandler_SavePreferences_TooLarge(t *testing.T) {
+	// Arrange
+	called := false
+	mockService := &MockScreenService{
+		SaveUserPreferencesFunc: func(ctx context.Context, sk string, uid uuid.UUID, prefs json.RawMessage) error {
+			called = true
+			return nil
+		},
+	}
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.PUT("/v1/screens/:screenKey/preferences", MockAuthMiddleware(uuid.New().String(), "school-1"), handler.SavePreferences)
+
+	// Act
+	body := `{"notes": "` + strings.Repeat("x", service.MaxScreenPreferencesBytes) + `"}`
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/v1/screens/materials-list/preferences", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "PAYLOAD_TOO_LARGE")
+	assert.False(t, called)
+}
+
+// ============================================
+// Tests: PatchPreferences, ResetPreferences, GetPreferencesHistory
+// ============================================
+
+func TestScreenHandler_PatchPreferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "retorna el resultado", body: `{"sortBy": null, "viewMode": "grid"}`, wantStatus: http.StatusOK, wantBody: `{"viewMode":"grid"}`},
+		{name: "JSON inválido", body: `{viewMode`, wantStatus: http.StatusBadRequest, wantBody: "INVALID_REQUEST"},
+		{name: "fuera del schema", body: `{"theme": "dark"}`, serviceErr: errors.NewValidationError("invalid preferences: theme"), wantStatus: http.StatusBadRequest, wantBody: "VALIDATION_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockScreenService{
+				PatchUserPreferencesFunc: func(ctx context.Context, sk string, uid uuid.UUID, patch json.RawMessage) (json.RawMessage, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return json.RawMessage(`{"viewMode":"grid"}`), nil
+				},
+			}
+			handler := NewScreenHandler(mockService, NewTestLogger())
+			router := SetupTestRouter()
+			router.PATCH("/v1/screens/:screenKey/preferences", MockAuthMiddleware(uuid.New().String(), "school-1"), handler.PatchPreferences)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PATCH", "/v1/screens/materials-list/preferences", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestScreenHandler_ResetPreferences(t *testing.T) {
+	// Arrange
+	testUserID := uuid.New()
+	var resetKey string
+	mockService := &MockScreenService{
+		ResetUserPreferencesFunc: func(ctx context.Context, sk string, uid uuid.UUID) error {
+			assert.Equal(t, testUserID, uid)
+			resetKey = sk
+			return nil
+		},
+	}
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.DELETE("/v1/screens/:screenKey/preferences", MockAuthMiddleware(testUserID.String(), "school-1"), handler.ResetPreferences)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/v1/screens/materials-list/preferences", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "materials-list", resetKey)
+}
+
+func TestScreenHandler_GetPreferencesHistory(t *testing.T) {
+	// Arrange
+	replacedAt := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	mockService := &MockScreenService{
+		GetPreferencesHistoryFunc: func(ctx context.Context, sk string, uid uuid.UUID) ([]dto.ScreenPreferencesVersionDTO, error) {
+			return []dto.ScreenPreferencesVersionDTO{
+				{Preferences: json.RawMessage(`{"sortBy":"title"}`), SavedAt: replacedAt.Add(-time.Hour), ReplacedAt: replacedAt},
+			}, nil
+		},
+	}
+	handler := NewScreenHandler(mockService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/v1/screens/:screenKey/preferences/history", MockAuthMiddleware(uuid.New().String(), "school-1"), handler.GetPreferencesHistory)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/v1/screens/materials-list/preferences/history", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	var history []dto.ScreenPreferencesVersionDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	require.Len(t, history, 1)
+	assert.JSONEq(t, `{"sortBy":"title"}`, string(history[0].Preferences))
+	assert.Equal(t, replacedAt, history[0].ReplacedAt)
+}
@@ -5,30 +5,40 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/EduGoGroup/edugo-shared/logger"
 )
 
 type StatsHandler struct {
 	statsService service.StatsService
+	views        MaterialViewRecorder
 	logger       logger.Logger
 }
 
-func NewStatsHandler(statsService service.StatsService, logger logger.Logger) *StatsHandler {
+// MaterialViewRecorder encola visualizaciones de materiales sin bloquear la respuesta
+// Lo implementa service.MaterialViewRecorder
+type MaterialViewRecorder interface {
+	Record(materialID, userID, schoolID string) bool
+}
+
+func NewStatsHandler(statsService service.StatsService, views MaterialViewRecorder, logger logger.Logger) *StatsHandler {
 	return &StatsHandler{
 		statsService: statsService,
+		views:        views,
 		logger:       logger,
 	}
 }
 
 // GetMaterialStats godoc
 // @Summary Get material statistics
-// @Description Retrieves statistics for a specific material including views, completion rate, and average score
+// @Description Retrieves statistics for a specific material: views, completion rate, pass rate, score distribution and median time spent
 // @Tags stats
 // @Produce json
 // @Param id path string true "Material ID (UUID format)"
-// @Success 200 {object} map[string]interface{} "Material statistics retrieved successfully"
+// @Success 200 {object} service.MaterialStats "Material statistics retrieved successfully"
 // @Failure 400 {object} ErrorResponse "Invalid material ID format"
 // @Failure 404 {object} ErrorResponse "Material not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -37,7 +47,8 @@ func NewStatsHandler(statsService service.StatsService, logger logger.Logger) *S
 func (h *StatsHandler) GetMaterialStats(c *gin.Context) {
 	id := c.Param("id")
 
-	stats, err := h.statsService.GetMaterialStats(c.Request.Context(), id)
+	subject := policy.NewSubject(middleware.GetUserID(c), middleware.GetActiveContext(c))
+	stats, err := h.statsService.GetMaterialStats(c.Request.Context(), id, subject)
 	if err != nil {
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
@@ -75,3 +86,25 @@ func (h *StatsHandler) GetGlobalStats(c *gin.Context) {
 
 	c.JSON(http.StatusOK, stats)
 }
+
+// TrackMaterialView registra una visualización del material cuando la respuesta es exitosa
+// Se encadena antes del handler de lectura (GET /materials/:id, /:id/download-url).
+// La visualización se encola y se escribe en segundo plano: la lectura no espera a MongoDB
+// y, si la cola está llena, la visualización se descarta
+func (h *StatsHandler) TrackMaterialView(c *gin.Context) {
+	c.Next()
+
+	if c.Writer.Status() != http.StatusOK {
+		return
+	}
+
+	var schoolID string
+	if activeCtx := middleware.GetActiveContext(c); activeCtx != nil {
+		schoolID = activeCtx.SchoolID
+	}
+
+	materialID := c.Param("id")
+	if !h.views.Record(materialID, middleware.GetUserID(c), schoolID) {
+		h.logger.Debug("cola de visualizaciones llena, visualización descartada", "material_id", materialID)
+	}
+}
@@ -9,10 +9,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 )
@@ -24,7 +26,7 @@ func TestNewStatsHandler(t *testing.T) {
 	logger := NewTestLogger()
 
 	// Act
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	// Assert
 	assert.NotNil(t, handler)
@@ -44,14 +46,14 @@ func TestStatsHandler_GetMaterialStats_Success(t *testing.T) {
 	}
 
 	mockService := &MockStatsService{
-		GetMaterialStatsFunc: func(ctx context.Context, matID string) (*service.MaterialStats, error) {
+		GetMaterialStatsFunc: func(ctx context.Context, matID string, subject policy.Subject) (*service.MaterialStats, error) {
 			assert.Equal(t, materialID, matID)
 			return expectedStats, nil
 		},
 	}
 
 	logger := NewTestLogger()
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	router := SetupTestRouter()
 	router.GET("/materials/:id/stats", handler.GetMaterialStats)
@@ -80,13 +82,13 @@ func TestStatsHandler_GetMaterialStats_MaterialNotFound(t *testing.T) {
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
 
 	mockService := &MockStatsService{
-		GetMaterialStatsFunc: func(ctx context.Context, matID string) (*service.MaterialStats, error) {
+		GetMaterialStatsFunc: func(ctx context.Context, matID string, subject policy.Subject) (*service.MaterialStats, error) {
 			return nil, errors.NewNotFoundError("material not found")
 		},
 	}
 
 	logger := NewTestLogger()
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	router := SetupTestRouter()
 	router.GET("/materials/:id/stats", handler.GetMaterialStats)
@@ -108,13 +110,13 @@ func TestStatsHandler_GetMaterialStats_InvalidMaterialID(t *testing.T) {
 	invalidID := "not-a-valid-uuid"
 
 	mockService := &MockStatsService{
-		GetMaterialStatsFunc: func(ctx context.Context, matID string) (*service.MaterialStats, error) {
+		GetMaterialStatsFunc: func(ctx context.Context, matID string, subject policy.Subject) (*service.MaterialStats, error) {
 			return nil, errors.NewValidationError("invalid material_id")
 		},
 	}
 
 	logger := NewTestLogger()
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	router := SetupTestRouter()
 	router.GET("/materials/:id/stats", handler.GetMaterialStats)
@@ -136,13 +138,13 @@ func TestStatsHandler_GetMaterialStats_ServiceError(t *testing.T) {
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
 
 	mockService := &MockStatsService{
-		GetMaterialStatsFunc: func(ctx context.Context, matID string) (*service.MaterialStats, error) {
+		GetMaterialStatsFunc: func(ctx context.Context, matID string, subject policy.Subject) (*service.MaterialStats, error) {
 			return nil, fmt.Errorf("database connection failed")
 		},
 	}
 
 	logger := NewTestLogger()
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	router := SetupTestRouter()
 	router.GET("/materials/:id/stats", handler.GetMaterialStats)
@@ -170,13 +172,13 @@ func TestStatsHandler_GetMaterialStats_WithZeroValues(t *testing.T) {
 	}
 
 	mockService := &MockStatsService{
-		GetMaterialStatsFunc: func(ctx context.Context, matID string) (*service.MaterialStats, error) {
+		GetMaterialStatsFunc: func(ctx context.Context, matID string, subject policy.Subject) (*service.MaterialStats, error) {
 			return expectedStats, nil
 		},
 	}
 
 	logger := NewTestLogger()
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	router := SetupTestRouter()
 	router.GET("/materials/:id/stats", handler.GetMaterialStats)
@@ -219,7 +221,7 @@ func TestStatsHandler_GetGlobalStats_Success(t *testing.T) {
 	}
 
 	logger := NewTestLogger()
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	router := SetupTestRouter()
 	router.GET("/stats/global", handler.GetGlobalStats)
@@ -253,7 +255,7 @@ func TestStatsHandler_GetGlobalStats_ServiceError(t *testing.T) {
 	}
 
 	logger := NewTestLogger()
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	router := SetupTestRouter()
 	router.GET("/stats/global", handler.GetGlobalStats)
@@ -289,7 +291,7 @@ func TestStatsHandler_GetGlobalStats_WithZeroValues(t *testing.T) {
 	}
 
 	logger := NewTestLogger()
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	router := SetupTestRouter()
 	router.GET("/stats/global", handler.GetGlobalStats)
@@ -323,7 +325,7 @@ func TestStatsHandler_GetGlobalStats_GenericError(t *testing.T) {
 	}
 
 	logger := NewTestLogger()
-	handler := NewStatsHandler(mockService, logger)
+	handler := NewStatsHandler(mockService, nil, logger)
 
 	router := SetupTestRouter()
 	router.GET("/stats/global", handler.GetGlobalStats)
@@ -383,14 +385,14 @@ func TestStatsHandler_GetMaterialStats_DifferentMaterialIDs(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			mockService := &MockStatsService{
-				GetMaterialStatsFunc: func(ctx context.Context, matID string) (*service.MaterialStats, error) {
+				GetMaterialStatsFunc: func(ctx context.Context, matID string, subject policy.Subject) (*service.MaterialStats, error) {
 					assert.Equal(t, tc.materialID, matID)
 					return tc.stats, nil
 				},
 			}
 
 			logger := NewTestLogger()
-			handler := NewStatsHandler(mockService, logger)
+			handler := NewStatsHandler(mockService, nil, logger)
 
 			router := SetupTestRouter()
 			router.GET("/materials/:id/stats", handler.GetMaterialStats)
@@ -414,3 +416,86 @@ func TestStatsHandler_GetMaterialStats_DifferentMaterialIDs(t *testing.T) {
 		})
 	}
 }
+
+// stubViewRecorder registra las visualizaciones encoladas; full simula la cola llena
+type stubViewRecorder struct {
+	views []string
+	full  bool
+}
+
+func (r *stubViewRecorder) Record(materialID, userID, schoolID string) bool {
+	if r.full {
+		return false
+	}
+	r.views = append(r.views, materialID+"|"+userID+"|"+schoolID)
+	return true
+}
+
+// TestStatsHandler_TrackMaterialView_RecordsOnSuccess verifica que se encola la visualización tras un 200
+// sin escribir en MongoDB durante la request
+func TestStatsHandler_TrackMaterialView_RecordsOnSuccess(t *testing.T) {
+	// Arrange
+	materialID := "550e8400-e29b-41d4-a716-446655440000"
+	mockService := &MockStatsService{
+		RecordMaterialViewFunc: func(ctx context.Context, matID, userID, schoolID string) error {
+			t.Error("la visualización no debe escribirse de forma síncrona")
+			return nil
+		},
+	}
+	views := &stubViewRecorder{}
+	handler := NewStatsHandler(mockService, views, NewTestLogger())
+
+	router := SetupTestRouter()
+	router.GET("/materials/:id", streamAuthMiddleware(), handler.TrackMaterialView, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/materials/"+materialID, nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{materialID + "|" + streamTestUserID + "|" + streamTestSchoolID}, views.views)
+}
+
+// TestStatsHandler_TrackMaterialView_QueueFull verifica que una cola llena no afecta la respuesta
+func TestStatsHandler_TrackMaterialView_QueueFull(t *testing.T) {
+	// Arrange
+	handler := NewStatsHandler(&MockStatsService{}, &stubViewRecorder{full: true}, NewTestLogger())
+
+	router := SetupTestRouter()
+	router.GET("/materials/:id", streamAuthMiddleware(), handler.TrackMaterialView, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/materials/550e8400-e29b-41d4-a716-446655440000", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestStatsHandler_TrackMaterialView_SkipsFailedResponses verifica que no se registran respuestas de error
+func TestStatsHandler_TrackMaterialView_SkipsFailedResponses(t *testing.T) {
+	// Arrange
+	views := &stubViewRecorder{}
+	handler := NewStatsHandler(&MockStatsService{}, views, NewTestLogger())
+
+	router := SetupTestRouter()
+	router.GET("/materials/:id", handler.TrackMaterialView, func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "material not found", Code: "NOT_FOUND"})
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/materials/550e8400-e29b-41d4-a716-446655440000", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, views.views)
+}
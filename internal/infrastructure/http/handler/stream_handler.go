@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/realtime"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// maxStreamClassSubscriptions limita materiales + unidades por conexión
+const maxStreamClassSubscriptions = 20
+
+// streamRetryMillis es el tiempo de reconexión sugerido a los clientes SSE
+const streamRetryMillis = 3000
+
+// StreamHandler expone eventos en tiempo real vía Server-Sent Events
+type StreamHandler struct {
+	hub             *realtime.Hub
+	materialService service.MaterialService
+	logger          logger.Logger
+}
+
+func NewStreamHandler(hub *realtime.Hub, materialService service.MaterialService, logger logger.Logger) *StreamHandler {
+	return &StreamHandler{
+		hub:             hub,
+		materialService: materialService,
+		logger:          logger,
+	}
+}
+
+// Stream godoc
+// @Summary Real-time event stream
+// @Description Stream Server-Sent Events con cambios de estado de materiales, intentos completados y progreso.
+// @Description El usuario siempre recibe sus propios eventos; con stats:unit puede suscribirse a materiales
+// @Description o unidades académicas de su escuela. Envía un evento "heartbeat" periódico.
+// @Tags stream
+// @Produce text/event-stream
+// @Param topics query string false "Patrones de tipo de evento separados por coma (ej: progress.*,assessment.#)"
+// @Param materials query string false "IDs de materiales a observar, separados por coma (requiere stats:unit)"
+// @Param units query string false "IDs de unidades académicas a observar, separados por coma (requiere stats:unit)"
+// @Success 200 {string} string "text/event-stream"
+// @Failure 400 {object} ErrorResponse "Invalid subscription"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden subscription"
+// @Failure 429 {object} ErrorResponse "Too many open streams"
+// @Failure 503 {object} ErrorResponse "Stream unavailable"
+// @Router /v1/stream [get]
+// @Security BearerAuth
+func (h *StreamHandler) Stream(c *gin.Context) {
+	filter, err := h.buildFilter(c)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	client, err := h.hub.Register(filter)
+	if err != nil {
+		if stderrors.Is(err, realtime.ErrTooManyConnections) {
+			c.JSON(http.StatusTooManyRequests, ErrorResponse{Error: "too many open streams", Code: "TOO_MANY_STREAMS"})
+			return
+		}
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "stream unavailable", Code: "STREAM_UNAVAILABLE"})
+		return
+	}
+	defer h.hub.Unregister(client)
+
+	h.logger.Info("stream opened",
+		"user_id", filter.UserID,
+		"materials", len(filter.MaterialIDs),
+		"units", len(filter.AcademicUnitIDs),
+	)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Evitar buffering en proxies (nginx)
+	c.Status(http.StatusOK)
+
+	if err := writeSSE(c, "", "ready", gin.H{"heartbeat_seconds": int(h.hub.HeartbeatInterval().Seconds())}, streamRetryMillis); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.hub.HeartbeatInterval())
+	defer heartbeat.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Debug("stream closed by client", "user_id", filter.UserID)
+			return
+
+		case <-client.Done():
+			if stderrors.Is(client.Err(), realtime.ErrSlowConsumer) {
+				_ = writeSSE(c, "", "error", ErrorResponse{Error: "client too slow, reconnect", Code: "SLOW_CONSUMER"}, 0)
+			}
+			return
+
+		case msg := <-client.Messages():
+			if err := writeSSE(c, msg.ID, msg.Type, msg, 0); err != nil {
+				h.logger.Debug("stream write failed", "user_id", filter.UserID, "error", err)
+				return
+			}
+
+		case now := <-heartbeat.C:
+			if err := writeSSE(c, "", "heartbeat", gin.H{"time": now.UTC()}, 0); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// buildFilter arma el filtro del cliente a partir del token y los query params
+// Las suscripciones a clases requieren stats:unit y no pueden salir de la escuela activa
+func (h *StreamHandler) buildFilter(c *gin.Context) (realtime.Filter, error) {
+	filter := realtime.Filter{
+		UserID: middleware.GetUserID(c),
+		Topics: splitCSV(c.Query("topics")),
+	}
+	if filter.UserID == "" {
+		return filter, errors.NewUnauthorizedError("user not authenticated")
+	}
+
+	activeCtx := middleware.GetActiveContext(c)
+	if activeCtx != nil {
+		filter.SchoolID = activeCtx.SchoolID
+	}
+
+	materialIDs := splitCSV(c.Query("materials"))
+	unitIDs := splitCSV(c.Query("units"))
+	if len(materialIDs) == 0 && len(unitIDs) == 0 {
+		return filter, nil
+	}
+
+	if len(materialIDs)+len(unitIDs) > maxStreamClassSubscriptions {
+		return filter, errors.NewValidationError(fmt.Sprintf("at most %d materials and units per stream", maxStreamClassSubscriptions))
+	}
+	if activeCtx == nil || activeCtx.SchoolID == "" || !middleware.HasPermission(c, enum.PermissionStatsUnit) {
+		return filter, errors.NewForbiddenError("class subscriptions require stats:unit")
+	}
+
+	for _, unitID := range unitIDs {
+		if _, err := uuid.Parse(unitID); err != nil {
+			return filter, errors.NewValidationError("invalid academic unit id: " + unitID)
+		}
+		// Sin stats:school solo se puede observar la unidad académica del contexto activo
+		if unitID != activeCtx.AcademicUnitID && !middleware.HasPermission(c, enum.PermissionStatsSchool) {
+			return filter, errors.NewForbiddenError("cannot subscribe to academic unit " + unitID)
+		}
+	}
+
+	// material:read oculta los materiales de otra escuela
+	subject := policy.NewSubject(filter.UserID, activeCtx)
+	for _, materialID := range materialIDs {
+		if _, err := h.materialService.GetMaterial(c.Request.Context(), materialID, subject); err != nil {
+			return filter, err
+		}
+	}
+
+	filter.MaterialIDs = materialIDs
+	filter.AcademicUnitIDs = unitIDs
+	return filter, nil
+}
+
+// writeSSE escribe un evento en formato text/event-stream y hace flush
+func writeSSE(c *gin.Context, id, event string, data interface{}, retryMillis int) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if retryMillis > 0 {
+		fmt.Fprintf(&b, "retry: %d\n", retryMillis)
+	}
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", event, payload)
+
+	if _, err := c.Writer.WriteString(b.String()); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
+
+// splitCSV separa un query param por comas descartando valores vacíos
+func splitCSV(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/realtime"
+	"github.com/EduGoGroup/edugo-shared/auth"
+)
+
+const (
+	streamTestUserID   = "660e8400-e29b-41d4-a716-446655440001"
+	streamTestSchoolID = "770e8400-e29b-41d4-a716-446655440002"
+	streamTestUnitID   = "880e8400-e29b-41d4-a716-446655440003"
+)
+
+// streamAuthMiddleware simula RemoteAuthMiddleware con ActiveContext RBAC
+func streamAuthMiddleware(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("user_id", streamTestUserID)
+		c.Set(middleware.ContextKeyActiveContext, &auth.UserContext{
+			RoleName:       "teacher",
+			SchoolID:       streamTestSchoolID,
+			AcademicUnitID: streamTestUnitID,
+			Permissions:    permissions,
+		})
+		c.Next()
+	}
+}
+
+func newStreamTestRouter(hub *realtime.Hub, materialService *MockMaterialService, permissions ...string) *gin.Engine {
+	handler := NewStreamHandler(hub, materialService, NewTestLogger())
+	router := SetupTestRouter()
+	router.GET("/stream", streamAuthMiddleware(permissions...), handler.Stream)
+	return router
+}
+
+// TestStreamHandler_DeliversOwnEvents verifica el evento inicial y la entrega de eventos propios
+func TestStreamHandler_DeliversOwnEvents(t *testing.T) {
+	hub := realtime.NewHub(realtime.Config{HeartbeatInterval: time.Hour}, NewTestLogger())
+	router := newStreamTestRouter(hub, &MockMaterialService{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/stream", nil)
+
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(w, req)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return hub.ConnectedClients() == 1 }, time.Second, 5*time.Millisecond)
+
+	hub.Broadcast(realtime.Message{
+		ID:     "evt-1",
+		Type:   "progress.updated",
+		Data:   json.RawMessage(`{"percentage":40}`),
+		UserID: streamTestUserID,
+	})
+	hub.Broadcast(realtime.Message{ID: "evt-2", Type: "progress.updated", UserID: "otro-usuario"})
+
+	// Esperar a que el mensaje propio se escriba antes de cerrar la conexión
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	body := w.Body.String()
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Contains(t, body, "retry: 3000\nevent: ready\n")
+	assert.Contains(t, body, "id: evt-1\nevent: progress.updated\n")
+	assert.Contains(t, body, `"percentage":40`)
+	assert.NotContains(t, body, "evt-2")
+	assert.Equal(t, 0, hub.ConnectedClients(), "el cliente debe desregistrarse al cerrar la conexión")
+}
+
+// TestStreamHandler_Heartbeat verifica el envío periódico de heartbeats
+func TestStreamHandler_Heartbeat(t *testing.T) {
+	hub := realtime.NewHub(realtime.Config{HeartbeatInterval: 10 * time.Millisecond}, NewTestLogger())
+	router := newStreamTestRouter(hub, &MockMaterialService{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Millisecond)
+	defer cancel()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/stream", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Contains(t, w.Body.String(), "event: heartbeat\n")
+}
+
+// TestStreamHandler_ClassSubscriptionRequiresPermission verifica que sin stats:unit no se observan clases
+func TestStreamHandler_ClassSubscriptionRequiresPermission(t *testing.T) {
+	hub := realtime.NewHub(realtime.Config{}, NewTestLogger())
+	router := newStreamTestRouter(hub, &MockMaterialService{}, "materials:read")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/stream?units="+streamTestUnitID, nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, 0, hub.ConnectedClients())
+}
+
+// TestStreamHandler_MaterialFromOtherSchool verifica que no se puede observar material de otra escuela
+func TestStreamHandler_MaterialFromOtherSchool(t *testing.T) {
+	materialService := &MockMaterialService{
+		GetMaterialFunc: func(ctx context.Context, id string, subject policy.Subject) (*dto.MaterialResponse, error) {
+			// El servicio real evalúa material:read con el Subject del contexto activo
+			target := policy.Target{SchoolID: "990e8400-e29b-41d4-a716-446655440009"}
+			if err := policy.Authorize(subject, policy.ResourceMaterial, policy.ActionRead, target); err != nil {
+				return nil, err
+			}
+			return &dto.MaterialResponse{ID: id, SchoolID: target.SchoolID}, nil
+		},
+	}
+	hub := realtime.NewHub(realtime.Config{}, NewTestLogger())
+	router := newStreamTestRouter(hub, materialService, "stats:unit")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/stream?materials=550e8400-e29b-41d4-a716-446655440000", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, 0, hub.ConnectedClients())
+}
+
+// TestStreamHandler_OtherUnitRequiresSchoolPermission verifica el alcance de las unidades académicas
+func TestStreamHandler_OtherUnitRequiresSchoolPermission(t *testing.T) {
+	hub := realtime.NewHub(realtime.Config{}, NewTestLogger())
+	router := newStreamTestRouter(hub, &MockMaterialService{}, "stats:unit")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/stream?units=990e8400-e29b-41d4-a716-446655440009", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/stream?units=not-a-uuid", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// TestStreamHandler_TooManyConnections verifica el límite de conexiones por usuario
+func TestStreamHandler_TooManyConnections(t *testing.T) {
+	hub := realtime.NewHub(realtime.Config{MaxConnectionsPerUser: 1}, NewTestLogger())
+	_, err := hub.Register(realtime.Filter{UserID: streamTestUserID})
+	require.NoError(t, err)
+
+	router := newStreamTestRouter(hub, &MockMaterialService{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/stream", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/EduGoGroup/edugo-shared/logger"
 )
@@ -31,15 +33,20 @@ func NewSummaryHandler(summaryService service.SummaryService, logger logger.Logg
 // @Param id path string true "Material ID (UUID format)"
 // @Success 200 {object} map[string]interface{} "Summary retrieved successfully"
 // @Failure 400 {object} ErrorResponse "Invalid material ID format"
-// @Failure 404 {object} ErrorResponse "Summary not found for this material"
+// @Failure 403 {object} MaterialLockedResponse "Material locked by a learning path"
+// @Failure 404 {object} ErrorResponse "Material or summary not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /v1/materials/{id}/summary [get]
 // @Security BearerAuth
 func (h *SummaryHandler) GetSummary(c *gin.Context) {
 	id := c.Param("id")
 
-	summary, err := h.summaryService.GetSummary(c.Request.Context(), id)
+	subject := policy.NewSubject(middleware.GetUserID(c), middleware.GetActiveContext(c))
+	summary, err := h.summaryService.GetSummary(c.Request.Context(), id, subject)
 	if err != nil {
+		if respondMaterialLocked(c, err) {
+			return
+		}
 		if appErr, ok := errors.GetAppError(err); ok {
 			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
 			return
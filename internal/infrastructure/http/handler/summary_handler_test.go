@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
@@ -75,7 +76,7 @@ func TestSummaryHandler_GetSummary_Success(t *testing.T) {
 	}
 
 	mockService := &MockSummaryService{
-		GetSummaryFunc: func(ctx context.Context, matID string) (*repository.MaterialSummary, error) {
+		GetSummaryFunc: func(ctx context.Context, matID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 			assert.Equal(t, materialID, matID)
 			return expectedSummary, nil
 		},
@@ -112,7 +113,7 @@ func TestSummaryHandler_GetSummary_MaterialNotFound(t *testing.T) {
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
 
 	mockService := &MockSummaryService{
-		GetSummaryFunc: func(ctx context.Context, matID string) (*repository.MaterialSummary, error) {
+		GetSummaryFunc: func(ctx context.Context, matID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 			return nil, errors.NewNotFoundError("summary")
 		},
 	}
@@ -140,7 +141,7 @@ func TestSummaryHandler_GetSummary_InvalidMaterialID(t *testing.T) {
 	invalidID := "not-a-valid-uuid"
 
 	mockService := &MockSummaryService{
-		GetSummaryFunc: func(ctx context.Context, matID string) (*repository.MaterialSummary, error) {
+		GetSummaryFunc: func(ctx context.Context, matID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 			return nil, errors.NewValidationError("invalid material_id")
 		},
 	}
@@ -168,7 +169,7 @@ func TestSummaryHandler_GetSummary_ServiceError(t *testing.T) {
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
 
 	mockService := &MockSummaryService{
-		GetSummaryFunc: func(ctx context.Context, matID string) (*repository.MaterialSummary, error) {
+		GetSummaryFunc: func(ctx context.Context, matID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 			return nil, fmt.Errorf("database connection failed")
 		},
 	}
@@ -196,7 +197,7 @@ func TestSummaryHandler_GetSummary_DatabaseError(t *testing.T) {
 	materialID := "550e8400-e29b-41d4-a716-446655440000"
 
 	mockService := &MockSummaryService{
-		GetSummaryFunc: func(ctx context.Context, matID string) (*repository.MaterialSummary, error) {
+		GetSummaryFunc: func(ctx context.Context, matID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 			return nil, errors.NewDatabaseError("get summary", fmt.Errorf("connection timeout"))
 		},
 	}
@@ -233,7 +234,7 @@ func TestSummaryHandler_GetSummary_EmptySummary(t *testing.T) {
 	}
 
 	mockService := &MockSummaryService{
-		GetSummaryFunc: func(ctx context.Context, matID string) (*repository.MaterialSummary, error) {
+		GetSummaryFunc: func(ctx context.Context, matID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 			return emptySummary, nil
 		},
 	}
@@ -295,7 +296,7 @@ func TestSummaryHandler_GetSummary_WithMultipleSections(t *testing.T) {
 	}
 
 	mockService := &MockSummaryService{
-		GetSummaryFunc: func(ctx context.Context, matID string) (*repository.MaterialSummary, error) {
+		GetSummaryFunc: func(ctx context.Context, matID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 			return summaryWithSections, nil
 		},
 	}
@@ -379,7 +380,7 @@ func TestSummaryHandler_GetSummary_DifferentMaterials(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			mockService := &MockSummaryService{
-				GetSummaryFunc: func(ctx context.Context, matID string) (*repository.MaterialSummary, error) {
+				GetSummaryFunc: func(ctx context.Context, matID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 					assert.Equal(t, tc.materialID, matID)
 					return tc.summary, nil
 				},
@@ -442,7 +443,7 @@ func TestSummaryHandler_GetSummary_WithSpecialCharacters(t *testing.T) {
 	}
 
 	mockService := &MockSummaryService{
-		GetSummaryFunc: func(ctx context.Context, matID string) (*repository.MaterialSummary, error) {
+		GetSummaryFunc: func(ctx context.Context, matID string, subject policy.Subject) (*repository.MaterialSummary, error) {
 			return summaryWithSpecialChars, nil
 		},
 	}
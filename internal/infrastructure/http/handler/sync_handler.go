@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	ginmiddleware "github.com/EduGoGroup/edugo-shared/middleware/gin"
+)
+
+type SyncHandler struct {
+	syncService service.SyncService
+	logger      logger.Logger
+}
+
+func NewSyncHandler(syncService service.SyncService, logger logger.Logger) *SyncHandler {
+	return &SyncHandler{
+		syncService: syncService,
+		logger:      logger,
+	}
+}
+
+// Sync godoc
+// @Summary Offline batch sync
+// @Description Applies a batch of changes made offline (progress updates, reading events and completed assessment attempts), each identified by a client-generated client_id so retries are idempotent. Items are applied in client_timestamp order: percentage is max-merged and last_page follows last-writer-wins. Invalid items are rejected one by one without failing the batch. Offline attempts may carry completed_at (default: client_timestamp), which must not be older than 30 days or than the material. Returns one result per item in request order plus the progress changed on the server since the given cursor (e.g. from another device); changes from the last 10 seconds are returned on the next sync
+// @Tags sync
+// @Accept json
+// @Produce json
+// @Param request body dto.SyncRequest true "Offline changes and last cursor"
+// @Success 200 {object} dto.SyncResponse "Per-item results and server changes"
+// @Failure 400 {object} ErrorResponse "Invalid body or cursor"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /v1/sync [post]
+// @Security BearerAuth
+func (h *SyncHandler) Sync(c *gin.Context) {
+	userID := ginmiddleware.MustGetUserID(c)
+	schoolID := middleware.MustGetSchoolIDFromContext(c)
+
+	var req dto.SyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid sync body", "error", err.Error())
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body", Code: "INVALID_REQUEST"})
+		return
+	}
+
+	// Los intentos requieren su propio permiso; sin él se rechazan ítem por ítem
+	allowAttempts := middleware.HasPermission(c, enum.PermissionAssessmentsAttempt)
+
+	// El subject lleva la unidad y los permisos del contexto activo: los intentos respetan el bloqueo de las rutas
+	subject := policy.NewSubject(userID, middleware.GetActiveContext(c))
+	subject.SchoolID = schoolID.String()
+
+	response, err := h.syncService.Sync(c.Request.Context(), subject, req, allowAttempts)
+	if err != nil {
+		if appErr, ok := errors.GetAppError(err); ok {
+			c.JSON(appErr.StatusCode, ErrorResponse{Error: appErr.Message, Code: string(appErr.Code)})
+			return
+		}
+		h.logger.Error("unexpected error during sync", "error", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/policy"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/common/types/enum"
+)
+
+func newSyncTestRouter(svc *MockSyncService, permissions ...string) *gin.Engine {
+	handler := NewSyncHandler(svc, NewTestLogger())
+	router := SetupTestRouter()
+	router.POST("/sync",
+		MockAuthMiddleware(streamTestUserID, streamTestSchoolID),
+		streamAuthMiddleware(permissions...),
+		handler.Sync,
+	)
+	return router
+}
+
+func postSync(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/sync", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestSyncHandler_Sync_Success verifica el paso del lote y del permiso de intentos al servicio
+func TestSyncHandler_Sync_Success(t *testing.T) {
+	tests := []struct {
+		name          string
+		permissions   []string
+		allowAttempts bool
+	}{
+		{"con permiso de intentos", []string{enum.PermissionProgressUpdate.String(), enum.PermissionAssessmentsAttempt.String()}, true},
+		{"sin permiso de intentos", []string{enum.PermissionProgressUpdate.String()}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockSyncService{
+				SyncFunc: func(ctx context.Context, subject policy.Subject, req dto.SyncRequest, allowAttempts bool) (*dto.SyncResponse, error) {
+					assert.Equal(t, streamTestUserID, subject.UserID)
+					assert.Equal(t, streamTestSchoolID, subject.SchoolID)
+					assert.Equal(t, tt.allowAttempts, allowAttempts)
+					assert.Equal(t, "cursor-1", req.Cursor)
+					require.Len(t, req.Items, 1)
+					return &dto.SyncResponse{
+						Results: []dto.SyncItemResultDTO{{ClientID: req.Items[0].ClientID, Type: req.Items[0].Type, Status: dto.SyncResultApplied}},
+						Changes: []dto.ProgressStateDTO{},
+						Cursor:  "cursor-2",
+					}, nil
+				},
+			}
+			router := newSyncTestRouter(mockService, tt.permissions...)
+
+			w := postSync(router, `{
+				"cursor": "cursor-1",
+				"items": [{
+					"client_id": "c-1",
+					"type": "progress",
+					"client_timestamp": "2026-01-10T10:00:00Z",
+					"progress": {"material_id": "550e8400-e29b-41d4-a716-446655440000", "progress_percentage": 40, "last_page": 3}
+				}]
+			}`)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var response dto.SyncResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Len(t, response.Results, 1)
+			assert.Equal(t, dto.SyncResultApplied, response.Results[0].Status)
+			assert.Equal(t, "cursor-2", response.Cursor)
+		})
+	}
+}
+
+// TestSyncHandler_Sync_InvalidBody verifica el rechazo de lotes mal formados
+func TestSyncHandler_Sync_InvalidBody(t *testing.T) {
+	router := newSyncTestRouter(&MockSyncService{
+		SyncFunc: func(ctx context.Context, subject policy.Subject, req dto.SyncRequest, allowAttempts bool) (*dto.SyncResponse, error) {
+			t.Fatal("el servicio no debe invocarse")
+			return nil, nil
+		},
+	})
+
+	bodies := map[string]string{
+		"json inválido":      `{"items": [`,
+		"tipo de dato":       `{"items": {}}`,
+		"cursor no es texto": `{"cursor": 1}`,
+	}
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			w := postSync(router, body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), "INVALID_REQUEST")
+		})
+	}
+}
+
+// TestSyncHandler_Sync_InvalidItemsReachService verifica que los ítems inválidos se validen en el
+// servicio (rechazo por ítem) y no rechacen el lote completo
+func TestSyncHandler_Sync_InvalidItemsReachService(t *testing.T) {
+	var received dto.SyncRequest
+	router := newSyncTestRouter(&MockSyncService{
+		SyncFunc: func(ctx context.Context, subject policy.Subject, req dto.SyncRequest, allowAttempts bool) (*dto.SyncResponse, error) {
+			received = req
+			return &dto.SyncResponse{}, nil
+		},
+	})
+
+	w := postSync(router, `{"items": [
+		{"client_id": "c-1", "type": "quiz", "client_timestamp": "2026-01-10T10:00:00Z"},
+		{"type": "progress", "client_timestamp": "2026-01-10T10:00:00Z"}
+	]}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, received.Items, 2)
+}
+
+// TestSyncHandler_Sync_InvalidCursor verifica la propagación de errores del servicio
+func TestSyncHandler_Sync_InvalidCursor(t *testing.T) {
+	router := newSyncTestRouter(&MockSyncService{
+		SyncFunc: func(ctx context.Context, subject policy.Subject, req dto.SyncRequest, allowAttempts bool) (*dto.SyncResponse, error) {
+			return nil, errors.NewValidationError("invalid cursor")
+		},
+	})
+
+	w := postSync(router, `{"cursor": "%%%"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, string(errors.ErrorCodeValidation), response.Code)
+}
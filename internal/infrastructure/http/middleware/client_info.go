@@ -16,7 +16,7 @@ const ginContextKey contextKey = "gin_context"
 func ClientInfoMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Extraer IP del cliente
-		// ClientIP() respeta X-Forwarded-For y X-Real-IP headers
+		// ClientIP() solo respeta X-Forwarded-For y X-Real-IP de proxies confiables (ConfigureClientIP)
 		clientIP := c.ClientIP()
 
 		// Extraer User-Agent
@@ -38,3 +38,32 @@ func ClientInfoMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// ClientIPConfig origen de la IP del cliente que usan ClientInfoMiddleware y el rate limiting
+type ClientIPConfig struct {
+	TrustedProxies  []string // IPs o CIDR de los proxies cuyo X-Forwarded-For se acepta; vacío = ninguno
+	TrustedPlatform string   // Header con la IP del cliente puesto por la plataforma (p. ej. CF-Connecting-IP); vacío = ninguno
+}
+
+// ConfigureClientIP configura de qué proxies acepta r la IP del cliente
+// Por defecto gin confía en cualquier proxy y un cliente podría elegir su IP con
+// X-Forwarded-For; sin proxies configurados (o si alguno es inválido) se usa la dirección de la conexión
+func ConfigureClientIP(r *gin.Engine, config ClientIPConfig) error {
+	r.TrustedPlatform = config.TrustedPlatform
+	if err := r.SetTrustedProxies(config.TrustedProxies); err != nil {
+		_ = r.SetTrustedProxies(nil)
+		return err
+	}
+	return nil
+}
+
+// GetClientIP obtiene la IP del cliente registrada por ClientInfoMiddleware
+// Sin el middleware usa c.ClientIP()
+func GetClientIP(c *gin.Context) string {
+	if clientInfo, ok := c.Request.Context().Value(ginContextKey).(map[string]interface{}); ok {
+		if ip, ok := clientInfo["client_ip"].(string); ok && ip != "" {
+			return ip
+		}
+	}
+	return c.ClientIP()
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/application/i18n"
+)
+
+// ContextKeyLocale key del idioma negociado en el contexto de gin
+const ContextKeyLocale = "locale"
+
+// Locale negocia el idioma de la respuesta con Accept-Language (ver i18n.Negotiate) y lo deja
+// en el contexto para los handlers. Si el cliente envía Accept-Language, los errores JSON
+// ({"error", "code"}) se traducen por código; el mensaje original queda en "detail".
+// Los mensajes de la API se redactan en inglés: en inglés o sin header no se reescriben
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Accept-Language")
+		locale := i18n.Negotiate(header)
+		c.Set(ContextKeyLocale, locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		if header == "" || locale == "en" {
+			c.Next()
+			return
+		}
+
+		writer := &localizedErrorWriter{ResponseWriter: c.Writer, locale: locale}
+		c.Writer = writer
+		defer writer.flush()
+
+		c.Next()
+	}
+}
+
+// GetLocale retorna el idioma negociado por Locale (i18n.DefaultLocale si el middleware no corrió)
+func GetLocale(c *gin.Context) string {
+	if locale := c.GetString(ContextKeyLocale); locale != "" {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+// localizedErrorWriter retiene el cuerpo de las respuestas de error JSON para traducirlo
+// Las respuestas exitosas (incluido el streaming) pasan sin buffer
+type localizedErrorWriter struct {
+	gin.ResponseWriter
+	locale string
+	buffer *bytes.Buffer
+}
+
+func (w *localizedErrorWriter) buffering() bool {
+	if w.buffer != nil {
+		return true
+	}
+	header := w.Header()
+	if w.Status() < http.StatusBadRequest || w.Written() ||
+		header.Get("Content-Encoding") != "" ||
+		!strings.HasPrefix(header.Get("Content-Type"), "application/json") {
+		return false
+	}
+	w.buffer = new(bytes.Buffer)
+	return true
+}
+
+func (w *localizedErrorWriter) Write(data []byte) (int, error) {
+	if !w.buffering() {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.buffer.Write(data)
+}
+
+func (w *localizedErrorWriter) WriteString(s string) (int, error) {
+	if !w.buffering() {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.buffer.WriteString(s)
+}
+
+// flush escribe el error traducido; si el cuerpo no tiene la forma esperada lo escribe tal cual
+func (w *localizedErrorWriter) flush() {
+	if w.buffer == nil {
+		return
+	}
+	body := w.buffer.Bytes()
+	w.buffer = nil
+
+	if localized, ok := localizeErrorBody(body, w.locale); ok {
+		body = localized
+	}
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// localizeErrorBody traduce el campo error según code, conservando el resto del cuerpo
+func localizeErrorBody(body []byte, locale string) ([]byte, bool) {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, false
+	}
+	code, _ := payload["code"].(string)
+	original, _ := payload["error"].(string)
+	message, ok := i18n.ErrorMessage(locale, code)
+	if !ok || message == original {
+		return nil, false
+	}
+
+	payload["error"] = message
+	if _, exists := payload["detail"]; !exists && original != "" {
+		payload["detail"] = original
+	}
+	localized, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false
+	}
+	return localized, true
+}
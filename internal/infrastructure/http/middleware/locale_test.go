@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLocaleTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Locale())
+	router.GET("/error", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "screen not found", "code": "NOT_FOUND"})
+	})
+	router.GET("/unknown-code", func(c *gin.Context) {
+		c.JSON(http.StatusConflict, gin.H{"error": "material locked", "code": "SOMETHING_ELSE"})
+	})
+	router.GET("/abort", func(c *gin.Context) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing token", "code": "UNAUTHORIZED"})
+	})
+	router.GET("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"locale": GetLocale(c), "code": "NOT_FOUND"})
+	})
+	return router
+}
+
+func localeRequest(t *testing.T, router *gin.Engine, path, acceptLanguage string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w, body
+}
+
+func TestLocale_TranslatesErrors(t *testing.T) {
+	router := newLocaleTestRouter()
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptLanguage string
+		wantStatus     int
+		wantError      string
+		wantDetail     any
+	}{
+		{name: "español", path: "/error", acceptLanguage: "es-AR", wantStatus: http.StatusNotFound, wantError: "El recurso solicitado no existe", wantDetail: "screen not found"},
+		{name: "portugués", path: "/error", acceptLanguage: "pt-BR,pt;q=0.9", wantStatus: http.StatusNotFound, wantError: "O recurso solicitado não existe", wantDetail: "screen not found"},
+		{name: "idioma no soportado usa el de respaldo", path: "/error", acceptLanguage: "fr", wantStatus: http.StatusNotFound, wantError: "El recurso solicitado no existe", wantDetail: "screen not found"},
+		{name: "inglés conserva el mensaje original", path: "/error", acceptLanguage: "en", wantStatus: http.StatusNotFound, wantError: "screen not found"},
+		{name: "sin Accept-Language no se reescribe", path: "/error", wantStatus: http.StatusNotFound, wantError: "screen not found"},
+		{name: "código sin traducción", path: "/unknown-code", acceptLanguage: "es", wantStatus: http.StatusConflict, wantError: "material locked"},
+		{name: "errores de middlewares (abort)", path: "/abort", acceptLanguage: "pt", wantStatus: http.StatusUnauthorized, wantError: "Autenticação necessária", wantDetail: "missing token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, body := localeRequest(t, router, tt.path, tt.acceptLanguage)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantError, body["error"])
+			assert.Equal(t, tt.wantDetail, body["detail"])
+			assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+		})
+	}
+}
+
+func TestLocale_SuccessPassesThrough(t *testing.T) {
+	router := newLocaleTestRouter()
+
+	w, body := localeRequest(t, router, "/ok", "pt")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "pt", body["locale"])
+	assert.Equal(t, "NOT_FOUND", body["code"], "las respuestas exitosas no se tocan")
+}
+
+func TestGetLocale_DefaultsWithoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	assert.Equal(t, "es", GetLocale(c))
+}
@@ -0,0 +1,10 @@
+package middleware
+
+import "github.com/EduGoGroup/edugo-shared/common/types/enum"
+
+// Permisos propios de api-mobile que aún no existen en shared/common/types/enum.
+// Se otorgan desde api-admin como cualquier otro permiso del rol.
+const (
+	// PermissionEventsManage permite inspeccionar y re-publicar eventos fallidos (dead-letter)
+	PermissionEventsManage = enum.Permission("events:manage")
+)
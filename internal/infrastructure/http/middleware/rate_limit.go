@@ -0,0 +1,266 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// Claves de los buckets de rate limiting (RateLimitPolicy.Key)
+const (
+	RateLimitKeyUser = "user" // user_id del JWT; sin usuario autenticado se usa la IP
+	RateLimitKeyIP   = "ip"   // IP del cliente (ClientInfoMiddleware)
+)
+
+const (
+	// rateLimitDefaultPolicy nombre de la política que aplica a rutas sin política propia
+	rateLimitDefaultPolicy = "default"
+	// rateLimitSweepInterval cada cuánto el store en memoria descarta buckets llenos
+	rateLimitSweepInterval = time.Minute
+)
+
+// httpRateLimitRequestsTotal cuenta las decisiones del rate limiter por política
+// result: allowed, limited o error (el store falló y el request se dejó pasar)
+var httpRateLimitRequestsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "http_rate_limit_requests_total",
+		Help: "Total number of requests evaluated by the rate limiter",
+	},
+	[]string{"policy", "result"},
+)
+
+// RateLimit límite de un token bucket: Requests por Period con ráfagas de hasta Burst
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int // Capacidad del bucket (default: Requests)
+}
+
+// capacity retorna la capacidad del bucket
+func (l RateLimit) capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// interval retorna el tiempo en reponer un token
+func (l RateLimit) interval() time.Duration {
+	return l.Period / time.Duration(l.Requests)
+}
+
+// RateLimitResult resultado de consumir un token
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration // Espera hasta el próximo token (solo si !Allowed)
+	Reset      time.Duration // Espera hasta que el bucket vuelva a estar lleno
+}
+
+// RateLimitStore guarda los buckets de rate limiting
+// MemoryRateLimitStore limita por instancia; un store compartido (p. ej. Redis) reparte el
+// mismo límite entre todas las instancias detrás del balanceador
+type RateLimitStore interface {
+	// Take consume un token del bucket key según limit
+	Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
+
+// RateLimitPolicy política de rate limiting de una ruta
+// Route es "METHOD /v1/ruta/:param" o solo la ruta (todos los métodos), como en el router
+// Requests 0 = sin límite
+type RateLimitPolicy struct {
+	Route string
+	Key   string // RateLimitKeyUser o RateLimitKeyIP
+	RateLimit
+}
+
+// RateLimitConfig configuración del middleware de rate limiting
+type RateLimitConfig struct {
+	Store    RateLimitStore
+	Policies []RateLimitPolicy // Políticas por ruta
+	Default  *RateLimitPolicy  // Rutas sin política propia; nil = sin límite. Route vacía = "default"
+	Logger   logger.Logger
+}
+
+// RateLimitMiddleware limita la tasa de requests por usuario o IP con un token bucket por
+// política. Agrega los headers RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset y
+// RateLimit-Policy y responde 429 con Retry-After al agotarse el bucket
+// Debe usarse DESPUÉS de RemoteAuthMiddleware para limitar por usuario; antes solo limita por IP
+func RateLimitMiddleware(config RateLimitConfig) gin.HandlerFunc {
+	policies := make(map[string]RateLimitPolicy, len(config.Policies))
+	for _, policy := range config.Policies {
+		policies[policy.Route] = policy
+	}
+
+	return func(c *gin.Context) {
+		policy, ok := policies[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			policy, ok = policies[c.FullPath()]
+		}
+		if !ok {
+			if config.Default == nil {
+				c.Next()
+				return
+			}
+			policy = *config.Default
+			if policy.Route == "" {
+				policy.Route = rateLimitDefaultPolicy
+			}
+		}
+		if policy.Requests <= 0 || policy.Period <= 0 {
+			// Política sin límite: permite excluir una ruta de la política por defecto
+			c.Next()
+			return
+		}
+
+		result, err := config.Store.Take(c.Request.Context(), policy.Route+"|"+rateLimitKey(c, policy.Key), policy.RateLimit)
+		if err != nil {
+			// Un store caído no debe tumbar la API: se deja pasar el request
+			httpRateLimitRequestsTotal.WithLabelValues(policy.Route, "error").Inc()
+			if config.Logger != nil {
+				config.Logger.Warn("error en rate limiting, request permitido", "policy", policy.Route, "error", err)
+			}
+			c.Next()
+			return
+		}
+
+		capacity := policy.capacity()
+		c.Header("RateLimit-Limit", strconv.Itoa(capacity))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		c.Header("RateLimit-Policy", strconv.Itoa(capacity)+";w="+strconv.Itoa(ceilSeconds(policy.Period)))
+
+		if !result.Allowed {
+			httpRateLimitRequestsTotal.WithLabelValues(policy.Route, "limited").Inc()
+			c.Header("Retry-After", strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, errorResponse{
+				Error: "too many requests, please try again later",
+				Code:  "RATE_LIMIT_EXCEEDED",
+			})
+			return
+		}
+
+		httpRateLimitRequestsTotal.WithLabelValues(policy.Route, "allowed").Inc()
+		c.Next()
+	}
+}
+
+// errorResponse cuerpo de error con el mismo formato que handler.ErrorResponse
+// (el middleware no puede importar handler); middleware.Locale traduce Error según Code
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// rateLimitKey identifica al cliente según la clave de la política
+func rateLimitKey(c *gin.Context, key string) string {
+	if key != RateLimitKeyIP {
+		if userID := GetUserID(c); userID != "" {
+			return "user:" + userID
+		}
+	}
+	return "ip:" + GetClientIP(c)
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// ============================================
+// Store en memoria
+// ============================================
+
+// MemoryRateLimitStore store de buckets en memoria (por instancia)
+// Los buckets que volvieron a llenarse se descartan periódicamente: equivalen a uno nuevo
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	tokens   float64
+	capacity float64
+	interval time.Duration
+	updated  time.Time
+}
+
+// NewMemoryRateLimitStore crea un store en memoria
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Take consume un token del bucket key
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	capacity := float64(limit.capacity())
+	bucket, ok := s.buckets[key]
+	if !ok || bucket.capacity != capacity || bucket.interval != limit.interval() {
+		// Bucket nuevo o política cambiada: empieza lleno
+		bucket = &tokenBucket{tokens: capacity, capacity: capacity, interval: limit.interval(), updated: now}
+		s.buckets[key] = bucket
+	}
+	bucket.refill(now)
+
+	if bucket.tokens < 1 {
+		return RateLimitResult{
+			Allowed:    false,
+			RetryAfter: time.Duration((1 - bucket.tokens) * float64(bucket.interval)),
+			Reset:      bucket.untilFull(),
+		}, nil
+	}
+
+	bucket.tokens--
+	return RateLimitResult{
+		Allowed:   true,
+		Remaining: int(bucket.tokens),
+		Reset:     bucket.untilFull(),
+	}, nil
+}
+
+// sweep descarta los buckets llenos (sin requests recientes)
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, bucket := range s.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= bucket.capacity {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// refill repone los tokens acumulados desde la última actualización
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated)
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens = math.Min(b.capacity, b.tokens+float64(elapsed)/float64(b.interval))
+	b.updated = now
+}
+
+// untilFull retorna el tiempo que tarda el bucket en volver a llenarse
+func (b *tokenBucket) untilFull() time.Duration {
+	return time.Duration((b.capacity - b.tokens) * float64(b.interval))
+}
@@ -0,0 +1,367 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newRateLimitRouter crea un router con el rate limiter; el header X-Test-User simula el
+// user_id que deja RemoteAuthMiddleware
+func newRateLimitRouter(config RateLimitConfig) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+	router.Use(RateLimitMiddleware(config))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/v1/materials/:id", ok)
+	router.POST("/v1/materials/:id/assessment/attempts", ok)
+	router.GET("/v1/stats/global", ok)
+	router.GET("/v1/stream", ok)
+	return router
+}
+
+func doRateLimitRequest(router *gin.Engine, method, path, userID, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if userID != "" {
+		req.Header.Set("X-Test-User", userID)
+	}
+	req.RemoteAddr = ip + ":12345"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// newTestRateLimitStore crea un store en memoria con un reloj controlado por el test
+func newTestRateLimitStore(now *time.Time) *MemoryRateLimitStore {
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestRateLimitMiddleware_LimitsAndSetsHeaders(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	router := newRateLimitRouter(RateLimitConfig{
+		Store: newTestRateLimitStore(&now),
+		Policies: []RateLimitPolicy{{
+			Route:     "POST /v1/materials/:id/assessment/attempts",
+			RateLimit: RateLimit{Requests: 2, Period: time.Minute},
+		}},
+	})
+
+	for i, remaining := range []string{"1", "0"} {
+		w := doRateLimitRequest(router, http.MethodPost, "/v1/materials/m1/assessment/attempts", "user-1", "10.0.0.1")
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Remaining"); got != remaining {
+			t.Errorf("request %d: expected RateLimit-Remaining %s, got %q", i, remaining, got)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "2" {
+			t.Errorf("request %d: expected RateLimit-Limit 2, got %q", i, got)
+		}
+		if got := w.Header().Get("RateLimit-Policy"); got != "2;w=60" {
+			t.Errorf("request %d: expected RateLimit-Policy 2;w=60, got %q", i, got)
+		}
+	}
+
+	w := doRateLimitRequest(router, http.MethodPost, "/v1/materials/m2/assessment/attempts", "user-1", "10.0.0.1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+	// 2 requests por minuto: un token cada 30s
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("expected Retry-After 30, got %q", got)
+	}
+	if got := w.Header().Get("RateLimit-Reset"); got != "60" {
+		t.Errorf("expected RateLimit-Reset 60, got %q", got)
+	}
+	if got := w.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("expected RateLimit-Remaining 0, got %q", got)
+	}
+	if got := w.Body.String(); got != `{"error":"too many requests, please try again later","code":"RATE_LIMIT_EXCEEDED"}` {
+		t.Errorf("unexpected 429 body: %s", got)
+	}
+
+	// Otro usuario tiene su propio bucket
+	if w := doRateLimitRequest(router, http.MethodPost, "/v1/materials/m1/assessment/attempts", "user-2", "10.0.0.1"); w.Code != http.StatusOK {
+		t.Errorf("expected 200 for another user, got %d", w.Code)
+	}
+
+	// Al reponerse un token se permite de nuevo
+	now = now.Add(30 * time.Second)
+	if w := doRateLimitRequest(router, http.MethodPost, "/v1/materials/m1/assessment/attempts", "user-1", "10.0.0.1"); w.Code != http.StatusOK {
+		t.Errorf("expected 200 after refill, got %d", w.Code)
+	}
+}
+
+func TestRateLimitMiddleware_PolicySelection(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	router := newRateLimitRouter(RateLimitConfig{
+		Store: newTestRateLimitStore(&now),
+		Policies: []RateLimitPolicy{
+			{Route: "/v1/stats/global", RateLimit: RateLimit{Requests: 1, Period: time.Minute}},
+			{Route: "GET /v1/stream"}, // Sin límite
+		},
+		Default: &RateLimitPolicy{RateLimit: RateLimit{Requests: 3, Period: time.Minute}},
+	})
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		allowed int
+		limited bool
+	}{
+		{"ruta sin método", http.MethodGet, "/v1/stats/global", 1, true},
+		{"política por defecto", http.MethodGet, "/v1/materials/m1", 3, true},
+		{"política sin límite", http.MethodGet, "/v1/stream", 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < tt.allowed; i++ {
+				if w := doRateLimitRequest(router, tt.method, tt.path, "user-1", "10.0.0.1"); w.Code != http.StatusOK {
+					t.Fatalf("request %d: expected 200, got %d", i, w.Code)
+				}
+			}
+			w := doRateLimitRequest(router, tt.method, tt.path, "user-1", "10.0.0.1")
+			if limited := w.Code == http.StatusTooManyRequests; limited != tt.limited {
+				t.Errorf("expected limited=%v, got status %d", tt.limited, w.Code)
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware_WithoutDefaultIsUnlimited(t *testing.T) {
+	router := newRateLimitRouter(RateLimitConfig{
+		Store: NewMemoryRateLimitStore(),
+		Policies: []RateLimitPolicy{
+			{Route: "/v1/stats/global", RateLimit: RateLimit{Requests: 1, Period: time.Minute}},
+		},
+	})
+
+	for i := 0; i < 5; i++ {
+		w := doRateLimitRequest(router, http.MethodGet, "/v1/materials/m1", "user-1", "10.0.0.1")
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "" {
+			t.Errorf("expected no RateLimit headers, got RateLimit-Limit %q", got)
+		}
+	}
+}
+
+func TestRateLimitMiddleware_Keys(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	limit := RateLimit{Requests: 1, Period: time.Minute}
+	router := newRateLimitRouter(RateLimitConfig{
+		Store: newTestRateLimitStore(&now),
+		Policies: []RateLimitPolicy{
+			{Route: "/v1/stats/global", Key: RateLimitKeyIP, RateLimit: limit},
+			{Route: "/v1/materials/:id", Key: RateLimitKeyUser, RateLimit: limit},
+		},
+	})
+
+	// key=ip: dos usuarios detrás de la misma IP comparten el bucket
+	doRateLimitRequest(router, http.MethodGet, "/v1/stats/global", "user-1", "10.0.0.1")
+	if w := doRateLimitRequest(router, http.MethodGet, "/v1/stats/global", "user-2", "10.0.0.1"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("key=ip: expected 429 for same IP, got %d", w.Code)
+	}
+	if w := doRateLimitRequest(router, http.MethodGet, "/v1/stats/global", "user-1", "10.0.0.2"); w.Code != http.StatusOK {
+		t.Errorf("key=ip: expected 200 for another IP, got %d", w.Code)
+	}
+
+	// key=user: el mismo usuario desde otra IP comparte el bucket
+	doRateLimitRequest(router, http.MethodGet, "/v1/materials/m1", "user-1", "10.0.0.1")
+	if w := doRateLimitRequest(router, http.MethodGet, "/v1/materials/m1", "user-1", "10.0.0.2"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("key=user: expected 429 for same user, got %d", w.Code)
+	}
+
+	// key=user sin usuario autenticado: se limita por IP
+	doRateLimitRequest(router, http.MethodGet, "/v1/materials/m1", "", "10.0.0.3")
+	if w := doRateLimitRequest(router, http.MethodGet, "/v1/materials/m1", "", "10.0.0.3"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("key=user without user: expected 429 for same IP, got %d", w.Code)
+	}
+	if w := doRateLimitRequest(router, http.MethodGet, "/v1/materials/m1", "", "10.0.0.4"); w.Code != http.StatusOK {
+		t.Errorf("key=user without user: expected 200 for another IP, got %d", w.Code)
+	}
+}
+
+func TestRateLimitMiddleware_ClientIP(t *testing.T) {
+	newRouter := func(clientIP ClientIPConfig) *gin.Engine {
+		router := gin.New()
+		if err := ConfigureClientIP(router, clientIP); err != nil {
+			t.Fatalf("ConfigureClientIP: %v", err)
+		}
+		router.Use(ClientInfoMiddleware())
+		router.Use(RateLimitMiddleware(RateLimitConfig{
+			Store:   NewMemoryRateLimitStore(),
+			Default: &RateLimitPolicy{Key: RateLimitKeyIP, RateLimit: RateLimit{Requests: 1, Period: time.Minute}},
+		}))
+		router.GET("/v1/materials/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+		return router
+	}
+	doRequest := func(router *gin.Engine, remoteIP, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/v1/materials/m1", nil)
+		req.RemoteAddr = remoteIP + ":12345"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Sin proxies confiables un X-Forwarded-For falso no cambia el bucket
+	router := newRouter(ClientIPConfig{})
+	doRequest(router, "203.0.113.7", "198.51.100.1")
+	if code := doRequest(router, "203.0.113.7", "198.51.100.2"); code != http.StatusTooManyRequests {
+		t.Errorf("spoofed X-Forwarded-For: expected 429, got %d", code)
+	}
+
+	// Detrás de un proxy confiable cada cliente tiene su bucket
+	router = newRouter(ClientIPConfig{TrustedProxies: []string{"10.0.0.0/8"}})
+	doRequest(router, "10.0.0.1", "198.51.100.1")
+	if code := doRequest(router, "10.0.0.1", "198.51.100.2"); code != http.StatusOK {
+		t.Errorf("trusted proxy: expected 200 for another client, got %d", code)
+	}
+	if code := doRequest(router, "10.0.0.2", "198.51.100.1"); code != http.StatusTooManyRequests {
+		t.Errorf("trusted proxy: expected 429 for same client, got %d", code)
+	}
+}
+
+func TestConfigureClientIP_InvalidProxyTrustsNone(t *testing.T) {
+	router := gin.New()
+	if err := ConfigureClientIP(router, ClientIPConfig{TrustedProxies: []string{"10.0.0.0/8", "proxy"}}); err == nil {
+		t.Fatal("expected error for invalid proxy")
+	}
+	router.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "10.0.0.1:12345"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if got := w.Body.String(); got != "10.0.0.1" {
+		t.Errorf("expected connection IP, got %q", got)
+	}
+}
+
+// failingRateLimitStore simula un store compartido caído
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, RateLimit) (RateLimitResult, error) {
+	return RateLimitResult{}, errors.New("store no disponible")
+}
+
+func TestRateLimitMiddleware_StoreErrorAllowsRequest(t *testing.T) {
+	router := newRateLimitRouter(RateLimitConfig{
+		Store:    failingRateLimitStore{},
+		Policies: []RateLimitPolicy{{Route: "GET /v1/materials/:id", RateLimit: RateLimit{Requests: 1, Period: time.Minute}}},
+	})
+	before := testutil.ToFloat64(httpRateLimitRequestsTotal.WithLabelValues("GET /v1/materials/:id", "error"))
+
+	for i := 0; i < 3; i++ {
+		if w := doRateLimitRequest(router, http.MethodGet, "/v1/materials/m1", "user-1", "10.0.0.1"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200 when the store fails, got %d", i, w.Code)
+		}
+	}
+
+	if got := testutil.ToFloat64(httpRateLimitRequestsTotal.WithLabelValues("GET /v1/materials/:id", "error")) - before; got != 3 {
+		t.Errorf("expected 3 error decisions, got %v", got)
+	}
+}
+
+func TestRateLimitMiddleware_Metrics(t *testing.T) {
+	router := newRateLimitRouter(RateLimitConfig{
+		Store:   NewMemoryRateLimitStore(),
+		Default: &RateLimitPolicy{RateLimit: RateLimit{Requests: 2, Period: time.Hour}},
+	})
+	allowed := httpRateLimitRequestsTotal.WithLabelValues("default", "allowed")
+	limited := httpRateLimitRequestsTotal.WithLabelValues("default", "limited")
+	allowedBefore, limitedBefore := testutil.ToFloat64(allowed), testutil.ToFloat64(limited)
+
+	for i := 0; i < 3; i++ {
+		doRateLimitRequest(router, http.MethodGet, "/v1/materials/m1", "metrics-user", "10.0.0.1")
+	}
+
+	if got := testutil.ToFloat64(allowed) - allowedBefore; got != 2 {
+		t.Errorf("expected 2 allowed decisions, got %v", got)
+	}
+	if got := testutil.ToFloat64(limited) - limitedBefore; got != 1 {
+		t.Errorf("expected 1 limited decision, got %v", got)
+	}
+}
+
+func TestRateLimitMiddleware_NamedDefaultPolicy(t *testing.T) {
+	router := newRateLimitRouter(RateLimitConfig{
+		Store:   NewMemoryRateLimitStore(),
+		Default: &RateLimitPolicy{Route: "pre_auth", Key: RateLimitKeyIP, RateLimit: RateLimit{Requests: 1, Period: time.Hour}},
+	})
+	limited := httpRateLimitRequestsTotal.WithLabelValues("pre_auth", "limited")
+	before := testutil.ToFloat64(limited)
+
+	doRateLimitRequest(router, http.MethodGet, "/v1/materials/m1", "", "10.0.0.9")
+	if w := doRateLimitRequest(router, http.MethodGet, "/v1/stats/global", "", "10.0.0.9"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+	if got := testutil.ToFloat64(limited) - before; got != 1 {
+		t.Errorf("expected 1 limited decision for pre_auth, got %v", got)
+	}
+}
+
+func TestMemoryRateLimitStore_Burst(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newTestRateLimitStore(&now)
+	limit := RateLimit{Requests: 60, Period: time.Minute, Burst: 2}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if result, _ := store.Take(ctx, "k", limit); !result.Allowed {
+			t.Fatalf("request %d: expected allowed within burst", i)
+		}
+	}
+	result, _ := store.Take(ctx, "k", limit)
+	if result.Allowed {
+		t.Fatal("expected limited after burst")
+	}
+	if result.RetryAfter != time.Second {
+		t.Errorf("expected RetryAfter 1s, got %v", result.RetryAfter)
+	}
+
+	// Aunque pase mucho tiempo el bucket no supera el burst
+	now = now.Add(time.Hour)
+	result, _ = store.Take(ctx, "k", limit)
+	if !result.Allowed || result.Remaining != 1 {
+		t.Errorf("expected allowed with 1 remaining, got %+v", result)
+	}
+}
+
+func TestMemoryRateLimitStore_Sweep(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newTestRateLimitStore(&now)
+	ctx := context.Background()
+
+	_, _ = store.Take(ctx, "idle", RateLimit{Requests: 10, Period: time.Minute})
+	_, _ = store.Take(ctx, "busy", RateLimit{Requests: 1, Period: time.Hour})
+
+	now = now.Add(2 * rateLimitSweepInterval)
+	_, _ = store.Take(ctx, "other", RateLimit{Requests: 10, Period: time.Minute})
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if _, ok := store.buckets["idle"]; ok {
+		t.Error("expected full bucket to be swept")
+	}
+	if _, ok := store.buckets["busy"]; !ok {
+		t.Error("expected bucket still refilling to be kept")
+	}
+}
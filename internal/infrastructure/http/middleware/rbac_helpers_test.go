@@ -157,3 +157,18 @@ func TestRequireAnyPermission_NoActiveContext(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, w.Code)
 	assert.Contains(t, w.Body.String(), "Se requiere contexto RBAC activo")
 }
+
+// Tests para HasPermission
+func TestHasPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	assert.False(t, HasPermission(c, enum.PermissionStatsUnit), "sin ActiveContext no hay permisos")
+
+	c.Set(ContextKeyActiveContext, &auth.UserContext{
+		Permissions: []string{"materials:read", "stats:unit"},
+	})
+
+	assert.True(t, HasPermission(c, enum.PermissionStatsUnit))
+	assert.False(t, HasPermission(c, enum.PermissionStatsSchool))
+}
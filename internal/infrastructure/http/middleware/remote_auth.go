@@ -81,7 +81,16 @@ func RemoteAuthMiddleware(config RemoteAuthConfig) gin.HandlerFunc {
 			return
 		}
 
-		// 6. Inyectar información del usuario en el contexto de Gin
+		// 6. Verificar que el token no fue revocado (logout o "cerrar todas las sesiones")
+		if config.AuthClient.IsRevoked(tokenInfo) {
+			if config.Logger != nil {
+				config.Logger.Warn("token revocado", "user_id", tokenInfo.UserID)
+			}
+			handleUnauthorized(c, config, "Token revocado")
+			return
+		}
+
+		// 7. Inyectar información del usuario en el contexto de Gin
 		// Estos valores estarán disponibles en los handlers
 		c.Set("user_id", tokenInfo.UserID)
 		c.Set("email", tokenInfo.Email)
@@ -101,7 +110,7 @@ func RemoteAuthMiddleware(config RemoteAuthConfig) gin.HandlerFunc {
 			config.Logger.Debug("autenticación exitosa", logFields...)
 		}
 
-		// 7. Continuar con el siguiente handler
+		// 8. Continuar con el siguiente handler
 		c.Next()
 	}
 }
@@ -204,6 +213,21 @@ func GetActiveContext(c *gin.Context) *auth.UserContext {
 	return nil
 }
 
+// HasPermission indica si el ActiveContext del usuario incluye el permiso indicado
+// Útil en handlers cuyo comportamiento cambia según permisos opcionales
+func HasPermission(c *gin.Context, permission enum.Permission) bool {
+	activeCtx := GetActiveContext(c)
+	if activeCtx == nil {
+		return false
+	}
+	for _, perm := range activeCtx.Permissions {
+		if perm == permission.String() {
+			return true
+		}
+	}
+	return false
+}
+
 // RequirePermission middleware que verifica que el usuario tenga el permiso especificado.
 // Requiere que el token JWT incluya ActiveContext con permisos RBAC.
 // Debe usarse DESPUES de RemoteAuthMiddleware.
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/client"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-shared/auth"
 	"github.com/EduGoGroup/edugo-shared/common/types/enum"
 )
@@ -476,3 +478,57 @@ func TestRemoteAuthMiddleware_AllRoles(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoteAuthMiddleware_RevokedToken(t *testing.T) {
+	authClient := client.NewAuthClient(client.AuthClientConfig{
+		JWTSecret:    testJWTSecret,
+		JWTIssuer:    testJWTIssuer,
+		CacheEnabled: true,
+		CacheTTL:     time.Minute,
+	})
+
+	router := gin.New()
+	router.Use(RemoteAuthMiddleware(RemoteAuthConfig{
+		AuthClient: authClient,
+	}))
+	router.GET("/protected", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	request := func(token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	logoutToken := generateTestToken(t, "user-logout", "logout@example.com", enum.SystemRoleTeacher, 15*time.Minute)
+	sessionsToken := generateTestToken(t, "user-sessions", "sessions@example.com", enum.SystemRoleStudent, 15*time.Minute)
+	otherToken := generateTestToken(t, "user-other", "other@example.com", enum.SystemRoleStudent, 15*time.Minute)
+	for _, token := range []string{logoutToken, sessionsToken, otherToken} {
+		if w := request(token); w.Code != http.StatusOK {
+			t.Fatalf("Status code esperado 200 antes de revocar, obtenido %d", w.Code)
+		}
+	}
+
+	// Logout (por jti) y "cerrar todas las sesiones" (por usuario) con las validaciones ya cacheadas
+	info, _ := authClient.ValidateToken(context.Background(), logoutToken)
+	authClient.RevokeTokens(repository.TokenRevocation{TokenID: info.TokenID})
+	authClient.RevokeTokens(repository.TokenRevocation{UserID: "user-sessions", IssuedBefore: time.Now().Add(time.Second)})
+
+	for _, token := range []string{logoutToken, sessionsToken} {
+		w := request(token)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Status code esperado 401 para token revocado, obtenido %d", w.Code)
+		}
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		if response["message"] != "Token revocado" {
+			t.Errorf("Mensaje esperado 'Token revocado', obtenido %v", response["message"])
+		}
+	}
+	if w := request(otherToken); w.Code != http.StatusOK {
+		t.Errorf("Tokens de otros usuarios no se ven afectados, obtenido %d", w.Code)
+	}
+}
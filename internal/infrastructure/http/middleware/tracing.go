@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/tracing"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDHeader es el header de respuesta con el trace ID del request
+const TraceIDHeader = "X-Trace-ID"
+
+// TracingConfig permite configurar el middleware de tracing
+type TracingConfig struct {
+	// SkipPaths son paths que no generan spans (ej: /metrics, /health)
+	SkipPaths []string
+}
+
+// TracingMiddleware crea un span servidor por cada request HTTP.
+// Extrae el contexto W3C (traceparent) de los headers entrantes, de modo que los
+// spans de base de datos, S3 y AMQP generados al atender el request cuelgan de la
+// misma traza. Debe ir después de RequestIDMiddleware para registrar el request_id.
+func TracingMiddleware() gin.HandlerFunc {
+	return TracingMiddlewareWithConfig(TracingConfig{})
+}
+
+// TracingMiddlewareWithConfig crea un middleware de tracing con configuración personalizada
+func TracingMiddlewareWithConfig(config TracingConfig) gin.HandlerFunc {
+	skipPaths := make(map[string]bool)
+	for _, path := range config.SkipPaths {
+		skipPaths[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unknown"
+		}
+
+		ctx, span := tracing.StartSpan(ctx, fmt.Sprintf("%s %s", c.Request.Method, route), trace.SpanKindServer,
+			attribute.String("http.request.method", c.Request.Method),
+			attribute.String("http.route", route),
+			attribute.String("url.path", c.Request.URL.Path),
+			attribute.String("request_id", GetRequestIDFromGin(c)),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		if traceID := tracing.TraceID(ctx); traceID != "" {
+			c.Header(TraceIDHeader, traceID)
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
+	}
+}
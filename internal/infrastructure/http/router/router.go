@@ -17,18 +17,26 @@ import (
 // Este servicio valida tokens contra api-admin usando RemoteAuthMiddleware.
 func SetupRouter(c *container.Container, healthHandler *handler.HealthHandler) *gin.Engine {
 	r := gin.New() // Usar gin.New() en lugar de Default() para control total de middleware
+	// Sin proxies confiables gin tomaría la IP de X-Forwarded-For de cualquier cliente
+	if err := middleware.ConfigureClientIP(r, c.Infrastructure.ClientIP); err != nil {
+		c.Infrastructure.Logger.Error("proxies confiables inválidos, se usa la IP de la conexión", "error", err)
+	}
 
 	// Middleware global (orden importante)
 	r.Use(gin.Recovery())                   // 1. Recuperar de panics
 	r.Use(middleware.RequestIDMiddleware()) // 2. Generar/propagar request ID para tracing
+	r.Use(middleware.TracingMiddlewareWithConfig(middleware.TracingConfig{
+		SkipPaths: []string{"/health", "/metrics"}, // No trazar endpoints de infraestructura
+	})) // 3. Span OpenTelemetry (propaga traceparent)
 	r.Use(middleware.MetricsMiddlewareWithConfig(middleware.MetricsConfig{
 		SkipPaths: []string{"/health", "/metrics"}, // No registrar métricas de endpoints de infraestructura
-	})) // 3. Métricas Prometheus
+	})) // 4. Métricas Prometheus
 	r.Use(middleware.LoggingMiddlewareWithConfig(c.Infrastructure.Logger, middleware.LoggingConfig{
 		SkipPaths: []string{"/health", "/metrics"}, // No loguear endpoints de infraestructura
-	})) // 4. Logging estructurado con request_id
-	r.Use(middleware.CORS())                 // 5. CORS headers
-	r.Use(middleware.ClientInfoMiddleware()) // 6. Extraer IP y User-Agent del cliente
+	})) // 5. Logging estructurado con request_id
+	r.Use(middleware.CORS())                 // 6. CORS headers
+	r.Use(middleware.ClientInfoMiddleware()) // 7. Extraer IP y User-Agent del cliente
+	r.Use(middleware.Locale())               // 8. Idioma (Accept-Language) y errores traducidos
 
 	// Endpoints de infraestructura (públicos, sin versión)
 	r.GET("/health", healthHandler.Check)
@@ -52,6 +60,11 @@ func SetupRouter(c *container.Container, healthHandler *handler.HealthHandler) *
 func setupProtectedRoutes(rg *gin.RouterGroup, c *container.Container) {
 	protected := rg.Group("")
 
+	// Límite por IP antes de validar el token: acota los requests con tokens inválidos
+	if c.Infrastructure.PreAuthLimiter != nil {
+		protected.Use(c.Infrastructure.PreAuthLimiter)
+	}
+
 	// Middleware de autenticación remota con api-admin
 	// Reemplaza la validación local de JWT por validación centralizada
 	protected.Use(middleware.RemoteAuthMiddleware(middleware.RemoteAuthConfig{
@@ -59,6 +72,11 @@ func setupProtectedRoutes(rg *gin.RouterGroup, c *container.Container) {
 		Logger:     c.Infrastructure.Logger,
 		SkipPaths:  []string{}, // Todas las rutas en este grupo requieren auth
 	}))
+
+	// Rate limiting después de la autenticación para limitar por usuario (config.RateLimitConfig)
+	if c.Infrastructure.RateLimiter != nil {
+		protected.Use(c.Infrastructure.RateLimiter)
+	}
 	{
 		// Rutas de materiales
 		setupMaterialRoutes(protected, c)
@@ -69,11 +87,26 @@ func setupProtectedRoutes(rg *gin.RouterGroup, c *container.Container) {
 		// Rutas de progreso (progress)
 		setupProgressRoutes(protected, c)
 
+		// Sincronización offline en lote
+		setupSyncRoutes(protected, c)
+
+		// Rutas de aprendizaje (secuencias de materiales con requisitos)
+		setupLearningPathRoutes(protected, c)
+
 		// Rutas de estadísticas globales
 		setupStatsRoutes(protected, c)
 
 		// Rutas de pantallas dinámicas (Dynamic UI - Phase 1)
 		setupScreenRoutes(protected, c)
+
+		// Reportes docentes por unidad académica
+		setupReportRoutes(protected, c)
+
+		// Rutas de administración (dead-letter de eventos)
+		setupAdminRoutes(protected, c)
+
+		// Stream de eventos en tiempo real (SSE)
+		setupStreamRoutes(protected, c)
 	}
 }
 
@@ -86,8 +119,10 @@ func setupMaterialRoutes(rg *gin.RouterGroup, c *container.Container) {
 			middleware.RequirePermission(enum.PermissionMaterialsRead),
 			c.Handlers.MaterialHandler.ListMaterials,
 		)
+		// Los servicios responden 403 MATERIAL_LOCKED en los materiales bloqueados por una ruta de aprendizaje
 		materials.GET("/:id",
 			middleware.RequirePermission(enum.PermissionMaterialsRead),
+			c.Handlers.StatsHandler.TrackMaterialView,
 			c.Handlers.MaterialHandler.GetMaterial,
 		)
 		materials.GET("/:id/versions",
@@ -96,6 +131,7 @@ func setupMaterialRoutes(rg *gin.RouterGroup, c *container.Container) {
 		)
 		materials.GET("/:id/download-url",
 			middleware.RequirePermission(enum.PermissionMaterialsDownload),
+			c.Handlers.StatsHandler.TrackMaterialView,
 			c.Handlers.MaterialHandler.GenerateDownloadURL,
 		)
 		materials.GET("/:id/summary",
@@ -106,6 +142,10 @@ func setupMaterialRoutes(rg *gin.RouterGroup, c *container.Container) {
 			middleware.RequirePermission(enum.PermissionAssessmentsRead),
 			c.Handlers.AssessmentHandler.GetMaterialAssessment,
 		)
+		materials.GET("/:id/assessment/analytics",
+			middleware.RequirePermission(enum.PermissionStatsUnit),
+			c.Handlers.AssessmentHandler.GetAssessmentAnalytics,
+		)
 		materials.GET("/:id/stats",
 			middleware.RequirePermission(enum.PermissionStatsUnit),
 			c.Handlers.StatsHandler.GetMaterialStats,
@@ -168,6 +208,76 @@ func setupProgressRoutes(rg *gin.RouterGroup, c *container.Container) {
 			middleware.RequirePermission(enum.PermissionProgressUpdate),
 			c.Handlers.ProgressHandler.UpsertProgress,
 		)
+		// Eventos de lectura en lote: el progreso se deriva en el servidor
+		progress.POST("/events",
+			middleware.RequirePermission(enum.PermissionProgressUpdate),
+			c.Handlers.ProgressHandler.RecordReadingEvents,
+		)
+		// Reinicio explícito: única forma de que el progreso baje
+		progress.POST("/reset",
+			middleware.RequirePermission(enum.PermissionProgressUpdate),
+			c.Handlers.ProgressHandler.ResetProgress,
+		)
+	}
+
+	// Progreso agregado del usuario autenticado ("mi aprendizaje")
+	users := rg.Group("/users")
+	{
+		users.GET("/me/progress",
+			middleware.RequirePermission(enum.PermissionProgressRead),
+			c.Handlers.ProgressHandler.ListMyProgress,
+		)
+		users.GET("/me/activity",
+			middleware.RequirePermission(enum.PermissionProgressRead),
+			c.Handlers.ActivityHandler.GetMyActivity,
+		)
+
+		// Cola de repaso espaciado (SM-2)
+		users.GET("/me/review",
+			middleware.RequirePermission(enum.PermissionProgressRead),
+			c.Handlers.ReviewHandler.GetMyReviewQueue,
+		)
+		users.POST("/me/review/:itemId",
+			middleware.RequirePermission(enum.PermissionProgressUpdate),
+			c.Handlers.ReviewHandler.GradeReviewItem,
+		)
+	}
+}
+
+// setupSyncRoutes configura la sincronización offline.
+// Requiere progress:update; los ítems de intentos exigen además assessments:attempt (verificado por ítem)
+func setupSyncRoutes(rg *gin.RouterGroup, c *container.Container) {
+	rg.POST("/sync",
+		middleware.RequirePermission(enum.PermissionProgressUpdate),
+		c.Handlers.SyncHandler.Sync,
+	)
+}
+
+// setupLearningPathRoutes configura las rutas de aprendizaje.
+// La lectura incluye el estado de desbloqueo del usuario; la gestión queda para docentes
+func setupLearningPathRoutes(rg *gin.RouterGroup, c *container.Container) {
+	paths := rg.Group("/paths")
+	{
+		paths.GET("",
+			middleware.RequirePermission(enum.PermissionMaterialsRead),
+			c.Handlers.LearningPathHandler.ListPaths,
+		)
+		paths.GET("/:id",
+			middleware.RequirePermission(enum.PermissionMaterialsRead),
+			c.Handlers.LearningPathHandler.GetPath,
+		)
+		paths.POST("",
+			middleware.RequirePermission(enum.PermissionMaterialsCreate),
+			c.Handlers.LearningPathHandler.CreatePath,
+		)
+		paths.PUT("/:id",
+			middleware.RequirePermission(enum.PermissionMaterialsUpdate),
+			c.Handlers.LearningPathHandler.UpdatePath,
+		)
+		paths.DELETE("/:id",
+			middleware.RequirePermission(enum.PermissionMaterialsUpdate),
+			c.Handlers.LearningPathHandler.DeletePath,
+		)
 	}
 }
 
@@ -187,10 +297,33 @@ func setupScreenRoutes(rg *gin.RouterGroup, c *container.Container) {
 			middleware.RequirePermission(enum.PermissionScreensRead),
 			c.Handlers.ScreenHandler.GetNavigation,
 		)
+		screens.GET("/bundle",
+			middleware.RequirePermission(enum.PermissionScreensRead),
+			middleware.Gzip(),
+			c.Handlers.ScreenHandler.GetBundle,
+		)
+		// POST recibe el bundle que el cliente ya tiene para responder solo el delta
+		screens.POST("/bundle",
+			middleware.RequirePermission(enum.PermissionScreensRead),
+			middleware.Gzip(),
+			c.Handlers.ScreenHandler.GetBundle,
+		)
 		screens.PUT("/:screenKey/preferences",
 			middleware.RequirePermission(enum.PermissionScreenInstancesUpdate),
 			c.Handlers.ScreenHandler.SavePreferences,
 		)
+		screens.PATCH("/:screenKey/preferences",
+			middleware.RequirePermission(enum.PermissionScreenInstancesUpdate),
+			c.Handlers.ScreenHandler.PatchPreferences,
+		)
+		screens.DELETE("/:screenKey/preferences",
+			middleware.RequirePermission(enum.PermissionScreenInstancesUpdate),
+			c.Handlers.ScreenHandler.ResetPreferences,
+		)
+		screens.GET("/:screenKey/preferences/history",
+			middleware.RequirePermission(enum.PermissionScreensRead),
+			c.Handlers.ScreenHandler.GetPreferencesHistory,
+		)
 	}
 }
 
@@ -203,5 +336,45 @@ func setupStatsRoutes(rg *gin.RouterGroup, c *container.Container) {
 			middleware.RequirePermission(enum.PermissionStatsGlobal),
 			c.Handlers.StatsHandler.GetGlobalStats,
 		)
+		// Serie diaria de actividad del sistema o de una escuela (requiere permiso stats:global)
+		stats.GET("/activity",
+			middleware.RequirePermission(enum.PermissionStatsGlobal),
+			c.Handlers.ActivityHandler.GetActivityStats,
+		)
+	}
+}
+
+// setupReportRoutes configura los reportes docentes por unidad académica.
+func setupReportRoutes(rg *gin.RouterGroup, c *container.Container) {
+	units := rg.Group("/academic-units")
+	{
+		// Libro de calificaciones (requiere permiso stats:unit; otras unidades requieren stats:school)
+		units.GET("/:id/reports/progress",
+			middleware.RequirePermission(enum.PermissionStatsUnit),
+			c.Handlers.ReportHandler.GetUnitProgressReport,
+		)
 	}
 }
+
+// setupAdminRoutes configura las rutas de administración operativa.
+func setupAdminRoutes(rg *gin.RouterGroup, c *container.Container) {
+	admin := rg.Group("/admin")
+	{
+		// Dead-letter de eventos (requiere permiso events:manage)
+		admin.GET("/events/failed",
+			middleware.RequirePermission(middleware.PermissionEventsManage),
+			c.Handlers.AdminEventHandler.ListFailedEvents,
+		)
+		admin.POST("/events/failed/:id/replay",
+			middleware.RequirePermission(middleware.PermissionEventsManage),
+			c.Handlers.AdminEventHandler.ReplayFailedEvent,
+		)
+	}
+}
+
+// setupStreamRoutes configura el stream de eventos en tiempo real (Server-Sent Events).
+// Solo requiere autenticación: los eventos propios siempre están permitidos y las
+// suscripciones a clases se validan en el handler.
+func setupStreamRoutes(rg *gin.RouterGroup, c *container.Container) {
+	rg.GET("/stream", c.Handlers.StreamHandler.Stream)
+}
@@ -0,0 +1,101 @@
+// Package deadletter persiste los eventos que no pudieron publicarse para
+// inspeccionarlos y re-publicarlos desde la API de administración.
+package deadletter
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// Publisher decora un rabbitmq.Publisher: si Publish falla, registra el evento
+// (exchange, routing key, payload, error e intentos) en el dead-letter store
+// y retorna el error original al llamador.
+type Publisher struct {
+	next   rabbitmq.Publisher
+	store  repository.FailedEventWriter
+	logger logger.Logger
+	now    func() time.Time
+}
+
+// NewPublisher crea un publisher con dead-letter
+func NewPublisher(next rabbitmq.Publisher, store repository.FailedEventWriter, log logger.Logger) *Publisher {
+	return &Publisher{
+		next:   next,
+		store:  store,
+		logger: log,
+		now:    time.Now,
+	}
+}
+
+// Publish publica el mensaje y registra la falla si el publisher subyacente retorna error
+func (p *Publisher) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	err := p.next.Publish(ctx, exchange, routingKey, body)
+	if err != nil {
+		p.RecordFailure(ctx, exchange, routingKey, body, err)
+	}
+	return err
+}
+
+// RecordFailure guarda un evento fallido con estado pending
+// Un error al persistir solo se registra en logs: el dead-letter nunca bloquea al llamador.
+// Un evento sin payload no se puede re-publicar, así que se rechaza en lugar de guardarlo
+func (p *Publisher) RecordFailure(ctx context.Context, exchange, routingKey string, payload []byte, cause error) {
+	if len(payload) == 0 {
+		p.logger.Error("dead-letter rejected event without payload, nothing to replay",
+			"exchange", exchange,
+			"routing_key", routingKey,
+			"cause", cause.Error(),
+		)
+		return
+	}
+
+	now := p.now().UTC()
+	event := &repository.FailedEvent{
+		ID:            uuid.New().String(),
+		Exchange:      exchange,
+		RoutingKey:    routingKey,
+		Payload:       payload,
+		Error:         cause.Error(),
+		Attempts:      1,
+		Status:        repository.FailedEventStatusPending,
+		FirstFailedAt: now,
+		LastFailedAt:  now,
+	}
+
+	// Contexto independiente: el del request puede estar cancelado (causa frecuente de la falla)
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err := p.store.Save(saveCtx, event); err != nil {
+		p.logger.Error("failed to store dead-letter event",
+			"exchange", exchange,
+			"routing_key", routingKey,
+			"cause", cause.Error(),
+			"error", err,
+		)
+		return
+	}
+
+	p.logger.Warn("event stored in dead-letter",
+		"failed_event_id", event.ID,
+		"exchange", exchange,
+		"routing_key", routingKey,
+		"error", cause.Error(),
+	)
+}
+
+// Close cierra el publisher subyacente
+func (p *Publisher) Close() error {
+	return p.next.Close()
+}
+
+// Verificar en compile-time que Publisher implementa las interfaces de rabbitmq
+var (
+	_ rabbitmq.Publisher       = (*Publisher)(nil)
+	_ rabbitmq.FailureRecorder = (*Publisher)(nil)
+)
@@ -0,0 +1,96 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	mockMongo "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/mongodb"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPublisher es un mock de rabbitmq.Publisher para testing
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	args := m.Called(ctx, exchange, routingKey, body)
+	return args.Error(0)
+}
+
+func (m *MockPublisher) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func TestPublisher_RecordsFailedPublish(t *testing.T) {
+	ctx := context.Background()
+	body := []byte(`{"material_id":"m-1"}`)
+	store := mockMongo.NewMockFailedEventRepository()
+
+	next := new(MockPublisher)
+	next.On("Publish", ctx, "edugo.events", "material.completed", body).Return(errors.New("broker down"))
+
+	publisher := NewPublisher(next, store, logger.NewZapLogger("error", "json"))
+	err := publisher.Publish(ctx, "edugo.events", "material.completed", body)
+
+	assert.ErrorContains(t, err, "broker down")
+
+	events, total, err := store.List(ctx, repository.FailedEventFilter{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, "edugo.events", events[0].Exchange)
+	assert.Equal(t, "material.completed", events[0].RoutingKey)
+	assert.Equal(t, body, events[0].Payload)
+	assert.Equal(t, "broker down", events[0].Error)
+	assert.Equal(t, 1, events[0].Attempts)
+	assert.Equal(t, repository.FailedEventStatusPending, events[0].Status)
+}
+
+func TestPublisher_SuccessfulPublishIsNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	store := mockMongo.NewMockFailedEventRepository()
+
+	next := new(MockPublisher)
+	next.On("Publish", ctx, "edugo.events", "material.completed", mock.Anything).Return(nil)
+
+	publisher := NewPublisher(next, store, logger.NewZapLogger("error", "json"))
+	require.NoError(t, publisher.Publish(ctx, "edugo.events", "material.completed", []byte(`{}`)))
+
+	_, total, err := store.List(ctx, repository.FailedEventFilter{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestReportFailure_RecordsOriginalBody(t *testing.T) {
+	ctx := context.Background()
+	body := []byte(`{"event_id":"evt-1"}`)
+	store := mockMongo.NewMockFailedEventRepository()
+	publisher := NewPublisher(new(MockPublisher), store, logger.NewZapLogger("error", "json"))
+
+	rabbitmq.ReportFailure(ctx, publisher, "edugo.events", "material.completed", body, errors.New("handler failed"))
+
+	events, _, err := store.List(ctx, repository.FailedEventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, body, events[0].Payload)
+	assert.Equal(t, "handler failed", events[0].Error)
+}
+
+func TestReportFailure_RejectsEventsWithoutPayload(t *testing.T) {
+	ctx := context.Background()
+	store := mockMongo.NewMockFailedEventRepository()
+	publisher := NewPublisher(new(MockPublisher), store, logger.NewZapLogger("error", "json"))
+
+	rabbitmq.ReportFailure(ctx, publisher, "edugo.materials", "material.uploaded", nil, errors.New("json: unsupported value"))
+
+	_, total, err := store.List(ctx, repository.FailedEventFilter{})
+	require.NoError(t, err)
+	assert.Zero(t, total, "a record without payload cannot be replayed")
+}
@@ -0,0 +1,62 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// DefaultDedupeCapacity es la cantidad de IDs recientes que recuerda el bus
+const DefaultDedupeCapacity = 4096
+
+// RecentIDs recuerda los últimos IDs de evento vistos para descartar duplicados
+// Es acotado: al superar la capacidad olvida los IDs más antiguos (FIFO)
+type RecentIDs struct {
+	mu    sync.Mutex
+	ids   map[string]struct{}
+	order []string
+	next  int
+}
+
+// NewRecentIDs crea un registro con la capacidad indicada (DefaultDedupeCapacity si es <= 0)
+func NewRecentIDs(capacity int) *RecentIDs {
+	if capacity <= 0 {
+		capacity = DefaultDedupeCapacity
+	}
+	return &RecentIDs{
+		ids:   make(map[string]struct{}, capacity),
+		order: make([]string, capacity),
+	}
+}
+
+// Add registra id y retorna true si no se había visto; un id vacío siempre es nuevo
+func (r *RecentIDs) Add(id string) bool {
+	if id == "" {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, seen := r.ids[id]; seen {
+		return false
+	}
+
+	if evicted := r.order[r.next]; evicted != "" {
+		delete(r.ids, evicted)
+	}
+	r.order[r.next] = id
+	r.next = (r.next + 1) % len(r.order)
+	r.ids[id] = struct{}{}
+	return true
+}
+
+// EventID extrae el event_id del envelope estándar (rabbitmq.Event); "" si el body no lo tiene
+func EventID(body []byte) string {
+	var envelope struct {
+		EventID string `json:"event_id"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return ""
+	}
+	return envelope.EventID
+}
@@ -0,0 +1,234 @@
+// Package eventbus provee un bus de eventos con transportes intercambiables.
+// El bus implementa rabbitmq.Publisher, por lo que los servicios no cambian:
+// publican igual que antes y el transporte configurado decide el destino
+// (RabbitMQ, un archivo JSON-lines o solo suscriptores en proceso).
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// Event es un evento publicado en el bus
+// ID es el event_id del envelope (vacío si el body no lo trae) y permite descartar duplicados
+type Event struct {
+	ID          string          `json:"id,omitempty"`
+	Exchange    string          `json:"exchange"`
+	RoutingKey  string          `json:"routing_key"`
+	Body        json.RawMessage `json:"body"`
+	PublishedAt time.Time       `json:"published_at"`
+}
+
+// Handler procesa un evento entregado a un suscriptor
+type Handler func(ctx context.Context, event Event) error
+
+// Transport entrega eventos fuera del proceso (broker, archivo, etc.)
+type Transport interface {
+	// Name identifica el transporte en logs
+	Name() string
+
+	// Send entrega el evento al destino del transporte
+	Send(ctx context.Context, event Event) error
+
+	// Close libera los recursos del transporte
+	Close() error
+}
+
+// EventBus publica eventos y permite suscribirse a ellos dentro del proceso
+type EventBus interface {
+	rabbitmq.Publisher
+
+	// Subscribe registra un handler para los routing keys que coinciden con el patrón.
+	// El patrón usa la semántica de un exchange topic de AMQP ("*" una palabra, "#" cero o más).
+	// Retorna una función para cancelar la suscripción.
+	Subscribe(pattern string, handler Handler) (unsubscribe func())
+
+	// Transport retorna el transporte configurado
+	Transport() Transport
+}
+
+type subscription struct {
+	id      uint64
+	pattern string
+	handler Handler
+}
+
+// Bus implementa EventBus sobre un Transport
+// Los suscriptores se invocan de forma síncrona después de enviar el evento al transporte,
+// incluso si el transporte falla, para que los consumidores locales no pierdan eventos
+// mientras el broker no está disponible. El evento fallido queda en el dead-letter store y
+// su replay solo vuelve a enviarse por el transporte (ver Transport), no a los suscriptores.
+// Si el publicador reintenta el mismo evento (mismo event_id) los suscriptores locales
+// no lo reciben de nuevo: el bus recuerda los últimos DefaultDedupeCapacity IDs.
+// Los handlers deben ser rápidos: bloquean al publicador.
+type Bus struct {
+	transport Transport
+	logger    logger.Logger
+	seen      *RecentIDs
+
+	mu            sync.RWMutex
+	subscriptions []subscription
+	nextID        uint64
+}
+
+// New crea un bus de eventos sobre el transporte indicado
+func New(transport Transport, log logger.Logger) *Bus {
+	return &Bus{
+		transport: transport,
+		logger:    log,
+		seen:      NewRecentIDs(DefaultDedupeCapacity),
+	}
+}
+
+// Publish envía el evento al transporte y lo entrega a los suscriptores locales
+// Implementa rabbitmq.Publisher
+func (b *Bus) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	event := Event{
+		ID:          EventID(body),
+		Exchange:    exchange,
+		RoutingKey:  routingKey,
+		Body:        json.RawMessage(body),
+		PublishedAt: time.Now().UTC(),
+	}
+
+	sendErr := b.transport.Send(ctx, event)
+	if sendErr != nil {
+		b.logger.Warn("event bus transport failed",
+			"transport", b.transport.Name(),
+			"exchange", exchange,
+			"routing_key", routingKey,
+			"error", sendErr,
+		)
+	}
+
+	if b.seen.Add(event.ID) {
+		b.dispatch(ctx, event)
+	} else {
+		b.logger.Debug("event already dispatched locally, skipping subscribers",
+			"event_id", event.ID,
+			"routing_key", routingKey,
+		)
+	}
+
+	if sendErr != nil {
+		return fmt.Errorf("event bus transport %s: %w", b.transport.Name(), sendErr)
+	}
+	return nil
+}
+
+// Subscribe registra un handler local para un patrón de routing key
+func (b *Bus) Subscribe(pattern string, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscriptions = append(b.subscriptions, subscription{
+		id:      id,
+		pattern: pattern,
+		handler: handler,
+	})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		for i, sub := range b.subscriptions {
+			if sub.id == id {
+				b.subscriptions = append(b.subscriptions[:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Close cierra el transporte subyacente (el archivo de FileTransport; AMQP no es dueño del publisher)
+func (b *Bus) Close() error {
+	return b.transport.Close()
+}
+
+// Transport retorna el transporte configurado
+func (b *Bus) Transport() Transport {
+	return b.transport
+}
+
+// dispatch entrega el evento a los suscriptores cuyo patrón coincide
+func (b *Bus) dispatch(ctx context.Context, event Event) {
+	b.mu.RLock()
+	matched := make([]subscription, 0, len(b.subscriptions))
+	for _, sub := range b.subscriptions {
+		if MatchTopic(sub.pattern, event.RoutingKey) {
+			matched = append(matched, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range matched {
+		b.invoke(ctx, sub, event)
+	}
+}
+
+// invoke ejecuta un handler aislando errores y panics del publicador
+func (b *Bus) invoke(ctx context.Context, sub subscription, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("event subscriber panicked",
+				"pattern", sub.pattern,
+				"routing_key", event.RoutingKey,
+				"panic", fmt.Sprint(r),
+			)
+		}
+	}()
+
+	if err := sub.handler(ctx, event); err != nil {
+		b.logger.Warn("event subscriber failed",
+			"pattern", sub.pattern,
+			"routing_key", event.RoutingKey,
+			"error", err,
+		)
+	}
+}
+
+// TransportPublisher publica directamente en un Transport sin pasar por los suscriptores del bus
+// Se usa para re-enviar eventos que los suscriptores locales ya recibieron (replay del dead-letter)
+type TransportPublisher struct {
+	transport Transport
+}
+
+// NewTransportPublisher crea un publisher sobre el transporte indicado
+func NewTransportPublisher(transport Transport) *TransportPublisher {
+	return &TransportPublisher{transport: transport}
+}
+
+// Publish envía el evento solo al transporte
+// Implementa rabbitmq.Publisher
+func (p *TransportPublisher) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	err := p.transport.Send(ctx, Event{
+		ID:          EventID(body),
+		Exchange:    exchange,
+		RoutingKey:  routingKey,
+		Body:        json.RawMessage(body),
+		PublishedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("event bus transport %s: %w", p.transport.Name(), err)
+	}
+	return nil
+}
+
+// Close no cierra el transporte: pertenece al bus
+func (p *TransportPublisher) Close() error {
+	return nil
+}
+
+// Verificar en compile-time que Bus implementa EventBus
+var (
+	_ EventBus           = (*Bus)(nil)
+	_ rabbitmq.Publisher = (*TransportPublisher)(nil)
+)
@@ -0,0 +1,264 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPublisher es un mock de rabbitmq.Publisher para testing
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	args := m.Called(ctx, exchange, routingKey, body)
+	return args.Error(0)
+}
+
+func (m *MockPublisher) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func newTestLogger() logger.Logger {
+	return logger.NewZapLogger("error", "json")
+}
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		routingKey string
+		want       bool
+	}{
+		{"material.completed", "material.completed", true},
+		{"material.completed", "material.uploaded", false},
+		{"material.*", "material.completed", true},
+		{"material.*", "material.completed.v2", false},
+		{"material.#", "material", true},
+		{"material.#", "material.completed.v2", true},
+		{"#", "assessment.attempt.completed", true},
+		{"*.completed", "material.completed", true},
+		{"*.completed", "completed", false},
+		{"#.completed", "assessment.attempt.completed", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"->"+tt.routingKey, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchTopic(tt.pattern, tt.routingKey))
+		})
+	}
+}
+
+func TestBus_InProcess_DeliversToMatchingSubscribers(t *testing.T) {
+	bus := New(NewInProcessTransport(), newTestLogger())
+	ctx := context.Background()
+
+	var materialEvents, allEvents int
+	bus.Subscribe("material.*", func(_ context.Context, event Event) error {
+		materialEvents++
+		assert.Equal(t, "edugo.events", event.Exchange)
+		return nil
+	})
+	bus.Subscribe("#", func(_ context.Context, _ Event) error {
+		allEvents++
+		return nil
+	})
+
+	require.NoError(t, bus.Publish(ctx, "edugo.events", "material.completed", []byte(`{"id":"1"}`)))
+	require.NoError(t, bus.Publish(ctx, "edugo.events", "assessment.attempt.completed", []byte(`{"id":"2"}`)))
+
+	assert.Equal(t, 1, materialEvents)
+	assert.Equal(t, 2, allEvents)
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	bus := New(NewInProcessTransport(), newTestLogger())
+	recorder := NewRecorder(bus, "#")
+
+	require.NoError(t, bus.Publish(context.Background(), "edugo.events", "material.completed", []byte(`{}`)))
+	recorder.Stop()
+	require.NoError(t, bus.Publish(context.Background(), "edugo.events", "material.completed", []byte(`{}`)))
+
+	assert.Len(t, recorder.Events(), 1)
+}
+
+func TestBus_SubscriberFailuresDoNotAffectPublisher(t *testing.T) {
+	bus := New(NewInProcessTransport(), newTestLogger())
+	recorder := NewRecorder(bus, "#")
+
+	bus.Subscribe("#", func(_ context.Context, _ Event) error {
+		return errors.New("subscriber failed")
+	})
+	bus.Subscribe("#", func(_ context.Context, _ Event) error {
+		panic("subscriber panicked")
+	})
+
+	err := bus.Publish(context.Background(), "edugo.events", "material.completed", []byte(`{}`))
+
+	assert.NoError(t, err)
+	assert.Len(t, recorder.EventsFor("material.completed"), 1)
+}
+
+func TestBus_AMQPTransport(t *testing.T) {
+	ctx := context.Background()
+	body := []byte(`{"material_id":"m-1"}`)
+
+	t.Run("delegates to publisher", func(t *testing.T) {
+		publisher := new(MockPublisher)
+		publisher.On("Publish", ctx, "edugo.events", "material.completed", body).Return(nil)
+
+		bus := New(NewAMQPTransport(publisher), newTestLogger())
+		recorder := NewRecorder(bus, "material.completed")
+
+		err := bus.Publish(ctx, "edugo.events", "material.completed", body)
+
+		assert.NoError(t, err)
+		assert.Len(t, recorder.Events(), 1)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("returns transport error but still notifies local subscribers", func(t *testing.T) {
+		publisher := new(MockPublisher)
+		publisher.On("Publish", ctx, "edugo.events", "material.completed", body).Return(errors.New("broker down"))
+
+		bus := New(NewAMQPTransport(publisher), newTestLogger())
+		recorder := NewRecorder(bus, "#")
+
+		err := bus.Publish(ctx, "edugo.events", "material.completed", body)
+
+		assert.ErrorContains(t, err, "broker down")
+		assert.Len(t, recorder.Events(), 1)
+	})
+
+	t.Run("retrying the same event resends it but does not notify subscribers twice", func(t *testing.T) {
+		envelope := []byte(`{"event_id":"evt-1","event_type":"material.completed"}`)
+		publisher := new(MockPublisher)
+		publisher.On("Publish", ctx, "edugo.events", "material.completed", envelope).Return(errors.New("broker down")).Once()
+		publisher.On("Publish", ctx, "edugo.events", "material.completed", envelope).Return(nil).Once()
+
+		bus := New(NewAMQPTransport(publisher), newTestLogger())
+		recorder := NewRecorder(bus, "#")
+
+		assert.Error(t, bus.Publish(ctx, "edugo.events", "material.completed", envelope))
+		assert.NoError(t, bus.Publish(ctx, "edugo.events", "material.completed", envelope))
+
+		require.Len(t, recorder.Events(), 1)
+		assert.Equal(t, "evt-1", recorder.Events()[0].ID)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("close does not close the bootstrap publisher", func(t *testing.T) {
+		publisher := new(MockPublisher)
+
+		bus := New(NewAMQPTransport(publisher), newTestLogger())
+
+		assert.NoError(t, bus.Close())
+		publisher.AssertNotCalled(t, "Close")
+	})
+}
+
+func TestRecentIDs(t *testing.T) {
+	ids := NewRecentIDs(2)
+
+	assert.True(t, ids.Add("a"))
+	assert.False(t, ids.Add("a"))
+	assert.True(t, ids.Add(""), "events without id are never deduplicated")
+	assert.True(t, ids.Add(""))
+	assert.True(t, ids.Add("b"))
+	assert.True(t, ids.Add("c"), "evicts the oldest id")
+	assert.True(t, ids.Add("a"))
+	assert.False(t, ids.Add("c"))
+}
+
+func TestFileTransport_WritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "events.jsonl")
+	transport, err := NewFileTransport(path)
+	require.NoError(t, err)
+
+	bus := New(transport, newTestLogger())
+	ctx := context.Background()
+
+	require.NoError(t, bus.Publish(ctx, "edugo.events", "material.completed", []byte(`{"material_id":"m-1"}`)))
+	require.NoError(t, bus.Publish(ctx, "edugo.events", "raw.text", []byte("not json")))
+	require.NoError(t, bus.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+
+	require.Len(t, records, 2)
+	assert.Equal(t, "material.completed", records[0]["routing_key"])
+	assert.Equal(t, map[string]interface{}{"material_id": "m-1"}, records[0]["body"])
+	assert.Equal(t, "not json", records[1]["body"])
+}
+
+func TestNewFromConfig(t *testing.T) {
+	log := newTestLogger()
+
+	t.Run("amqp with publisher", func(t *testing.T) {
+		bus, err := NewFromConfig(config.EventBusConfig{Transport: "amqp"}, new(MockPublisher), log)
+		require.NoError(t, err)
+		assert.Equal(t, TransportAMQP, bus.Transport().Name())
+	})
+
+	t.Run("amqp without publisher falls back to in-process", func(t *testing.T) {
+		bus, err := NewFromConfig(config.EventBusConfig{}, nil, log)
+		require.NoError(t, err)
+		assert.Equal(t, TransportInProcess, bus.Transport().Name())
+	})
+
+	t.Run("inprocess", func(t *testing.T) {
+		bus, err := NewFromConfig(config.EventBusConfig{Transport: "inprocess"}, new(MockPublisher), log)
+		require.NoError(t, err)
+		assert.Equal(t, TransportInProcess, bus.Transport().Name())
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.jsonl")
+		bus, err := NewFromConfig(config.EventBusConfig{Transport: "file", FilePath: path}, nil, log)
+		require.NoError(t, err)
+		defer func() { _ = bus.Close() }()
+		assert.Equal(t, TransportFile, bus.Transport().Name())
+	})
+
+	t.Run("unknown transport", func(t *testing.T) {
+		_, err := NewFromConfig(config.EventBusConfig{Transport: "kafka"}, nil, log)
+		assert.Error(t, err)
+	})
+}
+
+func TestTransportPublisher_SkipsLocalSubscribers(t *testing.T) {
+	ctx := context.Background()
+	body := []byte(`{"event_id":"evt-1"}`)
+
+	publisher := new(MockPublisher)
+	publisher.On("Publish", ctx, "edugo.events", "material.completed", body).Return(nil)
+
+	bus := New(NewAMQPTransport(publisher), newTestLogger())
+	recorder := NewRecorder(bus, "#")
+
+	replay := NewTransportPublisher(bus.Transport())
+	require.NoError(t, replay.Publish(ctx, "edugo.events", "material.completed", body))
+
+	assert.Empty(t, recorder.Events(), "a replay must not reach in-process subscribers again")
+	publisher.AssertExpectations(t)
+}
@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// NewFromConfig crea el bus de eventos con el transporte seleccionado en config.MessagingConfig
+// amqpPublisher se usa solo con transport=amqp; si es nil (RabbitMQ deshabilitado u opcional
+// no disponible) el bus degrada a transporte en proceso para no perder los eventos locales.
+func NewFromConfig(cfg config.EventBusConfig, amqpPublisher rabbitmq.Publisher, log logger.Logger) (*Bus, error) {
+	transportName := cfg.Transport
+	if transportName == "" {
+		transportName = TransportAMQP
+	}
+
+	var transport Transport
+	switch transportName {
+	case TransportAMQP:
+		if amqpPublisher == nil {
+			log.Warn("event bus configurado con amqp pero no hay publisher de RabbitMQ, usando transporte en proceso")
+			transport = NewInProcessTransport()
+		} else {
+			transport = NewAMQPTransport(amqpPublisher)
+		}
+	case TransportInProcess:
+		transport = NewInProcessTransport()
+	case TransportFile:
+		fileTransport, err := NewFileTransport(cfg.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		transport = fileTransport
+	default:
+		return nil, fmt.Errorf("unknown event bus transport: %s", transportName)
+	}
+
+	log.Info("event bus inicializado", "transport", transport.Name())
+
+	return New(transport, log), nil
+}
@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+)
+
+// Recorder acumula los eventos publicados en un bus para inspeccionarlos en tests
+// o en herramientas de desarrollo sin necesidad de RabbitMQ
+type Recorder struct {
+	mu          sync.Mutex
+	events      []Event
+	unsubscribe func()
+}
+
+// NewRecorder suscribe un Recorder al bus para los routing keys que coinciden con el patrón
+func NewRecorder(bus EventBus, pattern string) *Recorder {
+	r := &Recorder{}
+	r.unsubscribe = bus.Subscribe(pattern, func(_ context.Context, event Event) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.events = append(r.events, event)
+		return nil
+	})
+	return r
+}
+
+// Events retorna una copia de los eventos registrados
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := make([]Event, len(r.events))
+	copy(events, r.events)
+	return events
+}
+
+// EventsFor retorna los eventos registrados con el routing key indicado
+func (r *Recorder) EventsFor(routingKey string) []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []Event
+	for _, event := range r.events {
+		if event.RoutingKey == routingKey {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Reset descarta los eventos registrados
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// Stop cancela la suscripción del Recorder
+func (r *Recorder) Stop() {
+	if r.unsubscribe != nil {
+		r.unsubscribe()
+	}
+}
@@ -0,0 +1,41 @@
+package eventbus
+
+import "strings"
+
+// MatchTopic indica si un routing key coincide con un patrón de exchange topic de AMQP
+// "*" coincide con exactamente una palabra y "#" con cero o más palabras.
+// Ejemplos: "material.*" coincide con "material.completed"; "#" coincide con todo.
+func MatchTopic(pattern, routingKey string) bool {
+	if pattern == "#" {
+		return true
+	}
+	return matchWords(strings.Split(pattern, "."), strings.Split(routingKey, "."))
+}
+
+// matchWords compara recursivamente las palabras del patrón y del routing key
+func matchWords(pattern, key []string) bool {
+	if len(pattern) == 0 {
+		return len(key) == 0
+	}
+
+	switch pattern[0] {
+	case "#":
+		// "#" puede consumir cero o más palabras
+		for i := 0; i <= len(key); i++ {
+			if matchWords(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		if len(key) == 0 {
+			return false
+		}
+		return matchWords(pattern[1:], key[1:])
+	default:
+		if len(key) == 0 || pattern[0] != key[0] {
+			return false
+		}
+		return matchWords(pattern[1:], key[1:])
+	}
+}
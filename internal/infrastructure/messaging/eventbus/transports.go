@@ -0,0 +1,163 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+)
+
+// Nombres de transporte soportados (config: messaging.event_bus.transport)
+const (
+	TransportAMQP      = "amqp"
+	TransportInProcess = "inprocess"
+	TransportFile      = "file"
+)
+
+// ============================================
+// In-process
+// ============================================
+
+// InProcessTransport no entrega eventos fuera del proceso
+// Los eventos solo llegan a los suscriptores registrados en el bus
+type InProcessTransport struct{}
+
+// NewInProcessTransport crea un transporte en proceso
+func NewInProcessTransport() *InProcessTransport {
+	return &InProcessTransport{}
+}
+
+// Name identifica el transporte
+func (t *InProcessTransport) Name() string { return TransportInProcess }
+
+// Send no hace nada: la entrega ocurre en los suscriptores del bus
+func (t *InProcessTransport) Send(_ context.Context, _ Event) error { return nil }
+
+// Close no libera recursos
+func (t *InProcessTransport) Close() error { return nil }
+
+// ============================================
+// Archivo JSON-lines
+// ============================================
+
+// fileRecord es la línea escrita por FileTransport
+// Body se escribe como JSON embebido si es válido, o como string en caso contrario
+type fileRecord struct {
+	Exchange    string      `json:"exchange"`
+	RoutingKey  string      `json:"routing_key"`
+	PublishedAt string      `json:"published_at"`
+	Body        interface{} `json:"body"`
+}
+
+// FileTransport escribe cada evento como una línea JSON en un archivo (útil para depurar)
+type FileTransport struct {
+	mu   sync.Mutex
+	file *os.File
+	path string
+}
+
+// NewFileTransport abre (o crea) el archivo en modo append
+func NewFileTransport(path string) (*FileTransport, error) {
+	if path == "" {
+		return nil, fmt.Errorf("event bus file path is required")
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create event log directory: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log file: %w", err)
+	}
+
+	return &FileTransport{file: file, path: path}, nil
+}
+
+// Name identifica el transporte
+func (t *FileTransport) Name() string { return TransportFile }
+
+// Path retorna la ruta del archivo de eventos
+func (t *FileTransport) Path() string { return t.path }
+
+// Send agrega el evento como una línea JSON al archivo
+func (t *FileTransport) Send(_ context.Context, event Event) error {
+	record := fileRecord{
+		Exchange:    event.Exchange,
+		RoutingKey:  event.RoutingKey,
+		PublishedAt: event.PublishedAt.Format(time.RFC3339Nano),
+		Body:        event.Body,
+	}
+	if !json.Valid(event.Body) {
+		record.Body = string(event.Body)
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event: %w", err)
+	}
+	line = append(line, '\n')
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.file == nil {
+		return fmt.Errorf("event log file is closed")
+	}
+	if _, err := t.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	return nil
+}
+
+// Close cierra el archivo
+func (t *FileTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// ============================================
+// AMQP
+// ============================================
+
+// AMQPTransport entrega eventos a RabbitMQ usando un rabbitmq.Publisher existente
+type AMQPTransport struct {
+	publisher rabbitmq.Publisher
+}
+
+// NewAMQPTransport envuelve un publisher de RabbitMQ como transporte
+func NewAMQPTransport(publisher rabbitmq.Publisher) *AMQPTransport {
+	return &AMQPTransport{publisher: publisher}
+}
+
+// Name identifica el transporte
+func (t *AMQPTransport) Name() string { return TransportAMQP }
+
+// Send publica el evento en RabbitMQ
+func (t *AMQPTransport) Send(ctx context.Context, event Event) error {
+	return t.publisher.Publish(ctx, event.Exchange, event.RoutingKey, event.Body)
+}
+
+// Close no cierra el publisher: pertenece a bootstrap, que lo cierra en su cleanup junto con la conexión
+func (t *AMQPTransport) Close() error {
+	return nil
+}
+
+// Publisher retorna el publisher de RabbitMQ envuelto
+func (t *AMQPTransport) Publisher() rabbitmq.Publisher {
+	return t.publisher
+}
@@ -58,6 +58,53 @@ func NewMaterialCompletedEvent(payload MaterialCompletedPayload) Event {
 	}
 }
 
+// ProgressUpdatedPayload representa el payload del evento progress.updated
+// Se publica en cada actualización de progreso que no completa el material
+// (la que lo completa publica material.completed)
+type ProgressUpdatedPayload struct {
+	MaterialID string    `json:"material_id"`
+	SchoolID   string    `json:"school_id"`
+	UserID     string    `json:"user_id"`
+	Percentage int       `json:"percentage"`
+	LastPage   int       `json:"last_page"`
+	Status     string    `json:"status"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// NewProgressUpdatedEvent crea un nuevo evento progress.updated con envelope estándar
+func NewProgressUpdatedEvent(payload ProgressUpdatedPayload) Event {
+	return Event{
+		EventID:      uuid.New().String(),
+		EventType:    "progress.updated",
+		EventVersion: "1.0",
+		Timestamp:    time.Now().UTC(),
+		Payload:      payload,
+	}
+}
+
+// AttemptCompletedPayload representa el payload del evento assessment.attempt.completed
+// Se publica cuando un estudiante envía un intento y el servidor lo califica
+type AttemptCompletedPayload struct {
+	AttemptID    string    `json:"attempt_id"`
+	AssessmentID string    `json:"assessment_id"`
+	MaterialID   string    `json:"material_id"`
+	UserID       string    `json:"user_id"`
+	Score        float64   `json:"score"`
+	Passed       bool      `json:"passed"`
+	CompletedAt  time.Time `json:"completed_at"`
+}
+
+// NewAttemptCompletedEvent crea un nuevo evento assessment.attempt.completed con envelope estándar
+func NewAttemptCompletedEvent(payload AttemptCompletedPayload) Event {
+	return Event{
+		EventID:      uuid.New().String(),
+		EventType:    "assessment.attempt.completed",
+		EventVersion: "1.0",
+		Timestamp:    time.Now().UTC(),
+		Payload:      payload,
+	}
+}
+
 // AssessmentGeneratedPayload representa el payload del evento assessment.generated
 type AssessmentGeneratedPayload struct {
 	MaterialID       string `json:"material_id"`
@@ -0,0 +1,20 @@
+package rabbitmq
+
+import "context"
+
+// FailureRecorder registra eventos que no pudieron publicarse o procesarse (dead-letter)
+// Lo implementan los publishers que persisten las fallas para inspección y replay.
+// Los consumidores también pueden usarlo para registrar mensajes envenenados.
+type FailureRecorder interface {
+	RecordFailure(ctx context.Context, exchange, routingKey string, payload []byte, cause error)
+}
+
+// ReportFailure registra una falla si el publisher soporta dead-letter
+// Se usa para mensajes que el publisher no llega a ver (p. ej. un consumidor que descarta
+// un mensaje envenenado). payload es el body original: el dead-letter rechaza registros sin payload
+// porque no podrían re-publicarse
+func ReportFailure(ctx context.Context, publisher Publisher, exchange, routingKey string, payload []byte, cause error) {
+	if recorder, ok := publisher.(FailureRecorder); ok {
+		recorder.RecordFailure(ctx, exchange, routingKey, payload, cause)
+	}
+}
@@ -2,10 +2,14 @@ package rabbitmq
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/tracing"
 	"github.com/EduGoGroup/edugo-shared/logger"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -13,52 +17,282 @@ import (
 // HeaderRequestID es el nombre del header AMQP para propagar el request ID
 const HeaderRequestID = "X-Request-ID"
 
+// MessageHeaders construye los headers AMQP de un mensaje a partir del contexto:
+// X-Request-ID (si existe) y el contexto de traza W3C (traceparent, tracestate, baggage)
+func MessageHeaders(ctx context.Context) amqp.Table {
+	headers := amqp.Table{}
+	if requestID := middleware.GetRequestID(ctx); requestID != "" {
+		headers[HeaderRequestID] = requestID
+	}
+	tracing.InjectAMQPHeaders(ctx, headers)
+	return headers
+}
+
+var (
+	// ErrPublisherClosed se retorna cuando se publica sobre un publisher ya cerrado
+	ErrPublisherClosed = errors.New("rabbitmq publisher is closed")
+
+	// ErrNotConnected se retorna cuando no hay conexión activa con el broker
+	// y no se logró reconectar dentro del tiempo de espera configurado
+	ErrNotConnected = errors.New("rabbitmq publisher is not connected")
+)
+
 // Publisher define la interfaz para publicar mensajes
 type Publisher interface {
 	Publish(ctx context.Context, exchange, routingKey string, body []byte) error
 	Close() error
 }
 
+// ConnectionMonitor expone el estado real de la conexión AMQP de un publisher
+// Lo implementan los publishers que administran su propia conexión
+type ConnectionMonitor interface {
+	// IsConnected indica si existe una conexión y un canal utilizables
+	IsConnected() bool
+
+	// CheckHealth retorna un error si el publisher no está conectado al broker
+	CheckHealth(ctx context.Context) error
+}
+
+// ReconnectConfig configura la reconexión automática del publisher
+type ReconnectConfig struct {
+	// InitialInterval es la espera antes del primer intento de reconexión
+	InitialInterval time.Duration
+	// MaxInterval es el tope del backoff exponencial entre intentos
+	MaxInterval time.Duration
+	// Multiplier es el factor de crecimiento del backoff
+	Multiplier float64
+	// PublishTimeout es el tiempo máximo que Publish bloquea esperando la reconexión.
+	// Con valor 0 Publish falla inmediatamente con ErrNotConnected si no hay conexión
+	PublishTimeout time.Duration
+	// ConfirmTimeout es el tiempo máximo de espera del publisher confirm del broker
+	ConfirmTimeout time.Duration
+}
+
+// DefaultReconnectConfig retorna la configuración de reconexión por defecto
+func DefaultReconnectConfig() ReconnectConfig {
+	return ReconnectConfig{
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      2,
+		PublishTimeout:  10 * time.Second,
+		ConfirmTimeout:  5 * time.Second,
+	}
+}
+
+// nextInterval calcula el siguiente intervalo de backoff respetando el tope
+func (c ReconnectConfig) nextInterval(current time.Duration) time.Duration {
+	next := time.Duration(float64(current) * c.Multiplier)
+	if next <= current {
+		next = current
+	}
+	if c.MaxInterval > 0 && next > c.MaxInterval {
+		next = c.MaxInterval
+	}
+	return next
+}
+
 // RabbitMQPublisher implementa Publisher usando RabbitMQ
+// Mantiene su propia conexión y la recupera automáticamente si el broker la cierra:
+// vigila NotifyClose de la conexión y del canal, reconecta con backoff exponencial,
+// vuelve a declarar el exchange y a habilitar publisher confirms.
+// Mientras reconecta, Publish bloquea hasta ReconnectConfig.PublishTimeout.
 type RabbitMQPublisher struct {
-	conn     *amqp.Connection
-	channel  *amqp.Channel
+	url      string
 	exchange string
+	config   ReconnectConfig
 	logger   logger.Logger
+
+	// mu protege conn, channel, las notificaciones de cierre, ready y lastError
+	mu         sync.RWMutex
+	conn       *amqp.Connection
+	channel    *amqp.Channel
+	connClosed chan *amqp.Error
+	chanClosed chan *amqp.Error
+	ready      chan struct{} // cerrado mientras hay un canal utilizable
+	lastError  error
+
+	// borrowed es la conexión inicial prestada por quien creó el publisher; Close no la cierra
+	borrowed *amqp.Connection
+
+	connected  atomic.Bool
+	closed     atomic.Bool
+	reconnects atomic.Int64
+
+	done      chan struct{}
+	watchOnce sync.Once
+	closeOnce sync.Once
 }
 
 // NewRabbitMQPublisher crea una nueva instancia de RabbitMQPublisher
 func NewRabbitMQPublisher(url, exchange string, log logger.Logger) (*RabbitMQPublisher, error) {
-	publisher := &RabbitMQPublisher{
+	return NewRabbitMQPublisherWithConfig(url, exchange, DefaultReconnectConfig(), log)
+}
+
+// NewRabbitMQPublisherWithConfig crea un RabbitMQPublisher con configuración de reconexión explícita
+func NewRabbitMQPublisherWithConfig(url, exchange string, config ReconnectConfig, log logger.Logger) (*RabbitMQPublisher, error) {
+	publisher, err := newRabbitMQPublisher(exchange, config, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := publisher.Connect(url); err != nil {
+		_ = publisher.Close() // Liberar una conexión abierta a medias
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	return publisher, nil
+}
+
+// newRabbitMQPublisher aplica los valores por defecto de la configuración y crea el publisher sin conectar
+func newRabbitMQPublisher(exchange string, config ReconnectConfig, log logger.Logger) (*RabbitMQPublisher, error) {
+	defaults := DefaultReconnectConfig()
+	if config.InitialInterval <= 0 {
+		config.InitialInterval = defaults.InitialInterval
+	}
+	if config.MaxInterval <= 0 {
+		config.MaxInterval = defaults.MaxInterval
+	}
+	if config.Multiplier < 1 {
+		config.Multiplier = defaults.Multiplier
+	}
+	if config.ConfirmTimeout <= 0 {
+		config.ConfirmTimeout = defaults.ConfirmTimeout
+	}
+
+	return &RabbitMQPublisher{
 		exchange: exchange,
+		config:   config,
 		logger:   log,
+		ready:    make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// NewRabbitMQPublisherOnConnection crea un RabbitMQPublisher que publica sobre una conexión existente
+// en lugar de abrir una propia. La conexión sigue perteneciendo a quien la creó: Close no la cierra.
+// Si el broker la cierra, el publisher reconecta con url y desde entonces administra su propia conexión
+func NewRabbitMQPublisherOnConnection(conn *amqp.Connection, url, exchange string, config ReconnectConfig, log logger.Logger) (*RabbitMQPublisher, error) {
+	if conn == nil || conn.IsClosed() {
+		return NewRabbitMQPublisherWithConfig(url, exchange, config, log)
+	}
+
+	publisher, err := newRabbitMQPublisher(exchange, config, log)
+	if err != nil {
+		return nil, err
 	}
+	publisher.borrowed = conn
+	publisher.conn = conn
+	publisher.connClosed = conn.NotifyClose(make(chan *amqp.Error, 1))
 
 	if err := publisher.Connect(url); err != nil {
+		_ = publisher.Close()
 		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
 	return publisher, nil
 }
 
-// Connect establece la conexión con RabbitMQ y declara el exchange
+// Connect establece la conexión con RabbitMQ, declara el exchange
+// e inicia la vigilancia de la conexión para reconectar automáticamente
 func (p *RabbitMQPublisher) Connect(url string) error {
-	// Conectar a RabbitMQ
-	conn, err := amqp.Dial(url)
+	if p.closed.Load() {
+		return ErrPublisherClosed
+	}
+
+	p.mu.Lock()
+	p.url = url
+	if p.ready == nil {
+		p.ready = make(chan struct{})
+	}
+	if p.done == nil {
+		p.done = make(chan struct{})
+	}
+	p.mu.Unlock()
+
+	if err := p.connect(); err != nil {
+		return err
+	}
+
+	p.watchOnce.Do(func() {
+		go p.watch()
+	})
+
+	return nil
+}
+
+// connect abre (o reutiliza) la conexión, crea el canal, declara el exchange
+// y habilita publisher confirms. Deja el publisher listo para publicar.
+// El dial y la preparación del canal ocurren fuera de mu: mientras tanto Publish espera
+// en ready respetando PublishTimeout y el contexto del llamador
+func (p *RabbitMQPublisher) connect() error {
+	p.mu.RLock()
+	url, conn := p.url, p.conn
+	p.mu.RUnlock()
+
+	// Reutilizar la conexión si solo se cerró el canal
+	var connClosed chan *amqp.Error
+	dialed := conn == nil || conn.IsClosed()
+	if dialed {
+		var err error
+		conn, err = amqp.Dial(url)
+		if err != nil {
+			p.setLastError(err)
+			return fmt.Errorf("failed to dial RabbitMQ: %w", err)
+		}
+		connClosed = conn.NotifyClose(make(chan *amqp.Error, 1))
+	}
+
+	channel, err := p.openChannel(conn)
 	if err != nil {
-		return fmt.Errorf("failed to dial RabbitMQ: %w", err)
+		if dialed {
+			_ = conn.Close() // Ignorar error en cleanup
+		}
+		p.setLastError(err)
+		return err
 	}
-	p.conn = conn
 
-	// Crear canal
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Close pudo ejecutarse durante el dial: no publicar una conexión que nadie cerraría
+	if p.closed.Load() {
+		_ = channel.Close()
+		if dialed {
+			_ = conn.Close()
+		}
+		return ErrPublisherClosed
+	}
+
+	if dialed {
+		p.conn = conn
+		p.connClosed = connClosed
+	}
+	p.channel = channel
+	p.chanClosed = channel.NotifyClose(make(chan *amqp.Error, 1))
+	p.lastError = nil
+	p.connected.Store(true)
+
+	// Despertar a los Publish que esperan la reconexión
+	select {
+	case <-p.ready:
+	default:
+		close(p.ready)
+	}
+
+	p.logger.Info("Connected to RabbitMQ successfully",
+		"exchange", p.exchange,
+	)
+
+	return nil
+}
+
+// openChannel crea el canal, declara el exchange de tipo topic y habilita publisher confirms
+func (p *RabbitMQPublisher) openChannel(conn *amqp.Connection) (*amqp.Channel, error) {
 	channel, err := conn.Channel()
 	if err != nil {
-		_ = conn.Close() // Ignorar error en cleanup
-		return fmt.Errorf("failed to open channel: %w", err)
+		return nil, fmt.Errorf("failed to open channel: %w", err)
 	}
-	p.channel = channel
 
-	// Declarar exchange de tipo topic
 	err = channel.ExchangeDeclare(
 		p.exchange, // nombre
 		"topic",    // tipo
@@ -70,46 +304,224 @@ func (p *RabbitMQPublisher) Connect(url string) error {
 	)
 	if err != nil {
 		_ = channel.Close() // Ignorar error en cleanup
-		_ = conn.Close()    // Ignorar error en cleanup
-		return fmt.Errorf("failed to declare exchange: %w", err)
+		return nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	// Habilitar publisher confirms
-	err = channel.Confirm(false)
-	if err != nil {
+	if err := channel.Confirm(false); err != nil {
 		_ = channel.Close() // Ignorar error en cleanup
-		_ = conn.Close()    // Ignorar error en cleanup
-		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
 	}
 
-	p.logger.Info("Connected to RabbitMQ successfully",
+	return channel, nil
+}
+
+// setLastError registra el motivo del último intento de conexión fallido (lo reporta CheckHealth)
+func (p *RabbitMQPublisher) setLastError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastError = err
+}
+
+// watch espera el cierre de la conexión o del canal y dispara la reconexión
+func (p *RabbitMQPublisher) watch() {
+	for {
+		p.mu.RLock()
+		connClosed, chanClosed := p.connClosed, p.chanClosed
+		p.mu.RUnlock()
+
+		var reason *amqp.Error
+		select {
+		case <-p.done:
+			return
+		case reason = <-connClosed:
+		case reason = <-chanClosed:
+		}
+
+		if p.closed.Load() {
+			return
+		}
+
+		p.markDisconnected(reason)
+
+		if !p.reconnect() {
+			return
+		}
+	}
+}
+
+// markDisconnected marca el publisher como desconectado para que Publish espere
+func (p *RabbitMQPublisher) markDisconnected(reason *amqp.Error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.connected.Store(false)
+	p.resetReadyLocked()
+	p.channel = nil
+	if reason != nil {
+		p.lastError = reason
+	} else {
+		p.lastError = errors.New("connection closed")
+	}
+
+	p.logger.Warn("RabbitMQ connection lost, reconnecting",
 		"exchange", p.exchange,
+		"reason", p.lastError.Error(),
 	)
+}
 
-	return nil
+// resetReadyLocked crea un nuevo ready solo si el actual ya fue cerrado, para no dejar
+// esperando sobre un canal huérfano a los Publish que ya lo tomaron; requiere mu
+func (p *RabbitMQPublisher) resetReadyLocked() {
+	select {
+	case <-p.ready:
+		p.ready = make(chan struct{})
+	default:
+	}
 }
 
-// Publish publica un mensaje en el exchange especificado
-// Propaga automáticamente el request_id del contexto en los headers AMQP
-func (p *RabbitMQPublisher) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
-	if p.channel == nil {
-		return fmt.Errorf("channel is not initialized")
+// dropChannel descarta un canal que Publish encontró cerrado antes de que watch procese el cierre,
+// así el reintento espera al canal nuevo en lugar de volver a usar el cerrado
+func (p *RabbitMQPublisher) dropChannel(channel *amqp.Channel) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.channel != channel {
+		return // watch ya lo reemplazó
 	}
+	p.connected.Store(false)
+	p.resetReadyLocked()
+	p.channel = nil
+}
 
-	// Crear confirmaciones
-	confirms := p.channel.NotifyPublish(make(chan amqp.Confirmation, 1))
+// reconnect reintenta la conexión con backoff exponencial hasta lograrlo.
+// Retorna false si el publisher fue cerrado mientras reintentaba.
+func (p *RabbitMQPublisher) reconnect() bool {
+	interval := p.config.InitialInterval
 
-	// Extraer request_id del contexto para propagarlo
-	requestID := middleware.GetRequestID(ctx)
+	for attempt := 1; ; attempt++ {
+		timer := time.NewTimer(interval)
+		select {
+		case <-p.done:
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
 
-	// Construir headers AMQP con request_id para tracing distribuido
-	headers := amqp.Table{}
-	if requestID != "" {
-		headers[HeaderRequestID] = requestID
+		if err := p.connect(); err != nil {
+			interval = p.config.nextInterval(interval)
+			p.logger.Warn("RabbitMQ reconnection attempt failed",
+				"attempt", attempt,
+				"next_retry_in", interval.String(),
+				"error", err,
+			)
+			continue
+		}
+
+		p.reconnects.Add(1)
+		p.logger.Info("RabbitMQ connection recovered",
+			"attempt", attempt,
+			"exchange", p.exchange,
+		)
+		return true
+	}
+}
+
+// waitForChannel retorna el canal activo, bloqueando hasta PublishTimeout si hay una reconexión en curso
+func (p *RabbitMQPublisher) waitForChannel(ctx context.Context) (*amqp.Channel, error) {
+	var timeout <-chan time.Time
+	if p.config.PublishTimeout > 0 {
+		timer := time.NewTimer(p.config.PublishTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		if p.closed.Load() {
+			return nil, ErrPublisherClosed
+		}
+
+		p.mu.RLock()
+		channel, ready := p.channel, p.ready
+		p.mu.RUnlock()
+
+		if channel != nil && p.connected.Load() {
+			if !channel.IsClosed() {
+				return channel, nil
+			}
+			// El canal se cerró y watch aún no lo procesó
+			p.dropChannel(channel)
+			continue
+		}
+
+		if timeout == nil {
+			return nil, ErrNotConnected
+		}
+
+		select {
+		case <-ready:
+		case <-p.done:
+			return nil, ErrPublisherClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			return nil, ErrNotConnected
+		}
+	}
+}
+
+// Publish publica un mensaje en el exchange especificado
+// Propaga automáticamente el request_id y el contexto de traza W3C en los headers AMQP.
+// Si la conexión se está recuperando, espera hasta ReconnectConfig.PublishTimeout.
+// Si el canal se cierra durante la publicación reintenta una vez sobre el canal recuperado;
+// el mensaje puede llegar dos veces si el broker lo recibió antes del cierre (at-least-once)
+func (p *RabbitMQPublisher) Publish(ctx context.Context, exchange, routingKey string, body []byte) (err error) {
+	ctx, span := tracing.StartPublishSpan(ctx, exchange, routingKey, len(body))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var channel *amqp.Channel
+	for attempt := 1; ; attempt++ {
+		channel, err = p.waitForChannel(ctx)
+		if err != nil {
+			p.logger.Error("RabbitMQ channel not available",
+				"exchange", exchange,
+				"routing_key", routingKey,
+				"error", err,
+			)
+			return fmt.Errorf("failed to publish message: %w", err)
+		}
+
+		err = p.publishOnce(ctx, channel, exchange, routingKey, body)
+		if err == nil {
+			p.logger.Debug("Message published successfully",
+				"exchange", exchange,
+				"routing_key", routingKey,
+				"body_size", len(body),
+				"request_id", middleware.GetRequestID(ctx),
+				"trace_id", tracing.TraceID(ctx),
+			)
+			return nil
+		}
+
+		if attempt > 1 || !channel.IsClosed() || ctx.Err() != nil {
+			return err
+		}
+
+		p.logger.Warn("RabbitMQ channel closed while publishing, retrying on recovered channel",
+			"exchange", exchange,
+			"routing_key", routingKey,
+			"error", err,
+		)
+		p.dropChannel(channel)
 	}
+}
+
+// publishOnce publica sobre channel y espera el publisher confirm
+func (p *RabbitMQPublisher) publishOnce(ctx context.Context, channel *amqp.Channel, exchange, routingKey string, body []byte) error {
+	// Headers AMQP con request_id y traceparent para tracing distribuido
+	headers := MessageHeaders(ctx)
 
-	// Publicar mensaje
-	err := p.channel.PublishWithContext(
+	// Publicar mensaje con confirmación diferida (segura entre goroutines)
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(
 		ctx,
 		exchange,   // exchange
 		routingKey, // routing key
@@ -120,7 +532,7 @@ func (p *RabbitMQPublisher) Publish(ctx context.Context, exchange, routingKey st
 			ContentType:  "application/json",
 			Body:         body,
 			Timestamp:    time.Now(),
-			Headers:      headers, // Propagar request_id para tracing
+			Headers:      headers, // Propagar request_id y traceparent
 		},
 	)
 	if err != nil {
@@ -133,48 +545,86 @@ func (p *RabbitMQPublisher) Publish(ctx context.Context, exchange, routingKey st
 	}
 
 	// Esperar confirmación con timeout
-	select {
-	case confirm := <-confirms:
-		if !confirm.Ack {
-			p.logger.Warn("Message not acknowledged by broker",
-				"exchange", exchange,
-				"routing_key", routingKey,
-			)
-			return fmt.Errorf("message not acknowledged by broker")
-		}
-	case <-time.After(5 * time.Second):
+	confirmCtx, cancel := context.WithTimeout(ctx, p.config.ConfirmTimeout)
+	defer cancel()
+
+	acked, err := confirmation.WaitContext(confirmCtx)
+	if err != nil {
 		p.logger.Warn("Timeout waiting for publisher confirmation",
 			"exchange", exchange,
 			"routing_key", routingKey,
 		)
 		return fmt.Errorf("timeout waiting for publisher confirmation")
 	}
-
-	p.logger.Debug("Message published successfully",
-		"exchange", exchange,
-		"routing_key", routingKey,
-		"body_size", len(body),
-		"request_id", requestID,
-	)
+	if !acked {
+		// Un canal cerrado resuelve los confirms pendientes como nack
+		p.logger.Warn("Message not acknowledged by broker",
+			"exchange", exchange,
+			"routing_key", routingKey,
+		)
+		return fmt.Errorf("message not acknowledged by broker")
+	}
 
 	return nil
 }
 
-// Close cierra la conexión y el canal
+// IsConnected indica si el publisher tiene una conexión y un canal utilizables
+func (p *RabbitMQPublisher) IsConnected() bool {
+	return !p.closed.Load() && p.connected.Load()
+}
+
+// Reconnects retorna la cantidad de reconexiones exitosas desde el arranque
+func (p *RabbitMQPublisher) Reconnects() int64 {
+	return p.reconnects.Load()
+}
+
+// CheckHealth reporta el estado real de la conexión con el broker
+// Implementa handler.HealthChecker
+func (p *RabbitMQPublisher) CheckHealth(_ context.Context) error {
+	if p.closed.Load() {
+		return ErrPublisherClosed
+	}
+	if p.connected.Load() {
+		return nil
+	}
+
+	p.mu.RLock()
+	lastErr := p.lastError
+	p.mu.RUnlock()
+
+	if lastErr != nil {
+		return fmt.Errorf("%w: reconnecting (%v)", ErrNotConnected, lastErr)
+	}
+	return ErrNotConnected
+}
+
+// Close detiene la reconexión y cierra la conexión y el canal
 func (p *RabbitMQPublisher) Close() error {
 	var errs []error
 
-	if p.channel != nil {
-		if err := p.channel.Close(); err != nil {
-			errs = append(errs, fmt.Errorf("failed to close channel: %w", err))
+	p.closeOnce.Do(func() {
+		p.closed.Store(true)
+		p.connected.Store(false)
+		if p.done != nil {
+			close(p.done)
 		}
-	}
 
-	if p.conn != nil {
-		if err := p.conn.Close(); err != nil {
-			errs = append(errs, fmt.Errorf("failed to close connection: %w", err))
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if p.channel != nil && !p.channel.IsClosed() {
+			if err := p.channel.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close channel: %w", err))
+			}
 		}
-	}
+
+		// La conexión prestada la cierra su dueño
+		if p.conn != nil && p.conn != p.borrowed && !p.conn.IsClosed() {
+			if err := p.conn.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close connection: %w", err))
+			}
+		}
+	})
 
 	if len(errs) > 0 {
 		return fmt.Errorf("errors closing RabbitMQ: %v", errs)
@@ -183,3 +633,9 @@ func (p *RabbitMQPublisher) Close() error {
 	p.logger.Info("RabbitMQ connection closed successfully")
 	return nil
 }
+
+// Verificar en compile-time que RabbitMQPublisher implementa las interfaces
+var (
+	_ Publisher         = (*RabbitMQPublisher)(nil)
+	_ ConnectionMonitor = (*RabbitMQPublisher)(nil)
+)
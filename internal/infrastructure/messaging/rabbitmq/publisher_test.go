@@ -0,0 +1,190 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newDisconnectedPublisher crea un publisher sin conexión, como si estuviera reconectando
+func newDisconnectedPublisher(config ReconnectConfig) *RabbitMQPublisher {
+	return &RabbitMQPublisher{
+		exchange: "edugo.test",
+		config:   config,
+		logger:   logger.NewZapLogger("error", "json"),
+		ready:    make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func TestReconnectConfig_NextInterval(t *testing.T) {
+	config := ReconnectConfig{Multiplier: 2, MaxInterval: 3 * time.Second}
+
+	assert.Equal(t, 2*time.Second, config.nextInterval(time.Second))
+	assert.Equal(t, 3*time.Second, config.nextInterval(2*time.Second))
+	assert.Equal(t, 3*time.Second, config.nextInterval(3*time.Second))
+}
+
+func TestRabbitMQPublisher_Publish_FailsFastWithoutPublishTimeout(t *testing.T) {
+	p := newDisconnectedPublisher(ReconnectConfig{})
+
+	err := p.Publish(context.Background(), "edugo.test", "test.key", []byte(`{}`))
+
+	assert.ErrorIs(t, err, ErrNotConnected)
+}
+
+func TestRabbitMQPublisher_Publish_BlocksUntilPublishTimeout(t *testing.T) {
+	p := newDisconnectedPublisher(ReconnectConfig{PublishTimeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	err := p.Publish(context.Background(), "edugo.test", "test.key", []byte(`{}`))
+
+	assert.ErrorIs(t, err, ErrNotConnected)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestRabbitMQPublisher_Publish_RespectsContextWhileReconnecting(t *testing.T) {
+	p := newDisconnectedPublisher(ReconnectConfig{PublishTimeout: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := p.Publish(ctx, "edugo.test", "test.key", []byte(`{}`))
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRabbitMQPublisher_Publish_UnblocksWhenClosed(t *testing.T) {
+	p := newDisconnectedPublisher(ReconnectConfig{PublishTimeout: time.Minute})
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = p.Close()
+	}()
+
+	err := p.Publish(context.Background(), "edugo.test", "test.key", []byte(`{}`))
+
+	assert.ErrorIs(t, err, ErrPublisherClosed)
+}
+
+func TestRabbitMQPublisher_Publish_DoesNotBlockOnDial(t *testing.T) {
+	// Broker que acepta la conexión TCP y nunca responde el handshake AMQP
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	var (
+		mu       sync.Mutex
+		accepted []net.Conn
+	)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			accepted = append(accepted, conn)
+			mu.Unlock()
+		}
+	}()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range accepted {
+			_ = conn.Close()
+		}
+	})
+
+	p := newDisconnectedPublisher(ReconnectConfig{PublishTimeout: 50 * time.Millisecond})
+	p.url = "amqp://guest:guest@" + listener.Addr().String() + "/"
+	dialDone := make(chan error, 1)
+	go func() { dialDone <- p.connect() }()
+	time.Sleep(20 * time.Millisecond) // connect queda bloqueado en el handshake
+
+	start := time.Now()
+	err = p.Publish(context.Background(), "edugo.test", "test.key", []byte(`{}`))
+
+	assert.ErrorIs(t, err, ErrNotConnected)
+	assert.Less(t, time.Since(start), time.Second, "Publish no debe esperar al dial")
+	assert.Error(t, p.CheckHealth(context.Background()))
+
+	// Cerrar durante el dial descarta la conexión en lugar de publicarla
+	_ = p.Close()
+	mu.Lock()
+	for _, conn := range accepted {
+		_ = conn.Close()
+	}
+	mu.Unlock()
+	assert.Error(t, <-dialDone)
+	assert.False(t, p.IsConnected())
+}
+
+func TestRabbitMQPublisher_CheckHealth(t *testing.T) {
+	t.Run("reports reconnection reason while disconnected", func(t *testing.T) {
+		p := newDisconnectedPublisher(ReconnectConfig{})
+		p.lastError = errors.New("connection reset by peer")
+
+		err := p.CheckHealth(context.Background())
+
+		assert.ErrorIs(t, err, ErrNotConnected)
+		assert.Contains(t, err.Error(), "connection reset by peer")
+		assert.False(t, p.IsConnected())
+	})
+
+	t.Run("reports healthy when connected", func(t *testing.T) {
+		p := newDisconnectedPublisher(ReconnectConfig{})
+		p.connected.Store(true)
+
+		assert.NoError(t, p.CheckHealth(context.Background()))
+		assert.True(t, p.IsConnected())
+	})
+
+	t.Run("reports closed publisher", func(t *testing.T) {
+		p := newDisconnectedPublisher(ReconnectConfig{})
+		_ = p.Close()
+
+		assert.ErrorIs(t, p.CheckHealth(context.Background()), ErrPublisherClosed)
+		assert.False(t, p.IsConnected())
+	})
+}
+
+func TestMessageHeaders_PropagatesRequestIDAndTraceContext(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-123")
+	ctx = trace.ContextWithSpanContext(ctx, spanContext)
+
+	headers := MessageHeaders(ctx)
+
+	assert.Equal(t, "req-123", headers[HeaderRequestID])
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", headers["traceparent"])
+}
+
+func TestMessageHeaders_EmptyContext(t *testing.T) {
+	headers := MessageHeaders(context.Background())
+
+	assert.NotContains(t, headers, HeaderRequestID)
+	assert.NotContains(t, headers, "traceparent")
+}
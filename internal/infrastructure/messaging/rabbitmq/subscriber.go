@@ -0,0 +1,254 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-shared/logger"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DeliveryHandler procesa un mensaje recibido por un BroadcastSubscriber
+type DeliveryHandler func(ctx context.Context, routingKey string, body []byte) error
+
+// SubscriberConfig configura un BroadcastSubscriber
+type SubscriberConfig struct {
+	// Exchange es el exchange topic a escuchar (se declara si no existe)
+	Exchange string
+	// BindingKeys son los patrones de routing key a recibir ("screen.updated", "screen.*")
+	BindingKeys []string
+	// Reconnect configura el backoff entre intentos de conexión
+	Reconnect ReconnectConfig
+	// OnReconnect se invoca tras recuperar la conexión: los mensajes publicados mientras
+	// no existía la cola se perdieron, por lo que el consumidor debe resincronizarse
+	OnReconnect func()
+}
+
+// BroadcastSubscriber recibe en cada instancia todos los mensajes de un exchange topic
+// Declara una cola exclusiva, con nombre del servidor y auto-delete, por lo que cada pod
+// tiene su propia copia de los mensajes (útil para invalidar caches locales).
+// Si el broker no está disponible o la conexión se pierde, reintenta con backoff exponencial
+type BroadcastSubscriber struct {
+	url     string
+	config  SubscriberConfig
+	handler DeliveryHandler
+	logger  logger.Logger
+
+	mu   sync.Mutex
+	conn *amqp.Connection
+
+	done      chan struct{}
+	stopped   chan struct{}
+	startOnce sync.Once
+	closeOnce sync.Once
+}
+
+// NewBroadcastSubscriber crea el suscriptor sin conectarlo; Start inicia el consumo
+func NewBroadcastSubscriber(url string, config SubscriberConfig, handler DeliveryHandler, log logger.Logger) *BroadcastSubscriber {
+	defaults := DefaultReconnectConfig()
+	if config.Reconnect.InitialInterval <= 0 {
+		config.Reconnect.InitialInterval = defaults.InitialInterval
+	}
+	if config.Reconnect.MaxInterval <= 0 {
+		config.Reconnect.MaxInterval = defaults.MaxInterval
+	}
+	if config.Reconnect.Multiplier < 1 {
+		config.Reconnect.Multiplier = defaults.Multiplier
+	}
+
+	return &BroadcastSubscriber{
+		url:     url,
+		config:  config,
+		handler: handler,
+		logger:  log,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start inicia el consumo en segundo plano; es seguro llamarlo más de una vez
+func (s *BroadcastSubscriber) Start() {
+	s.startOnce.Do(func() {
+		go s.run()
+	})
+}
+
+// Close detiene el consumo y cierra la conexión
+func (s *BroadcastSubscriber) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		if s.conn != nil && !s.conn.IsClosed() {
+			err = s.conn.Close()
+		}
+		s.mu.Unlock()
+
+		// Esperar a que termine el loop solo si llegó a iniciarse
+		started := true
+		s.startOnce.Do(func() { started = false })
+		if started {
+			<-s.stopped
+		}
+	})
+	return err
+}
+
+// run conecta y consume hasta Close, reconectando con backoff cuando se pierde la conexión
+func (s *BroadcastSubscriber) run() {
+	defer close(s.stopped)
+
+	interval := s.config.Reconnect.InitialInterval
+	connectedBefore := false
+	for attempt := 1; ; attempt++ {
+		deliveries, closed, err := s.subscribe()
+		if err != nil {
+			s.logger.Warn("RabbitMQ subscriber connection failed",
+				"exchange", s.config.Exchange,
+				"attempt", attempt,
+				"next_retry_in", interval.String(),
+				"error", err,
+			)
+			if !s.wait(interval) {
+				return
+			}
+			interval = s.config.Reconnect.nextInterval(interval)
+			continue
+		}
+
+		s.logger.Info("RabbitMQ subscriber connected", "exchange", s.config.Exchange, "attempt", attempt)
+		if connectedBefore && s.config.OnReconnect != nil {
+			s.config.OnReconnect()
+		}
+		connectedBefore = true
+		attempt = 0
+		interval = s.config.Reconnect.InitialInterval
+
+		if !s.consume(deliveries, closed) {
+			return
+		}
+		s.disconnect()
+	}
+}
+
+// disconnect libera la conexión actual (p. ej. si solo se cerró el canal)
+func (s *BroadcastSubscriber) disconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil && !s.conn.IsClosed() {
+		_ = s.conn.Close() // Ignorar error: se reconecta a continuación
+	}
+	s.conn = nil
+}
+
+// subscribe abre conexión y canal, declara exchange y cola exclusiva y comienza a consumir
+func (s *BroadcastSubscriber) subscribe() (<-chan amqp.Delivery, <-chan *amqp.Error, error) {
+	conn, err := amqp.Dial(s.url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial RabbitMQ: %w", err)
+	}
+
+	fail := func(step string, err error) (<-chan amqp.Delivery, <-chan *amqp.Error, error) {
+		_ = conn.Close() // Ignorar error en cleanup
+		return nil, nil, fmt.Errorf("failed to %s: %w", step, err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return fail("open channel", err)
+	}
+	if err := channel.ExchangeDeclare(s.config.Exchange, "topic", true, false, false, false, nil); err != nil {
+		return fail("declare exchange", err)
+	}
+
+	// Cola sin nombre: el broker asigna uno único y la elimina al cerrar la conexión
+	queue, err := channel.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return fail("declare queue", err)
+	}
+	for _, key := range s.config.BindingKeys {
+		if err := channel.QueueBind(queue.Name, key, s.config.Exchange, false, nil); err != nil {
+			return fail("bind queue", err)
+		}
+	}
+
+	deliveries, err := channel.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return fail("start consuming", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.done:
+		_ = conn.Close() // Close se llamó mientras conectaba
+		return nil, nil, errors.New("subscriber closed")
+	default:
+	}
+	s.conn = conn
+
+	return deliveries, conn.NotifyClose(make(chan *amqp.Error, 1)), nil
+}
+
+// consume entrega los mensajes al handler; retorna false si el suscriptor fue cerrado
+func (s *BroadcastSubscriber) consume(deliveries <-chan amqp.Delivery, closed <-chan *amqp.Error) bool {
+	for {
+		select {
+		case <-s.done:
+			return false
+		case reason := <-closed:
+			s.logger.Warn("RabbitMQ subscriber connection lost, reconnecting",
+				"exchange", s.config.Exchange,
+				"reason", fmt.Sprint(reason),
+			)
+			return true
+		case delivery, ok := <-deliveries:
+			if !ok {
+				select {
+				case <-s.done:
+					return false
+				default:
+					return true
+				}
+			}
+			s.deliver(delivery)
+		}
+	}
+}
+
+// deliver invoca el handler aislando errores y panics del loop de consumo
+func (s *BroadcastSubscriber) deliver(delivery amqp.Delivery) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("RabbitMQ subscriber handler panicked",
+				"routing_key", delivery.RoutingKey,
+				"panic", fmt.Sprint(r),
+			)
+		}
+	}()
+
+	if err := s.handler(context.Background(), delivery.RoutingKey, delivery.Body); err != nil {
+		s.logger.Warn("RabbitMQ subscriber handler failed",
+			"routing_key", delivery.RoutingKey,
+			"error", err,
+		)
+	}
+}
+
+// wait espera el intervalo indicado; retorna false si el suscriptor fue cerrado
+func (s *BroadcastSubscriber) wait(interval time.Duration) bool {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-s.done:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
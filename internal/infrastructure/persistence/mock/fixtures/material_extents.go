@@ -0,0 +1,18 @@
+package fixtures
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// GetDefaultMaterialExtents retorna el tamaño del contenido de los materiales de prueba
+// tal como lo calcula el procesamiento (material_content_extent)
+func GetDefaultMaterialExtents() map[uuid.UUID]repository.ReadingExtent {
+	return map[uuid.UUID]repository.ReadingExtent{
+		MaterialGuidaSumasID: {TotalPages: 12},
+		MaterialGuiaRestasID: {TotalPages: 10},
+		MaterialLasPlantasID: {DurationSeconds: 600},
+		MaterialCicloAguaID:  {TotalPages: 15},
+	}
+}
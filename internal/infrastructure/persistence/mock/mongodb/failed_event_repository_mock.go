@@ -0,0 +1,76 @@
+package mongodb
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type failedEventRepositoryMock struct {
+	events map[string]*repository.FailedEvent
+	mu     sync.RWMutex
+}
+
+// NewMockFailedEventRepository crea un dead-letter store en memoria
+// Se usa en modo mock y como fallback cuando MongoDB no está disponible
+func NewMockFailedEventRepository() repository.FailedEventRepository {
+	return &failedEventRepositoryMock{events: make(map[string]*repository.FailedEvent)}
+}
+
+func (r *failedEventRepositoryMock) Save(ctx context.Context, event *repository.FailedEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copy := *event
+	r.events[event.ID] = &copy
+	return nil
+}
+
+func (r *failedEventRepositoryMock) Update(ctx context.Context, event *repository.FailedEvent) error {
+	return r.Save(ctx, event)
+}
+
+func (r *failedEventRepositoryMock) FindByID(ctx context.Context, id string) (*repository.FailedEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if event, ok := r.events[id]; ok {
+		copy := *event
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (r *failedEventRepositoryMock) List(ctx context.Context, filter repository.FailedEventFilter) ([]*repository.FailedEvent, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := make([]*repository.FailedEvent, 0, len(r.events))
+	for _, event := range r.events {
+		if filter.Status != "" && event.Status != filter.Status {
+			continue
+		}
+		if filter.RoutingKey != "" && event.RoutingKey != filter.RoutingKey {
+			continue
+		}
+		copy := *event
+		matches = append(matches, &copy)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].LastFailedAt.After(matches[j].LastFailedAt)
+	})
+
+	total := int64(len(matches))
+	if filter.Offset >= len(matches) {
+		return []*repository.FailedEvent{}, total, nil
+	}
+	matches = matches[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(matches) {
+		matches = matches[:filter.Limit]
+	}
+
+	return matches, total, nil
+}
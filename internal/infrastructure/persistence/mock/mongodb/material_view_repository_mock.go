@@ -0,0 +1,45 @@
+package mongodb
+
+import (
+	"context"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type materialViewRepositoryMock struct {
+	views []repository.MaterialView
+	mu    sync.RWMutex
+}
+
+// NewMockMaterialViewRepository crea un tracking de visualizaciones en memoria
+// Se usa en modo mock y como fallback cuando MongoDB no está disponible
+func NewMockMaterialViewRepository() repository.MaterialViewRepository {
+	return &materialViewRepositoryMock{}
+}
+
+func (r *materialViewRepositoryMock) RecordView(ctx context.Context, view *repository.MaterialView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.views = append(r.views, *view)
+	return nil
+}
+
+func (r *materialViewRepositoryMock) CountViews(ctx context.Context, materialID string) (*repository.MaterialViewCounts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := &repository.MaterialViewCounts{}
+	viewers := make(map[string]bool)
+	for _, view := range r.views {
+		if view.MaterialID != materialID {
+			continue
+		}
+		counts.TotalViews++
+		viewers[view.UserID] = true
+	}
+	counts.UniqueViewers = int64(len(viewers))
+
+	return counts, nil
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type learningActivityRepositoryMock struct {
+	mu         sync.RWMutex
+	activities []repository.ProgressActivity
+	eventIDs   map[string]bool
+}
+
+// NewMockLearningActivityRepository crea un registro de actividad en memoria
+// Solo contiene actualizaciones de progreso: en modo mock los intentos no suman a la serie
+func NewMockLearningActivityRepository() repository.LearningActivityRepository {
+	return &learningActivityRepositoryMock{eventIDs: make(map[string]bool)}
+}
+
+func (r *learningActivityRepositoryMock) RecordProgressActivity(ctx context.Context, activity *repository.ProgressActivity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if activity.EventID != "" {
+		if r.eventIDs[activity.EventID] {
+			return nil
+		}
+		r.eventIDs[activity.EventID] = true
+	}
+	r.activities = append(r.activities, *activity)
+	return nil
+}
+
+func (r *learningActivityRepositoryMock) GetDailyActivity(ctx context.Context, q repository.ActivityQuery) ([]repository.DailyActivity, error) {
+	location := q.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	type dayAccumulator struct {
+		seconds   float64
+		materials map[string]bool
+		users     map[string]bool
+	}
+	byDay := make(map[string]*dayAccumulator)
+	lastByUser := make(map[string]time.Time)
+
+	for _, a := range r.sortedActivities() {
+		if (q.UserID != "" && a.UserID != q.UserID) || (q.SchoolID != "" && a.SchoolID != q.SchoolID) {
+			continue
+		}
+		if !a.OccurredAt.Before(q.To) {
+			continue
+		}
+		previous, hasPrevious := lastByUser[a.UserID]
+		lastByUser[a.UserID] = a.OccurredAt
+		if a.OccurredAt.Before(q.From) {
+			continue
+		}
+
+		day := a.OccurredAt.In(location).Format("2006-01-02")
+		acc, ok := byDay[day]
+		if !ok {
+			acc = &dayAccumulator{materials: make(map[string]bool), users: make(map[string]bool)}
+			byDay[day] = acc
+		}
+		if gap := a.OccurredAt.Sub(previous); hasPrevious && gap <= repository.ActivitySessionGap {
+			acc.seconds += gap.Seconds()
+		}
+		acc.materials[a.MaterialID] = true
+		acc.users[a.UserID] = true
+	}
+
+	days := make([]repository.DailyActivity, 0, len(byDay))
+	for day, acc := range byDay {
+		days = append(days, repository.DailyActivity{
+			Day:              day,
+			StudySeconds:     int64(acc.seconds),
+			MaterialsTouched: len(acc.materials),
+			ActiveUsers:      len(acc.users),
+		})
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Day < days[j].Day })
+	return days, nil
+}
+
+func (r *learningActivityRepositoryMock) ListActiveDays(ctx context.Context, userID string, location *time.Location) ([]string, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	seen := make(map[string]bool)
+	days := make([]string, 0)
+	for _, a := range r.sortedActivities() {
+		if a.UserID != userID {
+			continue
+		}
+		day := a.OccurredAt.In(location).Format("2006-01-02")
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Strings(days)
+	return days, nil
+}
+
+// sortedActivities retorna una copia ordenada cronológicamente
+func (r *learningActivityRepositoryMock) sortedActivities() []repository.ProgressActivity {
+	r.mu.RLock()
+	activities := make([]repository.ProgressActivity, len(r.activities))
+	copy(activities, r.activities)
+	r.mu.RUnlock()
+
+	sort.SliceStable(activities, func(i, j int) bool { return activities[i].OccurredAt.Before(activities[j].OccurredAt) })
+	return activities
+}
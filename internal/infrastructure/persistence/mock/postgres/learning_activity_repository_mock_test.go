@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// TestLearningActivityRepositoryMock_GetDailyActivity verifica pausas de sesión, días locales e idempotencia
+func TestLearningActivityRepositoryMock_GetDailyActivity(t *testing.T) {
+	repo := NewMockLearningActivityRepository()
+	ctx := context.Background()
+	base := time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC) // 9 de marzo 22:00 en Bogotá
+
+	record := func(eventID string, offset time.Duration) {
+		require.NoError(t, repo.RecordProgressActivity(ctx, &repository.ProgressActivity{
+			EventID: eventID, UserID: "u1", SchoolID: "s1", MaterialID: "m1", OccurredAt: base.Add(offset),
+		}))
+	}
+	record("e1", 0)
+	record("e2", 10*time.Minute)
+	record("e2", 10*time.Minute) // replay del mismo evento
+	record("e3", time.Hour)      // nueva sesión: no suma minutos
+
+	bogota, err := time.LoadLocation("America/Bogota")
+	require.NoError(t, err)
+
+	days, err := repo.GetDailyActivity(ctx, repository.ActivityQuery{
+		UserID:   "u1",
+		From:     base.Add(-24 * time.Hour),
+		To:       base.Add(24 * time.Hour),
+		Location: bogota,
+	})
+	require.NoError(t, err)
+	require.Len(t, days, 1)
+	assert.Equal(t, "2026-03-09", days[0].Day)
+	assert.Equal(t, int64(600), days[0].StudySeconds)
+
+	activeDays, err := repo.ListActiveDays(ctx, "u1", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026-03-10"}, activeDays)
+}
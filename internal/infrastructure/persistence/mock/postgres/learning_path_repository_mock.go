@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/fixtures"
+)
+
+type learningPathRepositoryMock struct {
+	mu    sync.RWMutex
+	paths map[string]*repository.LearningPath
+}
+
+// NewMockLearningPathRepository crea un repositorio de rutas de aprendizaje en memoria
+// Los resultados por paso salen del progreso de los fixtures: en modo mock no hay intentos
+func NewMockLearningPathRepository() repository.LearningPathRepository {
+	return &learningPathRepositoryMock{paths: make(map[string]*repository.LearningPath)}
+}
+
+func (r *learningPathRepositoryMock) FindByID(ctx context.Context, id string) (*repository.LearningPath, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	path, ok := r.paths[id]
+	if !ok {
+		return nil, nil
+	}
+	return r.withTitles(path), nil
+}
+
+func (r *learningPathRepositoryMock) ListBySchool(ctx context.Context, schoolID string) ([]*repository.LearningPath, error) {
+	return r.list(func(p *repository.LearningPath) bool { return p.SchoolID == schoolID }), nil
+}
+
+func (r *learningPathRepositoryMock) ListByMaterial(ctx context.Context, materialID string) ([]*repository.LearningPath, error) {
+	return r.list(func(p *repository.LearningPath) bool {
+		for _, step := range p.Steps {
+			if step.MaterialID == materialID {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (r *learningPathRepositoryMock) GetStepResults(ctx context.Context, userID string, materialIDs []string) ([]repository.LearningPathStepResult, error) {
+	wanted := make(map[string]bool, len(materialIDs))
+	for _, id := range materialIDs {
+		wanted[id] = true
+	}
+
+	results := make([]repository.LearningPathStepResult, 0)
+	for key, p := range fixtures.GetDefaultProgress() {
+		if key.UserID.String() != userID || !wanted[key.MaterialID.String()] {
+			continue
+		}
+		results = append(results, repository.LearningPathStepResult{
+			MaterialID: key.MaterialID.String(),
+			Percentage: p.Percentage,
+		})
+	}
+	return results, nil
+}
+
+func (r *learningPathRepositoryMock) Create(ctx context.Context, path *repository.LearningPath) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := clonePath(path)
+	r.paths[path.ID] = stored
+	return nil
+}
+
+func (r *learningPathRepositoryMock) Update(ctx context.Context, path *repository.LearningPath) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.paths[path.ID]
+	if !ok {
+		return nil
+	}
+	updated := clonePath(path)
+	updated.SchoolID = existing.SchoolID
+	updated.CreatedBy = existing.CreatedBy
+	updated.CreatedAt = existing.CreatedAt
+	r.paths[path.ID] = updated
+	return nil
+}
+
+func (r *learningPathRepositoryMock) Delete(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.paths[id]; !ok {
+		return false, nil
+	}
+	delete(r.paths, id)
+	return true, nil
+}
+
+func (r *learningPathRepositoryMock) list(match func(*repository.LearningPath) bool) []*repository.LearningPath {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	paths := make([]*repository.LearningPath, 0)
+	for _, p := range r.paths {
+		if match(p) {
+			paths = append(paths, r.withTitles(p))
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i].Title != paths[j].Title {
+			return paths[i].Title < paths[j].Title
+		}
+		return paths[i].ID < paths[j].ID
+	})
+	return paths
+}
+
+// withTitles retorna una copia de la ruta con el título de los materiales conocidos en los fixtures
+func (r *learningPathRepositoryMock) withTitles(path *repository.LearningPath) *repository.LearningPath {
+	copied := clonePath(path)
+	materials := fixtures.GetDefaultMaterials()
+	for i, step := range copied.Steps {
+		for id, m := range materials {
+			if id.String() == step.MaterialID {
+				copied.Steps[i].MaterialTitle = m.Title
+				break
+			}
+		}
+	}
+	return copied
+}
+
+func clonePath(path *repository.LearningPath) *repository.LearningPath {
+	copied := *path
+	copied.Steps = make([]repository.LearningPathStep, len(path.Steps))
+	for i, step := range path.Steps {
+		copied.Steps[i] = step
+		copied.Steps[i].Prerequisites = append([]string(nil), step.Prerequisites...)
+	}
+	return &copied
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -85,9 +86,18 @@ func (r *progressRepositoryMock) Upsert(ctx context.Context, progress *pgentitie
 	now := time.Now()
 
 	if existing, exists := r.progress[key]; exists {
-		// Update
+		// Update: el porcentaje nunca retrocede
 		copy.CreatedAt = existing.CreatedAt
 		copy.UpdatedAt = now
+		if existing.Percentage > copy.Percentage {
+			copy.Percentage = existing.Percentage
+			copy.Status = existing.Status
+		}
+		// last_page: last-writer-wins por hora de acceso del cliente
+		if existing.LastAccessedAt.After(copy.LastAccessedAt) {
+			copy.LastPage = existing.LastPage
+			copy.LastAccessedAt = existing.LastAccessedAt
+		}
 	} else {
 		// Insert
 		copy.CreatedAt = now
@@ -98,6 +108,60 @@ func (r *progressRepositoryMock) Upsert(ctx context.Context, progress *pgentitie
 	return &copy, nil
 }
 
+func (r *progressRepositoryMock) ListChangedSince(ctx context.Context, userID valueobject.UserID, after repository.ProgressCursor, limit int) ([]*pgentities.Progress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var changes []*pgentities.Progress
+	for _, p := range r.progress {
+		if p.UserID != userID.UUID().UUID {
+			continue
+		}
+		if p.UpdatedAt.Before(after.UpdatedAt) ||
+			(p.UpdatedAt.Equal(after.UpdatedAt) && p.MaterialID.String() <= after.MaterialID) {
+			continue
+		}
+		copy := *p
+		changes = append(changes, &copy)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if !changes[i].UpdatedAt.Equal(changes[j].UpdatedAt) {
+			return changes[i].UpdatedAt.Before(changes[j].UpdatedAt)
+		}
+		return changes[i].MaterialID.String() < changes[j].MaterialID.String()
+	})
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+func (r *progressRepositoryMock) Reset(ctx context.Context, materialID valueobject.MaterialID, userID valueobject.UserID) (*pgentities.Progress, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := fixtures.ProgressKey{
+		MaterialID: materialID.UUID().UUID,
+		UserID:     userID.UUID().UUID,
+	}
+
+	existing, ok := r.progress[key]
+	if !ok {
+		return nil, nil
+	}
+
+	now := time.Now()
+	existing.Percentage = 0
+	existing.LastPage = 0
+	existing.Status = "not_started"
+	existing.LastAccessedAt = now
+	existing.UpdatedAt = now
+
+	copy := *existing
+	return &copy, nil
+}
+
 func (r *progressRepositoryMock) CountActiveUsers(ctx context.Context) (int64, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -130,3 +194,72 @@ func (r *progressRepositoryMock) CalculateAverageProgress(ctx context.Context) (
 
 	return total / float64(len(r.progress)), nil
 }
+
+func (r *progressRepositoryMock) GetMaterialProgressStats(ctx context.Context, materialID valueobject.MaterialID) (*repository.MaterialProgressStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &repository.MaterialProgressStats{}
+	var total float64
+	for key, p := range r.progress {
+		if key.MaterialID != materialID.UUID().UUID {
+			continue
+		}
+		stats.Learners++
+		if p.Percentage == 100 {
+			stats.Completed++
+		}
+		total += float64(p.Percentage)
+	}
+	if stats.Learners > 0 {
+		stats.AvgProgress = total / float64(stats.Learners)
+	}
+
+	return stats, nil
+}
+
+func (r *progressRepositoryMock) ListByUser(ctx context.Context, userID valueobject.UserID, filter repository.UserProgressFilter) ([]*repository.UserMaterialProgress, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	materials := fixtures.GetDefaultMaterials()
+	var items []*repository.UserMaterialProgress
+	for key, p := range r.progress {
+		if key.UserID != userID.UUID().UUID {
+			continue
+		}
+		if filter.Status == repository.UserProgressStatusInProgress && p.Percentage == 100 {
+			continue
+		}
+		if filter.Status == repository.UserProgressStatusCompleted && p.Percentage != 100 {
+			continue
+		}
+
+		item := &repository.UserMaterialProgress{
+			MaterialID:     p.MaterialID.String(),
+			Percentage:     p.Percentage,
+			LastPage:       p.LastPage,
+			Status:         p.Status,
+			LastAccessedAt: p.LastAccessedAt,
+		}
+		if material, ok := materials[p.MaterialID]; ok {
+			item.MaterialTitle = material.Title
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].LastAccessedAt.After(items[j].LastAccessedAt)
+	})
+
+	total := int64(len(items))
+	if filter.Offset >= len(items) {
+		return []*repository.UserMaterialProgress{}, total, nil
+	}
+	end := filter.Offset + filter.Limit
+	if filter.Limit <= 0 || end > len(items) {
+		end = len(items)
+	}
+
+	return items[filter.Offset:end], total, nil
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/fixtures"
+)
+
+type readingEventRepositoryMock struct {
+	mu      sync.RWMutex
+	events  []repository.ReadingEvent           // Orden de llegada, equivalente al id de reading_events
+	seen    map[string]bool                     // user_id + client_event_id
+	extents map[string]repository.ReadingExtent // material_id
+	flags   []repository.ReadingFlag
+}
+
+// NewMockReadingEventRepository crea un registro de eventos de lectura en memoria
+// con el tamaño de contenido de los materiales de fixtures
+func NewMockReadingEventRepository() repository.ReadingEventRepository {
+	extents := make(map[string]repository.ReadingExtent)
+	for id, extent := range fixtures.GetDefaultMaterialExtents() {
+		extents[id.String()] = extent
+	}
+	return &readingEventRepositoryMock{
+		seen:    make(map[string]bool),
+		extents: extents,
+	}
+}
+
+func (r *readingEventRepositoryMock) AppendReadingEvents(ctx context.Context, events []repository.ReadingEvent) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inserted := 0
+	for _, e := range events {
+		key := e.UserID + "|" + e.ClientEventID
+		if r.seen[key] {
+			continue
+		}
+		r.seen[key] = true
+		if e.ReceivedAt.IsZero() {
+			e.ReceivedAt = time.Now()
+		}
+		r.events = append(r.events, e)
+		inserted++
+	}
+	return inserted, nil
+}
+
+func (r *readingEventRepositoryMock) RecordReadingFlag(ctx context.Context, flag repository.ReadingFlag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.flags = append(r.flags, flag)
+	return nil
+}
+
+func (r *readingEventRepositoryMock) GetReadingCoverage(ctx context.Context, userID, materialID string) (*repository.ReadingCoverage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var relevant []repository.ReadingEvent
+	for _, e := range r.events {
+		if e.UserID != userID || e.MaterialID != materialID {
+			continue
+		}
+		if e.Kind == repository.ReadingEventReset {
+			relevant = relevant[:0]
+			continue
+		}
+		relevant = append(relevant, e)
+	}
+
+	coverage := &repository.ReadingCoverage{}
+	pages := make(map[int]bool)
+	sections := make(map[string]bool)
+	buckets := make(map[int]bool)
+	var lastPage *repository.ReadingEvent
+
+	for i, e := range relevant {
+		coverage.TimeOnTaskSeconds += e.DwellSeconds
+		switch e.Kind {
+		case repository.ReadingEventPage:
+			pages[e.Page] = true
+			if lastPage == nil || !e.OccurredAt.Before(lastPage.OccurredAt) {
+				lastPage = &relevant[i]
+			}
+		case repository.ReadingEventSection:
+			sections[e.SectionID] = true
+		case repository.ReadingEventVideo:
+			if e.DwellSeconds <= 0 || e.PositionSeconds <= 0 {
+				continue
+			}
+			from := max(e.PositionSeconds-e.DwellSeconds, 0) / repository.VideoCoverageBucketSeconds
+			to := (e.PositionSeconds - 1) / repository.VideoCoverageBucketSeconds
+			for b := from; b <= to; b++ {
+				buckets[b] = true
+			}
+		}
+	}
+
+	coverage.PagesViewed = len(pages)
+	coverage.SectionsViewed = len(sections)
+	coverage.WatchedSeconds = len(buckets) * repository.VideoCoverageBucketSeconds
+	if lastPage != nil {
+		coverage.LastPage = lastPage.Page
+	}
+	return coverage, nil
+}
+
+func (r *readingEventRepositoryMock) GetMaterialExtent(ctx context.Context, materialID string) (*repository.ReadingExtent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	extent := r.extents[materialID]
+	return &extent, nil
+}
+
+func (r *readingEventRepositoryMock) LastReadingEventReceivedAt(ctx context.Context, userID, materialID string) (*time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var last *time.Time
+	for i, e := range r.events {
+		if e.UserID != userID || e.MaterialID != materialID {
+			continue
+		}
+		if last == nil || e.ReceivedAt.After(*last) {
+			last = &r.events[i].ReceivedAt
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	receivedAt := *last
+	return &receivedAt, nil
+}
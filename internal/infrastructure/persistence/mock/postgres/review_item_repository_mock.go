@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type reviewItemRepositoryMock struct {
+	mu     sync.Mutex
+	items  map[string]*repository.ReviewItem // por ID
+	grades []repository.ReviewGrade
+}
+
+// NewMockReviewItemRepository crea una cola de repaso en memoria
+func NewMockReviewItemRepository() repository.ReviewItemRepository {
+	return &reviewItemRepositoryMock{items: make(map[string]*repository.ReviewItem)}
+}
+
+func (r *reviewItemRepositoryMock) EnqueueReviewItems(ctx context.Context, items []*repository.ReviewItem) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inserted := 0
+	for _, item := range items {
+		if existing := r.findBySource(item.UserID, item.Kind, item.SourceKey); existing != nil {
+			reviewedAfter := existing.LastReviewedAt != nil && !existing.LastReviewedAt.Before(item.CreatedAt)
+			if existing.Kind == repository.ReviewItemQuestion && !reviewedAfter {
+				existing.Repetitions = 0
+				existing.IntervalDays = 0
+				if item.DueAt.Before(existing.DueAt) {
+					existing.DueAt = item.DueAt
+				}
+			}
+			continue
+		}
+		stored := *item
+		r.items[item.ID] = &stored
+		inserted++
+	}
+	return inserted, nil
+}
+
+func (r *reviewItemRepositoryMock) ListDueReviewItems(ctx context.Context, userID string, now time.Time, limit int) ([]*repository.ReviewItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	due := r.due(userID, now)
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].DueAt.Equal(due[j].DueAt) {
+			return due[i].DueAt.Before(due[j].DueAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	items := make([]*repository.ReviewItem, len(due))
+	for i, item := range due {
+		copied := *item
+		items[i] = &copied
+	}
+	return items, nil
+}
+
+func (r *reviewItemRepositoryMock) CountDueReviewItems(ctx context.Context, userID string, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.due(userID, now)), nil
+}
+
+func (r *reviewItemRepositoryMock) FindReviewItem(ctx context.Context, userID, itemID string) (*repository.ReviewItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[itemID]
+	if !ok || item.UserID != userID {
+		return nil, nil
+	}
+	copied := *item
+	return &copied, nil
+}
+
+func (r *reviewItemRepositoryMock) SaveReview(ctx context.Context, item *repository.ReviewItem, grade repository.ReviewGrade) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.items[item.ID]; ok && existing.UserID == item.UserID {
+		stored := *item
+		r.items[item.ID] = &stored
+	}
+	r.grades = append(r.grades, grade)
+	return nil
+}
+
+func (r *reviewItemRepositoryMock) findBySource(userID, kind, sourceKey string) *repository.ReviewItem {
+	for _, item := range r.items {
+		if item.UserID == userID && item.Kind == kind && item.SourceKey == sourceKey {
+			return item
+		}
+	}
+	return nil
+}
+
+func (r *reviewItemRepositoryMock) due(userID string, now time.Time) []*repository.ReviewItem {
+	due := make([]*repository.ReviewItem, 0)
+	for _, item := range r.items {
+		if item.UserID == userID && !item.DueAt.After(now) {
+			due = append(due, item)
+		}
+	}
+	return due
+}
@@ -0,0 +1,19 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// mockScreenExperimentRepository es un stub para desarrollo sin BD: no hay experimentos activos
+type mockScreenExperimentRepository struct{}
+
+// NewMockScreenExperimentRepository crea una nueva instancia del mock
+func NewMockScreenExperimentRepository() repository.ScreenExperimentRepository {
+	return &mockScreenExperimentRepository{}
+}
+
+func (r *mockScreenExperimentRepository) GetActiveExperiments(ctx context.Context) ([]*repository.ScreenExperiment, error) {
+	return []*repository.ScreenExperiment{}, nil
+}
@@ -32,3 +32,11 @@ func (r *mockScreenRepository) GetUserPreferences(ctx context.Context, screenKey
 func (r *mockScreenRepository) SaveUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID, prefs json.RawMessage) error {
 	return nil
 }
+
+func (r *mockScreenRepository) DeleteUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID) error {
+	return nil
+}
+
+func (r *mockScreenRepository) GetUserPreferencesHistory(ctx context.Context, screenKey string, userID uuid.UUID) ([]*repository.ScreenPreferencesVersion, error) {
+	return []*repository.ScreenPreferencesVersion{}, nil
+}
@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// mockScreenTranslationRepository es un stub para desarrollo sin BD: todo se sirve en el idioma original
+type mockScreenTranslationRepository struct{}
+
+// NewMockScreenTranslationRepository crea una nueva instancia del mock
+func NewMockScreenTranslationRepository() repository.ScreenTranslationRepository {
+	return &mockScreenTranslationRepository{}
+}
+
+func (r *mockScreenTranslationRepository) GetSlotTranslations(ctx context.Context, screenKey, locale string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (r *mockScreenTranslationRepository) GetResourceTranslations(ctx context.Context, locale string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type statsSnapshotRepositoryMock struct {
+	mu       sync.RWMutex
+	snapshot *repository.GlobalStatsSnapshot
+}
+
+// NewMockStatsSnapshotRepository crea un store de snapshots en memoria (sin snapshot inicial)
+func NewMockStatsSnapshotRepository() repository.StatsSnapshotRepository {
+	return &statsSnapshotRepositoryMock{}
+}
+
+func (r *statsSnapshotRepositoryMock) GetGlobalSnapshot(ctx context.Context) (*repository.GlobalStatsSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.snapshot == nil {
+		return nil, nil
+	}
+	snapshot := *r.snapshot
+	return &snapshot, nil
+}
+
+func (r *statsSnapshotRepositoryMock) SaveGlobalSnapshot(ctx context.Context, snapshot *repository.GlobalStatsSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.snapshot != nil && !r.snapshot.GeneratedAt.Before(snapshot.GeneratedAt) {
+		return nil
+	}
+	saved := *snapshot
+	r.snapshot = &saved
+	return nil
+}
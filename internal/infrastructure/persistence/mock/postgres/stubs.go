@@ -89,6 +89,9 @@ func (r *mockAttemptRepository) CountCompletedAssessments(ctx context.Context) (
 func (r *mockAttemptRepository) CalculateAverageScore(ctx context.Context) (float64, error) {
 	return 75.5, nil // Mock: retorna valor de ejemplo
 }
+func (r *mockAttemptRepository) GetMaterialAttemptStats(ctx context.Context, materialID uuid.UUID) (*repositories.MaterialAttemptStats, error) {
+	return &repositories.MaterialAttemptStats{}, nil
+}
 
 // Answer Repository Stub
 type mockAnswerRepository struct{}
@@ -103,3 +106,6 @@ func (r *mockAnswerRepository) Save(ctx context.Context, answers []*pgentities.A
 func (r *mockAnswerRepository) FindByQuestionID(ctx context.Context, questionID string, limit, offset int) ([]*pgentities.AssessmentAttemptAnswer, error) {
 	return []*pgentities.AssessmentAttemptAnswer{}, nil
 }
+func (r *mockAnswerRepository) FindItemResponses(ctx context.Context, assessmentID uuid.UUID) ([]*repositories.ItemResponse, error) {
+	return []*repositories.ItemResponse{}, nil
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type syncOperationRepositoryMock struct {
+	mu  sync.Mutex
+	ops map[string]*repository.SyncOperation // user_id + client_id
+}
+
+// NewMockSyncOperationRepository crea un registro de operaciones de sincronización en memoria
+func NewMockSyncOperationRepository() repository.SyncOperationRepository {
+	return &syncOperationRepositoryMock{ops: make(map[string]*repository.SyncOperation)}
+}
+
+func (r *syncOperationRepositoryMock) ClaimSyncOperation(ctx context.Context, op *repository.SyncOperation) (*repository.SyncOperation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := op.UserID + "|" + op.ClientID
+	if existing, ok := r.ops[key]; ok {
+		abandoned := existing.Status == repository.SyncStatusPending &&
+			time.Since(existing.ClaimedAt) > repository.SyncClaimTimeout
+		if !abandoned {
+			copy := *existing
+			return &copy, nil
+		}
+	}
+
+	claimed := *op
+	claimed.Status = repository.SyncStatusPending
+	claimed.ClaimedAt = time.Now()
+	r.ops[key] = &claimed
+	return nil, nil
+}
+
+func (r *syncOperationRepositoryMock) CompleteSyncOperation(ctx context.Context, op *repository.SyncOperation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := op.UserID + "|" + op.ClientID
+	if existing, ok := r.ops[key]; ok {
+		existing.Status = op.Status
+		existing.Result = op.Result
+		existing.ErrorCode = op.ErrorCode
+		existing.ErrorMessage = op.ErrorMessage
+	}
+	return nil
+}
+
+func (r *syncOperationRepositoryMock) ReleaseSyncOperation(ctx context.Context, userID, clientID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := userID + "|" + clientID
+	if existing, ok := r.ops[key]; ok && existing.Status == repository.SyncStatusPending {
+		delete(r.ops, key)
+	}
+	return nil
+}
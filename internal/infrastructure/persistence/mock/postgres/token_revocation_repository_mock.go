@@ -0,0 +1,20 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// mockTokenRevocationRepository es un stub para desarrollo sin BD: no hay tokens revocados
+type mockTokenRevocationRepository struct{}
+
+// NewMockTokenRevocationRepository crea una nueva instancia del mock
+func NewMockTokenRevocationRepository() repository.TokenRevocationRepository {
+	return &mockTokenRevocationRepository{}
+}
+
+func (r *mockTokenRevocationRepository) GetRevocationsSince(ctx context.Context, since time.Time) ([]*repository.TokenRevocation, error) {
+	return nil, nil
+}
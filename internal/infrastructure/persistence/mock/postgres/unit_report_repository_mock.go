@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repositories"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/mock/fixtures"
+)
+
+type unitReportRepositoryMock struct{}
+
+// NewMockUnitReportRepository crea un repositorio de reportes construido desde los fixtures
+// Los estudiantes son los usuarios con progreso en los materiales de la unidad
+func NewMockUnitReportRepository() repository.UnitReportRepository {
+	return &unitReportRepositoryMock{}
+}
+
+func (r *unitReportRepositoryMock) GetUnitGradebook(ctx context.Context, academicUnitID string) (*repository.UnitGradebook, error) {
+	gradebook := &repository.UnitGradebook{AcademicUnitID: academicUnitID}
+
+	unitMaterials := make(map[string]bool)
+	for _, m := range fixtures.GetDefaultMaterials() {
+		if m.AcademicUnitID == nil || m.AcademicUnitID.String() != academicUnitID {
+			continue
+		}
+		gradebook.SchoolID = m.SchoolID.String()
+		unitMaterials[m.ID.String()] = true
+		gradebook.Materials = append(gradebook.Materials, repository.UnitReportMaterial{ID: m.ID.String(), Title: m.Title})
+	}
+	if len(gradebook.Materials) == 0 {
+		return nil, nil
+	}
+	sort.Slice(gradebook.Materials, func(i, j int) bool { return gradebook.Materials[i].Title < gradebook.Materials[j].Title })
+
+	users := fixtures.GetDefaultUsers()
+	seen := make(map[string]bool)
+	for key, p := range fixtures.GetDefaultProgress() {
+		if !unitMaterials[key.MaterialID.String()] {
+			continue
+		}
+		lastAccessed := p.LastAccessedAt
+		gradebook.Results = append(gradebook.Results, repository.UnitReportResult{
+			StudentID:      p.UserID.String(),
+			MaterialID:     p.MaterialID.String(),
+			Percentage:     p.Percentage,
+			LastAccessedAt: &lastAccessed,
+			PassThreshold:  repositories.DefaultPassThreshold,
+		})
+		if user, ok := users[p.UserID.String()]; ok && !seen[user.ID.String()] {
+			seen[user.ID.String()] = true
+			gradebook.Students = append(gradebook.Students, repository.UnitReportStudent{
+				ID:        user.ID.String(),
+				FirstName: user.FirstName,
+				LastName:  user.LastName,
+				Email:     user.Email,
+			})
+		}
+	}
+
+	return gradebook, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// failedEventDocument representa el schema MongoDB de la colección failed_events
+type failedEventDocument struct {
+	ID            string     `bson:"_id"`
+	Exchange      string     `bson:"exchange"`
+	RoutingKey    string     `bson:"routing_key"`
+	Payload       []byte     `bson:"payload,omitempty"`
+	Error         string     `bson:"error"`
+	Attempts      int        `bson:"attempts"`
+	Status        string     `bson:"status"`
+	FirstFailedAt time.Time  `bson:"first_failed_at"`
+	LastFailedAt  time.Time  `bson:"last_failed_at"`
+	ReplayedAt    *time.Time `bson:"replayed_at,omitempty"`
+}
+
+type mongoFailedEventRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoFailedEventRepository crea el dead-letter store sobre MongoDB
+func NewMongoFailedEventRepository(db *mongo.Database) repository.FailedEventRepository {
+	return &mongoFailedEventRepository{
+		collection: db.Collection("failed_events"),
+	}
+}
+
+func (r *mongoFailedEventRepository) Save(ctx context.Context, event *repository.FailedEvent) error {
+	if _, err := r.collection.InsertOne(ctx, toFailedEventDocument(event)); err != nil {
+		return fmt.Errorf("mongo: error saving failed event: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoFailedEventRepository) Update(ctx context.Context, event *repository.FailedEvent) error {
+	filter := bson.M{"_id": event.ID}
+	if _, err := r.collection.ReplaceOne(ctx, filter, toFailedEventDocument(event)); err != nil {
+		return fmt.Errorf("mongo: error updating failed event: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoFailedEventRepository) FindByID(ctx context.Context, id string) (*repository.FailedEvent, error) {
+	var doc failedEventDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mongo: error finding failed event: %w", err)
+	}
+	return doc.toEntity(), nil
+}
+
+func (r *mongoFailedEventRepository) List(ctx context.Context, filter repository.FailedEventFilter) ([]*repository.FailedEvent, int64, error) {
+	query := bson.M{}
+	if filter.Status != "" {
+		query["status"] = string(filter.Status)
+	}
+	if filter.RoutingKey != "" {
+		query["routing_key"] = filter.RoutingKey
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("mongo: error counting failed events: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "last_failed_at", Value: -1}}).
+		SetSkip(int64(filter.Offset))
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("mongo: error listing failed events: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var docs []failedEventDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, 0, fmt.Errorf("mongo: error decoding failed events: %w", err)
+	}
+
+	events := make([]*repository.FailedEvent, 0, len(docs))
+	for i := range docs {
+		events = append(events, docs[i].toEntity())
+	}
+
+	return events, total, nil
+}
+
+func toFailedEventDocument(event *repository.FailedEvent) failedEventDocument {
+	return failedEventDocument{
+		ID:            event.ID,
+		Exchange:      event.Exchange,
+		RoutingKey:    event.RoutingKey,
+		Payload:       event.Payload,
+		Error:         event.Error,
+		Attempts:      event.Attempts,
+		Status:        string(event.Status),
+		FirstFailedAt: event.FirstFailedAt,
+		LastFailedAt:  event.LastFailedAt,
+		ReplayedAt:    event.ReplayedAt,
+	}
+}
+
+func (d failedEventDocument) toEntity() *repository.FailedEvent {
+	return &repository.FailedEvent{
+		ID:            d.ID,
+		Exchange:      d.Exchange,
+		RoutingKey:    d.RoutingKey,
+		Payload:       d.Payload,
+		Error:         d.Error,
+		Attempts:      d.Attempts,
+		Status:        repository.FailedEventStatus(d.Status),
+		FirstFailedAt: d.FirstFailedAt,
+		LastFailedAt:  d.LastFailedAt,
+		ReplayedAt:    d.ReplayedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// materialViewDocument representa el schema MongoDB de la colección material_views
+type materialViewDocument struct {
+	MaterialID string    `bson:"material_id"`
+	UserID     string    `bson:"user_id"`
+	SchoolID   string    `bson:"school_id,omitempty"`
+	ViewedAt   time.Time `bson:"viewed_at"`
+}
+
+type mongoMaterialViewRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoMaterialViewRepository crea el tracking de visualizaciones sobre MongoDB
+func NewMongoMaterialViewRepository(db *mongo.Database) repository.MaterialViewRepository {
+	return &mongoMaterialViewRepository{
+		collection: db.Collection("material_views"),
+	}
+}
+
+func (r *mongoMaterialViewRepository) RecordView(ctx context.Context, view *repository.MaterialView) error {
+	doc := materialViewDocument{
+		MaterialID: view.MaterialID,
+		UserID:     view.UserID,
+		SchoolID:   view.SchoolID,
+		ViewedAt:   view.ViewedAt,
+	}
+	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+		return fmt.Errorf("mongo: error recording material view: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoMaterialViewRepository) CountViews(ctx context.Context, materialID string) (*repository.MaterialViewCounts, error) {
+	// Agrupar primero por usuario evita acumular todos los user_id en un solo documento
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"material_id": materialID}}},
+		{{Key: "$group", Value: bson.M{"_id": "$user_id", "views": bson.M{"$sum": 1}}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$views"}, "unique": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("mongo: error counting material views: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var results []struct {
+		Total  int64 `bson:"total"`
+		Unique int64 `bson:"unique"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("mongo: error decoding material views: %w", err)
+	}
+
+	counts := &repository.MaterialViewCounts{}
+	if len(results) > 0 {
+		counts.TotalViews = results[0].Total
+		counts.UniqueViewers = results[0].Unique
+	}
+	return counts, nil
+}
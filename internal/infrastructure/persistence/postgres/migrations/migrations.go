@@ -0,0 +1,148 @@
+// Package migrations contiene el DDL de las tablas propias de api-mobile que aún no
+// forman parte de edugo-infrastructure/postgres.
+//
+// Los scripts se aplican al arrancar (bootstrap, database.postgres.auto_migrate) después de
+// las migraciones de infrastructure, en orden lexicográfico. Cada script se ejecuta una sola
+// vez en su propia transacción y queda registrado en api_mobile_schema_migrations; un advisory
+// lock serializa las instancias que arrancan a la vez. Los scripts además son idempotentes
+// (IF NOT EXISTS) para bases donde las tablas se crearon antes del registro de versiones.
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+//go:embed sql/*.sql
+var scripts embed.FS
+
+// advisoryLockKey identifica el advisory lock de las migraciones de api-mobile
+const advisoryLockKey int64 = 0x6d6f62696c65 // "mobile"
+
+// versionTableDDL crea la tabla que registra los scripts aplicados
+const versionTableDDL = `
+	CREATE TABLE IF NOT EXISTS api_mobile_schema_migrations (
+		name       VARCHAR(255) PRIMARY KEY,
+		checksum   CHAR(64) NOT NULL,
+		applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+	)
+`
+
+// Files retorna los nombres de los scripts en el orden en que se aplican
+func Files() ([]string, error) {
+	names, err := fs.Glob(scripts, "sql/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("migrations: error listing scripts: %w", err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ApplyAll aplica sobre db los scripts pendientes y retorna los que aplicó
+// Falla si un script ya aplicado cambió: los cambios de esquema van en un script nuevo
+func ApplyAll(ctx context.Context, db *sql.DB) ([]string, error) {
+	names, err := Files()
+	if err != nil {
+		return nil, err
+	}
+
+	// El advisory lock es de sesión: lock, scripts y unlock usan la misma conexión
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migrations: error getting connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockKey); err != nil {
+		return nil, fmt.Errorf("migrations: error acquiring lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+	}()
+
+	if _, err := conn.ExecContext(ctx, versionTableDDL); err != nil {
+		return nil, fmt.Errorf("migrations: error creating version table: %w", err)
+	}
+	applied, err := appliedChecksums(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var newlyApplied []string
+	for _, name := range names {
+		script, err := scripts.ReadFile(name)
+		if err != nil {
+			return newlyApplied, fmt.Errorf("migrations: error reading %s: %w", name, err)
+		}
+		checksum := scriptChecksum(script)
+
+		if previous, ok := applied[name]; ok {
+			if previous != checksum {
+				return newlyApplied, fmt.Errorf("migrations: %s changed after being applied; add a new script instead", name)
+			}
+			continue
+		}
+
+		if err := applyScript(ctx, conn, name, string(script), checksum); err != nil {
+			return newlyApplied, err
+		}
+		newlyApplied = append(newlyApplied, name)
+	}
+	return newlyApplied, nil
+}
+
+// appliedChecksums retorna nombre -> checksum de los scripts ya aplicados
+func appliedChecksums(ctx context.Context, conn *sql.Conn) (map[string]string, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT name, checksum FROM api_mobile_schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("migrations: error reading applied scripts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	applied := make(map[string]string)
+	for rows.Next() {
+		var name, checksum string
+		if err := rows.Scan(&name, &checksum); err != nil {
+			return nil, fmt.Errorf("migrations: error scanning applied script: %w", err)
+		}
+		applied[name] = checksum
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("migrations: error iterating applied scripts: %w", err)
+	}
+	return applied, nil
+}
+
+// applyScript ejecuta el script y lo registra en la misma transacción
+func applyScript(ctx context.Context, conn *sql.Conn, name, script, checksum string) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("migrations: error starting transaction for %s: %w", name, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migrations: error applying %s: %w", name, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO api_mobile_schema_migrations (name, checksum) VALUES ($1, $2)`, name, checksum,
+	); err != nil {
+		return fmt.Errorf("migrations: error recording %s: %w", name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migrations: error committing %s: %w", name, err)
+	}
+	return nil
+}
+
+// scriptChecksum SHA-256 en hexadecimal del contenido del script
+func scriptChecksum(script []byte) string {
+	sum := sha256.Sum256(script)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,97 @@
+//go:build integration
+// +build integration
+
+package migrations_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	testifySuite "github.com/stretchr/testify/suite"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/postgres/migrations"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/testing/suite"
+)
+
+// MigrationsIntegrationSuite tests de integración del runner de migraciones de api-mobile
+// La suite base ya aplicó los scripts en SetupSuite
+type MigrationsIntegrationSuite struct {
+	suite.IntegrationTestSuite
+}
+
+// TestMigrationsIntegration ejecuta la suite
+func TestMigrationsIntegration(t *testing.T) {
+	testifySuite.Run(t, new(MigrationsIntegrationSuite))
+}
+
+// TestApplyAll_RecordsEveryScriptOnce valida que cada script queda registrado y no se vuelve a aplicar
+func (s *MigrationsIntegrationSuite) TestApplyAll_RecordsEveryScriptOnce() {
+	ctx := context.Background()
+
+	names, err := migrations.Files()
+	s.Require().NoError(err)
+
+	var recorded int
+	err = s.PostgresDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM api_mobile_schema_migrations`).Scan(&recorded)
+	s.Require().NoError(err)
+	s.Equal(len(names), recorded)
+
+	applied, err := migrations.ApplyAll(ctx, s.PostgresDB)
+	s.Require().NoError(err)
+	s.Empty(applied, "una base al día no aplica scripts")
+}
+
+// TestApplyAll_ConcurrentInstances valida que instancias que arrancan a la vez no aplican dos veces
+func (s *MigrationsIntegrationSuite) TestApplyAll_ConcurrentInstances() {
+	ctx := context.Background()
+
+	// Simular una base nueva para los dos últimos scripts
+	names, err := migrations.Files()
+	s.Require().NoError(err)
+	pending := names[len(names)-2:]
+	_, err = s.PostgresDB.ExecContext(ctx, `DELETE FROM api_mobile_schema_migrations WHERE name IN ($1, $2)`, pending[0], pending[1])
+	s.Require().NoError(err)
+
+	var wg sync.WaitGroup
+	results := make([][]string, 3)
+	errs := make([]error, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = migrations.ApplyAll(ctx, s.PostgresDB)
+		}(i)
+	}
+	wg.Wait()
+
+	var appliedTotal int
+	for i := range results {
+		s.Require().NoError(errs[i])
+		appliedTotal += len(results[i])
+	}
+	s.Equal(len(pending), appliedTotal, "cada script pendiente lo aplica una sola instancia")
+}
+
+// TestApplyAll_RejectsChangedScript valida que un script aplicado no puede modificarse
+func (s *MigrationsIntegrationSuite) TestApplyAll_RejectsChangedScript() {
+	ctx := context.Background()
+
+	names, err := migrations.Files()
+	s.Require().NoError(err)
+	_, err = s.PostgresDB.ExecContext(ctx,
+		`UPDATE api_mobile_schema_migrations SET checksum = $2 WHERE name = $1`,
+		names[0], "0000000000000000000000000000000000000000000000000000000000000000",
+	)
+	s.Require().NoError(err)
+
+	_, err = migrations.ApplyAll(ctx, s.PostgresDB)
+	s.Require().Error(err)
+	s.Contains(err.Error(), names[0])
+
+	// Restaurar el registro para el resto de las suites
+	_, err = s.PostgresDB.ExecContext(ctx, `DELETE FROM api_mobile_schema_migrations WHERE name = $1`, names[0])
+	s.Require().NoError(err)
+	_, err = migrations.ApplyAll(ctx, s.PostgresDB)
+	s.Require().NoError(err)
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+)
+
+var createStatement = regexp.MustCompile(`(?i)CREATE\s+(?:UNIQUE\s+)?(?:TABLE|INDEX|SEQUENCE)\s+(IF\s+NOT\s+EXISTS\s+)?[\w.]+`)
+
+func TestFiles_OrderedAndIdempotent(t *testing.T) {
+	names, err := Files()
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+	if len(names) == 0 {
+		t.Fatal("expected at least one migration script")
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("scripts not sorted: %v", names)
+	}
+
+	for _, name := range names {
+		script, err := scripts.ReadFile(name)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", name, err)
+		}
+		for _, match := range createStatement.FindAllStringSubmatch(string(script), -1) {
+			if match[1] == "" {
+				t.Errorf("%s: %q must use IF NOT EXISTS so ApplyAll can be re-run", name, match[0])
+			}
+		}
+	}
+}
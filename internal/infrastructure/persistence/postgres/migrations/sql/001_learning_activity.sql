@@ -0,0 +1,21 @@
+-- Actividad de aprendizaje append-only
+-- Una fila por actualización de progreso; event_id hace idempotentes re-entregas y replays
+CREATE TABLE IF NOT EXISTS learning_activity (
+    id          BIGSERIAL PRIMARY KEY,
+    event_id    VARCHAR(100) NOT NULL,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    school_id   UUID REFERENCES schools(id) ON DELETE SET NULL,
+    material_id UUID NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    percentage  INTEGER NOT NULL CHECK (percentage >= 0 AND percentage <= 100),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT learning_activity_event_id_key UNIQUE (event_id)
+);
+
+-- Serie por usuario (GetDailyActivity con user_id, ListActiveDays)
+CREATE INDEX IF NOT EXISTS idx_learning_activity_user_occurred
+    ON learning_activity (user_id, occurred_at);
+
+-- Serie por escuela o global del panel de administración
+CREATE INDEX IF NOT EXISTS idx_learning_activity_school_occurred
+    ON learning_activity (school_id, occurred_at);
@@ -0,0 +1,11 @@
+-- Snapshot compartido de estadísticas globales
+-- Una sola fila (id = 1) que todas las instancias leen y actualizan
+CREATE TABLE IF NOT EXISTS global_stats_snapshot (
+    id                          SMALLINT PRIMARY KEY CHECK (id = 1),
+    total_published_materials   BIGINT NOT NULL DEFAULT 0,
+    total_completed_assessments BIGINT NOT NULL DEFAULT 0,
+    average_assessment_score    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    active_users_last_30_days   BIGINT NOT NULL DEFAULT 0,
+    average_progress            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    generated_at                TIMESTAMP WITH TIME ZONE NOT NULL
+);
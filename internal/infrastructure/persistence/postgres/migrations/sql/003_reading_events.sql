@@ -0,0 +1,53 @@
+-- Eventos de lectura, tamaño del contenido y marcas de actividad sospechosa
+-- Eventos append-only; client_event_id hace idempotentes los reenvíos del cliente
+CREATE TABLE IF NOT EXISTS reading_events (
+    id               BIGSERIAL PRIMARY KEY,
+    client_event_id  VARCHAR(64) NOT NULL,
+    user_id          UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    school_id        UUID REFERENCES schools(id) ON DELETE SET NULL,
+    material_id      UUID NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    kind             VARCHAR(16) NOT NULL CHECK (kind IN ('page', 'section', 'video', 'reset')),
+    page             INTEGER CHECK (page >= 1),
+    section_id       VARCHAR(128),
+    position_seconds INTEGER NOT NULL DEFAULT 0 CHECK (position_seconds >= 0),
+    dwell_seconds    INTEGER NOT NULL DEFAULT 0 CHECK (dwell_seconds >= 0),
+    occurred_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT reading_events_user_client_event_key UNIQUE (user_id, client_event_id)
+);
+
+-- Cobertura de un usuario en un material desde el último reset (GetReadingCoverage)
+CREATE INDEX IF NOT EXISTS idx_reading_events_user_material
+    ON reading_events (user_id, material_id, id);
+
+-- Tamaño del contenido por usuario: lo reporta el cliente y nunca se comparte entre usuarios
+-- Cada campo solo crece (GREATEST); 0 = desconocido
+CREATE TABLE IF NOT EXISTS material_reading_extent (
+    user_id          UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    material_id      UUID NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    total_pages      INTEGER NOT NULL DEFAULT 0 CHECK (total_pages >= 0),
+    total_sections   INTEGER NOT NULL DEFAULT 0 CHECK (total_sections >= 0),
+    duration_seconds INTEGER NOT NULL DEFAULT 0 CHECK (duration_seconds >= 0),
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, material_id)
+);
+
+-- Marcas para revisión docente; no invalidan el progreso
+CREATE TABLE IF NOT EXISTS reading_flags (
+    id                   BIGSERIAL PRIMARY KEY,
+    user_id              UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    material_id          UUID NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    school_id            UUID REFERENCES schools(id) ON DELETE SET NULL,
+    reason               VARCHAR(32) NOT NULL,
+    from_percentage      INTEGER NOT NULL CHECK (from_percentage >= 0 AND from_percentage <= 100),
+    to_percentage        INTEGER NOT NULL CHECK (to_percentage >= 0 AND to_percentage <= 100),
+    time_on_task_seconds INTEGER NOT NULL DEFAULT 0,
+    flagged_at           TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Revisión por escuela, más recientes primero
+CREATE INDEX IF NOT EXISTS idx_reading_flags_school_flagged
+    ON reading_flags (school_id, flagged_at);
+
+CREATE INDEX IF NOT EXISTS idx_reading_flags_user_material
+    ON reading_flags (user_id, material_id);
@@ -0,0 +1,18 @@
+-- Operaciones de sincronización offline y feed de cambios de progreso
+-- Un registro por ítem del cliente: los reenvíos repiten el resultado sin volver a aplicarlo
+CREATE TABLE IF NOT EXISTS sync_operations (
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id     VARCHAR(64) NOT NULL,
+    type          VARCHAR(32) NOT NULL,
+    status        VARCHAR(16) NOT NULL CHECK (status IN ('pending', 'applied', 'rejected')),
+    result        JSONB,
+    error_code    VARCHAR(64),
+    error_message TEXT,
+    claimed_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at  TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (user_id, client_id)
+);
+
+-- Feed de cambios por usuario (ListChangedSince pagina por updated_at, material_id)
+CREATE INDEX IF NOT EXISTS idx_progress_user_updated
+    ON progress (user_id, updated_at, material_id);
@@ -0,0 +1,42 @@
+-- Rutas de aprendizaje, sus pasos y requisitos de desbloqueo
+CREATE TABLE IF NOT EXISTS learning_paths (
+    id               UUID PRIMARY KEY,
+    school_id        UUID NOT NULL REFERENCES schools(id) ON DELETE CASCADE,
+    academic_unit_id UUID REFERENCES academic_units(id) ON DELETE SET NULL,
+    title            VARCHAR(200) NOT NULL,
+    description      TEXT,
+    created_by       UUID NOT NULL REFERENCES users(id),
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Listado por escuela ordenado por título (ListBySchool)
+CREATE INDEX IF NOT EXISTS idx_learning_paths_school_title
+    ON learning_paths (school_id, title, id);
+
+-- Un material aparece a lo sumo una vez por ruta; los pasos se eliminan con la ruta
+CREATE TABLE IF NOT EXISTS learning_path_steps (
+    path_id     UUID NOT NULL REFERENCES learning_paths(id) ON DELETE CASCADE,
+    position    INTEGER NOT NULL CHECK (position >= 1),
+    material_id UUID NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    min_score   INTEGER CHECK (min_score >= 0 AND min_score <= 100),
+    PRIMARY KEY (path_id, position),
+    CONSTRAINT learning_path_steps_path_material_key UNIQUE (path_id, material_id)
+);
+
+-- Rutas que contienen un material (ListByMaterial y bloqueo de contenido)
+CREATE INDEX IF NOT EXISTS idx_learning_path_steps_material
+    ON learning_path_steps (material_id);
+
+-- Requisitos de un paso; se eliminan en cascada con el paso y con el paso requerido
+CREATE TABLE IF NOT EXISTS learning_path_step_prerequisites (
+    path_id                  UUID NOT NULL,
+    material_id              UUID NOT NULL,
+    prerequisite_material_id UUID NOT NULL,
+    PRIMARY KEY (path_id, material_id, prerequisite_material_id),
+    CHECK (material_id <> prerequisite_material_id),
+    FOREIGN KEY (path_id, material_id)
+        REFERENCES learning_path_steps (path_id, material_id) ON DELETE CASCADE,
+    FOREIGN KEY (path_id, prerequisite_material_id)
+        REFERENCES learning_path_steps (path_id, material_id) ON DELETE CASCADE
+);
@@ -0,0 +1,38 @@
+-- Cola de repaso espaciado (SM-2) e historial de calificaciones
+-- Un mismo origen (pregunta fallada o término de glosario) se encola una sola vez por usuario
+CREATE TABLE IF NOT EXISTS review_items (
+    id               UUID PRIMARY KEY,
+    user_id          UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    material_id      UUID NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    kind             VARCHAR(16) NOT NULL CHECK (kind IN ('question', 'glossary')),
+    source_key       VARCHAR(255) NOT NULL,
+    prompt           TEXT NOT NULL,
+    answer           TEXT NOT NULL,
+    options          JSONB NOT NULL DEFAULT '[]',
+    explanation      TEXT,
+    ease_factor      DOUBLE PRECISION NOT NULL DEFAULT 2.5 CHECK (ease_factor >= 1.3),
+    interval_days    INTEGER NOT NULL DEFAULT 0 CHECK (interval_days >= 0),
+    repetitions      INTEGER NOT NULL DEFAULT 0 CHECK (repetitions >= 0),
+    lapses           INTEGER NOT NULL DEFAULT 0 CHECK (lapses >= 0),
+    due_at           TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_reviewed_at TIMESTAMP WITH TIME ZONE,
+    -- Hora del evento que originó el ítem; protege los repasos de la reentrega de eventos
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT review_items_user_kind_source_key UNIQUE (user_id, kind, source_key)
+);
+
+-- Cola de vencidos por usuario, del más atrasado al más reciente (ListDueReviewItems)
+CREATE INDEX IF NOT EXISTS idx_review_items_user_due
+    ON review_items (user_id, due_at, id);
+
+-- Historial append-only de calificaciones; se elimina con el ítem
+CREATE TABLE IF NOT EXISTS review_logs (
+    id          BIGSERIAL PRIMARY KEY,
+    item_id     UUID NOT NULL REFERENCES review_items(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grade       SMALLINT NOT NULL CHECK (grade BETWEEN 0 AND 5),
+    reviewed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_logs_item_reviewed
+    ON review_logs (item_id, reviewed_at);
@@ -0,0 +1,15 @@
+-- Historial de preferencias de pantalla por usuario
+-- Cada guardado o reset archiva la versión reemplazada; se conservan las últimas
+-- ScreenPreferencesHistoryLimit por usuario y pantalla
+CREATE TABLE IF NOT EXISTS ui_config.screen_user_preference_history (
+    id                 UUID PRIMARY KEY,
+    screen_instance_id UUID NOT NULL REFERENCES ui_config.screen_instances(id) ON DELETE CASCADE,
+    user_id            UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    preferences        JSONB NOT NULL,
+    saved_at           TIMESTAMP WITH TIME ZONE NOT NULL,
+    replaced_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Versiones de un usuario en una pantalla, más recientes primero (historial y poda)
+CREATE INDEX IF NOT EXISTS idx_screen_user_preference_history_instance_user
+    ON ui_config.screen_user_preference_history (screen_instance_id, user_id, replaced_at DESC);
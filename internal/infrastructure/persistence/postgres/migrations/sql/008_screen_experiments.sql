@@ -0,0 +1,33 @@
+-- Experimentos A/B de pantallas y sus variantes
+-- api-admin administra el contenido; api-mobile solo lee los experimentos activos y vigentes
+CREATE TABLE IF NOT EXISTS ui_config.screen_experiments (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key        VARCHAR(100) NOT NULL UNIQUE,
+    screen_key VARCHAR(100) NOT NULL,
+    priority   INTEGER NOT NULL DEFAULT 0,
+    platforms  TEXT[] NOT NULL DEFAULT '{}',
+    school_ids TEXT[] NOT NULL DEFAULT '{}',
+    roles      TEXT[] NOT NULL DEFAULT '{}',
+    starts_at  TIMESTAMP WITH TIME ZONE,
+    ends_at    TIMESTAMP WITH TIME ZONE,
+    is_active  BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+-- Experimentos activos en orden de evaluación (el primero cuyo targeting aplica gana)
+CREATE INDEX IF NOT EXISTS idx_screen_experiments_active_priority
+    ON ui_config.screen_experiments (priority, key)
+    WHERE is_active = true;
+
+-- Variantes con su peso de tráfico; screen_key NULL sirve la pantalla original (control)
+CREATE TABLE IF NOT EXISTS ui_config.screen_experiment_variants (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    experiment_id UUID NOT NULL REFERENCES ui_config.screen_experiments(id) ON DELETE CASCADE,
+    key           VARCHAR(100) NOT NULL,
+    weight        INTEGER NOT NULL CHECK (weight >= 0),
+    screen_key    VARCHAR(100),
+    sort_order    INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (experiment_id, key)
+);
@@ -0,0 +1,22 @@
+-- Traducciones de textos de pantallas y etiquetas de menú
+-- api-admin administra el contenido; api-mobile las aplica por locale al resolver pantallas y navegación
+CREATE TABLE IF NOT EXISTS ui_config.screen_slot_translations (
+    screen_key VARCHAR(100) NOT NULL,
+    slot_key   VARCHAR(100) NOT NULL,
+    locale     VARCHAR(10) NOT NULL,
+    value      TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (screen_key, slot_key, locale)
+);
+
+CREATE TABLE IF NOT EXISTS ui_config.resource_translations (
+    resource_key VARCHAR(100) NOT NULL,
+    locale       VARCHAR(10) NOT NULL,
+    display_name VARCHAR(200) NOT NULL,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (resource_key, locale)
+);
+
+-- Todas las etiquetas de menú de un locale
+CREATE INDEX IF NOT EXISTS idx_resource_translations_locale
+    ON ui_config.resource_translations (locale);
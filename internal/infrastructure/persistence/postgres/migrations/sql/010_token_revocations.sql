@@ -0,0 +1,15 @@
+-- Revocaciones de access tokens: logout (por jti) y "cerrar todas las sesiones" (por usuario)
+-- api-admin registra las filas; api-mobile las sondea para invalidar sus validaciones cacheadas
+CREATE TABLE IF NOT EXISTS token_revocations (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    jti           VARCHAR(100),
+    user_id       UUID REFERENCES users(id) ON DELETE CASCADE,
+    issued_before TIMESTAMP WITH TIME ZONE,
+    expires_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (jti IS NOT NULL OR (user_id IS NOT NULL AND issued_before IS NOT NULL))
+);
+
+-- Sondeo incremental de revocaciones no vencidas
+CREATE INDEX IF NOT EXISTS idx_token_revocations_revoked_at
+    ON token_revocations (revoked_at);
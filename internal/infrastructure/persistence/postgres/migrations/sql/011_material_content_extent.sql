@@ -0,0 +1,13 @@
+-- Tamaño del contenido de cada material, usado para derivar el porcentaje de lectura
+-- Lo escribe el procesamiento del material (edugo-worker): páginas del PDF o presentación,
+-- secciones del contenido estructurado y duración del video. 0 = desconocido
+CREATE TABLE IF NOT EXISTS material_content_extent (
+    material_id      UUID PRIMARY KEY REFERENCES materials(id) ON DELETE CASCADE,
+    total_pages      INTEGER NOT NULL DEFAULT 0 CHECK (total_pages >= 0),
+    total_sections   INTEGER NOT NULL DEFAULT 0 CHECK (total_sections >= 0),
+    duration_seconds INTEGER NOT NULL DEFAULT 0 CHECK (duration_seconds >= 0),
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Reemplazada por material_content_extent: el tamaño reportado por el cliente no es confiable
+DROP TABLE IF EXISTS material_reading_extent;
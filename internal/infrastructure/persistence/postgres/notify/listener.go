@@ -0,0 +1,125 @@
+// Package notify escucha notificaciones de PostgreSQL (LISTEN/NOTIFY)
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/lib/pq"
+)
+
+const (
+	minReconnectInterval = 1 * time.Second
+	maxReconnectInterval = 30 * time.Second
+
+	// pingInterval verifica periódicamente la conexión: sin tráfico, una caída no se detecta
+	pingInterval = 90 * time.Second
+)
+
+// Handler procesa el payload de una notificación
+type Handler func(ctx context.Context, payload string) error
+
+// Listener escucha un canal de NOTIFY con una conexión dedicada (fuera del pool de database/sql)
+// pq.Listener reconecta solo; al reconectar se invoca onReconnect porque las notificaciones
+// emitidas mientras no había conexión se perdieron
+type Listener struct {
+	channel     string
+	listener    *pq.Listener
+	handler     Handler
+	onReconnect func()
+	logger      logger.Logger
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewListener crea el listener y se suscribe al canal; Start inicia la entrega de notificaciones
+func NewListener(connString, channel string, handler Handler, onReconnect func(), log logger.Logger) (*Listener, error) {
+	l := &Listener{
+		channel:     channel,
+		handler:     handler,
+		onReconnect: onReconnect,
+		logger:      log,
+		done:        make(chan struct{}),
+	}
+
+	l.listener = pq.NewListener(connString, minReconnectInterval, maxReconnectInterval, l.onEvent)
+	if err := l.listener.Listen(channel); err != nil {
+		_ = l.listener.Close() // Ignorar error en cleanup
+		return nil, fmt.Errorf("postgres: error listening on channel %s: %w", channel, err)
+	}
+	return l, nil
+}
+
+// Start inicia la entrega de notificaciones en segundo plano
+func (l *Listener) Start() {
+	go l.run()
+}
+
+// Close detiene el listener y cierra su conexión
+func (l *Listener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		close(l.done)
+		err = l.listener.Close()
+	})
+	return err
+}
+
+func (l *Listener) run() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case notification, ok := <-l.listener.Notify:
+			if !ok {
+				return
+			}
+			// pq envía nil tras reconectar
+			if notification == nil {
+				if l.onReconnect != nil {
+					l.onReconnect()
+				}
+				continue
+			}
+			l.deliver(notification.Extra)
+		case <-ticker.C:
+			if err := l.listener.Ping(); err != nil {
+				l.logger.Warn("postgres listener ping failed", "channel", l.channel, "error", err)
+			}
+		}
+	}
+}
+
+// deliver invoca el handler aislando errores y panics del loop
+func (l *Listener) deliver(payload string) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.logger.Error("postgres listener handler panicked", "channel", l.channel, "panic", fmt.Sprint(r))
+		}
+	}()
+
+	if err := l.handler(context.Background(), payload); err != nil {
+		l.logger.Warn("postgres listener handler failed", "channel", l.channel, "error", err)
+	}
+}
+
+// onEvent registra los cambios de estado de la conexión dedicada
+func (l *Listener) onEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventConnected:
+		l.logger.Info("postgres listener connected", "channel", l.channel)
+	case pq.ListenerEventDisconnected:
+		l.logger.Warn("postgres listener disconnected", "channel", l.channel, "error", err)
+	case pq.ListenerEventReconnected:
+		l.logger.Info("postgres listener reconnected", "channel", l.channel)
+	case pq.ListenerEventConnectionAttemptFailed:
+		l.logger.Warn("postgres listener connection attempt failed", "channel", l.channel, "error", err)
+	}
+}
@@ -223,6 +223,57 @@ func (r *PostgresAnswerRepository) FindByQuestionID(ctx context.Context, questio
 	return answers, nil
 }
 
+// FindItemResponses obtiene las respuestas del primer intento completado de cada estudiante
+// Solo se considera un intento por estudiante para que los reintentos no sesguen los índices
+func (r *PostgresAnswerRepository) FindItemResponses(ctx context.Context, assessmentID uuid.UUID) ([]*repositories.ItemResponse, error) {
+	query := `
+		WITH first_attempts AS (
+			SELECT DISTINCT ON (student_id) id, student_id, COALESCE(score, 0) AS score
+			FROM assessment_attempt
+			WHERE assessment_id = $1 AND completed_at IS NOT NULL
+			ORDER BY student_id, completed_at ASC
+		)
+		SELECT fa.id, fa.student_id, fa.score, ans.question_index,
+		       COALESCE(ans.student_answer, ''), COALESCE(ans.is_correct, false), ans.time_spent_seconds
+		FROM first_attempts fa
+		JOIN assessment_attempt_answer ans ON ans.attempt_id = fa.id
+		ORDER BY ans.question_index, fa.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, assessmentID.String())
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error finding item responses: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	responses := make([]*repositories.ItemResponse, 0)
+	for rows.Next() {
+		var (
+			attemptIDStr string
+			studentIDStr string
+			response     repositories.ItemResponse
+		)
+
+		err := rows.Scan(
+			&attemptIDStr, &studentIDStr, &response.AttemptScore, &response.QuestionIndex,
+			&response.StudentAnswer, &response.IsCorrect, &response.TimeSpentSeconds,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: error scanning item response: %w", err)
+		}
+
+		response.AttemptID, _ = uuid.Parse(attemptIDStr)
+		response.StudentID, _ = uuid.Parse(studentIDStr)
+		responses = append(responses, &response)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating item responses: %w", err)
+	}
+
+	return responses, nil
+}
+
 // GetQuestionDifficultyStats Analytics: GetQuestionDifficultyStats obtiene estadísticas de dificultad de una pregunta
 // Esta es una función helper para analytics (no en la interfaz del repositorio)
 func (r *PostgresAnswerRepository) GetQuestionDifficultyStats(ctx context.Context, questionID string) (totalAnswers int, correctAnswers int, errorRate float64, err error) {
@@ -433,6 +433,69 @@ func (r *PostgresAttemptRepository) CalculateAverageScore(ctx context.Context) (
 	return avgScore, nil
 }
 
+// GetMaterialAttemptStats agrega los intentos completados de la evaluación de un material
+// Implementa repositories.AssessmentStats para estadísticas por material
+// Un estudiante aprueba si alguno de sus intentos alcanza el pass_threshold (default 60)
+func (r *PostgresAttemptRepository) GetMaterialAttemptStats(ctx context.Context, materialID uuid.UUID) (*repositories.MaterialAttemptStats, error) {
+	summaryQuery := `
+		SELECT COUNT(*),
+		       COUNT(DISTINCT at.student_id),
+		       COUNT(DISTINCT at.student_id) FILTER (WHERE at.score >= COALESCE(a.pass_threshold, $2)),
+		       COALESCE(AVG(at.score), 0.0),
+		       COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY at.time_spent_seconds), 0.0)
+		FROM assessment_attempt at
+		JOIN assessment a ON a.id = at.assessment_id
+		WHERE a.material_id = $1 AND at.completed_at IS NOT NULL
+	`
+
+	stats := &repositories.MaterialAttemptStats{}
+	err := r.db.QueryRowContext(ctx, summaryQuery, materialID.String(), repositories.DefaultPassThreshold).Scan(
+		&stats.TotalAttempts,
+		&stats.UniqueStudents,
+		&stats.PassedStudents,
+		&stats.AvgScore,
+		&stats.MedianTimeSpentSeconds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error calculating material attempt stats: %w", err)
+	}
+
+	if stats.TotalAttempts == 0 {
+		return stats, nil
+	}
+
+	// Histograma: rangos de 10 puntos, el puntaje 100 cuenta en el último rango
+	histogramQuery := `
+		SELECT LEAST(GREATEST(FLOOR(at.score / 10)::int, 0), $2) AS bucket, COUNT(*)
+		FROM assessment_attempt at
+		JOIN assessment a ON a.id = at.assessment_id
+		WHERE a.material_id = $1 AND at.completed_at IS NOT NULL AND at.score IS NOT NULL
+		GROUP BY bucket
+	`
+
+	rows, err := r.db.QueryContext(ctx, histogramQuery, materialID.String(), repositories.ScoreHistogramBuckets-1)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error calculating score histogram: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			bucket int
+			count  int64
+		)
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning score histogram: %w", err)
+		}
+		stats.ScoreHistogram[bucket] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating score histogram: %w", err)
+	}
+
+	return stats, nil
+}
+
 // FindByStudent busca todos los intentos de un estudiante (historial)
 func (r *PostgresAttemptRepository) FindByStudent(ctx context.Context, studentID uuid.UUID, limit, offset int) ([]*pgentities.AssessmentAttempt, error) {
 	query := `
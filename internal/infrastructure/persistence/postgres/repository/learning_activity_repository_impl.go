@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type postgresLearningActivityRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLearningActivityRepository crea el registro de actividad sobre PostgreSQL
+// Las actualizaciones de progreso se guardan en learning_activity (append-only);
+// los intentos se leen directamente de assessment_attempt
+func NewPostgresLearningActivityRepository(db *sql.DB) repository.LearningActivityRepository {
+	return &postgresLearningActivityRepository{db: db}
+}
+
+func (r *postgresLearningActivityRepository) RecordProgressActivity(ctx context.Context, activity *repository.ProgressActivity) error {
+	// event_id es UNIQUE: re-entregas y replays del dead-letter no duplican actividad
+	query := `
+		INSERT INTO learning_activity (event_id, user_id, school_id, material_id, percentage, occurred_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6)
+		ON CONFLICT (event_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		activity.EventID,
+		activity.UserID,
+		activity.SchoolID,
+		activity.MaterialID,
+		activity.Percentage,
+		activity.OccurredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: error recording learning activity: %w", err)
+	}
+	return nil
+}
+
+// GetDailyActivity une progreso e intentos y agrupa por día local
+// El tiempo de estudio de una actualización de progreso es la pausa desde la anterior del mismo usuario,
+// si no supera ActivitySessionGap; la ventana se extiende hacia atrás para calcular la primera pausa
+func (r *postgresLearningActivityRepository) GetDailyActivity(ctx context.Context, q repository.ActivityQuery) ([]repository.DailyActivity, error) {
+	query := `
+		WITH progress_events AS (
+			SELECT la.user_id, la.material_id, la.occurred_at,
+			       EXTRACT(EPOCH FROM la.occurred_at - LAG(la.occurred_at) OVER (
+			           PARTITION BY la.user_id ORDER BY la.occurred_at
+			       )) AS gap_seconds
+			FROM learning_activity la
+			WHERE la.occurred_at >= $1::timestamptz - make_interval(secs => $4)
+			  AND la.occurred_at < $2
+			  AND ($5 = '' OR la.user_id::text = $5)
+			  AND ($6 = '' OR la.school_id::text = $6)
+		),
+		events AS (
+			SELECT user_id, material_id, occurred_at,
+			       CASE WHEN gap_seconds <= $4 THEN gap_seconds ELSE 0 END AS study_seconds,
+			       0 AS attempts
+			FROM progress_events
+			WHERE occurred_at >= $1
+			UNION ALL
+			SELECT at.student_id, a.material_id, at.completed_at,
+			       COALESCE(at.time_spent_seconds, 0), 1
+			FROM assessment_attempt at
+			JOIN assessment a ON a.id = at.assessment_id
+			JOIN materials m ON m.id = a.material_id
+			WHERE at.completed_at >= $1 AND at.completed_at < $2
+			  AND ($5 = '' OR at.student_id::text = $5)
+			  AND ($6 = '' OR m.school_id::text = $6)
+		)
+		SELECT to_char((occurred_at AT TIME ZONE $3)::date, 'YYYY-MM-DD') AS day,
+		       COALESCE(SUM(study_seconds), 0)::bigint,
+		       COUNT(DISTINCT material_id),
+		       SUM(attempts),
+		       COUNT(DISTINCT user_id)
+		FROM events
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		q.From,
+		q.To,
+		locationName(q.Location),
+		repository.ActivitySessionGap.Seconds(),
+		q.UserID,
+		q.SchoolID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error querying daily activity: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	days := make([]repository.DailyActivity, 0)
+	for rows.Next() {
+		var day repository.DailyActivity
+		if err := rows.Scan(&day.Day, &day.StudySeconds, &day.MaterialsTouched, &day.Attempts, &day.ActiveUsers); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning daily activity: %w", err)
+		}
+		days = append(days, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating daily activity: %w", err)
+	}
+	return days, nil
+}
+
+func (r *postgresLearningActivityRepository) ListActiveDays(ctx context.Context, userID string, location *time.Location) ([]string, error) {
+	query := `
+		SELECT DISTINCT to_char((occurred_at AT TIME ZONE $2)::date, 'YYYY-MM-DD') AS day
+		FROM (
+			SELECT occurred_at FROM learning_activity WHERE user_id = $1
+			UNION ALL
+			SELECT completed_at FROM assessment_attempt WHERE student_id = $1 AND completed_at IS NOT NULL
+		) activity
+		ORDER BY day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, locationName(location))
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error listing active days: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	days := make([]string, 0)
+	for rows.Next() {
+		var day string
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning active day: %w", err)
+		}
+		days = append(days, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating active days: %w", err)
+	}
+	return days, nil
+}
+
+// locationName retorna el nombre IANA que entiende AT TIME ZONE (UTC si no hay zona)
+func locationName(location *time.Location) string {
+	if location == nil {
+		return "UTC"
+	}
+	return location.String()
+}
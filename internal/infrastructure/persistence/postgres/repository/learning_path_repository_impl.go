@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type postgresLearningPathRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLearningPathRepository crea el repositorio de rutas de aprendizaje
+// Tablas: learning_paths, learning_path_steps (PK path_id, position; UNIQUE path_id, material_id)
+// y learning_path_step_prerequisites; pasos y requisitos se eliminan en cascada con la ruta
+func NewPostgresLearningPathRepository(db *sql.DB) repository.LearningPathRepository {
+	return &postgresLearningPathRepository{db: db}
+}
+
+const learningPathColumns = `id, school_id, academic_unit_id, title, COALESCE(description, ''), created_by, created_at, updated_at`
+
+func (r *postgresLearningPathRepository) FindByID(ctx context.Context, id string) (*repository.LearningPath, error) {
+	paths, err := r.queryPaths(ctx, `SELECT `+learningPathColumns+` FROM learning_paths WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	return paths[0], nil
+}
+
+func (r *postgresLearningPathRepository) ListBySchool(ctx context.Context, schoolID string) ([]*repository.LearningPath, error) {
+	return r.queryPaths(ctx, `SELECT `+learningPathColumns+` FROM learning_paths WHERE school_id = $1 ORDER BY title, id`, schoolID)
+}
+
+func (r *postgresLearningPathRepository) ListByMaterial(ctx context.Context, materialID string) ([]*repository.LearningPath, error) {
+	query := `
+		SELECT ` + learningPathColumns + `
+		FROM learning_paths
+		WHERE id IN (SELECT path_id FROM learning_path_steps WHERE material_id = $1)
+		ORDER BY title, id
+	`
+	return r.queryPaths(ctx, query, materialID)
+}
+
+func (r *postgresLearningPathRepository) GetStepResults(ctx context.Context, userID string, materialIDs []string) ([]repository.LearningPathStepResult, error) {
+	if len(materialIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT m.material_id, COALESCE(p.percentage, 0), best.best_score
+		FROM unnest($2::uuid[]) AS m(material_id)
+		LEFT JOIN progress p ON p.material_id = m.material_id AND p.user_id = $1
+		LEFT JOIN LATERAL (
+			SELECT MAX(at.score) AS best_score
+			FROM assessment a
+			JOIN assessment_attempt at ON at.assessment_id = a.id
+			WHERE a.material_id = m.material_id
+			  AND at.student_id = $1
+			  AND at.completed_at IS NOT NULL
+		) best ON true
+		WHERE p.material_id IS NOT NULL OR best.best_score IS NOT NULL
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(materialIDs))
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error getting learning path step results: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := make([]repository.LearningPathStepResult, 0, len(materialIDs))
+	for rows.Next() {
+		var (
+			result    repository.LearningPathStepResult
+			bestScore sql.NullFloat64
+		)
+		if err := rows.Scan(&result.MaterialID, &result.Percentage, &bestScore); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning learning path step result: %w", err)
+		}
+		if bestScore.Valid {
+			score := bestScore.Float64
+			result.BestScore = &score
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating learning path step results: %w", err)
+	}
+	return results, nil
+}
+
+func (r *postgresLearningPathRepository) Create(ctx context.Context, path *repository.LearningPath) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("postgres: error beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Ignorar error si ya se hizo Commit
+
+	query := `
+		INSERT INTO learning_paths (id, school_id, academic_unit_id, title, description, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		path.ID, path.SchoolID, path.AcademicUnitID, path.Title, path.Description, path.CreatedBy, path.CreatedAt, path.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: error creating learning path: %w", err)
+	}
+
+	if err := insertLearningPathSteps(ctx, tx, path); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("postgres: error committing learning path: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresLearningPathRepository) Update(ctx context.Context, path *repository.LearningPath) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("postgres: error beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Ignorar error si ya se hizo Commit
+
+	query := `
+		UPDATE learning_paths
+		SET academic_unit_id = $2, title = $3, description = NULLIF($4, ''), updated_at = $5
+		WHERE id = $1
+	`
+	_, err = tx.ExecContext(ctx, query, path.ID, path.AcademicUnitID, path.Title, path.Description, path.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("postgres: error updating learning path: %w", err)
+	}
+
+	// Los requisitos se eliminan en cascada con los pasos
+	if _, err := tx.ExecContext(ctx, `DELETE FROM learning_path_steps WHERE path_id = $1`, path.ID); err != nil {
+		return fmt.Errorf("postgres: error replacing learning path steps: %w", err)
+	}
+	if err := insertLearningPathSteps(ctx, tx, path); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("postgres: error committing learning path: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresLearningPathRepository) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM learning_paths WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("postgres: error deleting learning path: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("postgres: error deleting learning path: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func insertLearningPathSteps(ctx context.Context, tx *sql.Tx, path *repository.LearningPath) error {
+	stepQuery := `INSERT INTO learning_path_steps (path_id, position, material_id, min_score) VALUES ($1, $2, $3, $4)`
+	prereqQuery := `
+		INSERT INTO learning_path_step_prerequisites (path_id, material_id, prerequisite_material_id)
+		VALUES ($1, $2, $3)
+	`
+
+	for _, step := range path.Steps {
+		if _, err := tx.ExecContext(ctx, stepQuery, path.ID, step.Position, step.MaterialID, step.MinScore); err != nil {
+			return fmt.Errorf("postgres: error inserting learning path step: %w", err)
+		}
+		for _, prereq := range step.Prerequisites {
+			if _, err := tx.ExecContext(ctx, prereqQuery, path.ID, step.MaterialID, prereq); err != nil {
+				return fmt.Errorf("postgres: error inserting learning path prerequisite: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// queryPaths ejecuta una consulta sobre learning_paths y carga los pasos de cada ruta
+func (r *postgresLearningPathRepository) queryPaths(ctx context.Context, query string, args ...interface{}) ([]*repository.LearningPath, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error listing learning paths: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	paths := make([]*repository.LearningPath, 0)
+	byID := make(map[string]*repository.LearningPath)
+	for rows.Next() {
+		var (
+			path   repository.LearningPath
+			unitID sql.NullString
+		)
+		err := rows.Scan(&path.ID, &path.SchoolID, &unitID, &path.Title, &path.Description, &path.CreatedBy, &path.CreatedAt, &path.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: error scanning learning path: %w", err)
+		}
+		if unitID.Valid {
+			path.AcademicUnitID = &unitID.String
+		}
+		paths = append(paths, &path)
+		byID[path.ID] = &path
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating learning paths: %w", err)
+	}
+
+	if len(paths) == 0 {
+		return paths, nil
+	}
+	if err := r.loadSteps(ctx, byID); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// loadSteps completa los pasos (con título del material) y requisitos de las rutas indicadas
+func (r *postgresLearningPathRepository) loadSteps(ctx context.Context, byID map[string]*repository.LearningPath) error {
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+
+	stepQuery := `
+		SELECT s.path_id, s.position, s.material_id, COALESCE(m.title, ''), s.min_score,
+		       COALESCE(ARRAY(
+		           SELECT pr.prerequisite_material_id::text
+		           FROM learning_path_step_prerequisites pr
+		           WHERE pr.path_id = s.path_id AND pr.material_id = s.material_id
+		           ORDER BY pr.prerequisite_material_id
+		       ), '{}')
+		FROM learning_path_steps s
+		LEFT JOIN materials m ON m.id = s.material_id
+		WHERE s.path_id = ANY($1::uuid[])
+		ORDER BY s.path_id, s.position
+	`
+
+	rows, err := r.db.QueryContext(ctx, stepQuery, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("postgres: error listing learning path steps: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			pathID   string
+			step     repository.LearningPathStep
+			minScore sql.NullInt64
+			prereqs  pq.StringArray
+		)
+		if err := rows.Scan(&pathID, &step.Position, &step.MaterialID, &step.MaterialTitle, &minScore, &prereqs); err != nil {
+			return fmt.Errorf("postgres: error scanning learning path step: %w", err)
+		}
+		if minScore.Valid {
+			score := int(minScore.Int64)
+			step.MinScore = &score
+		}
+		step.Prerequisites = []string(prereqs)
+		if path, ok := byID[pathID]; ok {
+			path.Steps = append(path.Steps, step)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("postgres: error iterating learning path steps: %w", err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repositories"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/valueobject"
 	pgentities "github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
@@ -102,7 +103,11 @@ func (r *postgresProgressRepository) Update(ctx context.Context, progress *pgent
 
 // Upsert implementa operación idempotente INSERT o UPDATE usando ON CONFLICT de PostgreSQL.
 // Si el registro (material_id, user_id) existe, se actualiza; si no existe, se inserta.
-// Cuando percentage = 100, se establece status = 'completed' automáticamente.
+// El porcentaje es monótono: si el registrado es mayor se conservan porcentaje y status
+// (reportes atrasados u offline no retroceden el progreso; para volver a 0 se usa Reset).
+// last_page y last_accessed_at siguen last-writer-wins por last_accessed_at, que puede venir
+// del cliente en sincronizaciones offline. updated_at es NOW() de la base: ordena el feed de
+// ListChangedSince con un solo reloj para todas las instancias.
 // Retorna la entidad Progress actualizada.
 func (r *postgresProgressRepository) Upsert(ctx context.Context, progress *pgentities.Progress) (*pgentities.Progress, error) {
 	// Query UPSERT usando ON CONFLICT de PostgreSQL
@@ -112,14 +117,16 @@ func (r *postgresProgressRepository) Upsert(ctx context.Context, progress *pgent
 			material_id, user_id, percentage, last_page, status,
 			last_accessed_at, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
 		ON CONFLICT (material_id, user_id)
 		DO UPDATE SET
-			percentage = EXCLUDED.percentage,
-			last_page = EXCLUDED.last_page,
-			status = EXCLUDED.status,
-			last_accessed_at = EXCLUDED.last_accessed_at,
-			updated_at = EXCLUDED.updated_at
+			percentage = GREATEST(progress.percentage, EXCLUDED.percentage),
+			last_page = CASE WHEN EXCLUDED.last_accessed_at >= progress.last_accessed_at
+			                 THEN EXCLUDED.last_page ELSE progress.last_page END,
+			status = CASE WHEN progress.percentage > EXCLUDED.percentage
+			              THEN progress.status ELSE EXCLUDED.status END,
+			last_accessed_at = GREATEST(progress.last_accessed_at, EXCLUDED.last_accessed_at),
+			updated_at = NOW()
 		RETURNING material_id, user_id, percentage, last_page, status,
 		          last_accessed_at, created_at, updated_at
 	`
@@ -144,7 +151,6 @@ func (r *postgresProgressRepository) Upsert(ctx context.Context, progress *pgent
 		progress.Status,
 		progress.LastAccessedAt,
 		progress.CreatedAt,
-		progress.UpdatedAt,
 	).Scan(
 		&matID, &uID, &percentage, &lastPage, &status,
 		&lastAccessedAt, &createdAt, &updatedAt,
@@ -167,6 +173,73 @@ func (r *postgresProgressRepository) Upsert(ctx context.Context, progress *pgent
 	}, nil
 }
 
+// ListChangedSince pagina el progreso del usuario por (updated_at, material_id)
+// Los cambios más recientes que ProgressChangeSafetyLag quedan para la próxima sincronización:
+// una transacción en curso con updated_at menor todavía puede confirmarse
+// Usa idx_progress_user_updated (user_id, updated_at, material_id)
+func (r *postgresProgressRepository) ListChangedSince(ctx context.Context, userID valueobject.UserID, after repository.ProgressCursor, limit int) ([]*pgentities.Progress, error) {
+	query := `
+		SELECT material_id, user_id, percentage, last_page, status, last_accessed_at, created_at, updated_at
+		FROM progress
+		WHERE user_id = $1
+		  AND (updated_at, material_id) > ($2, $3::uuid)
+		  AND updated_at < NOW() - make_interval(secs => $5)
+		ORDER BY updated_at, material_id
+		LIMIT $4
+	`
+
+	afterMaterial := after.MaterialID
+	if afterMaterial == "" {
+		afterMaterial = uuid.Nil.String()
+	}
+	rows, err := r.db.QueryContext(ctx, query,
+		userID.UUID(), after.UpdatedAt, afterMaterial, limit, repository.ProgressChangeSafetyLag.Seconds(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var changes []*pgentities.Progress
+	for rows.Next() {
+		p := &pgentities.Progress{}
+		if err := rows.Scan(
+			&p.MaterialID, &p.UserID, &p.Percentage, &p.LastPage, &p.Status,
+			&p.LastAccessedAt, &p.CreatedAt, &p.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		changes = append(changes, p)
+	}
+	return changes, rows.Err()
+}
+
+// Reset lleva el progreso a 0 y status not_started conservando created_at
+func (r *postgresProgressRepository) Reset(ctx context.Context, materialID valueobject.MaterialID, userID valueobject.UserID) (*pgentities.Progress, error) {
+	query := `
+		UPDATE progress
+		SET percentage = 0, last_page = 0, status = 'not_started',
+		    last_accessed_at = NOW(), updated_at = NOW()
+		WHERE material_id = $1 AND user_id = $2
+		RETURNING material_id, user_id, percentage, last_page, status,
+		          last_accessed_at, created_at, updated_at
+	`
+
+	progress := &pgentities.Progress{}
+	err := r.db.QueryRowContext(ctx, query, materialID.UUID(), userID.UUID()).Scan(
+		&progress.MaterialID, &progress.UserID, &progress.Percentage, &progress.LastPage, &progress.Status,
+		&progress.LastAccessedAt, &progress.CreatedAt, &progress.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
 // CountActiveUsers cuenta usuarios únicos con actividad reciente (últimos 30 días)
 // Usado para estadísticas globales del sistema
 func (r *postgresProgressRepository) CountActiveUsers(ctx context.Context) (int64, error) {
@@ -198,3 +271,100 @@ func (r *postgresProgressRepository) CalculateAverageProgress(ctx context.Contex
 
 	return avgProgress, nil
 }
+
+// GetMaterialProgressStats agrega el progreso de todos los usuarios en un material
+// Usado para estadísticas por material (GET /v1/materials/:id/stats)
+func (r *postgresProgressRepository) GetMaterialProgressStats(ctx context.Context, materialID valueobject.MaterialID) (*repository.MaterialProgressStats, error) {
+	query := `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE percentage = 100),
+		       COALESCE(AVG(percentage), 0)
+		FROM progress
+		WHERE material_id = $1
+	`
+
+	stats := &repository.MaterialProgressStats{}
+	err := r.db.QueryRowContext(ctx, query, materialID.String()).Scan(&stats.Learners, &stats.Completed, &stats.AvgProgress)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// ListByUser lista el progreso de un usuario uniendo materials y su mejor intento (assessment_attempt)
+// Los materiales eliminados se excluyen; el estado se deriva del porcentaje para no depender de la columna status
+func (r *postgresProgressRepository) ListByUser(ctx context.Context, userID valueobject.UserID, filter repository.UserProgressFilter) ([]*repository.UserMaterialProgress, int64, error) {
+	where := "p.user_id = $1 AND m.deleted_at IS NULL"
+	switch filter.Status {
+	case repository.UserProgressStatusInProgress:
+		where += " AND p.percentage < 100"
+	case repository.UserProgressStatusCompleted:
+		where += " AND p.percentage = 100"
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM progress p JOIN materials m ON m.id = p.material_id WHERE ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, userID.UUID()).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT p.material_id, m.title, p.percentage, p.last_page, p.status, p.last_accessed_at,
+		       best.attempts, best.best_score, best.pass_threshold
+		FROM progress p
+		JOIN materials m ON m.id = p.material_id
+		LEFT JOIN LATERAL (
+			SELECT COUNT(at.id) AS attempts,
+			       MAX(at.score) AS best_score,
+			       MAX(COALESCE(a.pass_threshold, $4)) AS pass_threshold
+			FROM assessment a
+			JOIN assessment_attempt at ON at.assessment_id = a.id
+			WHERE a.material_id = p.material_id
+			  AND at.student_id = p.user_id
+			  AND at.completed_at IS NOT NULL
+		) best ON true
+		WHERE ` + where + `
+		ORDER BY p.last_accessed_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID.UUID(), filter.Limit, filter.Offset, repositories.DefaultPassThreshold)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := make([]*repository.UserMaterialProgress, 0)
+	for rows.Next() {
+		var (
+			materialID    uuid.UUID
+			item          repository.UserMaterialProgress
+			bestScore     sql.NullFloat64
+			passThreshold sql.NullInt64
+		)
+
+		err := rows.Scan(
+			&materialID, &item.MaterialTitle, &item.Percentage, &item.LastPage, &item.Status, &item.LastAccessedAt,
+			&item.AttemptsCount, &bestScore, &passThreshold,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		item.MaterialID = materialID.String()
+		if bestScore.Valid {
+			score := bestScore.Float64
+			passed := score >= float64(passThreshold.Int64)
+			item.BestScore = &score
+			item.Passed = &passed
+		}
+		results = append(results, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// readingEventColumns columnas insertadas por evento en reading_events
+const readingEventColumns = 11
+
+type postgresReadingEventRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresReadingEventRepository crea el repositorio de eventos de lectura
+// reading_events es append-only (UNIQUE user_id, client_event_id); el tamaño de cada material
+// vive en material_content_extent (lo escribe el procesamiento del material) y las marcas de
+// actividad sospechosa en reading_flags
+func NewPostgresReadingEventRepository(db *sql.DB) repository.ReadingEventRepository {
+	return &postgresReadingEventRepository{db: db}
+}
+
+func (r *postgresReadingEventRepository) AppendReadingEvents(ctx context.Context, events []repository.ReadingEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	// Un solo INSERT multi-fila; los reenvíos del cliente se ignoran por ON CONFLICT
+	placeholders := make([]string, 0, len(events))
+	args := make([]interface{}, 0, len(events)*readingEventColumns)
+	for i, e := range events {
+		base := i * readingEventColumns
+		placeholders = append(placeholders, fmt.Sprintf(
+			"($%d, $%d, NULLIF($%d, '')::uuid, $%d, $%d, NULLIF($%d, 0), NULLIF($%d, ''), $%d, $%d, $%d, $%d)",
+			base+1, base+2, base+3, base+4, base+5, base+6, base+7, base+8, base+9, base+10, base+11,
+		))
+		args = append(args,
+			e.ClientEventID, e.UserID, e.SchoolID, e.MaterialID, e.Kind,
+			e.Page, e.SectionID, e.PositionSeconds, e.DwellSeconds, e.OccurredAt, e.ReceivedAt,
+		)
+	}
+
+	query := `
+		INSERT INTO reading_events (
+			client_event_id, user_id, school_id, material_id, kind,
+			page, section_id, position_seconds, dwell_seconds, occurred_at, created_at
+		)
+		VALUES ` + strings.Join(placeholders, ", ") + `
+		ON CONFLICT (user_id, client_event_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: error appending reading events: %w", err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("postgres: error reading affected rows: %w", err)
+	}
+	return int(inserted), nil
+}
+
+func (r *postgresReadingEventRepository) RecordReadingFlag(ctx context.Context, flag repository.ReadingFlag) error {
+	query := `
+		INSERT INTO reading_flags (
+			user_id, material_id, school_id, reason,
+			from_percentage, to_percentage, time_on_task_seconds, flagged_at
+		)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		flag.UserID, flag.MaterialID, flag.SchoolID, flag.Reason,
+		flag.FromPercentage, flag.ToPercentage, flag.TimeOnTaskSeconds, flag.FlaggedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: error recording reading flag: %w", err)
+	}
+	return nil
+}
+
+// GetReadingCoverage agrega los eventos posteriores al último reinicio
+// El orden se toma del id (orden de llegada) y no de occurred_at: un lote offline con
+// timestamps viejos que llega después de un reinicio cuenta para el nuevo recorrido.
+// La cobertura de video expande cada evento al intervalo [position - dwell, position)
+// en tramos de VideoCoverageBucketSeconds
+func (r *postgresReadingEventRepository) GetReadingCoverage(ctx context.Context, userID, materialID string) (*repository.ReadingCoverage, error) {
+	query := `
+		WITH last_reset AS (
+			SELECT COALESCE(MAX(id), 0) AS id
+			FROM reading_events
+			WHERE user_id = $1 AND material_id = $2 AND kind = $3
+		),
+		ev AS (
+			SELECT re.*
+			FROM reading_events re, last_reset lr
+			WHERE re.user_id = $1 AND re.material_id = $2
+			  AND re.id > lr.id AND re.kind <> $3
+		)
+		SELECT
+			(SELECT COUNT(DISTINCT page) FROM ev WHERE kind = $4),
+			(SELECT COUNT(DISTINCT section_id) FROM ev WHERE kind = $5),
+			(SELECT COUNT(DISTINCT bucket) * $7
+			 FROM ev, generate_series(GREATEST(position_seconds - dwell_seconds, 0) / $7,
+			                          (position_seconds - 1) / $7) AS bucket
+			 WHERE kind = $6 AND dwell_seconds > 0 AND position_seconds > 0),
+			(SELECT COALESCE(SUM(dwell_seconds), 0) FROM ev),
+			COALESCE((SELECT page FROM ev WHERE kind = $4 ORDER BY occurred_at DESC, id DESC LIMIT 1), 0)
+	`
+
+	coverage := &repository.ReadingCoverage{}
+	err := r.db.QueryRowContext(ctx, query,
+		userID, materialID, repository.ReadingEventReset,
+		repository.ReadingEventPage, repository.ReadingEventSection, repository.ReadingEventVideo,
+		repository.VideoCoverageBucketSeconds,
+	).Scan(
+		&coverage.PagesViewed,
+		&coverage.SectionsViewed,
+		&coverage.WatchedSeconds,
+		&coverage.TimeOnTaskSeconds,
+		&coverage.LastPage,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error getting reading coverage: %w", err)
+	}
+	return coverage, nil
+}
+
+func (r *postgresReadingEventRepository) GetMaterialExtent(ctx context.Context, materialID string) (*repository.ReadingExtent, error) {
+	query := `
+		SELECT total_pages, total_sections, duration_seconds
+		FROM material_content_extent
+		WHERE material_id = $1
+	`
+
+	extent := &repository.ReadingExtent{}
+	err := r.db.QueryRowContext(ctx, query, materialID).Scan(
+		&extent.TotalPages, &extent.TotalSections, &extent.DurationSeconds,
+	)
+	if err == sql.ErrNoRows {
+		// Material aún sin procesar: tamaño desconocido
+		return extent, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error getting material extent: %w", err)
+	}
+	return extent, nil
+}
+
+func (r *postgresReadingEventRepository) LastReadingEventReceivedAt(ctx context.Context, userID, materialID string) (*time.Time, error) {
+	query := `
+		SELECT MAX(created_at)
+		FROM reading_events
+		WHERE user_id = $1 AND material_id = $2
+	`
+
+	var receivedAt sql.NullTime
+	if err := r.db.QueryRowContext(ctx, query, userID, materialID).Scan(&receivedAt); err != nil {
+		return nil, fmt.Errorf("postgres: error getting last reading event: %w", err)
+	}
+	if !receivedAt.Valid {
+		return nil, nil
+	}
+	return &receivedAt.Time, nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type postgresReviewItemRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresReviewItemRepository crea el repositorio de la cola de repaso
+// review_items tiene UNIQUE (user_id, kind, source_key); review_logs guarda cada calificación
+func NewPostgresReviewItemRepository(db *sql.DB) repository.ReviewItemRepository {
+	return &postgresReviewItemRepository{db: db}
+}
+
+const reviewItemColumns = `
+	id, user_id, material_id, kind, source_key, prompt, answer, options, COALESCE(explanation, ''),
+	ease_factor, interval_days, repetitions, lapses, due_at, last_reviewed_at, created_at
+`
+
+func (r *postgresReviewItemRepository) EnqueueReviewItems(ctx context.Context, items []*repository.ReviewItem) (int, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: error beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Ignorar error si ya se hizo Commit
+
+	// Una pregunta fallada de nuevo vuelve a aprenderse; el UPDATE no aplica a glosario ni a
+	// preguntas repasadas después del evento (created_at es la hora del evento: una reentrega no
+	// deshace un repaso), por lo que esos casos no retornan fila. xmax = 0 distingue INSERT de UPDATE
+	query := `
+		INSERT INTO review_items (
+			id, user_id, material_id, kind, source_key, prompt, answer, options, explanation,
+			ease_factor, interval_days, repetitions, lapses, due_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (user_id, kind, source_key) DO UPDATE SET
+			repetitions = 0,
+			interval_days = 0,
+			due_at = LEAST(review_items.due_at, EXCLUDED.due_at)
+		WHERE review_items.kind = '` + repository.ReviewItemQuestion + `'
+		  AND (review_items.last_reviewed_at IS NULL OR review_items.last_reviewed_at < EXCLUDED.created_at)
+		RETURNING (xmax = 0)
+	`
+
+	inserted := 0
+	for _, item := range items {
+		options, err := json.Marshal(item.Options)
+		if err != nil {
+			return 0, fmt.Errorf("postgres: error encoding review options: %w", err)
+		}
+
+		var isInsert bool
+		err = tx.QueryRowContext(ctx, query,
+			item.ID, item.UserID, item.MaterialID, item.Kind, item.SourceKey, item.Prompt, item.Answer, options, item.Explanation,
+			item.EaseFactor, item.IntervalDays, item.Repetitions, item.Lapses, item.DueAt, item.CreatedAt,
+		).Scan(&isInsert)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("postgres: error enqueuing review item: %w", err)
+		}
+		if isInsert {
+			inserted++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("postgres: error committing review items: %w", err)
+	}
+	return inserted, nil
+}
+
+func (r *postgresReviewItemRepository) ListDueReviewItems(ctx context.Context, userID string, now time.Time, limit int) ([]*repository.ReviewItem, error) {
+	query := `
+		SELECT ` + reviewItemColumns + `
+		FROM review_items
+		WHERE user_id = $1 AND due_at <= $2
+		ORDER BY due_at, id
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error listing due review items: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	items := make([]*repository.ReviewItem, 0)
+	for rows.Next() {
+		item, err := scanReviewItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating review items: %w", err)
+	}
+	return items, nil
+}
+
+func (r *postgresReviewItemRepository) CountDueReviewItems(ctx context.Context, userID string, now time.Time) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM review_items WHERE user_id = $1 AND due_at <= $2`
+	if err := r.db.QueryRowContext(ctx, query, userID, now).Scan(&count); err != nil {
+		return 0, fmt.Errorf("postgres: error counting due review items: %w", err)
+	}
+	return count, nil
+}
+
+func (r *postgresReviewItemRepository) FindReviewItem(ctx context.Context, userID, itemID string) (*repository.ReviewItem, error) {
+	query := `SELECT ` + reviewItemColumns + ` FROM review_items WHERE id = $1 AND user_id = $2`
+
+	item, err := scanReviewItem(r.db.QueryRowContext(ctx, query, itemID, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (r *postgresReviewItemRepository) SaveReview(ctx context.Context, item *repository.ReviewItem, grade repository.ReviewGrade) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("postgres: error beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Ignorar error si ya se hizo Commit
+
+	update := `
+		UPDATE review_items
+		SET ease_factor = $3, interval_days = $4, repetitions = $5, lapses = $6, due_at = $7, last_reviewed_at = $8
+		WHERE id = $1 AND user_id = $2
+	`
+	_, err = tx.ExecContext(ctx, update,
+		item.ID, item.UserID, item.EaseFactor, item.IntervalDays, item.Repetitions, item.Lapses, item.DueAt, item.LastReviewedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: error updating review item: %w", err)
+	}
+
+	insert := `INSERT INTO review_logs (item_id, user_id, grade, reviewed_at) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.ExecContext(ctx, insert, grade.ItemID, grade.UserID, grade.Grade, grade.ReviewedAt); err != nil {
+		return fmt.Errorf("postgres: error recording review grade: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("postgres: error committing review: %w", err)
+	}
+	return nil
+}
+
+// reviewItemScanner abstrae *sql.Row y *sql.Rows
+type reviewItemScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReviewItem(row reviewItemScanner) (*repository.ReviewItem, error) {
+	var (
+		item         repository.ReviewItem
+		options      []byte
+		lastReviewed sql.NullTime
+	)
+	err := row.Scan(
+		&item.ID, &item.UserID, &item.MaterialID, &item.Kind, &item.SourceKey, &item.Prompt, &item.Answer, &options, &item.Explanation,
+		&item.EaseFactor, &item.IntervalDays, &item.Repetitions, &item.Lapses, &item.DueAt, &lastReviewed, &item.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error scanning review item: %w", err)
+	}
+
+	if len(options) > 0 {
+		if err := json.Unmarshal(options, &item.Options); err != nil {
+			return nil, fmt.Errorf("postgres: error decoding review options: %w", err)
+		}
+	}
+	if lastReviewed.Valid {
+		item.LastReviewedAt = &lastReviewed.Time
+	}
+	return &item, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// PostgresScreenExperimentRepository implementa repository.ScreenExperimentRepository para PostgreSQL
+//
+// Las tablas las crea migrations/sql/008_screen_experiments.sql y su contenido lo administra api-admin:
+//
+//	ui_config.screen_experiments (id, key, screen_key, priority, platforms text[], school_ids text[],
+//	                              roles text[], starts_at, ends_at, is_active)
+//	ui_config.screen_experiment_variants (id, experiment_id, key, weight, screen_key, sort_order)
+type PostgresScreenExperimentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresScreenExperimentRepository crea una nueva instancia del repositorio de experimentos
+func NewPostgresScreenExperimentRepository(db *sql.DB) repository.ScreenExperimentRepository {
+	return &PostgresScreenExperimentRepository{db: db}
+}
+
+// GetActiveExperiments retorna los experimentos activos dentro de su ventana de fechas con sus variantes
+func (r *PostgresScreenExperimentRepository) GetActiveExperiments(ctx context.Context) ([]*repository.ScreenExperiment, error) {
+	query := `
+		SELECT e.id::text, e.key, e.screen_key,
+		       COALESCE(e.platforms, '{}'), COALESCE(e.school_ids, '{}'), COALESCE(e.roles, '{}'),
+		       v.key, v.weight, COALESCE(v.screen_key, '')
+		FROM ui_config.screen_experiments e
+		JOIN ui_config.screen_experiment_variants v ON v.experiment_id = e.id
+		WHERE e.is_active = true
+		  AND (e.starts_at IS NULL OR e.starts_at <= NOW())
+		  AND (e.ends_at IS NULL OR e.ends_at > NOW())
+		ORDER BY e.priority ASC, e.key ASC, v.sort_order ASC, v.key ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error getting screen experiments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var experiments []*repository.ScreenExperiment
+	byID := make(map[string]*repository.ScreenExperiment)
+	for rows.Next() {
+		var (
+			e repository.ScreenExperiment
+			v repository.ScreenExperimentVariant
+		)
+		if err := rows.Scan(
+			&e.ID, &e.Key, &e.ScreenKey,
+			pq.Array(&e.Platforms), pq.Array(&e.SchoolIDs), pq.Array(&e.Roles),
+			&v.Key, &v.Weight, &v.ScreenKey,
+		); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning screen experiment: %w", err)
+		}
+
+		experiment, ok := byID[e.ID]
+		if !ok {
+			experiment = &e
+			byID[e.ID] = experiment
+			experiments = append(experiments, experiment)
+		}
+		experiment.Variants = append(experiment.Variants, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating screen experiments: %w", err)
+	}
+
+	return experiments, nil
+}
@@ -146,26 +146,136 @@ func (r *PostgresScreenRepository) GetUserPreferences(ctx context.Context, scree
 }
 
 // SaveUserPreferences guarda las preferencias especificas del usuario
+// En la misma transacción archiva la versión anterior en screen_user_preference_history
 func (r *PostgresScreenRepository) SaveUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID, prefs json.RawMessage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("postgres: error beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Ignorar error si ya se hizo Commit
+
+	instanceID, err := r.archiveUserPreferences(ctx, tx, screenKey, userID)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO ui_config.screen_user_preferences (id, screen_instance_id, user_id, preferences, created_at, updated_at)
-		SELECT gen_random_uuid(), si.id, $2, $3, NOW(), NOW()
-		FROM ui_config.screen_instances si
-		WHERE si.screen_key = $1 AND si.is_active = true
+		VALUES (gen_random_uuid(), $1, $2, $3, NOW(), NOW())
 		ON CONFLICT (screen_instance_id, user_id) DO UPDATE SET
 			preferences = EXCLUDED.preferences,
 			updated_at = NOW()
 	`
+	if _, err := tx.ExecContext(ctx, query, instanceID, userID, prefs); err != nil {
+		return fmt.Errorf("postgres: error saving user preferences: %w", err)
+	}
 
-	result, err := r.db.ExecContext(ctx, query, screenKey, userID, prefs)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("postgres: error committing user preferences: %w", err)
+	}
+	return nil
+}
+
+// DeleteUserPreferences elimina las preferencias del usuario archivando la versión actual
+func (r *PostgresScreenRepository) DeleteUserPreferences(ctx context.Context, screenKey string, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("postgres: error saving user preferences: %w", err)
+		return fmt.Errorf("postgres: error beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Ignorar error si ya se hizo Commit
+
+	instanceID, err := r.archiveUserPreferences(ctx, tx, screenKey, userID)
+	if err != nil {
+		return err
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("postgres: screen instance not found for key %q", screenKey)
+	query := `DELETE FROM ui_config.screen_user_preferences WHERE screen_instance_id = $1 AND user_id = $2`
+	if _, err := tx.ExecContext(ctx, query, instanceID, userID); err != nil {
+		return fmt.Errorf("postgres: error deleting user preferences: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("postgres: error committing user preferences: %w", err)
+	}
 	return nil
 }
+
+// archiveUserPreferences copia las preferencias actuales al historial y descarta las versiones
+// que exceden ScreenPreferencesHistoryLimit. Retorna la instancia de pantalla activa
+func (r *PostgresScreenRepository) archiveUserPreferences(ctx context.Context, tx *sql.Tx, screenKey string, userID uuid.UUID) (string, error) {
+	var instanceID string
+	err := tx.QueryRowContext(ctx,
+		`SELECT id FROM ui_config.screen_instances WHERE screen_key = $1 AND is_active = true`,
+		screenKey,
+	).Scan(&instanceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("postgres: screen instance not found for key %q", screenKey)
+	}
+	if err != nil {
+		return "", fmt.Errorf("postgres: error getting screen instance: %w", err)
+	}
+
+	// FOR UPDATE serializa guardados concurrentes del mismo usuario sobre la misma pantalla
+	archive := `
+		WITH current AS (
+			SELECT preferences, updated_at
+			FROM ui_config.screen_user_preferences
+			WHERE screen_instance_id = $1 AND user_id = $2
+			FOR UPDATE
+		)
+		INSERT INTO ui_config.screen_user_preference_history (id, screen_instance_id, user_id, preferences, saved_at, replaced_at)
+		SELECT gen_random_uuid(), $1, $2, preferences, updated_at, NOW()
+		FROM current
+	`
+	if _, err := tx.ExecContext(ctx, archive, instanceID, userID); err != nil {
+		return "", fmt.Errorf("postgres: error archiving user preferences: %w", err)
+	}
+
+	prune := `
+		DELETE FROM ui_config.screen_user_preference_history
+		WHERE screen_instance_id = $1 AND user_id = $2
+		  AND id NOT IN (
+			SELECT id FROM ui_config.screen_user_preference_history
+			WHERE screen_instance_id = $1 AND user_id = $2
+			ORDER BY replaced_at DESC
+			LIMIT $3
+		  )
+	`
+	if _, err := tx.ExecContext(ctx, prune, instanceID, userID, repository.ScreenPreferencesHistoryLimit); err != nil {
+		return "", fmt.Errorf("postgres: error pruning user preferences history: %w", err)
+	}
+
+	return instanceID, nil
+}
+
+// GetUserPreferencesHistory retorna las versiones anteriores de las preferencias, más recientes primero
+func (r *PostgresScreenRepository) GetUserPreferencesHistory(ctx context.Context, screenKey string, userID uuid.UUID) ([]*repository.ScreenPreferencesVersion, error) {
+	query := `
+		SELECT h.preferences, h.saved_at, h.replaced_at
+		FROM ui_config.screen_user_preference_history h
+		JOIN ui_config.screen_instances si ON si.id = h.screen_instance_id
+		WHERE si.screen_key = $1 AND h.user_id = $2
+		ORDER BY h.replaced_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, screenKey, userID, repository.ScreenPreferencesHistoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error getting user preferences history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	versions := make([]*repository.ScreenPreferencesVersion, 0)
+	for rows.Next() {
+		version := &repository.ScreenPreferencesVersion{}
+		if err := rows.Scan(&version.Preferences, &version.SavedAt, &version.ReplacedAt); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning user preferences history: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating user preferences history: %w", err)
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// PostgresScreenTranslationRepository implementa repository.ScreenTranslationRepository para PostgreSQL
+//
+// Las tablas las crea migrations/sql/009_screen_translations.sql y su contenido lo administra api-admin:
+//
+//	ui_config.screen_slot_translations (screen_key, slot_key, locale, value)  PK (screen_key, slot_key, locale)
+//	ui_config.resource_translations (resource_key, locale, display_name)      PK (resource_key, locale)
+type PostgresScreenTranslationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresScreenTranslationRepository crea una nueva instancia del repositorio de traducciones
+func NewPostgresScreenTranslationRepository(db *sql.DB) repository.ScreenTranslationRepository {
+	return &PostgresScreenTranslationRepository{db: db}
+}
+
+// GetSlotTranslations retorna los textos traducidos de los slots de una pantalla
+func (r *PostgresScreenTranslationRepository) GetSlotTranslations(ctx context.Context, screenKey, locale string) (map[string]string, error) {
+	query := `
+		SELECT slot_key, value
+		FROM ui_config.screen_slot_translations
+		WHERE screen_key = $1 AND locale = $2
+	`
+	return r.queryTranslations(ctx, "slot", query, screenKey, locale)
+}
+
+// GetResourceTranslations retorna las etiquetas de menú traducidas
+func (r *PostgresScreenTranslationRepository) GetResourceTranslations(ctx context.Context, locale string) (map[string]string, error) {
+	query := `
+		SELECT resource_key, display_name
+		FROM ui_config.resource_translations
+		WHERE locale = $1
+	`
+	return r.queryTranslations(ctx, "resource", query, locale)
+}
+
+// queryTranslations lee pares clave -> texto
+func (r *PostgresScreenTranslationRepository) queryTranslations(ctx context.Context, kind, query string, args ...any) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error getting %s translations: %w", kind, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	translations := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning %s translation: %w", kind, err)
+		}
+		translations[key] = value
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating %s translations: %w", kind, err)
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// globalStatsSnapshotID es la única fila de global_stats_snapshot
+const globalStatsSnapshotID = 1
+
+type postgresStatsSnapshotRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresStatsSnapshotRepository crea el repositorio de snapshots sobre la tabla global_stats_snapshot
+func NewPostgresStatsSnapshotRepository(db *sql.DB) repository.StatsSnapshotRepository {
+	return &postgresStatsSnapshotRepository{db: db}
+}
+
+func (r *postgresStatsSnapshotRepository) GetGlobalSnapshot(ctx context.Context) (*repository.GlobalStatsSnapshot, error) {
+	query := `
+		SELECT total_published_materials, total_completed_assessments, average_assessment_score,
+		       active_users_last_30_days, average_progress, generated_at
+		FROM global_stats_snapshot
+		WHERE id = $1
+	`
+
+	snapshot := &repository.GlobalStatsSnapshot{}
+	err := r.db.QueryRowContext(ctx, query, globalStatsSnapshotID).Scan(
+		&snapshot.TotalPublishedMaterials,
+		&snapshot.TotalCompletedAssessments,
+		&snapshot.AverageAssessmentScore,
+		&snapshot.ActiveUsersLast30Days,
+		&snapshot.AverageProgress,
+		&snapshot.GeneratedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error reading global stats snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// SaveGlobalSnapshot hace upsert de la fila única
+// La condición sobre generated_at evita que una instancia lenta pise un snapshot más reciente
+func (r *postgresStatsSnapshotRepository) SaveGlobalSnapshot(ctx context.Context, snapshot *repository.GlobalStatsSnapshot) error {
+	query := `
+		INSERT INTO global_stats_snapshot (
+			id, total_published_materials, total_completed_assessments, average_assessment_score,
+			active_users_last_30_days, average_progress, generated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE SET
+			total_published_materials = EXCLUDED.total_published_materials,
+			total_completed_assessments = EXCLUDED.total_completed_assessments,
+			average_assessment_score = EXCLUDED.average_assessment_score,
+			active_users_last_30_days = EXCLUDED.active_users_last_30_days,
+			average_progress = EXCLUDED.average_progress,
+			generated_at = EXCLUDED.generated_at
+		WHERE global_stats_snapshot.generated_at < EXCLUDED.generated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		globalStatsSnapshotID,
+		snapshot.TotalPublishedMaterials,
+		snapshot.TotalCompletedAssessments,
+		snapshot.AverageAssessmentScore,
+		snapshot.ActiveUsersLast30Days,
+		snapshot.AverageProgress,
+		snapshot.GeneratedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: error saving global stats snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type postgresSyncOperationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSyncOperationRepository crea el registro de operaciones de sincronización
+// sync_operations tiene PRIMARY KEY (user_id, client_id)
+func NewPostgresSyncOperationRepository(db *sql.DB) repository.SyncOperationRepository {
+	return &postgresSyncOperationRepository{db: db}
+}
+
+func (r *postgresSyncOperationRepository) ClaimSyncOperation(ctx context.Context, op *repository.SyncOperation) (*repository.SyncOperation, error) {
+	// El INSERT gana si el ID es nuevo; el UPDATE solo retoma reclamos pendientes abandonados.
+	// Si ninguno afecta filas, el ítem ya fue (o está siendo) aplicado por otra request
+	claim := `
+		INSERT INTO sync_operations (user_id, client_id, type, status, claimed_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id, client_id) DO UPDATE SET
+			type = EXCLUDED.type,
+			claimed_at = NOW()
+		WHERE sync_operations.status = $4
+		  AND sync_operations.claimed_at < NOW() - make_interval(secs => $5)
+		RETURNING client_id
+	`
+
+	var claimed string
+	err := r.db.QueryRowContext(ctx, claim,
+		op.UserID, op.ClientID, op.Type, repository.SyncStatusPending, repository.SyncClaimTimeout.Seconds(),
+	).Scan(&claimed)
+	if err == nil {
+		return nil, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("postgres: error claiming sync operation: %w", err)
+	}
+
+	existing := `
+		SELECT user_id, client_id, type, status, result, COALESCE(error_code, ''), COALESCE(error_message, ''), claimed_at
+		FROM sync_operations
+		WHERE user_id = $1 AND client_id = $2
+	`
+
+	current := &repository.SyncOperation{}
+	var result []byte
+	err = r.db.QueryRowContext(ctx, existing, op.UserID, op.ClientID).Scan(
+		&current.UserID, &current.ClientID, &current.Type, &current.Status,
+		&result, &current.ErrorCode, &current.ErrorMessage, &current.ClaimedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error reading sync operation: %w", err)
+	}
+	current.Result = result
+	return current, nil
+}
+
+func (r *postgresSyncOperationRepository) CompleteSyncOperation(ctx context.Context, op *repository.SyncOperation) error {
+	query := `
+		UPDATE sync_operations
+		SET status = $3, result = $4, error_code = NULLIF($5, ''), error_message = NULLIF($6, ''), completed_at = NOW()
+		WHERE user_id = $1 AND client_id = $2
+	`
+
+	var result interface{}
+	if len(op.Result) > 0 {
+		result = []byte(op.Result)
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		op.UserID, op.ClientID, op.Status, result, op.ErrorCode, op.ErrorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: error completing sync operation: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresSyncOperationRepository) ReleaseSyncOperation(ctx context.Context, userID, clientID string) error {
+	query := `DELETE FROM sync_operations WHERE user_id = $1 AND client_id = $2 AND status = $3`
+
+	_, err := r.db.ExecContext(ctx, query, userID, clientID, repository.SyncStatusPending)
+	if err != nil {
+		return fmt.Errorf("postgres: error releasing sync operation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+// PostgresTokenRevocationRepository implementa repository.TokenRevocationRepository para PostgreSQL
+//
+// La tabla la crea migrations/sql/010_token_revocations.sql y la escribe api-admin (una fila por logout o "cerrar todas las sesiones"):
+//
+//	token_revocations (id, jti, user_id, issued_before, expires_at, revoked_at)
+//	jti NULL para revocaciones por usuario; user_id/issued_before NULL para revocaciones por token
+type PostgresTokenRevocationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTokenRevocationRepository crea una nueva instancia del repositorio de revocaciones
+func NewPostgresTokenRevocationRepository(db *sql.DB) repository.TokenRevocationRepository {
+	return &PostgresTokenRevocationRepository{db: db}
+}
+
+// GetRevocationsSince retorna las revocaciones no vencidas registradas después de since
+func (r *PostgresTokenRevocationRepository) GetRevocationsSince(ctx context.Context, since time.Time) ([]*repository.TokenRevocation, error) {
+	query := `
+		SELECT jti, user_id, issued_before, expires_at, revoked_at
+		FROM token_revocations
+		WHERE revoked_at > $1 AND expires_at > NOW()
+		ORDER BY revoked_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error getting token revocations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var revocations []*repository.TokenRevocation
+	for rows.Next() {
+		var (
+			rev          repository.TokenRevocation
+			tokenID      sql.NullString
+			userID       sql.NullString
+			issuedBefore sql.NullTime
+		)
+		if err := rows.Scan(&tokenID, &userID, &issuedBefore, &rev.ExpiresAt, &rev.RevokedAt); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning token revocation: %w", err)
+		}
+		rev.TokenID = tokenID.String
+		rev.UserID = userID.String
+		rev.IssuedBefore = issuedBefore.Time
+		revocations = append(revocations, &rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating token revocations: %w", err)
+	}
+	return revocations, nil
+}
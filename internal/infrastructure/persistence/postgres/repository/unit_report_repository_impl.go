@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repositories"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/domain/repository"
+)
+
+type postgresUnitReportRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUnitReportRepository crea el repositorio de reportes por unidad académica
+func NewPostgresUnitReportRepository(db *sql.DB) repository.UnitReportRepository {
+	return &postgresUnitReportRepository{db: db}
+}
+
+// GetUnitGradebook ejecuta una query por bloque (unidad, materiales, estudiantes, resultados)
+// Los estudiantes provienen de memberships activas con rol student en la unidad
+func (r *postgresUnitReportRepository) GetUnitGradebook(ctx context.Context, academicUnitID string) (*repository.UnitGradebook, error) {
+	gradebook := &repository.UnitGradebook{AcademicUnitID: academicUnitID}
+
+	err := r.db.QueryRowContext(ctx,
+		`SELECT name, school_id FROM academic_units WHERE id = $1`,
+		academicUnitID,
+	).Scan(&gradebook.AcademicUnitName, &gradebook.SchoolID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error finding academic unit: %w", err)
+	}
+
+	if gradebook.Materials, err = r.findMaterials(ctx, academicUnitID); err != nil {
+		return nil, err
+	}
+	if gradebook.Students, err = r.findStudents(ctx, academicUnitID); err != nil {
+		return nil, err
+	}
+	if gradebook.Results, err = r.findResults(ctx, academicUnitID); err != nil {
+		return nil, err
+	}
+
+	return gradebook, nil
+}
+
+func (r *postgresUnitReportRepository) findMaterials(ctx context.Context, academicUnitID string) ([]repository.UnitReportMaterial, error) {
+	query := `
+		SELECT m.id, m.title, EXISTS (SELECT 1 FROM assessment a WHERE a.material_id = m.id)
+		FROM materials m
+		WHERE m.academic_unit_id = $1 AND m.deleted_at IS NULL
+		ORDER BY m.created_at, m.title
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, academicUnitID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error finding unit materials: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	materials := make([]repository.UnitReportMaterial, 0)
+	for rows.Next() {
+		var m repository.UnitReportMaterial
+		if err := rows.Scan(&m.ID, &m.Title, &m.HasAssessment); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning unit material: %w", err)
+		}
+		materials = append(materials, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating unit materials: %w", err)
+	}
+	return materials, nil
+}
+
+func (r *postgresUnitReportRepository) findStudents(ctx context.Context, academicUnitID string) ([]repository.UnitReportStudent, error) {
+	query := `
+		SELECT u.id, u.first_name, u.last_name, u.email
+		FROM memberships mb
+		JOIN users u ON u.id = mb.user_id
+		WHERE mb.academic_unit_id = $1 AND mb.role = 'student' AND mb.is_active = true
+		ORDER BY u.last_name, u.first_name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, academicUnitID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error finding unit students: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	students := make([]repository.UnitReportStudent, 0)
+	for rows.Next() {
+		var s repository.UnitReportStudent
+		if err := rows.Scan(&s.ID, &s.FirstName, &s.LastName, &s.Email); err != nil {
+			return nil, fmt.Errorf("postgres: error scanning unit student: %w", err)
+		}
+		students = append(students, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating unit students: %w", err)
+	}
+	return students, nil
+}
+
+// findResults combina progreso e intentos con FULL OUTER JOIN: un estudiante puede
+// haber rendido la evaluación sin registrar progreso de lectura y viceversa
+func (r *postgresUnitReportRepository) findResults(ctx context.Context, academicUnitID string) ([]repository.UnitReportResult, error) {
+	query := `
+		WITH unit_materials AS (
+			SELECT id FROM materials WHERE academic_unit_id = $1 AND deleted_at IS NULL
+		),
+		unit_progress AS (
+			SELECT user_id, material_id, percentage, last_accessed_at
+			FROM progress
+			WHERE material_id IN (SELECT id FROM unit_materials)
+		),
+		unit_attempts AS (
+			SELECT at.student_id, a.material_id,
+			       COUNT(*) AS attempts,
+			       MAX(at.score) AS best_score,
+			       MAX(COALESCE(a.pass_threshold, $2)) AS pass_threshold
+			FROM assessment a
+			JOIN assessment_attempt at ON at.assessment_id = a.id
+			WHERE a.material_id IN (SELECT id FROM unit_materials) AND at.completed_at IS NOT NULL
+			GROUP BY at.student_id, a.material_id
+		)
+		SELECT COALESCE(p.user_id, ua.student_id), COALESCE(p.material_id, ua.material_id),
+		       COALESCE(p.percentage, 0), p.last_accessed_at,
+		       COALESCE(ua.attempts, 0), ua.best_score, COALESCE(ua.pass_threshold, $2)
+		FROM unit_progress p
+		FULL OUTER JOIN unit_attempts ua ON ua.student_id = p.user_id AND ua.material_id = p.material_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, academicUnitID, repositories.DefaultPassThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error finding unit results: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := make([]repository.UnitReportResult, 0)
+	for rows.Next() {
+		var (
+			result         repository.UnitReportResult
+			lastAccessedAt sql.NullTime
+			bestScore      sql.NullFloat64
+		)
+		err := rows.Scan(
+			&result.StudentID, &result.MaterialID, &result.Percentage, &lastAccessedAt,
+			&result.AttemptsCount, &bestScore, &result.PassThreshold,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: error scanning unit result: %w", err)
+		}
+		if lastAccessedAt.Valid {
+			t := lastAccessedAt.Time
+			result.LastAccessedAt = &t
+		}
+		if bestScore.Valid {
+			score := bestScore.Float64
+			result.BestScore = &score
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error iterating unit results: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,103 @@
+package realtime
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+)
+
+// Filter define qué eventos recibe un cliente del stream
+//
+// Un cliente siempre recibe sus propios eventos (user_id o teacher_id iguales a UserID).
+// Además recibe los eventos de las clases a las que se suscribió (materiales o unidades
+// académicas), nunca de otra escuela cuando el evento informa school_id.
+type Filter struct {
+	UserID          string
+	SchoolID        string
+	Topics          []string // Patrones de tipo de evento ("progress.*", "assessment.#"); vacío = todos
+	MaterialIDs     []string
+	AcademicUnitIDs []string
+}
+
+// Matches indica si el mensaje debe entregarse al cliente con este filtro
+func (f Filter) Matches(msg Message) bool {
+	if !f.matchesTopic(msg.Type) {
+		return false
+	}
+
+	// Eventos propios: progreso e intentos del estudiante, materiales del docente
+	if f.UserID != "" && (msg.UserID == f.UserID || msg.TeacherID == f.UserID) {
+		return true
+	}
+
+	// Suscripciones a clases: nunca cruzan escuelas
+	if msg.SchoolID != "" && f.SchoolID != "" && msg.SchoolID != f.SchoolID {
+		return false
+	}
+	if msg.MaterialID != "" && slices.Contains(f.MaterialIDs, msg.MaterialID) {
+		return true
+	}
+	if msg.AcademicUnitID != "" && slices.Contains(f.AcademicUnitIDs, msg.AcademicUnitID) {
+		return true
+	}
+
+	return false
+}
+
+// matchesTopic aplica los patrones de tópico con semántica de exchange topic
+func (f Filter) matchesTopic(eventType string) bool {
+	if len(f.Topics) == 0 {
+		return true
+	}
+	for _, pattern := range f.Topics {
+		if eventbus.MatchTopic(pattern, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Client es una conexión de stream registrada en el hub
+type Client struct {
+	id     uint64
+	filter Filter
+	send   chan Message
+
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+func newClient(id uint64, filter Filter, bufferSize int) *Client {
+	return &Client{
+		id:     id,
+		filter: filter,
+		send:   make(chan Message, bufferSize),
+		done:   make(chan struct{}),
+	}
+}
+
+// Messages retorna el canal de mensajes pendientes del cliente
+func (c *Client) Messages() <-chan Message {
+	return c.send
+}
+
+// Done se cierra cuando el hub desconecta al cliente
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err retorna el motivo de la desconexión (ErrSlowConsumer, ErrHubClosed) o nil
+// Solo es válido después de que Done se cerró
+func (c *Client) Err() error {
+	return c.err
+}
+
+// close marca al cliente como desconectado
+func (c *Client) close(err error) {
+	c.closeOnce.Do(func() {
+		c.err = err
+		close(c.done)
+	})
+}
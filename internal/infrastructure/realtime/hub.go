@@ -0,0 +1,237 @@
+// Package realtime distribuye eventos de dominio a clientes conectados en
+// tiempo real (GET /v1/stream). El Hub se suscribe al EventBus y, con RabbitMQ,
+// a los exchanges de eventos (HandleDelivery) para recibir también lo publicado
+// por otras instancias y servicios. Entrega cada evento una sola vez a los clientes
+// cuyo filtro coincide, sin bloquear nunca al publicador.
+package realtime
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// Patrones del EventBus (y binding keys de RabbitMQ) que se reenvían a los clientes del stream
+var streamPatterns = []string{
+	"material.#",   // material.uploaded, material.completed, cambios de estado
+	"assessment.#", // assessment.generated, assessment.attempt.completed
+	"progress.#",   // progress.updated
+}
+
+var (
+	// ErrHubClosed indica que el hub ya no acepta clientes (shutdown)
+	ErrHubClosed = errors.New("realtime: hub closed")
+
+	// ErrTooManyConnections indica que el usuario alcanzó el límite de conexiones simultáneas
+	ErrTooManyConnections = errors.New("realtime: too many connections for user")
+
+	// ErrSlowConsumer indica que el cliente fue desconectado por no consumir sus mensajes a tiempo
+	ErrSlowConsumer = errors.New("realtime: client disconnected, buffer full")
+)
+
+// Config configura el hub
+type Config struct {
+	HeartbeatInterval     time.Duration // Intervalo de heartbeat sugerido a los handlers
+	ClientBufferSize      int           // Mensajes pendientes por cliente antes de desconectarlo
+	MaxConnectionsPerUser int           // 0 = sin límite
+}
+
+// Valores por defecto si la configuración viene vacía
+const (
+	DefaultHeartbeatInterval = 15 * time.Second
+	DefaultClientBufferSize  = 64
+)
+
+// Hub mantiene los clientes conectados y les distribuye los eventos del bus
+// Backpressure: cada cliente tiene un buffer acotado; si se llena, el cliente se
+// desconecta con ErrSlowConsumer (debe reconectar) en lugar de frenar al publicador.
+type Hub struct {
+	cfg    Config
+	logger logger.Logger
+
+	mu          sync.RWMutex
+	clients     map[uint64]*Client
+	perUser     map[string]int
+	nextID      uint64
+	closed      bool
+	unsubscribe []func()
+
+	// seen descarta el mismo evento recibido por el bus local y por RabbitMQ
+	seen *eventbus.RecentIDs
+}
+
+// NewHub crea un hub sin suscripciones; usar Attach para conectarlo al EventBus
+func NewHub(cfg Config, log logger.Logger) *Hub {
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if cfg.ClientBufferSize <= 0 {
+		cfg.ClientBufferSize = DefaultClientBufferSize
+	}
+
+	return &Hub{
+		cfg:     cfg,
+		logger:  log,
+		clients: make(map[uint64]*Client),
+		perUser: make(map[string]int),
+		seen:    eventbus.NewRecentIDs(eventbus.DefaultDedupeCapacity),
+	}
+}
+
+// BindingKeys retorna los routing keys que el hub reenvía, para enlazar colas de RabbitMQ
+func BindingKeys() []string {
+	keys := make([]string, len(streamPatterns))
+	copy(keys, streamPatterns)
+	return keys
+}
+
+// Attach suscribe el hub a los eventos de material, evaluaciones y progreso del bus
+func (h *Hub) Attach(bus eventbus.EventBus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, pattern := range streamPatterns {
+		h.unsubscribe = append(h.unsubscribe, bus.Subscribe(pattern, h.HandleEvent))
+	}
+}
+
+// HeartbeatInterval retorna el intervalo de heartbeat configurado
+func (h *Hub) HeartbeatInterval() time.Duration {
+	return h.cfg.HeartbeatInterval
+}
+
+// Register conecta un nuevo cliente con el filtro indicado
+func (h *Hub) Register(filter Filter) (*Client, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+	if h.cfg.MaxConnectionsPerUser > 0 && h.perUser[filter.UserID] >= h.cfg.MaxConnectionsPerUser {
+		return nil, ErrTooManyConnections
+	}
+
+	h.nextID++
+	client := newClient(h.nextID, filter, h.cfg.ClientBufferSize)
+	h.clients[client.id] = client
+	h.perUser[filter.UserID]++
+	streamConnections.Inc()
+
+	return client, nil
+}
+
+// Unregister desconecta un cliente; es seguro llamarlo más de una vez
+func (h *Hub) Unregister(client *Client) {
+	h.mu.Lock()
+	removed := h.remove(client)
+	h.mu.Unlock()
+
+	if removed {
+		client.close(nil)
+	}
+}
+
+// HandleEvent implementa eventbus.Handler: normaliza el evento y lo distribuye
+// Un evento con event_id ya distribuido (llegó antes por el bus o por RabbitMQ) se descarta
+func (h *Hub) HandleEvent(ctx context.Context, event eventbus.Event) error {
+	msg, err := MessageFromEvent(event)
+	if err != nil {
+		return err
+	}
+	if !h.seen.Add(msg.ID) {
+		return nil
+	}
+	h.Broadcast(msg)
+	return nil
+}
+
+// HandleDelivery implementa rabbitmq.DeliveryHandler para alimentar el hub desde RabbitMQ
+func (h *Hub) HandleDelivery(ctx context.Context, routingKey string, body []byte) error {
+	return h.HandleEvent(ctx, eventbus.Event{RoutingKey: routingKey, Body: body})
+}
+
+// Broadcast entrega el mensaje a todos los clientes cuyo filtro coincide
+// Nunca bloquea: los clientes con el buffer lleno se desconectan
+func (h *Hub) Broadcast(msg Message) {
+	var slow []*Client
+
+	h.mu.RLock()
+	for _, client := range h.clients {
+		if !client.filter.Matches(msg) {
+			continue
+		}
+		select {
+		case client.send <- msg:
+			streamMessagesTotal.WithLabelValues(msg.Type).Inc()
+		default:
+			slow = append(slow, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(slow) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	for _, client := range slow {
+		if h.remove(client) {
+			client.close(ErrSlowConsumer)
+			streamDroppedClientsTotal.Inc()
+			h.logger.Warn("stream client disconnected: buffer full",
+				"user_id", client.filter.UserID,
+				"buffer_size", h.cfg.ClientBufferSize,
+				"event_type", msg.Type,
+			)
+		}
+	}
+	h.mu.Unlock()
+}
+
+// Close cancela las suscripciones al bus y desconecta a todos los clientes
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+
+	for _, unsubscribe := range h.unsubscribe {
+		unsubscribe()
+	}
+	h.unsubscribe = nil
+
+	for _, client := range h.clients {
+		h.remove(client)
+		client.close(ErrHubClosed)
+	}
+}
+
+// ConnectedClients retorna la cantidad de clientes conectados
+func (h *Hub) ConnectedClients() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+// remove quita al cliente de los índices; requiere h.mu tomado en escritura
+func (h *Hub) remove(client *Client) bool {
+	if _, ok := h.clients[client.id]; !ok {
+		return false
+	}
+	delete(h.clients, client.id)
+
+	h.perUser[client.filter.UserID]--
+	if h.perUser[client.filter.UserID] <= 0 {
+		delete(h.perUser, client.filter.UserID)
+	}
+	streamConnections.Dec()
+	return true
+}
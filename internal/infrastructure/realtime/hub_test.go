@@ -0,0 +1,248 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+func newTestHub(cfg Config) *Hub {
+	return NewHub(cfg, logger.NewZapLogger("error", "json"))
+}
+
+// receive espera un mensaje del cliente o falla tras un timeout
+func receive(t *testing.T, client *Client) Message {
+	t.Helper()
+	select {
+	case msg := <-client.Messages():
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("expected a message")
+		return Message{}
+	}
+}
+
+func assertNoMessage(t *testing.T, client *Client) {
+	t.Helper()
+	select {
+	case msg := <-client.Messages():
+		t.Fatalf("unexpected message %s", msg.Type)
+	default:
+	}
+}
+
+func TestFilter_Matches(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		msg    Message
+		want   bool
+	}{
+		{
+			name:   "evento propio del estudiante",
+			filter: Filter{UserID: "u1", SchoolID: "s1"},
+			msg:    Message{Type: "progress.updated", UserID: "u1"},
+			want:   true,
+		},
+		{
+			name:   "material propio del docente",
+			filter: Filter{UserID: "t1", SchoolID: "s1"},
+			msg:    Message{Type: "material.uploaded", TeacherID: "t1", SchoolID: "s1"},
+			want:   true,
+		},
+		{
+			name:   "evento de otro usuario sin suscripción",
+			filter: Filter{UserID: "u1", SchoolID: "s1"},
+			msg:    Message{Type: "progress.updated", UserID: "u2", SchoolID: "s1", MaterialID: "m1"},
+			want:   false,
+		},
+		{
+			name:   "suscripción a material",
+			filter: Filter{UserID: "t1", SchoolID: "s1", MaterialIDs: []string{"m1"}},
+			msg:    Message{Type: "assessment.attempt.completed", UserID: "u2", MaterialID: "m1"},
+			want:   true,
+		},
+		{
+			name:   "suscripción a unidad académica",
+			filter: Filter{UserID: "t1", SchoolID: "s1", AcademicUnitIDs: []string{"a1"}},
+			msg:    Message{Type: "material.uploaded", TeacherID: "t2", SchoolID: "s1", AcademicUnitID: "a1"},
+			want:   true,
+		},
+		{
+			name:   "la suscripción a clase no cruza escuelas",
+			filter: Filter{UserID: "t1", SchoolID: "s1", MaterialIDs: []string{"m1"}},
+			msg:    Message{Type: "progress.updated", UserID: "u2", SchoolID: "s2", MaterialID: "m1"},
+			want:   false,
+		},
+		{
+			name:   "filtro de tópicos",
+			filter: Filter{UserID: "u1", Topics: []string{"assessment.#"}},
+			msg:    Message{Type: "progress.updated", UserID: "u1"},
+			want:   false,
+		},
+		{
+			name:   "filtro de tópicos con comodín",
+			filter: Filter{UserID: "u1", Topics: []string{"progress.*", "assessment.#"}},
+			msg:    Message{Type: "assessment.attempt.completed", UserID: "u1"},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(tt.msg))
+		})
+	}
+}
+
+func TestHub_AttachDeliversBusEvents(t *testing.T) {
+	log := logger.NewZapLogger("error", "json")
+	bus := eventbus.New(eventbus.NewInProcessTransport(), log)
+	hub := NewHub(Config{}, log)
+	hub.Attach(bus)
+	defer hub.Close()
+
+	client, err := hub.Register(Filter{UserID: "u1"})
+	require.NoError(t, err)
+
+	event := rabbitmq.NewProgressUpdatedEvent(rabbitmq.ProgressUpdatedPayload{
+		MaterialID: "m1",
+		SchoolID:   "s1",
+		UserID:     "u1",
+		Percentage: 40,
+	})
+	body, err := event.ToJSON()
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(context.Background(), "edugo.events", "progress.updated", body))
+
+	// Eventos que no pertenecen a los patrones del stream no se reenvían
+	require.NoError(t, bus.Publish(context.Background(), "edugo.events", "screen.updated", []byte(`{}`)))
+
+	msg := receive(t, client)
+	assert.Equal(t, event.EventID, msg.ID)
+	assert.Equal(t, "progress.updated", msg.Type)
+	assert.Equal(t, "u1", msg.UserID)
+	assert.Equal(t, "s1", msg.SchoolID)
+	assert.Equal(t, "m1", msg.MaterialID)
+	assert.Contains(t, string(msg.Data), `"percentage":40`)
+	assertNoMessage(t, client)
+}
+
+func TestMessageFromEvent_AcademicUnitFromMetadata(t *testing.T) {
+	event := rabbitmq.NewMaterialUploadedEvent(rabbitmq.MaterialUploadedPayload{
+		MaterialID: "m1",
+		SchoolID:   "s1",
+		TeacherID:  "t1",
+		Metadata:   map[string]interface{}{"academic_unit_id": "a1"},
+	})
+	body, err := event.ToJSON()
+	require.NoError(t, err)
+
+	msg, err := MessageFromEvent(eventbus.Event{RoutingKey: "material.uploaded", Body: body})
+	require.NoError(t, err)
+	assert.Equal(t, "material.uploaded", msg.Type)
+	assert.Equal(t, "t1", msg.TeacherID)
+	assert.Equal(t, "a1", msg.AcademicUnitID)
+
+	_, err = MessageFromEvent(eventbus.Event{RoutingKey: "material.uploaded", Body: []byte("not-json")})
+	assert.Error(t, err)
+}
+
+func TestHub_SlowConsumerIsDisconnected(t *testing.T) {
+	hub := newTestHub(Config{ClientBufferSize: 2})
+
+	slow, err := hub.Register(Filter{UserID: "u1"})
+	require.NoError(t, err)
+	fast, err := hub.Register(Filter{UserID: "u1"})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		hub.Broadcast(Message{Type: "progress.updated", UserID: "u1"})
+		receive(t, fast)
+	}
+
+	// El buffer del cliente lento está lleno: el tercer mensaje lo desconecta sin bloquear
+	hub.Broadcast(Message{Type: "progress.updated", UserID: "u1"})
+
+	select {
+	case <-slow.Done():
+	case <-time.After(time.Second):
+		t.Fatal("slow client should be disconnected")
+	}
+	assert.ErrorIs(t, slow.Err(), ErrSlowConsumer)
+	assert.Equal(t, 1, hub.ConnectedClients())
+	receive(t, fast)
+}
+
+func TestHub_MaxConnectionsPerUser(t *testing.T) {
+	hub := newTestHub(Config{MaxConnectionsPerUser: 1})
+
+	first, err := hub.Register(Filter{UserID: "u1"})
+	require.NoError(t, err)
+
+	_, err = hub.Register(Filter{UserID: "u1"})
+	assert.ErrorIs(t, err, ErrTooManyConnections)
+
+	_, err = hub.Register(Filter{UserID: "u2"})
+	assert.NoError(t, err, "el límite es por usuario")
+
+	hub.Unregister(first)
+	hub.Unregister(first) // idempotente
+	_, err = hub.Register(Filter{UserID: "u1"})
+	assert.NoError(t, err)
+}
+
+func TestHub_CloseDisconnectsClients(t *testing.T) {
+	log := logger.NewZapLogger("error", "json")
+	bus := eventbus.New(eventbus.NewInProcessTransport(), log)
+	hub := NewHub(Config{}, log)
+	hub.Attach(bus)
+
+	client, err := hub.Register(Filter{UserID: "u1"})
+	require.NoError(t, err)
+
+	hub.Close()
+
+	<-client.Done()
+	assert.ErrorIs(t, client.Err(), ErrHubClosed)
+	assert.Equal(t, 0, hub.ConnectedClients())
+
+	_, err = hub.Register(Filter{UserID: "u1"})
+	assert.ErrorIs(t, err, ErrHubClosed)
+}
+
+func TestHub_DeduplicatesEventsFromBusAndRabbitMQ(t *testing.T) {
+	hub := newTestHub(Config{})
+	bus := eventbus.New(eventbus.NewInProcessTransport(), logger.NewZapLogger("error", "json"))
+	hub.Attach(bus)
+
+	client, err := hub.Register(Filter{UserID: "u1"})
+	require.NoError(t, err)
+
+	event := rabbitmq.NewProgressUpdatedEvent(rabbitmq.ProgressUpdatedPayload{MaterialID: "m1", UserID: "u1", Percentage: 40})
+	body, err := event.ToJSON()
+	require.NoError(t, err)
+
+	// Publicado localmente y recibido de vuelta desde RabbitMQ
+	require.NoError(t, bus.Publish(context.Background(), "edugo.events", "progress.updated", body))
+	require.NoError(t, hub.HandleDelivery(context.Background(), "progress.updated", body))
+
+	msg := receive(t, client)
+	assert.Equal(t, event.EventID, msg.ID)
+	assertNoMessage(t, client)
+
+	// Un evento de otra instancia solo llega por RabbitMQ
+	remote := rabbitmq.NewProgressUpdatedEvent(rabbitmq.ProgressUpdatedPayload{MaterialID: "m2", UserID: "u1", Percentage: 80})
+	remoteBody, err := remote.ToJSON()
+	require.NoError(t, err)
+	require.NoError(t, hub.HandleDelivery(context.Background(), "progress.updated", remoteBody))
+
+	assert.Equal(t, remote.EventID, receive(t, client).ID)
+}
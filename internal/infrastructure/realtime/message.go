@@ -0,0 +1,82 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/eventbus"
+)
+
+// Message es un evento normalizado listo para enviarse a los clientes del stream
+// Los campos de enrutamiento no se serializan: solo deciden quién recibe el mensaje.
+type Message struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+
+	UserID         string `json:"-"` // Usuario dueño del evento (progreso, intento)
+	TeacherID      string `json:"-"` // Docente autor del material
+	SchoolID       string `json:"-"`
+	MaterialID     string `json:"-"`
+	AcademicUnitID string `json:"-"`
+}
+
+// envelope es el envelope estándar de rabbitmq.Event con el payload sin decodificar
+type envelope struct {
+	EventID   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// routingFields son los campos del payload que determinan la audiencia del evento
+type routingFields struct {
+	UserID         string `json:"user_id"`
+	TeacherID      string `json:"teacher_id"`
+	SchoolID       string `json:"school_id"`
+	MaterialID     string `json:"material_id"`
+	AcademicUnitID string `json:"academic_unit_id"`
+	Metadata       struct {
+		AcademicUnitID string `json:"academic_unit_id"`
+	} `json:"metadata"`
+}
+
+// MessageFromEvent convierte un evento del bus (envelope rabbitmq.Event) en un Message
+func MessageFromEvent(event eventbus.Event) (Message, error) {
+	var env envelope
+	if err := json.Unmarshal(event.Body, &env); err != nil {
+		return Message{}, fmt.Errorf("realtime: invalid event envelope for %s: %w", event.RoutingKey, err)
+	}
+
+	var fields routingFields
+	if len(env.Payload) > 0 {
+		if err := json.Unmarshal(env.Payload, &fields); err != nil {
+			return Message{}, fmt.Errorf("realtime: invalid event payload for %s: %w", event.RoutingKey, err)
+		}
+	}
+
+	msg := Message{
+		ID:             env.EventID,
+		Type:           env.EventType,
+		Timestamp:      env.Timestamp,
+		Data:           env.Payload,
+		UserID:         fields.UserID,
+		TeacherID:      fields.TeacherID,
+		SchoolID:       fields.SchoolID,
+		MaterialID:     fields.MaterialID,
+		AcademicUnitID: fields.AcademicUnitID,
+	}
+	if msg.Type == "" {
+		msg.Type = event.RoutingKey
+	}
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = event.PublishedAt
+	}
+	if msg.AcademicUnitID == "" {
+		msg.AcademicUnitID = fields.Metadata.AcademicUnitID
+	}
+
+	return msg, nil
+}
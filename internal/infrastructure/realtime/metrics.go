@@ -0,0 +1,33 @@
+package realtime
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// streamConnections mide las conexiones de stream abiertas
+	streamConnections = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "stream_connections",
+			Help: "Number of open real-time stream connections",
+		},
+	)
+
+	// streamMessagesTotal cuenta los mensajes encolados a clientes del stream
+	streamMessagesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stream_messages_total",
+			Help: "Total number of messages queued to real-time stream clients",
+		},
+		[]string{"type"},
+	)
+
+	// streamDroppedClientsTotal cuenta los clientes desconectados por buffer lleno
+	streamDroppedClientsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "stream_dropped_clients_total",
+			Help: "Total number of real-time stream clients disconnected because their buffer was full",
+		},
+	)
+)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/tracing"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/EduGoGroup/edugo-shared/logger"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -89,9 +90,11 @@ func (c *S3Client) GeneratePresignedUploadURL(ctx context.Context, key string, c
 	}
 
 	// Generar URL presignada
+	ctx, span := tracing.StartS3Span(ctx, "PresignPutObject", c.bucketName, key)
 	presignedReq, err := presignClient.PresignPutObject(ctx, putObjectInput, func(opts *s3.PresignOptions) {
 		opts.Expires = expiresIn
 	})
+	tracing.EndSpan(span, err)
 	if err != nil {
 		c.logger.Error("error generando URL presignada para upload",
 			"key", key,
@@ -120,9 +123,11 @@ func (c *S3Client) GeneratePresignedDownloadURL(ctx context.Context, key string,
 	}
 
 	// Generar URL presignada
+	ctx, span := tracing.StartS3Span(ctx, "PresignGetObject", c.bucketName, key)
 	presignedReq, err := presignClient.PresignGetObject(ctx, getObjectInput, func(opts *s3.PresignOptions) {
 		opts.Expires = expiresIn
 	})
+	tracing.EndSpan(span, err)
 	if err != nil {
 		c.logger.Error("error generando URL presignada para download",
 			"key", key,
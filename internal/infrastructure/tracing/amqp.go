@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AMQPHeadersCarrier adapta amqp.Table a propagation.TextMapCarrier
+// Permite inyectar y extraer traceparent/tracestate en los headers de un mensaje
+type AMQPHeadersCarrier amqp.Table
+
+// Get retorna el valor de un header como string
+func (c AMQPHeadersCarrier) Get(key string) string {
+	value, ok := c[key]
+	if !ok {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// Set asigna un header
+func (c AMQPHeadersCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys retorna los nombres de los headers presentes
+func (c AMQPHeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// InjectAMQPHeaders escribe el contexto de traza del ctx (traceparent, baggage) en los headers
+func InjectAMQPHeaders(ctx context.Context, headers amqp.Table) {
+	otel.GetTextMapPropagator().Inject(ctx, AMQPHeadersCarrier(headers))
+}
+
+// ExtractAMQPHeaders retorna un contexto con el span remoto descrito en los headers
+func ExtractAMQPHeaders(ctx context.Context, headers amqp.Table) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, AMQPHeadersCarrier(headers))
+}
+
+// StartPublishSpan inicia un span productor para una publicación AMQP
+func StartPublishSpan(ctx context.Context, exchange, routingKey string, bodySize int) (context.Context, trace.Span) {
+	return StartSpan(ctx, fmt.Sprintf("%s publish", routingKey), trace.SpanKindProducer,
+		attribute.String("messaging.system", "rabbitmq"),
+		attribute.String("messaging.operation", "publish"),
+		attribute.String("messaging.destination.name", exchange),
+		attribute.String("messaging.rabbitmq.destination.routing_key", routingKey),
+		attribute.Int("messaging.message.body.size", bodySize),
+	)
+}
+
+// Verificar en compile-time que AMQPHeadersCarrier implementa propagation.TextMapCarrier
+var _ propagation.TextMapCarrier = AMQPHeadersCarrier(nil)
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// mongoMonitor correlaciona los eventos Started/Succeeded/Failed por RequestID
+type mongoMonitor struct {
+	mu    sync.Mutex
+	spans map[int64]trace.Span
+}
+
+// NewMongoMonitor crea un CommandMonitor que genera un span cliente por cada comando de MongoDB
+// Se registra en options.Client().SetMonitor(...) al crear el cliente
+func NewMongoMonitor() *event.CommandMonitor {
+	m := &mongoMonitor{spans: make(map[int64]trace.Span)}
+	return &event.CommandMonitor{
+		Started:   m.started,
+		Succeeded: m.succeeded,
+		Failed:    m.failed,
+	}
+}
+
+func (m *mongoMonitor) started(ctx context.Context, evt *event.CommandStartedEvent) {
+	_, span := StartSpan(ctx, "mongodb."+evt.CommandName, trace.SpanKindClient,
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.name", evt.DatabaseName),
+		attribute.String("db.operation", evt.CommandName),
+		attribute.String("network.connection.id", evt.ConnectionID),
+	)
+
+	m.mu.Lock()
+	m.spans[evt.RequestID] = span
+	m.mu.Unlock()
+}
+
+func (m *mongoMonitor) succeeded(_ context.Context, evt *event.CommandSucceededEvent) {
+	if span := m.take(evt.RequestID); span != nil {
+		EndSpan(span, nil)
+	}
+}
+
+func (m *mongoMonitor) failed(_ context.Context, evt *event.CommandFailedEvent) {
+	if span := m.take(evt.RequestID); span != nil {
+		EndSpan(span, evt.Failure)
+	}
+}
+
+// take retira el span asociado a un RequestID
+func (m *mongoMonitor) take(requestID int64) trace.Span {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	span, ok := m.spans[requestID]
+	if !ok {
+		return nil
+	}
+	delete(m.spans, requestID)
+	return span
+}
@@ -0,0 +1,19 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartS3Span inicia un span cliente para una operación de S3 (p. ej. PresignPutObject)
+func StartS3Span(ctx context.Context, operation, bucket, key string) (context.Context, trace.Span) {
+	return StartSpan(ctx, "S3."+operation, trace.SpanKindClient,
+		attribute.String("rpc.system", "aws-api"),
+		attribute.String("rpc.service", "S3"),
+		attribute.String("rpc.method", operation),
+		attribute.String("aws.s3.bucket", bucket),
+		attribute.String("aws.s3.key", key),
+	)
+}
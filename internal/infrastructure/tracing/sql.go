@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/XSAM/otelsql"
+	_ "github.com/lib/pq" // Driver PostgreSQL
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// OpenPostgres abre un *sql.DB de PostgreSQL instrumentado: cada query genera un span
+func OpenPostgres(dsn string, maxConnections int) (*sql.DB, error) {
+	db, err := otelsql.Open("postgres", dsn,
+		otelsql.WithAttributes(attribute.String("db.system", "postgresql")),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open instrumented PostgreSQL connection: %w", err)
+	}
+
+	if maxConnections > 0 {
+		db.SetMaxOpenConns(maxConnections)
+	}
+
+	return db, nil
+}
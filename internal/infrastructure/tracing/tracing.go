@@ -0,0 +1,170 @@
+// Package tracing configura OpenTelemetry para trazas distribuidas.
+// Expone el TracerProvider global, la propagación W3C (traceparent/baggage)
+// y helpers de instrumentación para HTTP, AMQP, MongoDB, PostgreSQL y S3.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName es el nombre de instrumentación usado por los spans de api-mobile
+const TracerName = "github.com/EduGoGroup/edugo-api-mobile"
+
+// Exportadores soportados (config: tracing.exporter)
+const (
+	ExporterOTLP = "otlp"
+	ExporterNoop = "noop"
+)
+
+// ShutdownFunc vacía y detiene el exportador de trazas
+type ShutdownFunc func(ctx context.Context) error
+
+// Tracer retorna el tracer de api-mobile desde el TracerProvider global
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Setup configura el TracerProvider y el propagador globales según config.TracingConfig.
+// La propagación W3C se habilita siempre, para que un traceparent entrante llegue
+// a los mensajes AMQP aunque este servicio no exporte sus propios spans.
+func Setup(ctx context.Context, cfg config.TracingConfig, environment string, log logger.Logger) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !cfg.Enabled {
+		log.Info("tracing deshabilitado, solo se propaga el contexto W3C")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := NewTracerProvider(exporter, cfg, environment)
+	otel.SetTracerProvider(provider)
+
+	log.Info("tracing habilitado",
+		"exporter", exporterName(cfg),
+		"endpoint", cfg.Endpoint,
+		"sample_ratio", cfg.SampleRatio,
+	)
+
+	return provider.Shutdown, nil
+}
+
+// NewTracerProvider crea un TracerProvider con el exportador indicado
+// Se expone para que los tests puedan usar un exportador en memoria o NoopExporter
+func NewTracerProvider(exporter sdktrace.SpanExporter, cfg config.TracingConfig, environment string) *sdktrace.TracerProvider {
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "edugo-api-mobile"
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("deployment.environment", environment),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+}
+
+// newExporter crea el exportador configurado
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch exporterName(cfg) {
+	case ExporterNoop:
+		return NewNoopExporter(), nil
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		return exporter, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", cfg.Exporter)
+	}
+}
+
+// exporterName normaliza el nombre del exportador (default: otlp)
+func exporterName(cfg config.TracingConfig) string {
+	if cfg.Exporter == "" {
+		return ExporterOTLP
+	}
+	return cfg.Exporter
+}
+
+// NoopExporter descarta todos los spans
+// Útil en tests y entornos sin collector: los spans se crean y propagan, pero no se envían
+type NoopExporter struct{}
+
+// NewNoopExporter crea un exportador que descarta los spans
+func NewNoopExporter() *NoopExporter {
+	return &NoopExporter{}
+}
+
+// ExportSpans descarta los spans recibidos
+func (e *NoopExporter) ExportSpans(_ context.Context, _ []sdktrace.ReadOnlySpan) error {
+	return nil
+}
+
+// Shutdown no libera recursos
+func (e *NoopExporter) Shutdown(_ context.Context) error {
+	return nil
+}
+
+// StartSpan inicia un span con el tracer de api-mobile
+func StartSpan(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// EndSpan registra el error (si existe) y finaliza el span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID retorna el trace ID del span activo en el contexto, o "" si no hay
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// Verificar en compile-time que NoopExporter implementa sdktrace.SpanExporter
+var _ sdktrace.SpanExporter = (*NoopExporter)(nil)
@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-mobile/internal/config"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// setupTestTracing registra un TracerProvider con exportador en memoria y lo restaura al terminar
+func setupTestTracing(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	return exporter
+}
+
+func TestSetup(t *testing.T) {
+	log := logger.NewZapLogger("error", "json")
+	previousProvider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previousProvider) })
+
+	t.Run("disabled only configures propagation", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), config.TracingConfig{Enabled: false}, "test", log)
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+		assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+	})
+
+	t.Run("noop exporter", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), config.TracingConfig{Enabled: true, Exporter: ExporterNoop}, "test", log)
+		require.NoError(t, err)
+
+		_, span := StartSpan(context.Background(), "noop", trace.SpanKindInternal)
+		assert.True(t, span.SpanContext().IsValid())
+		span.End()
+
+		assert.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		_, err := Setup(context.Background(), config.TracingConfig{Enabled: true, Exporter: "zipkin"}, "test", log)
+		assert.Error(t, err)
+	})
+}
+
+func TestAMQPHeaders_InjectAndExtract(t *testing.T) {
+	exporter := setupTestTracing(t)
+
+	ctx, span := StartPublishSpan(context.Background(), "edugo.materials", "material.completed", 42)
+	headers := amqp.Table{"X-Request-ID": "req-123"}
+	InjectAMQPHeaders(ctx, headers)
+	span.End()
+
+	traceparent, ok := headers["traceparent"].(string)
+	require.True(t, ok, "traceparent header should be injected")
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+	assert.Equal(t, "req-123", headers["X-Request-ID"])
+
+	// El consumidor reconstruye el contexto remoto desde los headers
+	remote := trace.SpanContextFromContext(ExtractAMQPHeaders(context.Background(), headers))
+	assert.True(t, remote.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "material.completed publish", spans[0].Name)
+	assert.Equal(t, trace.SpanKindProducer, spans[0].SpanKind)
+}
+
+func TestMongoMonitor(t *testing.T) {
+	exporter := setupTestTracing(t)
+	monitor := NewMongoMonitor()
+	ctx := context.Background()
+
+	monitor.Started(ctx, &event.CommandStartedEvent{CommandName: "find", DatabaseName: "edugo", RequestID: 1})
+	monitor.Started(ctx, &event.CommandStartedEvent{CommandName: "insert", DatabaseName: "edugo", RequestID: 2})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", RequestID: 1}})
+	monitor.Failed(ctx, &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "insert", RequestID: 2},
+		Failure:              errors.New("duplicate key"),
+	})
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "mongodb.find", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, "mongodb.insert", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
+
+func TestEndSpan_RecordsError(t *testing.T) {
+	exporter := setupTestTracing(t)
+
+	_, span := StartS3Span(context.Background(), "PresignPutObject", "edugo-materials", "materials/1.pdf")
+	EndSpan(span, errors.New("access denied"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "S3.PresignPutObject", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "access denied", spans[0].Status.Description)
+}
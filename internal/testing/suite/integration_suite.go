@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"time"
 
+	mobilemigrations "github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/persistence/postgres/migrations"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/migrations"
 	"github.com/EduGoGroup/edugo-shared/logger"
 	_ "github.com/lib/pq" // Driver PostgreSQL
@@ -174,6 +175,10 @@ func (s *IntegrationTestSuite) applyMigrations() {
 	`)
 	s.Require().NoError(err, "Tabla progress debe existir para compatibilidad")
 
+	// Tablas propias de api-mobile que aún no están en edugo-infrastructure
+	_, err = mobilemigrations.ApplyAll(context.Background(), s.PostgresDB)
+	s.Require().NoError(err, "Migraciones de api-mobile deben aplicarse correctamente")
+
 	s.Logger.Info("✅ Migraciones aplicadas")
 }
 
@@ -187,17 +192,17 @@ func (s *IntegrationTestSuite) applySeeds() {
 	s.Logger.Info("✅ Seeds aplicados")
 }
 
-// cleanDatabase limpia todas las tablas de la base de datos (excepto schema_migrations)
+// cleanDatabase limpia todas las tablas de la base de datos (excepto los registros de migraciones)
 // Implementación local ya que pgtesting.CleanDatabase() fue removido en v0.9.0
 func (s *IntegrationTestSuite) cleanDatabase() error {
 	s.Logger.Info("🧹 Limpiando base de datos...")
 
-	// Obtener todas las tablas (excepto schema_migrations)
+	// Obtener todas las tablas (excepto los registros de migraciones)
 	query := `
 		SELECT tablename
 		FROM pg_tables
 		WHERE schemaname = 'public'
-		AND tablename != 'schema_migrations'
+		AND tablename NOT IN ('schema_migrations', 'api_mobile_schema_migrations')
 		ORDER BY tablename
 	`
 
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-mobile/internal/infrastructure/messaging/rabbitmq"
 	"github.com/EduGoGroup/edugo-api-mobile/internal/testing/suite"
 	amqp "github.com/rabbitmq/amqp091-go"
 	testifySuite "github.com/stretchr/testify/suite"
@@ -245,3 +246,71 @@ func (s *RabbitMQSuite) TestMultiplePublishers() {
 
 	s.Logger.Info("✅ Múltiples publishers funcionando correctamente")
 }
+
+// TestPublisherRecoversFromBrokerDisconnect verifica que el publisher reconecta
+// cuando el broker cierra la conexión y sigue publicando con confirms
+func (s *RabbitMQSuite) TestPublisherRecoversFromBrokerDisconnect() {
+	ctx := context.Background()
+
+	rabbitURL, err := s.RabbitContainer.AmqpURL(ctx)
+	s.Require().NoError(err)
+
+	reconnectConfig := rabbitmq.DefaultReconnectConfig()
+	reconnectConfig.InitialInterval = 100 * time.Millisecond
+	reconnectConfig.MaxInterval = time.Second
+	reconnectConfig.PublishTimeout = 15 * time.Second
+
+	exchangeName := "test-reconnect-exchange"
+	publisher, err := rabbitmq.NewRabbitMQPublisherWithConfig(rabbitURL, exchangeName, reconnectConfig, s.Logger)
+	s.Require().NoError(err, "Debe conectar el publisher")
+	defer func() { _ = publisher.Close() }()
+
+	s.Require().NoError(publisher.Publish(ctx, exchangeName, "test.before", []byte(`{"step":"before"}`)))
+
+	// Forzar el cierre de todas las conexiones desde el broker
+	exitCode, _, err := s.RabbitContainer.Exec(ctx, []string{
+		"rabbitmqctl", "close_all_connections", "closed by integration test",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(0, exitCode, "rabbitmqctl debe cerrar las conexiones")
+
+	// Publish inmediato: si toma el canal que se está cerrando reintenta una vez sobre el recuperado
+	err = publisher.Publish(ctx, exchangeName, "test.after", []byte(`{"step":"after"}`))
+	s.NoError(err, "Debe publicar aunque el canal se cierre durante la publicación")
+
+	// La reconexión es asíncrona: esperar la señal antes de verificar el estado
+	s.Require().Eventually(func() bool { return publisher.Reconnects() >= 1 }, 15*time.Second, 50*time.Millisecond,
+		"Debe registrar al menos una reconexión")
+	s.True(publisher.IsConnected(), "Debe quedar conectado")
+	s.NoError(publisher.CheckHealth(ctx))
+
+	err = publisher.Publish(ctx, exchangeName, "test.reconnected", []byte(`{"step":"reconnected"}`))
+	s.NoError(err, "Debe publicar después de reconectar")
+
+	// El exchange fue re-declarado: un consumidor nuevo recibe los mensajes
+	conn, err := amqp.Dial(rabbitURL)
+	s.Require().NoError(err)
+	defer func() { _ = conn.Close() }()
+
+	ch, err := conn.Channel()
+	s.Require().NoError(err)
+	defer func() { _ = ch.Close() }()
+
+	queue, err := ch.QueueDeclare("", false, true, true, false, nil)
+	s.Require().NoError(err)
+	s.Require().NoError(ch.QueueBind(queue.Name, "test.#", exchangeName, false, nil))
+
+	msgs, err := ch.Consume(queue.Name, "", true, true, false, false, nil)
+	s.Require().NoError(err)
+
+	s.Require().NoError(publisher.Publish(ctx, exchangeName, "test.consumed", []byte(`{"step":"consumed"}`)))
+
+	select {
+	case msg := <-msgs:
+		s.Equal(`{"step":"consumed"}`, string(msg.Body))
+	case <-time.After(5 * time.Second):
+		s.Fail("Timeout esperando mensaje tras la reconexión")
+	}
+
+	s.Logger.Info("✅ Publisher recuperado tras cierre de conexión del broker")
+}